@@ -7,74 +7,120 @@ import (
 )
 
 type Alumni struct {
-	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
-	NIM        string             `bson:"nim" json:"nim"`
-	Nama       string             `bson:"nama" json:"nama"`
-	Jurusan    string             `bson:"jurusan" json:"jurusan"`
-	Angkatan   int                `bson:"angkatan" json:"angkatan"`
-	TahunLulus int                `bson:"tahun_lulus" json:"tahun_lulus"`
-	Email      string             `bson:"email" json:"email"`
-	RoleID     primitive.ObjectID `bson:"role_id" json:"role_id"`
-	NoTelepon  *string            `bson:"no_telepon,omitempty" json:"no_telepon,omitempty"`
-	Alamat     *string            `bson:"alamat,omitempty" json:"alamat,omitempty"`
-	Password   string             `bson:"password" json:"-"`
-	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt  time.Time          `bson:"updated_at" json:"updated_at"`
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	NIM            string             `bson:"nim" json:"nim"`
+	Nama           string             `bson:"nama" json:"nama"`
+	Jurusan        string             `bson:"jurusan" json:"jurusan"`
+	Angkatan       int                `bson:"angkatan" json:"angkatan"`
+	TahunLulus     int                `bson:"tahun_lulus" json:"tahun_lulus"`
+	Email          string             `bson:"email" json:"email"`
+	RoleID         primitive.ObjectID `bson:"role_id" json:"role_id"`
+	NoTelepon      *string            `bson:"no_telepon,omitempty" json:"no_telepon,omitempty"`
+	Alamat         *string            `bson:"alamat,omitempty" json:"alamat,omitempty"`
+	TanggalLahir   *time.Time         `bson:"tanggal_lahir,omitempty" json:"-"` // hanya untuk verifikasi kelulusan, tidak pernah dikirim
+	Password       string             `bson:"password" json:"-"`
+	CreatedAt      time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt      time.Time          `bson:"updated_at" json:"updated_at"`
+	Version        int                `bson:"version" json:"version"`   // naik setiap update; dikirim sebagai ETag
+	SessionVersion int                `bson:"session_version" json:"-"` // naik setiap password berubah; token JWT versi lama ditolak
+
+	// Proteksi brute-force login
+	FailedLoginAttempts int        `bson:"failed_login_attempts,omitempty" json:"-"`
+	LastFailedLoginAt   *time.Time `bson:"last_failed_login_at,omitempty" json:"-"`
+	LockedUntil         *time.Time `bson:"locked_until,omitempty" json:"-"`
+
+	// Two-factor authentication (TOTP); recovery_codes berisi hash SHA-256
+	TOTPEnabled       bool     `bson:"totp_enabled,omitempty" json:"-"`
+	TOTPSecret        string   `bson:"totp_secret,omitempty" json:"-"`
+	TOTPPendingSecret string   `bson:"totp_pending_secret,omitempty" json:"-"`
+	TOTPLastStep      int64    `bson:"totp_last_step,omitempty" json:"-"`
+	RecoveryCodes     []string `bson:"recovery_codes,omitempty" json:"-"`
+
+	// Identitas SSO (OIDC) yang tertaut; diisi saat login SSO pertama
+	OIDCIssuer  string `bson:"oidc_issuer,omitempty" json:"-"`
+	OIDCSubject string `bson:"oidc_subject,omitempty" json:"-"`
 }
 
 // Service Layer Request DTOs
 type CreateAlumniRequest struct {
-	NIM        string  `json:"nim" validate:"required"`
-	Nama       string  `json:"nama" validate:"required"`
-	Jurusan    string  `json:"jurusan" validate:"required"`
-	Angkatan   int     `json:"angkatan" validate:"required"`
-	TahunLulus int     `json:"tahun_lulus" validate:"required"`
-	Email      string  `json:"email" validate:"required,email"`
-	Password   string  `json:"password" validate:"required"`
-	RoleID     string  `json:"role_id" validate:"required"`
-	NoTelepon  *string `json:"no_telepon,omitempty"`
-	Alamat     *string `json:"alamat,omitempty"`
+	NIM          string  `json:"nim" validate:"required"`
+	Nama         string  `json:"nama" validate:"required"`
+	Jurusan      string  `json:"jurusan" validate:"required"`
+	Angkatan     int     `json:"angkatan" validate:"required"`
+	TahunLulus   int     `json:"tahun_lulus" validate:"required"`
+	Email        string  `json:"email" validate:"required,email"`
+	Password     string  `json:"password" validate:"required"`
+	RoleID       string  `json:"role_id" validate:"required"`
+	NoTelepon    *string `json:"no_telepon,omitempty"`
+	Alamat       *string `json:"alamat,omitempty"`
+	TanggalLahir *string `json:"tanggal_lahir,omitempty"` // YYYY-MM-DD, dipakai verifikasi kelulusan
 }
 
 // Repository Layer Request
 type CreateAlumniRepositoryRequest struct {
-	NIM        string             `bson:"nim"`
-	Nama       string             `bson:"nama"`
-	Jurusan    string             `bson:"jurusan"`
-	Angkatan   int                `bson:"angkatan"`
-	TahunLulus int                `bson:"tahun_lulus"`
-	Email      string             `bson:"email"`
-	Password   string             `bson:"password"`
-	RoleID     primitive.ObjectID `bson:"role_id"`
-	NoTelepon  *string            `bson:"no_telepon,omitempty"`
-	Alamat     *string            `bson:"alamat,omitempty"`
+	NIM          string             `bson:"nim"`
+	Nama         string             `bson:"nama"`
+	Jurusan      string             `bson:"jurusan"`
+	Angkatan     int                `bson:"angkatan"`
+	TahunLulus   int                `bson:"tahun_lulus"`
+	Email        string             `bson:"email"`
+	Password     string             `bson:"password"`
+	RoleID       primitive.ObjectID `bson:"role_id"`
+	NoTelepon    *string            `bson:"no_telepon,omitempty"`
+	Alamat       *string            `bson:"alamat,omitempty"`
+	TanggalLahir *time.Time         `bson:"tanggal_lahir,omitempty"`
 }
 
 type UpdateAlumniRequest struct {
-	NIM        *string `json:"nim,omitempty"`
-	Nama       *string `json:"nama,omitempty"`
-	Jurusan    *string `json:"jurusan,omitempty"`
-	Angkatan   *int    `json:"angkatan,omitempty"`
-	TahunLulus *int    `json:"tahun_lulus,omitempty"`
-	Email      *string `json:"email,omitempty"`
-	Password   *string `json:"password,omitempty"`
-	RoleID     *string `json:"role_id,omitempty"`
-	NoTelepon  *string `json:"no_telepon,omitempty"`
-	Alamat     *string `json:"alamat,omitempty"`
+	NIM          *string `json:"nim,omitempty"`
+	Nama         *string `json:"nama,omitempty"`
+	Jurusan      *string `json:"jurusan,omitempty"`
+	Angkatan     *int    `json:"angkatan,omitempty"`
+	TahunLulus   *int    `json:"tahun_lulus,omitempty"`
+	Email        *string `json:"email,omitempty"`
+	Password     *string `json:"password,omitempty"`
+	RoleID       *string `json:"role_id,omitempty"`
+	NoTelepon    *string `json:"no_telepon,omitempty"`
+	Alamat       *string `json:"alamat,omitempty"`
+	TanggalLahir *string `json:"tanggal_lahir,omitempty"`
 }
 
 // Repository Layer Request
 type UpdateAlumniRepositoryRequest struct {
-	NIM        *string             `bson:"nim,omitempty"`
-	Nama       *string             `bson:"nama,omitempty"`
-	Jurusan    *string             `bson:"jurusan,omitempty"`
-	Angkatan   *int                `bson:"angkatan,omitempty"`
-	TahunLulus *int                `bson:"tahun_lulus,omitempty"`
-	Email      *string             `bson:"email,omitempty"`
-	Password   *string             `bson:"password,omitempty"`
-	RoleID     *primitive.ObjectID `bson:"role_id,omitempty"`
-	NoTelepon  *string             `bson:"no_telepon,omitempty"`
-	Alamat     *string             `bson:"alamat,omitempty"`
+	NIM          *string             `bson:"nim,omitempty"`
+	Nama         *string             `bson:"nama,omitempty"`
+	Jurusan      *string             `bson:"jurusan,omitempty"`
+	Angkatan     *int                `bson:"angkatan,omitempty"`
+	TahunLulus   *int                `bson:"tahun_lulus,omitempty"`
+	Email        *string             `bson:"email,omitempty"`
+	Password     *string             `bson:"password,omitempty"`
+	RoleID       *primitive.ObjectID `bson:"role_id,omitempty"`
+	NoTelepon    *string             `bson:"no_telepon,omitempty"`
+	Alamat       *string             `bson:"alamat,omitempty"`
+	TanggalLahir *time.Time          `bson:"tanggal_lahir,omitempty"`
+	Unset        []string            `bson:"-"` // field opsional yang dihapus (PATCH dengan null)
+}
+
+// AlumniPatchDocument -> bentuk alumni yang bisa diubah lewat PATCH. Field opsional tanpa omitempty
+// agar null / remove bisa menghapusnya; password hanya ditulis, tidak pernah ada di dokumen awal.
+type AlumniPatchDocument struct {
+	NIM        string  `json:"nim"`
+	Nama       string  `json:"nama"`
+	Jurusan    string  `json:"jurusan"`
+	Angkatan   int     `json:"angkatan"`
+	TahunLulus int     `json:"tahun_lulus"`
+	Email      string  `json:"email"`
+	RoleID     string  `json:"role_id"`
+	NoTelepon  *string `json:"no_telepon"`
+	Alamat     *string `json:"alamat"`
+	Password   *string `json:"password,omitempty"`
+}
+
+// AlumniSearchResult -> hasil full-text search alumni beserta skor relevansi
+type AlumniSearchResult struct {
+	Alumni     `bson:",inline"`
+	Score      float64           `bson:"score" json:"score"`
+	Highlights map[string]string `bson:"-" json:"highlights,omitempty"`
 }
 
 // Alumni Employment Status Response
@@ -87,7 +133,11 @@ type AlumniEmploymentStatus struct {
 	NamaPerusahaan    *string            `bson:"nama_perusahaan,omitempty" json:"nama_perusahaan,omitempty"`
 	PosisiJabatan     *string            `bson:"posisi_jabatan,omitempty" json:"posisi_jabatan,omitempty"`
 	TanggalMulaiKerja *time.Time         `bson:"tanggal_mulai_kerja,omitempty" json:"tanggal_mulai_kerja,omitempty"`
-	GajiRange         *string            `bson:"gaji_range,omitempty" json:"gaji_range,omitempty"`
+	GajiMin           *int64             `bson:"gaji_min,omitempty" json:"gaji_min,omitempty"`
+	GajiMax           *int64             `bson:"gaji_max,omitempty" json:"gaji_max,omitempty"`
+	GajiMataUang      *string            `bson:"gaji_mata_uang,omitempty" json:"gaji_mata_uang,omitempty"`
+	GajiPeriode       *string            `bson:"gaji_periode,omitempty" json:"gaji_periode,omitempty"`
+	GajiRange         *string            `bson:"gaji_range,omitempty" json:"gaji_range,omitempty"` // teks gaji lama yang belum dimigrasi
 	LebihDari1Tahun   int                `bson:"lebih_dari_1_tahun" json:"lebih_dari_1_tahun"`
 	EmploymentCount   int                `bson:"employment_count" json:"employment_count"`
 }
@@ -102,8 +152,13 @@ type AlumniEmploymentStatusRequest struct {
 	NamaPerusahaan  *string `json:"nama_perusahaan,omitempty" query:"nama_perusahaan"`
 	PosisiJabatan   *string `json:"posisi_jabatan,omitempty" query:"posisi_jabatan"`
 	LebihDari1Tahun *int    `json:"lebih_dari_1_tahun,omitempty" query:"lebih_dari_1_tahun"`
+	SortBy          string  `json:"sortBy,omitempty" query:"sortBy"`
+	Order           string  `json:"order,omitempty" query:"order"`
 	Page            int     `json:"page" query:"page"`
 	Limit           int     `json:"limit" query:"limit"`
+	// Nominal gaji yang dirahasiakan hanya terlihat oleh admin (IncludeConfidential) dan alumni ViewerID
+	IncludeConfidential bool   `json:"-"`
+	ViewerID            string `json:"-"`
 }
 
 // Response Structs
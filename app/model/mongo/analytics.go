@@ -0,0 +1,120 @@
+package mongo
+
+// EmploymentRateItem -> tingkat keterserapan kerja satu kelompok alumni.
+// employed = punya pekerjaan aktif, ever_employed = pernah tercatat bekerja.
+type EmploymentRateItem struct {
+	Group          string  `bson:"group" json:"group"`
+	TotalAlumni    int     `bson:"total_alumni" json:"total_alumni"`
+	Employed       int     `bson:"employed" json:"employed"`
+	EverEmployed   int     `bson:"ever_employed" json:"ever_employed"`
+	EmploymentRate float64 `bson:"-" json:"employment_rate"`
+}
+
+// TimeToFirstJobItem -> jarak kelulusan ke pekerjaan pertama (bulan) satu kelompok alumni
+type TimeToFirstJobItem struct {
+	Group         string  `bson:"group" json:"group"`
+	AlumniWithJob int     `bson:"alumni_with_job" json:"alumni_with_job"`
+	MedianMonths  float64 `bson:"median_months" json:"median_months"`
+	AverageMonths float64 `bson:"average_months" json:"average_months"`
+}
+
+// DistributionItem -> jumlah pekerjaan dan alumni untuk satu nilai bidang_industri / lokasi_kerja
+type DistributionItem struct {
+	Value      string  `bson:"value" json:"value"`
+	Jobs       int     `bson:"jobs" json:"jobs"`
+	Alumni     int     `bson:"alumni" json:"alumni"`
+	Percentage float64 `bson:"-" json:"percentage"`
+}
+
+// SalaryBucketCount -> hasil mentah histogram gaji dari database; Min -1 berarti gaji tidak diketahui
+// (kosong, gaji_range tidak terbaca, atau mata uang selain IDR)
+type SalaryBucketCount struct {
+	Min   float64 `bson:"_id"`
+	Count int     `bson:"count"`
+}
+
+// SalaryBucket -> satu kelompok histogram gaji (juta rupiah)
+type SalaryBucket struct {
+	Label      string   `json:"label"`
+	Min        float64  `json:"min"`
+	Max        *float64 `json:"max"`
+	Count      int      `json:"count"`
+	Percentage float64  `json:"percentage"`
+}
+
+// SalaryHistogram -> histogram gaji per bulan; nilai gaji diambil dari titik tengah rentang
+type SalaryHistogram struct {
+	Unit     string         `json:"unit"`
+	Total    int            `json:"total"`
+	Unparsed int            `json:"unparsed"`
+	Buckets  []SalaryBucket `json:"buckets"`
+}
+
+// RetentionItem -> porsi pekerjaan yang bertahan lebih dari 1 tahun.
+// eligible_jobs hanya pekerjaan yang sudah selesai atau sudah berjalan minimal 1 tahun.
+type RetentionItem struct {
+	Group         string  `bson:"group" json:"group"`
+	EligibleJobs  int     `bson:"eligible_jobs" json:"eligible_jobs"`
+	RetainedJobs  int     `bson:"retained_jobs" json:"retained_jobs"`
+	RetentionRate float64 `bson:"-" json:"retention_rate"`
+}
+
+// EmploymentRateData -> data wrapper employment rate
+type EmploymentRateData struct {
+	GroupBy string               `json:"group_by"`
+	Items   []EmploymentRateItem `json:"items"`
+}
+
+// TimeToFirstJobData -> data wrapper time-to-first-job
+type TimeToFirstJobData struct {
+	GroupBy string               `json:"group_by"`
+	Items   []TimeToFirstJobItem `json:"items"`
+}
+
+// DistributionData -> data wrapper distribusi pekerjaan
+type DistributionData struct {
+	Field     string             `json:"field"`
+	TotalJobs int                `json:"total_jobs"`
+	Items     []DistributionItem `json:"items"`
+}
+
+// RetentionData -> data wrapper retensi pekerjaan
+type RetentionData struct {
+	GroupBy string          `json:"group_by"`
+	Items   []RetentionItem `json:"items"`
+}
+
+// EmploymentRateResponse -> response GET /analytics/employment-rate
+type EmploymentRateResponse struct {
+	Success bool               `json:"success"`
+	Message string             `json:"message"`
+	Data    EmploymentRateData `json:"data"`
+}
+
+// TimeToFirstJobResponse -> response GET /analytics/time-to-first-job
+type TimeToFirstJobResponse struct {
+	Success bool               `json:"success"`
+	Message string             `json:"message"`
+	Data    TimeToFirstJobData `json:"data"`
+}
+
+// DistributionResponse -> response GET /analytics/distribution
+type DistributionResponse struct {
+	Success bool             `json:"success"`
+	Message string           `json:"message"`
+	Data    DistributionData `json:"data"`
+}
+
+// SalaryHistogramResponse -> response GET /analytics/salary
+type SalaryHistogramResponse struct {
+	Success bool            `json:"success"`
+	Message string          `json:"message"`
+	Data    SalaryHistogram `json:"data"`
+}
+
+// RetentionResponse -> response GET /analytics/retention
+type RetentionResponse struct {
+	Success bool          `json:"success"`
+	Message string        `json:"message"`
+	Data    RetentionData `json:"data"`
+}
@@ -0,0 +1,54 @@
+package mongo
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// APIKey -> kredensial client mesin (partner) di collection api_keys. Hanya hash SHA-256 key
+// yang disimpan; Prefix membantu admin mengenali key tanpa menyimpan key utuh.
+type APIKey struct {
+	ID         primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	Name       string              `bson:"name" json:"name"`
+	Prefix     string              `bson:"prefix" json:"prefix"`
+	KeyHash    string              `bson:"key_hash" json:"-"`
+	Scopes     []string            `bson:"scopes" json:"scopes"`
+	RateLimit  int                 `bson:"rate_limit" json:"rate_limit"` // request per menit; 0 = tanpa batas
+	ExpiresAt  *time.Time          `bson:"expires_at,omitempty" json:"expires_at"`
+	LastUsedAt *time.Time          `bson:"last_used_at,omitempty" json:"last_used_at"`
+	RevokedAt  *time.Time          `bson:"revoked_at,omitempty" json:"revoked_at"`
+	CreatedBy  *primitive.ObjectID `bson:"created_by,omitempty" json:"created_by"`
+	CreatedAt  time.Time           `bson:"created_at" json:"created_at"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" validate:"required"`
+	Scopes    []string   `json:"scopes" validate:"required"`
+	RateLimit int        `json:"rate_limit"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// CreateAPIKeyData -> Key hanya dikembalikan sekali saat dibuat
+type CreateAPIKeyData struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+type CreateAPIKeyResponse struct {
+	Success bool             `json:"success"`
+	Message string           `json:"message"`
+	Data    CreateAPIKeyData `json:"data"`
+}
+
+type ListAPIKeysResponse struct {
+	Success bool     `json:"success"`
+	Message string   `json:"message"`
+	Data    []APIKey `json:"data"`
+}
+
+type APIKeyResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Data    APIKey `json:"data"`
+}
@@ -0,0 +1,41 @@
+package mongo
+
+import (
+	"time"
+
+	"go-fiber/helper"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AuditLog -> satu entri append-only di collection audit_logs: siapa melakukan apa terhadap
+// entity mana, beserta snapshot sebelum/sesudah dan asal request
+type AuditLog struct {
+	ID         primitive.ObjectID            `bson:"_id,omitempty" json:"id"`
+	ActorID    *primitive.ObjectID           `bson:"actor_id,omitempty" json:"actor_id"`
+	ActorRole  string                        `bson:"actor_role,omitempty" json:"actor_role,omitempty"`
+	Action     string                        `bson:"action" json:"action"`
+	EntityType string                        `bson:"entity_type" json:"entity_type"`
+	EntityID   string                        `bson:"entity_id,omitempty" json:"entity_id,omitempty"`
+	Before     map[string]any                `bson:"before,omitempty" json:"before,omitempty"`
+	After      map[string]any                `bson:"after,omitempty" json:"after,omitempty"`
+	Changes    map[string]helper.AuditChange `bson:"changes,omitempty" json:"changes,omitempty"`
+	Metadata   map[string]any                `bson:"metadata,omitempty" json:"metadata,omitempty"`
+	IP         string                        `bson:"ip,omitempty" json:"ip,omitempty"`
+	UserAgent  string                        `bson:"user_agent,omitempty" json:"user_agent,omitempty"`
+	RequestID  string                        `bson:"request_id,omitempty" json:"request_id,omitempty"`
+	CreatedAt  time.Time                     `bson:"created_at" json:"created_at"`
+}
+
+// AuditLogListData -> data wrapper untuk daftar audit log
+type AuditLogListData struct {
+	Items []AuditLog `json:"items"`
+	Meta  MetaInfo   `json:"meta"`
+}
+
+// AuditLogListResponse -> daftar audit log (query admin maupun riwayat satu entity)
+type AuditLogListResponse struct {
+	Success bool             `json:"success"`
+	Message string           `json:"message"`
+	Data    AuditLogListData `json:"data"`
+}
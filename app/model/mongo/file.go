@@ -10,24 +10,67 @@ import (
 type File struct {
 	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
 	AlumniID     primitive.ObjectID `bson:"alumni_id" json:"alumni_id"`
-	Category     string             `bson:"category" json:"category"` // photo | certificate
+	Category     string             `bson:"category" json:"category"` // lihat FileCategory.Name
 	FileName     string             `bson:"file_name" json:"file_name"`
 	OriginalName string             `bson:"original_name" json:"original_name"`
 	FilePath     string             `bson:"file_path" json:"file_path"`
 	FileType     string             `bson:"file_type" json:"file_type"`
 	FileSize     int64              `bson:"file_size" json:"file_size"`
 	UploadedAt   time.Time          `bson:"uploaded_at" json:"uploaded_at"`
+	ExpiresAt    *time.Time         `bson:"expires_at,omitempty" json:"expires_at,omitempty"`
+}
+
+// Kebijakan penyimpanan file per kategori
+const (
+	FilePolicySingle   = "single"   // hanya simpan satu file terbaru
+	FilePolicyMultiple = "multiple" // simpan banyak file sampai MaxCount
+)
+
+// FileCategory mendefinisikan aturan upload untuk satu kategori dokumen
+type FileCategory struct {
+	Name          string   `bson:"name" json:"name"`
+	Label         string   `bson:"label" json:"label"`
+	AllowedTypes  []string `bson:"allowed_types" json:"allowed_types"`
+	MaxSize       int64    `bson:"max_size" json:"max_size"`             // dalam byte
+	MaxCount      int      `bson:"max_count" json:"max_count"`           // 0 = tidak dibatasi
+	Policy        string   `bson:"policy" json:"policy"`                 // single | multiple
+	RetentionDays int      `bson:"retention_days" json:"retention_days"` // 0 = simpan selamanya
+}
+
+// DefaultFileCategories dipakai untuk seeding dan sebagai fallback bila
+// kategori belum ada di collection file_categories
+func DefaultFileCategories() []FileCategory {
+	return []FileCategory{
+		{Name: "photo", Label: "Foto Profil", AllowedTypes: []string{"image/jpeg", "image/png"}, MaxSize: 1 * 1024 * 1024, MaxCount: 1, Policy: FilePolicySingle},
+		{Name: "certificate", Label: "Sertifikat/Ijazah", AllowedTypes: []string{"application/pdf"}, MaxSize: 2 * 1024 * 1024, Policy: FilePolicyMultiple},
+		{Name: "transcript", Label: "Transkrip Nilai", AllowedTypes: []string{"application/pdf"}, MaxSize: 2 * 1024 * 1024, MaxCount: 3, Policy: FilePolicyMultiple},
+		{Name: "cv", Label: "Curriculum Vitae", AllowedTypes: []string{"application/pdf"}, MaxSize: 2 * 1024 * 1024, MaxCount: 1, Policy: FilePolicySingle, RetentionDays: 730},
+		{Name: "id_card", Label: "Kartu Identitas", AllowedTypes: []string{"image/jpeg", "image/png", "application/pdf"}, MaxSize: 1 * 1024 * 1024, MaxCount: 1, Policy: FilePolicySingle, RetentionDays: 365},
+		{Name: "award_letter", Label: "Surat Penghargaan", AllowedTypes: []string{"application/pdf", "image/jpeg", "image/png"}, MaxSize: 2 * 1024 * 1024, MaxCount: 10, Policy: FilePolicyMultiple},
+	}
+}
+
+// UpsertFileCategoryRequest -> payload admin untuk membuat/mengubah kategori
+type UpsertFileCategoryRequest struct {
+	Label         string   `json:"label"`
+	AllowedTypes  []string `json:"allowed_types" validate:"required"`
+	MaxSize       int64    `json:"max_size" validate:"required"`
+	MaxCount      int      `json:"max_count"`
+	Policy        string   `json:"policy" validate:"required,oneof=single multiple"`
+	RetentionDays int      `json:"retention_days"`
 }
 
 // FileResponse is a trimmed response for clients
 type FileResponse struct {
-	ID           string `json:"id"`
-	Category     string `json:"category"`
-	FileName     string `json:"file_name"`
-	OriginalName string `json:"original_name"`
-	FilePath     string `json:"file_path"`
-	FileType     string `json:"file_type"`
-	FileSize     int64  `json:"file_size"`
+	ID           string     `json:"id"`
+	Category     string     `json:"category"`
+	FileName     string     `json:"file_name"`
+	OriginalName string     `json:"original_name"`
+	FilePath     string     `json:"file_path"`
+	FileType     string     `json:"file_type"`
+	FileSize     int64      `json:"file_size"`
+	UploadedAt   time.Time  `json:"uploaded_at"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
 }
 
 // FileUploadResponse merepresentasikan response standar untuk upload file
@@ -36,3 +79,25 @@ type FileUploadResponse struct {
 	Message string       `json:"message"`
 	Data    FileResponse `json:"data"`
 }
+
+// ListFilesResponse -> daftar file milik user dengan cursor pagination
+type ListFilesResponse struct {
+	Success bool           `json:"success"`
+	Message string         `json:"message"`
+	Data    []FileResponse `json:"data"`
+	Meta    MetaInfo       `json:"meta"`
+}
+
+// ListFileCategoriesResponse -> daftar kategori dokumen beserta kebijakannya
+type ListFileCategoriesResponse struct {
+	Success bool           `json:"success"`
+	Message string         `json:"message"`
+	Data    []FileCategory `json:"data"`
+}
+
+// FileCategoryResponse -> response untuk satu kategori dokumen
+type FileCategoryResponse struct {
+	Success bool         `json:"success"`
+	Message string       `json:"message"`
+	Data    FileCategory `json:"data"`
+}
@@ -0,0 +1,59 @@
+package mongo
+
+import (
+	"encoding/json"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Status job background
+const (
+	JobStatusQueued    = "queued"
+	JobStatusRunning   = "running"
+	JobStatusCompleted = "completed"
+	JobStatusDead      = "dead" // gagal setelah max_attempts atau error permanen (dead-letter)
+	JobStatusCancelled = "cancelled"
+)
+
+// Job -> satu unit kerja di antrean persisten (collection jobs)
+type Job struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Type        string             `bson:"type" json:"type"`
+	Payload     json.RawMessage    `bson:"payload" json:"payload" swaggertype:"object"`
+	Status      string             `bson:"status" json:"status"`
+	Attempts    int                `bson:"attempts" json:"attempts"`
+	MaxAttempts int                `bson:"max_attempts" json:"max_attempts"`
+	RunAt       time.Time          `bson:"run_at" json:"run_at"`
+	Schedule    string             `bson:"schedule,omitempty" json:"schedule,omitempty"`
+	UniqueKey   *string            `bson:"unique_key,omitempty" json:"unique_key,omitempty"`
+	LockedBy    string             `bson:"locked_by,omitempty" json:"locked_by,omitempty"`
+	LockedUntil *time.Time         `bson:"locked_until,omitempty" json:"locked_until,omitempty"`
+	LastError   string             `bson:"last_error,omitempty" json:"last_error,omitempty"`
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+	StartedAt   *time.Time         `bson:"started_at,omitempty" json:"started_at,omitempty"`
+	FinishedAt  *time.Time         `bson:"finished_at,omitempty" json:"finished_at,omitempty"`
+	// Result -> progress / hasil yang disimpan handler, mis. jumlah baris import atau export
+	Result json.RawMessage `bson:"result,omitempty" json:"result,omitempty" swaggertype:"object"`
+}
+
+// JobListData -> data wrapper untuk daftar job
+type JobListData struct {
+	Items []Job    `json:"items"`
+	Meta  MetaInfo `json:"meta"`
+}
+
+// JobListResponse -> daftar job untuk admin
+type JobListResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    JobListData `json:"data"`
+}
+
+// JobResponse -> detail satu job
+type JobResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Data    Job    `json:"data"`
+}
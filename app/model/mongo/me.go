@@ -0,0 +1,67 @@
+package mongo
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Status permintaan penghapusan akun
+const (
+	AccountDeletionPending  = "pending"
+	AccountDeletionApproved = "approved" // akun sudah dihapus
+	AccountDeletionRejected = "rejected"
+)
+
+// MePatchDocument -> field profil yang boleh diubah alumni sendiri lewat PATCH /me; field lain ditolak
+type MePatchDocument struct {
+	NoTelepon *string `json:"no_telepon"`
+	Alamat    *string `json:"alamat"`
+}
+
+// MeProfile -> data alumni yang sedang login beserta permintaan penghapusan akun yang masih pending
+type MeProfile struct {
+	Alumni
+	DeletionRequest *AccountDeletionRequest `json:"deletion_request"`
+}
+
+type MeProfileResponse struct {
+	Success bool      `json:"success"`
+	Message string    `json:"message"`
+	Data    MeProfile `json:"data"`
+}
+
+// AccountDeletionRequest -> permintaan alumni untuk menghapus akunnya (DELETE /me), diproses admin.
+// NIM dan nama disalin agar riwayat tetap terbaca setelah akun dihapus.
+type AccountDeletionRequest struct {
+	ID          primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	AlumniID    primitive.ObjectID  `bson:"alumni_id" json:"alumni_id"`
+	NIM         string              `bson:"nim" json:"nim"`
+	Nama        string              `bson:"nama" json:"nama"`
+	Alasan      *string             `bson:"alasan,omitempty" json:"alasan"`
+	Status      string              `bson:"status" json:"status"`
+	Catatan     *string             `bson:"catatan,omitempty" json:"catatan"` // catatan admin saat menyetujui / menolak
+	ProcessedBy *primitive.ObjectID `bson:"processed_by,omitempty" json:"processed_by"`
+	ProcessedAt *time.Time          `bson:"processed_at,omitempty" json:"processed_at"`
+	CreatedAt   time.Time           `bson:"created_at" json:"created_at"`
+}
+
+type CreateAccountDeletionRequest struct {
+	Alasan *string `json:"alasan"`
+}
+
+type ProcessAccountDeletionRequest struct {
+	Catatan *string `json:"catatan"`
+}
+
+type AccountDeletionResponse struct {
+	Success bool                   `json:"success"`
+	Message string                 `json:"message"`
+	Data    AccountDeletionRequest `json:"data"`
+}
+
+type ListAccountDeletionResponse struct {
+	Success bool                     `json:"success"`
+	Message string                   `json:"message"`
+	Data    []AccountDeletionRequest `json:"data"`
+}
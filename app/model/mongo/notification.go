@@ -0,0 +1,43 @@
+package mongo
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Status email di outbox
+const (
+	EmailStatusPending = "pending"
+	EmailStatusSending = "sending"
+	EmailStatusSent    = "sent"
+	EmailStatusFailed  = "failed" // gagal setelah batas percobaan
+)
+
+// EmailOutbox -> email yang sudah dirender dan menunggu dikirim dispatcher (collection email_outbox)
+type EmailOutbox struct {
+	ID            primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	AlumniID      *primitive.ObjectID `bson:"alumni_id,omitempty" json:"alumni_id,omitempty"`
+	Template      string              `bson:"template" json:"template"`
+	Locale        string              `bson:"locale" json:"locale"`
+	To            string              `bson:"to" json:"to"`
+	Subject       string              `bson:"subject" json:"subject"`
+	TextBody      string              `bson:"text_body" json:"text_body"`
+	HTMLBody      string              `bson:"html_body" json:"html_body"`
+	Status        string              `bson:"status" json:"status"`
+	Attempts      int                 `bson:"attempts" json:"attempts"`
+	NextAttemptAt time.Time           `bson:"next_attempt_at" json:"next_attempt_at"`
+	LockedUntil   *time.Time          `bson:"locked_until,omitempty" json:"locked_until,omitempty"`
+	LastError     string              `bson:"last_error,omitempty" json:"last_error,omitempty"`
+	UniqueKey     *string             `bson:"unique_key,omitempty" json:"unique_key,omitempty"`
+	CreatedAt     time.Time           `bson:"created_at" json:"created_at"`
+	SentAt        *time.Time          `bson:"sent_at,omitempty" json:"sent_at,omitempty"`
+}
+
+// TracerReminderTarget -> alumni yang data pekerjaannya terakhir diperbarui sebelum cutoff
+type TracerReminderTarget struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	Nama        string             `bson:"nama"`
+	Email       string             `bson:"email"`
+	LastUpdated time.Time          `bson:"last_updated"`
+}
@@ -7,73 +7,183 @@ import (
 )
 
 type PekerjaanAlumni struct {
-	ID                  primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
-	AlumniID            primitive.ObjectID `bson:"alumni_id" json:"alumni_id"`
-	NamaPerusahaan      string             `bson:"nama_perusahaan" json:"nama_perusahaan"`
-	PosisiJabatan       string             `bson:"posisi_jabatan" json:"posisi_jabatan"`
-	BidangIndustri      string             `bson:"bidang_industri" json:"bidang_industri"`
-	LokasiKerja         string             `bson:"lokasi_kerja" json:"lokasi_kerja"`
-	GajiRange           *string            `bson:"gaji_range,omitempty" json:"gaji_range,omitempty"`
-	TanggalMulaiKerja   time.Time          `bson:"tanggal_mulai_kerja" json:"tanggal_mulai_kerja"`
-	TanggalSelesaiKerja *time.Time         `bson:"tanggal_selesai_kerja,omitempty" json:"tanggal_selesai_kerja,omitempty"`
-	StatusPekerjaan     string             `bson:"status_pekerjaan" json:"status_pekerjaan"`
-	DeskripsiPekerjaan  *string            `bson:"deskripsi_pekerjaan,omitempty" json:"deskripsi_pekerjaan,omitempty"`
-	CreatedAt           time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt           time.Time          `bson:"updated_at" json:"updated_at"`
+	ID                  primitive.ObjectID  `bson:"_id,omitempty" json:"id,omitempty"`
+	AlumniID            primitive.ObjectID  `bson:"alumni_id" json:"alumni_id"`
+	NamaPerusahaan      string              `bson:"nama_perusahaan" json:"nama_perusahaan"`
+	CompanyID           *primitive.ObjectID `bson:"company_id,omitempty" json:"company_id,omitempty"` // data referensi companies; nil bila teks bebas belum dikenal
+	PosisiJabatan       string              `bson:"posisi_jabatan" json:"posisi_jabatan"`
+	BidangIndustri      string              `bson:"bidang_industri" json:"bidang_industri"`
+	IndustryID          *primitive.ObjectID `bson:"industry_id,omitempty" json:"industry_id,omitempty"` // data referensi industries; nil bila teks bebas belum dikenal
+	LokasiKerja         string              `bson:"lokasi_kerja" json:"lokasi_kerja"`
+	Negara              *string             `bson:"negara,omitempty" json:"negara,omitempty"` // kode ISO dua huruf, mis. ID
+	Provinsi            *string             `bson:"provinsi,omitempty" json:"provinsi,omitempty"`
+	Kota                *string             `bson:"kota,omitempty" json:"kota,omitempty"`
+	Latitude            *float64            `bson:"latitude,omitempty" json:"latitude,omitempty"`
+	Longitude           *float64            `bson:"longitude,omitempty" json:"longitude,omitempty"`
+	Koordinat           *GeoPoint           `bson:"koordinat,omitempty" json:"-"` // salinan latitude / longitude untuk index 2dsphere
+	GajiMin             *int64              `bson:"gaji_min,omitempty" json:"gaji_min,omitempty"`
+	GajiMax             *int64              `bson:"gaji_max,omitempty" json:"gaji_max,omitempty"`
+	GajiMataUang        string              `bson:"gaji_mata_uang,omitempty" json:"gaji_mata_uang,omitempty"`
+	GajiPeriode         string              `bson:"gaji_periode,omitempty" json:"gaji_periode,omitempty"` // bulanan / tahunan
+	GajiRahasia         bool                `bson:"gaji_rahasia" json:"gaji_rahasia"`                     // nominal hanya terlihat oleh admin dan alumni pemiliknya
+	GajiRange           *string             `bson:"gaji_range,omitempty" json:"gaji_range,omitempty"`     // teks gaji lama yang belum dimigrasi
+	TanggalMulaiKerja   time.Time           `bson:"tanggal_mulai_kerja" json:"tanggal_mulai_kerja"`
+	TanggalSelesaiKerja *time.Time          `bson:"tanggal_selesai_kerja,omitempty" json:"tanggal_selesai_kerja,omitempty"`
+	StatusPekerjaan     string              `bson:"status_pekerjaan" json:"status_pekerjaan"`
+	ParuhWaktu          bool                `bson:"paruh_waktu" json:"paruh_waktu"` // boleh berjalan bersamaan dengan pekerjaan lain
+	DeskripsiPekerjaan  *string             `bson:"deskripsi_pekerjaan,omitempty" json:"deskripsi_pekerjaan,omitempty"`
+	CreatedAt           time.Time           `bson:"created_at" json:"created_at"`
+	UpdatedAt           time.Time           `bson:"updated_at" json:"updated_at"`
+	Version             int                 `bson:"version" json:"version"` // naik setiap update; dikirim sebagai ETag
+}
+
+// GeoPoint -> titik GeoJSON; Coordinates berurutan [longitude, latitude]
+type GeoPoint struct {
+	Type        string    `bson:"type"`
+	Coordinates []float64 `bson:"coordinates"`
+}
+
+// PekerjaanExportRow -> pekerjaan beserta NIM dan nama alumni untuk export
+type PekerjaanExportRow struct {
+	PekerjaanAlumni `bson:",inline"`
+	NIM             string `bson:"nim"`
+	NamaAlumni      string `bson:"nama_alumni"`
+}
+
+// PekerjaanSearchResult -> hasil full-text search pekerjaan beserta skor relevansi
+type PekerjaanSearchResult struct {
+	PekerjaanAlumni `bson:",inline"`
+	Score           float64           `bson:"score" json:"score"`
+	Highlights      map[string]string `bson:"-" json:"highlights,omitempty"`
 }
 
 // Service Layer Request (tanggal sebagai string)
 type CreatePekerjaanAlumniRequest struct {
-	AlumniID            string  `json:"alumni_id" validate:"required"`
-	NamaPerusahaan      string  `json:"nama_perusahaan" validate:"required"`
-	PosisiJabatan       string  `json:"posisi_jabatan" validate:"required"`
-	BidangIndustri      string  `json:"bidang_industri" validate:"required"`
-	LokasiKerja         string  `json:"lokasi_kerja" validate:"required"`
-	GajiRange           *string `json:"gaji_range,omitempty"`
-	TanggalMulaiKerja   string  `json:"tanggal_mulai_kerja" validate:"required"`
-	TanggalSelesaiKerja *string `json:"tanggal_selesai_kerja,omitempty"`
-	StatusPekerjaan     string  `json:"status_pekerjaan" validate:"required,oneof=aktif selesai resigned"`
-	DeskripsiPekerjaan  *string `json:"deskripsi_pekerjaan,omitempty"`
+	AlumniID            string   `json:"alumni_id" validate:"required"`
+	NamaPerusahaan      string   `json:"nama_perusahaan" validate:"required_without=CompanyID"`
+	CompanyID           *string  `json:"company_id,omitempty"`
+	PosisiJabatan       string   `json:"posisi_jabatan" validate:"required"`
+	BidangIndustri      string   `json:"bidang_industri" validate:"required_without=IndustryID"`
+	IndustryID          *string  `json:"industry_id,omitempty"`
+	LokasiKerja         string   `json:"lokasi_kerja" validate:"required"`
+	Negara              string   `json:"negara,omitempty"` // kosong = ID bila provinsi / kota diisi
+	Provinsi            string   `json:"provinsi,omitempty"`
+	Kota                string   `json:"kota,omitempty"`
+	Latitude            *float64 `json:"latitude,omitempty"` // kosong = titik tengah kota
+	Longitude           *float64 `json:"longitude,omitempty"`
+	GajiRange           *string  `json:"gaji_range,omitempty"` // format lama, mis. "5-10 juta"; dipakai bila gaji_min dan gaji_max kosong
+	GajiMin             *int64   `json:"gaji_min,omitempty"`
+	GajiMax             *int64   `json:"gaji_max,omitempty"`
+	GajiMataUang        string   `json:"gaji_mata_uang,omitempty"`
+	GajiPeriode         string   `json:"gaji_periode,omitempty"`
+	GajiRahasia         bool     `json:"gaji_rahasia"`
+	TanggalMulaiKerja   string   `json:"tanggal_mulai_kerja" validate:"required"`
+	TanggalSelesaiKerja *string  `json:"tanggal_selesai_kerja,omitempty"`
+	StatusPekerjaan     string   `json:"status_pekerjaan" validate:"required,oneof=aktif selesai resigned"`
+	ParuhWaktu          bool     `json:"paruh_waktu"`
+	DeskripsiPekerjaan  *string  `json:"deskripsi_pekerjaan,omitempty"`
 }
 
 // Repository Layer Request (tanggal sebagai time.Time)
 type CreatePekerjaanAlumniRepositoryRequest struct {
-	AlumniID            primitive.ObjectID `bson:"alumni_id"`
-	NamaPerusahaan      string             `bson:"nama_perusahaan"`
-	PosisiJabatan       string             `bson:"posisi_jabatan"`
-	BidangIndustri      string             `bson:"bidang_industri"`
-	LokasiKerja         string             `bson:"lokasi_kerja"`
-	GajiRange           *string            `bson:"gaji_range,omitempty"`
-	TanggalMulaiKerja   time.Time          `bson:"tanggal_mulai_kerja"`
-	TanggalSelesaiKerja *time.Time         `bson:"tanggal_selesai_kerja,omitempty"`
-	StatusPekerjaan     string             `bson:"status_pekerjaan"`
-	DeskripsiPekerjaan  *string            `bson:"deskripsi_pekerjaan,omitempty"`
+	AlumniID            primitive.ObjectID  `bson:"alumni_id"`
+	NamaPerusahaan      string              `bson:"nama_perusahaan"`
+	CompanyID           *primitive.ObjectID `bson:"company_id"`
+	PosisiJabatan       string              `bson:"posisi_jabatan"`
+	BidangIndustri      string              `bson:"bidang_industri"`
+	IndustryID          *primitive.ObjectID `bson:"industry_id"`
+	LokasiKerja         string              `bson:"lokasi_kerja"`
+	Negara              *string             `bson:"negara,omitempty"`
+	Provinsi            *string             `bson:"provinsi,omitempty"`
+	Kota                *string             `bson:"kota,omitempty"`
+	Latitude            *float64            `bson:"latitude,omitempty"`
+	Longitude           *float64            `bson:"longitude,omitempty"`
+	GajiMin             *int64              `bson:"gaji_min,omitempty"`
+	GajiMax             *int64              `bson:"gaji_max,omitempty"`
+	GajiMataUang        string              `bson:"gaji_mata_uang"`
+	GajiPeriode         string              `bson:"gaji_periode"`
+	GajiRahasia         bool                `bson:"gaji_rahasia"`
+	TanggalMulaiKerja   time.Time           `bson:"tanggal_mulai_kerja"`
+	TanggalSelesaiKerja *time.Time          `bson:"tanggal_selesai_kerja,omitempty"`
+	StatusPekerjaan     string              `bson:"status_pekerjaan"`
+	ParuhWaktu          bool                `bson:"paruh_waktu"`
+	DeskripsiPekerjaan  *string             `bson:"deskripsi_pekerjaan,omitempty"`
 }
 
 // Service Layer Request (tanggal sebagai string)
 type UpdatePekerjaanAlumniRequest struct {
-	NamaPerusahaan      string  `json:"nama_perusahaan" validate:"required"`
-	PosisiJabatan       string  `json:"posisi_jabatan" validate:"required"`
-	BidangIndustri      string  `json:"bidang_industri" validate:"required"`
-	LokasiKerja         string  `json:"lokasi_kerja" validate:"required"`
-	GajiRange           *string `json:"gaji_range,omitempty"`
-	TanggalMulaiKerja   string  `json:"tanggal_mulai_kerja" validate:"required"`
-	TanggalSelesaiKerja *string `json:"tanggal_selesai_kerja,omitempty"`
-	StatusPekerjaan     string  `json:"status_pekerjaan" validate:"required,oneof=aktif selesai resigned"`
-	DeskripsiPekerjaan  *string `json:"deskripsi_pekerjaan,omitempty"`
+	NamaPerusahaan      string   `json:"nama_perusahaan" validate:"required_without=CompanyID"`
+	CompanyID           *string  `json:"company_id,omitempty"`
+	PosisiJabatan       string   `json:"posisi_jabatan" validate:"required"`
+	BidangIndustri      string   `json:"bidang_industri" validate:"required_without=IndustryID"`
+	IndustryID          *string  `json:"industry_id,omitempty"`
+	LokasiKerja         string   `json:"lokasi_kerja" validate:"required"`
+	Negara              string   `json:"negara,omitempty"` // kosong = ID bila provinsi / kota diisi
+	Provinsi            string   `json:"provinsi,omitempty"`
+	Kota                string   `json:"kota,omitempty"`
+	Latitude            *float64 `json:"latitude,omitempty"` // kosong = titik tengah kota
+	Longitude           *float64 `json:"longitude,omitempty"`
+	GajiRange           *string  `json:"gaji_range,omitempty"` // format lama, mis. "5-10 juta"; dipakai bila gaji_min dan gaji_max kosong
+	GajiMin             *int64   `json:"gaji_min,omitempty"`
+	GajiMax             *int64   `json:"gaji_max,omitempty"`
+	GajiMataUang        string   `json:"gaji_mata_uang,omitempty"`
+	GajiPeriode         string   `json:"gaji_periode,omitempty"`
+	GajiRahasia         bool     `json:"gaji_rahasia"`
+	TanggalMulaiKerja   string   `json:"tanggal_mulai_kerja" validate:"required"`
+	TanggalSelesaiKerja *string  `json:"tanggal_selesai_kerja,omitempty"`
+	StatusPekerjaan     string   `json:"status_pekerjaan" validate:"required,oneof=aktif selesai resigned"`
+	ParuhWaktu          bool     `json:"paruh_waktu"`
+	DeskripsiPekerjaan  *string  `json:"deskripsi_pekerjaan,omitempty"`
+}
+
+// PekerjaanPatchDocument -> bentuk pekerjaan yang bisa diubah lewat PATCH (tanggal YYYY-MM-DD).
+// Field opsional tanpa omitempty agar null / remove bisa menghapusnya.
+type PekerjaanPatchDocument struct {
+	NamaPerusahaan      string   `json:"nama_perusahaan"`
+	PosisiJabatan       string   `json:"posisi_jabatan"`
+	BidangIndustri      string   `json:"bidang_industri"`
+	LokasiKerja         string   `json:"lokasi_kerja"`
+	Negara              *string  `json:"negara"`
+	Provinsi            *string  `json:"provinsi"`
+	Kota                *string  `json:"kota"`
+	Latitude            *float64 `json:"latitude"`
+	Longitude           *float64 `json:"longitude"`
+	GajiRange           *string  `json:"gaji_range"`
+	GajiMin             *int64   `json:"gaji_min"`
+	GajiMax             *int64   `json:"gaji_max"`
+	GajiMataUang        string   `json:"gaji_mata_uang"`
+	GajiPeriode         string   `json:"gaji_periode"`
+	GajiRahasia         bool     `json:"gaji_rahasia"`
+	TanggalMulaiKerja   string   `json:"tanggal_mulai_kerja"`
+	TanggalSelesaiKerja *string  `json:"tanggal_selesai_kerja"`
+	StatusPekerjaan     string   `json:"status_pekerjaan"`
+	ParuhWaktu          bool     `json:"paruh_waktu"`
+	DeskripsiPekerjaan  *string  `json:"deskripsi_pekerjaan"`
 }
 
 // Repository Layer Request (tanggal sebagai time.Time)
 type UpdatePekerjaanAlumniRepositoryRequest struct {
-	NamaPerusahaan      string     `bson:"nama_perusahaan"`
-	PosisiJabatan       string     `bson:"posisi_jabatan"`
-	BidangIndustri      string     `bson:"bidang_industri"`
-	LokasiKerja         string     `bson:"lokasi_kerja"`
-	GajiRange           *string    `bson:"gaji_range,omitempty"`
-	TanggalMulaiKerja   time.Time  `bson:"tanggal_mulai_kerja"`
-	TanggalSelesaiKerja *time.Time `bson:"tanggal_selesai_kerja,omitempty"`
-	StatusPekerjaan     string     `bson:"status_pekerjaan"`
-	DeskripsiPekerjaan  *string    `bson:"deskripsi_pekerjaan,omitempty"`
+	NamaPerusahaan      string              `bson:"nama_perusahaan"`
+	CompanyID           *primitive.ObjectID `bson:"company_id"`
+	PosisiJabatan       string              `bson:"posisi_jabatan"`
+	BidangIndustri      string              `bson:"bidang_industri"`
+	IndustryID          *primitive.ObjectID `bson:"industry_id"`
+	LokasiKerja         string              `bson:"lokasi_kerja"`
+	Negara              *string             `bson:"negara,omitempty"`
+	Provinsi            *string             `bson:"provinsi,omitempty"`
+	Kota                *string             `bson:"kota,omitempty"`
+	Latitude            *float64            `bson:"latitude,omitempty"`
+	Longitude           *float64            `bson:"longitude,omitempty"`
+	GajiMin             *int64              `bson:"gaji_min,omitempty"`
+	GajiMax             *int64              `bson:"gaji_max,omitempty"`
+	GajiMataUang        string              `bson:"gaji_mata_uang"`
+	GajiPeriode         string              `bson:"gaji_periode"`
+	GajiRahasia         bool                `bson:"gaji_rahasia"`
+	TanggalMulaiKerja   time.Time           `bson:"tanggal_mulai_kerja"`
+	TanggalSelesaiKerja *time.Time          `bson:"tanggal_selesai_kerja,omitempty"`
+	StatusPekerjaan     string              `bson:"status_pekerjaan"`
+	ParuhWaktu          bool                `bson:"paruh_waktu"`
+	DeskripsiPekerjaan  *string             `bson:"deskripsi_pekerjaan,omitempty"`
 }
 
 // Response Structs
@@ -100,3 +210,41 @@ type UpdatePekerjaanAlumniResponse struct {
 	Message string          `json:"message"`
 	Data    PekerjaanAlumni `json:"data"`
 }
+
+// CareerTimelineEntry -> satu pekerjaan pada timeline karier beserta masa kerjanya
+type CareerTimelineEntry struct {
+	PekerjaanAlumni
+	Ongoing      bool `json:"ongoing"`
+	TenureDays   int  `json:"tenure_days"`
+	TenureMonths int  `json:"tenure_months"`
+}
+
+// CareerGap -> masa tanpa pekerjaan di antara dua pekerjaan
+type CareerGap struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+	Days int       `json:"days"`
+}
+
+// CareerOverlap -> dua pekerjaan penuh waktu yang berjalan bersamaan
+type CareerOverlap struct {
+	PekerjaanIDs []primitive.ObjectID `json:"pekerjaan_ids"`
+	Days         int                  `json:"days"`
+}
+
+// CareerTimeline -> riwayat pekerjaan alumni urut dari yang paling lama. Total pengalaman tidak
+// menghitung periode yang tumpang tindih dua kali.
+type CareerTimeline struct {
+	AlumniID              primitive.ObjectID    `json:"alumni_id"`
+	Entries               []CareerTimelineEntry `json:"entries"`
+	Gaps                  []CareerGap           `json:"gaps"`
+	Overlaps              []CareerOverlap       `json:"overlaps"`
+	TotalExperienceDays   int                   `json:"total_experience_days"`
+	TotalExperienceMonths int                   `json:"total_experience_months"`
+}
+
+type CareerTimelineResponse struct {
+	Success bool           `json:"success"`
+	Message string         `json:"message"`
+	Data    CareerTimeline `json:"data"`
+}
@@ -0,0 +1,68 @@
+package mongo
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ReferenceData -> satu perusahaan (collection companies) atau bidang industri (collection
+// industries). Keys berisi nama dan alias yang sudah dinormalisasi untuk mencocokkan teks bebas.
+type ReferenceData struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Nama      string             `bson:"nama" json:"nama"`
+	Aliases   []string           `bson:"aliases" json:"aliases"`
+	Keys      []string           `bson:"keys" json:"-"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// ReferenceDataRequest -> create / update perusahaan atau bidang industri
+type ReferenceDataRequest struct {
+	Nama    string   `json:"nama" validate:"required"`
+	Aliases []string `json:"aliases"`
+}
+
+// ReferenceMergeRequest -> gabungkan data referensi duplikat ke data tujuan (:id)
+type ReferenceMergeRequest struct {
+	SourceIDs []string `json:"source_ids" validate:"required"`
+}
+
+// ReferenceMatch -> hasil autocomplete beserta skor kemiripan dan nama/alias yang cocok
+type ReferenceMatch struct {
+	ReferenceData
+	Score   float64 `json:"score"`
+	Matched string  `json:"matched"`
+}
+
+// ReferenceMergeResult -> data tujuan setelah merge dan jumlah pekerjaan yang dipindahkan
+type ReferenceMergeResult struct {
+	Reference ReferenceData `json:"reference"`
+	Merged    int           `json:"merged"`
+	Relinked  int64         `json:"relinked"`
+}
+
+// Response Structs
+type ReferenceDataResponse struct {
+	Success bool          `json:"success"`
+	Message string        `json:"message"`
+	Data    ReferenceData `json:"data"`
+}
+
+type ListReferenceDataResponse struct {
+	Success bool            `json:"success"`
+	Message string          `json:"message"`
+	Data    []ReferenceData `json:"data"`
+}
+
+type ReferenceAutocompleteResponse struct {
+	Success bool             `json:"success"`
+	Message string           `json:"message"`
+	Data    []ReferenceMatch `json:"data"`
+}
+
+type ReferenceMergeResponse struct {
+	Success bool                 `json:"success"`
+	Message string               `json:"message"`
+	Data    ReferenceMergeResult `json:"data"`
+}
@@ -1,14 +1,17 @@
 package mongo
 
-// MetaInfo -> informasi pagination & filter
+// MetaInfo -> informasi pagination & filter.
+// Pada mode cursor (?after / ?before) page & pages kosong, dan total hanya diisi bila with_total=true.
 type MetaInfo struct {
-	Page   int    `json:"page"`
-	Limit  int    `json:"limit"`
-	Total  int    `json:"total"`
-	Pages  int    `json:"pages"`
-	SortBy string `json:"sortBy"`
-	Order  string `json:"order"`
-	Search string `json:"search"`
+	Page       int    `json:"page,omitempty"`
+	Limit      int    `json:"limit"`
+	Total      *int   `json:"total,omitempty"`
+	Pages      int    `json:"pages,omitempty"`
+	SortBy     string `json:"sortBy,omitempty"`
+	Order      string `json:"order,omitempty"`
+	Search     string `json:"search"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
 
 // AlumniData -> data wrapper untuk GetAll Alumni
@@ -37,6 +40,32 @@ type GetAllPekerjaanResponse struct {
 	Data    PekerjaanData `json:"data"`
 }
 
+// SearchAlumniData -> data wrapper untuk hasil pencarian alumni
+type SearchAlumniData struct {
+	Items []AlumniSearchResult `json:"items"`
+	Meta  MetaInfo             `json:"meta"`
+}
+
+// SearchPekerjaanData -> data wrapper untuk hasil pencarian pekerjaan
+type SearchPekerjaanData struct {
+	Items []PekerjaanSearchResult `json:"items"`
+	Meta  MetaInfo                `json:"meta"`
+}
+
+// SearchAlumniResponse -> hasil full-text search alumni, diurutkan berdasarkan relevansi
+type SearchAlumniResponse struct {
+	Success bool             `json:"success"`
+	Message string           `json:"message"`
+	Data    SearchAlumniData `json:"data"`
+}
+
+// SearchPekerjaanResponse -> hasil full-text search pekerjaan, diurutkan berdasarkan relevansi
+type SearchPekerjaanResponse struct {
+	Success bool                `json:"success"`
+	Message string              `json:"message"`
+	Data    SearchPekerjaanData `json:"data"`
+}
+
 // Legacy responses (deprecated, untuk backward compatibility)
 type AlumniResponse struct {
 	Data []Alumni `json:"data"`
@@ -3,8 +3,9 @@ package mongo
 import "go.mongodb.org/mongo-driver/bson/primitive"
 
 type Role struct {
-	ID   primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
-	Name string             `bson:"name" json:"name"`
+	ID      primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Name    string             `bson:"name" json:"name"`
+	Version int                `bson:"version" json:"version"` // naik setiap update; dikirim sebagai ETag
 }
 
 type CreateRoleRequest struct {
@@ -15,6 +16,11 @@ type UpdateRoleRequest struct {
 	Name *string `json:"name,omitempty"`
 }
 
+// RolePatchDocument -> bentuk role yang bisa diubah lewat PATCH
+type RolePatchDocument struct {
+	Name string `json:"name"`
+}
+
 // Response Structs
 type GetRoleByIDResponse struct {
 	Success bool   `json:"success"`
@@ -3,74 +3,119 @@ package postgre
 import "time"
 
 type Alumni struct {
-	ID         int       `json:"id" db:"id"`
-	NIM        string    `json:"nim" db:"nim"`
-	Nama       string    `json:"nama" db:"nama"`
-	Jurusan    string    `json:"jurusan" db:"jurusan"`
-	Angkatan   int       `json:"angkatan" db:"angkatan"`
-	TahunLulus int       `json:"tahun_lulus" db:"tahun_lulus"`
-	Email      string    `json:"email" db:"email"`
-	RoleID     int       `json:"role_id" db:"role_id"`
-	NoTelepon  *string   `json:"no_telepon" db:"no_telepon"`
-	Alamat     *string   `json:"alamat" db:"alamat"`
-	Password   string    `json:"-" db:"password"`
-	CreatedAt  time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+	ID             int        `json:"id" db:"id"`
+	NIM            string     `json:"nim" db:"nim"`
+	Nama           string     `json:"nama" db:"nama"`
+	Jurusan        string     `json:"jurusan" db:"jurusan"`
+	Angkatan       int        `json:"angkatan" db:"angkatan"`
+	TahunLulus     int        `json:"tahun_lulus" db:"tahun_lulus"`
+	Email          string     `json:"email" db:"email"`
+	RoleID         int        `json:"role_id" db:"role_id"`
+	NoTelepon      *string    `json:"no_telepon" db:"no_telepon"`
+	Alamat         *string    `json:"alamat" db:"alamat"`
+	TanggalLahir   *time.Time `json:"-" db:"tanggal_lahir"` // hanya untuk verifikasi kelulusan, tidak pernah dikirim
+	Password       string     `json:"-" db:"password"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
+	Version        int        `json:"version" db:"version"`   // naik setiap update; dikirim sebagai ETag
+	SessionVersion int        `json:"-" db:"session_version"` // naik setiap password berubah; token JWT versi lama ditolak
+
+	// Proteksi brute-force login
+	FailedLoginAttempts int        `json:"-" db:"failed_login_attempts"`
+	LastFailedLoginAt   *time.Time `json:"-" db:"last_failed_login_at"`
+	LockedUntil         *time.Time `json:"-" db:"locked_until"`
+
+	// Two-factor authentication (TOTP)
+	TOTPEnabled       bool    `json:"-" db:"totp_enabled"`
+	TOTPSecret        *string `json:"-" db:"totp_secret"`
+	TOTPPendingSecret *string `json:"-" db:"totp_pending_secret"`
+	TOTPLastStep      int64   `json:"-" db:"totp_last_step"`
+
+	// Identitas SSO (OIDC) yang tertaut; diisi saat login SSO pertama
+	OIDCIssuer  *string `json:"-" db:"oidc_issuer"`
+	OIDCSubject *string `json:"-" db:"oidc_subject"`
 }
 
 // Service Layer Request DTOs
 type CreateAlumniRequest struct {
-	NIM        string  `json:"nim" validate:"required"`
-	Nama       string  `json:"nama" validate:"required"`
-	Jurusan    string  `json:"jurusan" validate:"required"`
-	Angkatan   int     `json:"angkatan" validate:"required"`
-	TahunLulus int     `json:"tahun_lulus" validate:"required"`
-	Email      string  `json:"email" validate:"required,email"`
-	Password   string  `json:"password" validate:"required"`
-	RoleID     int     `json:"role_id" validate:"required"`
-	NoTelepon  *string `json:"no_telepon"`
-	Alamat     *string `json:"alamat"`
+	NIM          string  `json:"nim" validate:"required"`
+	Nama         string  `json:"nama" validate:"required"`
+	Jurusan      string  `json:"jurusan" validate:"required"`
+	Angkatan     int     `json:"angkatan" validate:"required"`
+	TahunLulus   int     `json:"tahun_lulus" validate:"required"`
+	Email        string  `json:"email" validate:"required,email"`
+	Password     string  `json:"password" validate:"required"`
+	RoleID       int     `json:"role_id" validate:"required"`
+	NoTelepon    *string `json:"no_telepon"`
+	Alamat       *string `json:"alamat"`
+	TanggalLahir *string `json:"tanggal_lahir"` // YYYY-MM-DD, dipakai verifikasi kelulusan
 }
 
 // Repository Layer Request
 type CreateAlumniRepositoryRequest struct {
+	NIM          string     `json:"nim"`
+	Nama         string     `json:"nama"`
+	Jurusan      string     `json:"jurusan"`
+	Angkatan     int        `json:"angkatan"`
+	TahunLulus   int        `json:"tahun_lulus"`
+	Email        string     `json:"email"`
+	Password     string     `json:"password"`
+	RoleID       int        `json:"role_id"`
+	NoTelepon    *string    `json:"no_telepon"`
+	Alamat       *string    `json:"alamat"`
+	TanggalLahir *time.Time `json:"tanggal_lahir"`
+}
+
+type UpdateAlumniRequest struct {
+	NIM          *string `json:"nim"`
+	Nama         *string `json:"nama"`
+	Jurusan      *string `json:"jurusan"`
+	Angkatan     *int    `json:"angkatan"`
+	TahunLulus   *int    `json:"tahun_lulus"`
+	Email        *string `json:"email"`
+	Password     *string `json:"password"`
+	RoleID       *int    `json:"role_id"`
+	NoTelepon    *string `json:"no_telepon"`
+	Alamat       *string `json:"alamat"`
+	TanggalLahir *string `json:"tanggal_lahir"`
+}
+
+// Repository Layer Request
+type UpdateAlumniRepositoryRequest struct {
+	NIM          *string    `json:"nim"`
+	Nama         *string    `json:"nama"`
+	Jurusan      *string    `json:"jurusan"`
+	Angkatan     *int       `json:"angkatan"`
+	TahunLulus   *int       `json:"tahun_lulus"`
+	Email        *string    `json:"email"`
+	Password     *string    `json:"password"`
+	RoleID       *int       `json:"role_id"`
+	NoTelepon    *string    `json:"no_telepon"`
+	Alamat       *string    `json:"alamat"`
+	TanggalLahir *time.Time `json:"tanggal_lahir"`
+	Unset        []string   `json:"-"` // kolom opsional yang di-set NULL (PATCH dengan null)
+}
+
+// AlumniPatchDocument -> bentuk alumni yang bisa diubah lewat PATCH. Field opsional tanpa omitempty
+// agar null / remove bisa menghapusnya; password hanya ditulis, tidak pernah ada di dokumen awal.
+type AlumniPatchDocument struct {
 	NIM        string  `json:"nim"`
 	Nama       string  `json:"nama"`
 	Jurusan    string  `json:"jurusan"`
 	Angkatan   int     `json:"angkatan"`
 	TahunLulus int     `json:"tahun_lulus"`
 	Email      string  `json:"email"`
-	Password   string  `json:"password"`
 	RoleID     int     `json:"role_id"`
 	NoTelepon  *string `json:"no_telepon"`
 	Alamat     *string `json:"alamat"`
+	Password   *string `json:"password,omitempty"`
 }
 
-type UpdateAlumniRequest struct {
-	NIM        *string `json:"nim"`
-	Nama       *string `json:"nama"`
-	Jurusan    *string `json:"jurusan"`
-	Angkatan   *int    `json:"angkatan"`
-	TahunLulus *int    `json:"tahun_lulus"`
-	Email      *string `json:"email"`
-	Password   *string `json:"password"`
-	RoleID     *int    `json:"role_id"`
-	NoTelepon  *string `json:"no_telepon"`
-	Alamat     *string `json:"alamat"`
-}
-
-// Repository Layer Request
-type UpdateAlumniRepositoryRequest struct {
-	NIM        *string `json:"nim"`
-	Nama       *string `json:"nama"`
-	Jurusan    *string `json:"jurusan"`
-	Angkatan   *int    `json:"angkatan"`
-	TahunLulus *int    `json:"tahun_lulus"`
-	Email      *string `json:"email"`
-	Password   *string `json:"password"`
-	RoleID     *int    `json:"role_id"`
-	NoTelepon  *string `json:"no_telepon"`
-	Alamat     *string `json:"alamat"`
+// AlumniSearchResult -> hasil full-text search alumni beserta skor relevansi
+type AlumniSearchResult struct {
+	Alumni
+	Score      float64           `json:"score"`
+	Highlights map[string]string `json:"highlights,omitempty"`
 }
 
 // Alumni Employment Status Response
@@ -83,7 +128,11 @@ type AlumniEmploymentStatus struct {
 	NamaPerusahaan    *string    `json:"nama_perusahaan" db:"nama_perusahaan"`
 	PosisiJabatan     *string    `json:"posisi_jabatan" db:"posisi_jabatan"`
 	TanggalMulaiKerja *time.Time `json:"tanggal_mulai_kerja" db:"tanggal_mulai_kerja"`
-	GajiRange         *string    `json:"gaji_range" db:"gaji_range"`
+	GajiMin           *int64     `json:"gaji_min" db:"gaji_min"`
+	GajiMax           *int64     `json:"gaji_max" db:"gaji_max"`
+	GajiMataUang      *string    `json:"gaji_mata_uang" db:"gaji_mata_uang"`
+	GajiPeriode       *string    `json:"gaji_periode" db:"gaji_periode"`
+	GajiRange         *string    `json:"gaji_range" db:"gaji_range"` // teks gaji lama yang belum dimigrasi
 	LebihDari1Tahun   int        `json:"lebih_dari_1_tahun" db:"lebih_dari_1_tahun"`
 	EmploymentCount   int        `json:"employment_count" db:"employment_count"`
 }
@@ -98,8 +147,13 @@ type AlumniEmploymentStatusRequest struct {
 	NamaPerusahaan  *string `json:"nama_perusahaan" query:"nama_perusahaan"`
 	PosisiJabatan   *string `json:"posisi_jabatan" query:"posisi_jabatan"`
 	LebihDari1Tahun *int    `json:"lebih_dari_1_tahun" query:"lebih_dari_1_tahun"`
+	SortBy          string  `json:"sortBy" query:"sortBy"`
+	Order           string  `json:"order" query:"order"`
 	Page            int     `json:"page" query:"page"`
 	Limit           int     `json:"limit" query:"limit"`
+	// Nominal gaji yang dirahasiakan hanya terlihat oleh admin (IncludeConfidential) dan alumni ViewerID
+	IncludeConfidential bool `json:"-"`
+	ViewerID            int  `json:"-"`
 }
 
 // Response Structs
@@ -0,0 +1,50 @@
+package postgre
+
+import "time"
+
+// APIKey -> kredensial client mesin (partner) di tabel api_keys. Hanya hash SHA-256 key
+// yang disimpan; Prefix membantu admin mengenali key tanpa menyimpan key utuh.
+type APIKey struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	KeyHash    string     `json:"-"`
+	Scopes     []string   `json:"scopes"`
+	RateLimit  int        `json:"rate_limit"` // request per menit; 0 = tanpa batas
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedBy  *int       `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" validate:"required"`
+	Scopes    []string   `json:"scopes" validate:"required"`
+	RateLimit int        `json:"rate_limit"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// CreateAPIKeyData -> Key hanya dikembalikan sekali saat dibuat
+type CreateAPIKeyData struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+type CreateAPIKeyResponse struct {
+	Success bool             `json:"success"`
+	Message string           `json:"message"`
+	Data    CreateAPIKeyData `json:"data"`
+}
+
+type ListAPIKeysResponse struct {
+	Success bool     `json:"success"`
+	Message string   `json:"message"`
+	Data    []APIKey `json:"data"`
+}
+
+type APIKeyResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Data    APIKey `json:"data"`
+}
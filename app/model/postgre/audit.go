@@ -0,0 +1,39 @@
+package postgre
+
+import (
+	"time"
+
+	"go-fiber/helper"
+)
+
+// AuditLog -> satu entri append-only di tabel audit_logs: siapa melakukan apa terhadap
+// entity mana, beserta snapshot sebelum/sesudah dan asal request
+type AuditLog struct {
+	ID         int64                         `json:"id"`
+	ActorID    *int                          `json:"actor_id"`
+	ActorRole  string                        `json:"actor_role,omitempty"`
+	Action     string                        `json:"action"`
+	EntityType string                        `json:"entity_type"`
+	EntityID   string                        `json:"entity_id,omitempty"`
+	Before     map[string]any                `json:"before,omitempty"`
+	After      map[string]any                `json:"after,omitempty"`
+	Changes    map[string]helper.AuditChange `json:"changes,omitempty"`
+	Metadata   map[string]any                `json:"metadata,omitempty"`
+	IP         string                        `json:"ip,omitempty"`
+	UserAgent  string                        `json:"user_agent,omitempty"`
+	RequestID  string                        `json:"request_id,omitempty"`
+	CreatedAt  time.Time                     `json:"created_at"`
+}
+
+// AuditLogListData -> data wrapper untuk daftar audit log
+type AuditLogListData struct {
+	Items []AuditLog `json:"items"`
+	Meta  MetaInfo   `json:"meta"`
+}
+
+// AuditLogListResponse -> daftar audit log (query admin maupun riwayat satu entity)
+type AuditLogListResponse struct {
+	Success bool             `json:"success"`
+	Message string           `json:"message"`
+	Data    AuditLogListData `json:"data"`
+}
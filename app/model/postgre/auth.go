@@ -7,11 +7,12 @@ import (
 )
 
 type User struct {
-	ID        int       `json:"id" db:"id"`
-	Username  string    `json:"username" db:"username"`
-	Email     string    `json:"email" db:"email"`
-	Role      string    `json:"role" db:"role"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	ID             int       `json:"id" db:"id"`
+	Username       string    `json:"username" db:"username"`
+	Email          string    `json:"email" db:"email"`
+	Role           string    `json:"role" db:"role"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	SessionVersion int       `json:"-" db:"-"` // disalin ke klaim JWT "sv"
 }
 
 type LoginRequest struct {
@@ -21,7 +22,12 @@ type LoginRequest struct {
 
 type LoginData struct {
 	User  User   `json:"user"`
-	Token string `json:"token"`
+	Token string `json:"token,omitempty"`
+	// Diisi bila akun wajib 2FA: token sementara untuk POST /login/mfa (atau enrollment
+	// bila MFAEnrollmentRequired); Token kosong sampai kode diverifikasi
+	MFARequired           bool   `json:"mfa_required,omitempty"`
+	MFAEnrollmentRequired bool   `json:"mfa_enrollment_required,omitempty"`
+	MFAToken              string `json:"mfa_token,omitempty"`
 }
 
 type LoginResponse struct {
@@ -43,8 +49,90 @@ type GetProfileResponse struct {
 }
 
 type JWTClaims struct {
-	UserID   int    `json:"user_id"`
-	Username string `json:"username"`
-	Role     string `json:"role"`
+	UserID         int    `json:"user_id"`
+	Username       string `json:"username"`
+	Role           string `json:"role"`
+	SessionVersion int    `json:"sv"`
+	MFAPending     bool   `json:"mfa_pending,omitempty"` // token login tahap pertama, hanya untuk verifikasi/enrollment 2FA
 	jwt.RegisteredClaims
 }
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+type PasswordResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// ChangePasswordData -> token baru; token lama (semua sesi) tidak berlaku lagi
+type ChangePasswordData struct {
+	Token string `json:"token"`
+}
+
+type ChangePasswordResponse struct {
+	Success bool               `json:"success"`
+	Message string             `json:"message"`
+	Data    ChangePasswordData `json:"data"`
+}
+
+// UnlockAlumniResponse -> hasil admin membuka kunci akun yang terkunci karena gagal login
+type UnlockAlumniResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// Two-factor authentication (TOTP)
+type MFALoginRequest struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+	Code     string `json:"code" validate:"required"` // kode 6 digit atau kode pemulihan
+}
+
+type MFACodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type MFADisableRequest struct {
+	Password string `json:"password" validate:"required"`
+	Code     string `json:"code" validate:"required"`
+}
+
+type MFAEnrollData struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+type MFAEnrollResponse struct {
+	Success bool          `json:"success"`
+	Message string        `json:"message"`
+	Data    MFAEnrollData `json:"data"`
+}
+
+// MFARecoveryCodesData -> kode pemulihan hanya ditampilkan sekali. Token diisi bila
+// aktivasi dilakukan dengan token mfa_pending (enrollment wajib saat login admin).
+type MFARecoveryCodesData struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+	Token         string   `json:"token,omitempty"`
+}
+
+type MFARecoveryCodesResponse struct {
+	Success bool                 `json:"success"`
+	Message string               `json:"message"`
+	Data    MFARecoveryCodesData `json:"data"`
+}
+
+type MFAResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
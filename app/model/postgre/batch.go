@@ -0,0 +1,47 @@
+package postgre
+
+import "encoding/json"
+
+// Mode batch
+const (
+	BatchModeAllOrNothing = "all_or_nothing" // semua operasi dalam satu transaksi
+	BatchModeBestEffort   = "best_effort"    // tiap operasi berdiri sendiri
+)
+
+// BatchRequest -> body POST /alumni/batch dan /pekerjaan/batch
+type BatchRequest struct {
+	Mode       string           `json:"mode"` // all_or_nothing (default) atau best_effort
+	Operations []BatchOperation `json:"operations"`
+}
+
+// BatchOperation -> satu operasi batch. data berisi body create, atau merge patch (RFC 7396)
+// untuk update; if_match opsional dan berlaku seperti header If-Match.
+type BatchOperation struct {
+	Op      string          `json:"op"` // create, update, delete
+	ID      int             `json:"id,omitempty"`
+	IfMatch string          `json:"if_match,omitempty"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// BatchResult -> hasil satu operasi batch, urut sesuai operations
+type BatchResult struct {
+	Index   int         `json:"index"`
+	Op      string      `json:"op"`
+	ID      int         `json:"id,omitempty"`
+	Status  int         `json:"status"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+type BatchSummary struct {
+	Mode      string        `json:"mode"`
+	Succeeded int           `json:"succeeded"`
+	Failed    int           `json:"failed"`
+	Results   []BatchResult `json:"results"`
+}
+
+type BatchResponse struct {
+	Success bool         `json:"success"`
+	Message string       `json:"message"`
+	Data    BatchSummary `json:"data"`
+}
@@ -0,0 +1,32 @@
+package postgre
+
+import "time"
+
+// Status job export
+const (
+	ExportStatusQueued    = "queued"
+	ExportStatusRunning   = "running"
+	ExportStatusCompleted = "completed"
+	ExportStatusFailed    = "failed"
+)
+
+// ExportJob -> export besar yang berjalan di background; file bisa diunduh lewat download_url setelah completed
+type ExportJob struct {
+	ID          string     `json:"id"`
+	Resource    string     `json:"resource"`
+	Format      string     `json:"format"`
+	Status      string     `json:"status"`
+	Rows        int        `json:"rows"`
+	FileName    string     `json:"file_name"`
+	DownloadURL string     `json:"download_url,omitempty"`
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+}
+
+// ExportJobResponse -> response job export (saat dimulai dan saat polling)
+type ExportJobResponse struct {
+	Success bool      `json:"success"`
+	Message string    `json:"message"`
+	Data    ExportJob `json:"data"`
+}
@@ -0,0 +1,99 @@
+package postgre
+
+import "time"
+
+// Status job import
+const (
+	ImportStatusQueued    = "queued"
+	ImportStatusRunning   = "running"
+	ImportStatusCompleted = "completed"
+	ImportStatusFailed    = "failed"
+)
+
+// ImportRowError -> kesalahan pada satu baris file import (row = nomor baris di file, header = 1)
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// ImportRowResult -> hasil validasi satu baris pada dry-run; action = create, update, atau invalid
+type ImportRowResult struct {
+	Row    int              `json:"row"`
+	Action string           `json:"action"`
+	Key    string           `json:"key"`
+	Errors []ImportRowError `json:"errors,omitempty"`
+}
+
+// ImportReport -> laporan dry-run import per baris
+type ImportReport struct {
+	Resource    string            `json:"resource"`
+	TotalRows   int               `json:"total_rows"`
+	ValidRows   int               `json:"valid_rows"`
+	InvalidRows int               `json:"invalid_rows"`
+	ToCreate    int               `json:"to_create"`
+	ToUpdate    int               `json:"to_update"`
+	Rows        []ImportRowResult `json:"rows"`
+}
+
+// ImportJob -> progress import yang berjalan di job runner; error berisi penyebab bila job berhenti sebelum selesai
+type ImportJob struct {
+	ID         string           `json:"id"`
+	Resource   string           `json:"resource"`
+	Status     string           `json:"status"`
+	TotalRows  int              `json:"total_rows"`
+	Processed  int              `json:"processed"`
+	Created    int              `json:"created"`
+	Updated    int              `json:"updated"`
+	Failed     int              `json:"failed"`
+	Errors     []ImportRowError `json:"errors"`
+	Error      string           `json:"error,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+	FinishedAt *time.Time       `json:"finished_at,omitempty"`
+}
+
+// ImportReportResponse -> response dry-run import
+type ImportReportResponse struct {
+	Success bool         `json:"success"`
+	Message string       `json:"message"`
+	Data    ImportReport `json:"data"`
+}
+
+// ImportJobResponse -> response job import (saat dimulai dan saat polling)
+type ImportJobResponse struct {
+	Success bool      `json:"success"`
+	Message string    `json:"message"`
+	Data    ImportJob `json:"data"`
+}
+
+// AlumniKey -> identitas alumni untuk mencocokkan baris import (upsert by NIM/email)
+type AlumniKey struct {
+	ID    int
+	NIM   string
+	Email string
+}
+
+// AlumniImportRecord -> satu baris import alumni; ID nil berarti alumni baru.
+// Password kosong pada alumni lama berarti password tidak diubah.
+type AlumniImportRecord struct {
+	ID   *int
+	Data CreateAlumniRepositoryRequest
+}
+
+// PekerjaanKey -> identitas pekerjaan untuk upsert: alumni, perusahaan, posisi, dan tanggal mulai,
+// ditambah field yang dibutuhkan pemeriksaan aturan karier
+type PekerjaanKey struct {
+	ID                  int
+	AlumniID            int
+	NamaPerusahaan      string
+	PosisiJabatan       string
+	TanggalMulaiKerja   time.Time
+	TanggalSelesaiKerja *time.Time
+	ParuhWaktu          bool
+}
+
+// PekerjaanImportRecord -> satu baris import pekerjaan; ID nil berarti pekerjaan baru
+type PekerjaanImportRecord struct {
+	ID   *int
+	Data CreatePekerjaanAlumniRepositoryRequest
+}
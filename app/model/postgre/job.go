@@ -0,0 +1,57 @@
+package postgre
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Status job background
+const (
+	JobStatusQueued    = "queued"
+	JobStatusRunning   = "running"
+	JobStatusCompleted = "completed"
+	JobStatusDead      = "dead" // gagal setelah max_attempts atau error permanen (dead-letter)
+	JobStatusCancelled = "cancelled"
+)
+
+// Job -> satu unit kerja di antrean persisten (tabel jobs)
+type Job struct {
+	ID          int64           `json:"id"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload" swaggertype:"object"`
+	Status      string          `json:"status"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"max_attempts"`
+	RunAt       time.Time       `json:"run_at"`
+	Schedule    *string         `json:"schedule,omitempty"`
+	UniqueKey   *string         `json:"unique_key,omitempty"`
+	LockedBy    *string         `json:"locked_by,omitempty"`
+	LockedUntil *time.Time      `json:"locked_until,omitempty"`
+	LastError   *string         `json:"last_error,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	StartedAt   *time.Time      `json:"started_at,omitempty"`
+	FinishedAt  *time.Time      `json:"finished_at,omitempty"`
+	// Result -> progress / hasil yang disimpan handler, mis. jumlah baris import atau export
+	Result json.RawMessage `json:"result,omitempty" swaggertype:"object"`
+}
+
+// JobListData -> data wrapper untuk daftar job
+type JobListData struct {
+	Items []Job    `json:"items"`
+	Meta  MetaInfo `json:"meta"`
+}
+
+// JobListResponse -> daftar job untuk admin
+type JobListResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    JobListData `json:"data"`
+}
+
+// JobResponse -> detail satu job
+type JobResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Data    Job    `json:"data"`
+}
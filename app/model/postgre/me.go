@@ -0,0 +1,63 @@
+package postgre
+
+import "time"
+
+// Status permintaan penghapusan akun
+const (
+	AccountDeletionPending  = "pending"
+	AccountDeletionApproved = "approved" // akun sudah dihapus
+	AccountDeletionRejected = "rejected"
+)
+
+// MePatchDocument -> field profil yang boleh diubah alumni sendiri lewat PATCH /me; field lain ditolak
+type MePatchDocument struct {
+	NoTelepon *string `json:"no_telepon"`
+	Alamat    *string `json:"alamat"`
+}
+
+// MeProfile -> data alumni yang sedang login beserta permintaan penghapusan akun yang masih pending
+type MeProfile struct {
+	Alumni
+	DeletionRequest *AccountDeletionRequest `json:"deletion_request"`
+}
+
+type MeProfileResponse struct {
+	Success bool      `json:"success"`
+	Message string    `json:"message"`
+	Data    MeProfile `json:"data"`
+}
+
+// AccountDeletionRequest -> permintaan alumni untuk menghapus akunnya (DELETE /me), diproses admin.
+// NIM dan nama disalin agar riwayat tetap terbaca setelah akun dihapus (alumni_id menjadi NULL).
+type AccountDeletionRequest struct {
+	ID          int        `json:"id"`
+	AlumniID    *int       `json:"alumni_id"`
+	NIM         string     `json:"nim"`
+	Nama        string     `json:"nama"`
+	Alasan      *string    `json:"alasan"`
+	Status      string     `json:"status"`
+	Catatan     *string    `json:"catatan"` // catatan admin saat menyetujui / menolak
+	ProcessedBy *int       `json:"processed_by"`
+	ProcessedAt *time.Time `json:"processed_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type CreateAccountDeletionRequest struct {
+	Alasan *string `json:"alasan"`
+}
+
+type ProcessAccountDeletionRequest struct {
+	Catatan *string `json:"catatan"`
+}
+
+type AccountDeletionResponse struct {
+	Success bool                   `json:"success"`
+	Message string                 `json:"message"`
+	Data    AccountDeletionRequest `json:"data"`
+}
+
+type ListAccountDeletionResponse struct {
+	Success bool                     `json:"success"`
+	Message string                   `json:"message"`
+	Data    []AccountDeletionRequest `json:"data"`
+}
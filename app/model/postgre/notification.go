@@ -0,0 +1,38 @@
+package postgre
+
+import "time"
+
+// Status email di outbox
+const (
+	EmailStatusPending = "pending"
+	EmailStatusSending = "sending"
+	EmailStatusSent    = "sent"
+	EmailStatusFailed  = "failed" // gagal setelah batas percobaan
+)
+
+// EmailOutbox -> email yang sudah dirender dan menunggu dikirim dispatcher (tabel email_outbox)
+type EmailOutbox struct {
+	ID            int64      `json:"id"`
+	AlumniID      *int       `json:"alumni_id,omitempty"`
+	Template      string     `json:"template"`
+	Locale        string     `json:"locale"`
+	To            string     `json:"to"`
+	Subject       string     `json:"subject"`
+	TextBody      string     `json:"text_body"`
+	HTMLBody      string     `json:"html_body"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	LastError     *string    `json:"last_error,omitempty"`
+	UniqueKey     *string    `json:"unique_key,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+}
+
+// TracerReminderTarget -> alumni yang data pekerjaannya terakhir diperbarui sebelum cutoff
+type TracerReminderTarget struct {
+	ID          int
+	Nama        string
+	Email       string
+	LastUpdated time.Time
+}
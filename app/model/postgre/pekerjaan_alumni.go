@@ -6,70 +6,173 @@ type PekerjaanAlumni struct {
 	ID                  int        `json:"id"`
 	AlumniID            int        `json:"alumni_id"`
 	NamaPerusahaan      string     `json:"nama_perusahaan"`
+	CompanyID           *int       `json:"company_id"` // data referensi companies; nil bila teks bebas belum dikenal
 	PosisiJabatan       string     `json:"posisi_jabatan"`
 	BidangIndustri      string     `json:"bidang_industri"`
+	IndustryID          *int       `json:"industry_id"` // data referensi industries; nil bila teks bebas belum dikenal
 	LokasiKerja         string     `json:"lokasi_kerja"`
-	GajiRange           *string    `json:"gaji_range"`
+	Negara              *string    `json:"negara"` // kode ISO dua huruf, mis. ID
+	Provinsi            *string    `json:"provinsi"`
+	Kota                *string    `json:"kota"`
+	Latitude            *float64   `json:"latitude"`
+	Longitude           *float64   `json:"longitude"`
+	GajiMin             *int64     `json:"gaji_min"`
+	GajiMax             *int64     `json:"gaji_max"`
+	GajiMataUang        string     `json:"gaji_mata_uang"`
+	GajiPeriode         string     `json:"gaji_periode"` // bulanan / tahunan
+	GajiRahasia         bool       `json:"gaji_rahasia"` // nominal hanya terlihat oleh admin dan alumni pemiliknya
+	GajiRange           *string    `json:"gaji_range"`   // teks gaji lama yang belum dimigrasi
 	TanggalMulaiKerja   time.Time  `json:"tanggal_mulai_kerja"`
 	TanggalSelesaiKerja *time.Time `json:"tanggal_selesai_kerja"`
 	StatusPekerjaan     string     `json:"status_pekerjaan"`
+	ParuhWaktu          bool       `json:"paruh_waktu"` // boleh berjalan bersamaan dengan pekerjaan lain
 	DeskripsiPekerjaan  *string    `json:"deskripsi_pekerjaan"`
 	IsDeleted           *time.Time `json:"is_delete"`
 	CreatedAt           time.Time  `json:"created_at"`
 	UpdatedAt           time.Time  `json:"updated_at"`
+	Version             int        `json:"version"` // naik setiap update; dikirim sebagai ETag
+}
+
+// PekerjaanExportRow -> pekerjaan beserta NIM dan nama alumni untuk export
+type PekerjaanExportRow struct {
+	PekerjaanAlumni
+	NIM        string
+	NamaAlumni string
+}
+
+// PekerjaanSearchResult -> hasil full-text search pekerjaan beserta skor relevansi
+type PekerjaanSearchResult struct {
+	PekerjaanAlumni
+	Score      float64           `json:"score"`
+	Highlights map[string]string `json:"highlights,omitempty"`
 }
 
 // Service Layer Request (tanggal sebagai string)
 type CreatePekerjaanAlumniRequest struct {
-	AlumniID            int     `json:"alumni_id" validate:"required"`
-	NamaPerusahaan      string  `json:"nama_perusahaan" validate:"required"`
-	PosisiJabatan       string  `json:"posisi_jabatan" validate:"required"`
-	BidangIndustri      string  `json:"bidang_industri" validate:"required"`
-	LokasiKerja         string  `json:"lokasi_kerja" validate:"required"`
-	GajiRange           *string `json:"gaji_range"`
-	TanggalMulaiKerja   string  `json:"tanggal_mulai_kerja" validate:"required"`
-	TanggalSelesaiKerja *string `json:"tanggal_selesai_kerja"`
-	StatusPekerjaan     string  `json:"status_pekerjaan" validate:"required,oneof=aktif selesai resigned"`
-	DeskripsiPekerjaan  *string `json:"deskripsi_pekerjaan"`
+	AlumniID            int      `json:"alumni_id" validate:"required"`
+	NamaPerusahaan      string   `json:"nama_perusahaan" validate:"required_without=CompanyID"`
+	CompanyID           *int     `json:"company_id,omitempty"`
+	PosisiJabatan       string   `json:"posisi_jabatan" validate:"required"`
+	BidangIndustri      string   `json:"bidang_industri" validate:"required_without=IndustryID"`
+	IndustryID          *int     `json:"industry_id,omitempty"`
+	LokasiKerja         string   `json:"lokasi_kerja" validate:"required"`
+	Negara              string   `json:"negara"` // kosong = ID bila provinsi / kota diisi
+	Provinsi            string   `json:"provinsi"`
+	Kota                string   `json:"kota"`
+	Latitude            *float64 `json:"latitude"` // kosong = titik tengah kota
+	Longitude           *float64 `json:"longitude"`
+	GajiRange           *string  `json:"gaji_range"` // format lama, mis. "5-10 juta"; dipakai bila gaji_min dan gaji_max kosong
+	GajiMin             *int64   `json:"gaji_min"`
+	GajiMax             *int64   `json:"gaji_max"`
+	GajiMataUang        string   `json:"gaji_mata_uang"`
+	GajiPeriode         string   `json:"gaji_periode"`
+	GajiRahasia         bool     `json:"gaji_rahasia"`
+	TanggalMulaiKerja   string   `json:"tanggal_mulai_kerja" validate:"required"`
+	TanggalSelesaiKerja *string  `json:"tanggal_selesai_kerja"`
+	StatusPekerjaan     string   `json:"status_pekerjaan" validate:"required,oneof=aktif selesai resigned"`
+	ParuhWaktu          bool     `json:"paruh_waktu"`
+	DeskripsiPekerjaan  *string  `json:"deskripsi_pekerjaan"`
 }
 
 // Repository Layer Request (tanggal sebagai time.Time)
 type CreatePekerjaanAlumniRepositoryRequest struct {
-	AlumniID            int        `json:"alumni_id"`
-	NamaPerusahaan      string     `json:"nama_perusahaan"`
-	PosisiJabatan       string     `json:"posisi_jabatan"`
-	BidangIndustri      string     `json:"bidang_industri"`
+	AlumniID            int    `json:"alumni_id"`
+	NamaPerusahaan      string `json:"nama_perusahaan"`
+	CompanyID           *int
+	PosisiJabatan       string `json:"posisi_jabatan"`
+	BidangIndustri      string `json:"bidang_industri"`
+	IndustryID          *int
 	LokasiKerja         string     `json:"lokasi_kerja"`
-	GajiRange           *string    `json:"gaji_range"`
+	Negara              *string    `json:"negara"`
+	Provinsi            *string    `json:"provinsi"`
+	Kota                *string    `json:"kota"`
+	Latitude            *float64   `json:"latitude"`
+	Longitude           *float64   `json:"longitude"`
+	GajiMin             *int64     `json:"gaji_min"`
+	GajiMax             *int64     `json:"gaji_max"`
+	GajiMataUang        string     `json:"gaji_mata_uang"`
+	GajiPeriode         string     `json:"gaji_periode"`
+	GajiRahasia         bool       `json:"gaji_rahasia"`
 	TanggalMulaiKerja   time.Time  `json:"tanggal_mulai_kerja"`
 	TanggalSelesaiKerja *time.Time `json:"tanggal_selesai_kerja"`
 	StatusPekerjaan     string     `json:"status_pekerjaan"`
+	ParuhWaktu          bool       `json:"paruh_waktu"`
 	DeskripsiPekerjaan  *string    `json:"deskripsi_pekerjaan"`
 }
 
 // Service Layer Request (tanggal sebagai string)
 type UpdatePekerjaanAlumniRequest struct {
-	NamaPerusahaan      string  `json:"nama_perusahaan" validate:"required"`
-	PosisiJabatan       string  `json:"posisi_jabatan" validate:"required"`
-	BidangIndustri      string  `json:"bidang_industri" validate:"required"`
-	LokasiKerja         string  `json:"lokasi_kerja" validate:"required"`
-	GajiRange           *string `json:"gaji_range"`
-	TanggalMulaiKerja   string  `json:"tanggal_mulai_kerja" validate:"required"`
-	TanggalSelesaiKerja *string `json:"tanggal_selesai_kerja"`
-	StatusPekerjaan     string  `json:"status_pekerjaan" validate:"required,oneof=aktif selesai resigned"`
-	DeskripsiPekerjaan  *string `json:"deskripsi_pekerjaan"`
+	NamaPerusahaan      string   `json:"nama_perusahaan" validate:"required_without=CompanyID"`
+	CompanyID           *int     `json:"company_id,omitempty"`
+	PosisiJabatan       string   `json:"posisi_jabatan" validate:"required"`
+	BidangIndustri      string   `json:"bidang_industri" validate:"required_without=IndustryID"`
+	IndustryID          *int     `json:"industry_id,omitempty"`
+	LokasiKerja         string   `json:"lokasi_kerja" validate:"required"`
+	Negara              string   `json:"negara"` // kosong = ID bila provinsi / kota diisi
+	Provinsi            string   `json:"provinsi"`
+	Kota                string   `json:"kota"`
+	Latitude            *float64 `json:"latitude"` // kosong = titik tengah kota
+	Longitude           *float64 `json:"longitude"`
+	GajiRange           *string  `json:"gaji_range"` // format lama, mis. "5-10 juta"; dipakai bila gaji_min dan gaji_max kosong
+	GajiMin             *int64   `json:"gaji_min"`
+	GajiMax             *int64   `json:"gaji_max"`
+	GajiMataUang        string   `json:"gaji_mata_uang"`
+	GajiPeriode         string   `json:"gaji_periode"`
+	GajiRahasia         bool     `json:"gaji_rahasia"`
+	TanggalMulaiKerja   string   `json:"tanggal_mulai_kerja" validate:"required"`
+	TanggalSelesaiKerja *string  `json:"tanggal_selesai_kerja"`
+	StatusPekerjaan     string   `json:"status_pekerjaan" validate:"required,oneof=aktif selesai resigned"`
+	ParuhWaktu          bool     `json:"paruh_waktu"`
+	DeskripsiPekerjaan  *string  `json:"deskripsi_pekerjaan"`
+}
+
+// PekerjaanPatchDocument -> bentuk pekerjaan yang bisa diubah lewat PATCH (tanggal YYYY-MM-DD).
+// Field opsional tanpa omitempty agar null / remove bisa menghapusnya.
+type PekerjaanPatchDocument struct {
+	NamaPerusahaan      string   `json:"nama_perusahaan"`
+	PosisiJabatan       string   `json:"posisi_jabatan"`
+	BidangIndustri      string   `json:"bidang_industri"`
+	LokasiKerja         string   `json:"lokasi_kerja"`
+	Negara              *string  `json:"negara"`
+	Provinsi            *string  `json:"provinsi"`
+	Kota                *string  `json:"kota"`
+	Latitude            *float64 `json:"latitude"`
+	Longitude           *float64 `json:"longitude"`
+	GajiRange           *string  `json:"gaji_range"`
+	GajiMin             *int64   `json:"gaji_min"`
+	GajiMax             *int64   `json:"gaji_max"`
+	GajiMataUang        string   `json:"gaji_mata_uang"`
+	GajiPeriode         string   `json:"gaji_periode"`
+	GajiRahasia         bool     `json:"gaji_rahasia"`
+	TanggalMulaiKerja   string   `json:"tanggal_mulai_kerja"`
+	TanggalSelesaiKerja *string  `json:"tanggal_selesai_kerja"`
+	StatusPekerjaan     string   `json:"status_pekerjaan"`
+	ParuhWaktu          bool     `json:"paruh_waktu"`
+	DeskripsiPekerjaan  *string  `json:"deskripsi_pekerjaan"`
 }
 
 // Repository Layer Request (tanggal sebagai time.Time)
 type UpdatePekerjaanAlumniRepositoryRequest struct {
-	NamaPerusahaan      string     `json:"nama_perusahaan"`
-	PosisiJabatan       string     `json:"posisi_jabatan"`
-	BidangIndustri      string     `json:"bidang_industri"`
+	NamaPerusahaan      string `json:"nama_perusahaan"`
+	CompanyID           *int
+	PosisiJabatan       string `json:"posisi_jabatan"`
+	BidangIndustri      string `json:"bidang_industri"`
+	IndustryID          *int
 	LokasiKerja         string     `json:"lokasi_kerja"`
-	GajiRange           *string    `json:"gaji_range"`
+	Negara              *string    `json:"negara"`
+	Provinsi            *string    `json:"provinsi"`
+	Kota                *string    `json:"kota"`
+	Latitude            *float64   `json:"latitude"`
+	Longitude           *float64   `json:"longitude"`
+	GajiMin             *int64     `json:"gaji_min"`
+	GajiMax             *int64     `json:"gaji_max"`
+	GajiMataUang        string     `json:"gaji_mata_uang"`
+	GajiPeriode         string     `json:"gaji_periode"`
+	GajiRahasia         bool       `json:"gaji_rahasia"`
 	TanggalMulaiKerja   time.Time  `json:"tanggal_mulai_kerja"`
 	TanggalSelesaiKerja *time.Time `json:"tanggal_selesai_kerja"`
 	StatusPekerjaan     string     `json:"status_pekerjaan"`
+	ParuhWaktu          bool       `json:"paruh_waktu"`
 	DeskripsiPekerjaan  *string    `json:"deskripsi_pekerjaan"`
 }
 
@@ -112,9 +215,48 @@ type GetSoftDeletedPekerjaanAlumniResponse struct {
 	Success bool              `json:"success"`
 	Message string            `json:"message"`
 	Data    []PekerjaanAlumni `json:"data"`
+	Meta    MetaInfo          `json:"meta"`
 }
 
 type RestorePekerjaanAlumniResponse struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
 }
+
+// CareerTimelineEntry -> satu pekerjaan pada timeline karier beserta masa kerjanya
+type CareerTimelineEntry struct {
+	PekerjaanAlumni
+	Ongoing      bool `json:"ongoing"`
+	TenureDays   int  `json:"tenure_days"`
+	TenureMonths int  `json:"tenure_months"`
+}
+
+// CareerGap -> masa tanpa pekerjaan di antara dua pekerjaan
+type CareerGap struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+	Days int       `json:"days"`
+}
+
+// CareerOverlap -> dua pekerjaan penuh waktu yang berjalan bersamaan
+type CareerOverlap struct {
+	PekerjaanIDs []int `json:"pekerjaan_ids"`
+	Days         int   `json:"days"`
+}
+
+// CareerTimeline -> riwayat pekerjaan alumni urut dari yang paling lama. Total pengalaman tidak
+// menghitung periode yang tumpang tindih dua kali.
+type CareerTimeline struct {
+	AlumniID              int                   `json:"alumni_id"`
+	Entries               []CareerTimelineEntry `json:"entries"`
+	Gaps                  []CareerGap           `json:"gaps"`
+	Overlaps              []CareerOverlap       `json:"overlaps"`
+	TotalExperienceDays   int                   `json:"total_experience_days"`
+	TotalExperienceMonths int                   `json:"total_experience_months"`
+}
+
+type CareerTimelineResponse struct {
+	Success bool           `json:"success"`
+	Message string         `json:"message"`
+	Data    CareerTimeline `json:"data"`
+}
@@ -0,0 +1,64 @@
+package postgre
+
+import "time"
+
+// ReferenceData -> satu perusahaan (tabel companies) atau bidang industri (tabel industries).
+// Keys berisi nama dan alias yang sudah dinormalisasi untuk mencocokkan teks bebas.
+type ReferenceData struct {
+	ID        int       `json:"id"`
+	Nama      string    `json:"nama"`
+	Aliases   []string  `json:"aliases"`
+	Keys      []string  `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ReferenceDataRequest -> create / update perusahaan atau bidang industri
+type ReferenceDataRequest struct {
+	Nama    string   `json:"nama" validate:"required"`
+	Aliases []string `json:"aliases"`
+}
+
+// ReferenceMergeRequest -> gabungkan data referensi duplikat ke data tujuan (:id)
+type ReferenceMergeRequest struct {
+	SourceIDs []int `json:"source_ids" validate:"required"`
+}
+
+// ReferenceMatch -> hasil autocomplete beserta skor kemiripan dan nama/alias yang cocok
+type ReferenceMatch struct {
+	ReferenceData
+	Score   float64 `json:"score"`
+	Matched string  `json:"matched"`
+}
+
+// ReferenceMergeResult -> data tujuan setelah merge dan jumlah pekerjaan yang dipindahkan
+type ReferenceMergeResult struct {
+	Reference ReferenceData `json:"reference"`
+	Merged    int           `json:"merged"`
+	Relinked  int64         `json:"relinked"`
+}
+
+// Response Structs
+type ReferenceDataResponse struct {
+	Success bool          `json:"success"`
+	Message string        `json:"message"`
+	Data    ReferenceData `json:"data"`
+}
+
+type ListReferenceDataResponse struct {
+	Success bool            `json:"success"`
+	Message string          `json:"message"`
+	Data    []ReferenceData `json:"data"`
+}
+
+type ReferenceAutocompleteResponse struct {
+	Success bool             `json:"success"`
+	Message string           `json:"message"`
+	Data    []ReferenceMatch `json:"data"`
+}
+
+type ReferenceMergeResponse struct {
+	Success bool                 `json:"success"`
+	Message string               `json:"message"`
+	Data    ReferenceMergeResult `json:"data"`
+}
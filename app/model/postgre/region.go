@@ -0,0 +1,17 @@
+package postgre
+
+import "go-fiber/helper"
+
+// ListRegionResponse -> response GET /wilayah: daftar provinsi tanpa kota / kabupatennya
+type ListRegionResponse struct {
+	Success bool              `json:"success"`
+	Message string            `json:"message"`
+	Data    []helper.Province `json:"data"`
+}
+
+// RegionResponse -> response GET /wilayah/:kode: satu provinsi beserta kota / kabupatennya
+type RegionResponse struct {
+	Success bool            `json:"success"`
+	Message string          `json:"message"`
+	Data    helper.Province `json:"data"`
+}
@@ -1,8 +1,9 @@
 package postgre
 
 type Role struct {
-	ID   int    `json:"id" db:"id"`
-	Name string `json:"name" db:"name"`
+	ID      int    `json:"id" db:"id"`
+	Name    string `json:"name" db:"name"`
+	Version int    `json:"version" db:"version"` // naik setiap update; dikirim sebagai ETag
 }
 
 type CreateRoleRequest struct {
@@ -13,6 +14,11 @@ type UpdateRoleRequest struct {
 	Name *string `json:"name"`
 }
 
+// RolePatchDocument -> bentuk role yang bisa diubah lewat PATCH
+type RolePatchDocument struct {
+	Name string `json:"name"`
+}
+
 // Response Structs
 type GetRoleByIDResponse struct {
 	Success bool   `json:"success"`
@@ -0,0 +1,42 @@
+package postgre
+
+import (
+	"go-fiber/helper"
+	"time"
+)
+
+// VerifyAlumniRequest -> POST /partner/alumni/verify: data yang diketahui pemeriksa (mis. dari CV)
+type VerifyAlumniRequest struct {
+	NIM          string `json:"nim"`
+	Nama         string `json:"nama"`
+	TanggalLahir string `json:"tanggal_lahir"` // YYYY-MM-DD
+}
+
+// AlumniVerification -> hasil verifikasi. Bila tidak cocok hanya verified=false yang dikirim, tanpa
+// alasan, agar NIM tidak bisa ditebak satu per satu.
+type AlumniVerification struct {
+	Verified   bool       `json:"verified"`
+	Status     string     `json:"status,omitempty"` // lulus atau mahasiswa
+	Jurusan    string     `json:"jurusan,omitempty"`
+	Angkatan   int        `json:"angkatan,omitempty"`
+	TahunLulus int        `json:"tahun_lulus,omitempty"`
+	Token      string     `json:"token,omitempty"`      // pernyataan bertanda tangan (JWS EdDSA)
+	VerifyURL  string     `json:"verify_url,omitempty"` // halaman publik GET /verify/:token
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+}
+
+// VerifyAlumniResponse -> response POST /partner/alumni/verify
+type VerifyAlumniResponse struct {
+	Success bool               `json:"success"`
+	Message string             `json:"message"`
+	Data    AlumniVerification `json:"data"`
+}
+
+// VerificationTokenResponse -> response GET /verify/:token. Data tetap dikirim untuk token
+// kedaluwarsa yang tanda tangannya sah.
+type VerificationTokenResponse struct {
+	Success bool                       `json:"success"`
+	Message string                     `json:"message"`
+	Valid   bool                       `json:"valid"`
+	Data    *helper.VerificationClaims `json:"data,omitempty"`
+}
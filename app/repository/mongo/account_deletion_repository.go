@@ -0,0 +1,105 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	model "go-fiber/app/model/mongo"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongoDB "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Account Deletion Request Repository Functions
+
+// CreateAccountDeletion -> simpan permintaan hapus akun berstatus pending. Mengembalikan false tanpa
+// error bila alumni sudah punya permintaan pending (unique index parsial)
+func CreateAccountDeletion(db *mongoDB.Database, req *model.AccountDeletionRequest) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req.Status = model.AccountDeletionPending
+	req.CreatedAt = time.Now()
+	result, err := db.Collection("account_deletion_requests").InsertOne(ctx, req)
+	if err != nil {
+		if mongoDB.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	req.ID = result.InsertedID.(primitive.ObjectID)
+	return true, nil
+}
+
+// GetPendingAccountDeletion -> permintaan hapus akun alumni yang masih pending. nil bila tidak ada
+func GetPendingAccountDeletion(db *mongoDB.Database, alumniID primitive.ObjectID) (*model.AccountDeletionRequest, error) {
+	return findAccountDeletion(db, bson.M{"alumni_id": alumniID, "status": model.AccountDeletionPending})
+}
+
+// GetAccountDeletionByID -> satu permintaan hapus akun. nil bila tidak ditemukan
+func GetAccountDeletionByID(db *mongoDB.Database, id primitive.ObjectID) (*model.AccountDeletionRequest, error) {
+	return findAccountDeletion(db, bson.M{"_id": id})
+}
+
+func findAccountDeletion(db *mongoDB.Database, filter bson.M) (*model.AccountDeletionRequest, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var req model.AccountDeletionRequest
+	err := db.Collection("account_deletion_requests").FindOne(ctx, filter).Decode(&req)
+	if err == mongoDB.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// ListAccountDeletions -> permintaan hapus akun, terbaru lebih dulu; status kosong berarti semua
+func ListAccountDeletions(db *mongoDB.Database, status string) ([]model.AccountDeletionRequest, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+	cursor, err := db.Collection("account_deletion_requests").Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	requests := []model.AccountDeletionRequest{}
+	if err := cursor.All(ctx, &requests); err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// FinishAccountDeletion -> tandai permintaan pending sebagai approved / rejected. nil bila permintaan
+// tidak ada atau sudah diproses
+func FinishAccountDeletion(db *mongoDB.Database, id primitive.ObjectID, status string, processedBy primitive.ObjectID, catatan *string) (*model.AccountDeletionRequest, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	set := bson.M{"status": status, "processed_by": processedBy, "processed_at": time.Now()}
+	if catatan != nil {
+		set["catatan"] = *catatan
+	}
+	filter := bson.M{"_id": id, "status": model.AccountDeletionPending}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var req model.AccountDeletionRequest
+	err := db.Collection("account_deletion_requests").FindOneAndUpdate(ctx, filter, bson.M{"$set": set}, opts).Decode(&req)
+	if err == mongoDB.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
@@ -2,11 +2,12 @@ package mongo
 
 import (
 	"context"
-	"log"
+	"regexp"
 	"strings"
 	"time"
 
 	"go-fiber/app/model/mongo"
+	"go-fiber/helper"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -34,40 +35,21 @@ func GetAllAlumni(db *mongoDB.Database) ([]mongo.Alumni, error) {
 	return alumni, nil
 }
 
-// GetAlumniRepo -> ambil data alumni dari DB dengan pagination, sorting, dan search
-func GetAlumniRepo(db *mongoDB.Database, search, sortBy, order string, limit, offset int) ([]mongo.Alumni, error) {
-	// Debug logging
-	log.Printf("=== GetAlumniRepo Debug ===")
-	log.Printf("Search parameters - search: '%s' (len: %d), sortBy: '%s', order: '%s', limit: %d, offset: %d", search, len(search), sortBy, order, limit, offset)
-	log.Printf("Search is empty: %t", search == "")
-
+// GetAlumniRepo -> ambil data alumni dari DB dengan pagination, sorting, search, dan filter
+func GetAlumniRepo(db *mongoDB.Database, search string, filters []helper.Filter, sortBy, order string, limit, offset int) ([]mongo.Alumni, error) {
 	collection := db.Collection("alumni")
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	// Build filter
-	filter := bson.M{}
-	if search != "" {
-		searchPattern := bson.M{
-			"$or": []bson.M{
-				{"nama": bson.M{"$regex": search, "$options": "i"}},
-				{"email": bson.M{"$regex": search, "$options": "i"}},
-				{"jurusan": bson.M{"$regex": search, "$options": "i"}},
-				{"nim": bson.M{"$regex": search, "$options": "i"}},
-			},
-		}
-		filter = searchPattern
-		log.Printf("Using query with search filter - pattern: '%s'", search)
-	} else {
-		log.Printf("Using query without search filter")
-	}
+	filter := alumniListFilter(search, filters)
 
 	// Build sort
-	sortOrder := 1
+	sortOrder := "asc"
 	if strings.ToLower(order) == "desc" {
-		sortOrder = -1
+		sortOrder = "desc"
 	}
-	sort := bson.M{sortBy: sortOrder}
+	sort := keysetSort(mongoSortField(sortBy), sortOrder, nil)
 
 	// Set options
 	opts := options.Find().
@@ -75,12 +57,8 @@ func GetAlumniRepo(db *mongoDB.Database, search, sortBy, order string, limit, of
 		SetSkip(int64(offset)).
 		SetLimit(int64(limit))
 
-	log.Printf("Final filter: %v", filter)
-	log.Printf("Sort: %v", sort)
-
 	cursor, err := collection.Find(ctx, filter, opts)
 	if err != nil {
-		log.Printf("Query error: %v", err)
 		return nil, err
 	}
 	defer cursor.Close(ctx)
@@ -93,24 +71,113 @@ func GetAlumniRepo(db *mongoDB.Database, search, sortBy, order string, limit, of
 }
 
 // CountAlumniRepo -> hitung total data untuk pagination
-func CountAlumniRepo(db *mongoDB.Database, search string) (int, error) {
+func CountAlumniRepo(db *mongoDB.Database, search string, filters []helper.Filter) (int, error) {
+	collection := db.Collection("alumni")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	count, err := collection.CountDocuments(ctx, alumniListFilter(search, filters))
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// GetAlumniKeysetRepo -> listing alumni dengan keyset pagination pada (sortBy, _id), tanpa $skip.
+// Mengembalikan hasMore = masih ada data ke arah pengambilan.
+func GetAlumniKeysetRepo(db *mongoDB.Database, search string, filters []helper.Filter, sortBy, order string, keyset *helper.Keyset, limit int) ([]mongo.Alumni, bool, error) {
 	collection := db.Collection("alumni")
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	field := mongoSortField(sortBy)
+	filter := andFilter(alumniListFilter(search, filters), keysetCondition(field, order, keyset))
+	opts := options.Find().
+		SetSort(keysetSort(field, order, keyset)).
+		SetLimit(int64(limit + 1))
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, false, err
+	}
+	defer cursor.Close(ctx)
+
+	var alumni []mongo.Alumni
+	if err = cursor.All(ctx, &alumni); err != nil {
+		return nil, false, err
+	}
+	alumni, hasMore := trimKeysetPage(alumni, limit, keyset)
+	return alumni, hasMore, nil
+}
+
+// StreamAlumniRepo -> seluruh alumni yang cocok dengan search dan filter, diurutkan seperti listing,
+// diteruskan satu per satu ke fn (untuk export)
+func StreamAlumniRepo(db *mongoDB.Database, search string, filters []helper.Filter, sortBy, order string, fn func(mongo.Alumni) error) error {
+	collection := db.Collection("alumni")
+	ctx, cancel := context.WithTimeout(context.Background(), streamTimeout)
+	defer cancel()
+
+	opts := options.Find().
+		SetSort(keysetSort(mongoSortField(sortBy), order, nil)).
+		SetBatchSize(streamBatchSize)
+
+	cursor, err := collection.Find(ctx, alumniListFilter(search, filters), opts)
+	if err != nil {
+		return err
+	}
+	return streamCursor(ctx, cursor, fn)
+}
+
+// alumniListFilter -> filter search (regex ter-escape) digabung dengan filter query language
+func alumniListFilter(search string, filters []helper.Filter) bson.M {
 	filter := bson.M{}
 	if search != "" {
+		pattern := regexp.QuoteMeta(search)
 		filter = bson.M{
 			"$or": []bson.M{
-				{"nama": bson.M{"$regex": search, "$options": "i"}},
-				{"email": bson.M{"$regex": search, "$options": "i"}},
-				{"jurusan": bson.M{"$regex": search, "$options": "i"}},
-				{"nim": bson.M{"$regex": search, "$options": "i"}},
+				{"nama": bson.M{"$regex": pattern, "$options": "i"}},
+				{"email": bson.M{"$regex": pattern, "$options": "i"}},
+				{"jurusan": bson.M{"$regex": pattern, "$options": "i"}},
+				{"nim": bson.M{"$regex": pattern, "$options": "i"}},
 			},
 		}
 	}
+	return withFilters(filter, filters)
+}
+
+// SearchAlumniRepo -> full-text search memakai text index alumni, diurutkan berdasarkan textScore
+func SearchAlumniRepo(db *mongoDB.Database, query string, limit, offset int) ([]mongo.AlumniSearchResult, error) {
+	collection := db.Collection("alumni")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	score := bson.M{"$meta": "textScore"}
+	opts := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}, {Key: "_id", Value: 1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	cursor, err := collection.Find(ctx, bson.M{"$text": bson.M{"$search": query}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []mongo.AlumniSearchResult
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
 
-	count, err := collection.CountDocuments(ctx, filter)
+// CountSearchAlumniRepo -> hitung total hasil full-text search alumni
+func CountSearchAlumniRepo(db *mongoDB.Database, query string) (int, error) {
+	collection := db.Collection("alumni")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	count, err := collection.CountDocuments(ctx, bson.M{"$text": bson.M{"$search": query}})
 	if err != nil {
 		return 0, err
 	}
@@ -118,9 +185,14 @@ func CountAlumniRepo(db *mongoDB.Database, search string) (int, error) {
 }
 
 func GetAlumniByID(db *mongoDB.Database, id string) (*mongo.Alumni, error) {
-	collection := db.Collection("alumni")
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
+	return GetAlumniByIDCtx(ctx, db, id)
+}
+
+// GetAlumniByIDCtx -> GetAlumniByID dengan context dari pemanggil (mis. sesi transaksi batch)
+func GetAlumniByIDCtx(ctx context.Context, db *mongoDB.Database, id string) (*mongo.Alumni, error) {
+	collection := db.Collection("alumni")
 
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
@@ -139,6 +211,20 @@ func GetAlumniByID(db *mongoDB.Database, id string) (*mongo.Alumni, error) {
 	return &alumni, nil
 }
 
+// LockAlumniCtx -> tulis counter career_lock di dokumen alumni dalam transaksi ctx. Transaksi lain
+// yang menyentuh alumni yang sama mendapat write conflict dan diulang setelah transaksi ini selesai;
+// mongoDB.ErrNoDocuments bila alumni tidak ada.
+func LockAlumniCtx(ctx context.Context, db *mongoDB.Database, id primitive.ObjectID) error {
+	result, err := db.Collection("alumni").UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$inc": bson.M{"career_lock": 1}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongoDB.ErrNoDocuments
+	}
+	return nil
+}
+
 func GetAlumniByEmail(db *mongoDB.Database, email string) (*mongo.Alumni, error) {
 	collection := db.Collection("alumni")
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -157,24 +243,31 @@ func GetAlumniByEmail(db *mongoDB.Database, email string) (*mongo.Alumni, error)
 }
 
 func CreateAlumni(db *mongoDB.Database, req *mongo.CreateAlumniRepositoryRequest) (*mongo.Alumni, error) {
-	collection := db.Collection("alumni")
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
+	return CreateAlumniCtx(ctx, db, req)
+}
+
+// CreateAlumniCtx -> CreateAlumni dengan context dari pemanggil (mis. sesi transaksi batch)
+func CreateAlumniCtx(ctx context.Context, db *mongoDB.Database, req *mongo.CreateAlumniRepositoryRequest) (*mongo.Alumni, error) {
+	collection := db.Collection("alumni")
 
 	now := time.Now()
 	alumni := &mongo.Alumni{
-		NIM:        req.NIM,
-		Nama:       req.Nama,
-		Jurusan:    req.Jurusan,
-		Angkatan:   req.Angkatan,
-		TahunLulus: req.TahunLulus,
-		Email:      req.Email,
-		RoleID:     req.RoleID,
-		NoTelepon:  req.NoTelepon,
-		Alamat:     req.Alamat,
-		Password:   req.Password,
-		CreatedAt:  now,
-		UpdatedAt:  now,
+		NIM:          req.NIM,
+		Nama:         req.Nama,
+		Jurusan:      req.Jurusan,
+		Angkatan:     req.Angkatan,
+		TahunLulus:   req.TahunLulus,
+		Email:        req.Email,
+		RoleID:       req.RoleID,
+		NoTelepon:    req.NoTelepon,
+		Alamat:       req.Alamat,
+		TanggalLahir: req.TanggalLahir,
+		Password:     req.Password,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		Version:      1,
 	}
 
 	result, err := collection.InsertOne(ctx, alumni)
@@ -186,10 +279,17 @@ func CreateAlumni(db *mongoDB.Database, req *mongo.CreateAlumniRepositoryRequest
 	return alumni, nil
 }
 
-func UpdateAlumni(db *mongoDB.Database, id string, req *mongo.UpdateAlumniRepositoryRequest) (*mongo.Alumni, error) {
-	collection := db.Collection("alumni")
+// UpdateAlumni -> update alumni dan naikkan versinya. expectedVersion (If-Match) dicek di filter
+// update yang sama; helper.ErrVersionConflict bila versinya sudah berubah.
+func UpdateAlumni(db *mongoDB.Database, id string, req *mongo.UpdateAlumniRepositoryRequest, expectedVersion *int) (*mongo.Alumni, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
+	return UpdateAlumniCtx(ctx, db, id, req, expectedVersion)
+}
+
+// UpdateAlumniCtx -> UpdateAlumni dengan context dari pemanggil (mis. sesi transaksi batch)
+func UpdateAlumniCtx(ctx context.Context, db *mongoDB.Database, id string, req *mongo.UpdateAlumniRepositoryRequest, expectedVersion *int) (*mongo.Alumni, error) {
+	collection := db.Collection("alumni")
 
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
@@ -197,7 +297,7 @@ func UpdateAlumni(db *mongoDB.Database, id string, req *mongo.UpdateAlumniReposi
 	}
 
 	// Build update document
-	update := bson.M{"$set": bson.M{"updated_at": time.Now()}}
+	update := bson.M{"$set": bson.M{"updated_at": time.Now()}, "$inc": bson.M{"version": 1}}
 
 	if req.NIM != nil {
 		update["$set"].(bson.M)["nim"] = *req.NIM
@@ -222,6 +322,8 @@ func UpdateAlumni(db *mongoDB.Database, id string, req *mongo.UpdateAlumniReposi
 	}
 	if req.Password != nil {
 		update["$set"].(bson.M)["password"] = *req.Password
+		// Password diganti admin: cabut semua sesi alumni
+		update["$inc"].(bson.M)["session_version"] = 1
 	}
 	if req.NoTelepon != nil {
 		update["$set"].(bson.M)["no_telepon"] = *req.NoTelepon
@@ -229,16 +331,28 @@ func UpdateAlumni(db *mongoDB.Database, id string, req *mongo.UpdateAlumniReposi
 	if req.Alamat != nil {
 		update["$set"].(bson.M)["alamat"] = *req.Alamat
 	}
+	if req.TanggalLahir != nil {
+		update["$set"].(bson.M)["tanggal_lahir"] = *req.TanggalLahir
+	}
+	if len(req.Unset) > 0 {
+		unset := bson.M{}
+		for _, field := range req.Unset {
+			unset[field] = ""
+		}
+		update["$unset"] = unset
+	}
 
-	filter := bson.M{"_id": objID}
-	_, err = collection.UpdateOne(ctx, filter, update)
+	result, err := collection.UpdateOne(ctx, versionFilter(objID, expectedVersion), update)
 	if err != nil {
 		return nil, err
 	}
+	if result.MatchedCount == 0 && expectedVersion != nil {
+		return nil, helper.ErrVersionConflict
+	}
 
 	// Return updated document
 	var alumni mongo.Alumni
-	err = collection.FindOne(ctx, filter).Decode(&alumni)
+	err = collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&alumni)
 	if err != nil {
 		return nil, err
 	}
@@ -246,9 +360,14 @@ func UpdateAlumni(db *mongoDB.Database, id string, req *mongo.UpdateAlumniReposi
 }
 
 func DeleteAlumni(db *mongoDB.Database, id string) error {
-	collection := db.Collection("alumni")
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
+	return DeleteAlumniCtx(ctx, db, id)
+}
+
+// DeleteAlumniCtx -> DeleteAlumni dengan context dari pemanggil (mis. sesi transaksi batch)
+func DeleteAlumniCtx(ctx context.Context, db *mongoDB.Database, id string) error {
+	collection := db.Collection("alumni")
 
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
@@ -278,8 +397,28 @@ func CheckAlumniByNim(db *mongoDB.Database, nim string) (*mongo.Alumni, error) {
 	return &alumni, nil
 }
 
+// GetAlumniVerificationByNIM -> data alumni yang dibutuhkan verifikasi kelulusan, termasuk tanggal
+// lahir; nil bila NIM tidak ditemukan
+func GetAlumniVerificationByNIM(db *mongoDB.Database, nim string) (*mongo.Alumni, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.FindOne().
+		SetProjection(bson.M{"nim": 1, "nama": 1, "jurusan": 1, "angkatan": 1, "tahun_lulus": 1, "tanggal_lahir": 1}).
+		SetSort(bson.D{{Key: "_id", Value: 1}})
+	var alumni mongo.Alumni
+	err := db.Collection("alumni").FindOne(ctx, bson.M{"nim": nim}, opts).Decode(&alumni)
+	if err != nil {
+		if err == mongoDB.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &alumni, nil
+}
+
 // Get Alumni Employment Status with filtering and pagination
-func GetAlumniEmploymentStatus(db *mongoDB.Database, req *mongo.AlumniEmploymentStatusRequest) ([]mongo.AlumniEmploymentStatus, error) {
+func GetAlumniEmploymentStatus(db *mongoDB.Database, req *mongo.AlumniEmploymentStatusRequest, filters []helper.Filter) ([]mongo.AlumniEmploymentStatus, error) {
 	// Set default pagination
 	if req.Page <= 0 {
 		req.Page = 1
@@ -290,6 +429,101 @@ func GetAlumniEmploymentStatus(db *mongoDB.Database, req *mongo.AlumniEmployment
 
 	offset := (req.Page - 1) * req.Limit
 
+	pipeline := append(employmentStatusPipeline(req, filters), employmentStatusSort(req)...)
+	pipeline = append(pipeline,
+		bson.M{"$skip": offset},
+		bson.M{"$limit": req.Limit},
+	)
+
+	collection := db.Collection("alumni")
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []mongo.AlumniEmploymentStatus
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// CountAlumniEmploymentStatus -> total seluruh baris status pekerjaan yang cocok dengan filter (bukan hanya halaman ini)
+func CountAlumniEmploymentStatus(db *mongoDB.Database, req *mongo.AlumniEmploymentStatusRequest, filters []helper.Filter) (int, error) {
+	pipeline := append(employmentStatusPipeline(req, filters), bson.M{"$count": "total"})
+
+	collection := db.Collection("alumni")
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var result []struct {
+		Total int `bson:"total"`
+	}
+	if err = cursor.All(ctx, &result); err != nil {
+		return 0, err
+	}
+	if len(result) == 0 {
+		return 0, nil
+	}
+	return result[0].Total, nil
+}
+
+// StreamAlumniEmploymentStatus -> seluruh baris status pekerjaan yang cocok dengan filter, tanpa pagination
+func StreamAlumniEmploymentStatus(db *mongoDB.Database, req *mongo.AlumniEmploymentStatusRequest, filters []helper.Filter, fn func(mongo.AlumniEmploymentStatus) error) error {
+	pipeline := append(employmentStatusPipeline(req, filters), employmentStatusSort(req)...)
+
+	collection := db.Collection("alumni")
+	ctx, cancel := context.WithTimeout(context.Background(), streamTimeout)
+	defer cancel()
+
+	opts := options.Aggregate().SetAllowDiskUse(true).SetBatchSize(streamBatchSize)
+	cursor, err := collection.Aggregate(ctx, pipeline, opts)
+	if err != nil {
+		return err
+	}
+	return streamCursor(ctx, cursor, fn)
+}
+
+// EmploymentStatusSortFields -> whitelist ?sortBy status pekerjaan; nominal gaji dibandingkan
+// apa adanya, gabungkan dengan filter gaji_mata_uang / gaji_periode agar sebanding
+var EmploymentStatusSortFields = map[string]bool{
+	"nama":                true,
+	"angkatan":            true,
+	"tanggal_mulai_kerja": true,
+	"gaji_min":            true,
+	"gaji_max":            true,
+}
+
+// employmentStatusSort -> tahap sort status pekerjaan; alumni tanpa nilai (belum bekerja, gaji
+// kosong / dirahasiakan) selalu di akhir
+func employmentStatusSort(req *mongo.AlumniEmploymentStatusRequest) []bson.M {
+	field := req.SortBy
+	if !EmploymentStatusSortFields[field] {
+		field = "nama"
+	}
+	direction := 1
+	if strings.ToLower(req.Order) == "desc" {
+		direction = -1
+	}
+	return []bson.M{
+		{"$addFields": bson.M{"sort_missing": bson.M{"$eq": []interface{}{bson.M{"$ifNull": []interface{}{"$" + field, nil}}, nil}}}},
+		{"$sort": bson.D{{Key: "sort_missing", Value: 1}, {Key: field, Value: direction}, {Key: "_id", Value: 1}}},
+		{"$unset": "sort_missing"},
+	}
+}
+
+// employmentStatusPipeline -> tahap agregasi status pekerjaan sebelum sort/pagination
+func employmentStatusPipeline(req *mongo.AlumniEmploymentStatusRequest, filters []helper.Filter) []bson.M {
 	// Build match stage for filtering
 	matchStage := bson.M{}
 	if req.ID != nil {
@@ -299,17 +533,30 @@ func GetAlumniEmploymentStatus(db *mongoDB.Database, req *mongo.AlumniEmployment
 		}
 	}
 	if req.Nama != nil {
-		matchStage["nama"] = bson.M{"$regex": *req.Nama, "$options": "i"}
+		matchStage["nama"] = bson.M{"$regex": regexp.QuoteMeta(*req.Nama), "$options": "i"}
 	}
 	if req.Jurusan != nil {
-		matchStage["jurusan"] = bson.M{"$regex": *req.Jurusan, "$options": "i"}
+		matchStage["jurusan"] = bson.M{"$regex": regexp.QuoteMeta(*req.Jurusan), "$options": "i"}
 	}
 	if req.Angkatan != nil {
 		matchStage["angkatan"] = *req.Angkatan
 	}
 
+	// Nominal gaji rahasia disembunyikan sebelum filter dan sort agar tidak bisa ditebak lewat ?filter
+	var salaryHidden interface{} = false
+	if !req.IncludeConfidential {
+		viewer, _ := primitive.ObjectIDFromHex(req.ViewerID)
+		salaryHidden = bson.M{"$and": []interface{}{
+			bson.M{"$eq": []interface{}{"$latest_pekerjaan.gaji_rahasia", true}},
+			bson.M{"$ne": []interface{}{"$_id", viewer}},
+		}}
+	}
+	visibleSalary := func(field string) bson.M {
+		return bson.M{"$cond": bson.M{"if": salaryHidden, "then": "$$REMOVE", "else": "$latest_pekerjaan." + field}}
+	}
+
 	// Build aggregation pipeline
-	pipeline := []bson.M{
+	return []bson.M{
 		{"$match": matchStage},
 		{"$lookup": bson.M{
 			"from":         "pekerjaan_alumni",
@@ -336,7 +583,11 @@ func GetAlumniEmploymentStatus(db *mongoDB.Database, req *mongo.AlumniEmployment
 			"nama_perusahaan":     "$latest_pekerjaan.nama_perusahaan",
 			"posisi_jabatan":      "$latest_pekerjaan.posisi_jabatan",
 			"tanggal_mulai_kerja": "$latest_pekerjaan.tanggal_mulai_kerja",
-			"gaji_range":          "$latest_pekerjaan.gaji_range",
+			"gaji_min":            visibleSalary("gaji_min"),
+			"gaji_max":            visibleSalary("gaji_max"),
+			"gaji_mata_uang":      "$latest_pekerjaan.gaji_mata_uang",
+			"gaji_periode":        "$latest_pekerjaan.gaji_periode",
+			"gaji_range":          visibleSalary("gaji_range"),
 			"lebih_dari_1_tahun": bson.M{
 				"$cond": bson.M{
 					"if": bson.M{
@@ -348,24 +599,6 @@ func GetAlumniEmploymentStatus(db *mongoDB.Database, req *mongo.AlumniEmployment
 			},
 			"employment_count": 1,
 		}},
-		{"$sort": bson.M{"nama": 1}},
-		{"$skip": offset},
-		{"$limit": req.Limit},
+		{"$match": buildMongoFilter(filters)},
 	}
-
-	collection := db.Collection("alumni")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	cursor, err := collection.Aggregate(ctx, pipeline)
-	if err != nil {
-		return nil, err
-	}
-	defer cursor.Close(ctx)
-
-	var results []mongo.AlumniEmploymentStatus
-	if err = cursor.All(ctx, &results); err != nil {
-		return nil, err
-	}
-	return results, nil
 }
@@ -0,0 +1,289 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go-fiber/app/model/mongo"
+	"go-fiber/helper"
+
+	"go.mongodb.org/mongo-driver/bson"
+	mongoDB "go.mongodb.org/mongo-driver/mongo"
+)
+
+// Analytics Repository Functions
+// Semua pipeline dimulai dari koleksi alumni agar filter kohort (filter[angkatan], dst.) memakai index alumni.
+
+// AnalyticsGroupFields -> nilai group_by yang diizinkan beserta field alumni-nya
+var AnalyticsGroupFields = map[string]string{
+	"angkatan":    "angkatan",
+	"jurusan":     "jurusan",
+	"tahun_lulus": "tahun_lulus",
+}
+
+// AnalyticsDistributionFields -> field pekerjaan yang bisa dihitung distribusinya
+var AnalyticsDistributionFields = map[string]string{
+	"bidang_industri": "bidang_industri",
+	"lokasi_kerja":    "lokasi_kerja",
+	"provinsi":        "provinsi",
+	"kota":            "kota",
+}
+
+// AnalyticsFilterFields -> whitelist filter kohort alumni untuk endpoint analytics
+var AnalyticsFilterFields = helper.FilterSpec{
+	"jurusan":     AlumniFilterFields["jurusan"],
+	"angkatan":    AlumniFilterFields["angkatan"],
+	"tahun_lulus": AlumniFilterFields["tahun_lulus"],
+}
+
+// analyticsGroupKey -> ekspresi _id $group; group_by kosong berarti satu kelompok "all"
+func analyticsGroupKey(groupBy string) interface{} {
+	if field, ok := AnalyticsGroupFields[groupBy]; ok {
+		return "$" + field
+	}
+	return "all"
+}
+
+// jobsLookup -> $lookup pekerjaan (tidak terhapus) milik alumni ke field "jobs"
+func jobsLookup(activeOnly bool, extra ...bson.M) bson.M {
+	match := bson.M{
+		"$expr":     bson.M{"$eq": []interface{}{"$alumni_id", "$$alumni_id"}},
+		"is_delete": nil,
+	}
+	if activeOnly {
+		match["status_pekerjaan"] = "aktif"
+	}
+	pipeline := append([]bson.M{{"$match": match}}, extra...)
+	return bson.M{"$lookup": bson.M{
+		"from":     "pekerjaan_alumni",
+		"let":      bson.M{"alumni_id": "$_id"},
+		"pipeline": pipeline,
+		"as":       "jobs",
+	}}
+}
+
+// GetEmploymentRate -> jumlah alumni, yang sedang bekerja, dan yang pernah bekerja per kelompok
+func GetEmploymentRate(db *mongoDB.Database, groupBy string, filters []helper.Filter) ([]mongo.EmploymentRateItem, error) {
+	pipeline := []bson.M{
+		{"$match": buildMongoFilter(filters)},
+		jobsLookup(false, bson.M{"$project": bson.M{"status_pekerjaan": 1}}),
+		{"$group": bson.M{
+			"_id":          analyticsGroupKey(groupBy),
+			"total_alumni": bson.M{"$sum": 1},
+			"employed": bson.M{"$sum": bson.M{"$cond": []interface{}{
+				bson.M{"$in": []interface{}{"aktif", "$jobs.status_pekerjaan"}}, 1, 0,
+			}}},
+			"ever_employed": bson.M{"$sum": bson.M{"$cond": []interface{}{
+				bson.M{"$gt": []interface{}{bson.M{"$size": "$jobs"}, 0}}, 1, 0,
+			}}},
+		}},
+		{"$sort": bson.M{"_id": 1}},
+		{"$project": bson.M{
+			"_id":           0,
+			"group":         bson.M{"$toString": "$_id"},
+			"total_alumni":  1,
+			"employed":      1,
+			"ever_employed": 1,
+		}},
+	}
+
+	var results []mongo.EmploymentRateItem
+	if err := aggregateAll(db.Collection("alumni"), pipeline, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetTimeToFirstJob -> median dan rata-rata bulan dari kelulusan ke pekerjaan pertama per kelompok.
+// Kelulusan diasumsikan bulan helper.GraduationMonth pada tahun_lulus; pekerjaan sebelum lulus dihitung 0.
+func GetTimeToFirstJob(db *mongoDB.Database, groupBy string, filters []helper.Filter) ([]mongo.TimeToFirstJobItem, error) {
+	pipeline := []bson.M{
+		{"$match": buildMongoFilter(filters)},
+		jobsLookup(false, bson.M{"$group": bson.M{"_id": nil, "first": bson.M{"$min": "$tanggal_mulai_kerja"}}}),
+		// Alumni tanpa pekerjaan tidak ikut dihitung
+		{"$unwind": "$jobs"},
+		{"$project": bson.M{
+			"group_key": analyticsGroupKey(groupBy),
+			"months": bson.M{"$max": []interface{}{0, bson.M{"$add": []interface{}{
+				bson.M{"$multiply": []interface{}{bson.M{"$subtract": []interface{}{bson.M{"$year": "$jobs.first"}, "$tahun_lulus"}}, 12}},
+				bson.M{"$subtract": []interface{}{bson.M{"$month": "$jobs.first"}, helper.GraduationMonth}},
+			}}}},
+		}},
+		{"$sort": bson.M{"months": 1}},
+		{"$group": bson.M{
+			"_id":     "$group_key",
+			"months":  bson.M{"$push": "$months"},
+			"average": bson.M{"$avg": "$months"},
+		}},
+		{"$sort": bson.M{"_id": 1}},
+		// Median tanpa operator $median (MongoDB 7) agar tetap jalan di versi lama
+		{"$project": bson.M{
+			"_id":             0,
+			"group":           bson.M{"$toString": "$_id"},
+			"alumni_with_job": bson.M{"$size": "$months"},
+			"average_months":  "$average",
+			"median_months": bson.M{"$let": bson.M{
+				"vars": bson.M{"mid": bson.M{"$divide": []interface{}{bson.M{"$subtract": []interface{}{bson.M{"$size": "$months"}, 1}}, 2}}},
+				"in": bson.M{"$avg": []interface{}{
+					bson.M{"$arrayElemAt": []interface{}{"$months", bson.M{"$toInt": bson.M{"$floor": "$$mid"}}}},
+					bson.M{"$arrayElemAt": []interface{}{"$months", bson.M{"$toInt": bson.M{"$ceil": "$$mid"}}}},
+				}},
+			}},
+		}},
+	}
+
+	var results []mongo.TimeToFirstJobItem
+	if err := aggregateAll(db.Collection("alumni"), pipeline, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetJobDistribution -> jumlah pekerjaan dan alumni per nilai field (bidang_industri / lokasi_kerja / provinsi / kota);
+// pekerjaan tanpa lokasi terstruktur dikelompokkan sebagai nilai kosong
+func GetJobDistribution(db *mongoDB.Database, field string, activeOnly bool, filters []helper.Filter) ([]mongo.DistributionItem, error) {
+	pipeline := []bson.M{
+		{"$match": buildMongoFilter(filters)},
+		jobsLookup(activeOnly, bson.M{"$project": bson.M{field: 1}}),
+		{"$unwind": "$jobs"},
+		{"$group": bson.M{
+			"_id":    "$jobs." + field,
+			"jobs":   bson.M{"$sum": 1},
+			"alumni": bson.M{"$addToSet": "$_id"},
+		}},
+		{"$project": bson.M{
+			"_id":    0,
+			"value":  "$_id",
+			"jobs":   1,
+			"alumni": bson.M{"$size": "$alumni"},
+		}},
+		{"$sort": bson.D{{Key: "jobs", Value: -1}, {Key: "value", Value: 1}}},
+	}
+
+	var results []mongo.DistributionItem
+	if err := aggregateAll(db.Collection("alumni"), pipeline, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetSalaryHistogram -> jumlah pekerjaan per kelompok gaji (helper.SalaryBoundaries), dalam juta
+// rupiah per bulan. Gaji terstruktur memakai titik tengah gaji_min / gaji_max (gaji tahunan dibagi
+// 12, mata uang selain IDR tidak dikelompokkan). Pekerjaan yang masih memakai gaji_range lama
+// dibaca dari angka pertama dan terakhirnya; nilai >= 100000 dianggap rupiah.
+func GetSalaryHistogram(db *mongoDB.Database, activeOnly bool, filters []helper.Filter) ([]mongo.SalaryBucketCount, error) {
+	toJuta := func(expr interface{}) bson.M {
+		return bson.M{"$let": bson.M{
+			"vars": bson.M{"n": bson.M{"$toDouble": expr}},
+			"in":   bson.M{"$cond": []interface{}{bson.M{"$gte": []interface{}{"$$n", 100000}}, bson.M{"$divide": []interface{}{"$$n", 1000000}}, "$$n"}},
+		}}
+	}
+	boundaries := append(append([]float64{}, helper.SalaryBoundaries...), 1e12)
+
+	pipeline := []bson.M{
+		{"$match": buildMongoFilter(filters)},
+		jobsLookup(activeOnly, bson.M{"$project": bson.M{
+			"gaji_range": 1, "gaji_min": 1, "gaji_max": 1, "gaji_mata_uang": 1, "gaji_periode": 1,
+		}}),
+		{"$unwind": "$jobs"},
+		{"$project": bson.M{
+			"min":      bson.M{"$ifNull": []interface{}{"$jobs.gaji_min", "$jobs.gaji_max"}},
+			"max":      bson.M{"$ifNull": []interface{}{"$jobs.gaji_max", "$jobs.gaji_min"}},
+			"currency": "$jobs.gaji_mata_uang",
+			"months":   bson.M{"$cond": []interface{}{bson.M{"$eq": []interface{}{"$jobs.gaji_periode", helper.SalaryPeriodYearly}}, 12, 1}},
+			"numbers": bson.M{"$regexFindAll": bson.M{
+				"input": bson.M{"$replaceAll": bson.M{"input": bson.M{"$ifNull": []interface{}{"$jobs.gaji_range", ""}}, "find": ".", "replacement": ""}},
+				"regex": "[0-9]+",
+			}},
+		}},
+		{"$project": bson.M{
+			"salary": bson.M{"$switch": bson.M{
+				"branches": []bson.M{
+					{
+						"case": bson.M{"$eq": []interface{}{bson.M{"$ifNull": []interface{}{"$min", nil}}, nil}},
+						"then": bson.M{"$cond": []interface{}{
+							bson.M{"$eq": []interface{}{bson.M{"$size": "$numbers"}, 0}},
+							nil,
+							bson.M{"$divide": []interface{}{bson.M{"$add": []interface{}{
+								toJuta(bson.M{"$arrayElemAt": []interface{}{"$numbers.match", 0}}),
+								toJuta(bson.M{"$arrayElemAt": []interface{}{"$numbers.match", -1}}),
+							}}, 2}},
+						}},
+					},
+					{
+						"case": bson.M{"$eq": []interface{}{"$currency", helper.DefaultSalaryCurrency}},
+						"then": bson.M{"$divide": []interface{}{
+							bson.M{"$add": []interface{}{"$min", "$max"}},
+							bson.M{"$multiply": []interface{}{2000000, "$months"}},
+						}},
+					},
+				},
+				"default": nil,
+			}},
+		}},
+		{"$bucket": bson.M{
+			"groupBy":    "$salary",
+			"boundaries": boundaries,
+			"default":    -1,
+			"output":     bson.M{"count": bson.M{"$sum": 1}},
+		}},
+	}
+
+	var results []mongo.SalaryBucketCount
+	if err := aggregateAll(db.Collection("alumni"), pipeline, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetJobRetention -> pekerjaan yang bertahan lebih dari helper.RetentionDays per kelompok.
+// Pekerjaan aktif yang belum berjalan 1 tahun belum bisa dinilai sehingga tidak dihitung.
+func GetJobRetention(db *mongoDB.Database, groupBy string, filters []helper.Filter) ([]mongo.RetentionItem, error) {
+	retentionMillis := int64(helper.RetentionDays) * 24 * 60 * 60 * 1000
+	end := bson.M{"$ifNull": []interface{}{"$jobs.tanggal_selesai_kerja", "$$NOW"}}
+
+	pipeline := []bson.M{
+		{"$match": buildMongoFilter(filters)},
+		jobsLookup(false, bson.M{"$project": bson.M{"tanggal_mulai_kerja": 1, "tanggal_selesai_kerja": 1}}),
+		{"$unwind": "$jobs"},
+		{"$project": bson.M{
+			"group_key": analyticsGroupKey(groupBy),
+			"eligible": bson.M{"$or": []interface{}{
+				bson.M{"$ne": []interface{}{bson.M{"$ifNull": []interface{}{"$jobs.tanggal_selesai_kerja", nil}}, nil}},
+				bson.M{"$lte": []interface{}{"$jobs.tanggal_mulai_kerja", bson.M{"$subtract": []interface{}{"$$NOW", retentionMillis}}}},
+			}},
+			"retained": bson.M{"$gt": []interface{}{bson.M{"$subtract": []interface{}{end, "$jobs.tanggal_mulai_kerja"}}, retentionMillis}},
+		}},
+		{"$group": bson.M{
+			"_id":           "$group_key",
+			"eligible_jobs": bson.M{"$sum": bson.M{"$cond": []interface{}{"$eligible", 1, 0}}},
+			"retained_jobs": bson.M{"$sum": bson.M{"$cond": []interface{}{"$retained", 1, 0}}},
+		}},
+		{"$sort": bson.M{"_id": 1}},
+		{"$project": bson.M{
+			"_id":           0,
+			"group":         bson.M{"$toString": "$_id"},
+			"eligible_jobs": 1,
+			"retained_jobs": 1,
+		}},
+	}
+
+	var results []mongo.RetentionItem
+	if err := aggregateAll(db.Collection("alumni"), pipeline, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// aggregateAll menjalankan pipeline dan men-decode seluruh hasil ke out
+func aggregateAll(collection *mongoDB.Collection, pipeline []bson.M, out interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+	return cursor.All(ctx, out)
+}
@@ -0,0 +1,116 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	model "go-fiber/app/model/mongo"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongoDB "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// API Key Repository Functions
+
+// apiKeyTouchInterval -> last_used_at hanya ditulis ulang bila lebih lama dari interval ini,
+// agar setiap request partner tidak selalu menjadi operasi tulis
+const apiKeyTouchInterval = time.Minute
+
+// CreateAPIKey -> simpan API key baru (hanya hash key)
+func CreateAPIKey(db *mongoDB.Database, key *model.APIKey) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	key.CreatedAt = time.Now()
+	result, err := db.Collection("api_keys").InsertOne(ctx, key)
+	if err != nil {
+		return err
+	}
+	key.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// ListAPIKeys -> semua API key, terbaru lebih dulu
+func ListAPIKeys(db *mongoDB.Database) ([]model.APIKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := db.Collection("api_keys").Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	keys := []model.APIKey{}
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey -> cabut API key. nil bila key tidak ditemukan; key yang sudah dicabut
+// dikembalikan apa adanya
+func RevokeAPIKey(db *mongoDB.Database, id primitive.ObjectID) (*model.APIKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{"_id": id, "revoked_at": bson.M{"$exists": false}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var key model.APIKey
+	err := db.Collection("api_keys").FindOneAndUpdate(ctx, filter, bson.M{"$set": bson.M{"revoked_at": now}}, opts).Decode(&key)
+	if err == mongoDB.ErrNoDocuments {
+		err = db.Collection("api_keys").FindOne(ctx, bson.M{"_id": id}).Decode(&key)
+	}
+	if err == mongoDB.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// FindActiveAPIKeyByHash -> API key yang belum dicabut dan belum kedaluwarsa. nil bila tidak ada
+func FindActiveAPIKeyByHash(db *mongoDB.Database, hash string) (*model.APIKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"key_hash":   hash,
+		"revoked_at": bson.M{"$exists": false},
+		"$or": bson.A{
+			bson.M{"expires_at": bson.M{"$exists": false}},
+			bson.M{"expires_at": bson.M{"$gt": time.Now()}},
+		},
+	}
+	var key model.APIKey
+	err := db.Collection("api_keys").FindOne(ctx, filter).Decode(&key)
+	if err == mongoDB.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// TouchAPIKeyLastUsed -> catat waktu pemakaian terakhir API key
+func TouchAPIKeyLastUsed(db *mongoDB.Database, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{
+		"_id": id,
+		"$or": bson.A{
+			bson.M{"last_used_at": bson.M{"$exists": false}},
+			bson.M{"last_used_at": bson.M{"$lt": now.Add(-apiKeyTouchInterval)}},
+		},
+	}
+	_, err := db.Collection("api_keys").UpdateOne(ctx, filter, bson.M{"$set": bson.M{"last_used_at": now}})
+	return err
+}
@@ -0,0 +1,94 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	model "go-fiber/app/model/mongo"
+	"go-fiber/helper"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongoDB "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Audit Repository Functions
+// Audit log bersifat append-only: tidak ada fungsi update maupun delete.
+
+// InsertAuditLog -> tambah satu entri audit log
+func InsertAuditLog(db *mongoDB.Database, entry *model.AuditLog) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	entry.CreatedAt = time.Now()
+	result, err := db.Collection("audit_logs").InsertOne(ctx, entry)
+	if err != nil {
+		return err
+	}
+	entry.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// auditLogQuery -> filter Mongo dari filter audit log; ActorID harus hex ObjectID yang valid
+func auditLogQuery(f helper.AuditLogFilter) bson.M {
+	filter := bson.M{}
+	if id, err := primitive.ObjectIDFromHex(f.ActorID); err == nil {
+		filter["actor_id"] = id
+	}
+	if f.ActorRole != "" {
+		filter["actor_role"] = f.ActorRole
+	}
+	if f.Action != "" {
+		filter["action"] = f.Action
+	}
+	if f.EntityType != "" {
+		filter["entity_type"] = f.EntityType
+	}
+	if f.EntityID != "" {
+		filter["entity_id"] = f.EntityID
+	}
+	if f.RequestID != "" {
+		filter["request_id"] = f.RequestID
+	}
+	if f.From != nil || f.To != nil {
+		createdAt := bson.M{}
+		if f.From != nil {
+			createdAt["$gte"] = *f.From
+		}
+		if f.To != nil {
+			createdAt["$lte"] = *f.To
+		}
+		filter["created_at"] = createdAt
+	}
+	return filter
+}
+
+// FindAuditLogs -> audit log sesuai filter, terbaru lebih dulu, beserta total
+func FindAuditLogs(db *mongoDB.Database, f helper.AuditLogFilter, page, limit int) ([]model.AuditLog, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := auditLogQuery(f)
+	collection := db.Collection("audit_logs")
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64((page - 1) * limit)).
+		SetLimit(int64(limit))
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	logs := []model.AuditLog{}
+	if err := cursor.All(ctx, &logs); err != nil {
+		return nil, 0, err
+	}
+	return logs, int(total), nil
+}
@@ -0,0 +1,55 @@
+package mongo
+
+import (
+	"context"
+	model "go-fiber/app/model/mongo"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type FileCategoryRepository interface {
+	FindByName(ctx context.Context, name string) (*model.FileCategory, error)
+	List(ctx context.Context) ([]model.FileCategory, error)
+	Upsert(ctx context.Context, category *model.FileCategory) error
+}
+
+type fileCategoryRepository struct {
+	collection *mongo.Collection
+}
+
+func NewFileCategoryRepository(db *mongo.Database) FileCategoryRepository {
+	return &fileCategoryRepository{collection: db.Collection("file_categories")}
+}
+
+func (r *fileCategoryRepository) FindByName(ctx context.Context, name string) (*model.FileCategory, error) {
+	var fc model.FileCategory
+	err := r.collection.FindOne(ctx, bson.M{"name": name}).Decode(&fc)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &fc, nil
+}
+
+func (r *fileCategoryRepository) List(ctx context.Context) ([]model.FileCategory, error) {
+	cur, err := r.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"name": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var categories []model.FileCategory
+	if err := cur.All(ctx, &categories); err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+func (r *fileCategoryRepository) Upsert(ctx context.Context, category *model.FileCategory) error {
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"name": category.Name}, category, options.Replace().SetUpsert(true))
+	return err
+}
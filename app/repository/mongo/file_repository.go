@@ -3,17 +3,23 @@ package mongo
 import (
 	"context"
 	model "go-fiber/app/model/mongo"
+	"go-fiber/helper"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type FileRepository interface {
 	Create(ctx context.Context, file *model.File) error
 	FindByAlumniAndCategory(ctx context.Context, alumniID primitive.ObjectID, category string) (*model.File, error)
 	ListByAlumni(ctx context.Context, alumniID primitive.ObjectID) ([]model.File, error)
+	CountByAlumniAndCategory(ctx context.Context, alumniID primitive.ObjectID, category string) (int64, error)
+	ListExpired(ctx context.Context, now time.Time) ([]model.File, error)
+	ListByAlumniKeyset(ctx context.Context, alumniID primitive.ObjectID, filters []helper.Filter, keyset *helper.Keyset, limit int) ([]model.File, bool, error)
+	CountByAlumni(ctx context.Context, alumniID primitive.ObjectID, filters []helper.Filter) (int64, error)
 	DeleteByID(ctx context.Context, id primitive.ObjectID) error
 }
 
@@ -58,6 +64,50 @@ func (r *fileRepository) ListByAlumni(ctx context.Context, alumniID primitive.Ob
 	return files, nil
 }
 
+func (r *fileRepository) CountByAlumniAndCategory(ctx context.Context, alumniID primitive.ObjectID, category string) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"alumni_id": alumniID, "category": category})
+}
+
+// ListExpired -> file yang sudah melewati masa retensi kategorinya
+func (r *fileRepository) ListExpired(ctx context.Context, now time.Time) ([]model.File, error) {
+	cur, err := r.collection.Find(ctx, bson.M{"expires_at": bson.M{"$lte": now}})
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var files []model.File
+	if err := cur.All(ctx, &files); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// ListByAlumniKeyset -> file milik alumni, terbaru dulu, dengan keyset pada (uploaded_at, _id)
+func (r *fileRepository) ListByAlumniKeyset(ctx context.Context, alumniID primitive.ObjectID, filters []helper.Filter, keyset *helper.Keyset, limit int) ([]model.File, bool, error) {
+	filter := andFilter(withFilters(bson.M{"alumni_id": alumniID}, filters), keysetCondition("uploaded_at", "desc", keyset))
+	opts := options.Find().
+		SetSort(keysetSort("uploaded_at", "desc", keyset)).
+		SetLimit(int64(limit + 1))
+
+	cur, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, false, err
+	}
+	defer cur.Close(ctx)
+
+	var files []model.File
+	if err := cur.All(ctx, &files); err != nil {
+		return nil, false, err
+	}
+	files, hasMore := trimKeysetPage(files, limit, keyset)
+	return files, hasMore, nil
+}
+
+func (r *fileRepository) CountByAlumni(ctx context.Context, alumniID primitive.ObjectID, filters []helper.Filter) (int64, error) {
+	return r.collection.CountDocuments(ctx, withFilters(bson.M{"alumni_id": alumniID}, filters))
+}
+
 func (r *fileRepository) DeleteByID(ctx context.Context, id primitive.ObjectID) error {
 	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
 	return err
@@ -0,0 +1,125 @@
+package mongo
+
+import (
+	"regexp"
+
+	"go-fiber/helper"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// AlumniFilterFields -> whitelist filter[field][op] untuk list alumni
+var AlumniFilterFields = helper.FilterSpec{
+	"id":          {Column: "_id", Type: helper.FilterTypeObjectID, Ops: helper.IDFilterOps},
+	"nim":         {Column: "nim", Type: helper.FilterTypeString, Ops: helper.StringFilterOps},
+	"nama":        {Column: "nama", Type: helper.FilterTypeString, Ops: helper.StringFilterOps},
+	"email":       {Column: "email", Type: helper.FilterTypeString, Ops: helper.StringFilterOps},
+	"jurusan":     {Column: "jurusan", Type: helper.FilterTypeString, Ops: helper.StringFilterOps},
+	"angkatan":    {Column: "angkatan", Type: helper.FilterTypeInt, Ops: helper.NumberFilterOps},
+	"tahun_lulus": {Column: "tahun_lulus", Type: helper.FilterTypeInt, Ops: helper.NumberFilterOps},
+	"created_at":  {Column: "created_at", Type: helper.FilterTypeDate, Ops: helper.NumberFilterOps},
+}
+
+// PekerjaanFilterFields -> whitelist filter[field][op] untuk list pekerjaan
+var PekerjaanFilterFields = helper.FilterSpec{
+	"id":                    {Column: "_id", Type: helper.FilterTypeObjectID, Ops: helper.IDFilterOps},
+	"alumni_id":             {Column: "alumni_id", Type: helper.FilterTypeObjectID, Ops: helper.IDFilterOps},
+	"nama_perusahaan":       {Column: "nama_perusahaan", Type: helper.FilterTypeString, Ops: helper.StringFilterOps},
+	"posisi_jabatan":        {Column: "posisi_jabatan", Type: helper.FilterTypeString, Ops: helper.StringFilterOps},
+	"bidang_industri":       {Column: "bidang_industri", Type: helper.FilterTypeString, Ops: helper.StringFilterOps},
+	"lokasi_kerja":          {Column: "lokasi_kerja", Type: helper.FilterTypeString, Ops: helper.StringFilterOps},
+	"gaji_range":            {Column: "gaji_range", Type: helper.FilterTypeString, Ops: helper.StringFilterOps},
+	"negara":                {Column: "negara", Type: helper.FilterTypeString, Ops: []string{helper.FilterNull, helper.FilterEq, helper.FilterNe, helper.FilterIn, helper.FilterNin}},
+	"provinsi":              {Column: "provinsi", Type: helper.FilterTypeString, Ops: append([]string{helper.FilterNull}, helper.StringFilterOps...)},
+	"kota":                  {Column: "kota", Type: helper.FilterTypeString, Ops: append([]string{helper.FilterNull}, helper.StringFilterOps...)},
+	"koordinat":             {Column: "koordinat", Type: helper.FilterTypeGeo, Ops: helper.GeoFilterOps},
+	"status_pekerjaan":      {Column: "status_pekerjaan", Type: helper.FilterTypeString, Ops: []string{helper.FilterEq, helper.FilterNe, helper.FilterIn, helper.FilterNin}},
+	"tanggal_mulai_kerja":   {Column: "tanggal_mulai_kerja", Type: helper.FilterTypeDate, Ops: helper.NumberFilterOps},
+	"tanggal_selesai_kerja": {Column: "tanggal_selesai_kerja", Type: helper.FilterTypeDate, Ops: append([]string{helper.FilterNull}, helper.NumberFilterOps...)},
+	"created_at":            {Column: "created_at", Type: helper.FilterTypeDate, Ops: helper.NumberFilterOps},
+}
+
+// EmploymentStatusFilterFields -> whitelist filter[field][op] untuk status pekerjaan alumni
+// (diterapkan setelah $project, jadi path mengikuti field hasil agregasi)
+var EmploymentStatusFilterFields = helper.FilterSpec{
+	"id":                  {Column: "_id", Type: helper.FilterTypeObjectID, Ops: helper.IDFilterOps},
+	"nama":                {Column: "nama", Type: helper.FilterTypeString, Ops: helper.StringFilterOps},
+	"jurusan":             {Column: "jurusan", Type: helper.FilterTypeString, Ops: helper.StringFilterOps},
+	"angkatan":            {Column: "angkatan", Type: helper.FilterTypeInt, Ops: helper.NumberFilterOps},
+	"bidang_industri":     {Column: "bidang_industri", Type: helper.FilterTypeString, Ops: append([]string{helper.FilterNull}, helper.StringFilterOps...)},
+	"nama_perusahaan":     {Column: "nama_perusahaan", Type: helper.FilterTypeString, Ops: append([]string{helper.FilterNull}, helper.StringFilterOps...)},
+	"posisi_jabatan":      {Column: "posisi_jabatan", Type: helper.FilterTypeString, Ops: append([]string{helper.FilterNull}, helper.StringFilterOps...)},
+	"tanggal_mulai_kerja": {Column: "tanggal_mulai_kerja", Type: helper.FilterTypeDate, Ops: append([]string{helper.FilterNull}, helper.NumberFilterOps...)},
+	"gaji_min":            {Column: "gaji_min", Type: helper.FilterTypeInt, Ops: append([]string{helper.FilterNull}, helper.NumberFilterOps...)},
+	"gaji_max":            {Column: "gaji_max", Type: helper.FilterTypeInt, Ops: append([]string{helper.FilterNull}, helper.NumberFilterOps...)},
+	"gaji_mata_uang":      {Column: "gaji_mata_uang", Type: helper.FilterTypeString, Ops: []string{helper.FilterEq, helper.FilterNe, helper.FilterIn, helper.FilterNin}},
+	"gaji_periode":        {Column: "gaji_periode", Type: helper.FilterTypeString, Ops: []string{helper.FilterEq, helper.FilterNe, helper.FilterIn, helper.FilterNin}},
+	"lebih_dari_1_tahun":  {Column: "lebih_dari_1_tahun", Type: helper.FilterTypeInt, Ops: []string{helper.FilterEq, helper.FilterNe}},
+	"employment_count":    {Column: "employment_count", Type: helper.FilterTypeInt, Ops: helper.NumberFilterOps},
+}
+
+// FileFilterFields -> whitelist filter[field][op] untuk daftar file user
+var FileFilterFields = helper.FilterSpec{
+	"category":  {Column: "category", Type: helper.FilterTypeString, Ops: []string{helper.FilterEq, helper.FilterIn}},
+	"file_type": {Column: "file_type", Type: helper.FilterTypeString, Ops: []string{helper.FilterEq, helper.FilterIn}},
+}
+
+// buildMongoFilter menerjemahkan filter yang sudah divalidasi menjadi dokumen filter Mongo.
+// Nilai selalu dikirim sebagai data (bukan operator), dan like di-escape dengan QuoteMeta.
+func buildMongoFilter(filters []helper.Filter) bson.M {
+	var conds []bson.M
+	for _, f := range filters {
+		var cond interface{}
+		switch f.Op {
+		case helper.FilterEq:
+			cond = bson.M{"$eq": f.Values[0]}
+		case helper.FilterNe:
+			cond = bson.M{"$ne": f.Values[0]}
+		case helper.FilterGt:
+			cond = bson.M{"$gt": f.Values[0]}
+		case helper.FilterGte:
+			cond = bson.M{"$gte": f.Values[0]}
+		case helper.FilterLt:
+			cond = bson.M{"$lt": f.Values[0]}
+		case helper.FilterLte:
+			cond = bson.M{"$lte": f.Values[0]}
+		case helper.FilterIn:
+			cond = bson.M{"$in": f.Values}
+		case helper.FilterNin:
+			cond = bson.M{"$nin": f.Values}
+		case helper.FilterBetween:
+			cond = bson.M{"$gte": f.Values[0], "$lte": f.Values[1]}
+		case helper.FilterLike:
+			cond = bson.M{"$regex": regexp.QuoteMeta(f.Values[0].(string)), "$options": "i"}
+		case helper.FilterNull:
+			if f.Values[0].(bool) {
+				cond = bson.M{"$eq": nil}
+			} else {
+				cond = bson.M{"$ne": nil}
+			}
+		case helper.FilterNear:
+			// $centerSphere memakai radius dalam radian; dilayani index 2dsphere koordinat
+			geo := f.Values[0].(helper.GeoRadius)
+			cond = bson.M{"$geoWithin": bson.M{"$centerSphere": bson.A{bson.A{geo.Lng, geo.Lat}, geo.RadiusKm / helper.EarthRadiusKm}}}
+		default:
+			continue
+		}
+		conds = append(conds, bson.M{f.Column: cond})
+	}
+
+	if len(conds) == 0 {
+		return bson.M{}
+	}
+	return bson.M{"$and": conds}
+}
+
+// withFilters menggabungkan filter dasar (mis. search) dengan filter query language
+func withFilters(base bson.M, filters []helper.Filter) bson.M {
+	if len(filters) == 0 {
+		return base
+	}
+	if len(base) == 0 {
+		return buildMongoFilter(filters)
+	}
+	return bson.M{"$and": []bson.M{base, buildMongoFilter(filters)}}
+}
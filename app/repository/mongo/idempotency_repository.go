@@ -0,0 +1,97 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-fiber/helper"
+
+	"go.mongodb.org/mongo-driver/bson"
+	mongoDB "go.mongodb.org/mongo-driver/mongo"
+)
+
+// Idempotency Repository Functions
+
+// IdempotencyStore -> helper.IdempotencyStore di collection idempotency_keys. Dokumen
+// kedaluwarsa dihapus TTL index pada expires_at.
+type IdempotencyStore struct {
+	db *mongoDB.Database
+}
+
+func NewIdempotencyStore(db *mongoDB.Database) *IdempotencyStore {
+	return &IdempotencyStore{db: db}
+}
+
+type idempotencyDoc struct {
+	Key         string    `bson:"_id"`
+	RequestHash string    `bson:"request_hash"`
+	Completed   bool      `bson:"completed"`
+	StatusCode  int       `bson:"status_code,omitempty"`
+	ContentType string    `bson:"content_type,omitempty"`
+	Body        []byte    `bson:"body,omitempty"`
+	CreatedAt   time.Time `bson:"created_at"`
+	ExpiresAt   time.Time `bson:"expires_at"`
+}
+
+func (s *IdempotencyStore) Reserve(key, requestHash string, ttl time.Duration) (*helper.IdempotencyRecord, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := s.db.Collection("idempotency_keys")
+	now := time.Now()
+	// TTL monitor hanya berjalan tiap menit; dokumen yang sudah kedaluwarsa dianggap tidak ada
+	if _, err := collection.DeleteOne(ctx, bson.M{"_id": key, "expires_at": bson.M{"$lte": now}}); err != nil {
+		return nil, err
+	}
+
+	_, err := collection.InsertOne(ctx, idempotencyDoc{
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	})
+	if err == nil {
+		return nil, nil
+	}
+	if !mongoDB.IsDuplicateKeyError(err) {
+		return nil, err
+	}
+
+	var doc idempotencyDoc
+	if err := collection.FindOne(ctx, bson.M{"_id": key}).Decode(&doc); err != nil {
+		// Dihapus request lain di antara insert dan find; anggap masih diproses
+		if errors.Is(err, mongoDB.ErrNoDocuments) {
+			return &helper.IdempotencyRecord{RequestHash: requestHash}, nil
+		}
+		return nil, err
+	}
+	return &helper.IdempotencyRecord{
+		RequestHash: doc.RequestHash,
+		Completed:   doc.Completed,
+		StatusCode:  doc.StatusCode,
+		ContentType: doc.ContentType,
+		Body:        doc.Body,
+	}, nil
+}
+
+func (s *IdempotencyStore) Complete(key string, record helper.IdempotencyRecord) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := s.db.Collection("idempotency_keys").UpdateOne(ctx, bson.M{"_id": key}, bson.M{"$set": bson.M{
+		"completed":    true,
+		"status_code":  record.StatusCode,
+		"content_type": record.ContentType,
+		"body":         record.Body,
+	}})
+	return err
+}
+
+func (s *IdempotencyStore) Release(key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := s.db.Collection("idempotency_keys").DeleteOne(ctx, bson.M{"_id": key})
+	return err
+}
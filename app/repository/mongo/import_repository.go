@@ -0,0 +1,208 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go-fiber/app/model/mongo"
+	"go-fiber/helper"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongoDB "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Import Repository Functions
+
+// FindAlumniKeys -> alumni yang _id, NIM, atau email-nya ada di daftar (untuk upsert import)
+func FindAlumniKeys(db *mongoDB.Database, ids []primitive.ObjectID, nims, emails []string) ([]mongo.AlumniKey, error) {
+	var or []bson.M
+	if len(ids) > 0 {
+		or = append(or, bson.M{"_id": bson.M{"$in": ids}})
+	}
+	if len(nims) > 0 {
+		or = append(or, bson.M{"nim": bson.M{"$in": nims}})
+	}
+	if len(emails) > 0 {
+		or = append(or, bson.M{"email": bson.M{"$in": emails}})
+	}
+	if len(or) == 0 {
+		return nil, nil
+	}
+
+	collection := db.Collection("alumni")
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetProjection(bson.M{"_id": 1, "nim": 1, "email": 1})
+	cursor, err := collection.Find(ctx, bson.M{"$or": or}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var keys []mongo.AlumniKey
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// UpsertAlumniBatch -> insert alumni baru dan update alumni lama dalam satu BulkWrite
+func UpsertAlumniBatch(db *mongoDB.Database, records []mongo.AlumniImportRecord) (created, updated int, err error) {
+	now := time.Now()
+	models := make([]mongoDB.WriteModel, 0, len(records))
+	for _, r := range records {
+		d := r.Data
+		if r.ID == nil {
+			models = append(models, mongoDB.NewInsertOneModel().SetDocument(mongo.Alumni{
+				NIM:          d.NIM,
+				Nama:         d.Nama,
+				Jurusan:      d.Jurusan,
+				Angkatan:     d.Angkatan,
+				TahunLulus:   d.TahunLulus,
+				Email:        d.Email,
+				RoleID:       d.RoleID,
+				NoTelepon:    d.NoTelepon,
+				Alamat:       d.Alamat,
+				TanggalLahir: d.TanggalLahir,
+				Password:     d.Password,
+				CreatedAt:    now,
+				UpdatedAt:    now,
+				Version:      1,
+			}))
+			continue
+		}
+
+		set := bson.M{
+			"nim":         d.NIM,
+			"nama":        d.Nama,
+			"jurusan":     d.Jurusan,
+			"angkatan":    d.Angkatan,
+			"tahun_lulus": d.TahunLulus,
+			"email":       d.Email,
+			"role_id":     d.RoleID,
+			"updated_at":  now,
+		}
+		if d.NoTelepon != nil {
+			set["no_telepon"] = *d.NoTelepon
+		}
+		if d.Alamat != nil {
+			set["alamat"] = *d.Alamat
+		}
+		if d.TanggalLahir != nil {
+			set["tanggal_lahir"] = *d.TanggalLahir
+		}
+		inc := bson.M{"version": 1}
+		update := bson.M{"$set": set, "$inc": inc}
+		if d.Password != "" {
+			set["password"] = d.Password
+			inc["session_version"] = 1
+		}
+		models = append(models, mongoDB.NewUpdateOneModel().SetFilter(bson.M{"_id": *r.ID}).SetUpdate(update))
+	}
+	if len(models) == 0 {
+		return 0, 0, nil
+	}
+
+	collection := db.Collection("alumni")
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	result, err := collection.BulkWrite(ctx, models)
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(result.InsertedCount), int(result.MatchedCount), nil
+}
+
+// FindPekerjaanKeys -> pekerjaan (tidak terhapus) milik alumni di daftar, untuk mencocokkan baris import
+func FindPekerjaanKeys(db *mongoDB.Database, alumniIDs []primitive.ObjectID) ([]mongo.PekerjaanKey, error) {
+	if len(alumniIDs) == 0 {
+		return nil, nil
+	}
+
+	collection := db.Collection("pekerjaan_alumni")
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetProjection(bson.M{"_id": 1, "alumni_id": 1, "nama_perusahaan": 1, "posisi_jabatan": 1, "tanggal_mulai_kerja": 1,
+		"tanggal_selesai_kerja": 1, "paruh_waktu": 1})
+	cursor, err := collection.Find(ctx, bson.M{"alumni_id": bson.M{"$in": alumniIDs}, "is_delete": nil}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var keys []mongo.PekerjaanKey
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// UpsertPekerjaanCtx -> insert satu pekerjaan baru atau update pekerjaan lama dengan context dari
+// pemanggil (sesi transaksi import); created true bila dokumen baru dibuat
+func UpsertPekerjaanCtx(ctx context.Context, db *mongoDB.Database, r mongo.PekerjaanImportRecord, now time.Time) (created bool, err error) {
+	collection := db.Collection("pekerjaan_alumni")
+	d := r.Data
+	if r.ID == nil {
+		_, err := collection.InsertOne(ctx, mongo.PekerjaanAlumni{
+			AlumniID:            d.AlumniID,
+			NamaPerusahaan:      d.NamaPerusahaan,
+			PosisiJabatan:       d.PosisiJabatan,
+			BidangIndustri:      d.BidangIndustri,
+			LokasiKerja:         d.LokasiKerja,
+			Negara:              d.Negara,
+			Provinsi:            d.Provinsi,
+			Kota:                d.Kota,
+			Latitude:            d.Latitude,
+			Longitude:           d.Longitude,
+			Koordinat:           geoPoint(d.Latitude, d.Longitude),
+			GajiMin:             d.GajiMin,
+			GajiMax:             d.GajiMax,
+			GajiMataUang:        d.GajiMataUang,
+			GajiPeriode:         d.GajiPeriode,
+			TanggalMulaiKerja:   d.TanggalMulaiKerja,
+			TanggalSelesaiKerja: d.TanggalSelesaiKerja,
+			StatusPekerjaan:     d.StatusPekerjaan,
+			DeskripsiPekerjaan:  d.DeskripsiPekerjaan,
+			CreatedAt:           now,
+			UpdatedAt:           now,
+			Version:             1,
+		})
+		return err == nil, err
+	}
+
+	// Kolom opsional yang kosong di file tidak menimpa data lama
+	set := bson.M{
+		"bidang_industri":  d.BidangIndustri,
+		"lokasi_kerja":     d.LokasiKerja,
+		"status_pekerjaan": d.StatusPekerjaan,
+		"updated_at":       now,
+	}
+	unset := bson.M{}
+	update := bson.M{"$set": set, "$inc": bson.M{"version": 1}}
+	if d.GajiMin != nil || d.GajiMax != nil {
+		set["gaji_min"], set["gaji_max"] = d.GajiMin, d.GajiMax
+		set["gaji_mata_uang"], set["gaji_periode"] = d.GajiMataUang, d.GajiPeriode
+		unset["gaji_range"] = ""
+	}
+	// Lokasi hasil tebakan tidak menghapus lokasi terstruktur yang sudah diisi manual
+	loc := helper.Location{Negara: d.Negara, Provinsi: d.Provinsi, Kota: d.Kota, Latitude: d.Latitude, Longitude: d.Longitude}
+	if !loc.IsZero() {
+		setLocation(set, unset, loc)
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	if d.TanggalSelesaiKerja != nil {
+		set["tanggal_selesai_kerja"] = *d.TanggalSelesaiKerja
+	}
+	if d.DeskripsiPekerjaan != nil {
+		set["deskripsi_pekerjaan"] = *d.DeskripsiPekerjaan
+	}
+	_, err = collection.UpdateOne(ctx, bson.M{"_id": *r.ID}, update)
+	return false, err
+}
@@ -0,0 +1,244 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go-fiber/app/model/mongo"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongoDB "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Job Repository Functions
+
+func jobContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), 10*time.Second)
+}
+
+// InsertJob -> simpan job baru ke antrean. Mengembalikan false tanpa error bila
+// unique_key sudah dipakai job lain (mis. jadwal cron yang sudah di-enqueue instance lain)
+func InsertJob(db *mongoDB.Database, job *mongo.Job) (bool, error) {
+	ctx, cancel := jobContext()
+	defer cancel()
+
+	result, err := db.Collection("jobs").InsertOne(ctx, job)
+	if err != nil {
+		if mongoDB.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	job.ID = result.InsertedID.(primitive.ObjectID)
+	return true, nil
+}
+
+// ClaimJob -> ambil satu job yang siap dijalankan secara atomik dan kunci untuk worker ini.
+// Job running yang lock-nya kedaluwarsa (worker mati) ikut diambil ulang.
+func ClaimJob(db *mongoDB.Database, workerID string, lock time.Duration) (*mongo.Job, error) {
+	ctx, cancel := jobContext()
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{"$or": []bson.M{
+		{"status": mongo.JobStatusQueued, "run_at": bson.M{"$lte": now}},
+		{"status": mongo.JobStatusRunning, "locked_until": bson.M{"$lt": now}},
+	}}
+	update := bson.M{
+		"$set": bson.M{
+			"status":       mongo.JobStatusRunning,
+			"locked_by":    workerID,
+			"locked_until": now.Add(lock),
+			"started_at":   now,
+			"updated_at":   now,
+		},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "run_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var job mongo.Job
+	if err := db.Collection("jobs").FindOneAndUpdate(ctx, filter, update, opts).Decode(&job); err != nil {
+		if err == mongoDB.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+// updateLockedJob -> update job yang masih dikunci worker ini; false bila job sudah
+// diambil alih atau dibatalkan admin
+func updateLockedJob(db *mongoDB.Database, id primitive.ObjectID, workerID string, update bson.M) (bool, error) {
+	ctx, cancel := jobContext()
+	defer cancel()
+
+	filter := bson.M{"_id": id, "status": mongo.JobStatusRunning, "locked_by": workerID}
+	result, err := db.Collection("jobs").UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// ExtendJobLock -> perpanjang lock job yang sedang berjalan (heartbeat)
+func ExtendJobLock(db *mongoDB.Database, id primitive.ObjectID, workerID string, lock time.Duration) (bool, error) {
+	now := time.Now()
+	return updateLockedJob(db, id, workerID, bson.M{"$set": bson.M{"locked_until": now.Add(lock), "updated_at": now}})
+}
+
+// SaveJobResult -> simpan progress / hasil job yang masih dikerjakan worker ini
+func SaveJobResult(db *mongoDB.Database, id primitive.ObjectID, workerID string, result []byte) error {
+	_, err := updateLockedJob(db, id, workerID, bson.M{"$set": bson.M{"result": result, "updated_at": time.Now()}})
+	return err
+}
+
+// CompleteJob -> tandai job selesai
+func CompleteJob(db *mongoDB.Database, id primitive.ObjectID, workerID string) error {
+	now := time.Now()
+	_, err := updateLockedJob(db, id, workerID, bson.M{
+		"$set":   bson.M{"status": mongo.JobStatusCompleted, "finished_at": now, "updated_at": now},
+		"$unset": bson.M{"locked_by": "", "locked_until": "", "last_error": ""},
+	})
+	return err
+}
+
+// RetryJobLater -> kembalikan job ke antrean dengan jadwal retry berikutnya
+func RetryJobLater(db *mongoDB.Database, id primitive.ObjectID, workerID string, runAt time.Time, lastError string) error {
+	_, err := updateLockedJob(db, id, workerID, bson.M{
+		"$set":   bson.M{"status": mongo.JobStatusQueued, "run_at": runAt, "last_error": lastError, "updated_at": time.Now()},
+		"$unset": bson.M{"locked_by": "", "locked_until": ""},
+	})
+	return err
+}
+
+// DeadLetterJob -> job berhenti dicoba ulang dan menunggu tindakan admin
+func DeadLetterJob(db *mongoDB.Database, id primitive.ObjectID, workerID string, lastError string) error {
+	now := time.Now()
+	_, err := updateLockedJob(db, id, workerID, bson.M{
+		"$set":   bson.M{"status": mongo.JobStatusDead, "last_error": lastError, "finished_at": now, "updated_at": now},
+		"$unset": bson.M{"locked_by": "", "locked_until": ""},
+	})
+	return err
+}
+
+// ReleaseJob -> lepas job yang terputus karena shutdown tanpa menghitung percobaan
+func ReleaseJob(db *mongoDB.Database, id primitive.ObjectID, workerID string) error {
+	_, err := updateLockedJob(db, id, workerID, bson.M{
+		"$set":   bson.M{"status": mongo.JobStatusQueued, "run_at": time.Now(), "updated_at": time.Now()},
+		"$inc":   bson.M{"attempts": -1},
+		"$unset": bson.M{"locked_by": "", "locked_until": ""},
+	})
+	return err
+}
+
+// FindJobs -> daftar job terbaru dengan filter status/type opsional
+func FindJobs(db *mongoDB.Database, status, jobType string, page, limit int) ([]mongo.Job, int, error) {
+	ctx, cancel := jobContext()
+	defer cancel()
+
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+	if jobType != "" {
+		filter["type"] = jobType
+	}
+
+	collection := db.Collection("jobs")
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64((page - 1) * limit)).
+		SetLimit(int64(limit))
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var jobs []mongo.Job
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, 0, err
+	}
+	return jobs, int(total), nil
+}
+
+// FindJobByID -> detail job; nil bila tidak ada
+func FindJobByID(db *mongoDB.Database, id primitive.ObjectID) (*mongo.Job, error) {
+	ctx, cancel := jobContext()
+	defer cancel()
+
+	var job mongo.Job
+	if err := db.Collection("jobs").FindOne(ctx, bson.M{"_id": id}).Decode(&job); err != nil {
+		if err == mongoDB.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+// RequeueJob -> jalankan ulang job dead/cancelled dari awal; nil bila status sudah berubah
+func RequeueJob(db *mongoDB.Database, id primitive.ObjectID) (*mongo.Job, error) {
+	ctx, cancel := jobContext()
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{"_id": id, "status": bson.M{"$in": []string{mongo.JobStatusDead, mongo.JobStatusCancelled}}}
+	update := bson.M{
+		"$set":   bson.M{"status": mongo.JobStatusQueued, "attempts": 0, "run_at": now, "updated_at": now},
+		"$unset": bson.M{"finished_at": "", "last_error": ""},
+	}
+	return findOneAndUpdateJob(ctx, db, filter, update)
+}
+
+// CancelJob -> batalkan job queued/running; worker yang menjalankan akan berhenti
+// saat heartbeat berikutnya. nil bila status sudah berubah
+func CancelJob(db *mongoDB.Database, id primitive.ObjectID) (*mongo.Job, error) {
+	ctx, cancel := jobContext()
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{"_id": id, "status": bson.M{"$in": []string{mongo.JobStatusQueued, mongo.JobStatusRunning}}}
+	update := bson.M{
+		"$set":   bson.M{"status": mongo.JobStatusCancelled, "finished_at": now, "updated_at": now},
+		"$unset": bson.M{"locked_by": "", "locked_until": ""},
+	}
+	return findOneAndUpdateJob(ctx, db, filter, update)
+}
+
+// DeleteFinishedJobs -> hapus job completed/cancelled yang selesai sebelum before.
+// Job dead disimpan agar bisa diperiksa dan di-retry admin.
+func DeleteFinishedJobs(db *mongoDB.Database, before time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	result, err := db.Collection("jobs").DeleteMany(ctx, bson.M{
+		"status":      bson.M{"$in": []string{mongo.JobStatusCompleted, mongo.JobStatusCancelled}},
+		"finished_at": bson.M{"$lt": before},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+func findOneAndUpdateJob(ctx context.Context, db *mongoDB.Database, filter, update bson.M) (*mongo.Job, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var job mongo.Job
+	if err := db.Collection("jobs").FindOneAndUpdate(ctx, filter, update, opts).Decode(&job); err != nil {
+		if err == mongoDB.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &job, nil
+}
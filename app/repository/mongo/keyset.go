@@ -0,0 +1,97 @@
+package mongo
+
+import (
+	"go-fiber/helper"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// AlumniSortTypes -> kolom sort alumni yang bisa dipakai untuk cursor beserta tipe nilainya
+var AlumniSortTypes = map[string]string{
+	"id":          helper.FilterTypeObjectID,
+	"nama":        helper.FilterTypeString,
+	"email":       helper.FilterTypeString,
+	"jurusan":     helper.FilterTypeString,
+	"angkatan":    helper.FilterTypeInt,
+	"tahun_lulus": helper.FilterTypeInt,
+	"created_at":  helper.FilterTypeDate,
+}
+
+// PekerjaanSortTypes -> kolom sort pekerjaan yang bisa dipakai untuk cursor beserta tipe nilainya
+var PekerjaanSortTypes = map[string]string{
+	"id":                  helper.FilterTypeObjectID,
+	"nama_perusahaan":     helper.FilterTypeString,
+	"posisi_jabatan":      helper.FilterTypeString,
+	"bidang_industri":     helper.FilterTypeString,
+	"lokasi_kerja":        helper.FilterTypeString,
+	"tanggal_mulai_kerja": helper.FilterTypeDate,
+	"status_pekerjaan":    helper.FilterTypeString,
+	"created_at":          helper.FilterTypeDate,
+}
+
+// mongoSortField -> "id" pada API adalah "_id" di dokumen
+func mongoSortField(sortBy string) string {
+	if sortBy == "id" {
+		return "_id"
+	}
+	return sortBy
+}
+
+// keysetSort -> urutan (field, _id) searah; dibalik saat mengambil halaman sebelumnya
+func keysetSort(field, order string, keyset *helper.Keyset) bson.D {
+	dir := 1
+	if order == "desc" {
+		dir = -1
+	}
+	if keyset != nil && keyset.Before {
+		dir = -dir
+	}
+	if field == "_id" {
+		return bson.D{{Key: "_id", Value: dir}}
+	}
+	return bson.D{{Key: field, Value: dir}, {Key: "_id", Value: dir}}
+}
+
+// keysetCondition -> dokumen setelah (atau sebelum) posisi cursor pada urutan (field, _id)
+func keysetCondition(field, order string, keyset *helper.Keyset) bson.M {
+	if keyset == nil {
+		return bson.M{}
+	}
+	op := "$gt"
+	if (order == "desc") != keyset.Before {
+		op = "$lt"
+	}
+	if field == "_id" {
+		return bson.M{"_id": bson.M{op: keyset.ID}}
+	}
+	return bson.M{"$or": []bson.M{
+		{field: bson.M{op: keyset.Value}},
+		{field: keyset.Value, "_id": bson.M{op: keyset.ID}},
+	}}
+}
+
+// andFilter -> gabungkan dua filter tanpa membuat $and kosong
+func andFilter(a, b bson.M) bson.M {
+	if len(a) == 0 {
+		return b
+	}
+	if len(b) == 0 {
+		return a
+	}
+	return bson.M{"$and": []bson.M{a, b}}
+}
+
+// trimKeysetPage -> buang item ke-(limit+1) sebagai penanda hasMore, lalu kembalikan
+// urutan asli bila halaman diambil mundur (before)
+func trimKeysetPage[T any](items []T, limit int, keyset *helper.Keyset) ([]T, bool) {
+	hasMore := len(items) > limit
+	if hasMore {
+		items = items[:limit]
+	}
+	if keyset != nil && keyset.Before {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+	return items, hasMore
+}
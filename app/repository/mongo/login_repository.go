@@ -0,0 +1,105 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go-fiber/app/model/mongo"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongoDB "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Login Repository Functions
+
+// RateLimitStore -> helper.RateLimitStore di collection rate_limits agar counter dibagi
+// semua instance. Dokumen kedaluwarsa dihapus TTL index pada expires_at.
+type RateLimitStore struct {
+	db *mongoDB.Database
+}
+
+func NewRateLimitStore(db *mongoDB.Database) *RateLimitStore {
+	return &RateLimitStore{db: db}
+}
+
+type rateLimitDoc struct {
+	Count     int       `bson:"count"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
+
+func (s *RateLimitStore) Hit(key string, window time.Duration) (int, time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Update pipeline: window yang sudah lewat (atau dokumen baru) dimulai ulang dari 1
+	now := time.Now()
+	active := bson.M{"$gt": bson.A{"$expires_at", now}}
+	update := mongoDB.Pipeline{{{Key: "$set", Value: bson.M{
+		"count":      bson.M{"$cond": bson.A{active, bson.M{"$add": bson.A{"$count", 1}}, 1}},
+		"expires_at": bson.M{"$cond": bson.A{active, "$expires_at", now.Add(window)}},
+	}}}}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var doc rateLimitDoc
+	if err := s.db.Collection("rate_limits").FindOneAndUpdate(ctx, bson.M{"_id": key}, update, opts).Decode(&doc); err != nil {
+		return 0, 0, err
+	}
+	return doc.Count, doc.ExpiresAt.Sub(now), nil
+}
+
+func (s *RateLimitStore) Reset(key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := s.db.Collection("rate_limits").DeleteOne(ctx, bson.M{"_id": key})
+	return err
+}
+
+// RecordLoginFailure -> tambah failed_login_attempts secara atomik. Bila mencapai
+// maxFailures, akun dikunci sampai now+lockout dan counter dimulai ulang.
+// Mengembalikan alumni terbaru; nil bila tidak ditemukan
+func RecordLoginFailure(db *mongoDB.Database, id primitive.ObjectID, maxFailures int, lockout time.Duration) (*mongo.Alumni, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	reached := bson.M{"$gte": bson.A{"$failed_login_attempts", maxFailures}}
+	update := mongoDB.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"failed_login_attempts": bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$failed_login_attempts", 0}}, 1}},
+			"last_failed_login_at":  now,
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"locked_until":          bson.M{"$cond": bson.A{reached, now.Add(lockout), "$locked_until"}},
+			"failed_login_attempts": bson.M{"$cond": bson.A{reached, 0, "$failed_login_attempts"}},
+		}}},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var alumni mongo.Alumni
+	if err := db.Collection("alumni").FindOneAndUpdate(ctx, bson.M{"_id": id}, update, opts).Decode(&alumni); err != nil {
+		if err == mongoDB.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &alumni, nil
+}
+
+// ResetLoginFailures -> hapus counter gagal login dan kunci akun (login berhasil atau unlock admin).
+// Mengembalikan false bila alumni tidak ditemukan
+func ResetLoginFailures(db *mongoDB.Database, id primitive.ObjectID) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := db.Collection("alumni").UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set":   bson.M{"failed_login_attempts": 0},
+		"$unset": bson.M{"locked_until": "", "last_failed_login_at": ""},
+	})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
@@ -0,0 +1,101 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongoDB "go.mongodb.org/mongo-driver/mongo"
+)
+
+// MFA Repository Functions
+
+// SetPendingTOTPSecret -> simpan secret enrollment yang belum diverifikasi. Gagal (false)
+// bila alumni tidak ditemukan atau 2FA sudah aktif
+func SetPendingTOTPSecret(db *mongoDB.Database, id primitive.ObjectID, secret string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": id, "totp_enabled": bson.M{"$ne": true}}
+	result, err := db.Collection("alumni").UpdateOne(ctx, filter, bson.M{"$set": bson.M{"totp_pending_secret": secret}})
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// EnableTOTP -> aktifkan 2FA dengan secret enrollment yang sudah diverifikasi. Secret harus
+// masih sama dengan pending secret agar enrollment paralel tidak saling menimpa
+func EnableTOTP(db *mongoDB.Database, id primitive.ObjectID, secret string, step int64, recoveryHashes []string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": id, "totp_pending_secret": secret, "totp_enabled": bson.M{"$ne": true}}
+	update := bson.M{
+		"$set": bson.M{
+			"totp_enabled":   true,
+			"totp_secret":    secret,
+			"totp_last_step": step,
+			"recovery_codes": recoveryHashes,
+		},
+		"$unset": bson.M{"totp_pending_secret": ""},
+	}
+	result, err := db.Collection("alumni").UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// UseTOTPStep -> tandai step TOTP sudah dipakai. false bila step ini (atau yang lebih baru)
+// sudah pernah dipakai, sehingga kode yang sama tidak bisa diputar ulang
+func UseTOTPStep(db *mongoDB.Database, id primitive.ObjectID, step int64) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": id, "totp_enabled": true, "totp_last_step": bson.M{"$not": bson.M{"$gte": step}}}
+	result, err := db.Collection("alumni").UpdateOne(ctx, filter, bson.M{"$set": bson.M{"totp_last_step": step}})
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// ConsumeRecoveryCode -> hapus hash kode pemulihan secara atomik; false bila tidak ada
+func ConsumeRecoveryCode(db *mongoDB.Database, id primitive.ObjectID, codeHash string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": id, "totp_enabled": true, "recovery_codes": codeHash}
+	result, err := db.Collection("alumni").UpdateOne(ctx, filter, bson.M{"$pull": bson.M{"recovery_codes": codeHash}})
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// ReplaceRecoveryCodes -> ganti semua kode pemulihan (kode lama tidak berlaku lagi)
+func ReplaceRecoveryCodes(db *mongoDB.Database, id primitive.ObjectID, recoveryHashes []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := db.Collection("alumni").UpdateOne(ctx, bson.M{"_id": id, "totp_enabled": true},
+		bson.M{"$set": bson.M{"recovery_codes": recoveryHashes}})
+	return err
+}
+
+// DisableTOTP -> hapus secret, kode pemulihan, dan status 2FA
+func DisableTOTP(db *mongoDB.Database, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := db.Collection("alumni").UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$unset": bson.M{
+		"totp_enabled":        "",
+		"totp_secret":         "",
+		"totp_pending_secret": "",
+		"totp_last_step":      "",
+		"recovery_codes":      "",
+	}})
+	return err
+}
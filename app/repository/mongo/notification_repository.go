@@ -0,0 +1,144 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go-fiber/app/model/mongo"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongoDB "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Notification Repository Functions
+
+// InsertEmailOutbox -> simpan email ke outbox. Mengembalikan false tanpa error bila
+// unique_key sudah ada (email yang sama sudah pernah di-enqueue)
+func InsertEmailOutbox(db *mongoDB.Database, email *mongo.EmailOutbox) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := db.Collection("email_outbox").InsertOne(ctx, email)
+	if err != nil {
+		if mongoDB.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	email.ID = result.InsertedID.(primitive.ObjectID)
+	return true, nil
+}
+
+// ClaimEmailOutbox -> ambil satu email yang siap dikirim secara atomik. Email "sending"
+// yang lock-nya kedaluwarsa (dispatcher mati di tengah pengiriman) ikut diambil ulang.
+func ClaimEmailOutbox(db *mongoDB.Database, lock time.Duration) (*mongo.EmailOutbox, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{"$or": []bson.M{
+		{"status": mongo.EmailStatusPending, "next_attempt_at": bson.M{"$lte": now}},
+		{"status": mongo.EmailStatusSending, "locked_until": bson.M{"$lt": now}},
+	}}
+	update := bson.M{
+		"$set": bson.M{"status": mongo.EmailStatusSending, "locked_until": now.Add(lock)},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var email mongo.EmailOutbox
+	if err := db.Collection("email_outbox").FindOneAndUpdate(ctx, filter, update, opts).Decode(&email); err != nil {
+		if err == mongoDB.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &email, nil
+}
+
+func updateEmailOutbox(db *mongoDB.Database, id primitive.ObjectID, update bson.M) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := db.Collection("email_outbox").UpdateOne(ctx, bson.M{"_id": id}, update)
+	return err
+}
+
+// MarkEmailSent -> email terkirim
+func MarkEmailSent(db *mongoDB.Database, id primitive.ObjectID) error {
+	return updateEmailOutbox(db, id, bson.M{
+		"$set":   bson.M{"status": mongo.EmailStatusSent, "sent_at": time.Now()},
+		"$unset": bson.M{"locked_until": "", "last_error": ""},
+	})
+}
+
+// MarkEmailRetry -> kembalikan email ke pending untuk dicoba lagi pada nextAttempt
+func MarkEmailRetry(db *mongoDB.Database, id primitive.ObjectID, nextAttempt time.Time, lastError string) error {
+	return updateEmailOutbox(db, id, bson.M{
+		"$set":   bson.M{"status": mongo.EmailStatusPending, "next_attempt_at": nextAttempt, "last_error": lastError},
+		"$unset": bson.M{"locked_until": ""},
+	})
+}
+
+// MarkEmailFailed -> email berhenti dicoba setelah batas percobaan
+func MarkEmailFailed(db *mongoDB.Database, id primitive.ObjectID, lastError string) error {
+	return updateEmailOutbox(db, id, bson.M{
+		"$set":   bson.M{"status": mongo.EmailStatusFailed, "last_error": lastError},
+		"$unset": bson.M{"locked_until": ""},
+	})
+}
+
+// FindTracerReminderTargets -> alumni yang pekerjaan terakhirnya (updated_at terbaru)
+// diperbarui sebelum cutoff
+func FindTracerReminderTargets(db *mongoDB.Database, cutoff time.Time) ([]mongo.TracerReminderTarget, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	pipeline := mongoDB.Pipeline{
+		{{Key: "$group", Value: bson.M{"_id": "$alumni_id", "last_updated": bson.M{"$max": "$updated_at"}}}},
+		{{Key: "$match", Value: bson.M{"last_updated": bson.M{"$lt": cutoff}}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "alumni",
+			"localField":   "_id",
+			"foreignField": "_id",
+			"as":           "alumni",
+		}}},
+		{{Key: "$unwind", Value: "$alumni"}},
+		{{Key: "$project", Value: bson.M{
+			"nama":         "$alumni.nama",
+			"email":        "$alumni.email",
+			"last_updated": 1,
+		}}},
+	}
+	cursor, err := db.Collection("pekerjaan_alumni").Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var targets []mongo.TracerReminderTarget
+	if err := cursor.All(ctx, &targets); err != nil {
+		return nil, err
+	}
+	return targets, nil
+}
+
+// FindAlumniContact -> nama dan email alumni untuk notifikasi; nil bila tidak ada
+func FindAlumniContact(db *mongoDB.Database, id primitive.ObjectID) (*mongo.Alumni, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var alumni mongo.Alumni
+	opts := options.FindOne().SetProjection(bson.M{"nama": 1, "email": 1})
+	if err := db.Collection("alumni").FindOne(ctx, bson.M{"_id": id}, opts).Decode(&alumni); err != nil {
+		if err == mongoDB.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &alumni, nil
+}
@@ -0,0 +1,58 @@
+package mongo
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"go-fiber/app/model/mongo"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongoDB "go.mongodb.org/mongo-driver/mongo"
+)
+
+// OIDC Repository Functions
+
+func findOneAlumni(db *mongoDB.Database, filter bson.M) (*mongo.Alumni, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var alumni mongo.Alumni
+	if err := db.Collection("alumni").FindOne(ctx, filter).Decode(&alumni); err != nil {
+		if err == mongoDB.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &alumni, nil
+}
+
+// FindAlumniByOIDCSubject -> alumni yang sudah tertaut ke identitas SSO; nil bila belum ada
+func FindAlumniByOIDCSubject(db *mongoDB.Database, issuer, subject string) (*mongo.Alumni, error) {
+	return findOneAlumni(db, bson.M{"oidc_issuer": issuer, "oidc_subject": subject})
+}
+
+// FindAlumniByEmailFold -> cari alumni berdasarkan email tanpa membedakan huruf besar/kecil
+func FindAlumniByEmailFold(db *mongoDB.Database, email string) (*mongo.Alumni, error) {
+	pattern := "^" + regexp.QuoteMeta(email) + "$"
+	return findOneAlumni(db, bson.M{"email": bson.M{"$regex": pattern, "$options": "i"}})
+}
+
+// LinkOIDCIdentity -> tautkan identitas SSO ke alumni. false bila alumni sudah tertaut ke
+// identitas lain (atau tidak ditemukan)
+func LinkOIDCIdentity(db *mongoDB.Database, id primitive.ObjectID, issuer, subject string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": id, "$or": bson.A{
+		bson.M{"oidc_subject": bson.M{"$exists": false}},
+		bson.M{"oidc_issuer": issuer, "oidc_subject": subject},
+	}}
+	update := bson.M{"$set": bson.M{"oidc_issuer": issuer, "oidc_subject": subject}}
+	result, err := db.Collection("alumni").UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
@@ -0,0 +1,108 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go-fiber/app/model/mongo"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongoDB "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Password Repository Functions
+
+// GetAlumniSessionVersion -> session_version alumni; found false bila alumni sudah dihapus
+func GetAlumniSessionVersion(db *mongoDB.Database, id primitive.ObjectID) (int, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var alumni mongo.Alumni
+	opts := options.FindOne().SetProjection(bson.M{"session_version": 1})
+	if err := db.Collection("alumni").FindOne(ctx, bson.M{"_id": id}, opts).Decode(&alumni); err != nil {
+		if err == mongoDB.ErrNoDocuments {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	return alumni.SessionVersion, true, nil
+}
+
+// UpdateAlumniPassword -> simpan hash password baru dan naikkan session_version sehingga
+// semua token lama ditolak. Mengembalikan alumni terbaru; nil bila tidak ditemukan
+func UpdateAlumniPassword(db *mongoDB.Database, id primitive.ObjectID, hashed string) (*mongo.Alumni, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	update := bson.M{
+		"$set": bson.M{"password": hashed, "updated_at": time.Now()},
+		"$inc": bson.M{"session_version": 1},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var alumni mongo.Alumni
+	if err := db.Collection("alumni").FindOneAndUpdate(ctx, bson.M{"_id": id}, update, opts).Decode(&alumni); err != nil {
+		if err == mongoDB.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &alumni, nil
+}
+
+// CreatePasswordReset -> simpan token reset baru; token lama alumni yang belum dipakai dihapus
+// sehingga hanya tautan terakhir yang berlaku
+func CreatePasswordReset(db *mongoDB.Database, reset *mongo.PasswordReset) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := db.Collection("password_resets")
+	if _, err := collection.DeleteMany(ctx, bson.M{"alumni_id": reset.AlumniID, "used_at": nil}); err != nil {
+		return err
+	}
+	result, err := collection.InsertOne(ctx, reset)
+	if err != nil {
+		return err
+	}
+	reset.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindPasswordReset -> token reset yang masih berlaku; nil bila tidak ada, sudah dipakai,
+// atau kedaluwarsa
+func FindPasswordReset(db *mongoDB.Database, tokenHash string) (*mongo.PasswordReset, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"token_hash": tokenHash, "used_at": nil, "expires_at": bson.M{"$gt": time.Now()}}
+	var reset mongo.PasswordReset
+	if err := db.Collection("password_resets").FindOne(ctx, filter).Decode(&reset); err != nil {
+		if err == mongoDB.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &reset, nil
+}
+
+// ConsumePasswordReset -> tandai token terpakai secara atomik. nil bila token tidak ada,
+// sudah dipakai, atau kedaluwarsa
+func ConsumePasswordReset(db *mongoDB.Database, tokenHash string) (*mongo.PasswordReset, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{"token_hash": tokenHash, "used_at": nil, "expires_at": bson.M{"$gt": now}}
+	update := bson.M{"$set": bson.M{"used_at": now}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var reset mongo.PasswordReset
+	if err := db.Collection("password_resets").FindOneAndUpdate(ctx, filter, update, opts).Decode(&reset); err != nil {
+		if err == mongoDB.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &reset, nil
+}
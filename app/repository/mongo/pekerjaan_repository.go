@@ -2,10 +2,12 @@ package mongo
 
 import (
 	"context"
+	"regexp"
 	"strings"
 	"time"
 
 	"go-fiber/app/model/mongo"
+	"go-fiber/helper"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -37,9 +39,14 @@ func GetAllPekerjaan(db *mongoDB.Database) ([]mongo.PekerjaanAlumni, error) {
 }
 
 func GetPekerjaanByID(db *mongoDB.Database, id string) (*mongo.PekerjaanAlumni, error) {
-	collection := db.Collection("pekerjaan_alumni")
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
+	return GetPekerjaanByIDCtx(ctx, db, id)
+}
+
+// GetPekerjaanByIDCtx -> GetPekerjaanByID dengan context dari pemanggil (mis. sesi transaksi batch)
+func GetPekerjaanByIDCtx(ctx context.Context, db *mongoDB.Database, id string) (*mongo.PekerjaanAlumni, error) {
+	collection := db.Collection("pekerjaan_alumni")
 
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
@@ -59,9 +66,14 @@ func GetPekerjaanByID(db *mongoDB.Database, id string) (*mongo.PekerjaanAlumni,
 }
 
 func GetPekerjaanByAlumniID(db *mongoDB.Database, alumniID string) ([]mongo.PekerjaanAlumni, error) {
-	collection := db.Collection("pekerjaan_alumni")
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
+	return GetPekerjaanByAlumniIDCtx(ctx, db, alumniID)
+}
+
+// GetPekerjaanByAlumniIDCtx -> GetPekerjaanByAlumniID dengan context dari pemanggil (mis. sesi transaksi batch)
+func GetPekerjaanByAlumniIDCtx(ctx context.Context, db *mongoDB.Database, alumniID string) ([]mongo.PekerjaanAlumni, error) {
+	collection := db.Collection("pekerjaan_alumni")
 
 	objID, err := primitive.ObjectIDFromHex(alumniID)
 	if err != nil {
@@ -88,24 +100,43 @@ func GetPekerjaanByAlumniID(db *mongoDB.Database, alumniID string) ([]mongo.Peke
 }
 
 func CreatePekerjaan(db *mongoDB.Database, req *mongo.CreatePekerjaanAlumniRepositoryRequest) (*mongo.PekerjaanAlumni, error) {
-	collection := db.Collection("pekerjaan_alumni")
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
+	return CreatePekerjaanCtx(ctx, db, req)
+}
+
+// CreatePekerjaanCtx -> CreatePekerjaan dengan context dari pemanggil (mis. sesi transaksi batch)
+func CreatePekerjaanCtx(ctx context.Context, db *mongoDB.Database, req *mongo.CreatePekerjaanAlumniRepositoryRequest) (*mongo.PekerjaanAlumni, error) {
+	collection := db.Collection("pekerjaan_alumni")
 
 	now := time.Now()
 	pekerjaan := &mongo.PekerjaanAlumni{
 		AlumniID:            req.AlumniID,
 		NamaPerusahaan:      req.NamaPerusahaan,
+		CompanyID:           req.CompanyID,
 		PosisiJabatan:       req.PosisiJabatan,
 		BidangIndustri:      req.BidangIndustri,
+		IndustryID:          req.IndustryID,
 		LokasiKerja:         req.LokasiKerja,
-		GajiRange:           req.GajiRange,
+		Negara:              req.Negara,
+		Provinsi:            req.Provinsi,
+		Kota:                req.Kota,
+		Latitude:            req.Latitude,
+		Longitude:           req.Longitude,
+		Koordinat:           geoPoint(req.Latitude, req.Longitude),
+		GajiMin:             req.GajiMin,
+		GajiMax:             req.GajiMax,
+		GajiMataUang:        req.GajiMataUang,
+		GajiPeriode:         req.GajiPeriode,
+		GajiRahasia:         req.GajiRahasia,
 		TanggalMulaiKerja:   req.TanggalMulaiKerja,
 		TanggalSelesaiKerja: req.TanggalSelesaiKerja,
 		StatusPekerjaan:     req.StatusPekerjaan,
+		ParuhWaktu:          req.ParuhWaktu,
 		DeskripsiPekerjaan:  req.DeskripsiPekerjaan,
 		CreatedAt:           now,
 		UpdatedAt:           now,
+		Version:             1,
 	}
 
 	result, err := collection.InsertOne(ctx, pekerjaan)
@@ -117,40 +148,57 @@ func CreatePekerjaan(db *mongoDB.Database, req *mongo.CreatePekerjaanAlumniRepos
 	return pekerjaan, nil
 }
 
-func UpdatePekerjaan(db *mongoDB.Database, id string, req *mongo.UpdatePekerjaanAlumniRepositoryRequest) (*mongo.PekerjaanAlumni, error) {
-	collection := db.Collection("pekerjaan_alumni")
+// UpdatePekerjaan -> update pekerjaan dan naikkan versinya. expectedVersion (If-Match) dicek di
+// filter update yang sama; helper.ErrVersionConflict bila versinya sudah berubah.
+func UpdatePekerjaan(db *mongoDB.Database, id string, req *mongo.UpdatePekerjaanAlumniRepositoryRequest, expectedVersion *int) (*mongo.PekerjaanAlumni, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
+	return UpdatePekerjaanCtx(ctx, db, id, req, expectedVersion)
+}
+
+// UpdatePekerjaanCtx -> UpdatePekerjaan dengan context dari pemanggil (mis. sesi transaksi batch)
+func UpdatePekerjaanCtx(ctx context.Context, db *mongoDB.Database, id string, req *mongo.UpdatePekerjaanAlumniRepositoryRequest, expectedVersion *int) (*mongo.PekerjaanAlumni, error) {
+	collection := db.Collection("pekerjaan_alumni")
 
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return nil, err
 	}
 
-	update := bson.M{
-		"$set": bson.M{
-			"nama_perusahaan":       req.NamaPerusahaan,
-			"posisi_jabatan":        req.PosisiJabatan,
-			"bidang_industri":       req.BidangIndustri,
-			"lokasi_kerja":          req.LokasiKerja,
-			"gaji_range":            req.GajiRange,
-			"tanggal_mulai_kerja":   req.TanggalMulaiKerja,
-			"tanggal_selesai_kerja": req.TanggalSelesaiKerja,
-			"status_pekerjaan":      req.StatusPekerjaan,
-			"deskripsi_pekerjaan":   req.DeskripsiPekerjaan,
-			"updated_at":            time.Now(),
-		},
+	set := bson.M{
+		"nama_perusahaan":       req.NamaPerusahaan,
+		"company_id":            req.CompanyID,
+		"posisi_jabatan":        req.PosisiJabatan,
+		"bidang_industri":       req.BidangIndustri,
+		"industry_id":           req.IndustryID,
+		"lokasi_kerja":          req.LokasiKerja,
+		"gaji_min":              req.GajiMin,
+		"gaji_max":              req.GajiMax,
+		"gaji_mata_uang":        req.GajiMataUang,
+		"gaji_periode":          req.GajiPeriode,
+		"gaji_rahasia":          req.GajiRahasia,
+		"tanggal_mulai_kerja":   req.TanggalMulaiKerja,
+		"tanggal_selesai_kerja": req.TanggalSelesaiKerja,
+		"status_pekerjaan":      req.StatusPekerjaan,
+		"paruh_waktu":           req.ParuhWaktu,
+		"deskripsi_pekerjaan":   req.DeskripsiPekerjaan,
+		"updated_at":            time.Now(),
 	}
+	unset := bson.M{"gaji_range": ""} // teks gaji lama digantikan gaji terstruktur
+	setLocation(set, unset, helper.Location{Negara: req.Negara, Provinsi: req.Provinsi, Kota: req.Kota, Latitude: req.Latitude, Longitude: req.Longitude})
+	update := bson.M{"$set": set, "$unset": unset, "$inc": bson.M{"version": 1}}
 
-	filter := bson.M{"_id": objID}
-	_, err = collection.UpdateOne(ctx, filter, update)
+	result, err := collection.UpdateOne(ctx, versionFilter(objID, expectedVersion), update)
 	if err != nil {
 		return nil, err
 	}
+	if result.MatchedCount == 0 && expectedVersion != nil {
+		return nil, helper.ErrVersionConflict
+	}
 
 	// Return updated document
 	var pekerjaan mongo.PekerjaanAlumni
-	err = collection.FindOne(ctx, filter).Decode(&pekerjaan)
+	err = collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&pekerjaan)
 	if err != nil {
 		return nil, err
 	}
@@ -158,9 +206,14 @@ func UpdatePekerjaan(db *mongoDB.Database, id string, req *mongo.UpdatePekerjaan
 }
 
 func DeletePekerjaan(db *mongoDB.Database, id string) error {
-	collection := db.Collection("pekerjaan_alumni")
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
+	return DeletePekerjaanCtx(ctx, db, id)
+}
+
+// DeletePekerjaanCtx -> DeletePekerjaan dengan context dari pemanggil (mis. sesi transaksi batch)
+func DeletePekerjaanCtx(ctx context.Context, db *mongoDB.Database, id string) error {
+	collection := db.Collection("pekerjaan_alumni")
 
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
@@ -173,26 +226,18 @@ func DeletePekerjaan(db *mongoDB.Database, id string) error {
 }
 
 // GetPekerjaanRepo -> ambil data pekerjaan alumni dari DB dengan pagination, sorting, dan search
-func GetPekerjaanRepo(db *mongoDB.Database, search, sortBy, order string, limit, offset int) ([]mongo.PekerjaanAlumni, error) {
+func GetPekerjaanRepo(db *mongoDB.Database, search string, filters []helper.Filter, sortBy, order string, limit, offset int) ([]mongo.PekerjaanAlumni, error) {
 	collection := db.Collection("pekerjaan_alumni")
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	filter := bson.M{}
-	if search != "" {
-		filter["$or"] = []bson.M{
-			{"nama_perusahaan": bson.M{"$regex": search, "$options": "i"}},
-			{"posisi_jabatan": bson.M{"$regex": search, "$options": "i"}},
-			{"bidang_industri": bson.M{"$regex": search, "$options": "i"}},
-			{"lokasi_kerja": bson.M{"$regex": search, "$options": "i"}},
-		}
-	}
+	filter := pekerjaanListFilter(search, filters)
 
-	sortOrder := 1
+	sortOrder := "asc"
 	if strings.ToLower(order) == "desc" {
-		sortOrder = -1
+		sortOrder = "desc"
 	}
-	sort := bson.D{{Key: sortBy, Value: sortOrder}}
+	sort := keysetSort(mongoSortField(sortBy), sortOrder, nil)
 
 	pipeline := mongoDB.Pipeline{
 		{{Key: "$match", Value: filter}},
@@ -217,24 +262,247 @@ func GetPekerjaanRepo(db *mongoDB.Database, search, sortBy, order string, limit,
 }
 
 // CountPekerjaanRepo -> hitung total data untuk pagination
-func CountPekerjaanRepo(db *mongoDB.Database, search string) (int, error) {
+func CountPekerjaanRepo(db *mongoDB.Database, search string, filters []helper.Filter) (int, error) {
+	collection := db.Collection("pekerjaan_alumni")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	count, err := collection.CountDocuments(ctx, pekerjaanListFilter(search, filters))
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// GetPekerjaanKeysetRepo -> listing pekerjaan dengan keyset pagination pada (sortBy, _id).
+// $sort dan $limit dijalankan sebelum $lookup agar memakai index dan tidak memproses seluruh koleksi.
+func GetPekerjaanKeysetRepo(db *mongoDB.Database, search string, filters []helper.Filter, sortBy, order string, keyset *helper.Keyset, limit int) ([]mongo.PekerjaanAlumni, bool, error) {
 	collection := db.Collection("pekerjaan_alumni")
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	field := mongoSortField(sortBy)
+	filter := andFilter(pekerjaanListFilter(search, filters), keysetCondition(field, order, keyset))
+
+	pipeline := mongoDB.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$sort", Value: keysetSort(field, order, keyset)}},
+		{{Key: "$limit", Value: limit + 1}},
+		{{Key: "$lookup", Value: bson.D{{Key: "from", Value: "alumni"}, {Key: "localField", Value: "alumni_id"}, {Key: "foreignField", Value: "_id"}, {Key: "as", Value: "alumniData"}}}},
+		{{Key: "$unwind", Value: bson.D{{Key: "path", Value: "$alumniData"}}}},
+	}
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, false, err
+	}
+	defer cursor.Close(ctx)
+
+	var pekerjaan []mongo.PekerjaanAlumni
+	if err = cursor.All(ctx, &pekerjaan); err != nil {
+		return nil, false, err
+	}
+	pekerjaan, hasMore := trimKeysetPage(pekerjaan, limit, keyset)
+	return pekerjaan, hasMore, nil
+}
+
+// StreamPekerjaanRepo -> seluruh pekerjaan yang cocok dengan search dan filter beserta NIM dan nama
+// alumninya, diteruskan satu per satu ke fn (untuk export)
+func StreamPekerjaanRepo(db *mongoDB.Database, search string, filters []helper.Filter, sortBy, order string, fn func(mongo.PekerjaanExportRow) error) error {
+	collection := db.Collection("pekerjaan_alumni")
+	ctx, cancel := context.WithTimeout(context.Background(), streamTimeout)
+	defer cancel()
+
+	pipeline := mongoDB.Pipeline{
+		{{Key: "$match", Value: pekerjaanListFilter(search, filters)}},
+		{{Key: "$sort", Value: keysetSort(mongoSortField(sortBy), order, nil)}},
+		{{Key: "$lookup", Value: bson.D{{Key: "from", Value: "alumni"}, {Key: "localField", Value: "alumni_id"}, {Key: "foreignField", Value: "_id"}, {Key: "as", Value: "alumniData"}}}},
+		{{Key: "$unwind", Value: bson.D{{Key: "path", Value: "$alumniData"}, {Key: "preserveNullAndEmptyArrays", Value: true}}}},
+		{{Key: "$addFields", Value: bson.D{{Key: "nim", Value: "$alumniData.nim"}, {Key: "nama_alumni", Value: "$alumniData.nama"}}}},
+		{{Key: "$project", Value: bson.D{{Key: "alumniData", Value: 0}}}},
+	}
+
+	opts := options.Aggregate().SetAllowDiskUse(true).SetBatchSize(streamBatchSize)
+	cursor, err := collection.Aggregate(ctx, pipeline, opts)
+	if err != nil {
+		return err
+	}
+	return streamCursor(ctx, cursor, fn)
+}
+
+// pekerjaanListFilter -> filter search (regex ter-escape) digabung dengan filter query language
+func pekerjaanListFilter(search string, filters []helper.Filter) bson.M {
 	filter := bson.M{}
 	if search != "" {
+		pattern := regexp.QuoteMeta(search)
 		filter["$or"] = []bson.M{
-			{"nama_perusahaan": bson.M{"$regex": search, "$options": "i"}},
-			{"posisi_jabatan": bson.M{"$regex": search, "$options": "i"}},
-			{"bidang_industri": bson.M{"$regex": search, "$options": "i"}},
-			{"lokasi_kerja": bson.M{"$regex": search, "$options": "i"}},
+			{"nama_perusahaan": bson.M{"$regex": pattern, "$options": "i"}},
+			{"posisi_jabatan": bson.M{"$regex": pattern, "$options": "i"}},
+			{"bidang_industri": bson.M{"$regex": pattern, "$options": "i"}},
+			{"lokasi_kerja": bson.M{"$regex": pattern, "$options": "i"}},
 		}
 	}
+	return withFilters(filter, filters)
+}
+
+// SearchPekerjaanRepo -> full-text search memakai text index pekerjaan_alumni, diurutkan berdasarkan textScore
+func SearchPekerjaanRepo(db *mongoDB.Database, query string, limit, offset int) ([]mongo.PekerjaanSearchResult, error) {
+	collection := db.Collection("pekerjaan_alumni")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
 
-	count, err := collection.CountDocuments(ctx, filter)
+	score := bson.M{"$meta": "textScore"}
+	opts := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}, {Key: "_id", Value: 1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	cursor, err := collection.Find(ctx, bson.M{"$text": bson.M{"$search": query}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []mongo.PekerjaanSearchResult
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// CountSearchPekerjaanRepo -> hitung total hasil full-text search pekerjaan
+func CountSearchPekerjaanRepo(db *mongoDB.Database, query string) (int, error) {
+	collection := db.Collection("pekerjaan_alumni")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	count, err := collection.CountDocuments(ctx, bson.M{"$text": bson.M{"$search": query}})
 	if err != nil {
 		return 0, err
 	}
 	return int(count), nil
 }
+
+// ListLegacySalaryPekerjaan -> id dan teks gaji_range pekerjaan yang belum punya gaji terstruktur
+func ListLegacySalaryPekerjaan(ctx context.Context, db *mongoDB.Database) (map[primitive.ObjectID]string, error) {
+	filter := bson.M{
+		"gaji_range": bson.M{"$type": "string", "$ne": ""},
+		"gaji_min":   nil,
+		"gaji_max":   nil,
+	}
+	opts := options.Find().SetProjection(bson.M{"gaji_range": 1})
+	cursor, err := db.Collection("pekerjaan_alumni").Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	legacy := map[primitive.ObjectID]string{}
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID        primitive.ObjectID `bson:"_id"`
+			GajiRange string             `bson:"gaji_range"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		legacy[doc.ID] = doc.GajiRange
+	}
+	return legacy, cursor.Err()
+}
+
+// MigratePekerjaanSalary -> simpan gaji hasil parsing teks lama dan hapus gaji_range. Dokumen yang
+// sudah diubah sejak dibaca (gaji_range berbeda) tidak disentuh.
+func MigratePekerjaanSalary(ctx context.Context, db *mongoDB.Database, id primitive.ObjectID, legacy string, salary helper.Salary) (bool, error) {
+	update := bson.M{
+		"$set": bson.M{
+			"gaji_min":       salary.Min,
+			"gaji_max":       salary.Max,
+			"gaji_mata_uang": salary.Currency,
+			"gaji_periode":   salary.Period,
+			"updated_at":     time.Now(),
+		},
+		"$unset": bson.M{"gaji_range": ""},
+		"$inc":   bson.M{"version": 1},
+	}
+	result, err := db.Collection("pekerjaan_alumni").UpdateOne(ctx, bson.M{"_id": id, "gaji_range": legacy}, update)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// geoPoint -> GeoJSON Point untuk index 2dsphere koordinat; nil bila koordinat kosong
+func geoPoint(lat, lng *float64) *mongo.GeoPoint {
+	if lat == nil || lng == nil {
+		return nil
+	}
+	return &mongo.GeoPoint{Type: "Point", Coordinates: []float64{*lng, *lat}}
+}
+
+// setLocation -> field lokasi terstruktur untuk update; field kosong masuk $unset (bukan null)
+// agar sama dengan dokumen baru yang memakai omitempty
+func setLocation(set, unset bson.M, loc helper.Location) {
+	put := func(field string, empty bool, value interface{}) {
+		if empty {
+			unset[field] = ""
+		} else {
+			set[field] = value
+		}
+	}
+	put("negara", loc.Negara == nil, loc.Negara)
+	put("provinsi", loc.Provinsi == nil, loc.Provinsi)
+	put("kota", loc.Kota == nil, loc.Kota)
+	put("latitude", loc.Latitude == nil, loc.Latitude)
+	put("longitude", loc.Longitude == nil, loc.Longitude)
+	put("koordinat", loc.Latitude == nil || loc.Longitude == nil, geoPoint(loc.Latitude, loc.Longitude))
+}
+
+// ListUnlocatedPekerjaan -> id dan lokasi_kerja pekerjaan yang belum punya lokasi terstruktur
+func ListUnlocatedPekerjaan(ctx context.Context, db *mongoDB.Database) (map[primitive.ObjectID]string, error) {
+	filter := bson.M{
+		"lokasi_kerja": bson.M{"$type": "string", "$ne": ""},
+		"negara":       nil,
+		"provinsi":     nil,
+		"kota":         nil,
+		"latitude":     nil,
+	}
+	opts := options.Find().SetProjection(bson.M{"lokasi_kerja": 1})
+	cursor, err := db.Collection("pekerjaan_alumni").Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	unlocated := map[primitive.ObjectID]string{}
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID          primitive.ObjectID `bson:"_id"`
+			LokasiKerja string             `bson:"lokasi_kerja"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		unlocated[doc.ID] = doc.LokasiKerja
+	}
+	return unlocated, cursor.Err()
+}
+
+// BackfillPekerjaanLocation -> simpan lokasi hasil tebakan dari lokasi_kerja. Dokumen yang sudah
+// diubah sejak dibaca (lokasi_kerja berbeda atau lokasi sudah terisi) tidak disentuh.
+func BackfillPekerjaanLocation(ctx context.Context, db *mongoDB.Database, id primitive.ObjectID, lokasiKerja string, loc helper.Location) (bool, error) {
+	set := bson.M{"updated_at": time.Now()}
+	unset := bson.M{}
+	setLocation(set, unset, loc)
+	update := bson.M{"$set": set, "$inc": bson.M{"version": 1}}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	filter := bson.M{"_id": id, "lokasi_kerja": lokasiKerja, "negara": nil, "provinsi": nil, "kota": nil, "latitude": nil}
+	result, err := db.Collection("pekerjaan_alumni").UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
@@ -0,0 +1,179 @@
+package mongo
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"go-fiber/app/model/mongo"
+	"go-fiber/helper"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongoDB "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ListReferences -> semua perusahaan / bidang industri urut nama; search dicocokkan dengan nama
+// dan alias yang sudah dinormalisasi
+func ListReferences(db *mongoDB.Database, kind helper.ReferenceKind, search string) ([]mongo.ReferenceData, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if key := helper.NormalizeReferenceName(search); key != "" {
+		filter["keys"] = bson.M{"$regex": regexp.QuoteMeta(key)}
+	}
+	cursor, err := db.Collection(kind.Name).Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "nama", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	refs := []mongo.ReferenceData{}
+	if err := cursor.All(ctx, &refs); err != nil {
+		return nil, err
+	}
+	return refs, nil
+}
+
+func GetReferenceByID(db *mongoDB.Database, kind helper.ReferenceKind, id string) (*mongo.ReferenceData, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	return GetReferenceByIDCtx(ctx, db, kind, objID)
+}
+
+// GetReferenceByIDCtx -> nil, nil bila tidak ditemukan
+func GetReferenceByIDCtx(ctx context.Context, db *mongoDB.Database, kind helper.ReferenceKind, id primitive.ObjectID) (*mongo.ReferenceData, error) {
+	return findReference(ctx, db, kind, bson.M{"_id": id})
+}
+
+// GetReferenceByKeyCtx -> data referensi yang nama atau aliasnya berkunci key; nil, nil bila tidak ada
+func GetReferenceByKeyCtx(ctx context.Context, db *mongoDB.Database, kind helper.ReferenceKind, key string) (*mongo.ReferenceData, error) {
+	return findReference(ctx, db, kind, bson.M{"keys": key})
+}
+
+// GetReferenceConflict -> data referensi lain (selain exclude) yang memakai salah satu keys
+func GetReferenceConflict(db *mongoDB.Database, kind helper.ReferenceKind, keys []string, exclude primitive.ObjectID) (*mongo.ReferenceData, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return findReference(ctx, db, kind, bson.M{"keys": bson.M{"$in": keys}, "_id": bson.M{"$ne": exclude}})
+}
+
+func findReference(ctx context.Context, db *mongoDB.Database, kind helper.ReferenceKind, filter bson.M) (*mongo.ReferenceData, error) {
+	var ref mongo.ReferenceData
+	err := db.Collection(kind.Name).FindOne(ctx, filter).Decode(&ref)
+	if err != nil {
+		if err == mongoDB.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ref, nil
+}
+
+func CreateReference(db *mongoDB.Database, kind helper.ReferenceKind, ref *mongo.ReferenceData) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return CreateReferenceCtx(ctx, db, kind, ref)
+}
+
+// CreateReferenceCtx -> simpan ref dan isi ID serta timestamp-nya
+func CreateReferenceCtx(ctx context.Context, db *mongoDB.Database, kind helper.ReferenceKind, ref *mongo.ReferenceData) error {
+	now := time.Now()
+	ref.CreatedAt, ref.UpdatedAt = now, now
+	result, err := db.Collection(kind.Name).InsertOne(ctx, ref)
+	if err != nil {
+		return err
+	}
+	ref.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// UpdateReferenceCtx -> simpan nama, alias, dan keys ref
+func UpdateReferenceCtx(ctx context.Context, db *mongoDB.Database, kind helper.ReferenceKind, ref *mongo.ReferenceData) error {
+	ref.UpdatedAt = time.Now()
+	_, err := db.Collection(kind.Name).UpdateOne(ctx, bson.M{"_id": ref.ID}, bson.M{"$set": bson.M{
+		"nama":       ref.Nama,
+		"aliases":    ref.Aliases,
+		"keys":       ref.Keys,
+		"updated_at": ref.UpdatedAt,
+	}})
+	return err
+}
+
+func DeleteReference(db *mongoDB.Database, kind helper.ReferenceKind, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return DeleteReferencesCtx(ctx, db, kind, []primitive.ObjectID{id})
+}
+
+func DeleteReferencesCtx(ctx context.Context, db *mongoDB.Database, kind helper.ReferenceKind, ids []primitive.ObjectID) error {
+	_, err := db.Collection(kind.Name).DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	return err
+}
+
+// RelinkPekerjaanReferenceCtx -> pekerjaan yang terhubung ke salah satu from dipindahkan ke ref dan
+// teks bebasnya diganti nama ref (dipakai saat rename dan merge)
+func RelinkPekerjaanReferenceCtx(ctx context.Context, db *mongoDB.Database, kind helper.ReferenceKind, from []primitive.ObjectID, ref *mongo.ReferenceData) (int64, error) {
+	return linkPekerjaan(ctx, db, kind, bson.M{kind.IDField: bson.M{"$in": from}}, ref)
+}
+
+// LinkPekerjaanByTextCtx -> hubungkan pekerjaan tanpa referensi yang teks bebasnya persis text ke ref
+func LinkPekerjaanByTextCtx(ctx context.Context, db *mongoDB.Database, kind helper.ReferenceKind, text string, ref *mongo.ReferenceData) (int64, error) {
+	return linkPekerjaan(ctx, db, kind, bson.M{kind.IDField: nil, kind.TextField: text}, ref)
+}
+
+func linkPekerjaan(ctx context.Context, db *mongoDB.Database, kind helper.ReferenceKind, filter bson.M, ref *mongo.ReferenceData) (int64, error) {
+	result, err := db.Collection("pekerjaan_alumni").UpdateMany(ctx, filter, bson.M{
+		"$set": bson.M{kind.IDField: ref.ID, kind.TextField: ref.Nama, "updated_at": time.Now()},
+		"$inc": bson.M{"version": 1},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// UnlinkPekerjaanReference -> lepas pekerjaan dari ref yang akan dihapus; teks bebasnya tetap
+func UnlinkPekerjaanReference(db *mongoDB.Database, kind helper.ReferenceKind, id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := db.Collection("pekerjaan_alumni").UpdateMany(ctx, bson.M{kind.IDField: id}, bson.M{
+		"$unset": bson.M{kind.IDField: ""},
+		"$inc":   bson.M{"version": 1},
+	})
+	return err
+}
+
+// UnlinkedPekerjaanTextsCtx -> teks bebas pekerjaan yang belum terhubung ke referensi, yang paling
+// sering dipakai lebih dulu
+func UnlinkedPekerjaanTextsCtx(ctx context.Context, db *mongoDB.Database, kind helper.ReferenceKind) ([]string, error) {
+	pipeline := mongoDB.Pipeline{
+		{{Key: "$match", Value: bson.M{kind.IDField: nil, kind.TextField: bson.M{"$nin": bson.A{"", nil}}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$" + kind.TextField, "count": bson.M{"$sum": 1}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+	}
+	cursor, err := db.Collection("pekerjaan_alumni").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		Text string `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+	texts := make([]string, len(rows))
+	for i, row := range rows {
+		texts[i] = row.Text
+	}
+	return texts, nil
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"go-fiber/app/model/mongo"
+	"go-fiber/helper"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -17,7 +18,8 @@ func CreateRole(db *mongoDB.Database, req *mongo.CreateRoleRequest) (*mongo.Role
 	defer cancel()
 
 	role := &mongo.Role{
-		Name: req.Name,
+		Name:    req.Name,
+		Version: 1,
 	}
 
 	result, err := collection.InsertOne(ctx, role)
@@ -103,7 +105,9 @@ func ListRoles(db *mongoDB.Database) ([]mongo.Role, error) {
 	return roles, nil
 }
 
-func UpdateRole(db *mongoDB.Database, id string, req *mongo.UpdateRoleRequest) (*mongo.Role, error) {
+// UpdateRole -> update role dan naikkan versinya. expectedVersion (If-Match) dicek di filter
+// update yang sama; helper.ErrVersionConflict bila versinya sudah berubah.
+func UpdateRole(db *mongoDB.Database, id string, req *mongo.UpdateRoleRequest, expectedVersion *int) (*mongo.Role, error) {
 	collection := db.Collection("roles")
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -123,15 +127,17 @@ func UpdateRole(db *mongoDB.Database, id string, req *mongo.UpdateRoleRequest) (
 		return GetRoleByID(db, id)
 	}
 
-	filter := bson.M{"_id": objID}
-	_, err = collection.UpdateOne(ctx, filter, bson.M{"$set": update})
+	result, err := collection.UpdateOne(ctx, versionFilter(objID, expectedVersion), bson.M{"$set": update, "$inc": bson.M{"version": 1}})
 	if err != nil {
 		return nil, err
 	}
+	if result.MatchedCount == 0 && expectedVersion != nil {
+		return nil, helper.ErrVersionConflict
+	}
 
 	// Return updated document
 	var role mongo.Role
-	err = collection.FindOne(ctx, filter).Decode(&role)
+	err = collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&role)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,29 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	mongoDB "go.mongodb.org/mongo-driver/mongo"
+)
+
+// streamTimeout -> batas waktu satu query streaming (export), jauh di atas query listing biasa
+const streamTimeout = 30 * time.Minute
+
+// streamBatchSize -> jumlah dokumen per batch cursor saat streaming
+const streamBatchSize = 500
+
+// streamCursor -> decode dokumen satu per satu lalu teruskan ke fn tanpa menampung semuanya di memori
+func streamCursor[T any](ctx context.Context, cursor *mongoDB.Cursor, fn func(T) error) error {
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		var item T
+		if err := cursor.Decode(&item); err != nil {
+			return err
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
@@ -0,0 +1,27 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	mongoDB "go.mongodb.org/mongo-driver/mongo"
+)
+
+// RunInTransaction -> jalankan fn dalam satu transaksi MongoDB (butuh replica set atau mongos).
+// Semua operasi di fn harus memakai ctx yang diberikan agar ikut transaksi; fn bisa dipanggil
+// ulang bila transaksi dicoba lagi karena error sementara.
+func RunInTransaction(db *mongoDB.Database, fn func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	session, err := db.Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongoDB.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
@@ -0,0 +1,20 @@
+package mongo
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// versionFilter -> filter update bersyarat: _id dan versi yang diharapkan (nil berarti tanpa
+// syarat). Dokumen lama yang belum punya field version dianggap versi 0.
+func versionFilter(id primitive.ObjectID, expected *int) bson.M {
+	filter := bson.M{"_id": id}
+	if expected != nil {
+		if *expected == 0 {
+			filter["version"] = bson.M{"$in": bson.A{0, nil}}
+		} else {
+			filter["version"] = *expected
+		}
+	}
+	return filter
+}
@@ -0,0 +1,82 @@
+package postgre
+
+import (
+	"database/sql"
+
+	model "go-fiber/app/model/postgre"
+)
+
+// Account Deletion Request Repository Functions
+
+const accountDeletionColumns = `id, alumni_id, nim, nama, alasan, status, catatan, processed_by, processed_at, created_at`
+
+func scanAccountDeletion(row interface{ Scan(...any) error }) (*model.AccountDeletionRequest, error) {
+	var req model.AccountDeletionRequest
+	err := row.Scan(&req.ID, &req.AlumniID, &req.NIM, &req.Nama, &req.Alasan, &req.Status, &req.Catatan,
+		&req.ProcessedBy, &req.ProcessedAt, &req.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// CreateAccountDeletion -> simpan permintaan hapus akun berstatus pending. Mengembalikan false tanpa
+// error bila alumni sudah punya permintaan pending (unique index parsial)
+func CreateAccountDeletion(db *sql.DB, req *model.AccountDeletionRequest) (bool, error) {
+	query := `INSERT INTO account_deletion_requests (alumni_id, nim, nama, alasan)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (alumni_id) WHERE status = 'pending' DO NOTHING
+		RETURNING ` + accountDeletionColumns
+	created, err := scanAccountDeletion(db.QueryRow(query, req.AlumniID, req.NIM, req.Nama, req.Alasan))
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	*req = *created
+	return true, nil
+}
+
+// GetPendingAccountDeletion -> permintaan hapus akun alumni yang masih pending (sql.ErrNoRows bila tidak ada)
+func GetPendingAccountDeletion(db *sql.DB, alumniID int) (*model.AccountDeletionRequest, error) {
+	query := `SELECT ` + accountDeletionColumns + ` FROM account_deletion_requests
+		WHERE alumni_id = $1 AND status = 'pending'`
+	return scanAccountDeletion(db.QueryRow(query, alumniID))
+}
+
+// GetAccountDeletionByID -> satu permintaan hapus akun (sql.ErrNoRows bila tidak ditemukan)
+func GetAccountDeletionByID(db DBTX, id int) (*model.AccountDeletionRequest, error) {
+	return scanAccountDeletion(db.QueryRow(`SELECT `+accountDeletionColumns+` FROM account_deletion_requests WHERE id = $1`, id))
+}
+
+// ListAccountDeletions -> permintaan hapus akun, terbaru lebih dulu; status kosong berarti semua
+func ListAccountDeletions(db *sql.DB, status string) ([]model.AccountDeletionRequest, error) {
+	rows, err := db.Query(`SELECT `+accountDeletionColumns+` FROM account_deletion_requests
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY created_at DESC, id DESC`, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	requests := []model.AccountDeletionRequest{}
+	for rows.Next() {
+		req, err := scanAccountDeletion(rows)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, *req)
+	}
+	return requests, rows.Err()
+}
+
+// FinishAccountDeletion -> tandai permintaan pending sebagai approved / rejected (sql.ErrNoRows bila
+// permintaan tidak ada atau sudah diproses)
+func FinishAccountDeletion(db DBTX, id int, status string, processedBy int, catatan *string) (*model.AccountDeletionRequest, error) {
+	query := `UPDATE account_deletion_requests
+		SET status = $2, processed_by = $3, catatan = $4, processed_at = NOW()
+		WHERE id = $1 AND status = 'pending'
+		RETURNING ` + accountDeletionColumns
+	return scanAccountDeletion(db.QueryRow(query, id, status, processedBy, catatan))
+}
@@ -4,7 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	model "go-fiber/app/model/postgre"
-	"log"
+	"go-fiber/helper"
 	"strings"
 	"time"
 )
@@ -31,55 +31,64 @@ func GetAllAlumni(db *sql.DB) ([]model.Alumni, error) {
 	return alumni, nil
 }
 
-// GetAlumniRepo -> ambil data alumni dari DB dengan pagination, sorting, dan search
-func GetAlumniRepo(db *sql.DB, search, sortBy, order string, limit, offset int) ([]model.Alumni, error) {
-	// Debug logging
-	log.Printf("=== GetAlumniRepo Debug ===")
-	log.Printf("Search parameters - search: '%s' (len: %d), sortBy: '%s', order: '%s', limit: %d, offset: %d", search, len(search), sortBy, order, limit, offset)
-	log.Printf("Search is empty: %t", search == "")
+// GetAlumniRepo -> ambil data alumni dari DB dengan pagination, sorting, search, dan filter
+func GetAlumniRepo(db *sql.DB, search string, filters []helper.Filter, sortBy, order string, limit, offset int) ([]model.Alumni, error) {
+	conditions, args := alumniConditions(search, filters)
+	args = append(args, limit, offset)
 
-	// Buat query yang lebih sederhana untuk testing
-	var query string
-	var args []interface{}
-
-	if search == "" {
-		// Jika search kosong, tampilkan semua data
-		query = fmt.Sprintf(`
-            SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, created_at, updated_at
-			FROM alumni
-			ORDER BY %s %s
-			LIMIT $1 OFFSET $2
-		`, sortBy, order)
-		args = []interface{}{limit, offset}
-		log.Printf("Using query without search filter")
-	} else {
-		// Jika ada search, gunakan filter
-		searchPattern := "%" + search + "%"
-		query = fmt.Sprintf(`
-            SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, created_at, updated_at
-			FROM alumni
-			WHERE nama ILIKE $1 OR email ILIKE $1 OR jurusan ILIKE $1 OR nim ILIKE $1
-			ORDER BY %s %s
-			LIMIT $2 OFFSET $3
-		`, sortBy, order)
-		args = []interface{}{searchPattern, limit, offset}
-		log.Printf("Using query with search filter - pattern: '%s'", searchPattern)
-	}
-
-	log.Printf("Final query: %s", query)
-	log.Printf("Query parameters: %v", args)
+	query := fmt.Sprintf(`
+		SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, created_at, updated_at, version
+		FROM alumni
+		%s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d
+	`, whereSQL(conditions), keysetOrder(sortBy, order, nil), len(args)-1, len(args))
 
 	rows, err := db.Query(query, args...)
 	if err != nil {
-		log.Printf("Query error: %v", err)
 		return nil, err
 	}
 	defer rows.Close()
 
+	return scanAlumniRows(rows)
+}
+
+// GetAlumniKeysetRepo -> listing alumni dengan keyset pagination pada (sortBy, id), tanpa OFFSET.
+// Mengembalikan hasMore = masih ada data ke arah pengambilan.
+func GetAlumniKeysetRepo(db *sql.DB, search string, filters []helper.Filter, sortBy, order string, keyset *helper.Keyset, limit int) ([]model.Alumni, bool, error) {
+	conditions, args := alumniConditions(search, filters)
+	if clause, keysetArgs := keysetClause(sortBy, order, keyset, len(args)+1); clause != "" {
+		conditions = append(conditions, clause)
+		args = append(args, keysetArgs...)
+	}
+	args = append(args, limit+1)
+
+	query := fmt.Sprintf(`
+		SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, created_at, updated_at, version
+		FROM alumni
+		%s
+		ORDER BY %s
+		LIMIT $%d
+	`, whereSQL(conditions), keysetOrder(sortBy, order, keyset), len(args))
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	alumni, err := scanAlumniRows(rows)
+	if err != nil {
+		return nil, false, err
+	}
+	alumni, hasMore := trimKeysetPage(alumni, limit, keyset)
+	return alumni, hasMore, nil
+}
+
+func scanAlumniRows(rows *sql.Rows) ([]model.Alumni, error) {
 	var alumni []model.Alumni
 	for rows.Next() {
-		var a model.Alumni
-		err := rows.Scan(&a.ID, &a.NIM, &a.Nama, &a.Jurusan, &a.Angkatan, &a.TahunLulus, &a.Email, &a.NoTelepon, &a.Alamat, &a.CreatedAt, &a.UpdatedAt)
+		a, err := scanAlumni(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -88,70 +97,184 @@ func GetAlumniRepo(db *sql.DB, search, sortBy, order string, limit, offset int)
 	return alumni, nil
 }
 
+func scanAlumni(rows *sql.Rows) (model.Alumni, error) {
+	var a model.Alumni
+	err := rows.Scan(&a.ID, &a.NIM, &a.Nama, &a.Jurusan, &a.Angkatan, &a.TahunLulus, &a.Email, &a.NoTelepon, &a.Alamat, &a.CreatedAt, &a.UpdatedAt, &a.Version)
+	return a, err
+}
+
+// StreamAlumniRepo -> seluruh alumni yang cocok dengan search dan filter, diurutkan seperti listing,
+// diteruskan satu per satu ke fn (untuk export)
+func StreamAlumniRepo(db *sql.DB, search string, filters []helper.Filter, sortBy, order string, fn func(model.Alumni) error) error {
+	conditions, args := alumniConditions(search, filters)
+	query := fmt.Sprintf(`
+		SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, created_at, updated_at, version
+		FROM alumni
+		%s
+		ORDER BY %s
+	`, whereSQL(conditions), keysetOrder(sortBy, order, nil))
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	return streamRows(rows, scanAlumni, fn)
+}
+
 // CountAlumniRepo -> hitung total data untuk pagination
-func CountAlumniRepo(db *sql.DB, search string) (int, error) {
+func CountAlumniRepo(db *sql.DB, search string, filters []helper.Filter) (int, error) {
 	var total int
-	var countQuery string
+	conditions, args := alumniConditions(search, filters)
+
+	err := db.QueryRow(`SELECT COUNT(*) FROM alumni `+whereSQL(conditions), args...).Scan(&total)
+	if err != nil && err != sql.ErrNoRows {
+		return 0, err
+	}
+	return total, nil
+}
+
+// alumniConditions -> kondisi search (ILIKE) dan filter query language
+func alumniConditions(search string, filters []helper.Filter) ([]string, []interface{}) {
+	var conditions []string
 	var args []interface{}
 
-	if search == "" {
-		countQuery = `SELECT COUNT(*) FROM alumni`
-		args = []interface{}{}
-	} else {
-		countQuery = `SELECT COUNT(*) FROM alumni WHERE nama ILIKE $1 OR email ILIKE $1 OR jurusan ILIKE $1 OR nim ILIKE $1`
-		args = []interface{}{"%" + search + "%"}
+	if search != "" {
+		conditions = append(conditions, "(nama ILIKE $1 OR email ILIKE $1 OR jurusan ILIKE $1 OR nim ILIKE $1)")
+		args = append(args, "%"+search+"%")
 	}
 
-	err := db.QueryRow(countQuery, args...).Scan(&total)
+	filterConditions, filterArgs := buildSQLFilter(filters, len(args)+1)
+	conditions = append(conditions, filterConditions...)
+	args = append(args, filterArgs...)
+	return conditions, args
+}
+
+// searchHeadlineOptions -> penanda highlight untuk ts_headline
+const searchHeadlineOptions = "StartSel=<em>, StopSel=</em>, HighlightAll=true"
+
+// SearchAlumniRepo -> full-text search memakai search_vector (GIN), diurutkan berdasarkan ts_rank_cd
+func SearchAlumniRepo(db *sql.DB, query string, limit, offset int) ([]model.AlumniSearchResult, error) {
+	sqlQuery := `
+		SELECT a.id, a.nim, a.nama, a.jurusan, a.angkatan, a.tahun_lulus, a.email, a.no_telepon, a.alamat, a.created_at, a.updated_at,
+			ts_rank_cd(a.search_vector, q) AS score,
+			ts_headline('idn_unaccent', coalesce(a.nama, ''), q, $4),
+			ts_headline('simple', coalesce(a.nim, ''), q, $4),
+			ts_headline('simple', coalesce(a.email, ''), q, $4),
+			ts_headline('idn_unaccent', coalesce(a.jurusan, ''), q, $4)
+		FROM alumni a, websearch_to_tsquery('idn_unaccent', $1) q
+		WHERE a.search_vector @@ q
+		ORDER BY score DESC, a.id
+		LIMIT $2 OFFSET $3`
+
+	rows, err := db.Query(sqlQuery, query, limit, offset, searchHeadlineOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []model.AlumniSearchResult
+	for rows.Next() {
+		var r model.AlumniSearchResult
+		var hNama, hNIM, hEmail, hJurusan string
+		err := rows.Scan(&r.ID, &r.NIM, &r.Nama, &r.Jurusan, &r.Angkatan, &r.TahunLulus, &r.Email, &r.NoTelepon, &r.Alamat, &r.CreatedAt, &r.UpdatedAt,
+			&r.Score, &hNama, &hNIM, &hEmail, &hJurusan)
+		if err != nil {
+			return nil, err
+		}
+		r.Highlights = collectHeadlines(map[string]string{
+			"nama":    hNama,
+			"nim":     hNIM,
+			"email":   hEmail,
+			"jurusan": hJurusan,
+		})
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// CountSearchAlumniRepo -> hitung total hasil full-text search alumni
+func CountSearchAlumniRepo(db *sql.DB, query string) (int, error) {
+	var total int
+	err := db.QueryRow(`SELECT COUNT(*) FROM alumni WHERE search_vector @@ websearch_to_tsquery('idn_unaccent', $1)`, query).Scan(&total)
 	if err != nil && err != sql.ErrNoRows {
 		return 0, err
 	}
 	return total, nil
 }
 
-func GetAlumniByID(db *sql.DB, id int) (*model.Alumni, error) {
+// collectHeadlines -> hanya headline yang benar-benar mengandung highlight
+func collectHeadlines(headlines map[string]string) map[string]string {
+	result := map[string]string{}
+	for field, h := range headlines {
+		if strings.Contains(h, "<em>") {
+			result[field] = h
+		}
+	}
+	return result
+}
+
+func GetAlumniByID(db DBTX, id int) (*model.Alumni, error) {
+	alumni := new(model.Alumni)
+	query := `SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, role_id, no_telepon, alamat, password, created_at, updated_at, session_version, version FROM alumni WHERE id = $1`
+	err := db.QueryRow(query, id).Scan(&alumni.ID, &alumni.NIM, &alumni.Nama, &alumni.Jurusan, &alumni.Angkatan, &alumni.TahunLulus, &alumni.Email, &alumni.RoleID, &alumni.NoTelepon, &alumni.Alamat, &alumni.Password, &alumni.CreatedAt, &alumni.UpdatedAt, &alumni.SessionVersion, &alumni.Version)
+	if err != nil {
+		return nil, err
+	}
+	return alumni, nil
+}
+
+// LockAlumni -> kunci baris alumni (FOR UPDATE) sampai transaksi selesai; sql.ErrNoRows bila tidak ada
+func LockAlumni(db DBTX, id int) error {
+	return db.QueryRow(`SELECT id FROM alumni WHERE id = $1 FOR UPDATE`, id).Scan(&id)
+}
+
+func GetAlumniByEmail(db *sql.DB, email string) (*model.Alumni, error) {
 	alumni := new(model.Alumni)
-	query := `SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, role_id, no_telepon, alamat, password, created_at, updated_at FROM alumni WHERE id = $1`
-	err := db.QueryRow(query, id).Scan(&alumni.ID, &alumni.NIM, &alumni.Nama, &alumni.Jurusan, &alumni.Angkatan, &alumni.TahunLulus, &alumni.Email, &alumni.RoleID, &alumni.NoTelepon, &alumni.Alamat, &alumni.Password, &alumni.CreatedAt, &alumni.UpdatedAt)
+	query := `SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, role_id, no_telepon, alamat, password, created_at, updated_at, session_version, version FROM alumni WHERE email = $1`
+	err := db.QueryRow(query, email).Scan(&alumni.ID, &alumni.NIM, &alumni.Nama, &alumni.Jurusan, &alumni.Angkatan, &alumni.TahunLulus, &alumni.Email, &alumni.RoleID, &alumni.NoTelepon, &alumni.Alamat, &alumni.Password, &alumni.CreatedAt, &alumni.UpdatedAt, &alumni.SessionVersion, &alumni.Version)
 	if err != nil {
 		return nil, err
 	}
 	return alumni, nil
 }
 
-func CreateAlumni(db *sql.DB, req *model.CreateAlumniRepositoryRequest) (*model.Alumni, error) {
-	query := `INSERT INTO alumni (nim, nama, jurusan, angkatan, tahun_lulus, email, role_id, no_telepon, alamat, password, created_at, updated_at) 
-              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id, created_at, updated_at`
+func CreateAlumni(db DBTX, req *model.CreateAlumniRepositoryRequest) (*model.Alumni, error) {
+	query := `INSERT INTO alumni (nim, nama, jurusan, angkatan, tahun_lulus, email, role_id, no_telepon, alamat, password, created_at, updated_at, tanggal_lahir) 
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING id, created_at, updated_at, version`
 
 	now := time.Now()
-	var id int
+	var id, version int
 	var createdAt, updatedAt time.Time
 
-	err := db.QueryRow(query, req.NIM, req.Nama, req.Jurusan, req.Angkatan, req.TahunLulus, req.Email, req.RoleID, req.NoTelepon, req.Alamat, req.Password, now, now).
-		Scan(&id, &createdAt, &updatedAt)
+	err := db.QueryRow(query, req.NIM, req.Nama, req.Jurusan, req.Angkatan, req.TahunLulus, req.Email, req.RoleID, req.NoTelepon, req.Alamat, req.Password, now, now, req.TanggalLahir).
+		Scan(&id, &createdAt, &updatedAt, &version)
 	if err != nil {
 		return nil, err
 	}
 
 	alumni := &model.Alumni{
-		ID:         id,
-		NIM:        req.NIM,
-		Nama:       req.Nama,
-		Jurusan:    req.Jurusan,
-		Angkatan:   req.Angkatan,
-		TahunLulus: req.TahunLulus,
-		Email:      req.Email,
-		RoleID:     req.RoleID,
-		NoTelepon:  req.NoTelepon,
-		Alamat:     req.Alamat,
-		Password:   req.Password,
-		CreatedAt:  createdAt,
-		UpdatedAt:  updatedAt,
+		ID:           id,
+		NIM:          req.NIM,
+		Nama:         req.Nama,
+		Jurusan:      req.Jurusan,
+		Angkatan:     req.Angkatan,
+		TahunLulus:   req.TahunLulus,
+		Email:        req.Email,
+		RoleID:       req.RoleID,
+		NoTelepon:    req.NoTelepon,
+		Alamat:       req.Alamat,
+		TanggalLahir: req.TanggalLahir,
+		Password:     req.Password,
+		CreatedAt:    createdAt,
+		UpdatedAt:    updatedAt,
+		Version:      version,
 	}
 	return alumni, nil
 }
 
-func UpdateAlumni(db *sql.DB, id int, req *model.UpdateAlumniRepositoryRequest) (*model.Alumni, error) {
+// UpdateAlumni -> update alumni dan naikkan versinya. expectedVersion (If-Match) dicek di WHERE
+// yang sama; helper.ErrVersionConflict bila versinya sudah berubah.
+func UpdateAlumni(db DBTX, id int, req *model.UpdateAlumniRepositoryRequest, expectedVersion *int) (*model.Alumni, error) {
 	// Build dynamic query based on provided fields
 	setParts := []string{}
 	args := []interface{}{}
@@ -196,6 +319,8 @@ func UpdateAlumni(db *sql.DB, id int, req *model.UpdateAlumniRepositoryRequest)
 		setParts = append(setParts, "password = $"+fmt.Sprintf("%d", argIndex))
 		args = append(args, *req.Password)
 		argIndex++
+		// Password diganti admin: cabut semua sesi alumni
+		setParts = append(setParts, "session_version = session_version + 1")
 	}
 	if req.NoTelepon != nil {
 		setParts = append(setParts, "no_telepon = $"+fmt.Sprintf("%d", argIndex))
@@ -207,28 +332,45 @@ func UpdateAlumni(db *sql.DB, id int, req *model.UpdateAlumniRepositoryRequest)
 		args = append(args, *req.Alamat)
 		argIndex++
 	}
+	if req.TanggalLahir != nil {
+		setParts = append(setParts, "tanggal_lahir = $"+fmt.Sprintf("%d", argIndex))
+		args = append(args, *req.TanggalLahir)
+		argIndex++
+	}
+	for _, column := range req.Unset {
+		// Nama kolom dari daftar tetap di service, bukan input client
+		setParts = append(setParts, column+" = NULL")
+	}
 
 	if len(setParts) == 0 {
 		return GetAlumniByID(db, id)
 	}
 
-	setParts = append(setParts, "updated_at = $"+fmt.Sprintf("%d", argIndex))
+	setParts = append(setParts, "updated_at = $"+fmt.Sprintf("%d", argIndex), "version = version + 1")
 	args = append(args, time.Now())
 	argIndex++
 
+	where := " WHERE id = $" + fmt.Sprintf("%d", argIndex)
 	args = append(args, id)
+	if expectedVersion != nil {
+		where += " AND version = $" + fmt.Sprintf("%d", argIndex+1)
+		args = append(args, *expectedVersion)
+	}
 
-	query := "UPDATE alumni SET " + strings.Join(setParts, ", ") + " WHERE id = $" + fmt.Sprintf("%d", argIndex) + " RETURNING id, nim, nama, jurusan, angkatan, tahun_lulus, email, role_id, no_telepon, alamat, password, created_at, updated_at"
+	query := "UPDATE alumni SET " + strings.Join(setParts, ", ") + where + " RETURNING id, nim, nama, jurusan, angkatan, tahun_lulus, email, role_id, no_telepon, alamat, password, created_at, updated_at, version"
 
 	alumni := new(model.Alumni)
-	err := db.QueryRow(query, args...).Scan(&alumni.ID, &alumni.NIM, &alumni.Nama, &alumni.Jurusan, &alumni.Angkatan, &alumni.TahunLulus, &alumni.Email, &alumni.RoleID, &alumni.NoTelepon, &alumni.Alamat, &alumni.Password, &alumni.CreatedAt, &alumni.UpdatedAt)
+	err := db.QueryRow(query, args...).Scan(&alumni.ID, &alumni.NIM, &alumni.Nama, &alumni.Jurusan, &alumni.Angkatan, &alumni.TahunLulus, &alumni.Email, &alumni.RoleID, &alumni.NoTelepon, &alumni.Alamat, &alumni.Password, &alumni.CreatedAt, &alumni.UpdatedAt, &alumni.Version)
+	if err == sql.ErrNoRows && expectedVersion != nil {
+		return nil, helper.ErrVersionConflict
+	}
 	if err != nil {
 		return nil, err
 	}
 	return alumni, nil
 }
 
-func DeleteAlumni(db *sql.DB, id int) error {
+func DeleteAlumni(db DBTX, id int) error {
 	query := `DELETE FROM alumni WHERE id = $1`
 	_, err := db.Exec(query, id)
 	return err
@@ -246,7 +388,7 @@ func CheckAlumniByNim(db *sql.DB, nim string) (*model.Alumni, error) {
 }
 
 // Get Alumni Employment Status with filtering and pagination
-func GetAlumniEmploymentStatus(db *sql.DB, req *model.AlumniEmploymentStatusRequest) ([]model.AlumniEmploymentStatus, error) {
+func GetAlumniEmploymentStatus(db *sql.DB, req *model.AlumniEmploymentStatusRequest, filters []helper.Filter) ([]model.AlumniEmploymentStatus, error) {
 	// Set default pagination
 	if req.Page <= 0 {
 		req.Page = 1
@@ -257,6 +399,105 @@ func GetAlumniEmploymentStatus(db *sql.DB, req *model.AlumniEmploymentStatusRequ
 
 	offset := (req.Page - 1) * req.Limit
 
+	baseQuery, args := employmentStatusQuery(req, filters)
+	argIndex := len(args) + 1
+
+	// Add pagination parameters
+	args = append(args, req.Limit, offset)
+
+	query := baseQuery + `
+		ORDER BY ` + employmentStatusOrder(req) + `
+		LIMIT $` + fmt.Sprintf("%d", argIndex) + ` OFFSET $` + fmt.Sprintf("%d", argIndex+1)
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []model.AlumniEmploymentStatus
+	for rows.Next() {
+		result, err := scanEmploymentStatus(rows)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func scanEmploymentStatus(rows *sql.Rows) (model.AlumniEmploymentStatus, error) {
+	var result model.AlumniEmploymentStatus
+	err := rows.Scan(
+		&result.ID,
+		&result.Nama,
+		&result.Jurusan,
+		&result.Angkatan,
+		&result.BidangIndustri,
+		&result.NamaPerusahaan,
+		&result.PosisiJabatan,
+		&result.TanggalMulaiKerja,
+		&result.GajiMin,
+		&result.GajiMax,
+		&result.GajiMataUang,
+		&result.GajiPeriode,
+		&result.GajiRange,
+		&result.LebihDari1Tahun,
+		&result.EmploymentCount,
+	)
+	return result, err
+}
+
+// StreamAlumniEmploymentStatus -> seluruh baris status pekerjaan yang cocok dengan filter, tanpa pagination
+func StreamAlumniEmploymentStatus(db *sql.DB, req *model.AlumniEmploymentStatusRequest, filters []helper.Filter, fn func(model.AlumniEmploymentStatus) error) error {
+	baseQuery, args := employmentStatusQuery(req, filters)
+
+	rows, err := db.Query(baseQuery+`
+		ORDER BY `+employmentStatusOrder(req), args...)
+	if err != nil {
+		return err
+	}
+	return streamRows(rows, scanEmploymentStatus, fn)
+}
+
+// CountAlumniEmploymentStatus -> total seluruh baris status pekerjaan yang cocok dengan filter (bukan hanya halaman ini)
+func CountAlumniEmploymentStatus(db *sql.DB, req *model.AlumniEmploymentStatusRequest, filters []helper.Filter) (int, error) {
+	baseQuery, args := employmentStatusQuery(req, filters)
+
+	var total int
+	err := db.QueryRow(`SELECT COUNT(*) FROM (`+baseQuery+`) t`, args...).Scan(&total)
+	if err != nil && err != sql.ErrNoRows {
+		return 0, err
+	}
+	return total, nil
+}
+
+// EmploymentStatusSortColumns -> whitelist ?sortBy status pekerjaan; nominal gaji dibandingkan
+// apa adanya, gabungkan dengan filter gaji_mata_uang / gaji_periode agar sebanding
+var EmploymentStatusSortColumns = map[string]string{
+	"nama":                "a.nama",
+	"angkatan":            "a.angkatan",
+	"tanggal_mulai_kerja": "le.tanggal_mulai_kerja",
+	"gaji_min":            "le.visible_gaji_min",
+	"gaji_max":            "le.visible_gaji_max",
+}
+
+// employmentStatusOrder -> ORDER BY status pekerjaan; alumni tanpa nilai (belum bekerja, gaji
+// kosong / dirahasiakan) selalu di akhir
+func employmentStatusOrder(req *model.AlumniEmploymentStatusRequest) string {
+	column, ok := EmploymentStatusSortColumns[req.SortBy]
+	if !ok {
+		column = EmploymentStatusSortColumns["nama"]
+	}
+	direction := "ASC"
+	if strings.ToLower(req.Order) == "desc" {
+		direction = "DESC"
+	}
+	return column + " " + direction + " NULLS LAST, a.id"
+}
+
+// employmentStatusQuery -> query status pekerjaan (tanpa ORDER/LIMIT) beserta argumennya
+func employmentStatusQuery(req *model.AlumniEmploymentStatusRequest, filters []helper.Filter) (string, []interface{}) {
 	// Build WHERE clause based on filters
 	whereConditions := []string{}
 	args := []interface{}{}
@@ -303,19 +544,29 @@ func GetAlumniEmploymentStatus(db *sql.DB, req *model.AlumniEmploymentStatusRequ
 		argIndex++
 	}
 
+	filterConditions, filterArgs := buildSQLFilter(filters, argIndex)
+	whereConditions = append(whereConditions, filterConditions...)
+	args = append(args, filterArgs...)
+
 	// Build WHERE clause
 	whereClause := ""
 	if len(whereConditions) > 0 {
 		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
 	}
 
-	// Add pagination parameters
-	args = append(args, req.Limit, offset)
+	// Nominal gaji rahasia disembunyikan sebelum filter dan sort agar tidak bisa ditebak lewat ?filter
+	salaryHidden := "FALSE"
+	if !req.IncludeConfidential {
+		salaryHidden = fmt.Sprintf("p.gaji_rahasia AND p.alumni_id <> %d", req.ViewerID)
+	}
 
 	query := `
 		-- Subquery: pekerjaan terbaru per alumni (berdasarkan tanggal_mulai_kerja terbesar)
 		WITH latest_employment AS (
-			SELECT p.*
+			SELECT p.*,
+				CASE WHEN ` + salaryHidden + ` THEN NULL ELSE p.gaji_min END AS visible_gaji_min,
+				CASE WHEN ` + salaryHidden + ` THEN NULL ELSE p.gaji_max END AS visible_gaji_max,
+				CASE WHEN ` + salaryHidden + ` THEN NULL ELSE p.gaji_range END AS visible_gaji_range
 			FROM pekerjaan_alumni p
 			JOIN (
 				SELECT alumni_id, MAX(tanggal_mulai_kerja) AS latest_start
@@ -337,7 +588,11 @@ func GetAlumniEmploymentStatus(db *sql.DB, req *model.AlumniEmploymentStatusRequ
 			le.nama_perusahaan,
 			le.posisi_jabatan,
 			le.tanggal_mulai_kerja,
-			le.gaji_range,
+			le.visible_gaji_min,
+			le.visible_gaji_max,
+			le.gaji_mata_uang,
+			le.gaji_periode,
+			le.visible_gaji_range,
 			CASE
 				WHEN le.tanggal_mulai_kerja <= (CURRENT_DATE - INTERVAL '1 year') THEN 1
 				ELSE 0
@@ -346,36 +601,19 @@ func GetAlumniEmploymentStatus(db *sql.DB, req *model.AlumniEmploymentStatusRequ
 		FROM alumni a
 		LEFT JOIN latest_employment le ON a.id = le.alumni_id
 		LEFT JOIN employment_counts ec ON a.id = ec.alumni_id
-		` + whereClause + `
-		ORDER BY a.nama
-		LIMIT $` + fmt.Sprintf("%d", argIndex) + ` OFFSET $` + fmt.Sprintf("%d", argIndex+1)
+		` + whereClause
 
-	rows, err := db.Query(query, args...)
+	return query, args
+}
+
+// GetAlumniVerificationByNIM -> data alumni yang dibutuhkan verifikasi kelulusan, termasuk tanggal
+// lahir; sql.ErrNoRows bila NIM tidak ditemukan
+func GetAlumniVerificationByNIM(db *sql.DB, nim string) (*model.Alumni, error) {
+	alumni := new(model.Alumni)
+	query := `SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, tanggal_lahir FROM alumni WHERE nim = $1 ORDER BY id LIMIT 1`
+	err := db.QueryRow(query, nim).Scan(&alumni.ID, &alumni.NIM, &alumni.Nama, &alumni.Jurusan, &alumni.Angkatan, &alumni.TahunLulus, &alumni.TanggalLahir)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	var results []model.AlumniEmploymentStatus
-	for rows.Next() {
-		var result model.AlumniEmploymentStatus
-		err := rows.Scan(
-			&result.ID,
-			&result.Nama,
-			&result.Jurusan,
-			&result.Angkatan,
-			&result.BidangIndustri,
-			&result.NamaPerusahaan,
-			&result.PosisiJabatan,
-			&result.TanggalMulaiKerja,
-			&result.GajiRange,
-			&result.LebihDari1Tahun,
-			&result.EmploymentCount,
-		)
-		if err != nil {
-			return nil, err
-		}
-		results = append(results, result)
-	}
-	return results, nil
+	return alumni, nil
 }
@@ -0,0 +1,253 @@
+package postgre
+
+import (
+	"database/sql"
+	"fmt"
+	model "go-fiber/app/model/postgre"
+	"go-fiber/helper"
+	"strings"
+)
+
+// Analytics Repository Functions
+// Setiap query memakai alias a (alumni) dan p (pekerjaan_alumni) sehingga filter kohort bisa dipakai ulang.
+
+// AnalyticsGroupFields -> nilai group_by yang diizinkan beserta kolom alumni-nya
+var AnalyticsGroupFields = map[string]string{
+	"angkatan":    "a.angkatan",
+	"jurusan":     "a.jurusan",
+	"tahun_lulus": "a.tahun_lulus",
+}
+
+// AnalyticsDistributionFields -> kolom pekerjaan yang bisa dihitung distribusinya
+var AnalyticsDistributionFields = map[string]string{
+	"bidang_industri": "p.bidang_industri",
+	"lokasi_kerja":    "p.lokasi_kerja",
+	"provinsi":        "COALESCE(p.provinsi, '')",
+	"kota":            "COALESCE(p.kota, '')",
+}
+
+// AnalyticsFilterFields -> whitelist filter kohort alumni untuk endpoint analytics
+var AnalyticsFilterFields = helper.FilterSpec{
+	"jurusan":     {Column: "a.jurusan", Type: helper.FilterTypeString, Ops: helper.StringFilterOps},
+	"angkatan":    {Column: "a.angkatan", Type: helper.FilterTypeInt, Ops: helper.NumberFilterOps},
+	"tahun_lulus": {Column: "a.tahun_lulus", Type: helper.FilterTypeInt, Ops: helper.NumberFilterOps},
+}
+
+// analyticsGroupKey -> ekspresi kelompok; group_by kosong berarti satu kelompok 'all'
+func analyticsGroupKey(groupBy string) string {
+	if column, ok := AnalyticsGroupFields[groupBy]; ok {
+		return column
+	}
+	return "'all'"
+}
+
+// jobsJoin -> join pekerjaan yang tidak terhapus, opsional hanya yang berstatus aktif
+func jobsJoin(activeOnly bool) string {
+	join := "JOIN pekerjaan_alumni p ON p.alumni_id = a.id AND p.is_delete IS NULL"
+	if activeOnly {
+		join += " AND p.status_pekerjaan = 'aktif'"
+	}
+	return join
+}
+
+// GetEmploymentRate -> jumlah alumni, yang sedang bekerja, dan yang pernah bekerja per kelompok
+func GetEmploymentRate(db *sql.DB, groupBy string, filters []helper.Filter) ([]model.EmploymentRateItem, error) {
+	conditions, args := buildSQLFilter(filters, 1)
+	query := fmt.Sprintf(`
+		SELECT group_key::text, COUNT(*),
+			COUNT(*) FILTER (WHERE employed),
+			COUNT(*) FILTER (WHERE ever_employed)
+		FROM (
+			SELECT %s AS group_key,
+				EXISTS (SELECT 1 FROM pekerjaan_alumni p WHERE p.alumni_id = a.id AND p.is_delete IS NULL AND p.status_pekerjaan = 'aktif') AS employed,
+				EXISTS (SELECT 1 FROM pekerjaan_alumni p WHERE p.alumni_id = a.id AND p.is_delete IS NULL) AS ever_employed
+			FROM alumni a
+			%s
+		) t
+		GROUP BY group_key
+		ORDER BY group_key`, analyticsGroupKey(groupBy), whereSQL(conditions))
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []model.EmploymentRateItem
+	for rows.Next() {
+		var item model.EmploymentRateItem
+		if err := rows.Scan(&item.Group, &item.TotalAlumni, &item.Employed, &item.EverEmployed); err != nil {
+			return nil, err
+		}
+		results = append(results, item)
+	}
+	return results, rows.Err()
+}
+
+// GetTimeToFirstJob -> median dan rata-rata bulan dari kelulusan ke pekerjaan pertama per kelompok.
+// Kelulusan diasumsikan bulan helper.GraduationMonth pada tahun_lulus; pekerjaan sebelum lulus dihitung 0.
+func GetTimeToFirstJob(db *sql.DB, groupBy string, filters []helper.Filter) ([]model.TimeToFirstJobItem, error) {
+	conditions, args := buildSQLFilter(filters, 1)
+	query := fmt.Sprintf(`
+		WITH first_job AS (
+			SELECT %s AS group_key,
+				GREATEST(0, (EXTRACT(YEAR FROM MIN(p.tanggal_mulai_kerja)) - a.tahun_lulus) * 12
+					+ EXTRACT(MONTH FROM MIN(p.tanggal_mulai_kerja)) - %d) AS months
+			FROM alumni a
+			%s
+			%s
+			GROUP BY a.id
+		)
+		SELECT group_key::text, COUNT(*),
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY months),
+			AVG(months)::float8
+		FROM first_job
+		GROUP BY group_key
+		ORDER BY group_key`, analyticsGroupKey(groupBy), helper.GraduationMonth, jobsJoin(false), whereSQL(conditions))
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []model.TimeToFirstJobItem
+	for rows.Next() {
+		var item model.TimeToFirstJobItem
+		if err := rows.Scan(&item.Group, &item.AlumniWithJob, &item.MedianMonths, &item.AverageMonths); err != nil {
+			return nil, err
+		}
+		results = append(results, item)
+	}
+	return results, rows.Err()
+}
+
+// GetJobDistribution -> jumlah pekerjaan dan alumni per nilai kolom (bidang_industri / lokasi_kerja / provinsi / kota)
+func GetJobDistribution(db *sql.DB, field string, activeOnly bool, filters []helper.Filter) ([]model.DistributionItem, error) {
+	column, ok := AnalyticsDistributionFields[field]
+	if !ok {
+		return nil, fmt.Errorf("field distribusi tidak dikenal: %s", field)
+	}
+
+	conditions, args := buildSQLFilter(filters, 1)
+	query := fmt.Sprintf(`
+		SELECT %[1]s, COUNT(*), COUNT(DISTINCT a.id)
+		FROM alumni a
+		%[2]s
+		%[3]s
+		GROUP BY %[1]s
+		ORDER BY COUNT(*) DESC, %[1]s`, column, jobsJoin(activeOnly), whereSQL(conditions))
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []model.DistributionItem
+	for rows.Next() {
+		var item model.DistributionItem
+		if err := rows.Scan(&item.Value, &item.Jobs, &item.Alumni); err != nil {
+			return nil, err
+		}
+		results = append(results, item)
+	}
+	return results, rows.Err()
+}
+
+// GetSalaryHistogram -> jumlah pekerjaan per kelompok gaji (helper.SalaryBoundaries), dalam juta
+// rupiah per bulan. Gaji terstruktur memakai titik tengah gaji_min / gaji_max (gaji tahunan dibagi
+// 12, mata uang selain IDR tidak dikelompokkan). Pekerjaan yang masih memakai gaji_range lama
+// dibaca dari angka pertama dan terakhirnya; nilai >= 100000 dianggap rupiah.
+func GetSalaryHistogram(db *sql.DB, activeOnly bool, filters []helper.Filter) ([]model.SalaryBucketCount, error) {
+	// CASE dari batas terbesar ke terkecil; batas berasal dari konstanta, bukan input user
+	var bucket strings.Builder
+	bucket.WriteString("CASE WHEN salary IS NULL THEN -1")
+	for i := len(helper.SalaryBoundaries) - 1; i >= 0; i-- {
+		fmt.Fprintf(&bucket, " WHEN salary >= %[1]g THEN %[1]g", helper.SalaryBoundaries[i])
+	}
+	bucket.WriteString(" ELSE -1 END")
+
+	toJuta := func(expr string) string {
+		return fmt.Sprintf("CASE WHEN %[1]s >= 100000 THEN %[1]s / 1000000 ELSE %[1]s END", expr)
+	}
+
+	conditions, args := buildSQLFilter(filters, 1)
+	query := fmt.Sprintf(`
+		WITH parsed AS (
+			SELECT gaji_min, gaji_max, gaji_mata_uang, gaji_periode,
+				substring(g FROM '[0-9]+')::numeric AS lo,
+				substring(g FROM '([0-9]+)[^0-9]*$')::numeric AS hi
+			FROM (
+				SELECT p.gaji_min, p.gaji_max, p.gaji_mata_uang, p.gaji_periode,
+					replace(COALESCE(p.gaji_range, ''), '.', '') AS g
+				FROM alumni a
+				%s
+				%s
+			) s
+		), salaries AS (
+			SELECT CASE
+				WHEN gaji_min IS NULL AND gaji_max IS NULL THEN (%s + %s) / 2
+				WHEN gaji_mata_uang = '%s' THEN (COALESCE(gaji_min, gaji_max) + COALESCE(gaji_max, gaji_min)) / 2.0 / 1000000
+					/ CASE WHEN gaji_periode = '%s' THEN 12 ELSE 1 END
+			END AS salary
+			FROM parsed
+		)
+		SELECT (%s)::float8 AS bucket, COUNT(*)
+		FROM salaries
+		GROUP BY bucket
+		ORDER BY bucket`, jobsJoin(activeOnly), whereSQL(conditions), toJuta("lo"), toJuta("hi"),
+		helper.DefaultSalaryCurrency, helper.SalaryPeriodYearly, bucket.String())
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []model.SalaryBucketCount
+	for rows.Next() {
+		var item model.SalaryBucketCount
+		if err := rows.Scan(&item.Min, &item.Count); err != nil {
+			return nil, err
+		}
+		results = append(results, item)
+	}
+	return results, rows.Err()
+}
+
+// GetJobRetention -> pekerjaan yang bertahan lebih dari helper.RetentionDays per kelompok.
+// Pekerjaan aktif yang belum berjalan 1 tahun belum bisa dinilai sehingga tidak dihitung.
+func GetJobRetention(db *sql.DB, groupBy string, filters []helper.Filter) ([]model.RetentionItem, error) {
+	conditions, args := buildSQLFilter(filters, 1)
+	query := fmt.Sprintf(`
+		SELECT group_key::text,
+			COUNT(*) FILTER (WHERE eligible),
+			COUNT(*) FILTER (WHERE retained)
+		FROM (
+			SELECT %[1]s AS group_key,
+				(p.tanggal_selesai_kerja IS NOT NULL OR p.tanggal_mulai_kerja <= CURRENT_DATE - %[2]d) AS eligible,
+				(COALESCE(p.tanggal_selesai_kerja, CURRENT_DATE) - p.tanggal_mulai_kerja) > %[2]d AS retained
+			FROM alumni a
+			%[3]s
+			%[4]s
+		) t
+		GROUP BY group_key
+		ORDER BY group_key`, analyticsGroupKey(groupBy), helper.RetentionDays, jobsJoin(false), whereSQL(conditions))
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []model.RetentionItem
+	for rows.Next() {
+		var item model.RetentionItem
+		if err := rows.Scan(&item.Group, &item.EligibleJobs, &item.RetainedJobs); err != nil {
+			return nil, err
+		}
+		results = append(results, item)
+	}
+	return results, rows.Err()
+}
@@ -0,0 +1,78 @@
+package postgre
+
+import (
+	"database/sql"
+
+	model "go-fiber/app/model/postgre"
+
+	"github.com/lib/pq"
+)
+
+// API Key Repository Functions
+
+const apiKeyColumns = `id, name, prefix, key_hash, scopes, rate_limit, expires_at, last_used_at,
+	revoked_at, created_by, created_at`
+
+func scanAPIKey(row interface{ Scan(...any) error }) (*model.APIKey, error) {
+	var key model.APIKey
+	err := row.Scan(&key.ID, &key.Name, &key.Prefix, &key.KeyHash, pq.Array(&key.Scopes), &key.RateLimit,
+		&key.ExpiresAt, &key.LastUsedAt, &key.RevokedAt, &key.CreatedBy, &key.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// CreateAPIKey -> simpan API key baru (hanya hash key)
+func CreateAPIKey(db *sql.DB, key *model.APIKey) error {
+	query := `INSERT INTO api_keys (name, prefix, key_hash, scopes, rate_limit, expires_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at`
+	return db.QueryRow(query, key.Name, key.Prefix, key.KeyHash, pq.Array(key.Scopes), key.RateLimit,
+		key.ExpiresAt, key.CreatedBy).Scan(&key.ID, &key.CreatedAt)
+}
+
+// ListAPIKeys -> semua API key, terbaru lebih dulu
+func ListAPIKeys(db *sql.DB) ([]model.APIKey, error) {
+	rows, err := db.Query(`SELECT ` + apiKeyColumns + ` FROM api_keys ORDER BY created_at DESC, id DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []model.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, *key)
+	}
+	return keys, rows.Err()
+}
+
+// RevokeAPIKey -> cabut API key (sql.ErrNoRows bila tidak ditemukan). Key yang sudah dicabut
+// dikembalikan dengan revoked_at semula
+func RevokeAPIKey(db *sql.DB, id int) (*model.APIKey, error) {
+	query := `UPDATE api_keys SET revoked_at = COALESCE(revoked_at, NOW())
+		WHERE id = $1
+		RETURNING ` + apiKeyColumns
+	return scanAPIKey(db.QueryRow(query, id))
+}
+
+// FindActiveAPIKeyByHash -> API key yang belum dicabut dan belum kedaluwarsa
+// (sql.ErrNoRows bila tidak ada)
+func FindActiveAPIKeyByHash(db *sql.DB, hash string) (*model.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys
+		WHERE key_hash = $1 AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > NOW())`
+	return scanAPIKey(db.QueryRow(query, hash))
+}
+
+// TouchAPIKeyLastUsed -> catat waktu pemakaian terakhir API key. Hanya ditulis ulang bila
+// lebih lama dari satu menit agar setiap request partner tidak selalu menjadi operasi tulis
+func TouchAPIKeyLastUsed(db *sql.DB, id int) error {
+	query := `UPDATE api_keys SET last_used_at = NOW()
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')`
+	_, err := db.Exec(query, id)
+	return err
+}
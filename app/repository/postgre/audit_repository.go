@@ -0,0 +1,148 @@
+package postgre
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strconv"
+
+	model "go-fiber/app/model/postgre"
+	"go-fiber/helper"
+)
+
+// Audit Repository Functions
+// Audit log bersifat append-only: tidak ada fungsi update maupun delete, dan trigger di
+// schema menolak UPDATE/DELETE pada tabel audit_logs.
+
+const auditLogColumns = `id, actor_id, COALESCE(actor_role, ''), action, entity_type, COALESCE(entity_id, ''),
+	before, after, changes, metadata, COALESCE(ip, ''), COALESCE(user_agent, ''), COALESCE(request_id, ''), created_at`
+
+// auditJSON -> nilai kolom JSONB; nil (NULL) bila v kosong
+func auditJSON[T any](v map[string]T) (any, error) {
+	if len(v) == 0 {
+		return nil, nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(raw), nil
+}
+
+// InsertAuditLog -> tambah satu entri audit log
+func InsertAuditLog(db DBTX, entry *model.AuditLog) error {
+	before, err := auditJSON(entry.Before)
+	if err != nil {
+		return err
+	}
+	after, err := auditJSON(entry.After)
+	if err != nil {
+		return err
+	}
+	changes, err := auditJSON(entry.Changes)
+	if err != nil {
+		return err
+	}
+	metadata, err := auditJSON(entry.Metadata)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO audit_logs (actor_id, actor_role, action, entity_type, entity_id, before, after,
+			changes, metadata, ip, user_agent, request_id)
+		VALUES ($1, NULLIF($2, ''), $3, $4, NULLIF($5, ''), $6, $7, $8, $9, NULLIF($10, ''), NULLIF($11, ''), NULLIF($12, ''))
+		RETURNING id, created_at`
+	return db.QueryRow(query, entry.ActorID, entry.ActorRole, entry.Action, entry.EntityType, entry.EntityID,
+		before, after, changes, metadata, entry.IP, entry.UserAgent, entry.RequestID).
+		Scan(&entry.ID, &entry.CreatedAt)
+}
+
+func scanAuditLog(rows *sql.Rows) (model.AuditLog, error) {
+	var entry model.AuditLog
+	var actorID sql.NullInt64
+	var before, after, changes, metadata []byte
+	err := rows.Scan(&entry.ID, &actorID, &entry.ActorRole, &entry.Action, &entry.EntityType, &entry.EntityID,
+		&before, &after, &changes, &metadata, &entry.IP, &entry.UserAgent, &entry.RequestID, &entry.CreatedAt)
+	if err != nil {
+		return entry, err
+	}
+	if actorID.Valid {
+		id := int(actorID.Int64)
+		entry.ActorID = &id
+	}
+	for _, col := range []struct {
+		raw  []byte
+		dest any
+	}{{before, &entry.Before}, {after, &entry.After}, {changes, &entry.Changes}, {metadata, &entry.Metadata}} {
+		if len(col.raw) == 0 {
+			continue
+		}
+		if err := json.Unmarshal(col.raw, col.dest); err != nil {
+			return entry, err
+		}
+	}
+	return entry, nil
+}
+
+// auditLogWhere -> klausa WHERE dan argumen dari filter audit log; ActorID harus angka
+func auditLogWhere(f helper.AuditLogFilter) (string, []any) {
+	where := "WHERE 1=1"
+	args := []any{}
+	add := func(cond string, value any) {
+		args = append(args, value)
+		where += " AND " + cond + " $" + strconv.Itoa(len(args))
+	}
+	if id, err := strconv.Atoi(f.ActorID); err == nil {
+		add("actor_id =", id)
+	}
+	if f.ActorRole != "" {
+		add("actor_role =", f.ActorRole)
+	}
+	if f.Action != "" {
+		add("action =", f.Action)
+	}
+	if f.EntityType != "" {
+		add("entity_type =", f.EntityType)
+	}
+	if f.EntityID != "" {
+		add("entity_id =", f.EntityID)
+	}
+	if f.RequestID != "" {
+		add("request_id =", f.RequestID)
+	}
+	if f.From != nil {
+		add("created_at >=", *f.From)
+	}
+	if f.To != nil {
+		add("created_at <=", *f.To)
+	}
+	return where, args
+}
+
+// FindAuditLogs -> audit log sesuai filter, terbaru lebih dulu, beserta total
+func FindAuditLogs(db *sql.DB, f helper.AuditLogFilter, page, limit int) ([]model.AuditLog, int, error) {
+	where, args := auditLogWhere(f)
+
+	var total int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM audit_logs `+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	n := len(args)
+	query := `SELECT ` + auditLogColumns + ` FROM audit_logs ` + where + `
+		ORDER BY created_at DESC, id DESC LIMIT $` + strconv.Itoa(n+1) + ` OFFSET $` + strconv.Itoa(n+2)
+	rows, err := db.Query(query, append(args, limit, (page-1)*limit)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	logs := []model.AuditLog{}
+	for rows.Next() {
+		entry, err := scanAuditLog(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		logs = append(logs, entry)
+	}
+	return logs, total, rows.Err()
+}
@@ -0,0 +1,125 @@
+package postgre
+
+import (
+	"fmt"
+	"strings"
+
+	"go-fiber/helper"
+)
+
+// AlumniFilterFields -> whitelist filter[field][op] untuk list alumni
+var AlumniFilterFields = helper.FilterSpec{
+	"id":          {Column: "id", Type: helper.FilterTypeInt, Ops: helper.IDFilterOps},
+	"nim":         {Column: "nim", Type: helper.FilterTypeString, Ops: helper.StringFilterOps},
+	"nama":        {Column: "nama", Type: helper.FilterTypeString, Ops: helper.StringFilterOps},
+	"email":       {Column: "email", Type: helper.FilterTypeString, Ops: helper.StringFilterOps},
+	"jurusan":     {Column: "jurusan", Type: helper.FilterTypeString, Ops: helper.StringFilterOps},
+	"angkatan":    {Column: "angkatan", Type: helper.FilterTypeInt, Ops: helper.NumberFilterOps},
+	"tahun_lulus": {Column: "tahun_lulus", Type: helper.FilterTypeInt, Ops: helper.NumberFilterOps},
+	"created_at":  {Column: "created_at", Type: helper.FilterTypeDate, Ops: helper.NumberFilterOps},
+}
+
+// PekerjaanFilterFields -> whitelist filter[field][op] untuk list pekerjaan
+var PekerjaanFilterFields = helper.FilterSpec{
+	"id":                    {Column: "id", Type: helper.FilterTypeInt, Ops: helper.IDFilterOps},
+	"alumni_id":             {Column: "alumni_id", Type: helper.FilterTypeInt, Ops: helper.IDFilterOps},
+	"nama_perusahaan":       {Column: "nama_perusahaan", Type: helper.FilterTypeString, Ops: helper.StringFilterOps},
+	"posisi_jabatan":        {Column: "posisi_jabatan", Type: helper.FilterTypeString, Ops: helper.StringFilterOps},
+	"bidang_industri":       {Column: "bidang_industri", Type: helper.FilterTypeString, Ops: helper.StringFilterOps},
+	"lokasi_kerja":          {Column: "lokasi_kerja", Type: helper.FilterTypeString, Ops: helper.StringFilterOps},
+	"gaji_range":            {Column: "gaji_range", Type: helper.FilterTypeString, Ops: helper.StringFilterOps},
+	"negara":                {Column: "negara", Type: helper.FilterTypeString, Ops: []string{helper.FilterNull, helper.FilterEq, helper.FilterNe, helper.FilterIn, helper.FilterNin}},
+	"provinsi":              {Column: "provinsi", Type: helper.FilterTypeString, Ops: append([]string{helper.FilterNull}, helper.StringFilterOps...)},
+	"kota":                  {Column: "kota", Type: helper.FilterTypeString, Ops: append([]string{helper.FilterNull}, helper.StringFilterOps...)},
+	"koordinat":             {Column: "latitude,longitude", Type: helper.FilterTypeGeo, Ops: helper.GeoFilterOps},
+	"status_pekerjaan":      {Column: "status_pekerjaan", Type: helper.FilterTypeString, Ops: []string{helper.FilterEq, helper.FilterNe, helper.FilterIn, helper.FilterNin}},
+	"tanggal_mulai_kerja":   {Column: "tanggal_mulai_kerja", Type: helper.FilterTypeDate, Ops: helper.NumberFilterOps},
+	"tanggal_selesai_kerja": {Column: "tanggal_selesai_kerja", Type: helper.FilterTypeDate, Ops: append([]string{helper.FilterNull}, helper.NumberFilterOps...)},
+	"created_at":            {Column: "created_at", Type: helper.FilterTypeDate, Ops: helper.NumberFilterOps},
+}
+
+// EmploymentStatusFilterFields -> whitelist filter[field][op] untuk status pekerjaan alumni
+// (kolom mengacu ke alias a, le, ec pada query GetAlumniEmploymentStatus)
+var EmploymentStatusFilterFields = helper.FilterSpec{
+	"id":                  {Column: "a.id", Type: helper.FilterTypeInt, Ops: helper.IDFilterOps},
+	"nama":                {Column: "a.nama", Type: helper.FilterTypeString, Ops: helper.StringFilterOps},
+	"jurusan":             {Column: "a.jurusan", Type: helper.FilterTypeString, Ops: helper.StringFilterOps},
+	"angkatan":            {Column: "a.angkatan", Type: helper.FilterTypeInt, Ops: helper.NumberFilterOps},
+	"bidang_industri":     {Column: "le.bidang_industri", Type: helper.FilterTypeString, Ops: append([]string{helper.FilterNull}, helper.StringFilterOps...)},
+	"nama_perusahaan":     {Column: "le.nama_perusahaan", Type: helper.FilterTypeString, Ops: append([]string{helper.FilterNull}, helper.StringFilterOps...)},
+	"posisi_jabatan":      {Column: "le.posisi_jabatan", Type: helper.FilterTypeString, Ops: append([]string{helper.FilterNull}, helper.StringFilterOps...)},
+	"tanggal_mulai_kerja": {Column: "le.tanggal_mulai_kerja", Type: helper.FilterTypeDate, Ops: append([]string{helper.FilterNull}, helper.NumberFilterOps...)},
+	"gaji_min":            {Column: "le.visible_gaji_min", Type: helper.FilterTypeInt, Ops: append([]string{helper.FilterNull}, helper.NumberFilterOps...)},
+	"gaji_max":            {Column: "le.visible_gaji_max", Type: helper.FilterTypeInt, Ops: append([]string{helper.FilterNull}, helper.NumberFilterOps...)},
+	"gaji_mata_uang":      {Column: "le.gaji_mata_uang", Type: helper.FilterTypeString, Ops: []string{helper.FilterEq, helper.FilterNe, helper.FilterIn, helper.FilterNin}},
+	"gaji_periode":        {Column: "le.gaji_periode", Type: helper.FilterTypeString, Ops: []string{helper.FilterEq, helper.FilterNe, helper.FilterIn, helper.FilterNin}},
+	"lebih_dari_1_tahun":  {Column: "CASE WHEN le.tanggal_mulai_kerja <= (CURRENT_DATE - INTERVAL '1 year') THEN 1 ELSE 0 END", Type: helper.FilterTypeInt, Ops: []string{helper.FilterEq, helper.FilterNe}},
+	"employment_count":    {Column: "COALESCE(ec.employment_count, 0)", Type: helper.FilterTypeInt, Ops: helper.NumberFilterOps},
+}
+
+// buildSQLFilter menerjemahkan filter yang sudah divalidasi menjadi kondisi WHERE berparameter.
+// Kolom selalu berasal dari whitelist, nilai selalu lewat placeholder mulai dari $argIndex.
+func buildSQLFilter(filters []helper.Filter, argIndex int) ([]string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	placeholder := func(v interface{}) string {
+		args = append(args, v)
+		p := fmt.Sprintf("$%d", argIndex)
+		argIndex++
+		return p
+	}
+
+	for _, f := range filters {
+		switch f.Op {
+		case helper.FilterEq:
+			conditions = append(conditions, f.Column+" = "+placeholder(f.Values[0]))
+		case helper.FilterNe:
+			conditions = append(conditions, f.Column+" <> "+placeholder(f.Values[0]))
+		case helper.FilterGt:
+			conditions = append(conditions, f.Column+" > "+placeholder(f.Values[0]))
+		case helper.FilterGte:
+			conditions = append(conditions, f.Column+" >= "+placeholder(f.Values[0]))
+		case helper.FilterLt:
+			conditions = append(conditions, f.Column+" < "+placeholder(f.Values[0]))
+		case helper.FilterLte:
+			conditions = append(conditions, f.Column+" <= "+placeholder(f.Values[0]))
+		case helper.FilterIn, helper.FilterNin:
+			holders := make([]string, len(f.Values))
+			for i, v := range f.Values {
+				holders[i] = placeholder(v)
+			}
+			op := " IN "
+			if f.Op == helper.FilterNin {
+				op = " NOT IN "
+			}
+			conditions = append(conditions, f.Column+op+"("+strings.Join(holders, ", ")+")")
+		case helper.FilterBetween:
+			conditions = append(conditions, f.Column+" BETWEEN "+placeholder(f.Values[0])+" AND "+placeholder(f.Values[1]))
+		case helper.FilterLike:
+			conditions = append(conditions, f.Column+" ILIKE "+placeholder("%"+escapeLike(f.Values[0].(string))+"%"))
+		case helper.FilterNull:
+			if f.Values[0].(bool) {
+				conditions = append(conditions, f.Column+" IS NULL")
+			} else {
+				conditions = append(conditions, f.Column+" IS NOT NULL")
+			}
+		case helper.FilterNear:
+			// Column berisi "kolom_lat,kolom_lng"; bounding box menyaring lewat index sebelum
+			// haversine_km menghitung jarak sebenarnya
+			geo := f.Values[0].(helper.GeoRadius)
+			latColumn, lngColumn, _ := strings.Cut(f.Column, ",")
+			minLat, maxLat, minLng, maxLng := geo.BoundingBox()
+			conditions = append(conditions,
+				latColumn+" BETWEEN "+placeholder(minLat)+" AND "+placeholder(maxLat),
+				lngColumn+" BETWEEN "+placeholder(minLng)+" AND "+placeholder(maxLng),
+				fmt.Sprintf("haversine_km(%s, %s, %s, %s) <= %s", latColumn, lngColumn, placeholder(geo.Lat), placeholder(geo.Lng), placeholder(geo.RadiusKm)))
+		}
+	}
+	return conditions, args
+}
+
+// escapeLike meng-escape karakter wildcard LIKE agar nilai diperlakukan literal
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
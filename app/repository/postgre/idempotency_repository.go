@@ -0,0 +1,85 @@
+package postgre
+
+import (
+	"database/sql"
+	"time"
+
+	"go-fiber/helper"
+)
+
+// Idempotency Repository Functions
+
+// IdempotencyStore -> helper.IdempotencyStore di tabel idempotency_keys. Baris kedaluwarsa
+// dihapus oleh job jobs.prune.
+type IdempotencyStore struct {
+	db *sql.DB
+}
+
+func NewIdempotencyStore(db *sql.DB) *IdempotencyStore {
+	return &IdempotencyStore{db: db}
+}
+
+func (s *IdempotencyStore) Reserve(key, requestHash string, ttl time.Duration) (*helper.IdempotencyRecord, error) {
+	// Baris yang sudah kedaluwarsa tapi belum di-prune ditimpa seperti key baru
+	query := `
+		INSERT INTO idempotency_keys (key, request_hash, expires_at)
+		VALUES ($1, $2, NOW() + make_interval(secs => $3))
+		ON CONFLICT (key) DO UPDATE SET
+			request_hash = EXCLUDED.request_hash,
+			completed = FALSE,
+			status_code = NULL,
+			content_type = NULL,
+			response_body = NULL,
+			created_at = NOW(),
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= NOW()
+		RETURNING key
+	`
+	var reserved string
+	err := s.db.QueryRow(query, key, requestHash, ttl.Seconds()).Scan(&reserved)
+	if err == nil {
+		return nil, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	record := &helper.IdempotencyRecord{}
+	var statusCode sql.NullInt64
+	var contentType sql.NullString
+	err = s.db.QueryRow(`SELECT request_hash, completed, status_code, content_type, response_body FROM idempotency_keys WHERE key = $1`, key).
+		Scan(&record.RequestHash, &record.Completed, &statusCode, &contentType, &record.Body)
+	if err == sql.ErrNoRows {
+		// Dihapus request lain di antara insert dan select; anggap masih diproses
+		return &helper.IdempotencyRecord{RequestHash: requestHash}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	record.StatusCode = int(statusCode.Int64)
+	record.ContentType = contentType.String
+	return record, nil
+}
+
+func (s *IdempotencyStore) Complete(key string, record helper.IdempotencyRecord) error {
+	_, err := s.db.Exec(`
+		UPDATE idempotency_keys
+		SET completed = TRUE, status_code = $2, content_type = $3, response_body = $4
+		WHERE key = $1
+	`, key, record.StatusCode, record.ContentType, record.Body)
+	return err
+}
+
+func (s *IdempotencyStore) Release(key string) error {
+	_, err := s.db.Exec(`DELETE FROM idempotency_keys WHERE key = $1`, key)
+	return err
+}
+
+// DeleteExpiredIdempotencyKeys -> hapus Idempotency-Key yang sudah kedaluwarsa
+func DeleteExpiredIdempotencyKeys(db *sql.DB) (int64, error) {
+	result, err := db.Exec(`DELETE FROM idempotency_keys WHERE expires_at <= NOW()`)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
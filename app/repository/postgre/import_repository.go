@@ -0,0 +1,140 @@
+package postgre
+
+import (
+	"database/sql"
+	model "go-fiber/app/model/postgre"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Import Repository Functions
+
+// FindAlumniKeys -> alumni yang id, NIM, atau email-nya ada di daftar (untuk upsert import)
+func FindAlumniKeys(db *sql.DB, ids []int, nims, emails []string) ([]model.AlumniKey, error) {
+	if len(ids) == 0 && len(nims) == 0 && len(emails) == 0 {
+		return nil, nil
+	}
+
+	query := `SELECT id, nim, email FROM alumni
+		WHERE id = ANY($1) OR nim = ANY($2) OR LOWER(email) = ANY($3)`
+	rows, err := db.Query(query, pq.Array(ids), pq.Array(nims), pq.Array(emails))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []model.AlumniKey
+	for rows.Next() {
+		var k model.AlumniKey
+		if err := rows.Scan(&k.ID, &k.NIM, &k.Email); err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, rows.Err()
+}
+
+// UpsertAlumniBatch -> insert alumni baru dan update alumni lama dalam satu transaksi
+func UpsertAlumniBatch(db *sql.DB, records []model.AlumniImportRecord) (created, updated int, err error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback()
+
+	insertStmt, err := tx.Prepare(`INSERT INTO alumni (nim, nama, jurusan, angkatan, tahun_lulus, email, role_id, no_telepon, alamat, password, created_at, updated_at, tanggal_lahir)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $11, $12)`)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer insertStmt.Close()
+
+	// Kolom opsional yang kosong dan password kosong tidak menimpa data lama
+	updateStmt, err := tx.Prepare(`UPDATE alumni SET nim = $1, nama = $2, jurusan = $3, angkatan = $4, tahun_lulus = $5, email = $6, role_id = $7,
+		no_telepon = COALESCE($8, no_telepon), alamat = COALESCE($9, alamat), password = COALESCE(NULLIF($10, ''), password),
+		session_version = session_version + CASE WHEN $10 = '' THEN 0 ELSE 1 END, updated_at = $11, version = version + 1,
+		tanggal_lahir = COALESCE($13, tanggal_lahir)
+		WHERE id = $12`)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer updateStmt.Close()
+
+	now := time.Now()
+	for _, r := range records {
+		d := r.Data
+		if r.ID == nil {
+			if _, err := insertStmt.Exec(d.NIM, d.Nama, d.Jurusan, d.Angkatan, d.TahunLulus, d.Email, d.RoleID, d.NoTelepon, d.Alamat, d.Password, now, d.TanggalLahir); err != nil {
+				return 0, 0, err
+			}
+			created++
+			continue
+		}
+		if _, err := updateStmt.Exec(d.NIM, d.Nama, d.Jurusan, d.Angkatan, d.TahunLulus, d.Email, d.RoleID, d.NoTelepon, d.Alamat, d.Password, now, *r.ID, d.TanggalLahir); err != nil {
+			return 0, 0, err
+		}
+		updated++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, err
+	}
+	return created, updated, nil
+}
+
+// FindPekerjaanKeys -> pekerjaan (tidak terhapus) milik alumni di daftar, untuk mencocokkan baris import
+func FindPekerjaanKeys(db *sql.DB, alumniIDs []int) ([]model.PekerjaanKey, error) {
+	if len(alumniIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, tanggal_mulai_kerja, tanggal_selesai_kerja, paruh_waktu FROM pekerjaan_alumni
+		WHERE alumni_id = ANY($1) AND is_delete IS NULL`
+	rows, err := db.Query(query, pq.Array(alumniIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []model.PekerjaanKey
+	for rows.Next() {
+		var k model.PekerjaanKey
+		if err := rows.Scan(&k.ID, &k.AlumniID, &k.NamaPerusahaan, &k.PosisiJabatan, &k.TanggalMulaiKerja, &k.TanggalSelesaiKerja, &k.ParuhWaktu); err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, rows.Err()
+}
+
+// UpsertPekerjaan -> insert satu pekerjaan baru atau update pekerjaan lama di dalam transaksi
+// pemanggil; created true bila baris baru dibuat
+func UpsertPekerjaan(tx DBTX, r model.PekerjaanImportRecord, now time.Time) (created bool, err error) {
+	d := r.Data
+	if r.ID == nil {
+		_, err := tx.Exec(`INSERT INTO pekerjaan_alumni (alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, gaji_min, gaji_max, gaji_mata_uang, gaji_periode, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at, negara, provinsi, kota, latitude, longitude)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $14, $15, $16, $17, $18, $19)`,
+			d.AlumniID, d.NamaPerusahaan, d.PosisiJabatan, d.BidangIndustri, d.LokasiKerja, d.GajiMin, d.GajiMax, d.GajiMataUang, d.GajiPeriode, d.TanggalMulaiKerja, d.TanggalSelesaiKerja, d.StatusPekerjaan, d.DeskripsiPekerjaan, now, d.Negara, d.Provinsi, d.Kota, d.Latitude, d.Longitude)
+		return err == nil, err
+	}
+
+	// Kolom opsional yang kosong di file tidak menimpa data lama; gaji hanya diganti bila file
+	// memuat nominal ($4 / $5), lokasi terstruktur hanya diganti bila baris menghasilkan lokasi ($12)
+	_, err = tx.Exec(`UPDATE pekerjaan_alumni SET bidang_industri = $1, lokasi_kerja = $2, status_pekerjaan = $3,
+		negara = CASE WHEN $12::varchar IS NULL THEN negara ELSE $12 END,
+		provinsi = CASE WHEN $12::varchar IS NULL THEN provinsi ELSE $13 END,
+		kota = CASE WHEN $12::varchar IS NULL THEN kota ELSE $14 END,
+		latitude = CASE WHEN $12::varchar IS NULL THEN latitude ELSE $15 END,
+		longitude = CASE WHEN $12::varchar IS NULL THEN longitude ELSE $16 END,
+		gaji_min = CASE WHEN $4::bigint IS NULL AND $5::bigint IS NULL THEN gaji_min ELSE $4 END,
+		gaji_max = CASE WHEN $4::bigint IS NULL AND $5::bigint IS NULL THEN gaji_max ELSE $5 END,
+		gaji_mata_uang = CASE WHEN $4::bigint IS NULL AND $5::bigint IS NULL THEN gaji_mata_uang ELSE $6 END,
+		gaji_periode = CASE WHEN $4::bigint IS NULL AND $5::bigint IS NULL THEN gaji_periode ELSE $7 END,
+		gaji_range = CASE WHEN $4::bigint IS NULL AND $5::bigint IS NULL THEN gaji_range END,
+		tanggal_selesai_kerja = COALESCE($8, tanggal_selesai_kerja), deskripsi_pekerjaan = COALESCE($9, deskripsi_pekerjaan), updated_at = $10,
+		version = version + 1
+		WHERE id = $11`,
+		d.BidangIndustri, d.LokasiKerja, d.StatusPekerjaan, d.GajiMin, d.GajiMax, d.GajiMataUang, d.GajiPeriode, d.TanggalSelesaiKerja, d.DeskripsiPekerjaan, now, *r.ID, d.Negara, d.Provinsi, d.Kota, d.Latitude, d.Longitude)
+	return false, err
+}
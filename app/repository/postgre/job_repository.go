@@ -0,0 +1,201 @@
+package postgre
+
+import (
+	"database/sql"
+	model "go-fiber/app/model/postgre"
+	"time"
+)
+
+// Job Repository Functions
+
+const jobColumns = `id, type, payload, status, attempts, max_attempts, run_at, schedule, unique_key,
+	locked_by, locked_until, last_error, created_at, updated_at, started_at, finished_at, result`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanJob(row rowScanner) (model.Job, error) {
+	var j model.Job
+	var payload, result []byte
+	err := row.Scan(&j.ID, &j.Type, &payload, &j.Status, &j.Attempts, &j.MaxAttempts, &j.RunAt, &j.Schedule, &j.UniqueKey,
+		&j.LockedBy, &j.LockedUntil, &j.LastError, &j.CreatedAt, &j.UpdatedAt, &j.StartedAt, &j.FinishedAt, &result)
+	j.Payload = payload
+	j.Result = result
+	return j, err
+}
+
+// InsertJob -> simpan job baru ke antrean, dijalankan setelah delay. Mengembalikan false tanpa
+// error bila unique_key sudah dipakai job lain (mis. jadwal cron yang sudah di-enqueue instance lain)
+func InsertJob(db *sql.DB, job *model.Job, delay time.Duration) (bool, error) {
+	var result interface{}
+	if len(job.Result) > 0 {
+		result = []byte(job.Result)
+	}
+	query := `INSERT INTO jobs (type, payload, status, max_attempts, run_at, schedule, unique_key, result)
+		VALUES ($1, $2, 'queued', $3, NOW() + make_interval(secs => $4), $5, $6, $7)
+		ON CONFLICT (unique_key) DO NOTHING
+		RETURNING ` + jobColumns
+	inserted, err := scanJob(db.QueryRow(query, job.Type, []byte(job.Payload), job.MaxAttempts,
+		delay.Seconds(), job.Schedule, job.UniqueKey, result))
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	*job = inserted
+	return true, nil
+}
+
+// ClaimJob -> ambil satu job yang siap dijalankan dan kunci untuk worker ini. FOR UPDATE
+// SKIP LOCKED membuat worker lain (termasuk di instance lain) langsung melewati baris yang
+// sedang di-claim. Job running yang lock-nya kedaluwarsa (worker mati) ikut diambil ulang.
+func ClaimJob(db *sql.DB, workerID string, lock time.Duration) (*model.Job, error) {
+	query := `UPDATE jobs SET status = 'running', attempts = attempts + 1, locked_by = $1,
+			locked_until = NOW() + make_interval(secs => $2), started_at = NOW(), updated_at = NOW()
+		WHERE id = (
+			SELECT id FROM jobs
+			WHERE (status = 'queued' AND run_at <= NOW())
+				OR (status = 'running' AND locked_until < NOW())
+			ORDER BY run_at, id
+			FOR UPDATE SKIP LOCKED
+			LIMIT 1
+		)
+		RETURNING ` + jobColumns
+	job, err := scanJob(db.QueryRow(query, workerID, lock.Seconds()))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// updateLockedJob -> update job yang masih dikunci worker ini; false bila job sudah
+// diambil alih atau dibatalkan admin. $1 = id, $2 = worker
+func updateLockedJob(db *sql.DB, set string, args ...interface{}) (bool, error) {
+	query := `UPDATE jobs SET ` + set + `, updated_at = NOW()
+		WHERE id = $1 AND status = 'running' AND locked_by = $2`
+	result, err := db.Exec(query, args...)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+// ExtendJobLock -> perpanjang lock job yang sedang berjalan (heartbeat)
+func ExtendJobLock(db *sql.DB, id int64, workerID string, lock time.Duration) (bool, error) {
+	return updateLockedJob(db, `locked_until = NOW() + make_interval(secs => $3)`, id, workerID, lock.Seconds())
+}
+
+// SaveJobResult -> simpan progress / hasil job yang masih dikerjakan worker ini
+func SaveJobResult(db *sql.DB, id int64, workerID string, result []byte) error {
+	_, err := updateLockedJob(db, `result = $3`, id, workerID, result)
+	return err
+}
+
+// CompleteJob -> tandai job selesai
+func CompleteJob(db *sql.DB, id int64, workerID string) error {
+	_, err := updateLockedJob(db, `status = 'completed', finished_at = NOW(),
+		locked_by = NULL, locked_until = NULL, last_error = NULL`, id, workerID)
+	return err
+}
+
+// RetryJobLater -> kembalikan job ke antrean dengan jeda sebelum percobaan berikutnya
+func RetryJobLater(db *sql.DB, id int64, workerID string, delay time.Duration, lastError string) error {
+	_, err := updateLockedJob(db, `status = 'queued', run_at = NOW() + make_interval(secs => $3), last_error = $4,
+		locked_by = NULL, locked_until = NULL`, id, workerID, delay.Seconds(), lastError)
+	return err
+}
+
+// DeadLetterJob -> job berhenti dicoba ulang dan menunggu tindakan admin
+func DeadLetterJob(db *sql.DB, id int64, workerID string, lastError string) error {
+	_, err := updateLockedJob(db, `status = 'dead', last_error = $3, finished_at = NOW(),
+		locked_by = NULL, locked_until = NULL`, id, workerID, lastError)
+	return err
+}
+
+// ReleaseJob -> lepas job yang terputus karena shutdown tanpa menghitung percobaan
+func ReleaseJob(db *sql.DB, id int64, workerID string) error {
+	_, err := updateLockedJob(db, `status = 'queued', run_at = NOW(), attempts = attempts - 1,
+		locked_by = NULL, locked_until = NULL`, id, workerID)
+	return err
+}
+
+// FindJobs -> daftar job terbaru dengan filter status/type opsional
+func FindJobs(db *sql.DB, status, jobType string, page, limit int) ([]model.Job, int, error) {
+	where := `WHERE ($1 = '' OR status = $1) AND ($2 = '' OR type = $2)`
+
+	var total int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM jobs `+where, status, jobType).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT ` + jobColumns + ` FROM jobs ` + where + `
+		ORDER BY created_at DESC, id DESC LIMIT $3 OFFSET $4`
+	rows, err := db.Query(query, status, jobType, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var jobs []model.Job
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, total, rows.Err()
+}
+
+// FindJobByID -> detail job; sql.ErrNoRows bila tidak ada
+func FindJobByID(db *sql.DB, id int64) (*model.Job, error) {
+	job, err := scanJob(db.QueryRow(`SELECT `+jobColumns+` FROM jobs WHERE id = $1`, id))
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// RequeueJob -> jalankan ulang job dead/cancelled dari awal; sql.ErrNoRows bila status sudah berubah
+func RequeueJob(db *sql.DB, id int64) (*model.Job, error) {
+	query := `UPDATE jobs SET status = 'queued', attempts = 0, run_at = NOW(), updated_at = NOW(),
+			finished_at = NULL, last_error = NULL
+		WHERE id = $1 AND status IN ('dead', 'cancelled')
+		RETURNING ` + jobColumns
+	job, err := scanJob(db.QueryRow(query, id))
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// CancelJob -> batalkan job queued/running; worker yang menjalankan akan berhenti
+// saat heartbeat berikutnya. sql.ErrNoRows bila status sudah berubah
+func CancelJob(db *sql.DB, id int64) (*model.Job, error) {
+	query := `UPDATE jobs SET status = 'cancelled', finished_at = NOW(), updated_at = NOW(),
+			locked_by = NULL, locked_until = NULL
+		WHERE id = $1 AND status IN ('queued', 'running')
+		RETURNING ` + jobColumns
+	job, err := scanJob(db.QueryRow(query, id))
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// DeleteFinishedJobs -> hapus job completed/cancelled yang selesai sebelum before.
+// Job dead disimpan agar bisa diperiksa dan di-retry admin.
+func DeleteFinishedJobs(db *sql.DB, before time.Time) (int64, error) {
+	query := `DELETE FROM jobs WHERE status IN ('completed', 'cancelled') AND finished_at < $1`
+	result, err := db.Exec(query, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
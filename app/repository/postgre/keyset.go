@@ -0,0 +1,81 @@
+package postgre
+
+import (
+	"fmt"
+	"strings"
+
+	"go-fiber/helper"
+)
+
+// AlumniSortTypes -> kolom sort alumni yang bisa dipakai untuk cursor beserta tipe nilainya
+var AlumniSortTypes = map[string]string{
+	"id":          helper.FilterTypeInt,
+	"nama":        helper.FilterTypeString,
+	"email":       helper.FilterTypeString,
+	"jurusan":     helper.FilterTypeString,
+	"angkatan":    helper.FilterTypeInt,
+	"tahun_lulus": helper.FilterTypeInt,
+	"created_at":  helper.FilterTypeDate,
+}
+
+// PekerjaanSortTypes -> kolom sort pekerjaan yang bisa dipakai untuk cursor beserta tipe nilainya
+var PekerjaanSortTypes = map[string]string{
+	"id":                  helper.FilterTypeInt,
+	"nama_perusahaan":     helper.FilterTypeString,
+	"posisi_jabatan":      helper.FilterTypeString,
+	"bidang_industri":     helper.FilterTypeString,
+	"lokasi_kerja":        helper.FilterTypeString,
+	"tanggal_mulai_kerja": helper.FilterTypeDate,
+	"status_pekerjaan":    helper.FilterTypeString,
+	"created_at":          helper.FilterTypeDate,
+}
+
+// keysetOrder -> ORDER BY (column, id) searah; dibalik saat mengambil halaman sebelumnya
+func keysetOrder(column, order string, keyset *helper.Keyset) string {
+	dir := "ASC"
+	if (order == "desc") != (keyset != nil && keyset.Before) {
+		dir = "DESC"
+	}
+	if column == "id" {
+		return "id " + dir
+	}
+	return column + " " + dir + ", id " + dir
+}
+
+// keysetClause -> baris setelah (atau sebelum) posisi cursor memakai row comparison (column, id)
+func keysetClause(column, order string, keyset *helper.Keyset, argIndex int) (string, []interface{}) {
+	if keyset == nil {
+		return "", nil
+	}
+	op := ">"
+	if (order == "desc") != keyset.Before {
+		op = "<"
+	}
+	if column == "id" {
+		return fmt.Sprintf("id %s $%d", op, argIndex), []interface{}{keyset.ID}
+	}
+	return fmt.Sprintf("(%s, id) %s ($%d, $%d)", column, op, argIndex, argIndex+1), []interface{}{keyset.Value, keyset.ID}
+}
+
+// whereSQL -> gabungkan kondisi menjadi klausa WHERE (kosong bila tidak ada kondisi)
+func whereSQL(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(conditions, " AND ")
+}
+
+// trimKeysetPage -> buang item ke-(limit+1) sebagai penanda hasMore, lalu kembalikan
+// urutan asli bila halaman diambil mundur (before)
+func trimKeysetPage[T any](items []T, limit int, keyset *helper.Keyset) ([]T, bool) {
+	hasMore := len(items) > limit
+	if hasMore {
+		items = items[:limit]
+	}
+	if keyset != nil && keyset.Before {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+	return items, hasMore
+}
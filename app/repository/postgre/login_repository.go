@@ -0,0 +1,160 @@
+package postgre
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Login Repository Functions
+
+// RateLimitStore -> helper.RateLimitStore di tabel rate_limits agar counter dibagi
+// semua instance. Baris kedaluwarsa dihapus oleh job jobs.prune.
+type RateLimitStore struct {
+	db *sql.DB
+}
+
+func NewRateLimitStore(db *sql.DB) *RateLimitStore {
+	return &RateLimitStore{db: db}
+}
+
+func (s *RateLimitStore) Hit(key string, window time.Duration) (int, time.Duration, error) {
+	// Window yang sudah lewat dimulai ulang dari 1; sisa waktu dihitung di database
+	query := `
+		INSERT INTO rate_limits (key, count, expires_at)
+		VALUES ($1, 1, NOW() + make_interval(secs => $2))
+		ON CONFLICT (key) DO UPDATE SET
+			count = CASE WHEN rate_limits.expires_at > NOW() THEN rate_limits.count + 1 ELSE 1 END,
+			expires_at = CASE WHEN rate_limits.expires_at > NOW() THEN rate_limits.expires_at ELSE EXCLUDED.expires_at END
+		RETURNING count, EXTRACT(EPOCH FROM expires_at - NOW())
+	`
+	var count int
+	var remaining float64
+	if err := s.db.QueryRow(query, key, window.Seconds()).Scan(&count, &remaining); err != nil {
+		return 0, 0, err
+	}
+	return count, time.Duration(remaining * float64(time.Second)), nil
+}
+
+func (s *RateLimitStore) Reset(key string) error {
+	_, err := s.db.Exec(`DELETE FROM rate_limits WHERE key = $1`, key)
+	return err
+}
+
+// DeleteExpiredRateLimits -> hapus counter yang window-nya sudah lewat
+func DeleteExpiredRateLimits(db *sql.DB) (int64, error) {
+	result, err := db.Exec(`DELETE FROM rate_limits WHERE expires_at <= NOW()`)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// LoginState -> data alumni untuk login beserta status proteksi brute-force.
+// Durasi dihitung di database karena kolom TIMESTAMP tanpa zona waktu.
+type LoginState struct {
+	ID                  int
+	Email               string
+	Password            string
+	Role                string
+	SessionVersion      int
+	FailedLoginAttempts int
+	TOTPEnabled         bool
+	LockedFor           time.Duration // sisa waktu kunci akun; 0 bila tidak terkunci
+	SinceLastFailure    time.Duration // waktu sejak gagal login terakhir; 0 bila belum pernah
+}
+
+// GetLoginState -> cari alumni berdasarkan email untuk login
+func GetLoginState(db *sql.DB, email string) (*LoginState, error) {
+	return queryLoginState(db, `a.email = $1`, email)
+}
+
+// GetLoginStateByOIDCSubject -> alumni yang sudah tertaut ke identitas SSO
+func GetLoginStateByOIDCSubject(db *sql.DB, issuer, subject string) (*LoginState, error) {
+	return queryLoginState(db, `a.oidc_issuer = $1 AND a.oidc_subject = $2`, issuer, subject)
+}
+
+// GetLoginStateByEmailFold -> seperti GetLoginState tanpa membedakan huruf besar/kecil
+func GetLoginStateByEmailFold(db *sql.DB, email string) (*LoginState, error) {
+	return queryLoginState(db, `LOWER(a.email) = LOWER($1)`, email)
+}
+
+// GetLoginStateByNIM -> alumni dengan NIM tersebut; sql.ErrNoRows juga bila NIM dipakai
+// lebih dari satu alumni (tidak bisa ditautkan dengan aman)
+func GetLoginStateByNIM(db *sql.DB, nim string) (*LoginState, error) {
+	return queryLoginState(db, `a.nim = $1 AND (SELECT COUNT(*) FROM alumni WHERE nim = $1) = 1`, nim)
+}
+
+func queryLoginState(db *sql.DB, where string, args ...interface{}) (*LoginState, error) {
+	query := `
+		SELECT a.id, a.email, a.password, r.name, a.session_version, a.failed_login_attempts, a.totp_enabled,
+			COALESCE(GREATEST(EXTRACT(EPOCH FROM a.locked_until - NOW()), 0), 0),
+			COALESCE(EXTRACT(EPOCH FROM NOW() - a.last_failed_login_at), 0)
+		FROM alumni a
+		JOIN roles r ON r.id = a.role_id
+		WHERE ` + where
+	var s LoginState
+	var lockedFor, sinceFailure float64
+	err := db.QueryRow(query, args...).Scan(&s.ID, &s.Email, &s.Password, &s.Role, &s.SessionVersion,
+		&s.FailedLoginAttempts, &s.TOTPEnabled, &lockedFor, &sinceFailure)
+	if err != nil {
+		return nil, err
+	}
+	s.LockedFor = time.Duration(lockedFor * float64(time.Second))
+	s.SinceLastFailure = time.Duration(sinceFailure * float64(time.Second))
+	return &s, nil
+}
+
+// LinkOIDCIdentity -> tautkan identitas SSO ke alumni. false bila alumni sudah tertaut ke
+// identitas lain (atau tidak ditemukan)
+func LinkOIDCIdentity(db *sql.DB, id int, issuer, subject string) (bool, error) {
+	query := `
+		UPDATE alumni SET oidc_issuer = $2, oidc_subject = $3
+		WHERE id = $1 AND (oidc_subject IS NULL OR (oidc_issuer = $2 AND oidc_subject = $3))
+	`
+	result, err := db.Exec(query, id, issuer, subject)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	return rows > 0, err
+}
+
+// RecordLoginFailure -> tambah failed_login_attempts secara atomik. Bila mencapai
+// maxFailures, akun dikunci selama lockout dan counter dimulai ulang.
+// Mengembalikan jumlah gagal berjalan dan sisa waktu kunci (0 bila tidak terkunci)
+func RecordLoginFailure(db *sql.DB, id, maxFailures int, lockout time.Duration) (int, time.Duration, error) {
+	query := `
+		UPDATE alumni SET
+			failed_login_attempts = CASE WHEN failed_login_attempts + 1 >= $2 THEN 0 ELSE failed_login_attempts + 1 END,
+			locked_until = CASE WHEN failed_login_attempts + 1 >= $2 THEN NOW() + make_interval(secs => $3) ELSE locked_until END,
+			last_failed_login_at = NOW()
+		WHERE id = $1
+		RETURNING failed_login_attempts, COALESCE(GREATEST(EXTRACT(EPOCH FROM locked_until - NOW()), 0), 0)
+	`
+	var attempts int
+	var lockedFor float64
+	if err := db.QueryRow(query, id, maxFailures, lockout.Seconds()).Scan(&attempts, &lockedFor); err != nil {
+		return 0, 0, err
+	}
+	return attempts, time.Duration(lockedFor * float64(time.Second)), nil
+}
+
+// ResetLoginFailures -> hapus counter gagal login dan kunci akun (login berhasil atau unlock admin)
+func ResetLoginFailures(db *sql.DB, id int) error {
+	query := `
+		UPDATE alumni SET failed_login_attempts = 0, locked_until = NULL, last_failed_login_at = NULL
+		WHERE id = $1
+	`
+	result, err := db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package postgre
+
+import (
+	"database/sql"
+)
+
+// MFA Repository Functions
+
+// MFAState -> data alumni untuk endpoint 2FA
+type MFAState struct {
+	ID                int
+	Email             string
+	Password          string
+	Role              string
+	SessionVersion    int
+	TOTPEnabled       bool
+	TOTPSecret        string
+	TOTPPendingSecret string
+}
+
+// GetMFAState -> status 2FA alumni; sql.ErrNoRows bila tidak ditemukan
+func GetMFAState(db *sql.DB, id int) (*MFAState, error) {
+	query := `
+		SELECT a.id, a.email, a.password, r.name, a.session_version, a.totp_enabled,
+			COALESCE(a.totp_secret, ''), COALESCE(a.totp_pending_secret, '')
+		FROM alumni a
+		JOIN roles r ON r.id = a.role_id
+		WHERE a.id = $1
+	`
+	var s MFAState
+	err := db.QueryRow(query, id).Scan(&s.ID, &s.Email, &s.Password, &s.Role, &s.SessionVersion,
+		&s.TOTPEnabled, &s.TOTPSecret, &s.TOTPPendingSecret)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// SetPendingTOTPSecret -> simpan secret enrollment yang belum diverifikasi. Gagal (false)
+// bila alumni tidak ditemukan atau 2FA sudah aktif
+func SetPendingTOTPSecret(db *sql.DB, id int, secret string) (bool, error) {
+	result, err := db.Exec(`UPDATE alumni SET totp_pending_secret = $2 WHERE id = $1 AND NOT totp_enabled`, id, secret)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	return rows > 0, err
+}
+
+// EnableTOTP -> aktifkan 2FA dan simpan hash kode pemulihan dalam satu transaksi. Secret harus
+// masih sama dengan pending secret agar enrollment paralel tidak saling menimpa
+func EnableTOTP(db *sql.DB, id int, secret string, step int64, recoveryHashes []string) (bool, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		UPDATE alumni SET totp_enabled = TRUE, totp_secret = $2, totp_pending_secret = NULL, totp_last_step = $3
+		WHERE id = $1 AND totp_pending_secret = $2 AND NOT totp_enabled
+	`, id, secret, step)
+	if err != nil {
+		return false, err
+	}
+	if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+		return false, err
+	}
+	if err := replaceRecoveryCodes(tx, id, recoveryHashes); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// UseTOTPStep -> tandai step TOTP sudah dipakai. false bila step ini (atau yang lebih baru)
+// sudah pernah dipakai, sehingga kode yang sama tidak bisa diputar ulang
+func UseTOTPStep(db *sql.DB, id int, step int64) (bool, error) {
+	result, err := db.Exec(`UPDATE alumni SET totp_last_step = $2 WHERE id = $1 AND totp_enabled AND totp_last_step < $2`, id, step)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	return rows > 0, err
+}
+
+// ConsumeRecoveryCode -> hapus kode pemulihan secara atomik; false bila tidak ada
+func ConsumeRecoveryCode(db *sql.DB, id int, codeHash string) (bool, error) {
+	result, err := db.Exec(`DELETE FROM mfa_recovery_codes WHERE alumni_id = $1 AND code_hash = $2`, id, codeHash)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	return rows > 0, err
+}
+
+// ReplaceRecoveryCodes -> ganti semua kode pemulihan (kode lama tidak berlaku lagi)
+func ReplaceRecoveryCodes(db *sql.DB, id int, recoveryHashes []string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := replaceRecoveryCodes(tx, id, recoveryHashes); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func replaceRecoveryCodes(tx DBTX, id int, recoveryHashes []string) error {
+	if _, err := tx.Exec(`DELETE FROM mfa_recovery_codes WHERE alumni_id = $1`, id); err != nil {
+		return err
+	}
+	for _, hash := range recoveryHashes {
+		if _, err := tx.Exec(`INSERT INTO mfa_recovery_codes (alumni_id, code_hash) VALUES ($1, $2)`, id, hash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DisableTOTP -> hapus secret, kode pemulihan, dan status 2FA
+func DisableTOTP(db *sql.DB, id int) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		UPDATE alumni SET totp_enabled = FALSE, totp_secret = NULL, totp_pending_secret = NULL, totp_last_step = 0
+		WHERE id = $1
+	`, id)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM mfa_recovery_codes WHERE alumni_id = $1`, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,109 @@
+package postgre
+
+import (
+	"database/sql"
+	model "go-fiber/app/model/postgre"
+	"time"
+)
+
+// Notification Repository Functions
+
+// InsertEmailOutbox -> simpan email ke outbox; panggil dengan *sql.Tx agar email hanya
+// terkirim bila transaksi penulisan datanya commit. Mengembalikan false tanpa error bila
+// unique_key sudah ada (email yang sama sudah pernah di-enqueue)
+func InsertEmailOutbox(db DBTX, email *model.EmailOutbox) (bool, error) {
+	query := `INSERT INTO email_outbox (alumni_id, template, locale, "to", subject, text_body, html_body, unique_key)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (unique_key) DO NOTHING
+		RETURNING id, status, next_attempt_at, created_at`
+	err := db.QueryRow(query, email.AlumniID, email.Template, email.Locale, email.To, email.Subject,
+		email.TextBody, email.HTMLBody, email.UniqueKey).
+		Scan(&email.ID, &email.Status, &email.NextAttemptAt, &email.CreatedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ClaimEmailOutbox -> ambil satu email yang siap dikirim (FOR UPDATE SKIP LOCKED). Email
+// "sending" yang lock-nya kedaluwarsa (dispatcher mati di tengah pengiriman) ikut diambil ulang.
+func ClaimEmailOutbox(db *sql.DB, lock time.Duration) (*model.EmailOutbox, error) {
+	query := `UPDATE email_outbox SET status = 'sending', attempts = attempts + 1,
+			locked_until = NOW() + make_interval(secs => $1)
+		WHERE id = (
+			SELECT id FROM email_outbox
+			WHERE (status = 'pending' AND next_attempt_at <= NOW())
+				OR (status = 'sending' AND locked_until < NOW())
+			ORDER BY next_attempt_at, id
+			FOR UPDATE SKIP LOCKED
+			LIMIT 1
+		)
+		RETURNING id, alumni_id, template, locale, "to", subject, text_body, html_body, status, attempts,
+			next_attempt_at, last_error, unique_key, created_at, sent_at`
+
+	e := new(model.EmailOutbox)
+	err := db.QueryRow(query, lock.Seconds()).Scan(&e.ID, &e.AlumniID, &e.Template, &e.Locale, &e.To, &e.Subject,
+		&e.TextBody, &e.HTMLBody, &e.Status, &e.Attempts, &e.NextAttemptAt, &e.LastError, &e.UniqueKey, &e.CreatedAt, &e.SentAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// MarkEmailSent -> email terkirim
+func MarkEmailSent(db *sql.DB, id int64) error {
+	_, err := db.Exec(`UPDATE email_outbox SET status = 'sent', sent_at = NOW(), locked_until = NULL, last_error = NULL
+		WHERE id = $1`, id)
+	return err
+}
+
+// MarkEmailRetry -> kembalikan email ke pending untuk dicoba lagi setelah delay
+func MarkEmailRetry(db *sql.DB, id int64, delay time.Duration, lastError string) error {
+	_, err := db.Exec(`UPDATE email_outbox SET status = 'pending', next_attempt_at = NOW() + make_interval(secs => $2),
+			last_error = $3, locked_until = NULL
+		WHERE id = $1`, id, delay.Seconds(), lastError)
+	return err
+}
+
+// MarkEmailFailed -> email berhenti dicoba setelah batas percobaan
+func MarkEmailFailed(db *sql.DB, id int64, lastError string) error {
+	_, err := db.Exec(`UPDATE email_outbox SET status = 'failed', last_error = $2, locked_until = NULL
+		WHERE id = $1`, id, lastError)
+	return err
+}
+
+// FindTracerReminderTargets -> alumni yang pekerjaan terakhirnya (updated_at terbaru)
+// diperbarui sebelum cutoff; pekerjaan di trash tidak dihitung
+func FindTracerReminderTargets(db *sql.DB, cutoff time.Time) ([]model.TracerReminderTarget, error) {
+	query := `SELECT a.id, a.nama, a.email, p.last_updated
+		FROM (
+			SELECT alumni_id, MAX(updated_at) AS last_updated
+			FROM pekerjaan_alumni
+			WHERE is_delete IS NULL
+			GROUP BY alumni_id
+		) p
+		JOIN alumni a ON a.id = p.alumni_id
+		WHERE p.last_updated < $1
+		ORDER BY a.id`
+	rows, err := db.Query(query, cutoff)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var targets []model.TracerReminderTarget
+	for rows.Next() {
+		var t model.TracerReminderTarget
+		if err := rows.Scan(&t.ID, &t.Nama, &t.Email, &t.LastUpdated); err != nil {
+			return nil, err
+		}
+		targets = append(targets, t)
+	}
+	return targets, rows.Err()
+}
@@ -0,0 +1,66 @@
+package postgre
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Password Repository Functions
+
+// GetAlumniSessionVersion -> session_version alumni; sql.ErrNoRows bila alumni sudah dihapus
+func GetAlumniSessionVersion(db *sql.DB, id int) (int, error) {
+	var version int
+	err := db.QueryRow(`SELECT session_version FROM alumni WHERE id = $1`, id).Scan(&version)
+	return version, err
+}
+
+// UpdateAlumniPassword -> simpan hash password baru dan naikkan session_version sehingga
+// semua token lama ditolak. Mengembalikan session_version baru
+func UpdateAlumniPassword(db DBTX, id int, hashed string) (int, error) {
+	var version int
+	err := db.QueryRow(`UPDATE alumni SET password = $2, session_version = session_version + 1, updated_at = NOW()
+		WHERE id = $1 RETURNING session_version`, id, hashed).Scan(&version)
+	return version, err
+}
+
+// CreatePasswordReset -> simpan token reset baru yang berlaku selama ttl dan kembalikan
+// waktu kedaluwarsanya. Token lama alumni dan token kedaluwarsa milik siapa pun dihapus
+// sehingga hanya tautan terakhir yang berlaku
+func CreatePasswordReset(db *sql.DB, alumniID int, tokenHash string, ttl time.Duration) (time.Time, error) {
+	var expiresAt time.Time
+	tx, err := db.Begin()
+	if err != nil {
+		return expiresAt, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM password_resets WHERE alumni_id = $1 OR expires_at < NOW()`, alumniID); err != nil {
+		return expiresAt, err
+	}
+	err = tx.QueryRow(`INSERT INTO password_resets (alumni_id, token_hash, expires_at)
+		VALUES ($1, $2, NOW() + make_interval(secs => $3))
+		RETURNING expires_at`, alumniID, tokenHash, ttl.Seconds()).Scan(&expiresAt)
+	if err != nil {
+		return expiresAt, err
+	}
+	return expiresAt, tx.Commit()
+}
+
+// FindPasswordReset -> alumni_id pemilik token yang masih berlaku; sql.ErrNoRows bila token
+// tidak ada, sudah dipakai, atau kedaluwarsa
+func FindPasswordReset(db *sql.DB, tokenHash string) (int, error) {
+	var alumniID int
+	err := db.QueryRow(`SELECT alumni_id FROM password_resets
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()`, tokenHash).Scan(&alumniID)
+	return alumniID, err
+}
+
+// ConsumePasswordReset -> tandai token terpakai; sql.ErrNoRows bila token sudah dipakai
+// atau kedaluwarsa. Panggil dalam transaksi yang sama dengan UpdateAlumniPassword
+func ConsumePasswordReset(db DBTX, tokenHash string) (int, error) {
+	var alumniID int
+	err := db.QueryRow(`UPDATE password_resets SET used_at = NOW()
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING alumni_id`, tokenHash).Scan(&alumniID)
+	return alumniID, err
+}
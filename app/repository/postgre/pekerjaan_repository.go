@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	model "go-fiber/app/model/postgre"
+	"go-fiber/helper"
 	"log"
 	"strings"
 	"time"
@@ -12,7 +13,7 @@ import (
 // Pekerjaan Alumni Repository Functions
 
 func GetAllPekerjaan(db *sql.DB) ([]model.PekerjaanAlumni, error) {
-	query := `SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at, is_delete FROM pekerjaan_alumni WHERE is_delete IS NULL ORDER BY created_at DESC`
+	query := `SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, negara, provinsi, kota, latitude, longitude, gaji_range, gaji_min, gaji_max, gaji_mata_uang, gaji_periode, gaji_rahasia, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at, is_delete, version, paruh_waktu, company_id, industry_id FROM pekerjaan_alumni WHERE is_delete IS NULL ORDER BY created_at DESC`
 	rows, err := db.Query(query)
 	if err != nil {
 		return nil, err
@@ -22,7 +23,7 @@ func GetAllPekerjaan(db *sql.DB) ([]model.PekerjaanAlumni, error) {
 	var pekerjaan []model.PekerjaanAlumni
 	for rows.Next() {
 		var p model.PekerjaanAlumni
-		err := rows.Scan(&p.ID, &p.AlumniID, &p.NamaPerusahaan, &p.PosisiJabatan, &p.BidangIndustri, &p.LokasiKerja, &p.GajiRange, &p.TanggalMulaiKerja, &p.TanggalSelesaiKerja, &p.StatusPekerjaan, &p.DeskripsiPekerjaan, &p.CreatedAt, &p.UpdatedAt, &p.IsDeleted)
+		err := rows.Scan(&p.ID, &p.AlumniID, &p.NamaPerusahaan, &p.PosisiJabatan, &p.BidangIndustri, &p.LokasiKerja, &p.Negara, &p.Provinsi, &p.Kota, &p.Latitude, &p.Longitude, &p.GajiRange, &p.GajiMin, &p.GajiMax, &p.GajiMataUang, &p.GajiPeriode, &p.GajiRahasia, &p.TanggalMulaiKerja, &p.TanggalSelesaiKerja, &p.StatusPekerjaan, &p.DeskripsiPekerjaan, &p.CreatedAt, &p.UpdatedAt, &p.IsDeleted, &p.Version, &p.ParuhWaktu, &p.CompanyID, &p.IndustryID)
 		if err != nil {
 			return nil, err
 		}
@@ -31,18 +32,18 @@ func GetAllPekerjaan(db *sql.DB) ([]model.PekerjaanAlumni, error) {
 	return pekerjaan, nil
 }
 
-func GetPekerjaanByID(db *sql.DB, id int) (*model.PekerjaanAlumni, error) {
+func GetPekerjaanByID(db DBTX, id int) (*model.PekerjaanAlumni, error) {
 	pekerjaan := new(model.PekerjaanAlumni)
-	query := `SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at, is_delete FROM pekerjaan_alumni WHERE id = $1 AND is_delete IS NULL`
-	err := db.QueryRow(query, id).Scan(&pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan, &pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja, &pekerjaan.GajiRange, &pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja, &pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan, &pekerjaan.CreatedAt, &pekerjaan.UpdatedAt, &pekerjaan.IsDeleted)
+	query := `SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, negara, provinsi, kota, latitude, longitude, gaji_range, gaji_min, gaji_max, gaji_mata_uang, gaji_periode, gaji_rahasia, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at, is_delete, version, paruh_waktu, company_id, industry_id FROM pekerjaan_alumni WHERE id = $1 AND is_delete IS NULL`
+	err := db.QueryRow(query, id).Scan(&pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan, &pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja, &pekerjaan.Negara, &pekerjaan.Provinsi, &pekerjaan.Kota, &pekerjaan.Latitude, &pekerjaan.Longitude, &pekerjaan.GajiRange, &pekerjaan.GajiMin, &pekerjaan.GajiMax, &pekerjaan.GajiMataUang, &pekerjaan.GajiPeriode, &pekerjaan.GajiRahasia, &pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja, &pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan, &pekerjaan.CreatedAt, &pekerjaan.UpdatedAt, &pekerjaan.IsDeleted, &pekerjaan.Version, &pekerjaan.ParuhWaktu, &pekerjaan.CompanyID, &pekerjaan.IndustryID)
 	if err != nil {
 		return nil, err
 	}
 	return pekerjaan, nil
 }
 
-func GetPekerjaanByAlumniID(db *sql.DB, alumniID int) ([]model.PekerjaanAlumni, error) {
-	query := `SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at, is_delete FROM pekerjaan_alumni WHERE alumni_id = $1 AND is_delete IS NULL ORDER BY tanggal_mulai_kerja DESC`
+func GetPekerjaanByAlumniID(db DBTX, alumniID int) ([]model.PekerjaanAlumni, error) {
+	query := `SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, negara, provinsi, kota, latitude, longitude, gaji_range, gaji_min, gaji_max, gaji_mata_uang, gaji_periode, gaji_rahasia, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at, is_delete, version, paruh_waktu, company_id, industry_id FROM pekerjaan_alumni WHERE alumni_id = $1 AND is_delete IS NULL ORDER BY tanggal_mulai_kerja DESC`
 	rows, err := db.Query(query, alumniID)
 	if err != nil {
 		return nil, err
@@ -52,7 +53,7 @@ func GetPekerjaanByAlumniID(db *sql.DB, alumniID int) ([]model.PekerjaanAlumni,
 	var pekerjaan []model.PekerjaanAlumni
 	for rows.Next() {
 		var p model.PekerjaanAlumni
-		err := rows.Scan(&p.ID, &p.AlumniID, &p.NamaPerusahaan, &p.PosisiJabatan, &p.BidangIndustri, &p.LokasiKerja, &p.GajiRange, &p.TanggalMulaiKerja, &p.TanggalSelesaiKerja, &p.StatusPekerjaan, &p.DeskripsiPekerjaan, &p.CreatedAt, &p.UpdatedAt, &p.IsDeleted)
+		err := rows.Scan(&p.ID, &p.AlumniID, &p.NamaPerusahaan, &p.PosisiJabatan, &p.BidangIndustri, &p.LokasiKerja, &p.Negara, &p.Provinsi, &p.Kota, &p.Latitude, &p.Longitude, &p.GajiRange, &p.GajiMin, &p.GajiMax, &p.GajiMataUang, &p.GajiPeriode, &p.GajiRahasia, &p.TanggalMulaiKerja, &p.TanggalSelesaiKerja, &p.StatusPekerjaan, &p.DeskripsiPekerjaan, &p.CreatedAt, &p.UpdatedAt, &p.IsDeleted, &p.Version, &p.ParuhWaktu, &p.CompanyID, &p.IndustryID)
 		if err != nil {
 			return nil, err
 		}
@@ -61,16 +62,16 @@ func GetPekerjaanByAlumniID(db *sql.DB, alumniID int) ([]model.PekerjaanAlumni,
 	return pekerjaan, nil
 }
 
-func CreatePekerjaan(db *sql.DB, req *model.CreatePekerjaanAlumniRepositoryRequest) (*model.PekerjaanAlumni, error) {
-	query := `INSERT INTO pekerjaan_alumni (alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at) 
-			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id, created_at, updated_at`
+func CreatePekerjaan(db DBTX, req *model.CreatePekerjaanAlumniRepositoryRequest) (*model.PekerjaanAlumni, error) {
+	query := `INSERT INTO pekerjaan_alumni (alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, gaji_min, gaji_max, gaji_mata_uang, gaji_periode, gaji_rahasia, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at, paruh_waktu, company_id, industry_id, negara, provinsi, kota, latitude, longitude) 
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24) RETURNING id, created_at, updated_at, version`
 
 	now := time.Now()
-	var id int
+	var id, version int
 	var createdAt, updatedAt time.Time
 
-	err := db.QueryRow(query, req.AlumniID, req.NamaPerusahaan, req.PosisiJabatan, req.BidangIndustri, req.LokasiKerja, req.GajiRange, req.TanggalMulaiKerja, req.TanggalSelesaiKerja, req.StatusPekerjaan, req.DeskripsiPekerjaan, now, now).
-		Scan(&id, &createdAt, &updatedAt)
+	err := db.QueryRow(query, req.AlumniID, req.NamaPerusahaan, req.PosisiJabatan, req.BidangIndustri, req.LokasiKerja, req.GajiMin, req.GajiMax, req.GajiMataUang, req.GajiPeriode, req.GajiRahasia, req.TanggalMulaiKerja, req.TanggalSelesaiKerja, req.StatusPekerjaan, req.DeskripsiPekerjaan, now, now, req.ParuhWaktu, req.CompanyID, req.IndustryID, req.Negara, req.Provinsi, req.Kota, req.Latitude, req.Longitude).
+		Scan(&id, &createdAt, &updatedAt, &version)
 	if err != nil {
 		return nil, err
 	}
@@ -79,33 +80,62 @@ func CreatePekerjaan(db *sql.DB, req *model.CreatePekerjaanAlumniRepositoryReque
 		ID:                  id,
 		AlumniID:            req.AlumniID,
 		NamaPerusahaan:      req.NamaPerusahaan,
+		CompanyID:           req.CompanyID,
 		PosisiJabatan:       req.PosisiJabatan,
 		BidangIndustri:      req.BidangIndustri,
+		IndustryID:          req.IndustryID,
 		LokasiKerja:         req.LokasiKerja,
-		GajiRange:           req.GajiRange,
+		Negara:              req.Negara,
+		Provinsi:            req.Provinsi,
+		Kota:                req.Kota,
+		Latitude:            req.Latitude,
+		Longitude:           req.Longitude,
+		GajiMin:             req.GajiMin,
+		GajiMax:             req.GajiMax,
+		GajiMataUang:        req.GajiMataUang,
+		GajiPeriode:         req.GajiPeriode,
+		GajiRahasia:         req.GajiRahasia,
 		TanggalMulaiKerja:   req.TanggalMulaiKerja,
 		TanggalSelesaiKerja: req.TanggalSelesaiKerja,
 		StatusPekerjaan:     req.StatusPekerjaan,
+		ParuhWaktu:          req.ParuhWaktu,
 		DeskripsiPekerjaan:  req.DeskripsiPekerjaan,
 		CreatedAt:           createdAt,
 		UpdatedAt:           updatedAt,
+		Version:             version,
 	}
 	return pekerjaan, nil
 }
 
-func UpdatePekerjaan(db *sql.DB, id int, req *model.UpdatePekerjaanAlumniRepositoryRequest) (*model.PekerjaanAlumni, error) {
+// UpdatePekerjaan -> update pekerjaan dan naikkan versinya. expectedVersion (If-Match) dicek di
+// WHERE yang sama; helper.ErrVersionConflict bila versinya sudah berubah.
+func UpdatePekerjaan(db DBTX, id int, req *model.UpdatePekerjaanAlumniRepositoryRequest, expectedVersion *int) (*model.PekerjaanAlumni, error) {
 	// Build dynamic query based on provided fields
 	setParts := []string{
 		"nama_perusahaan = $1",
 		"posisi_jabatan = $2",
 		"bidang_industri = $3",
 		"lokasi_kerja = $4",
-		"gaji_range = $5",
-		"tanggal_mulai_kerja = $6",
-		"tanggal_selesai_kerja = $7",
-		"status_pekerjaan = $8",
-		"deskripsi_pekerjaan = $9",
-		"updated_at = $10",
+		"gaji_min = $5",
+		"gaji_max = $6",
+		"gaji_mata_uang = $7",
+		"gaji_periode = $8",
+		"gaji_rahasia = $9",
+		"gaji_range = NULL",
+		"tanggal_mulai_kerja = $10",
+		"tanggal_selesai_kerja = $11",
+		"status_pekerjaan = $12",
+		"deskripsi_pekerjaan = $13",
+		"updated_at = $14",
+		"paruh_waktu = $15",
+		"company_id = $16",
+		"industry_id = $17",
+		"negara = $18",
+		"provinsi = $19",
+		"kota = $20",
+		"latitude = $21",
+		"longitude = $22",
+		"version = version + 1",
 	}
 
 	args := []interface{}{
@@ -113,39 +143,60 @@ func UpdatePekerjaan(db *sql.DB, id int, req *model.UpdatePekerjaanAlumniReposit
 		req.PosisiJabatan,
 		req.BidangIndustri,
 		req.LokasiKerja,
-		req.GajiRange,
+		req.GajiMin,
+		req.GajiMax,
+		req.GajiMataUang,
+		req.GajiPeriode,
+		req.GajiRahasia,
 		req.TanggalMulaiKerja,
 		req.TanggalSelesaiKerja,
 		req.StatusPekerjaan,
 		req.DeskripsiPekerjaan,
 		time.Now(),
+		req.ParuhWaktu,
+		req.CompanyID,
+		req.IndustryID,
+		req.Negara,
+		req.Provinsi,
+		req.Kota,
+		req.Latitude,
+		req.Longitude,
 		id,
 	}
 
-	query := "UPDATE pekerjaan_alumni SET " + strings.Join(setParts, ", ") + " WHERE id = $11 RETURNING id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at"
+	where := " WHERE id = $23"
+	if expectedVersion != nil {
+		where += " AND version = $24"
+		args = append(args, *expectedVersion)
+	}
+
+	query := "UPDATE pekerjaan_alumni SET " + strings.Join(setParts, ", ") + where + " RETURNING id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, negara, provinsi, kota, latitude, longitude, gaji_range, gaji_min, gaji_max, gaji_mata_uang, gaji_periode, gaji_rahasia, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at, version, paruh_waktu, company_id, industry_id"
 
 	pekerjaan := new(model.PekerjaanAlumni)
-	err := db.QueryRow(query, args...).Scan(&pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan, &pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja, &pekerjaan.GajiRange, &pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja, &pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan, &pekerjaan.CreatedAt, &pekerjaan.UpdatedAt)
+	err := db.QueryRow(query, args...).Scan(&pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan, &pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja, &pekerjaan.Negara, &pekerjaan.Provinsi, &pekerjaan.Kota, &pekerjaan.Latitude, &pekerjaan.Longitude, &pekerjaan.GajiRange, &pekerjaan.GajiMin, &pekerjaan.GajiMax, &pekerjaan.GajiMataUang, &pekerjaan.GajiPeriode, &pekerjaan.GajiRahasia, &pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja, &pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan, &pekerjaan.CreatedAt, &pekerjaan.UpdatedAt, &pekerjaan.Version, &pekerjaan.ParuhWaktu, &pekerjaan.CompanyID, &pekerjaan.IndustryID)
+	if err == sql.ErrNoRows && expectedVersion != nil {
+		return nil, helper.ErrVersionConflict
+	}
 	if err != nil {
 		return nil, err
 	}
 	return pekerjaan, nil
 }
 
-func DeletePekerjaan(db *sql.DB, id int) error {
+func DeletePekerjaan(db DBTX, id int) error {
 	query := `DELETE FROM pekerjaan_alumni WHERE id = $1`
 	_, err := db.Exec(query, id)
 	return err
 }
 
-func SoftDeletePekerjaan(db *sql.DB, id int) error {
-	query := `UPDATE pekerjaan_alumni SET is_delete = $1 WHERE id = $2`
+func SoftDeletePekerjaan(db DBTX, id int) error {
+	query := `UPDATE pekerjaan_alumni SET is_delete = $1, version = version + 1 WHERE id = $2`
 	_, err := db.Exec(query, time.Now(), id)
 	return err
 }
 
 func RestorePekerjaan(db *sql.DB, id int) error {
-	query := `UPDATE pekerjaan_alumni SET is_delete = NULL WHERE id = $1`
+	query := `UPDATE pekerjaan_alumni SET is_delete = NULL, version = version + 1 WHERE id = $1`
 	_, err := db.Exec(query, id)
 	return err
 }
@@ -156,16 +207,26 @@ func HardDeletePekerjaan(db *sql.DB, id int) error {
 	return err
 }
 
+// PurgeDeletedPekerjaan -> hard delete pekerjaan yang sudah di trash sebelum cutoff
+func PurgeDeletedPekerjaan(db *sql.DB, cutoff time.Time) (int64, error) {
+	query := `DELETE FROM pekerjaan_alumni WHERE is_delete IS NOT NULL AND is_delete < $1`
+	result, err := db.Exec(query, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 func GetPekerjaanWithDeletedByID(db *sql.DB, id int) (*model.PekerjaanAlumni, error) {
 	p := new(model.PekerjaanAlumni)
 	query := `SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri,
-                     lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja,
-                     status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at, is_delete
+                     lokasi_kerja, negara, provinsi, kota, latitude, longitude, gaji_range, gaji_min, gaji_max, gaji_mata_uang, gaji_periode, gaji_rahasia, tanggal_mulai_kerja, tanggal_selesai_kerja,
+                     status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at, is_delete, version, paruh_waktu, company_id, industry_id
               FROM pekerjaan_alumni WHERE id = $1`
 	err := db.QueryRow(query, id).Scan(&p.ID, &p.AlumniID, &p.NamaPerusahaan,
-		&p.PosisiJabatan, &p.BidangIndustri, &p.LokasiKerja, &p.GajiRange,
+		&p.PosisiJabatan, &p.BidangIndustri, &p.LokasiKerja, &p.Negara, &p.Provinsi, &p.Kota, &p.Latitude, &p.Longitude, &p.GajiRange, &p.GajiMin, &p.GajiMax, &p.GajiMataUang, &p.GajiPeriode, &p.GajiRahasia,
 		&p.TanggalMulaiKerja, &p.TanggalSelesaiKerja, &p.StatusPekerjaan,
-		&p.DeskripsiPekerjaan, &p.CreatedAt, &p.UpdatedAt, &p.IsDeleted)
+		&p.DeskripsiPekerjaan, &p.CreatedAt, &p.UpdatedAt, &p.IsDeleted, &p.Version, &p.ParuhWaktu, &p.CompanyID, &p.IndustryID)
 	if err != nil {
 		return nil, err
 	}
@@ -173,26 +234,65 @@ func GetPekerjaanWithDeletedByID(db *sql.DB, id int) (*model.PekerjaanAlumni, er
 }
 
 // GetPekerjaanRepo -> ambil data pekerjaan alumni dari DB dengan pagination, sorting, dan search
-func GetPekerjaanRepo(db *sql.DB, search, sortBy, order string, limit, offset int) ([]model.PekerjaanAlumni, error) {
+func GetPekerjaanRepo(db *sql.DB, search string, filters []helper.Filter, sortBy, order string, limit, offset int) ([]model.PekerjaanAlumni, error) {
+	conditions, args := pekerjaanConditions(search, filters)
+	args = append(args, limit, offset)
+
 	query := fmt.Sprintf(`
-		SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at, is_delete
+		SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, negara, provinsi, kota, latitude, longitude, gaji_range, gaji_min, gaji_max, gaji_mata_uang, gaji_periode, gaji_rahasia, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at, is_delete, version, paruh_waktu, company_id, industry_id
 		FROM pekerjaan_alumni
-		WHERE (nama_perusahaan ILIKE $1 OR posisi_jabatan ILIKE $1 OR bidang_industri ILIKE $1 OR lokasi_kerja ILIKE $1) AND is_delete IS NULL
-		ORDER BY %s %s
-		LIMIT $2 OFFSET $3
-	`, sortBy, order)
+		%s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d
+	`, whereSQL(conditions), keysetOrder(sortBy, order, nil), len(args)-1, len(args))
 
-	rows, err := db.Query(query, "%"+search+"%", limit, offset)
+	rows, err := db.Query(query, args...)
 	if err != nil {
 		log.Println("Query error:", err)
 		return nil, err
 	}
 	defer rows.Close()
 
+	return scanPekerjaanRows(rows)
+}
+
+// GetPekerjaanKeysetRepo -> listing pekerjaan dengan keyset pagination pada (sortBy, id), tanpa OFFSET
+func GetPekerjaanKeysetRepo(db *sql.DB, search string, filters []helper.Filter, sortBy, order string, keyset *helper.Keyset, limit int) ([]model.PekerjaanAlumni, bool, error) {
+	conditions, args := pekerjaanConditions(search, filters)
+	if clause, keysetArgs := keysetClause(sortBy, order, keyset, len(args)+1); clause != "" {
+		conditions = append(conditions, clause)
+		args = append(args, keysetArgs...)
+	}
+	args = append(args, limit+1)
+
+	query := fmt.Sprintf(`
+		SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, negara, provinsi, kota, latitude, longitude, gaji_range, gaji_min, gaji_max, gaji_mata_uang, gaji_periode, gaji_rahasia, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at, is_delete, version, paruh_waktu, company_id, industry_id
+		FROM pekerjaan_alumni
+		%s
+		ORDER BY %s
+		LIMIT $%d
+	`, whereSQL(conditions), keysetOrder(sortBy, order, keyset), len(args))
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		log.Println("Query error:", err)
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	pekerjaan, err := scanPekerjaanRows(rows)
+	if err != nil {
+		return nil, false, err
+	}
+	pekerjaan, hasMore := trimKeysetPage(pekerjaan, limit, keyset)
+	return pekerjaan, hasMore, nil
+}
+
+func scanPekerjaanRows(rows *sql.Rows) ([]model.PekerjaanAlumni, error) {
 	var pekerjaan []model.PekerjaanAlumni
 	for rows.Next() {
 		var p model.PekerjaanAlumni
-		err := rows.Scan(&p.ID, &p.AlumniID, &p.NamaPerusahaan, &p.PosisiJabatan, &p.BidangIndustri, &p.LokasiKerja, &p.GajiRange, &p.TanggalMulaiKerja, &p.TanggalSelesaiKerja, &p.StatusPekerjaan, &p.DeskripsiPekerjaan, &p.CreatedAt, &p.UpdatedAt, &p.IsDeleted)
+		err := rows.Scan(&p.ID, &p.AlumniID, &p.NamaPerusahaan, &p.PosisiJabatan, &p.BidangIndustri, &p.LokasiKerja, &p.Negara, &p.Provinsi, &p.Kota, &p.Latitude, &p.Longitude, &p.GajiRange, &p.GajiMin, &p.GajiMax, &p.GajiMataUang, &p.GajiPeriode, &p.GajiRahasia, &p.TanggalMulaiKerja, &p.TanggalSelesaiKerja, &p.StatusPekerjaan, &p.DeskripsiPekerjaan, &p.CreatedAt, &p.UpdatedAt, &p.IsDeleted, &p.Version, &p.ParuhWaktu, &p.CompanyID, &p.IndustryID)
 		if err != nil {
 			return nil, err
 		}
@@ -201,50 +301,256 @@ func GetPekerjaanRepo(db *sql.DB, search, sortBy, order string, limit, offset in
 	return pekerjaan, nil
 }
 
-// GetDeletedPekerjaanRepo -> ambil data pekerjaan alumni yang sudah dihapus dengan pagination
-func GetDeletedPekerjaanRepo(db *sql.DB, offset, limit int) ([]model.PekerjaanAlumni, int, error) {
+// StreamPekerjaanRepo -> seluruh pekerjaan yang cocok dengan search dan filter beserta NIM dan nama
+// alumninya, diteruskan satu per satu ke fn (untuk export)
+func StreamPekerjaanRepo(db *sql.DB, search string, filters []helper.Filter, sortBy, order string, fn func(model.PekerjaanExportRow) error) error {
+	conditions, args := pekerjaanConditions(search, filters)
+	query := fmt.Sprintf(`
+		SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, negara, provinsi, kota, latitude, longitude, gaji_range, gaji_min, gaji_max, gaji_mata_uang, gaji_periode, gaji_rahasia, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at, is_delete,
+			COALESCE((SELECT a.nim FROM alumni a WHERE a.id = pekerjaan_alumni.alumni_id), ''),
+			COALESCE((SELECT a.nama FROM alumni a WHERE a.id = pekerjaan_alumni.alumni_id), '')
+		FROM pekerjaan_alumni
+		%s
+		ORDER BY %s
+	`, whereSQL(conditions), keysetOrder(sortBy, order, nil))
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	return streamRows(rows, func(rows *sql.Rows) (model.PekerjaanExportRow, error) {
+		var r model.PekerjaanExportRow
+		p := &r.PekerjaanAlumni
+		err := rows.Scan(&p.ID, &p.AlumniID, &p.NamaPerusahaan, &p.PosisiJabatan, &p.BidangIndustri, &p.LokasiKerja, &p.Negara, &p.Provinsi, &p.Kota, &p.Latitude, &p.Longitude, &p.GajiRange, &p.GajiMin, &p.GajiMax, &p.GajiMataUang, &p.GajiPeriode, &p.GajiRahasia, &p.TanggalMulaiKerja, &p.TanggalSelesaiKerja, &p.StatusPekerjaan, &p.DeskripsiPekerjaan, &p.CreatedAt, &p.UpdatedAt, &p.IsDeleted, &r.NIM, &r.NamaAlumni)
+		return r, err
+	}, fn)
+}
+
+// GetDeletedPekerjaanRepo -> ambil data pekerjaan alumni yang sudah dihapus dengan pagination.
+// alumniID != nil membatasi hasil ke pekerjaan milik alumni tersebut (akses user).
+func GetDeletedPekerjaanRepo(db *sql.DB, alumniID *int, offset, limit int) ([]model.PekerjaanAlumni, int, error) {
+	conditions, args := deletedPekerjaanConditions(alumniID)
+
 	// Query untuk mengambil data yang sudah dihapus
-	query := `SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at, is_delete
+	query := fmt.Sprintf(`SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, negara, provinsi, kota, latitude, longitude, gaji_range, gaji_min, gaji_max, gaji_mata_uang, gaji_periode, gaji_rahasia, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at, is_delete, version, paruh_waktu, company_id, industry_id
 		FROM pekerjaan_alumni
-		WHERE is_delete IS NOT NULL
-		ORDER BY is_delete DESC
-		LIMIT $1 OFFSET $2`
+		%s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d`, whereSQL(conditions), keysetOrder("is_delete", "desc", nil), len(args)+1, len(args)+2)
 
-	rows, err := db.Query(query, limit, offset)
+	rows, err := db.Query(query, append(args, limit, offset)...)
 	if err != nil {
 		log.Println("Query error:", err)
 		return nil, 0, err
 	}
 	defer rows.Close()
 
-	var pekerjaan []model.PekerjaanAlumni
-	for rows.Next() {
-		var p model.PekerjaanAlumni
-		err := rows.Scan(&p.ID, &p.AlumniID, &p.NamaPerusahaan, &p.PosisiJabatan, &p.BidangIndustri, &p.LokasiKerja, &p.GajiRange, &p.TanggalMulaiKerja, &p.TanggalSelesaiKerja, &p.StatusPekerjaan, &p.DeskripsiPekerjaan, &p.CreatedAt, &p.UpdatedAt, &p.IsDeleted)
-		if err != nil {
-			return nil, 0, err
-		}
-		pekerjaan = append(pekerjaan, p)
+	pekerjaan, err := scanPekerjaanRows(rows)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	// Query untuk menghitung total data yang sudah dihapus
-	countQuery := `SELECT COUNT(*) FROM pekerjaan_alumni WHERE is_delete IS NOT NULL`
-	var total int
-	err = db.QueryRow(countQuery).Scan(&total)
-	if err != nil && err != sql.ErrNoRows {
+	total, err := CountDeletedPekerjaanRepo(db, alumniID)
+	if err != nil {
 		return nil, 0, err
 	}
 
 	return pekerjaan, total, nil
 }
 
+// GetDeletedPekerjaanKeysetRepo -> trash listing dengan keyset pagination pada (is_delete DESC, id DESC)
+func GetDeletedPekerjaanKeysetRepo(db *sql.DB, alumniID *int, keyset *helper.Keyset, limit int) ([]model.PekerjaanAlumni, bool, error) {
+	conditions, args := deletedPekerjaanConditions(alumniID)
+	if clause, keysetArgs := keysetClause("is_delete", "desc", keyset, len(args)+1); clause != "" {
+		conditions = append(conditions, clause)
+		args = append(args, keysetArgs...)
+	}
+	args = append(args, limit+1)
+
+	query := fmt.Sprintf(`SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, negara, provinsi, kota, latitude, longitude, gaji_range, gaji_min, gaji_max, gaji_mata_uang, gaji_periode, gaji_rahasia, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at, is_delete, version, paruh_waktu, company_id, industry_id
+		FROM pekerjaan_alumni
+		%s
+		ORDER BY %s
+		LIMIT $%d`, whereSQL(conditions), keysetOrder("is_delete", "desc", keyset), len(args))
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		log.Println("Query error:", err)
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	pekerjaan, err := scanPekerjaanRows(rows)
+	if err != nil {
+		return nil, false, err
+	}
+	pekerjaan, hasMore := trimKeysetPage(pekerjaan, limit, keyset)
+	return pekerjaan, hasMore, nil
+}
+
+// CountDeletedPekerjaanRepo -> hitung total data yang sudah dihapus
+func CountDeletedPekerjaanRepo(db *sql.DB, alumniID *int) (int, error) {
+	conditions, args := deletedPekerjaanConditions(alumniID)
+	var total int
+	err := db.QueryRow(`SELECT COUNT(*) FROM pekerjaan_alumni `+whereSQL(conditions), args...).Scan(&total)
+	if err != nil && err != sql.ErrNoRows {
+		return 0, err
+	}
+	return total, nil
+}
+
+func deletedPekerjaanConditions(alumniID *int) ([]string, []interface{}) {
+	conditions := []string{"is_delete IS NOT NULL"}
+	var args []interface{}
+	if alumniID != nil {
+		conditions = append(conditions, "alumni_id = $1")
+		args = append(args, *alumniID)
+	}
+	return conditions, args
+}
+
 // CountPekerjaanRepo -> hitung total data untuk pagination
-func CountPekerjaanRepo(db *sql.DB, search string) (int, error) {
+func CountPekerjaanRepo(db *sql.DB, search string, filters []helper.Filter) (int, error) {
+	var total int
+	conditions, args := pekerjaanConditions(search, filters)
+	err := db.QueryRow(`SELECT COUNT(*) FROM pekerjaan_alumni `+whereSQL(conditions), args...).Scan(&total)
+	if err != nil && err != sql.ErrNoRows {
+		return 0, err
+	}
+	return total, nil
+}
+
+// pekerjaanConditions -> kondisi search (ILIKE), filter query language, dan hanya data yang belum dihapus
+func pekerjaanConditions(search string, filters []helper.Filter) ([]string, []interface{}) {
+	conditions := []string{
+		"(nama_perusahaan ILIKE $1 OR posisi_jabatan ILIKE $1 OR bidang_industri ILIKE $1 OR lokasi_kerja ILIKE $1)",
+		"is_delete IS NULL",
+	}
+	args := []interface{}{"%" + search + "%"}
+
+	filterConditions, filterArgs := buildSQLFilter(filters, len(args)+1)
+	conditions = append(conditions, filterConditions...)
+	args = append(args, filterArgs...)
+	return conditions, args
+}
+
+// SearchPekerjaanRepo -> full-text search memakai search_vector (GIN), diurutkan berdasarkan ts_rank_cd
+func SearchPekerjaanRepo(db *sql.DB, query string, limit, offset int) ([]model.PekerjaanSearchResult, error) {
+	sqlQuery := `
+		SELECT p.id, p.alumni_id, p.nama_perusahaan, p.posisi_jabatan, p.bidang_industri, p.lokasi_kerja, p.negara, p.provinsi, p.kota, p.latitude, p.longitude, p.gaji_range, p.gaji_min, p.gaji_max, p.gaji_mata_uang, p.gaji_periode, p.gaji_rahasia, p.tanggal_mulai_kerja, p.tanggal_selesai_kerja, p.status_pekerjaan, p.deskripsi_pekerjaan, p.created_at, p.updated_at, p.is_delete,
+			ts_rank_cd(p.search_vector, q) AS score,
+			ts_headline('idn_unaccent', p.nama_perusahaan, q, $4),
+			ts_headline('idn_unaccent', p.posisi_jabatan, q, $4),
+			ts_headline('idn_unaccent', p.bidang_industri, q, $4),
+			ts_headline('idn_unaccent', p.lokasi_kerja, q, $4),
+			ts_headline('idn_unaccent', coalesce(p.deskripsi_pekerjaan, ''), q, $4)
+		FROM pekerjaan_alumni p, websearch_to_tsquery('idn_unaccent', $1) q
+		WHERE p.search_vector @@ q AND p.is_delete IS NULL
+		ORDER BY score DESC, p.id
+		LIMIT $2 OFFSET $3`
+
+	rows, err := db.Query(sqlQuery, query, limit, offset, searchHeadlineOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []model.PekerjaanSearchResult
+	for rows.Next() {
+		var r model.PekerjaanSearchResult
+		var hPerusahaan, hPosisi, hBidang, hLokasi, hDeskripsi string
+		err := rows.Scan(&r.ID, &r.AlumniID, &r.NamaPerusahaan, &r.PosisiJabatan, &r.BidangIndustri, &r.LokasiKerja, &r.Negara, &r.Provinsi, &r.Kota, &r.Latitude, &r.Longitude, &r.GajiRange, &r.GajiMin, &r.GajiMax, &r.GajiMataUang, &r.GajiPeriode, &r.GajiRahasia, &r.TanggalMulaiKerja, &r.TanggalSelesaiKerja, &r.StatusPekerjaan, &r.DeskripsiPekerjaan, &r.CreatedAt, &r.UpdatedAt, &r.IsDeleted,
+			&r.Score, &hPerusahaan, &hPosisi, &hBidang, &hLokasi, &hDeskripsi)
+		if err != nil {
+			return nil, err
+		}
+		r.Highlights = collectHeadlines(map[string]string{
+			"nama_perusahaan":     hPerusahaan,
+			"posisi_jabatan":      hPosisi,
+			"bidang_industri":     hBidang,
+			"lokasi_kerja":        hLokasi,
+			"deskripsi_pekerjaan": hDeskripsi,
+		})
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// CountSearchPekerjaanRepo -> hitung total hasil full-text search pekerjaan
+func CountSearchPekerjaanRepo(db *sql.DB, query string) (int, error) {
 	var total int
-	countQuery := `SELECT COUNT(*) FROM pekerjaan_alumni WHERE (nama_perusahaan ILIKE $1 OR posisi_jabatan ILIKE $1 OR bidang_industri ILIKE $1 OR lokasi_kerja ILIKE $1) AND is_delete IS NULL`
-	err := db.QueryRow(countQuery, "%"+search+"%").Scan(&total)
+	err := db.QueryRow(`SELECT COUNT(*) FROM pekerjaan_alumni WHERE search_vector @@ websearch_to_tsquery('idn_unaccent', $1) AND is_delete IS NULL`, query).Scan(&total)
 	if err != nil && err != sql.ErrNoRows {
 		return 0, err
 	}
 	return total, nil
 }
+
+// ListLegacySalaryPekerjaan -> id dan teks gaji_range pekerjaan yang belum punya gaji terstruktur
+func ListLegacySalaryPekerjaan(db *sql.DB) (map[int]string, error) {
+	rows, err := db.Query(`SELECT id, gaji_range FROM pekerjaan_alumni
+		WHERE gaji_range IS NOT NULL AND gaji_range <> '' AND gaji_min IS NULL AND gaji_max IS NULL`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	legacy := map[int]string{}
+	for rows.Next() {
+		var id int
+		var text string
+		if err := rows.Scan(&id, &text); err != nil {
+			return nil, err
+		}
+		legacy[id] = text
+	}
+	return legacy, rows.Err()
+}
+
+// MigratePekerjaanSalary -> simpan gaji hasil parsing teks lama dan kosongkan gaji_range. Baris yang
+// sudah diubah sejak dibaca (gaji_range berbeda) tidak disentuh.
+func MigratePekerjaanSalary(db *sql.DB, id int, legacy string, salary helper.Salary) (bool, error) {
+	result, err := db.Exec(`UPDATE pekerjaan_alumni SET gaji_min = $1, gaji_max = $2, gaji_mata_uang = $3, gaji_periode = $4,
+		gaji_range = NULL, updated_at = NOW(), version = version + 1
+		WHERE id = $5 AND gaji_range = $6`, salary.Min, salary.Max, salary.Currency, salary.Period, id, legacy)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+// ListUnlocatedPekerjaan -> id dan lokasi_kerja pekerjaan yang belum punya lokasi terstruktur
+func ListUnlocatedPekerjaan(db *sql.DB) (map[int]string, error) {
+	rows, err := db.Query(`SELECT id, lokasi_kerja FROM pekerjaan_alumni
+		WHERE negara IS NULL AND provinsi IS NULL AND kota IS NULL AND latitude IS NULL AND lokasi_kerja <> ''`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	unlocated := map[int]string{}
+	for rows.Next() {
+		var id int
+		var lokasi string
+		if err := rows.Scan(&id, &lokasi); err != nil {
+			return nil, err
+		}
+		unlocated[id] = lokasi
+	}
+	return unlocated, rows.Err()
+}
+
+// BackfillPekerjaanLocation -> simpan lokasi hasil tebakan dari lokasi_kerja. Baris yang sudah
+// diubah sejak dibaca (lokasi_kerja berbeda atau lokasi sudah terisi) tidak disentuh.
+func BackfillPekerjaanLocation(db *sql.DB, id int, lokasiKerja string, loc helper.Location) (bool, error) {
+	result, err := db.Exec(`UPDATE pekerjaan_alumni SET negara = $1, provinsi = $2, kota = $3, latitude = $4, longitude = $5,
+		updated_at = NOW(), version = version + 1
+		WHERE id = $6 AND lokasi_kerja = $7 AND negara IS NULL AND provinsi IS NULL AND kota IS NULL AND latitude IS NULL`,
+		loc.Negara, loc.Provinsi, loc.Kota, loc.Latitude, loc.Longitude, id, lokasiKerja)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
@@ -0,0 +1,136 @@
+package postgre
+
+import (
+	"database/sql"
+	"fmt"
+
+	model "go-fiber/app/model/postgre"
+	"go-fiber/helper"
+
+	"github.com/lib/pq"
+)
+
+// Reference Repository Functions. Nama tabel dan kolom diambil dari helper.ReferenceKind (konstan),
+// bukan dari input client.
+
+const referenceColumns = `id, nama, aliases, keys, created_at, updated_at`
+
+func scanReference(row interface{ Scan(...any) error }) (*model.ReferenceData, error) {
+	var ref model.ReferenceData
+	err := row.Scan(&ref.ID, &ref.Nama, pq.Array(&ref.Aliases), pq.Array(&ref.Keys), &ref.CreatedAt, &ref.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &ref, nil
+}
+
+// ListReferences -> semua perusahaan / bidang industri urut nama; search dicocokkan dengan nama
+// dan alias yang sudah dinormalisasi
+func ListReferences(db *sql.DB, kind helper.ReferenceKind, search string) ([]model.ReferenceData, error) {
+	query := `SELECT ` + referenceColumns + ` FROM ` + kind.Name
+	args := []interface{}{}
+	if key := helper.NormalizeReferenceName(search); key != "" {
+		query += ` WHERE EXISTS (SELECT 1 FROM unnest(keys) k WHERE k LIKE '%' || $1 || '%')`
+		args = append(args, key)
+	}
+	rows, err := db.Query(query+` ORDER BY nama, id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	refs := []model.ReferenceData{}
+	for rows.Next() {
+		ref, err := scanReference(rows)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, *ref)
+	}
+	return refs, rows.Err()
+}
+
+// GetReferenceByID -> sql.ErrNoRows bila tidak ditemukan
+func GetReferenceByID(db DBTX, kind helper.ReferenceKind, id int) (*model.ReferenceData, error) {
+	return scanReference(db.QueryRow(`SELECT `+referenceColumns+` FROM `+kind.Name+` WHERE id = $1`, id))
+}
+
+// GetReferenceByKey -> data referensi yang nama atau aliasnya berkunci key (sql.ErrNoRows bila tidak ada)
+func GetReferenceByKey(db DBTX, kind helper.ReferenceKind, key string) (*model.ReferenceData, error) {
+	return scanReference(db.QueryRow(`SELECT `+referenceColumns+` FROM `+kind.Name+` WHERE $1 = ANY(keys) ORDER BY id LIMIT 1`, key))
+}
+
+// GetReferenceConflict -> data referensi lain (selain exclude) yang memakai salah satu keys
+// (sql.ErrNoRows bila tidak ada)
+func GetReferenceConflict(db DBTX, kind helper.ReferenceKind, keys []string, exclude int) (*model.ReferenceData, error) {
+	query := `SELECT ` + referenceColumns + ` FROM ` + kind.Name + ` WHERE keys && $1 AND id <> $2 ORDER BY id LIMIT 1`
+	return scanReference(db.QueryRow(query, pq.Array(keys), exclude))
+}
+
+// CreateReference -> simpan ref dan isi ID serta timestamp-nya
+func CreateReference(db DBTX, kind helper.ReferenceKind, ref *model.ReferenceData) error {
+	query := `INSERT INTO ` + kind.Name + ` (nama, aliases, keys) VALUES ($1, $2, $3) RETURNING id, created_at, updated_at`
+	return db.QueryRow(query, ref.Nama, pq.Array(ref.Aliases), pq.Array(ref.Keys)).Scan(&ref.ID, &ref.CreatedAt, &ref.UpdatedAt)
+}
+
+// UpdateReference -> simpan nama, alias, dan keys ref
+func UpdateReference(db DBTX, kind helper.ReferenceKind, ref *model.ReferenceData) error {
+	query := `UPDATE ` + kind.Name + ` SET nama = $1, aliases = $2, keys = $3, updated_at = NOW() WHERE id = $4 RETURNING updated_at`
+	return db.QueryRow(query, ref.Nama, pq.Array(ref.Aliases), pq.Array(ref.Keys), ref.ID).Scan(&ref.UpdatedAt)
+}
+
+func DeleteReferences(db DBTX, kind helper.ReferenceKind, ids []int) error {
+	_, err := db.Exec(`DELETE FROM `+kind.Name+` WHERE id = ANY($1)`, pq.Array(ids))
+	return err
+}
+
+// RelinkPekerjaanReference -> pekerjaan yang terhubung ke salah satu from dipindahkan ke ref dan
+// teks bebasnya diganti nama ref (dipakai saat rename dan merge)
+func RelinkPekerjaanReference(db DBTX, kind helper.ReferenceKind, from []int, ref *model.ReferenceData) (int64, error) {
+	return linkPekerjaan(db, kind, kind.IDField+` = ANY($3)`, pq.Array(from), ref)
+}
+
+// LinkPekerjaanByText -> hubungkan pekerjaan tanpa referensi yang teks bebasnya persis text ke ref
+func LinkPekerjaanByText(db DBTX, kind helper.ReferenceKind, text string, ref *model.ReferenceData) (int64, error) {
+	return linkPekerjaan(db, kind, kind.IDField+` IS NULL AND `+kind.TextField+` = $3`, text, ref)
+}
+
+func linkPekerjaan(db DBTX, kind helper.ReferenceKind, where string, arg interface{}, ref *model.ReferenceData) (int64, error) {
+	query := fmt.Sprintf(`UPDATE pekerjaan_alumni SET %s = $1, %s = $2, updated_at = NOW(), version = version + 1 WHERE %s`,
+		kind.IDField, kind.TextField, where)
+	result, err := db.Exec(query, ref.ID, ref.Nama, arg)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// UnlinkPekerjaanReference -> lepas pekerjaan dari ref yang akan dihapus; teks bebasnya tetap
+func UnlinkPekerjaanReference(db DBTX, kind helper.ReferenceKind, id int) error {
+	query := fmt.Sprintf(`UPDATE pekerjaan_alumni SET %s = NULL, version = version + 1 WHERE %s = $1`, kind.IDField, kind.IDField)
+	_, err := db.Exec(query, id)
+	return err
+}
+
+// UnlinkedPekerjaanTexts -> teks bebas pekerjaan yang belum terhubung ke referensi, yang paling
+// sering dipakai lebih dulu
+func UnlinkedPekerjaanTexts(db DBTX, kind helper.ReferenceKind) ([]string, error) {
+	query := fmt.Sprintf(`SELECT %[2]s FROM pekerjaan_alumni
+		WHERE %[1]s IS NULL AND %[2]s <> ''
+		GROUP BY %[2]s ORDER BY COUNT(*) DESC, %[2]s`, kind.IDField, kind.TextField)
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	texts := []string{}
+	for rows.Next() {
+		var text string
+		if err := rows.Scan(&text); err != nil {
+			return nil, err
+		}
+		texts = append(texts, text)
+	}
+	return texts, rows.Err()
+}
@@ -4,12 +4,13 @@ import (
 	"database/sql"
 	"fmt"
 	model "go-fiber/app/model/postgre"
+	"go-fiber/helper"
 	"strings"
 )
 
 func CreateRole(db *sql.DB, req *model.CreateRoleRequest) (*model.Role, error) {
 	var role model.Role
-	err := db.QueryRow(`INSERT INTO roles (name) VALUES ($1) RETURNING id, name`, req.Name).Scan(&role.ID, &role.Name)
+	err := db.QueryRow(`INSERT INTO roles (name) VALUES ($1) RETURNING id, name, version`, req.Name).Scan(&role.ID, &role.Name, &role.Version)
 	if err != nil {
 		return nil, err
 	}
@@ -18,7 +19,16 @@ func CreateRole(db *sql.DB, req *model.CreateRoleRequest) (*model.Role, error) {
 
 func GetRoleByID(db *sql.DB, id int) (*model.Role, error) {
 	var role model.Role
-	err := db.QueryRow(`SELECT id, name FROM roles WHERE id = $1`, id).Scan(&role.ID, &role.Name)
+	err := db.QueryRow(`SELECT id, name, version FROM roles WHERE id = $1`, id).Scan(&role.ID, &role.Name, &role.Version)
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func GetRoleByName(db *sql.DB, name string) (*model.Role, error) {
+	var role model.Role
+	err := db.QueryRow(`SELECT id, name, version FROM roles WHERE name = $1`, name).Scan(&role.ID, &role.Name, &role.Version)
 	if err != nil {
 		return nil, err
 	}
@@ -26,7 +36,7 @@ func GetRoleByID(db *sql.DB, id int) (*model.Role, error) {
 }
 
 func ListRoles(db *sql.DB) ([]model.Role, error) {
-	rows, err := db.Query(`SELECT id, name FROM roles ORDER BY id`)
+	rows, err := db.Query(`SELECT id, name, version FROM roles ORDER BY id`)
 	if err != nil {
 		return nil, err
 	}
@@ -34,7 +44,7 @@ func ListRoles(db *sql.DB) ([]model.Role, error) {
 	var roles []model.Role
 	for rows.Next() {
 		var r model.Role
-		if err := rows.Scan(&r.ID, &r.Name); err != nil {
+		if err := rows.Scan(&r.ID, &r.Name, &r.Version); err != nil {
 			return nil, err
 		}
 		roles = append(roles, r)
@@ -42,7 +52,9 @@ func ListRoles(db *sql.DB) ([]model.Role, error) {
 	return roles, nil
 }
 
-func UpdateRole(db *sql.DB, id int, req *model.UpdateRoleRequest) (*model.Role, error) {
+// UpdateRole -> update role dan naikkan versinya. expectedVersion (If-Match) dicek di WHERE
+// yang sama; helper.ErrVersionConflict bila versinya sudah berubah.
+func UpdateRole(db *sql.DB, id int, req *model.UpdateRoleRequest, expectedVersion *int) (*model.Role, error) {
 	setParts := []string{}
 	args := []interface{}{}
 	idx := 1
@@ -54,10 +66,20 @@ func UpdateRole(db *sql.DB, id int, req *model.UpdateRoleRequest) (*model.Role,
 	if len(setParts) == 0 {
 		return GetRoleByID(db, id)
 	}
+	setParts = append(setParts, "version = version + 1")
 	args = append(args, id)
-	query := "UPDATE roles SET " + strings.Join(setParts, ", ") + fmt.Sprintf(" WHERE id = $%d RETURNING id, name", idx)
+	where := fmt.Sprintf(" WHERE id = $%d", idx)
+	if expectedVersion != nil {
+		where += fmt.Sprintf(" AND version = $%d", idx+1)
+		args = append(args, *expectedVersion)
+	}
+	query := "UPDATE roles SET " + strings.Join(setParts, ", ") + where + " RETURNING id, name, version"
 	var role model.Role
-	if err := db.QueryRow(query, args...).Scan(&role.ID, &role.Name); err != nil {
+	err := db.QueryRow(query, args...).Scan(&role.ID, &role.Name, &role.Version)
+	if err == sql.ErrNoRows && expectedVersion != nil {
+		return nil, helper.ErrVersionConflict
+	}
+	if err != nil {
 		return nil, err
 	}
 	return &role, nil
@@ -0,0 +1,18 @@
+package postgre
+
+import "database/sql"
+
+// streamRows -> scan baris satu per satu lalu teruskan ke fn tanpa menampung semuanya di memori
+func streamRows[T any](rows *sql.Rows, scan func(*sql.Rows) (T, error), fn func(T) error) error {
+	defer rows.Close()
+	for rows.Next() {
+		item, err := scan(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,10 @@
+package postgre
+
+import "database/sql"
+
+// DBTX -> *sql.DB atau *sql.Tx, agar fungsi repository bisa dipakai di dalam transaksi
+type DBTX interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
@@ -1,11 +1,12 @@
 package mongo
 
 import (
+	"errors"
 	"go-fiber/app/model/mongo"
 	repository "go-fiber/app/repository/mongo"
+	"go-fiber/helper"
 	utils "go-fiber/utils/mongo"
 	"log"
-	"os"
 	"strconv"
 	"strings"
 
@@ -25,63 +26,186 @@ func GetAllAlumniService(c *fiber.Ctx, db *mongoDB.Database) error {
 	}
 
 	// Parse query parameters
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	limit, _ := strconv.Atoi(c.Query("limit", "10"))
 	sortBy := c.Query("sortBy", "id")
 	order := c.Query("order", "asc")
 	search := c.Query("search", "")
 
-	// Debug logging
-	log.Printf("=== Service Debug ===")
-	log.Printf("Service received parameters - page: %d, limit: %d, sortBy: '%s', order: '%s', search: '%s' (len: %d)", page, limit, sortBy, order, search, len(search))
-	log.Printf("Search is empty: %t", search == "")
-
-	// Hitung offset untuk pagination
-	offset := (page - 1) * limit
-
 	// Validasi input
-	sortByWhitelist := map[string]bool{"id": true, "nama": true, "email": true, "jurusan": true, "angkatan": true, "tahun_lulus": true, "created_at": true}
-	if !sortByWhitelist[sortBy] {
+	if _, ok := repository.AlumniSortTypes[sortBy]; !ok {
 		sortBy = "id"
 	}
 	if strings.ToLower(order) != "desc" {
 		order = "asc"
 	}
 
-	// Ambil data dari repository
-	alumni, err := repository.GetAlumniRepo(db, search, sortBy, order, limit, offset)
+	filters, err := helper.ParseFilters(c.Queries(), repository.AlumniFilterFields)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"error": "Failed to fetch alumni",
+		return c.Status(400).JSON(fiber.Map{
+			"error": err.Error(),
 		})
 	}
 
-	total, err := repository.CountAlumniRepo(db, search)
+	page, err := parseListPage(c, sortBy, order, repository.AlumniSortTypes[sortBy], helper.FilterTypeObjectID)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Ambil data dari repository: mode cursor tanpa $skip, mode page tetap pakai offset
+	var alumni []mongo.Alumni
+	var hasMore bool
+	if page.Keyset != nil {
+		alumni, hasMore, err = repository.GetAlumniKeysetRepo(db, search, filters, sortBy, order, page.Keyset, page.Limit)
+	} else {
+		alumni, err = repository.GetAlumniRepo(db, search, filters, sortBy, order, page.Limit, page.Offset)
+	}
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{
-			"error": "Failed to count alumni",
+			"error": "Failed to fetch alumni",
 		})
 	}
 
+	// Total selalu dihitung pada mode page, pada mode cursor hanya bila with_total=true
+	var total *int
+	if page.Keyset == nil || page.WithTotal {
+		count, err := repository.CountAlumniRepo(db, search, filters)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{
+				"error": "Failed to count alumni",
+			})
+		}
+		total = &count
+		if page.Keyset == nil {
+			hasMore = page.Offset+len(alumni) < count
+		}
+	}
+
+	var first, last *helper.CursorPosition
+	if len(alumni) > 0 {
+		first = alumniPosition(alumni[0], sortBy)
+		last = alumniPosition(alumni[len(alumni)-1], sortBy)
+	}
+
+	meta := page.meta(total, hasMore, first, last)
+	meta.SortBy = sortBy
+	meta.Order = order
+	meta.Search = search
+
 	// Buat response pakai model
 	response := mongo.GetAllAlumniResponse{
 		Success: true,
 		Message: "Berhasil mengambil data alumni",
 		Data: mongo.AlumniData{
 			Items: alumni,
+			Meta:  meta,
+		},
+	}
+
+	return c.JSON(response)
+}
+
+// alumniPosition -> nilai kolom sort + id alumni untuk membentuk cursor
+func alumniPosition(a mongo.Alumni, sortBy string) *helper.CursorPosition {
+	var value interface{}
+	switch sortBy {
+	case "nama":
+		value = a.Nama
+	case "email":
+		value = a.Email
+	case "jurusan":
+		value = a.Jurusan
+	case "angkatan":
+		value = a.Angkatan
+	case "tahun_lulus":
+		value = a.TahunLulus
+	case "created_at":
+		value = a.CreatedAt
+	default:
+		value = a.ID
+	}
+	return &helper.CursorPosition{Value: value, ID: a.ID}
+}
+
+// SearchAlumniService -> full-text search alumni dengan ranking relevansi dan highlight
+func SearchAlumniService(c *fiber.Ctx, db *mongoDB.Database) error {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(mongo.SearchAlumniResponse{
+			Success: false,
+			Message: "Parameter q wajib diisi",
+			Data:    mongo.SearchAlumniData{Items: []mongo.AlumniSearchResult{}},
+		})
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	offset := (page - 1) * limit
+
+	results, err := repository.SearchAlumniRepo(db, query, limit, offset)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(mongo.SearchAlumniResponse{
+			Success: false,
+			Message: "Gagal mencari alumni: " + err.Error(),
+			Data:    mongo.SearchAlumniData{Items: []mongo.AlumniSearchResult{}},
+		})
+	}
+
+	total, err := repository.CountSearchAlumniRepo(db, query)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(mongo.SearchAlumniResponse{
+			Success: false,
+			Message: "Gagal menghitung hasil pencarian: " + err.Error(),
+			Data:    mongo.SearchAlumniData{Items: []mongo.AlumniSearchResult{}},
+		})
+	}
+
+	terms := helper.SearchTerms(query)
+	for i := range results {
+		results[i].Highlights = highlightFields(terms, map[string]string{
+			"nama":    results[i].Nama,
+			"nim":     results[i].NIM,
+			"email":   results[i].Email,
+			"jurusan": results[i].Jurusan,
+		})
+	}
+	if results == nil {
+		results = []mongo.AlumniSearchResult{}
+	}
+
+	return c.JSON(mongo.SearchAlumniResponse{
+		Success: true,
+		Message: "Berhasil mencari alumni",
+		Data: mongo.SearchAlumniData{
+			Items: results,
 			Meta: mongo.MetaInfo{
 				Page:   page,
 				Limit:  limit,
-				Total:  total,
+				Total:  &total,
 				Pages:  (total + limit - 1) / limit,
-				SortBy: sortBy,
-				Order:  order,
-				Search: search,
+				SortBy: "relevance",
+				Order:  "desc",
+				Search: query,
 			},
 		},
-	}
+	})
+}
 
-	return c.JSON(response)
+// highlightFields -> hanya field yang mengandung term pencarian yang dikembalikan
+func highlightFields(terms []string, fields map[string]string) map[string]string {
+	highlights := map[string]string{}
+	for name, value := range fields {
+		if h := helper.Highlight(value, terms); h != "" {
+			highlights[name] = h
+		}
+	}
+	return highlights
 }
 
 func GetAlumniByIDService(c *fiber.Ctx, db *mongoDB.Database) error {
@@ -119,6 +243,7 @@ func GetAlumniByIDService(c *fiber.Ctx, db *mongoDB.Database) error {
 		})
 	}
 
+	c.Set(fiber.HeaderETag, helper.ETag(alumni.Version))
 	return c.Status(fiber.StatusOK).JSON(mongo.GetAlumniByIDResponse{
 		Success: true,
 		Message: "Berhasil mengambil data alumni",
@@ -126,63 +251,66 @@ func GetAlumniByIDService(c *fiber.Ctx, db *mongoDB.Database) error {
 	})
 }
 
-func CreateAlumniService(c *fiber.Ctx, db *mongoDB.Database) error {
-	var req mongo.CreateAlumniRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(mongo.CreateAlumniResponse{
-			Success: false,
-			Message: "Format data tidak valid: " + err.Error(),
-			Data:    mongo.Alumni{},
-		})
-	}
-
+// alumniCreateRequest -> validasi request create alumni dan hash password-nya; dipakai create
+// biasa dan batch
+func alumniCreateRequest(req *mongo.CreateAlumniRequest) (*mongo.CreateAlumniRepositoryRequest, *fiber.Error) {
 	if req.NIM == "" || req.Nama == "" || req.Jurusan == "" || req.Email == "" || req.Password == "" || req.RoleID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(mongo.CreateAlumniResponse{
-			Success: false,
-			Message: "NIM, nama, jurusan, email, password, dan role_id wajib diisi",
-			Data:    mongo.Alumni{},
-		})
+		return nil, fiber.NewError(fiber.StatusBadRequest, "NIM, nama, jurusan, email, password, dan role_id wajib diisi")
 	}
-
 	if req.Angkatan <= 0 || req.TahunLulus <= 0 {
-		return c.Status(fiber.StatusBadRequest).JSON(mongo.CreateAlumniResponse{
-			Success: false,
-			Message: "Angkatan dan tahun lulus harus lebih dari 0",
-			Data:    mongo.Alumni{},
-		})
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Angkatan dan tahun lulus harus lebih dari 0")
 	}
 
 	// Convert RoleID string to ObjectID
 	roleID, err := primitive.ObjectIDFromHex(req.RoleID)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(mongo.CreateAlumniResponse{
-			Success: false,
-			Message: "Role ID tidak valid",
-			Data:    mongo.Alumni{},
-		})
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Role ID tidak valid")
 	}
 
+	if err := utils.ValidatePassword(req.Password, req.Email, req.NIM); err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Password tidak memenuhi kebijakan: "+err.Error())
+	}
+	tanggalLahir, ferr := parseTanggalLahir(req.TanggalLahir)
+	if ferr != nil {
+		return nil, ferr
+	}
 	hashed, err := utils.HashPassword(req.Password)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(mongo.CreateAlumniResponse{
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Gagal memproses password")
+	}
+
+	return &mongo.CreateAlumniRepositoryRequest{
+		NIM:          req.NIM,
+		Nama:         req.Nama,
+		Jurusan:      req.Jurusan,
+		Angkatan:     req.Angkatan,
+		TahunLulus:   req.TahunLulus,
+		Email:        req.Email,
+		Password:     hashed,
+		RoleID:       roleID,
+		NoTelepon:    req.NoTelepon,
+		Alamat:       req.Alamat,
+		TanggalLahir: tanggalLahir,
+	}, nil
+}
+
+func CreateAlumniService(c *fiber.Ctx, db *mongoDB.Database) error {
+	var req mongo.CreateAlumniRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(mongo.CreateAlumniResponse{
 			Success: false,
-			Message: "Gagal memproses password",
+			Message: "Format data tidak valid: " + err.Error(),
 			Data:    mongo.Alumni{},
 		})
 	}
 
-	// Konversi ke repository request
-	repoReq := &mongo.CreateAlumniRepositoryRequest{
-		NIM:        req.NIM,
-		Nama:       req.Nama,
-		Jurusan:    req.Jurusan,
-		Angkatan:   req.Angkatan,
-		TahunLulus: req.TahunLulus,
-		Email:      req.Email,
-		Password:   hashed,
-		RoleID:     roleID,
-		NoTelepon:  req.NoTelepon,
-		Alamat:     req.Alamat,
+	repoReq, ferr := alumniCreateRequest(&req)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(mongo.CreateAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    mongo.Alumni{},
+		})
 	}
 
 	alumni, err := repository.CreateAlumni(db, repoReq)
@@ -193,6 +321,8 @@ func CreateAlumniService(c *fiber.Ctx, db *mongoDB.Database) error {
 			Data:    mongo.Alumni{},
 		})
 	}
+	notifyAccountCreated(db, alumni)
+	recordAudit(c, db, auditEntry(helper.AuditActionCreate, helper.AuditEntityAlumni, alumni.ID.Hex(), nil, alumni))
 
 	return c.Status(fiber.StatusCreated).JSON(mongo.CreateAlumniResponse{
 		Success: true,
@@ -219,6 +349,15 @@ func UpdateAlumniService(c *fiber.Ctx, db *mongoDB.Database) error {
 		})
 	}
 
+	expectedVersion, ferr := ifMatchVersion(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(mongo.UpdateAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    mongo.Alumni{},
+		})
+	}
+
 	var req mongo.UpdateAlumniRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(mongo.UpdateAlumniResponse{
@@ -227,9 +366,17 @@ func UpdateAlumniService(c *fiber.Ctx, db *mongoDB.Database) error {
 			Data:    mongo.Alumni{},
 		})
 	}
+	tanggalLahir, ferr := parseTanggalLahir(req.TanggalLahir)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(mongo.UpdateAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    mongo.Alumni{},
+		})
+	}
 
 	// Check if alumni exists
-	_, err := repository.GetAlumniByID(db, idStr)
+	existing, err := repository.GetAlumniByID(db, idStr)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(mongo.UpdateAlumniResponse{
 			Success: false,
@@ -237,20 +384,51 @@ func UpdateAlumniService(c *fiber.Ctx, db *mongoDB.Database) error {
 			Data:    mongo.Alumni{},
 		})
 	}
+	if existing == nil {
+		return c.Status(fiber.StatusNotFound).JSON(mongo.UpdateAlumniResponse{
+			Success: false,
+			Message: "Alumni tidak ditemukan",
+			Data:    mongo.Alumni{},
+		})
+	}
+	if versionMismatch(c, expectedVersion, existing.Version) {
+		return c.Status(fiber.StatusPreconditionFailed).JSON(mongo.UpdateAlumniResponse{
+			Success: false,
+			Message: versionConflictMessage,
+			Data:    mongo.Alumni{},
+		})
+	}
 
 	// Hash password if provided
 	repoReq := &mongo.UpdateAlumniRepositoryRequest{
-		NIM:        req.NIM,
-		Nama:       req.Nama,
-		Jurusan:    req.Jurusan,
-		Angkatan:   req.Angkatan,
-		TahunLulus: req.TahunLulus,
-		Email:      req.Email,
-		NoTelepon:  req.NoTelepon,
-		Alamat:     req.Alamat,
+		NIM:          req.NIM,
+		Nama:         req.Nama,
+		Jurusan:      req.Jurusan,
+		Angkatan:     req.Angkatan,
+		TahunLulus:   req.TahunLulus,
+		Email:        req.Email,
+		NoTelepon:    req.NoTelepon,
+		Alamat:       req.Alamat,
+		TanggalLahir: tanggalLahir,
 	}
 
 	if req.Password != nil && *req.Password != "" {
+		// Password tidak boleh sama dengan email/NIM lama maupun yang baru dikirim
+		identifiers := []string{existing.Email, existing.NIM}
+		if req.Email != nil {
+			identifiers = append(identifiers, *req.Email)
+		}
+		if req.NIM != nil {
+			identifiers = append(identifiers, *req.NIM)
+		}
+		if err := utils.ValidatePassword(*req.Password, identifiers...); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(mongo.UpdateAlumniResponse{
+				Success: false,
+				Message: "Password tidak memenuhi kebijakan: " + err.Error(),
+				Data:    mongo.Alumni{},
+			})
+		}
+
 		hashed, err := utils.HashPassword(*req.Password)
 		if err != nil {
 			return c.Status(fiber.StatusInternalServerError).JSON(mongo.UpdateAlumniResponse{
@@ -274,7 +452,14 @@ func UpdateAlumniService(c *fiber.Ctx, db *mongoDB.Database) error {
 		repoReq.RoleID = &roleID
 	}
 
-	alumni, err := repository.UpdateAlumni(db, idStr, repoReq)
+	alumni, err := repository.UpdateAlumni(db, idStr, repoReq, expectedVersion)
+	if errors.Is(err, helper.ErrVersionConflict) {
+		return c.Status(fiber.StatusPreconditionFailed).JSON(mongo.UpdateAlumniResponse{
+			Success: false,
+			Message: versionConflictMessage,
+			Data:    mongo.Alumni{},
+		})
+	}
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(mongo.UpdateAlumniResponse{
 			Success: false,
@@ -282,7 +467,14 @@ func UpdateAlumniService(c *fiber.Ctx, db *mongoDB.Database) error {
 			Data:    mongo.Alumni{},
 		})
 	}
+	entry := auditEntry(helper.AuditActionUpdate, helper.AuditEntityAlumni, idStr, existing, alumni)
+	if repoReq.Password != nil {
+		// Password tidak pernah masuk snapshot; cukup catat bahwa ia diganti
+		entry.Metadata = map[string]any{"password_changed": true}
+	}
+	recordAudit(c, db, entry)
 
+	c.Set(fiber.HeaderETag, helper.ETag(alumni.Version))
 	return c.Status(fiber.StatusOK).JSON(mongo.UpdateAlumniResponse{
 		Success: true,
 		Message: "Berhasil mengupdate alumni",
@@ -290,6 +482,166 @@ func UpdateAlumniService(c *fiber.Ctx, db *mongoDB.Database) error {
 	})
 }
 
+// PatchAlumniService -> ubah sebagian alumni dengan JSON Merge Patch (RFC 7396) atau JSON Patch
+// (RFC 6902). Patch diterapkan ke data saat ini, hasilnya divalidasi seperti create lalu disimpan;
+// null / remove pada no_telepon dan alamat menghapus field tersebut.
+func PatchAlumniService(c *fiber.Ctx, db *mongoDB.Database) error {
+	idStr := c.Params("id")
+	if _, err := primitive.ObjectIDFromHex(idStr); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(mongo.UpdateAlumniResponse{
+			Success: false,
+			Message: "Format ID tidak valid",
+			Data:    mongo.Alumni{},
+		})
+	}
+
+	format, ferr := patchFormat(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(mongo.UpdateAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    mongo.Alumni{},
+		})
+	}
+
+	expectedVersion, ferr := ifMatchVersion(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(mongo.UpdateAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    mongo.Alumni{},
+		})
+	}
+
+	existing, err := repository.GetAlumniByID(db, idStr)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(mongo.UpdateAlumniResponse{
+			Success: false,
+			Message: "Gagal mengambil data alumni: " + err.Error(),
+			Data:    mongo.Alumni{},
+		})
+	}
+	if existing == nil {
+		return c.Status(fiber.StatusNotFound).JSON(mongo.UpdateAlumniResponse{
+			Success: false,
+			Message: "Alumni tidak ditemukan",
+			Data:    mongo.Alumni{},
+		})
+	}
+	if versionMismatch(c, expectedVersion, existing.Version) {
+		return c.Status(fiber.StatusPreconditionFailed).JSON(mongo.UpdateAlumniResponse{
+			Success: false,
+			Message: versionConflictMessage,
+			Data:    mongo.Alumni{},
+		})
+	}
+
+	var doc mongo.AlumniPatchDocument
+	if ferr := applyPatch(c, format, alumniPatchDocument(existing), &doc); ferr != nil {
+		return c.Status(ferr.Code).JSON(mongo.UpdateAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    mongo.Alumni{},
+		})
+	}
+	repoReq, ferr := alumniPatchRequest(existing, &doc)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(mongo.UpdateAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    mongo.Alumni{},
+		})
+	}
+
+	alumni, err := repository.UpdateAlumni(db, idStr, repoReq, expectedVersion)
+	if errors.Is(err, helper.ErrVersionConflict) {
+		return c.Status(fiber.StatusPreconditionFailed).JSON(mongo.UpdateAlumniResponse{
+			Success: false,
+			Message: versionConflictMessage,
+			Data:    mongo.Alumni{},
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(mongo.UpdateAlumniResponse{
+			Success: false,
+			Message: "Gagal mengupdate alumni: " + err.Error(),
+			Data:    mongo.Alumni{},
+		})
+	}
+	entry := auditEntry(helper.AuditActionUpdate, helper.AuditEntityAlumni, idStr, existing, alumni)
+	if repoReq.Password != nil {
+		entry.Metadata = map[string]any{"password_changed": true}
+	}
+	recordAudit(c, db, entry)
+
+	c.Set(fiber.HeaderETag, helper.ETag(alumni.Version))
+	return c.Status(fiber.StatusOK).JSON(mongo.UpdateAlumniResponse{
+		Success: true,
+		Message: "Berhasil mengupdate alumni",
+		Data:    *alumni,
+	})
+}
+
+// alumniPatchDocument -> data alumni saat ini dalam bentuk yang bisa di-patch
+func alumniPatchDocument(existing *mongo.Alumni) mongo.AlumniPatchDocument {
+	return mongo.AlumniPatchDocument{
+		NIM:        existing.NIM,
+		Nama:       existing.Nama,
+		Jurusan:    existing.Jurusan,
+		Angkatan:   existing.Angkatan,
+		TahunLulus: existing.TahunLulus,
+		Email:      existing.Email,
+		RoleID:     existing.RoleID.Hex(),
+		NoTelepon:  existing.NoTelepon,
+		Alamat:     existing.Alamat,
+	}
+}
+
+// alumniPatchRequest -> validasi hasil patch (aturan sama seperti create) lalu ubah menjadi update
+// lengkap; field opsional yang null masuk Unset
+func alumniPatchRequest(existing *mongo.Alumni, doc *mongo.AlumniPatchDocument) (*mongo.UpdateAlumniRepositoryRequest, *fiber.Error) {
+	if doc.NIM == "" || doc.Nama == "" || doc.Jurusan == "" || doc.Email == "" || doc.RoleID == "" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "NIM, nama, jurusan, email, dan role_id wajib diisi")
+	}
+	if doc.Angkatan <= 0 || doc.TahunLulus <= 0 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Angkatan dan tahun lulus harus lebih dari 0")
+	}
+	roleID, err := primitive.ObjectIDFromHex(doc.RoleID)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Role ID tidak valid")
+	}
+
+	repoReq := &mongo.UpdateAlumniRepositoryRequest{
+		NIM:        &doc.NIM,
+		Nama:       &doc.Nama,
+		Jurusan:    &doc.Jurusan,
+		Angkatan:   &doc.Angkatan,
+		TahunLulus: &doc.TahunLulus,
+		Email:      &doc.Email,
+		RoleID:     &roleID,
+		NoTelepon:  doc.NoTelepon,
+		Alamat:     doc.Alamat,
+	}
+	if doc.NoTelepon == nil {
+		repoReq.Unset = append(repoReq.Unset, "no_telepon")
+	}
+	if doc.Alamat == nil {
+		repoReq.Unset = append(repoReq.Unset, "alamat")
+	}
+
+	if doc.Password != nil {
+		if err := utils.ValidatePassword(*doc.Password, existing.Email, existing.NIM, doc.Email, doc.NIM); err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Password tidak memenuhi kebijakan: "+err.Error())
+		}
+		hashed, err := utils.HashPassword(*doc.Password)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusInternalServerError, "Gagal memproses password")
+		}
+		repoReq.Password = &hashed
+	}
+	return repoReq, nil
+}
+
 func DeleteAlumniService(c *fiber.Ctx, db *mongoDB.Database) error {
 	idStr := c.Params("id")
 	if idStr == "" {
@@ -307,7 +659,7 @@ func DeleteAlumniService(c *fiber.Ctx, db *mongoDB.Database) error {
 	}
 
 	// Check if alumni exists
-	_, err := repository.GetAlumniByID(db, idStr)
+	existing, err := repository.GetAlumniByID(db, idStr)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(mongo.DeleteAlumniResponse{
 			Success: false,
@@ -322,6 +674,7 @@ func DeleteAlumniService(c *fiber.Ctx, db *mongoDB.Database) error {
 			Message: "Gagal menghapus alumni: " + err.Error(),
 		})
 	}
+	recordAudit(c, db, auditEntry(helper.AuditActionDelete, helper.AuditEntityAlumni, idStr, existing, nil))
 
 	return c.Status(fiber.StatusOK).JSON(mongo.DeleteAlumniResponse{
 		Success: true,
@@ -329,15 +682,26 @@ func DeleteAlumniService(c *fiber.Ctx, db *mongoDB.Database) error {
 	})
 }
 
+// CheckAlumniService -> endpoint cek alumni lama dengan API_KEY tunggal di query string.
+// Deprecated: gunakan GET /partner/alumni/check dengan header X-API-Key
 func CheckAlumniService(c *fiber.Ctx, db *mongoDB.Database) error {
-	key := c.Query("key")
-	if key != os.Getenv("API_KEY") {
+	if !legacyCheckAllowed(c) {
+		return c.Status(fiber.StatusGone).JSON(fiber.Map{
+			"message": "Endpoint ini sudah dinonaktifkan, gunakan " + partnerCheckPath + " dengan header " + helper.APIKeyHeader,
+			"success": false,
+		})
+	}
+	if !legacyAPIKeyValid(c.Query("key")) {
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 			"message": "Key tidak valid",
 			"success": false,
 		})
 	}
-	nim := c.Query("nim")
+	return checkAlumniByNIM(c, db, c.Query("nim"))
+}
+
+// checkAlumniByNIM -> respons cek status alumni, dipakai endpoint lama dan endpoint partner
+func checkAlumniByNIM(c *fiber.Ctx, db *mongoDB.Database, nim string) error {
 	if nim == "" {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"message": "NIM wajib diisi",
@@ -366,56 +730,81 @@ func CheckAlumniService(c *fiber.Ctx, db *mongoDB.Database) error {
 	})
 }
 
-// Get Alumni Employment Status Service
-func GetAlumniEmploymentStatusService(c *fiber.Ctx, db *mongoDB.Database) error {
-	// Parse query parameters
+// parseEmploymentStatusRequest -> filter lama status pekerjaan (id, nama, jurusan, ...) beserta sort,
+// page, limit, dan hak melihat gaji rahasia
+func parseEmploymentStatusRequest(c *fiber.Ctx) *mongo.AlumniEmploymentStatusRequest {
+	role, _ := c.Locals("role").(string)
+	viewerID, _ := c.Locals("user_id").(string)
+	return employmentStatusRequest(c, role, viewerID)
+}
+
+// employmentStatusRequest -> isi parseEmploymentStatusRequest dari query apa pun, dipakai juga job export
+func employmentStatusRequest(q queryValues, role, viewerID string) *mongo.AlumniEmploymentStatusRequest {
 	req := &mongo.AlumniEmploymentStatusRequest{
-		Page:  1,
-		Limit: 20,
+		SortBy: q.Query("sortBy"),
+		Order:  q.Query("order"),
+		Page:   1,
+		Limit:  20,
 	}
+	req.IncludeConfidential = role == "admin"
+	req.ViewerID = viewerID
 
 	// Parse query parameters
-	if idStr := c.Query("id"); idStr != "" {
+	if idStr := q.Query("id"); idStr != "" {
 		req.ID = &idStr
 	}
-	if nama := c.Query("nama"); nama != "" {
+	if nama := q.Query("nama"); nama != "" {
 		req.Nama = &nama
 	}
-	if jurusan := c.Query("jurusan"); jurusan != "" {
+	if jurusan := q.Query("jurusan"); jurusan != "" {
 		req.Jurusan = &jurusan
 	}
-	if angkatanStr := c.Query("angkatan"); angkatanStr != "" {
+	if angkatanStr := q.Query("angkatan"); angkatanStr != "" {
 		if angkatan, err := strconv.Atoi(angkatanStr); err == nil {
 			req.Angkatan = &angkatan
 		}
 	}
-	if bidangIndustri := c.Query("bidang_industri"); bidangIndustri != "" {
+	if bidangIndustri := q.Query("bidang_industri"); bidangIndustri != "" {
 		req.BidangIndustri = &bidangIndustri
 	}
-	if namaPerusahaan := c.Query("nama_perusahaan"); namaPerusahaan != "" {
+	if namaPerusahaan := q.Query("nama_perusahaan"); namaPerusahaan != "" {
 		req.NamaPerusahaan = &namaPerusahaan
 	}
-	if posisiJabatan := c.Query("posisi_jabatan"); posisiJabatan != "" {
+	if posisiJabatan := q.Query("posisi_jabatan"); posisiJabatan != "" {
 		req.PosisiJabatan = &posisiJabatan
 	}
-	if lebihDari1TahunStr := c.Query("lebih_dari_1_tahun"); lebihDari1TahunStr != "" {
+	if lebihDari1TahunStr := q.Query("lebih_dari_1_tahun"); lebihDari1TahunStr != "" {
 		if lebihDari1Tahun, err := strconv.Atoi(lebihDari1TahunStr); err == nil {
 			req.LebihDari1Tahun = &lebihDari1Tahun
 		}
 	}
-	if pageStr := c.Query("page"); pageStr != "" {
+	if pageStr := q.Query("page"); pageStr != "" {
 		if page, err := strconv.Atoi(pageStr); err == nil && page > 0 {
 			req.Page = page
 		}
 	}
-	if limitStr := c.Query("limit"); limitStr != "" {
+	if limitStr := q.Query("limit"); limitStr != "" {
 		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
 			req.Limit = limit
 		}
 	}
+	return req
+}
+
+// Get Alumni Employment Status Service
+func GetAlumniEmploymentStatusService(c *fiber.Ctx, db *mongoDB.Database) error {
+	req := parseEmploymentStatusRequest(c)
+
+	filters, err := helper.ParseFilters(c.Queries(), repository.EmploymentStatusFilterFields)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+			"success": false,
+		})
+	}
 
 	// Get data from repository
-	results, err := repository.GetAlumniEmploymentStatus(db, req)
+	results, err := repository.GetAlumniEmploymentStatus(db, req, filters)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"message": "Gagal mengambil data status pekerjaan alumni: " + err.Error(),
@@ -423,8 +812,14 @@ func GetAlumniEmploymentStatusService(c *fiber.Ctx, db *mongoDB.Database) error
 		})
 	}
 
-	// Calculate pagination info
-	totalRecords := len(results)
+	// Calculate pagination info dari total seluruh data, bukan hanya halaman ini
+	totalRecords, err := repository.CountAlumniEmploymentStatus(db, req, filters)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Gagal menghitung data status pekerjaan alumni: " + err.Error(),
+			"success": false,
+		})
+	}
 	totalPages := (totalRecords + req.Limit - 1) / req.Limit
 
 	return c.Status(fiber.StatusOK).JSON(mongo.GetAlumniEmploymentStatusResponse{
@@ -0,0 +1,173 @@
+package mongo
+
+import (
+	"log"
+	"math"
+	"strconv"
+
+	model "go-fiber/app/model/mongo"
+	repository "go-fiber/app/repository/mongo"
+	"go-fiber/helper"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongoDB "go.mongodb.org/mongo-driver/mongo"
+)
+
+// auditEntry -> entri audit untuk perubahan entity; before/after di-snapshot dari bentuk JSON-nya
+// (field rahasia tidak ikut) dan diff dihitung bila keduanya ada
+func auditEntry(action, entityType, entityID string, before, after any) model.AuditLog {
+	entry := model.AuditLog{
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Before:     helper.AuditSnapshot(before),
+		After:      helper.AuditSnapshot(after),
+	}
+	if entry.Before != nil && entry.After != nil {
+		entry.Changes = helper.AuditDiff(entry.Before, entry.After)
+	}
+	return entry
+}
+
+// recordAudit -> simpan entri audit beserta asal request. Pelaku diambil dari token bila belum
+// diisi. Kegagalan hanya dicatat di log agar operasi yang sudah berhasil tidak ikut gagal.
+func recordAudit(c *fiber.Ctx, db *mongoDB.Database, entry model.AuditLog) {
+	if db == nil {
+		return
+	}
+	if entry.ActorID == nil {
+		if userID, _ := c.Locals("user_id").(string); userID != "" {
+			if id, err := primitive.ObjectIDFromHex(userID); err == nil {
+				entry.ActorID = &id
+			}
+		}
+	}
+	if entry.ActorRole == "" {
+		entry.ActorRole, _ = c.Locals("role").(string)
+	}
+	entry.IP = c.IP()
+	entry.UserAgent = c.Get(fiber.HeaderUserAgent)
+	entry.RequestID = c.GetRespHeader(fiber.HeaderXRequestID)
+
+	if err := repository.InsertAuditLog(db, &entry); err != nil {
+		log.Printf("Gagal menyimpan audit log %s %s/%s: %v", entry.Action, entry.EntityType, entry.EntityID, err)
+	}
+}
+
+// recordLoginAudit -> audit login berhasil atau gagal. Pelaku adalah akun yang login (nil bila
+// email tidak dikenal) karena request login belum membawa token.
+func recordLoginAudit(c *fiber.Ctx, db *mongoDB.Database, action string, alumniID *primitive.ObjectID, role string, metadata map[string]any) {
+	entry := model.AuditLog{
+		Action:     action,
+		EntityType: helper.AuditEntityAlumni,
+		ActorID:    alumniID,
+		ActorRole:  role,
+		Metadata:   metadata,
+	}
+	if alumniID != nil {
+		entry.EntityID = alumniID.Hex()
+	}
+	recordAudit(c, db, entry)
+}
+
+// recordAccountAudit -> audit perubahan akun oleh alumni itu sendiri yang tidak tampak di
+// snapshot (password, 2FA); metadata menjelaskan apa yang berubah
+func recordAccountAudit(c *fiber.Ctx, db *mongoDB.Database, alumniID primitive.ObjectID, metadata map[string]any) {
+	entry := auditEntry(helper.AuditActionUpdate, helper.AuditEntityAlumni, alumniID.Hex(), nil, nil)
+	entry.ActorID = &alumniID
+	entry.Metadata = metadata
+	recordAudit(c, db, entry)
+}
+
+// auditPage -> page dan limit daftar audit log
+func auditPage(c *fiber.Ctx) (int, int) {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	return page, limit
+}
+
+func auditLogList(c *fiber.Ctx, db *mongoDB.Database, filter helper.AuditLogFilter) error {
+	page, limit := auditPage(c)
+	logs, total, err := repository.FindAuditLogs(db, filter, page, limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Gagal mengambil audit log: " + err.Error(),
+		})
+	}
+	return c.JSON(model.AuditLogListResponse{
+		Success: true,
+		Message: "Berhasil mengambil audit log",
+		Data: model.AuditLogListData{
+			Items: logs,
+			Meta: model.MetaInfo{
+				Page:  page,
+				Limit: limit,
+				Total: &total,
+				Pages: int(math.Ceil(float64(total) / float64(limit))),
+			},
+		},
+	})
+}
+
+// ListAuditLogsService -> GET /audit-logs: query audit log untuk admin dengan filter actor_id,
+// actor_role, action, entity_type, entity_id, request_id, from dan to
+func ListAuditLogsService(c *fiber.Ctx, db *mongoDB.Database) error {
+	filter := helper.AuditLogFilter{
+		ActorID:    c.Query("actor_id"),
+		ActorRole:  c.Query("actor_role"),
+		Action:     c.Query("action"),
+		EntityType: c.Query("entity_type"),
+		EntityID:   c.Query("entity_id"),
+		RequestID:  c.Query("request_id"),
+	}
+	if filter.ActorID != "" {
+		if _, err := primitive.ObjectIDFromHex(filter.ActorID); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "actor_id tidak valid"})
+		}
+	}
+	var err error
+	if v := c.Query("from"); v != "" {
+		if filter.From, err = helper.ParseAuditTime(v, false); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "from harus RFC3339 atau YYYY-MM-DD"})
+		}
+	}
+	if v := c.Query("to"); v != "" {
+		if filter.To, err = helper.ParseAuditTime(v, true); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "to harus RFC3339 atau YYYY-MM-DD"})
+		}
+	}
+	return auditLogList(c, db, filter)
+}
+
+// entityHistory -> riwayat audit satu entity, terbaru lebih dulu. Entity yang sudah dihapus
+// tetap punya riwayat.
+func entityHistory(c *fiber.Ctx, db *mongoDB.Database, entityType string) error {
+	id := c.Params("id")
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "Format ID tidak valid"})
+	}
+	return auditLogList(c, db, helper.AuditLogFilter{EntityType: entityType, EntityID: id})
+}
+
+// AlumniHistoryService -> GET /alumni/:id/history
+func AlumniHistoryService(c *fiber.Ctx, db *mongoDB.Database) error {
+	return entityHistory(c, db, helper.AuditEntityAlumni)
+}
+
+// PekerjaanHistoryService -> GET /pekerjaan/:id/history
+func PekerjaanHistoryService(c *fiber.Ctx, db *mongoDB.Database) error {
+	return entityHistory(c, db, helper.AuditEntityPekerjaan)
+}
+
+// RoleHistoryService -> GET /roles/:id/history
+func RoleHistoryService(c *fiber.Ctx, db *mongoDB.Database) error {
+	return entityHistory(c, db, helper.AuditEntityRole)
+}
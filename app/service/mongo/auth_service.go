@@ -1,14 +1,54 @@
 package mongo
 
 import (
+	"log"
+	"strings"
+	"sync"
+	"time"
+
 	"go-fiber/app/model/mongo"
 	repository "go-fiber/app/repository/mongo"
+	"go-fiber/helper"
 	utils "go-fiber/utils/mongo"
 
 	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	mongoDB "go.mongodb.org/mongo-driver/mongo"
 )
 
+var loginLimiter struct {
+	once  sync.Once
+	cfg   helper.LoginProtection
+	store helper.RateLimitStore
+}
+
+// loginProtection -> konfigurasi dan store rate limit login, dibuat sekali saat login pertama
+func loginProtection(db *mongoDB.Database) (helper.LoginProtection, helper.RateLimitStore) {
+	loginLimiter.once.Do(func() {
+		loginLimiter.cfg = helper.LoginProtectionFromEnv()
+		if loginLimiter.cfg.Store == helper.RateLimitStoreDatabase {
+			loginLimiter.store = repository.NewRateLimitStore(db)
+		} else {
+			loginLimiter.store = helper.NewMemoryRateLimitStore()
+		}
+	})
+	return loginLimiter.cfg, loginLimiter.store
+}
+
+func loginIPKey(ip string) string {
+	return "login:ip:" + ip
+}
+
+func loginAccountKey(email string) string {
+	return "login:account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+// tooManyAttempts -> 429 dengan header Retry-After
+func tooManyAttempts(c *fiber.Ctx, retryAfter time.Duration, message string) error {
+	c.Set(fiber.HeaderRetryAfter, helper.RetryAfterSeconds(retryAfter))
+	return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{"error": message})
+}
+
 // Handler untuk login
 func LoginService(c *fiber.Ctx, db *mongoDB.Database) error {
 	type loginRequest struct {
@@ -23,18 +63,65 @@ func LoginService(c *fiber.Ctx, db *mongoDB.Database) error {
 		return c.Status(400).JSON(fiber.Map{"error": "Email dan password harus diisi"})
 	}
 
+	// Rate limit per IP dan per akun (termasuk email yang tidak terdaftar)
+	cfg, limiter := loginProtection(db)
+	count, retryAfter, err := limiter.Hit(loginIPKey(c.IP()), cfg.Window)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal memeriksa rate limit"})
+	}
+	if count > cfg.IPLimit {
+		return tooManyAttempts(c, retryAfter, "Terlalu banyak percobaan login, coba lagi nanti")
+	}
+	count, retryAfter, err = limiter.Hit(loginAccountKey(req.Email), cfg.Window)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal memeriksa rate limit"})
+	}
+	if count > cfg.AccountLimit {
+		return tooManyAttempts(c, retryAfter, "Terlalu banyak percobaan login, coba lagi nanti")
+	}
+
 	alumni, err := repository.GetAlumniByEmail(db, req.Email)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"error": "Error database"})
 	}
 	if alumni == nil {
+		recordLoginAudit(c, db, helper.AuditActionLoginFailed, nil, "", map[string]any{"method": "password", "email": req.Email, "reason": "unknown_email"})
 		return c.Status(401).JSON(fiber.Map{"error": "Email atau password salah"})
 	}
 
+	now := time.Now()
+	if alumni.LockedUntil != nil && alumni.LockedUntil.After(now) {
+		recordLoginAudit(c, db, helper.AuditActionLoginFailed, &alumni.ID, "", map[string]any{"method": "password", "reason": "locked"})
+		return tooManyAttempts(c, alumni.LockedUntil.Sub(now), "Akun terkunci sementara karena terlalu banyak percobaan login gagal")
+	}
+	// Jeda progresif: percobaan berikutnya baru diterima setelah jeda dari gagal terakhir
+	if alumni.LastFailedLoginAt != nil {
+		if wait := alumni.LastFailedLoginAt.Add(cfg.FailureDelay(alumni.FailedLoginAttempts)).Sub(now); wait > 0 {
+			return tooManyAttempts(c, wait, "Terlalu cepat, tunggu sebelum mencoba login lagi")
+		}
+	}
+
 	if !utils.CheckPassword(req.Password, alumni.Password) {
+		updated, err := repository.RecordLoginFailure(db, alumni.ID, cfg.MaxFailures, cfg.Lockout)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Error database"})
+		}
+		recordLoginAudit(c, db, helper.AuditActionLoginFailed, &alumni.ID, "", map[string]any{"method": "password", "reason": "wrong_password"})
+		if updated != nil && updated.LockedUntil != nil && updated.LockedUntil.After(now) {
+			return tooManyAttempts(c, updated.LockedUntil.Sub(now), "Akun terkunci sementara karena terlalu banyak percobaan login gagal")
+		}
 		return c.Status(401).JSON(fiber.Map{"error": "Email atau password salah"})
 	}
 
+	if alumni.FailedLoginAttempts > 0 || alumni.LockedUntil != nil {
+		if _, err := repository.ResetLoginFailures(db, alumni.ID); err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Error database"})
+		}
+	}
+	if err := limiter.Reset(loginAccountKey(req.Email)); err != nil {
+		log.Printf("Reset login rate limit for %s failed: %v", req.Email, err)
+	}
+
 	// Get role name from roles collection
 	role, err := repository.GetRoleByObjectID(db, alumni.RoleID)
 	if err != nil {
@@ -42,10 +129,43 @@ func LoginService(c *fiber.Ctx, db *mongoDB.Database) error {
 	}
 
 	user := mongo.User{
-		ID:       alumni.ID,
-		Username: alumni.Email,
-		Email:    alumni.Email,
-		Role:     role.Name,
+		ID:             alumni.ID,
+		Username:       alumni.Email,
+		Email:          alumni.Email,
+		Role:           role.Name,
+		SessionVersion: alumni.SessionVersion,
+	}
+
+	return issueLoginResponse(c, db, user, alumni.TOTPEnabled, "password")
+}
+
+// issueLoginResponse -> respons login sukses (password maupun SSO). Akun dengan 2FA (wajib
+// untuk admin) mendapat token mfa_pending dulu, selain itu langsung token penuh. Login baru
+// dicatat di audit log saat token penuh diterbitkan.
+func issueLoginResponse(c *fiber.Ctx, db *mongoDB.Database, user mongo.User, totpEnabled bool, method string) error {
+	if mfaRequired(user.Role, totpEnabled) {
+		mfaToken, err := utils.GenerateMFAPendingToken(user)
+		if err != nil {
+			return c.Status(500).JSON(mongo.LoginResponse{
+				Success: false,
+				Message: "Gagal generate token",
+				Data:    mongo.LoginData{},
+			})
+		}
+		message := "Masukkan kode 2FA"
+		if !totpEnabled {
+			message = "2FA wajib untuk admin, lakukan enrollment"
+		}
+		return c.JSON(mongo.LoginResponse{
+			Success: true,
+			Message: message,
+			Data: mongo.LoginData{
+				User:                  user,
+				MFARequired:           true,
+				MFAEnrollmentRequired: !totpEnabled,
+				MFAToken:              mfaToken,
+			},
+		})
 	}
 
 	token, err := utils.GenerateToken(user)
@@ -56,6 +176,7 @@ func LoginService(c *fiber.Ctx, db *mongoDB.Database) error {
 			Data:    mongo.LoginData{},
 		})
 	}
+	recordLoginAudit(c, db, helper.AuditActionLogin, &user.ID, user.Role, map[string]any{"method": method})
 
 	return c.JSON(mongo.LoginResponse{
 		Success: true,
@@ -67,7 +188,34 @@ func LoginService(c *fiber.Ctx, db *mongoDB.Database) error {
 	})
 }
 
-// Handler untuk melihat profile user yang sedang login
+// UnlockAlumniService -> POST /alumni/:id/unlock: admin membuka kunci akun dan menghapus
+// counter gagal login serta rate limit akun
+func UnlockAlumniService(c *fiber.Ctx, db *mongoDB.Database) error {
+	idStr := c.Params("id")
+	if _, err := primitive.ObjectIDFromHex(idStr); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(mongo.UnlockAlumniResponse{Success: false, Message: "Format ID tidak valid"})
+	}
+	alumni, err := repository.GetAlumniByID(db, idStr)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(mongo.UnlockAlumniResponse{Success: false, Message: "Gagal mengambil data alumni: " + err.Error()})
+	}
+	if alumni == nil {
+		return c.Status(fiber.StatusNotFound).JSON(mongo.UnlockAlumniResponse{Success: false, Message: "Alumni tidak ditemukan"})
+	}
+
+	if _, err := repository.ResetLoginFailures(db, alumni.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(mongo.UnlockAlumniResponse{Success: false, Message: "Gagal membuka kunci akun: " + err.Error()})
+	}
+	_, limiter := loginProtection(db)
+	if err := limiter.Reset(loginAccountKey(alumni.Email)); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(mongo.UnlockAlumniResponse{Success: false, Message: "Gagal menghapus rate limit akun: " + err.Error()})
+	}
+	recordAudit(c, db, auditEntry(helper.AuditActionUnlock, helper.AuditEntityAlumni, idStr, nil, nil))
+
+	return c.JSON(mongo.UnlockAlumniResponse{Success: true, Message: "Kunci akun berhasil dibuka"})
+}
+
+// Handler untuk melihat profile user yang sedang login (isi token saja; data alumni lengkap ada di GET /me)
 func GetProfileService(c *fiber.Ctx, db *mongoDB.Database) error {
 	userID := c.Locals("user_id").(string)
 	username := c.Locals("username").(string)
@@ -0,0 +1,447 @@
+package mongo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-fiber/app/model/mongo"
+	repository "go-fiber/app/repository/mongo"
+	"go-fiber/helper"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongoDB "go.mongodb.org/mongo-driver/mongo"
+)
+
+// errBatchAborted -> satu operasi gagal pada mode all_or_nothing sehingga transaksi dibatalkan
+var errBatchAborted = errors.New("batch dibatalkan")
+
+// batchOutcome -> hasil operasi batch yang berhasil. effects (audit, email) baru dijalankan
+// setelah perubahan benar-benar tersimpan.
+type batchOutcome struct {
+	status  int
+	message string
+	id      string
+	data    interface{}
+	effects func()
+}
+
+// batchStep -> operasi batch yang sudah lolos validasi; ctx adalah sesi transaksi pada mode
+// all_or_nothing
+type batchStep func(ctx context.Context) (*batchOutcome, *fiber.Error)
+
+// runBatch -> parse body batch, siapkan tiap operasi dengan prepare lalu jalankan sesuai mode.
+// Validasi yang tidak butuh database dilakukan di prepare sehingga all_or_nothing bisa gagal
+// sebelum transaksi dibuka.
+func runBatch(c *fiber.Ctx, db *mongoDB.Database, prepare func(op mongo.BatchOperation) (batchStep, *fiber.Error)) error {
+	var req mongo.BatchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return batchFailed(c, fiber.StatusBadRequest, "Format data tidak valid: "+err.Error())
+	}
+	if req.Mode == "" {
+		req.Mode = mongo.BatchModeAllOrNothing
+	}
+	if req.Mode != mongo.BatchModeAllOrNothing && req.Mode != mongo.BatchModeBestEffort {
+		return batchFailed(c, fiber.StatusBadRequest, "Mode harus all_or_nothing atau best_effort")
+	}
+	if len(req.Operations) == 0 {
+		return batchFailed(c, fiber.StatusBadRequest, "Operations wajib diisi")
+	}
+	if limit := helper.BatchMaxOperations(); len(req.Operations) > limit {
+		return batchFailed(c, fiber.StatusRequestEntityTooLarge, fmt.Sprintf("Maksimal %d operasi per batch", limit))
+	}
+
+	summary := mongo.BatchSummary{Mode: req.Mode, Results: make([]mongo.BatchResult, len(req.Operations))}
+	steps := make([]batchStep, len(req.Operations))
+	invalid := false
+	for i, op := range req.Operations {
+		summary.Results[i] = mongo.BatchResult{Index: i, Op: op.Op, ID: op.ID}
+		step, ferr := prepare(op)
+		if ferr != nil {
+			setBatchError(&summary.Results[i], ferr)
+			invalid = true
+			continue
+		}
+		steps[i] = step
+	}
+
+	if req.Mode == mongo.BatchModeBestEffort {
+		for i, step := range steps {
+			if step == nil {
+				continue
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			outcome, ferr := step(ctx)
+			cancel()
+			if ferr != nil {
+				setBatchError(&summary.Results[i], ferr)
+				continue
+			}
+			setBatchOutcome(&summary.Results[i], outcome)
+		}
+		return batchResponse(c, summary)
+	}
+
+	if !invalid {
+		var outcomes []*batchOutcome
+		err := repository.RunInTransaction(db, func(ctx context.Context) error {
+			outcomes = make([]*batchOutcome, len(steps))
+			for i, step := range steps {
+				outcome, ferr := step(ctx)
+				if ferr != nil {
+					setBatchError(&summary.Results[i], ferr)
+					return errBatchAborted
+				}
+				outcomes[i] = outcome
+			}
+			return nil
+		})
+		if err == nil {
+			for i, outcome := range outcomes {
+				setBatchOutcome(&summary.Results[i], outcome)
+			}
+			return batchResponse(c, summary)
+		}
+		if !errors.Is(err, errBatchAborted) {
+			// Transaksi gagal di luar operasi, mis. MongoDB bukan replica set
+			return batchFailed(c, fiber.StatusInternalServerError, "Gagal menjalankan transaksi batch: "+err.Error())
+		}
+	}
+
+	// all_or_nothing gagal: tidak ada perubahan yang tersimpan
+	for i := range summary.Results {
+		if summary.Results[i].Status < fiber.StatusBadRequest {
+			summary.Results[i].Status = fiber.StatusFailedDependency
+			summary.Results[i].Message = "Dibatalkan karena operasi lain gagal"
+			summary.Results[i].Data = nil
+		}
+	}
+	return batchResponse(c, summary)
+}
+
+func setBatchError(result *mongo.BatchResult, ferr *fiber.Error) {
+	result.Status = ferr.Code
+	result.Message = ferr.Message
+}
+
+// setBatchOutcome -> isi hasil operasi yang berhasil dan jalankan efek sampingnya
+func setBatchOutcome(result *mongo.BatchResult, outcome *batchOutcome) {
+	result.Status = outcome.status
+	result.Message = outcome.message
+	result.Data = outcome.data
+	if outcome.id != "" {
+		result.ID = outcome.id
+	}
+	if outcome.effects != nil {
+		outcome.effects()
+	}
+}
+
+// batchResponse -> 200 bila semua berhasil, 207 bila best_effort gagal sebagian, dan status
+// operasi pertama yang gagal bila all_or_nothing dibatalkan
+func batchResponse(c *fiber.Ctx, summary mongo.BatchSummary) error {
+	status := 0
+	for _, result := range summary.Results {
+		if result.Status >= fiber.StatusBadRequest {
+			summary.Failed++
+			if status == 0 && result.Status != fiber.StatusFailedDependency {
+				status = result.Status
+			}
+		} else {
+			summary.Succeeded++
+		}
+	}
+
+	message := "Semua operasi batch berhasil"
+	switch {
+	case summary.Failed == 0:
+		status = fiber.StatusOK
+	case summary.Mode == mongo.BatchModeBestEffort:
+		status = fiber.StatusMultiStatus
+		message = fmt.Sprintf("%d dari %d operasi batch gagal", summary.Failed, len(summary.Results))
+	default:
+		message = "Batch dibatalkan, tidak ada perubahan yang disimpan"
+	}
+	return c.Status(status).JSON(mongo.BatchResponse{
+		Success: summary.Failed == 0,
+		Message: message,
+		Data:    summary,
+	})
+}
+
+func batchFailed(c *fiber.Ctx, status int, message string) error {
+	return c.Status(status).JSON(mongo.BatchResponse{
+		Success: false,
+		Message: message,
+		Data:    mongo.BatchSummary{Results: []mongo.BatchResult{}},
+	})
+}
+
+// batchIfMatch -> versi dari if_match operasi, aturannya sama dengan header If-Match
+func batchIfMatch(op mongo.BatchOperation) (*int, *fiber.Error) {
+	version, ok, err := helper.ParseIfMatch(op.IfMatch)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	if !ok {
+		if helper.IfMatchRequired() && op.IfMatch == "" {
+			return nil, fiber.NewError(fiber.StatusPreconditionRequired, "if_match wajib diisi dengan ETag dari response GET")
+		}
+		return nil, nil
+	}
+	return &version, nil
+}
+
+// batchMergePatch -> data operasi update harus berupa object merge patch
+func batchMergePatch(op mongo.BatchOperation) *fiber.Error {
+	var patch map[string]json.RawMessage
+	if err := json.Unmarshal(op.Data, &patch); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "data update harus berupa object merge patch")
+	}
+	return nil
+}
+
+var errBatchUnknownOp = fiber.NewError(fiber.StatusBadRequest, "op harus create, update, atau delete")
+
+// BatchAlumniService -> banyak create/update/delete alumni dalam satu request. Update memakai
+// merge patch seperti PATCH /alumni/:id.
+func BatchAlumniService(c *fiber.Ctx, db *mongoDB.Database) error {
+	return runBatch(c, db, func(op mongo.BatchOperation) (batchStep, *fiber.Error) {
+		switch op.Op {
+		case "create":
+			var req mongo.CreateAlumniRequest
+			if err := json.Unmarshal(op.Data, &req); err != nil {
+				return nil, fiber.NewError(fiber.StatusBadRequest, "Format data tidak valid: "+err.Error())
+			}
+			repoReq, ferr := alumniCreateRequest(&req)
+			if ferr != nil {
+				return nil, ferr
+			}
+			return func(ctx context.Context) (*batchOutcome, *fiber.Error) {
+				alumni, err := repository.CreateAlumniCtx(ctx, db, repoReq)
+				if err != nil {
+					return nil, fiber.NewError(fiber.StatusInternalServerError, "Gagal membuat alumni: "+err.Error())
+				}
+				return &batchOutcome{
+					status:  fiber.StatusCreated,
+					message: "Berhasil membuat alumni",
+					id:      alumni.ID.Hex(),
+					data:    alumni,
+					effects: func() {
+						notifyAccountCreated(db, alumni)
+						recordAudit(c, db, auditEntry(helper.AuditActionCreate, helper.AuditEntityAlumni, alumni.ID.Hex(), nil, alumni))
+					},
+				}, nil
+			}, nil
+
+		case "update":
+			if _, err := primitive.ObjectIDFromHex(op.ID); err != nil {
+				return nil, fiber.NewError(fiber.StatusBadRequest, "Format ID tidak valid")
+			}
+			expectedVersion, ferr := batchIfMatch(op)
+			if ferr != nil {
+				return nil, ferr
+			}
+			if ferr := batchMergePatch(op); ferr != nil {
+				return nil, ferr
+			}
+			return func(ctx context.Context) (*batchOutcome, *fiber.Error) {
+				existing, err := repository.GetAlumniByIDCtx(ctx, db, op.ID)
+				if err != nil {
+					return nil, fiber.NewError(fiber.StatusInternalServerError, "Gagal mengambil data alumni: "+err.Error())
+				}
+				if existing == nil {
+					return nil, fiber.NewError(fiber.StatusNotFound, "Alumni tidak ditemukan")
+				}
+				if expectedVersion != nil && *expectedVersion != existing.Version {
+					return nil, fiber.NewError(fiber.StatusPreconditionFailed, versionConflictMessage)
+				}
+				var doc mongo.AlumniPatchDocument
+				if ferr := patchDocument(helper.MergePatchContentType, op.Data, alumniPatchDocument(existing), &doc); ferr != nil {
+					return nil, ferr
+				}
+				repoReq, ferr := alumniPatchRequest(existing, &doc)
+				if ferr != nil {
+					return nil, ferr
+				}
+				alumni, err := repository.UpdateAlumniCtx(ctx, db, op.ID, repoReq, expectedVersion)
+				if errors.Is(err, helper.ErrVersionConflict) {
+					return nil, fiber.NewError(fiber.StatusPreconditionFailed, versionConflictMessage)
+				}
+				if err != nil {
+					return nil, fiber.NewError(fiber.StatusInternalServerError, "Gagal mengupdate alumni: "+err.Error())
+				}
+				return &batchOutcome{
+					status:  fiber.StatusOK,
+					message: "Berhasil mengupdate alumni",
+					data:    alumni,
+					effects: func() {
+						entry := auditEntry(helper.AuditActionUpdate, helper.AuditEntityAlumni, op.ID, existing, alumni)
+						if repoReq.Password != nil {
+							entry.Metadata = map[string]any{"password_changed": true}
+						}
+						recordAudit(c, db, entry)
+					},
+				}, nil
+			}, nil
+
+		case "delete":
+			if _, err := primitive.ObjectIDFromHex(op.ID); err != nil {
+				return nil, fiber.NewError(fiber.StatusBadRequest, "Format ID tidak valid")
+			}
+			return func(ctx context.Context) (*batchOutcome, *fiber.Error) {
+				existing, err := repository.GetAlumniByIDCtx(ctx, db, op.ID)
+				if err != nil {
+					return nil, fiber.NewError(fiber.StatusInternalServerError, "Gagal mengambil data alumni: "+err.Error())
+				}
+				if existing == nil {
+					return nil, fiber.NewError(fiber.StatusNotFound, "Alumni tidak ditemukan")
+				}
+				if err := repository.DeleteAlumniCtx(ctx, db, op.ID); err != nil {
+					return nil, fiber.NewError(fiber.StatusInternalServerError, "Gagal menghapus alumni: "+err.Error())
+				}
+				return &batchOutcome{
+					status:  fiber.StatusOK,
+					message: "Berhasil menghapus alumni",
+					effects: func() {
+						recordAudit(c, db, auditEntry(helper.AuditActionDelete, helper.AuditEntityAlumni, op.ID, existing, nil))
+					},
+				}, nil
+			}, nil
+		}
+		return nil, errBatchUnknownOp
+	})
+}
+
+// BatchPekerjaanService -> banyak create/update/delete pekerjaan dalam satu request, mis. menandai
+// banyak kontrak selesai sekaligus. Update memakai merge patch seperti PATCH /pekerjaan/:id.
+func BatchPekerjaanService(c *fiber.Ctx, db *mongoDB.Database) error {
+	return runBatch(c, db, func(op mongo.BatchOperation) (batchStep, *fiber.Error) {
+		switch op.Op {
+		case "create":
+			var req mongo.CreatePekerjaanAlumniRequest
+			if err := json.Unmarshal(op.Data, &req); err != nil {
+				return nil, fiber.NewError(fiber.StatusBadRequest, "Format data tidak valid: "+err.Error())
+			}
+			repoReq, ferr := pekerjaanCreateRequest(&req)
+			if ferr != nil {
+				return nil, ferr
+			}
+			return func(ctx context.Context) (*batchOutcome, *fiber.Error) {
+				alumni, err := repository.GetAlumniByIDCtx(ctx, db, req.AlumniID)
+				if err != nil {
+					return nil, fiber.NewError(fiber.StatusInternalServerError, "Gagal mengambil data alumni: "+err.Error())
+				}
+				if alumni == nil {
+					return nil, fiber.NewError(fiber.StatusNotFound, "Alumni tidak ditemukan")
+				}
+				if ferr := linkPekerjaanReferences(ctx, db, &repoReq.CompanyID, &repoReq.NamaPerusahaan, &repoReq.IndustryID, &repoReq.BidangIndustri); ferr != nil {
+					return nil, ferr
+				}
+				if ferr := lockAlumni(ctx, db, repoReq.AlumniID); ferr != nil {
+					return nil, ferr
+				}
+				if ferr := checkCareerRules(ctx, db, repoReq.AlumniID, createCareerPeriod(repoReq)); ferr != nil {
+					return nil, ferr
+				}
+				pekerjaan, err := repository.CreatePekerjaanCtx(ctx, db, repoReq)
+				if err != nil {
+					return nil, fiber.NewError(fiber.StatusInternalServerError, "Gagal membuat pekerjaan: "+err.Error())
+				}
+				return &batchOutcome{
+					status:  fiber.StatusCreated,
+					message: "Berhasil membuat pekerjaan",
+					id:      pekerjaan.ID.Hex(),
+					data:    pekerjaan,
+					effects: func() {
+						recordAudit(c, db, auditEntry(helper.AuditActionCreate, helper.AuditEntityPekerjaan, pekerjaan.ID.Hex(), nil, pekerjaan))
+					},
+				}, nil
+			}, nil
+
+		case "update":
+			if _, err := primitive.ObjectIDFromHex(op.ID); err != nil {
+				return nil, fiber.NewError(fiber.StatusBadRequest, "Format ID tidak valid")
+			}
+			expectedVersion, ferr := batchIfMatch(op)
+			if ferr != nil {
+				return nil, ferr
+			}
+			if ferr := batchMergePatch(op); ferr != nil {
+				return nil, ferr
+			}
+			return func(ctx context.Context) (*batchOutcome, *fiber.Error) {
+				existing, err := repository.GetPekerjaanByIDCtx(ctx, db, op.ID)
+				if err != nil {
+					return nil, fiber.NewError(fiber.StatusInternalServerError, "Gagal mengambil data pekerjaan: "+err.Error())
+				}
+				if existing == nil {
+					return nil, fiber.NewError(fiber.StatusNotFound, "Pekerjaan tidak ditemukan")
+				}
+				if expectedVersion != nil && *expectedVersion != existing.Version {
+					return nil, fiber.NewError(fiber.StatusPreconditionFailed, versionConflictMessage)
+				}
+				var doc mongo.PekerjaanPatchDocument
+				if ferr := patchDocument(helper.MergePatchContentType, op.Data, pekerjaanPatchDocument(existing), &doc); ferr != nil {
+					return nil, ferr
+				}
+				repoReq, ferr := pekerjaanPatchRequest(&doc)
+				if ferr != nil {
+					return nil, ferr
+				}
+				if ferr := linkPekerjaanReferences(ctx, db, &repoReq.CompanyID, &repoReq.NamaPerusahaan, &repoReq.IndustryID, &repoReq.BidangIndustri); ferr != nil {
+					return nil, ferr
+				}
+				if ferr := lockAlumni(ctx, db, existing.AlumniID); ferr != nil {
+					return nil, ferr
+				}
+				if ferr := checkCareerRules(ctx, db, existing.AlumniID, updateCareerPeriod(existing.ID, repoReq)); ferr != nil {
+					return nil, ferr
+				}
+				pekerjaan, err := repository.UpdatePekerjaanCtx(ctx, db, op.ID, repoReq, expectedVersion)
+				if errors.Is(err, helper.ErrVersionConflict) {
+					return nil, fiber.NewError(fiber.StatusPreconditionFailed, versionConflictMessage)
+				}
+				if err != nil {
+					return nil, fiber.NewError(fiber.StatusInternalServerError, "Gagal mengupdate pekerjaan: "+err.Error())
+				}
+				return &batchOutcome{
+					status:  fiber.StatusOK,
+					message: "Berhasil mengupdate pekerjaan",
+					data:    pekerjaan,
+					effects: func() {
+						recordAudit(c, db, auditEntry(helper.AuditActionUpdate, helper.AuditEntityPekerjaan, op.ID, existing, pekerjaan))
+					},
+				}, nil
+			}, nil
+
+		case "delete":
+			if _, err := primitive.ObjectIDFromHex(op.ID); err != nil {
+				return nil, fiber.NewError(fiber.StatusBadRequest, "Format ID tidak valid")
+			}
+			return func(ctx context.Context) (*batchOutcome, *fiber.Error) {
+				existing, err := repository.GetPekerjaanByIDCtx(ctx, db, op.ID)
+				if err != nil {
+					return nil, fiber.NewError(fiber.StatusInternalServerError, "Gagal mengambil data pekerjaan: "+err.Error())
+				}
+				if existing == nil {
+					return nil, fiber.NewError(fiber.StatusNotFound, "Pekerjaan tidak ditemukan")
+				}
+				if err := repository.DeletePekerjaanCtx(ctx, db, op.ID); err != nil {
+					return nil, fiber.NewError(fiber.StatusInternalServerError, "Gagal menghapus pekerjaan: "+err.Error())
+				}
+				return &batchOutcome{
+					status:  fiber.StatusOK,
+					message: "Berhasil menghapus pekerjaan",
+					effects: func() {
+						recordAudit(c, db, auditEntry(helper.AuditActionDelete, helper.AuditEntityPekerjaan, op.ID, existing, nil))
+					},
+				}, nil
+			}, nil
+		}
+		return nil, errBatchUnknownOp
+	})
+}
@@ -0,0 +1,191 @@
+package mongo
+
+import (
+	"context"
+	"go-fiber/app/model/mongo"
+	repository "go-fiber/app/repository/mongo"
+	"go-fiber/helper"
+	"sort"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongoDB "go.mongodb.org/mongo-driver/mongo"
+)
+
+// CareerTimelineService -> GET /alumni/:id/career: riwayat pekerjaan alumni berurutan dengan masa
+// kerja per pekerjaan, celah antar pekerjaan, dan total pengalaman
+func CareerTimelineService(c *fiber.Ctx, db *mongoDB.Database) error {
+	id := c.Params("id")
+	alumniID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(mongo.CareerTimelineResponse{
+			Success: false,
+			Message: "Format ID tidak valid",
+		})
+	}
+
+	alumni, err := repository.GetAlumniByID(db, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(mongo.CareerTimelineResponse{
+			Success: false,
+			Message: "Gagal mengambil data alumni: " + err.Error(),
+		})
+	}
+	if alumni == nil {
+		return c.Status(fiber.StatusNotFound).JSON(mongo.CareerTimelineResponse{
+			Success: false,
+			Message: "Alumni tidak ditemukan",
+		})
+	}
+
+	pekerjaan, err := repository.GetPekerjaanByAlumniID(db, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(mongo.CareerTimelineResponse{
+			Success: false,
+			Message: "Gagal mengambil data pekerjaan: " + err.Error(),
+		})
+	}
+
+	for i := range pekerjaan {
+		maskSalary(c, &pekerjaan[i])
+	}
+
+	return c.Status(fiber.StatusOK).JSON(mongo.CareerTimelineResponse{
+		Success: true,
+		Message: "Berhasil mengambil riwayat karier alumni",
+		Data:    careerTimeline(alumniID, pekerjaan, time.Now()),
+	})
+}
+
+// careerTimeline -> susun timeline dari pekerjaan alumni; pekerjaan yang masih berjalan dihitung
+// sampai now
+func careerTimeline(alumniID primitive.ObjectID, pekerjaan []mongo.PekerjaanAlumni, now time.Time) mongo.CareerTimeline {
+	sort.SliceStable(pekerjaan, func(i, j int) bool {
+		return pekerjaan[i].TanggalMulaiKerja.Before(pekerjaan[j].TanggalMulaiKerja)
+	})
+
+	periods := make([]helper.CareerPeriod, len(pekerjaan))
+	entries := make([]mongo.CareerTimelineEntry, len(pekerjaan))
+	for i, p := range pekerjaan {
+		periods[i] = careerPeriod(p)
+		days, months := helper.CareerTenure(periods[i], now)
+		entries[i] = mongo.CareerTimelineEntry{
+			PekerjaanAlumni: p,
+			Ongoing:         p.TanggalSelesaiKerja == nil,
+			TenureDays:      days,
+			TenureMonths:    months,
+		}
+	}
+
+	summary := helper.SummarizeCareer(periods, now)
+	timeline := mongo.CareerTimeline{
+		AlumniID:              alumniID,
+		Entries:               entries,
+		Gaps:                  make([]mongo.CareerGap, len(summary.Gaps)),
+		Overlaps:              make([]mongo.CareerOverlap, len(summary.Overlaps)),
+		TotalExperienceDays:   summary.TotalDays,
+		TotalExperienceMonths: summary.TotalMonths,
+	}
+	for i, g := range summary.Gaps {
+		timeline.Gaps[i] = mongo.CareerGap{From: g.From, To: g.To, Days: g.Days}
+	}
+	for i, o := range summary.Overlaps {
+		timeline.Overlaps[i] = mongo.CareerOverlap{
+			PekerjaanIDs: []primitive.ObjectID{pekerjaan[o.First].ID, pekerjaan[o.Second].ID},
+			Days:         o.Days,
+		}
+	}
+	return timeline
+}
+
+// careerPeriod -> pekerjaan dalam bentuk yang diperiksa aturan karier
+func careerPeriod(p mongo.PekerjaanAlumni) helper.CareerPeriod {
+	return helper.CareerPeriod{
+		ID:         p.ID.Hex(),
+		Label:      p.PosisiJabatan + " di " + p.NamaPerusahaan,
+		Start:      p.TanggalMulaiKerja,
+		End:        p.TanggalSelesaiKerja,
+		Aktif:      p.StatusPekerjaan == "aktif",
+		ParuhWaktu: p.ParuhWaktu,
+	}
+}
+
+func createCareerPeriod(req *mongo.CreatePekerjaanAlumniRepositoryRequest) helper.CareerPeriod {
+	return careerPeriod(mongo.PekerjaanAlumni{
+		NamaPerusahaan:      req.NamaPerusahaan,
+		PosisiJabatan:       req.PosisiJabatan,
+		TanggalMulaiKerja:   req.TanggalMulaiKerja,
+		TanggalSelesaiKerja: req.TanggalSelesaiKerja,
+		StatusPekerjaan:     req.StatusPekerjaan,
+		ParuhWaktu:          req.ParuhWaktu,
+	})
+}
+
+func updateCareerPeriod(id primitive.ObjectID, req *mongo.UpdatePekerjaanAlumniRepositoryRequest) helper.CareerPeriod {
+	return careerPeriod(mongo.PekerjaanAlumni{
+		ID:                  id,
+		NamaPerusahaan:      req.NamaPerusahaan,
+		PosisiJabatan:       req.PosisiJabatan,
+		TanggalMulaiKerja:   req.TanggalMulaiKerja,
+		TanggalSelesaiKerja: req.TanggalSelesaiKerja,
+		StatusPekerjaan:     req.StatusPekerjaan,
+		ParuhWaktu:          req.ParuhWaktu,
+	})
+}
+
+// lockAlumni -> sentuh dokumen alumni di transaksi ctx sehingga pemeriksaan aturan karier dan
+// penyimpanan pekerjaan untuk alumni yang sama berjalan bergantian, tidak bisa sama-sama lolos
+func lockAlumni(ctx context.Context, db *mongoDB.Database, alumniID primitive.ObjectID) *fiber.Error {
+	if err := repository.LockAlumniCtx(ctx, db, alumniID); err != nil {
+		if err == mongoDB.ErrNoDocuments {
+			return fiber.NewError(fiber.StatusNotFound, "Alumni tidak ditemukan")
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, "Gagal mengunci data alumni: "+err.Error())
+	}
+	return nil
+}
+
+// checkCareerRules -> 409 bila candidate bertabrakan dengan pekerjaan lain milik alumni menurut
+// aturan CAREER_*. pending -> pekerjaan yang belum tersimpan (baris lain di file import) dan
+// menggantikan pekerjaan tersimpan dengan ID yang sama.
+func checkCareerRules(ctx context.Context, db *mongoDB.Database, alumniID primitive.ObjectID, candidate helper.CareerPeriod, pending ...helper.CareerPeriod) *fiber.Error {
+	pekerjaan, err := repository.GetPekerjaanByAlumniIDCtx(ctx, db, alumniID.Hex())
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "Gagal mengambil riwayat pekerjaan alumni: "+err.Error())
+	}
+	if err := helper.CareerRulesFromEnv().Check(candidate, helper.MergeCareerPeriods(careerPeriods(pekerjaan), pending)); err != nil {
+		return fiber.NewError(fiber.StatusConflict, "Riwayat pekerjaan tidak valid: "+err.Error())
+	}
+	return nil
+}
+
+func careerPeriods(pekerjaan []mongo.PekerjaanAlumni) []helper.CareerPeriod {
+	periods := make([]helper.CareerPeriod, len(pekerjaan))
+	for i, p := range pekerjaan {
+		periods[i] = careerPeriod(p)
+	}
+	return periods
+}
+
+// saveWithCareerRules -> kunci alumni, checkCareerRules, lalu save dalam satu transaksi MongoDB.
+// Pelanggaran aturan dikembalikan sebagai *fiber.Error, error lain berasal dari save atau transaksi.
+func saveWithCareerRules(db *mongoDB.Database, alumniID primitive.ObjectID, candidate helper.CareerPeriod, save func(ctx context.Context) (*mongo.PekerjaanAlumni, error)) (*mongo.PekerjaanAlumni, error) {
+	var pekerjaan *mongo.PekerjaanAlumni
+	err := repository.RunInTransaction(db, func(ctx context.Context) error {
+		if ferr := lockAlumni(ctx, db, alumniID); ferr != nil {
+			return ferr
+		}
+		if ferr := checkCareerRules(ctx, db, alumniID, candidate); ferr != nil {
+			return ferr
+		}
+		var err error
+		pekerjaan, err = save(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pekerjaan, nil
+}
@@ -0,0 +1,670 @@
+package mongo
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-fiber/app/model/mongo"
+	repository "go-fiber/app/repository/mongo"
+	"go-fiber/helper"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongoDB "go.mongodb.org/mongo-driver/mongo"
+)
+
+// Export Services
+
+const (
+	// Export dengan jumlah baris di atas ini otomatis dijalankan sebagai job background
+	exportSyncLimit = 10000
+	// File hasil job export disimpan sementara sebelum dihapus
+	exportJobRetention = 24 * time.Hour
+	// Progress job diperbarui setiap sekian baris
+	exportProgressEvery = 1000
+)
+
+// exportDir -> lokasi file hasil job export
+var exportDir = filepath.Join(os.TempDir(), "go-fiber-exports")
+
+// exportSource -> data yang diexport: judul laporan, kolom, jumlah baris, dan streaming baris
+type exportSource struct {
+	Resource string
+	Title    string
+	Columns  []helper.ExportColumn
+	// Count nil berarti data kecil (agregat) dan selalu di-stream langsung kecuali ?async=true
+	Count  func() (int, error)
+	Stream func(write func(values []interface{}) error) error
+}
+
+// Job type export
+const JobTypeExport = "export"
+
+// queryValues -> sumber query string: *fiber.Ctx saat request, exportParams saat job export berjalan
+type queryValues interface {
+	Query(key string, defaultValue ...string) string
+	Queries() map[string]string
+}
+
+// exportParams -> query string dan identitas pemanggil yang dibutuhkan untuk membangun ulang
+// exportSource di worker job
+type exportParams struct {
+	Values map[string]string `json:"query"`
+	Role   string            `json:"role"`
+	UserID string            `json:"user_id"`
+}
+
+func newExportParams(c *fiber.Ctx) exportParams {
+	p := exportParams{Values: c.Queries()}
+	p.Role, _ = c.Locals("role").(string)
+	p.UserID, _ = c.Locals("user_id").(string)
+	return p
+}
+
+func (p exportParams) Query(key string, defaultValue ...string) string {
+	if v := p.Values[key]; v != "" {
+		return v
+	}
+	if len(defaultValue) > 0 {
+		return defaultValue[0]
+	}
+	return ""
+}
+
+func (p exportParams) Queries() map[string]string {
+	return p.Values
+}
+
+// queryBool -> sama dengan c.QueryBool untuk queryValues
+func queryBool(q queryValues, key string) bool {
+	v, _ := strconv.ParseBool(q.Query(key))
+	return v
+}
+
+// exportSources -> pembangun exportSource per jenis export; nama jenis disimpan di payload job
+var exportSources = map[string]func(db *mongoDB.Database, q exportParams) (exportSource, error){
+	"alumni":            alumniExportSource,
+	"pekerjaan":         pekerjaanExportSource,
+	"employment-status": employmentStatusExportSource,
+	"analytics":         analyticsExportSource,
+}
+
+// exportJobPayload -> payload job export
+type exportJobPayload struct {
+	Kind     string       `json:"kind"`
+	Resource string       `json:"resource"`
+	Format   string       `json:"format"`
+	FileName string       `json:"file_name"`
+	Params   exportParams `json:"params"`
+}
+
+// exportResult -> progress job export di kolom result
+type exportResult struct {
+	Rows int `json:"rows"`
+}
+
+func exportFilePath(jobID primitive.ObjectID, format string) string {
+	return filepath.Join(exportDir, jobID.Hex()+"."+format)
+}
+
+func exportError(c *fiber.Ctx, status int, message string) error {
+	return c.Status(status).JSON(fiber.Map{
+		"success": false,
+		"message": message,
+	})
+}
+
+// runExport -> ?format=csv|xlsx|ndjson|pdf (default csv). Export di-stream langsung sebagai attachment,
+// kecuali ?async=true atau jumlah baris melebihi exportSyncLimit: job export di antrean dengan response 202.
+func runExport(c *fiber.Ctx, db *mongoDB.Database, kind string) error {
+	format := strings.ToLower(c.Query("format", helper.ExportFormatCSV))
+	contentType, err := helper.ExportContentType(format)
+	if err != nil {
+		return exportError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	params := newExportParams(c)
+	src, err := exportSources[kind](db, params)
+	if err != nil {
+		return exportError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	async := c.QueryBool("async")
+	if !async && src.Count != nil {
+		total, err := src.Count()
+		if err != nil {
+			return exportError(c, fiber.StatusInternalServerError, "Gagal menghitung data export: "+err.Error())
+		}
+		async = total > exportSyncLimit
+	}
+
+	fileName := fmt.Sprintf("%s-%s.%s", src.Resource, time.Now().Format("20060102-150405"), format)
+	if async {
+		// Tidak dicoba ulang otomatis; job yang dilepas saat shutdown ditulis ulang dari awal
+		job, err := EnqueueJob(db, JobTypeExport, exportJobPayload{
+			Kind:     kind,
+			Resource: src.Resource,
+			Format:   format,
+			FileName: fileName,
+			Params:   params,
+		}, JobOptions{MaxAttempts: 1})
+		if err != nil {
+			return exportError(c, fiber.StatusInternalServerError, "Gagal membuat job export: "+err.Error())
+		}
+		view, err := exportJobView(job)
+		if err != nil {
+			return exportError(c, fiber.StatusInternalServerError, err.Error())
+		}
+		return c.Status(fiber.StatusAccepted).JSON(mongo.ExportJobResponse{
+			Success: true,
+			Message: "Export dijalankan job runner, cek progress di /exports/" + view.ID,
+			Data:    view,
+		})
+	}
+
+	c.Attachment(fileName)
+	c.Set(fiber.HeaderContentType, contentType)
+	// Cursor baru dibuka saat body ditulis; error di tengah stream hanya bisa dicatat ke log
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if _, err := writeExport(w, format, src, nil); err != nil {
+			log.Printf("export %s (%s) gagal: %v", src.Resource, format, err)
+		}
+	})
+	return nil
+}
+
+// writeExport menulis seluruh baris ke w dan mengembalikan jumlah baris; progress dipanggil berkala bila tidak nil
+func writeExport(w *bufio.Writer, format string, src exportSource, progress func(rows int)) (int, error) {
+	ew, err := helper.NewExportWriter(format, w, src.Title, src.Columns)
+	if err != nil {
+		return 0, err
+	}
+
+	rows := 0
+	err = src.Stream(func(values []interface{}) error {
+		if err := ew.WriteRow(values); err != nil {
+			return err
+		}
+		rows++
+		if progress != nil && rows%exportProgressEvery == 0 {
+			progress(rows)
+		}
+		return nil
+	})
+	if err != nil {
+		return rows, err
+	}
+	if err := ew.Close(); err != nil {
+		return rows, err
+	}
+	return rows, w.Flush()
+}
+
+// purgeExpiredExports menghapus file export yang melewati masa retensi
+func purgeExpiredExports() {
+	entries, err := os.ReadDir(exportDir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err == nil && time.Since(info.ModTime()) > exportJobRetention {
+			os.Remove(filepath.Join(exportDir, entry.Name()))
+		}
+	}
+}
+
+func registerExportJobs(r *JobRunner) {
+	RegisterJobHandler(r, JobTypeExport, func(ctx context.Context, p exportJobPayload) error {
+		build, ok := exportSources[p.Kind]
+		if !ok {
+			return PermanentJobError(fmt.Errorf("jenis export %q tidak dikenal", p.Kind))
+		}
+		src, err := build(r.db, p.Params)
+		if err != nil {
+			return PermanentJobError(err)
+		}
+		// Berhenti di tengah stream saat runner shutdown atau job dibatalkan
+		stream := src.Stream
+		src.Stream = func(write func([]interface{}) error) error {
+			return stream(func(values []interface{}) error {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				return write(values)
+			})
+		}
+
+		purgeExpiredExports()
+		path := exportFilePath(runningJob(ctx).ID, p.Format)
+		rows, err := func() (int, error) {
+			if err := os.MkdirAll(exportDir, 0755); err != nil {
+				return 0, err
+			}
+			f, err := os.Create(path)
+			if err != nil {
+				return 0, err
+			}
+			defer f.Close()
+
+			return writeExport(bufio.NewWriter(f), p.Format, src, func(rows int) {
+				if err := r.saveJobResult(ctx, exportResult{Rows: rows}); err != nil {
+					log.Printf("export %s: gagal menyimpan progress: %v", p.Resource, err)
+				}
+			})
+		}()
+		if err != nil {
+			os.Remove(path)
+			return err
+		}
+		return r.saveJobResult(ctx, exportResult{Rows: rows})
+	})
+}
+
+// exportJobView -> progress export dari job di antrean; status job dipetakan ke status export
+func exportJobView(job *mongo.Job) (mongo.ExportJob, error) {
+	var p exportJobPayload
+	if err := json.Unmarshal(job.Payload, &p); err != nil {
+		return mongo.ExportJob{}, fmt.Errorf("payload job export tidak valid: %v", err)
+	}
+	var result exportResult
+	if len(job.Result) > 0 {
+		if err := json.Unmarshal(job.Result, &result); err != nil {
+			return mongo.ExportJob{}, fmt.Errorf("progress job export tidak valid: %v", err)
+		}
+	}
+
+	view := mongo.ExportJob{
+		ID:         job.ID.Hex(),
+		Resource:   p.Resource,
+		Format:     p.Format,
+		Rows:       result.Rows,
+		FileName:   p.FileName,
+		CreatedAt:  job.CreatedAt,
+		FinishedAt: job.FinishedAt,
+	}
+	switch job.Status {
+	case mongo.JobStatusQueued:
+		view.Status = mongo.ExportStatusQueued
+	case mongo.JobStatusRunning:
+		view.Status = mongo.ExportStatusRunning
+	case mongo.JobStatusCompleted:
+		view.Status = mongo.ExportStatusCompleted
+		view.DownloadURL = "/exports/" + view.ID + "/download"
+	case mongo.JobStatusCancelled:
+		view.Status = mongo.ExportStatusFailed
+		view.Error = "Job export dibatalkan"
+	default:
+		view.Status = mongo.ExportStatusFailed
+		view.Error = job.LastError
+	}
+	return view, nil
+}
+
+// ExportAlumniService -> GET /alumni/export, search/sortBy/order/filter sama dengan GET /alumni
+func ExportAlumniService(c *fiber.Ctx, db *mongoDB.Database) error {
+	return runExport(c, db, "alumni")
+}
+
+func alumniExportSource(db *mongoDB.Database, q exportParams) (exportSource, error) {
+	sortBy := q.Query("sortBy", "id")
+	order := q.Query("order", "asc")
+	search := q.Query("search", "")
+	if _, ok := repository.AlumniSortTypes[sortBy]; !ok {
+		sortBy = "id"
+	}
+	if strings.ToLower(order) != "desc" {
+		order = "asc"
+	}
+
+	filters, err := helper.ParseFilters(q.Queries(), repository.AlumniFilterFields)
+	if err != nil {
+		return exportSource{}, err
+	}
+
+	return exportSource{
+		Resource: "alumni",
+		Title:    "Data Alumni",
+		Columns: []helper.ExportColumn{
+			{Key: "id", Label: "ID", Width: 2.4},
+			{Key: "nim", Label: "NIM", Width: 1.2},
+			{Key: "nama", Label: "Nama", Width: 2},
+			{Key: "jurusan", Label: "Jurusan", Width: 1.6},
+			{Key: "angkatan", Label: "Angkatan", Width: 0.8},
+			{Key: "tahun_lulus", Label: "Tahun Lulus", Width: 0.9},
+			{Key: "email", Label: "Email", Width: 2.4},
+			{Key: "no_telepon", Label: "No. Telepon", Width: 1.3},
+			{Key: "alamat", Label: "Alamat", Width: 2.4},
+		},
+		Count: func() (int, error) {
+			return repository.CountAlumniRepo(db, search, filters)
+		},
+		Stream: func(write func([]interface{}) error) error {
+			return repository.StreamAlumniRepo(db, search, filters, sortBy, order, func(a mongo.Alumni) error {
+				return write([]interface{}{a.ID, a.NIM, a.Nama, a.Jurusan, a.Angkatan, a.TahunLulus, a.Email, a.NoTelepon, a.Alamat})
+			})
+		},
+	}, nil
+}
+
+// ExportPekerjaanService -> GET /pekerjaan/export, search/sortBy/order/filter sama dengan GET /pekerjaan.
+// Kolom nim dan nama_alumni ikut diexport sehingga file bisa langsung di-import kembali.
+func ExportPekerjaanService(c *fiber.Ctx, db *mongoDB.Database) error {
+	return runExport(c, db, "pekerjaan")
+}
+
+func pekerjaanExportSource(db *mongoDB.Database, q exportParams) (exportSource, error) {
+	sortBy := q.Query("sortBy", "id")
+	order := q.Query("order", "asc")
+	search := q.Query("search", "")
+	if _, ok := repository.PekerjaanSortTypes[sortBy]; !ok {
+		sortBy = "id"
+	}
+	if strings.ToLower(order) != "desc" {
+		order = "asc"
+	}
+
+	filters, err := helper.ParseFilters(q.Queries(), repository.PekerjaanFilterFields)
+	if err != nil {
+		return exportSource{}, err
+	}
+
+	return exportSource{
+		Resource: "pekerjaan",
+		Title:    "Data Pekerjaan Alumni",
+		Columns: []helper.ExportColumn{
+			{Key: "id", Label: "ID", Width: 2.4},
+			{Key: "alumni_id", Label: "ID Alumni", Width: 2.4},
+			{Key: "nim", Label: "NIM", Width: 1.2},
+			{Key: "nama_alumni", Label: "Nama Alumni", Width: 1.8},
+			{Key: "nama_perusahaan", Label: "Perusahaan", Width: 1.8},
+			{Key: "posisi_jabatan", Label: "Posisi", Width: 1.6},
+			{Key: "bidang_industri", Label: "Bidang Industri", Width: 1.4},
+			{Key: "lokasi_kerja", Label: "Lokasi", Width: 1.2},
+			{Key: "provinsi", Label: "Provinsi", Width: 1.2},
+			{Key: "kota", Label: "Kota", Width: 1.2},
+			{Key: "gaji_range", Label: "Gaji", Width: 1},
+			{Key: "tanggal_mulai_kerja", Label: "Mulai", Width: 1.1},
+			{Key: "tanggal_selesai_kerja", Label: "Selesai", Width: 1.1},
+			{Key: "status_pekerjaan", Label: "Status", Width: 0.9},
+			{Key: "deskripsi_pekerjaan", Label: "Deskripsi", Width: 2},
+		},
+		Count: func() (int, error) {
+			return repository.CountPekerjaanRepo(db, search, filters)
+		},
+		Stream: func(write func([]interface{}) error) error {
+			return repository.StreamPekerjaanRepo(db, search, filters, sortBy, order, func(p mongo.PekerjaanExportRow) error {
+				return write([]interface{}{p.ID, p.AlumniID, p.NIM, p.NamaAlumni, p.NamaPerusahaan, p.PosisiJabatan, p.BidangIndustri,
+					p.LokasiKerja, p.Provinsi, p.Kota, exportSalary(p.GajiMin, p.GajiMax, &p.GajiMataUang, &p.GajiPeriode, p.GajiRange), p.TanggalMulaiKerja, p.TanggalSelesaiKerja, p.StatusPekerjaan, p.DeskripsiPekerjaan})
+			})
+		},
+	}, nil
+}
+
+// exportSalary -> teks kolom Gaji: gaji terstruktur ("5-8 juta"), atau gaji_range lama bila belum dimigrasi
+func exportSalary(min, max *int64, currency, period, legacy *string) *string {
+	if min == nil && max == nil {
+		return legacy
+	}
+	text := helper.FormatSalary(min, max, *currency, *period)
+	return &text
+}
+
+// ExportAlumniEmploymentStatusService -> GET /alumni/employment-status/export, filter sama dengan
+// GET /alumni/employment-status tanpa pagination
+func ExportAlumniEmploymentStatusService(c *fiber.Ctx, db *mongoDB.Database) error {
+	return runExport(c, db, "employment-status")
+}
+
+func employmentStatusExportSource(db *mongoDB.Database, q exportParams) (exportSource, error) {
+	req := employmentStatusRequest(q, q.Role, q.UserID)
+	filters, err := helper.ParseFilters(q.Queries(), repository.EmploymentStatusFilterFields)
+	if err != nil {
+		return exportSource{}, err
+	}
+
+	return exportSource{
+		Resource: "employment-status",
+		Title:    "Status Pekerjaan Alumni",
+		Columns: []helper.ExportColumn{
+			{Key: "id", Label: "ID", Width: 2.4},
+			{Key: "nama", Label: "Nama", Width: 2},
+			{Key: "jurusan", Label: "Jurusan", Width: 1.6},
+			{Key: "angkatan", Label: "Angkatan", Width: 0.8},
+			{Key: "bidang_industri", Label: "Bidang Industri", Width: 1.5},
+			{Key: "nama_perusahaan", Label: "Perusahaan", Width: 1.8},
+			{Key: "posisi_jabatan", Label: "Posisi", Width: 1.6},
+			{Key: "tanggal_mulai_kerja", Label: "Mulai", Width: 1.1},
+			{Key: "gaji_range", Label: "Gaji", Width: 1},
+			{Key: "lebih_dari_1_tahun", Label: "> 1 Tahun", Width: 0.8},
+			{Key: "employment_count", Label: "Jml Pekerjaan", Width: 1},
+		},
+		Count: func() (int, error) {
+			return repository.CountAlumniEmploymentStatus(db, req, filters)
+		},
+		Stream: func(write func([]interface{}) error) error {
+			return repository.StreamAlumniEmploymentStatus(db, req, filters, func(s mongo.AlumniEmploymentStatus) error {
+				return write([]interface{}{s.ID, s.Nama, s.Jurusan, s.Angkatan, s.BidangIndustri, s.NamaPerusahaan, s.PosisiJabatan,
+					s.TanggalMulaiKerja, exportSalary(s.GajiMin, s.GajiMax, s.GajiMataUang, s.GajiPeriode, s.GajiRange), s.LebihDari1Tahun, s.EmploymentCount})
+			})
+		},
+	}, nil
+}
+
+// ExportAnalyticsService -> GET /analytics/export?metric=employment-rate|time-to-first-job|distribution|salary|retention,
+// parameter lain sama dengan endpoint analytics masing-masing
+func ExportAnalyticsService(c *fiber.Ctx, db *mongoDB.Database) error {
+	return runExport(c, db, "analytics")
+}
+
+func analyticsExportSource(db *mongoDB.Database, q exportParams) (exportSource, error) {
+	metric := q.Query("metric")
+	activeOnly := queryBool(q, "active_only")
+
+	defaultGroupBy := "all"
+	if metric == "employment-rate" {
+		defaultGroupBy = "angkatan"
+	}
+	groupBy, filters, err := parseAnalyticsQuery(q, defaultGroupBy)
+	if err != nil {
+		return exportSource{}, err
+	}
+	groupColumn := helper.ExportColumn{Key: "group", Label: "Kelompok (" + groupBy + ")", Width: 2}
+
+	var src exportSource
+	switch metric {
+	case "employment-rate":
+		src = exportSource{
+			Title: "Employment Rate Alumni per " + groupBy,
+			Columns: []helper.ExportColumn{groupColumn,
+				{Key: "total_alumni", Label: "Total Alumni"},
+				{Key: "employed", Label: "Bekerja"},
+				{Key: "ever_employed", Label: "Pernah Bekerja"},
+				{Key: "employment_rate", Label: "Employment Rate (%)"},
+			},
+			Stream: func(write func([]interface{}) error) error {
+				items, err := loadEmploymentRate(db, groupBy, filters)
+				if err != nil {
+					return err
+				}
+				for _, item := range items {
+					if err := write([]interface{}{item.Group, item.TotalAlumni, item.Employed, item.EverEmployed, item.EmploymentRate}); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		}
+	case "time-to-first-job":
+		src = exportSource{
+			Title: "Waktu Tunggu Pekerjaan Pertama per " + groupBy,
+			Columns: []helper.ExportColumn{groupColumn,
+				{Key: "alumni_with_job", Label: "Alumni Bekerja"},
+				{Key: "median_months", Label: "Median (bulan)"},
+				{Key: "average_months", Label: "Rata-rata (bulan)"},
+			},
+			Stream: func(write func([]interface{}) error) error {
+				items, err := loadTimeToFirstJob(db, groupBy, filters)
+				if err != nil {
+					return err
+				}
+				for _, item := range items {
+					if err := write([]interface{}{item.Group, item.AlumniWithJob, item.MedianMonths, item.AverageMonths}); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		}
+	case "distribution":
+		field := q.Query("field", "bidang_industri")
+		if _, ok := repository.AnalyticsDistributionFields[field]; !ok {
+			return exportSource{}, fmt.Errorf("field harus bidang_industri, lokasi_kerja, provinsi, atau kota")
+		}
+		src = exportSource{
+			Title: "Distribusi Pekerjaan per " + field,
+			Columns: []helper.ExportColumn{
+				{Key: field, Label: field, Width: 2},
+				{Key: "jobs", Label: "Pekerjaan"},
+				{Key: "alumni", Label: "Alumni"},
+				{Key: "percentage", Label: "Persentase (%)"},
+			},
+			Stream: func(write func([]interface{}) error) error {
+				items, _, err := loadJobDistribution(db, field, activeOnly, filters)
+				if err != nil {
+					return err
+				}
+				for _, item := range items {
+					if err := write([]interface{}{item.Value, item.Jobs, item.Alumni, item.Percentage}); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		}
+	case "salary":
+		src = exportSource{
+			Title: "Histogram Gaji Alumni",
+			Columns: []helper.ExportColumn{
+				{Key: "label", Label: "Kelompok Gaji", Width: 2},
+				{Key: "min", Label: "Min (juta)"},
+				{Key: "max", Label: "Max (juta)"},
+				{Key: "count", Label: "Jumlah"},
+				{Key: "percentage", Label: "Persentase (%)"},
+			},
+			Stream: func(write func([]interface{}) error) error {
+				histogram, err := loadSalaryHistogram(db, activeOnly, filters)
+				if err != nil {
+					return err
+				}
+				for _, b := range histogram.Buckets {
+					if err := write([]interface{}{b.Label, b.Min, b.Max, b.Count, b.Percentage}); err != nil {
+						return err
+					}
+				}
+				return write([]interface{}{"tidak terbaca", nil, nil, histogram.Unparsed, nil})
+			},
+		}
+	case "retention":
+		src = exportSource{
+			Title: "Retensi Pekerjaan Alumni per " + groupBy,
+			Columns: []helper.ExportColumn{groupColumn,
+				{Key: "eligible_jobs", Label: "Pekerjaan Dinilai"},
+				{Key: "retained_jobs", Label: "Bertahan > 1 Tahun"},
+				{Key: "retention_rate", Label: "Retensi (%)"},
+			},
+			Stream: func(write func([]interface{}) error) error {
+				items, err := loadJobRetention(db, groupBy, filters)
+				if err != nil {
+					return err
+				}
+				for _, item := range items {
+					if err := write([]interface{}{item.Group, item.EligibleJobs, item.RetainedJobs, item.RetentionRate}); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		}
+	default:
+		return exportSource{}, fmt.Errorf("metric harus salah satu dari employment-rate, time-to-first-job, distribution, salary, retention")
+	}
+
+	src.Resource = "analytics-" + metric
+	return src, nil
+}
+
+// findExportJob -> job export untuk :id; nil tanpa error bila id tidak valid atau job bukan export
+func findExportJob(db *mongoDB.Database, idParam string) (*mongo.Job, error) {
+	id, err := primitive.ObjectIDFromHex(idParam)
+	if err != nil {
+		return nil, nil
+	}
+	job, err := repository.FindJobByID(db, id)
+	if err != nil || job == nil {
+		return nil, err
+	}
+	if job.Type != JobTypeExport {
+		return nil, nil
+	}
+	return job, nil
+}
+
+// GetExportJobService -> GET /exports/:id untuk polling progress export dari collection jobs
+func GetExportJobService(c *fiber.Ctx, db *mongoDB.Database) error {
+	job, err := findExportJob(db, c.Params("id"))
+	if err != nil {
+		return exportError(c, fiber.StatusInternalServerError, "Gagal mengambil job export: "+err.Error())
+	}
+	if job == nil {
+		return exportError(c, fiber.StatusNotFound, "Job export tidak ditemukan")
+	}
+
+	view, err := exportJobView(job)
+	if err != nil {
+		return exportError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(mongo.ExportJobResponse{
+		Success: true,
+		Message: "Status job export " + view.Status,
+		Data:    view,
+	})
+}
+
+// DownloadExportService -> GET /exports/:id/download, hanya untuk job yang sudah completed. File ada
+// di direktori sementara instance yang menjalankan job dan dihapus setelah masa retensi.
+func DownloadExportService(c *fiber.Ctx, db *mongoDB.Database) error {
+	job, err := findExportJob(db, c.Params("id"))
+	if err != nil {
+		return exportError(c, fiber.StatusInternalServerError, "Gagal mengambil job export: "+err.Error())
+	}
+	if job == nil {
+		return exportError(c, fiber.StatusNotFound, "Job export tidak ditemukan")
+	}
+	view, err := exportJobView(job)
+	if err != nil {
+		return exportError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	if view.Status != mongo.ExportStatusCompleted {
+		return exportError(c, fiber.StatusConflict, "Export belum selesai (status "+view.Status+")")
+	}
+
+	path := exportFilePath(job.ID, view.Format)
+	if _, err := os.Stat(path); err != nil {
+		return exportError(c, fiber.StatusGone, "File export sudah tidak tersedia, jalankan export ulang")
+	}
+	if err := c.SendFile(path); err != nil {
+		return err
+	}
+	contentType, _ := helper.ExportContentType(view.Format)
+	c.Attachment(view.FileName)
+	c.Set(fiber.HeaderContentType, contentType)
+	return nil
+}
@@ -13,11 +13,12 @@ import (
 
 	model "go-fiber/app/model/mongo"
 	repository "go-fiber/app/repository/mongo"
+	"go-fiber/helper"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
-	goMongo "go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	goMongo "go.mongodb.org/mongo-driver/mongo"
 )
 
 const (
@@ -25,29 +26,269 @@ const (
 	categoryCertificate = "certificate"
 )
 
+// UploadPhotoService & UploadCertificateService dipertahankan untuk kompatibilitas;
+// kebijakannya tetap dibaca dari collection file_categories seperti upload generik.
 func UploadPhotoService(c *fiber.Ctx, db *goMongo.Database) error {
-	return handleUpload(c, db, categoryPhoto, 1*1024*1024, []string{"image/jpeg", "image/png"})
+	return uploadToCategory(c, db, categoryPhoto)
 }
 
 func UploadCertificateService(c *fiber.Ctx, db *goMongo.Database) error {
-	return handleUpload(c, db, categoryCertificate, 2*1024*1024, []string{"application/pdf"})
+	return uploadToCategory(c, db, categoryCertificate)
 }
 
-func handleUpload(c *fiber.Ctx, db *goMongo.Database, category string, maxSize int64, allowedTypes []string) error {
-	userIDParam := c.Params("id")
-	if userIDParam == "" {
+// UploadFileService -> upload generik, kebijakan diambil dari kategori pada path
+func UploadFileService(c *fiber.Ctx, db *goMongo.Database) error {
+	return uploadToCategory(c, db, c.Params("category"))
+}
+
+func uploadToCategory(c *fiber.Ctx, db *goMongo.Database, name string) error {
+	if c.Params("id") == "" {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "User id is required"})
 	}
 
-    fileHeader, err := c.FormFile("file")
-    if err != nil || fileHeader == nil {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-            "success": false,
-            "message": "No file uploaded. Use form-data with key 'file' (type File)",
-        })
-    }
+	fileHeader, err := c.FormFile("file")
+	if err != nil || fileHeader == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "No file uploaded. Use form-data with key 'file' (type File)",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	category, err := resolveFileCategory(ctx, db, name)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Failed to load file category"})
+	}
+	if category == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"success": false, "message": "Unknown file category"})
+	}
+
+	return handleUpload(c, db, *category, fileHeader)
+}
+
+// ListFilesService -> daftar file milik user, terbaru dulu, dengan cursor pagination (?after / ?before)
+func ListFilesService(c *fiber.Ctx, db *goMongo.Database) error {
+	alumniOID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "Invalid user id"})
+	}
+	return listFiles(c, db, alumniOID)
+}
+
+// listFiles -> daftar file milik alumniOID; dipakai GET /users/:id/files dan GET /me/files
+func listFiles(c *fiber.Ctx, db *goMongo.Database, alumniOID primitive.ObjectID) error {
+	filters, err := helper.ParseFilters(c.Queries(), repository.FileFilterFields)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+
+	page, err := parseListPage(c, "uploaded_at", "desc", helper.FilterTypeDate, helper.FilterTypeObjectID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+	// Listing file hanya berbasis cursor; tanpa after/before berarti halaman pertama
+	page.Page, page.Offset = 1, 0
+
+	repo := repository.NewFileRepository(db)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	files, hasMore, err := repo.ListByAlumniKeyset(ctx, alumniOID, filters, page.Keyset, page.Limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Failed to list files"})
+	}
+
+	var total *int
+	if page.WithTotal {
+		count, err := repo.CountByAlumni(ctx, alumniOID, filters)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Failed to count files"})
+		}
+		n := int(count)
+		total = &n
+	}
+
+	data := make([]model.FileResponse, 0, len(files))
+	for _, f := range files {
+		data = append(data, toFileResponse(f))
+	}
+
+	var first, last *helper.CursorPosition
+	if len(files) > 0 {
+		first = &helper.CursorPosition{Value: files[0].UploadedAt, ID: files[0].ID}
+		last = &helper.CursorPosition{Value: files[len(files)-1].UploadedAt, ID: files[len(files)-1].ID}
+	}
+
+	meta := page.meta(total, hasMore, first, last)
+	meta.SortBy = "uploaded_at"
+	meta.Order = "desc"
+
+	return c.JSON(model.ListFilesResponse{
+		Success: true,
+		Message: "Files retrieved successfully",
+		Data:    data,
+		Meta:    meta,
+	})
+}
+
+// ListFileCategoriesService -> daftar kategori beserta kebijakannya
+func ListFileCategoriesService(c *fiber.Ctx, db *goMongo.Database) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	categories, err := repository.NewFileCategoryRepository(db).List(ctx)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ListFileCategoriesResponse{
+			Success: false,
+			Message: "Gagal mengambil kategori file",
+			Data:    []model.FileCategory{},
+		})
+	}
+	if len(categories) == 0 {
+		categories = model.DefaultFileCategories()
+	}
+
+	return c.JSON(model.ListFileCategoriesResponse{
+		Success: true,
+		Message: "Berhasil mengambil kategori file",
+		Data:    categories,
+	})
+}
+
+// UpsertFileCategoryService -> admin membuat atau mengubah kebijakan kategori
+func UpsertFileCategoryService(c *fiber.Ctx, db *goMongo.Database) error {
+	name := strings.ToLower(strings.TrimSpace(c.Params("name")))
+	if name == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(model.FileCategoryResponse{
+			Success: false,
+			Message: "Nama kategori wajib diisi",
+		})
+	}
+
+	var req model.UpsertFileCategoryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.FileCategoryResponse{
+			Success: false,
+			Message: "Format data tidak valid: " + err.Error(),
+		})
+	}
+	if len(req.AllowedTypes) == 0 || req.MaxSize <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(model.FileCategoryResponse{
+			Success: false,
+			Message: "allowed_types dan max_size wajib diisi",
+		})
+	}
+	if req.Policy != model.FilePolicySingle && req.Policy != model.FilePolicyMultiple {
+		return c.Status(fiber.StatusBadRequest).JSON(model.FileCategoryResponse{
+			Success: false,
+			Message: "Policy harus single atau multiple",
+		})
+	}
+	if req.MaxCount < 0 || req.RetentionDays < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(model.FileCategoryResponse{
+			Success: false,
+			Message: "max_count dan retention_days tidak boleh negatif",
+		})
+	}
+
+	category := model.FileCategory{
+		Name:          name,
+		Label:         req.Label,
+		AllowedTypes:  req.AllowedTypes,
+		MaxSize:       req.MaxSize,
+		MaxCount:      req.MaxCount,
+		Policy:        req.Policy,
+		RetentionDays: req.RetentionDays,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	categories := repository.NewFileCategoryRepository(db)
+	existing, err := categories.FindByName(ctx, name)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.FileCategoryResponse{
+			Success: false,
+			Message: "Gagal mengambil kategori file",
+		})
+	}
+	if err := categories.Upsert(ctx, &category); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.FileCategoryResponse{
+			Success: false,
+			Message: "Gagal menyimpan kategori file",
+		})
+	}
+
+	action := helper.AuditActionUpdate
+	if existing == nil {
+		action = helper.AuditActionCreate
+	}
+	recordAudit(c, db, auditEntry(action, helper.AuditEntityFileCategory, name, existing, category))
+
+	return c.JSON(model.FileCategoryResponse{
+		Success: true,
+		Message: "Berhasil menyimpan kategori file",
+		Data:    category,
+	})
+}
+
+// PurgeExpiredFiles menghapus file (disk + metadata) yang melewati masa retensi
+func PurgeExpiredFiles(db *goMongo.Database) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	repo := repository.NewFileRepository(db)
+	expired, err := repo.ListExpired(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	for _, f := range expired {
+		_ = os.Remove(f.FilePath)
+		if err := repo.DeleteByID(ctx, f.ID); err != nil {
+			return 0, err
+		}
+	}
+	return len(expired), nil
+}
+
+func resolveFileCategory(ctx context.Context, db *goMongo.Database, name string) (*model.FileCategory, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return nil, nil
+	}
+	if db == nil {
+		return defaultFileCategory(name), nil
+	}
+	category, err := repository.NewFileCategoryRepository(db).FindByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if category != nil {
+		return category, nil
+	}
+	return defaultFileCategory(name), nil
+}
+
+func defaultFileCategory(name string) *model.FileCategory {
+	for _, fc := range model.DefaultFileCategories() {
+		if fc.Name == name {
+			return &fc
+		}
+	}
+	return nil
+}
+
+func handleUpload(c *fiber.Ctx, db *goMongo.Database, category model.FileCategory, fileHeader *multipart.FileHeader) error {
+	userIDParam := c.Params("id")
+	alumniOID, err := primitive.ObjectIDFromHex(userIDParam)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "Invalid user id"})
+	}
 
-	if fileHeader.Size > maxSize {
+	if fileHeader.Size > category.MaxSize {
+		notifyFileUpload(db, alumniOID, category, fileHeader.Filename, nil, fileRejectTooLarge)
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "File too large"})
 	}
 
@@ -55,12 +296,29 @@ func handleUpload(c *fiber.Ctx, db *goMongo.Database, category string, maxSize i
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": err.Error()})
 	}
-	if !isAllowed(contentType, allowedTypes) {
+	if !isAllowed(contentType, category.AllowedTypes) {
+		notifyFileUpload(db, alumniOID, category, fileHeader.Filename, nil, fileRejectTypeNotAllowed)
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "File type not allowed"})
 	}
 
+	repo := repository.NewFileRepository(db)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Multiple policy: tolak bila jumlah file sudah mencapai batas
+	if category.Policy != model.FilePolicySingle && category.MaxCount > 0 {
+		count, err := repo.CountByAlumniAndCategory(ctx, alumniOID, category.Name)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Failed to check existing files"})
+		}
+		if count >= int64(category.MaxCount) {
+			notifyFileUpload(db, alumniOID, category, fileHeader.Filename, nil, fileRejectMaxCount)
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "Maximum number of files for this category reached"})
+		}
+	}
+
 	// Build destination path
-	userDir := filepath.Join("uploads", userIDParam, category)
+	userDir := filepath.Join("uploads", userIDParam, category.Name)
 	if err := os.MkdirAll(userDir, os.ModePerm); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Failed to create upload directory"})
 	}
@@ -75,53 +333,66 @@ func handleUpload(c *fiber.Ctx, db *goMongo.Database, category string, maxSize i
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": err.Error()})
 	}
 
-	// Persist metadata (upsert behavior for photo: keep one latest)
-	repo := repository.NewFileRepository(db)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	alumniOID, err := primitive.ObjectIDFromHex(userIDParam)
-	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "Invalid user id"})
-	}
-
-	// If category is photo, remove previous file if any (single latest policy)
-	if category == categoryPhoto {
-		if existing, err := repo.FindByAlumniAndCategory(ctx, alumniOID, category); err == nil && existing != nil {
-			_ = os.Remove(existing.FilePath)
-			_ = repo.DeleteByID(ctx, existing.ID)
+	// Single policy: catat file sebelumnya, baru dihapus setelah metadata baru tersimpan
+	var replaced *model.File
+	if category.Policy == model.FilePolicySingle {
+		if existing, err := repo.FindByAlumniAndCategory(ctx, alumniOID, category.Name); err == nil && existing != nil {
+			replaced = existing
 		}
 	}
 
 	record := &model.File{
 		AlumniID:     alumniOID,
-		Category:     category,
+		Category:     category.Name,
 		FileName:     newName,
 		OriginalName: fileHeader.Filename,
 		FilePath:     destPath,
 		FileType:     contentType,
 		FileSize:     fileHeader.Size,
 	}
+	if category.RetentionDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, category.RetentionDays)
+		record.ExpiresAt = &expiresAt
+	}
 	if err := repo.Create(ctx, record); err != nil {
 		_ = os.Remove(destPath)
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Failed to save metadata"})
 	}
+	if replaced != nil {
+		_ = os.Remove(replaced.FilePath)
+		_ = repo.DeleteByID(ctx, replaced.ID)
+	}
+	notifyFileUpload(db, alumniOID, category, fileHeader.Filename, record.ExpiresAt, "")
+
+	entry := auditEntry(helper.AuditActionUpload, helper.AuditEntityFile, record.ID.Hex(), nil, toFileResponse(*record))
+	entry.Metadata = map[string]any{"alumni_id": userIDParam, "category": category.Name}
+	if replaced != nil {
+		entry.Before = helper.AuditSnapshot(toFileResponse(*replaced))
+		entry.Metadata["replaced_file_id"] = replaced.ID.Hex()
+	}
+	recordAudit(c, db, entry)
 
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"success": true,
 		"message": "File uploaded successfully",
-		"data": model.FileResponse{
-			ID:           record.ID.Hex(),
-			Category:     record.Category,
-			FileName:     record.FileName,
-			OriginalName: record.OriginalName,
-			FilePath:     "/" + filepath.ToSlash(record.FilePath),
-			FileType:     record.FileType,
-			FileSize:     record.FileSize,
-		},
+		"data":    toFileResponse(*record),
 	})
 }
 
+func toFileResponse(f model.File) model.FileResponse {
+	return model.FileResponse{
+		ID:           f.ID.Hex(),
+		Category:     f.Category,
+		FileName:     f.FileName,
+		OriginalName: f.OriginalName,
+		FilePath:     "/" + filepath.ToSlash(f.FilePath),
+		FileType:     f.FileType,
+		FileSize:     f.FileSize,
+		UploadedAt:   f.UploadedAt,
+		ExpiresAt:    f.ExpiresAt,
+	}
+}
+
 func isAllowed(ct string, allowed []string) bool {
 	for _, a := range allowed {
 		if strings.EqualFold(ct, a) {
@@ -133,7 +404,9 @@ func isAllowed(ct string, allowed []string) bool {
 
 func sniffContentType(hdr *multipart.FileHeader) (string, error) {
 	f, err := hdr.Open()
-	if err != nil { return "", err }
+	if err != nil {
+		return "", err
+	}
 	defer f.Close()
 
 	buf := make([]byte, 512)
@@ -159,15 +432,17 @@ func extensionForType(ct string, fallbackFromName string) string {
 
 func saveUploadedFile(hdr *multipart.FileHeader, destPath string) error {
 	src, err := hdr.Open()
-	if err != nil { return err }
+	if err != nil {
+		return err
+	}
 	defer src.Close()
 
 	out, err := os.Create(destPath)
-	if err != nil { return err }
+	if err != nil {
+		return err
+	}
 	defer out.Close()
 
 	_, err = io.Copy(out, src)
 	return err
 }
-
-
@@ -0,0 +1,14 @@
+package mongo
+
+import (
+	repository "go-fiber/app/repository/mongo"
+	"go-fiber/helper"
+
+	mongoDB "go.mongodb.org/mongo-driver/mongo"
+)
+
+// IdempotencyStore -> middleware.IdempotencyStore: response disimpan di database agar retry
+// yang masuk ke instance lain tetap di-replay
+func IdempotencyStore(db *mongoDB.Database) helper.IdempotencyStore {
+	return repository.NewIdempotencyStore(db)
+}
@@ -0,0 +1,797 @@
+package mongo
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-fiber/app/model/mongo"
+	repository "go-fiber/app/repository/mongo"
+	"go-fiber/helper"
+	utils "go-fiber/utils/mongo"
+	"io"
+	"log"
+	"net/mail"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongoDB "go.mongodb.org/mongo-driver/mongo"
+)
+
+// Import Services
+
+const (
+	importBatchSize = 100
+	maxImportRows   = 5000
+)
+
+// Job type import
+const (
+	JobTypeAlumniImport    = "alumni.import"
+	JobTypePekerjaanImport = "pekerjaan.import"
+)
+
+var alumniImportColumns = []string{"nim", "nama", "jurusan", "angkatan", "tahun_lulus", "email"}
+
+var pekerjaanImportColumns = []string{"nama_perusahaan", "posisi_jabatan", "bidang_industri", "lokasi_kerja", "tanggal_mulai_kerja", "status_pekerjaan"}
+
+// importRow -> satu baris file yang sudah dipetakan ke record repository
+type importRow[T any] struct {
+	Row    int                    `json:"row"`
+	Key    string                 `json:"key"`
+	Record T                      `json:"record"`
+	Errors []mongo.ImportRowError `json:"errors,omitempty"`
+}
+
+func (r *importRow[T]) fail(field, format string, args ...interface{}) {
+	r.Errors = append(r.Errors, mongo.ImportRowError{Row: r.Row, Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// readImportSheet membaca multipart field "file" (.csv / .xlsx) dan memastikan kolom wajib ada
+func readImportSheet(c *fiber.Ctx, required []string) (*helper.Sheet, error) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return nil, fmt.Errorf("file wajib diunggah pada field 'file'")
+	}
+	f, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+
+	sheet, err := helper.ReadSpreadsheet(fileHeader.Filename, data)
+	if err != nil {
+		return nil, err
+	}
+
+	present := map[string]bool{}
+	for _, h := range sheet.Headers {
+		present[h] = true
+	}
+	var missing []string
+	for _, col := range required {
+		if !present[col] {
+			missing = append(missing, col)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("kolom wajib tidak ada: %s", strings.Join(missing, ", "))
+	}
+	if len(sheet.Rows) == 0 {
+		return nil, fmt.Errorf("file tidak berisi data")
+	}
+	if len(sheet.Rows) > maxImportRows {
+		return nil, fmt.Errorf("maksimal %d baris per file, file berisi %d baris", maxImportRows, len(sheet.Rows))
+	}
+	return sheet, nil
+}
+
+func optionalValue(values map[string]string, key string) *string {
+	if v := values[key]; v != "" {
+		return &v
+	}
+	return nil
+}
+
+// importReport -> laporan dry-run; action ditentukan dari ada/tidaknya record lama
+func importReport[T any](resource string, rows []importRow[T], isUpdate func(T) bool) mongo.ImportReport {
+	report := mongo.ImportReport{Resource: resource, TotalRows: len(rows), Rows: make([]mongo.ImportRowResult, 0, len(rows))}
+	for _, r := range rows {
+		result := mongo.ImportRowResult{Row: r.Row, Key: r.Key, Errors: r.Errors}
+		switch {
+		case len(r.Errors) > 0:
+			result.Action = "invalid"
+			report.InvalidRows++
+		case isUpdate(r.Record):
+			result.Action = "update"
+			report.ValidRows++
+			report.ToUpdate++
+		default:
+			result.Action = "create"
+			report.ValidRows++
+			report.ToCreate++
+		}
+		report.Rows = append(report.Rows, result)
+	}
+	return report
+}
+
+// importPayload -> payload job import: hanya baris valid, baris tidak valid sudah tercatat di result awal
+type importPayload[T any] struct {
+	Rows []importRow[T] `json:"rows"`
+}
+
+// enqueueImport memasukkan baris valid ke antrean job. Baris yang tidak valid langsung dihitung
+// processed dan failed pada result awal sehingga sudah terlihat saat polling sebelum worker mulai.
+func enqueueImport[T any](db *mongoDB.Database, jobType, resource string, rows []importRow[T]) (mongo.ImportJob, error) {
+	progress := mongo.ImportJob{Resource: resource, TotalRows: len(rows), Errors: []mongo.ImportRowError{}}
+	var valid []importRow[T]
+	for _, r := range rows {
+		if len(r.Errors) > 0 {
+			progress.Processed++
+			progress.Failed++
+			progress.Errors = append(progress.Errors, r.Errors...)
+			continue
+		}
+		valid = append(valid, r)
+	}
+
+	// Tidak dicoba ulang otomatis: batch yang gagal sudah dicatat per baris di result
+	job, err := EnqueueJob(db, jobType, importPayload[T]{Rows: valid}, JobOptions{MaxAttempts: 1, Result: progress})
+	if err != nil {
+		return mongo.ImportJob{}, err
+	}
+	return importJobView(job)
+}
+
+// runImport meng-upsert baris valid per batch dan menyimpan progress setelah setiap batch. Job yang
+// dilepas saat shutdown melanjutkan dari batch terakhir yang tersimpan, bukan dari awal;
+// upsert mengembalikan error baris untuk baris yang ditolak tanpa menggagalkan batch.
+func runImport[T any](ctx context.Context, r *JobRunner, rows []importRow[T], upsert func([]importRow[T]) (int, int, []mongo.ImportRowError, error)) error {
+	job := runningJob(ctx)
+	var progress mongo.ImportJob
+	if err := json.Unmarshal(job.Result, &progress); err != nil {
+		return PermanentJobError(fmt.Errorf("progress import tidak valid: %v", err))
+	}
+
+	for start := progress.Processed - (progress.TotalRows - len(rows)); start < len(rows); start += importBatchSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		batch := rows[start:min(start+importBatchSize, len(rows))]
+		created, updated, rowErrors, err := upsert(batch)
+
+		progress.Processed += len(batch)
+		if err != nil {
+			log.Printf("import %s job %s: batch gagal: %v", progress.Resource, job.ID.Hex(), err)
+			progress.Failed += len(batch)
+			for _, row := range batch {
+				progress.Errors = append(progress.Errors, mongo.ImportRowError{Row: row.Row, Message: "Gagal menyimpan: " + err.Error()})
+			}
+		} else {
+			progress.Created += created
+			progress.Updated += updated
+			progress.Failed += len(rowErrors)
+			progress.Errors = append(progress.Errors, rowErrors...)
+		}
+		if err := r.saveJobResult(ctx, progress); err != nil {
+			return err
+		}
+	}
+
+	progress.Status = mongo.ImportStatusCompleted
+	if len(rows) > 0 && progress.Created+progress.Updated == 0 {
+		progress.Status = mongo.ImportStatusFailed
+	}
+	return r.saveJobResult(ctx, progress)
+}
+
+// importJobView -> progress import dari job di antrean; status job dipetakan ke status import
+func importJobView(job *mongo.Job) (mongo.ImportJob, error) {
+	var view mongo.ImportJob
+	if len(job.Result) > 0 {
+		if err := json.Unmarshal(job.Result, &view); err != nil {
+			return mongo.ImportJob{}, err
+		}
+	}
+	view.ID = job.ID.Hex()
+	view.CreatedAt = job.CreatedAt
+	view.FinishedAt = job.FinishedAt
+	switch job.Status {
+	case mongo.JobStatusQueued:
+		view.Status = mongo.ImportStatusQueued
+	case mongo.JobStatusRunning:
+		view.Status = mongo.ImportStatusRunning
+	case mongo.JobStatusCompleted:
+		if view.Status == "" {
+			view.Status = mongo.ImportStatusCompleted
+		}
+	case mongo.JobStatusCancelled:
+		view.Status = mongo.ImportStatusFailed
+		view.Error = "Job import dibatalkan"
+	default:
+		view.Status = mongo.ImportStatusFailed
+		view.Error = job.LastError
+	}
+	return view, nil
+}
+
+func registerImportJobs(r *JobRunner) {
+	RegisterJobHandler(r, JobTypeAlumniImport, func(ctx context.Context, p importPayload[mongo.AlumniImportRecord]) error {
+		return runImport(ctx, r, p.Rows, func(batch []importRow[mongo.AlumniImportRecord]) (int, int, []mongo.ImportRowError, error) {
+			records := make([]mongo.AlumniImportRecord, len(batch))
+			for i, row := range batch {
+				records[i] = row.Record
+				// Alumni baru tanpa kolom password mendapat password acak; alumni mengatur ulang sendiri
+				if records[i].ID != nil || records[i].Data.Password != "" {
+					continue
+				}
+				hashed, err := utils.HashPassword(randomImportPassword())
+				if err != nil {
+					return 0, 0, nil, err
+				}
+				records[i].Data.Password = hashed
+			}
+			created, updated, err := repository.UpsertAlumniBatch(r.db, records)
+			return created, updated, nil, err
+		})
+	})
+	RegisterJobHandler(r, JobTypePekerjaanImport, func(ctx context.Context, p importPayload[mongo.PekerjaanImportRecord]) error {
+		return runImport(ctx, r, p.Rows, func(batch []importRow[mongo.PekerjaanImportRecord]) (int, int, []mongo.ImportRowError, error) {
+			return upsertPekerjaanImport(r.db, batch)
+		})
+	})
+}
+
+// prepareAlumniImport memetakan baris file ke CreateAlumniRepositoryRequest, memvalidasi
+// dengan aturan yang sama seperti CreateAlumniService, dan mencocokkan alumni lama by NIM/email
+func prepareAlumniImport(db *mongoDB.Database, sheet *helper.Sheet) ([]importRow[mongo.AlumniImportRecord], error) {
+	defaultRole, err := repository.GetRoleByName(db, "user")
+	if err != nil {
+		return nil, err
+	}
+	roleCache := map[string]*primitive.ObjectID{}
+	resolveRole := func(value string) (*primitive.ObjectID, error) {
+		if id, ok := roleCache[value]; ok {
+			return id, nil
+		}
+		var role *mongo.Role
+		if oid, err := primitive.ObjectIDFromHex(value); err == nil {
+			role, err = repository.GetRoleByObjectID(db, oid)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			role, err = repository.GetRoleByName(db, value)
+			if err != nil {
+				return nil, err
+			}
+		}
+		var id *primitive.ObjectID
+		if role != nil {
+			id = &role.ID
+		}
+		roleCache[value] = id
+		return id, nil
+	}
+
+	rows := make([]importRow[mongo.AlumniImportRecord], 0, len(sheet.Rows))
+	seenNIM := map[string]int{}
+	seenEmail := map[string]int{}
+	var nims, emails []string
+
+	for _, sr := range sheet.Rows {
+		v := sr.Values
+		row := importRow[mongo.AlumniImportRecord]{Row: sr.Number, Key: v["nim"]}
+		d := &row.Record.Data
+		d.NIM, d.Nama, d.Jurusan = v["nim"], v["nama"], v["jurusan"]
+		d.Email = strings.ToLower(v["email"])
+		d.Password = v["password"]
+		d.NoTelepon = optionalValue(v, "no_telepon")
+		d.Alamat = optionalValue(v, "alamat")
+		if raw := v["tanggal_lahir"]; raw != "" {
+			if t, err := helper.ParseSpreadsheetDate(raw); err != nil {
+				row.fail("tanggal_lahir", "%v", err)
+			} else {
+				d.TanggalLahir = &t
+			}
+		}
+
+		for _, col := range []string{"nim", "nama", "jurusan", "email"} {
+			if v[col] == "" {
+				row.fail(col, "%s wajib diisi", col)
+			}
+		}
+		if d.Email != "" {
+			if _, err := mail.ParseAddress(d.Email); err != nil {
+				row.fail("email", "email tidak valid")
+			}
+		}
+		if d.Password != "" {
+			if err := utils.ValidatePassword(d.Password, d.Email, d.NIM); err != nil {
+				row.fail("password", "%v", err)
+			}
+		}
+		for _, col := range []string{"angkatan", "tahun_lulus"} {
+			n, err := helper.ParseSpreadsheetInt(v[col])
+			if err != nil || n <= 0 {
+				row.fail(col, "%s harus angka lebih dari 0", col)
+				continue
+			}
+			if col == "angkatan" {
+				d.Angkatan = n
+			} else {
+				d.TahunLulus = n
+			}
+		}
+
+		roleValue := v["role_id"]
+		if roleValue == "" {
+			roleValue = v["role"]
+		}
+		if roleValue == "" {
+			if defaultRole == nil {
+				row.fail("role_id", "role default 'user' tidak ditemukan")
+			} else {
+				d.RoleID = defaultRole.ID
+			}
+		} else if roleID, err := resolveRole(roleValue); err != nil {
+			return nil, err
+		} else if roleID == nil {
+			row.fail("role_id", "role '%s' tidak ditemukan", roleValue)
+		} else {
+			d.RoleID = *roleID
+		}
+
+		// NIM dan email harus unik di dalam file
+		if d.NIM != "" {
+			if first, ok := seenNIM[d.NIM]; ok {
+				row.fail("nim", "NIM duplikat dengan baris %d", first)
+			} else {
+				seenNIM[d.NIM] = sr.Number
+				nims = append(nims, d.NIM)
+			}
+		}
+		if d.Email != "" {
+			if first, ok := seenEmail[d.Email]; ok {
+				row.fail("email", "email duplikat dengan baris %d", first)
+			} else {
+				seenEmail[d.Email] = sr.Number
+				emails = append(emails, d.Email)
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	keys, err := repository.FindAlumniKeys(db, nil, nims, emails)
+	if err != nil {
+		return nil, err
+	}
+	byNIM := map[string]primitive.ObjectID{}
+	byEmail := map[string]primitive.ObjectID{}
+	for _, k := range keys {
+		byNIM[k.NIM] = k.ID
+		byEmail[strings.ToLower(k.Email)] = k.ID
+	}
+
+	for i := range rows {
+		row := &rows[i]
+		d := row.Record.Data
+		nimID, nimFound := byNIM[d.NIM]
+		emailID, emailFound := byEmail[d.Email]
+		switch {
+		case nimFound && emailFound && nimID != emailID:
+			row.fail("email", "NIM dan email milik dua alumni yang berbeda")
+		case nimFound:
+			row.Record.ID = &nimID
+		case emailFound:
+			row.Record.ID = &emailID
+		}
+	}
+	return rows, nil
+}
+
+// hashImportPasswords meng-hash password dari file sebelum job di-enqueue agar payload job tidak
+// pernah berisi password plaintext. Hash dijalankan paralel karena bcrypt lambat untuk ribuan baris.
+func hashImportPasswords(rows []importRow[mongo.AlumniImportRecord]) error {
+	errs := make([]error, len(rows))
+	sem := make(chan struct{}, runtime.NumCPU())
+	var wg sync.WaitGroup
+	for i := range rows {
+		if len(rows[i].Errors) > 0 || rows[i].Record.Data.Password == "" {
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(d *mongo.CreateAlumniRepositoryRequest, err *error) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			d.Password, *err = utils.HashPassword(d.Password)
+		}(&rows[i].Record.Data, &errs[i])
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func randomImportPassword() string {
+	b := make([]byte, 18)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// ImportAlumniService -> POST /alumni/import (multipart file .csv/.xlsx, ?dry_run=true untuk laporan saja)
+func ImportAlumniService(c *fiber.Ctx, db *mongoDB.Database) error {
+	sheet, err := readImportSheet(c, alumniImportColumns)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+
+	rows, err := prepareAlumniImport(db, sheet)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Gagal memvalidasi data import: " + err.Error()})
+	}
+
+	if c.QueryBool("dry_run") {
+		return c.JSON(mongo.ImportReportResponse{
+			Success: true,
+			Message: "Dry-run import alumni selesai, tidak ada data yang disimpan",
+			Data: importReport("alumni", rows, func(r mongo.AlumniImportRecord) bool {
+				return r.ID != nil
+			}),
+		})
+	}
+
+	if err := hashImportPasswords(rows); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Gagal memproses password: " + err.Error()})
+	}
+	job, err := enqueueImport(db, JobTypeAlumniImport, "alumni", rows)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Gagal membuat job import: " + err.Error()})
+	}
+
+	recordImportAudit(c, db, helper.AuditEntityAlumni, job)
+
+	return c.Status(fiber.StatusAccepted).JSON(mongo.ImportJobResponse{
+		Success: true,
+		Message: "Import alumni dijalankan job runner, cek progress di /imports/" + job.ID,
+		Data:    job,
+	})
+}
+
+// recordImportAudit -> satu entri audit per import; perubahan per baris ada di laporan job import
+func recordImportAudit(c *fiber.Ctx, db *mongoDB.Database, entityType string, job mongo.ImportJob) {
+	entry := auditEntry(helper.AuditActionImport, entityType, "", nil, nil)
+	entry.Metadata = map[string]any{"import_job_id": job.ID, "total_rows": job.TotalRows, "invalid_rows": job.Failed}
+	recordAudit(c, db, entry)
+}
+
+// pekerjaanImportKey -> kunci upsert pekerjaan: alumni, perusahaan, posisi, tanggal mulai
+func pekerjaanImportKey(alumniID primitive.ObjectID, perusahaan, posisi string, mulai time.Time) string {
+	return strings.Join([]string{alumniID.Hex(), perusahaan, posisi, mulai.Format("2006-01-02")}, "|")
+}
+
+// preparePekerjaanImport memetakan baris file ke CreatePekerjaanAlumniRepositoryRequest.
+// Alumni dirujuk lewat kolom nim atau alumni_id; pekerjaan yang sama (alumni, perusahaan,
+// posisi, tanggal mulai) diperbarui, selain itu dibuat baru.
+func preparePekerjaanImport(db *mongoDB.Database, sheet *helper.Sheet) ([]importRow[mongo.PekerjaanImportRecord], error) {
+	var nims []string
+	var ids []primitive.ObjectID
+	for _, sr := range sheet.Rows {
+		if nim := sr.Values["nim"]; nim != "" {
+			nims = append(nims, nim)
+		} else if oid, err := primitive.ObjectIDFromHex(sr.Values["alumni_id"]); err == nil {
+			ids = append(ids, oid)
+		}
+	}
+
+	alumniKeys, err := repository.FindAlumniKeys(db, ids, nims, nil)
+	if err != nil {
+		return nil, err
+	}
+	byNIM := map[string]primitive.ObjectID{}
+	byID := map[primitive.ObjectID]bool{}
+	var alumniIDs []primitive.ObjectID
+	for _, k := range alumniKeys {
+		byNIM[k.NIM] = k.ID
+		byID[k.ID] = true
+		alumniIDs = append(alumniIDs, k.ID)
+	}
+
+	jobKeys, err := repository.FindPekerjaanKeys(db, alumniIDs)
+	if err != nil {
+		return nil, err
+	}
+	existing := map[string]primitive.ObjectID{}
+	saved := map[primitive.ObjectID]mongo.PekerjaanAlumni{}
+	for _, k := range jobKeys {
+		existing[pekerjaanImportKey(k.AlumniID, k.NamaPerusahaan, k.PosisiJabatan, k.TanggalMulaiKerja)] = k.ID
+		saved[k.ID] = mongo.PekerjaanAlumni{ID: k.ID, TanggalSelesaiKerja: k.TanggalSelesaiKerja, ParuhWaktu: k.ParuhWaktu}
+	}
+
+	rows := make([]importRow[mongo.PekerjaanImportRecord], 0, len(sheet.Rows))
+	seen := map[string]int{}
+	for _, sr := range sheet.Rows {
+		v := sr.Values
+		row := importRow[mongo.PekerjaanImportRecord]{Row: sr.Number}
+		d := &row.Record.Data
+		d.NamaPerusahaan, d.PosisiJabatan = v["nama_perusahaan"], v["posisi_jabatan"]
+		d.BidangIndustri, d.LokasiKerja = v["bidang_industri"], v["lokasi_kerja"]
+		d.StatusPekerjaan = strings.ToLower(v["status_pekerjaan"])
+		// Kolom gaji_range memakai teks seperti "5-10 juta" dan disimpan sebagai gaji terstruktur
+		if salary, err := helper.ResolveSalary(optionalValue(v, "gaji_range"), nil, nil, "", ""); err != nil {
+			row.fail("gaji_range", "%v", err)
+		} else {
+			d.GajiMin, d.GajiMax = salary.Min, salary.Max
+			d.GajiMataUang, d.GajiPeriode = salary.Currency, salary.Period
+		}
+		// Kolom provinsi / kota opsional; bila kosong lokasi ditebak dari teks lokasi_kerja
+		if loc, err := helper.ResolveLocation(d.LokasiKerja, "", v["provinsi"], v["kota"], nil, nil); err != nil {
+			field := "kota"
+			if _, ok := helper.FindProvince(v["provinsi"]); !ok && v["provinsi"] != "" {
+				field = "provinsi"
+			}
+			row.fail(field, "%v", err)
+		} else {
+			d.Negara, d.Provinsi, d.Kota = loc.Negara, loc.Provinsi, loc.Kota
+			d.Latitude, d.Longitude = loc.Latitude, loc.Longitude
+		}
+		d.DeskripsiPekerjaan = optionalValue(v, "deskripsi_pekerjaan")
+
+		alumniRef := v["nim"]
+		switch {
+		case v["nim"] != "":
+			if id, ok := byNIM[v["nim"]]; ok {
+				d.AlumniID = id
+			} else {
+				row.fail("nim", "alumni dengan NIM %s tidak ditemukan", v["nim"])
+			}
+		case v["alumni_id"] != "":
+			alumniRef = v["alumni_id"]
+			oid, err := primitive.ObjectIDFromHex(v["alumni_id"])
+			if err != nil || !byID[oid] {
+				row.fail("alumni_id", "alumni %s tidak ditemukan", v["alumni_id"])
+			} else {
+				d.AlumniID = oid
+			}
+		default:
+			row.fail("nim", "nim atau alumni_id wajib diisi")
+		}
+
+		for _, col := range pekerjaanImportColumns {
+			if v[col] == "" {
+				row.fail(col, "%s wajib diisi", col)
+			}
+		}
+		if d.StatusPekerjaan != "" && d.StatusPekerjaan != "aktif" && d.StatusPekerjaan != "selesai" && d.StatusPekerjaan != "resigned" {
+			row.fail("status_pekerjaan", "status pekerjaan harus aktif, selesai, atau resigned")
+		}
+		if raw := v["tanggal_mulai_kerja"]; raw != "" {
+			if t, err := helper.ParseSpreadsheetDate(raw); err != nil {
+				row.fail("tanggal_mulai_kerja", "%v", err)
+			} else {
+				d.TanggalMulaiKerja = t
+			}
+		}
+		if raw := v["tanggal_selesai_kerja"]; raw != "" {
+			if t, err := helper.ParseSpreadsheetDate(raw); err != nil {
+				row.fail("tanggal_selesai_kerja", "%v", err)
+			} else if !d.TanggalMulaiKerja.IsZero() && t.Before(d.TanggalMulaiKerja) {
+				row.fail("tanggal_selesai_kerja", "tanggal selesai kerja sebelum tanggal mulai kerja")
+			} else {
+				d.TanggalSelesaiKerja = &t
+			}
+		}
+
+		row.Key = strings.Join([]string{alumniRef, d.NamaPerusahaan, d.PosisiJabatan, v["tanggal_mulai_kerja"]}, "|")
+		if len(row.Errors) == 0 {
+			key := pekerjaanImportKey(d.AlumniID, d.NamaPerusahaan, d.PosisiJabatan, d.TanggalMulaiKerja)
+			if first, ok := seen[key]; ok {
+				row.fail("", "pekerjaan duplikat dengan baris %d", first)
+			} else {
+				seen[key] = sr.Number
+				if id, ok := existing[key]; ok {
+					row.Record.ID = &id
+				}
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	if err := checkImportCareerRules(db, rows, saved); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// importCareerPeriod -> periode karier satu baris import. Baris update memakai ID pekerjaan lama,
+// mempertahankan tanggal selesai lama bila kolomnya kosong (seperti UpsertPekerjaanCtx), dan status
+// paruh waktu lama karena file tidak memuatnya.
+func importCareerPeriod(row int, r mongo.PekerjaanImportRecord, saved *mongo.PekerjaanAlumni) helper.CareerPeriod {
+	d := r.Data
+	p := mongo.PekerjaanAlumni{
+		NamaPerusahaan:      d.NamaPerusahaan,
+		PosisiJabatan:       d.PosisiJabatan,
+		TanggalMulaiKerja:   d.TanggalMulaiKerja,
+		TanggalSelesaiKerja: d.TanggalSelesaiKerja,
+		StatusPekerjaan:     d.StatusPekerjaan,
+	}
+	if saved != nil {
+		p.ID, p.ParuhWaktu = saved.ID, saved.ParuhWaktu
+		if p.TanggalSelesaiKerja == nil {
+			p.TanggalSelesaiKerja = saved.TanggalSelesaiKerja
+		}
+	}
+	period := careerPeriod(p)
+	if saved == nil {
+		period.ID = fmt.Sprintf("baris:%d", row)
+	}
+	period.Label += fmt.Sprintf(" (baris %d)", row)
+	return period
+}
+
+// checkImportCareerRules menjalankan checkCareerRules untuk setiap baris valid dengan baris valid lain
+// milik alumni yang sama di file ini sebagai pending, sehingga pelanggaran sudah terlihat di dry-run
+func checkImportCareerRules(db *mongoDB.Database, rows []importRow[mongo.PekerjaanImportRecord], saved map[primitive.ObjectID]mongo.PekerjaanAlumni) error {
+	periods := make([]helper.CareerPeriod, len(rows))
+	byAlumni := map[primitive.ObjectID][]int{}
+	for i, row := range rows {
+		if len(row.Errors) > 0 {
+			continue
+		}
+		var old *mongo.PekerjaanAlumni
+		if row.Record.ID != nil {
+			p := saved[*row.Record.ID]
+			old = &p
+		}
+		periods[i] = importCareerPeriod(row.Row, row.Record, old)
+		byAlumni[row.Record.Data.AlumniID] = append(byAlumni[row.Record.Data.AlumniID], i)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+	for alumniID, indexes := range byAlumni {
+		for _, i := range indexes {
+			pending := make([]helper.CareerPeriod, 0, len(indexes)-1)
+			for _, j := range indexes {
+				if j != i {
+					pending = append(pending, periods[j])
+				}
+			}
+			if ferr := checkCareerRules(ctx, db, alumniID, periods[i], pending...); ferr != nil {
+				if ferr.Code == fiber.StatusInternalServerError {
+					return ferr
+				}
+				rows[i].fail("", "%s", ferr.Message)
+			}
+		}
+	}
+	return nil
+}
+
+// upsertPekerjaanImport menyimpan satu batch import pekerjaan dalam satu transaksi. Setiap baris
+// diperiksa ulang dengan checkCareerRules setelah dokumen alumninya dikunci, terhadap data terbaru
+// termasuk baris sebelumnya dari file; baris yang ditolak dicatat sebagai error baris dan tidak disimpan.
+func upsertPekerjaanImport(db *mongoDB.Database, batch []importRow[mongo.PekerjaanImportRecord]) (created, updated int, rowErrors []mongo.ImportRowError, err error) {
+	now := time.Now()
+	err = repository.RunInTransaction(db, func(ctx context.Context) error {
+		// fn bisa diulang oleh driver; hitungan dimulai dari nol setiap percobaan
+		created, updated, rowErrors = 0, 0, nil
+		for _, row := range batch {
+			r := row.Record
+			ferr := lockAlumni(ctx, db, r.Data.AlumniID)
+			var saved *mongo.PekerjaanAlumni
+			if ferr == nil && r.ID != nil {
+				var err error
+				if saved, err = repository.GetPekerjaanByIDCtx(ctx, db, r.ID.Hex()); err != nil {
+					return err
+				}
+				if saved == nil {
+					ferr = fiber.NewError(fiber.StatusNotFound, "Pekerjaan tidak ditemukan")
+				}
+			}
+			if ferr == nil {
+				ferr = checkCareerRules(ctx, db, r.Data.AlumniID, importCareerPeriod(row.Row, r, saved))
+			}
+			if ferr != nil {
+				if ferr.Code == fiber.StatusInternalServerError {
+					return ferr
+				}
+				rowErrors = append(rowErrors, mongo.ImportRowError{Row: row.Row, Message: ferr.Message})
+				continue
+			}
+
+			isNew, err := repository.UpsertPekerjaanCtx(ctx, db, r, now)
+			if err != nil {
+				return err
+			}
+			if isNew {
+				created++
+			} else {
+				updated++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	return created, updated, rowErrors, nil
+}
+
+// ImportPekerjaanService -> POST /pekerjaan/import (multipart file .csv/.xlsx, ?dry_run=true untuk laporan saja)
+func ImportPekerjaanService(c *fiber.Ctx, db *mongoDB.Database) error {
+	sheet, err := readImportSheet(c, pekerjaanImportColumns)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": err.Error()})
+	}
+
+	rows, err := preparePekerjaanImport(db, sheet)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Gagal memvalidasi data import: " + err.Error()})
+	}
+
+	if c.QueryBool("dry_run") {
+		return c.JSON(mongo.ImportReportResponse{
+			Success: true,
+			Message: "Dry-run import pekerjaan selesai, tidak ada data yang disimpan",
+			Data: importReport("pekerjaan", rows, func(r mongo.PekerjaanImportRecord) bool {
+				return r.ID != nil
+			}),
+		})
+	}
+
+	job, err := enqueueImport(db, JobTypePekerjaanImport, "pekerjaan", rows)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Gagal membuat job import: " + err.Error()})
+	}
+
+	recordImportAudit(c, db, helper.AuditEntityPekerjaan, job)
+
+	return c.Status(fiber.StatusAccepted).JSON(mongo.ImportJobResponse{
+		Success: true,
+		Message: "Import pekerjaan dijalankan job runner, cek progress di /imports/" + job.ID,
+		Data:    job,
+	})
+}
+
+// GetImportJobService -> GET /imports/:id untuk polling progress import dari collection jobs
+func GetImportJobService(c *fiber.Ctx, db *mongoDB.Database) error {
+	notFound := func() error {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"success": false, "message": "Job import tidak ditemukan"})
+	}
+	id, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return notFound()
+	}
+	job, err := repository.FindJobByID(db, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Gagal mengambil job import: " + err.Error()})
+	}
+	if job == nil || (job.Type != JobTypeAlumniImport && job.Type != JobTypePekerjaanImport) {
+		return notFound()
+	}
+
+	view, err := importJobView(job)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Progress job import tidak valid: " + err.Error()})
+	}
+	return c.JSON(mongo.ImportJobResponse{
+		Success: true,
+		Message: "Status job import " + view.Status,
+		Data:    view,
+	})
+}
@@ -0,0 +1,547 @@
+package mongo
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	model "go-fiber/app/model/mongo"
+	repository "go-fiber/app/repository/mongo"
+	"go-fiber/helper"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongoDB "go.mongodb.org/mongo-driver/mongo"
+)
+
+// Konfigurasi job runner
+const (
+	jobPollInterval       = 2 * time.Second
+	jobLockTimeout        = 5 * time.Minute
+	jobHeartbeatInterval  = jobLockTimeout / 3
+	jobScheduleInterval   = 15 * time.Second
+	jobDefaultMaxAttempts = 5
+	jobRetryBase          = 30 * time.Second
+	jobRetryMax           = time.Hour
+	// Sisa waktu bagi handler untuk berhenti setelah context-nya dibatalkan saat shutdown
+	jobShutdownGrace = 5 * time.Second
+	// Job completed/cancelled yang lebih lama dari ini dihapus oleh job jobs.prune
+	jobRetention = 7 * 24 * time.Hour
+)
+
+// Job type bawaan
+const (
+	JobTypeFileRetentionPurge = "file.retention_purge"
+	JobTypeJobsPrune          = "jobs.prune"
+)
+
+// JobOptions -> opsi enqueue; nilai kosong memakai default (5 percobaan, langsung dijalankan)
+type JobOptions struct {
+	MaxAttempts int
+	RunAt       time.Time
+	// UniqueKey mencegah job ganda: enqueue dengan key yang sudah ada diabaikan
+	UniqueKey string
+	Schedule  string
+	// Result -> isi awal field result, mis. progress yang sudah bisa dipolling sebelum job diambil worker
+	Result interface{}
+}
+
+// ErrDuplicateJob dikembalikan EnqueueJob bila UniqueKey sudah dipakai
+var ErrDuplicateJob = errors.New("job dengan unique_key yang sama sudah ada")
+
+// permanentJobError -> error yang tidak perlu dicoba ulang
+type permanentJobError struct{ err error }
+
+func (e permanentJobError) Error() string { return e.err.Error() }
+func (e permanentJobError) Unwrap() error { return e.err }
+
+// PermanentJobError menandai error handler sebagai permanen sehingga job langsung di-dead-letter
+func PermanentJobError(err error) error {
+	return permanentJobError{err: err}
+}
+
+// EnqueueJob -> masukkan job ke antrean; payload di-encode sebagai JSON
+func EnqueueJob(db *mongoDB.Database, jobType string, payload interface{}, opts JobOptions) (*model.Job, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	job := &model.Job{
+		Type:        jobType,
+		Payload:     raw,
+		Status:      model.JobStatusQueued,
+		MaxAttempts: opts.MaxAttempts,
+		RunAt:       opts.RunAt,
+		Schedule:    opts.Schedule,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if job.MaxAttempts <= 0 {
+		job.MaxAttempts = jobDefaultMaxAttempts
+	}
+	if job.RunAt.IsZero() {
+		job.RunAt = now
+	}
+	if opts.UniqueKey != "" {
+		job.UniqueKey = &opts.UniqueKey
+	}
+	if opts.Result != nil {
+		if job.Result, err = json.Marshal(opts.Result); err != nil {
+			return nil, err
+		}
+	}
+
+	inserted, err := repository.InsertJob(db, job)
+	if err != nil {
+		return nil, err
+	}
+	if !inserted {
+		return nil, ErrDuplicateJob
+	}
+	return job, nil
+}
+
+type jobHandler func(ctx context.Context, payload json.RawMessage) error
+
+type runningJobKey struct{}
+
+// runningJob -> job yang sedang dijalankan handler (dipasang JobRunner.run pada context)
+func runningJob(ctx context.Context) *model.Job {
+	job, _ := ctx.Value(runningJobKey{}).(*model.Job)
+	return job
+}
+
+type jobSchedule struct {
+	name    string
+	jobType string
+	cron    *helper.CronSchedule
+	payload interface{}
+	next    time.Time
+}
+
+// JobRunner -> worker pool yang mengambil job dari collection jobs. Aman dijalankan di
+// beberapa instance sekaligus: claim bersifat atomik dan jadwal cron di-dedupe lewat unique_key.
+type JobRunner struct {
+	db       *mongoDB.Database
+	workers  int
+	workerID string
+
+	handlers  map[string]jobHandler
+	schedules []*jobSchedule
+
+	stop    chan struct{}
+	ctx     context.Context // dibatalkan saat shutdown melewati batas waktu
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	started bool
+}
+
+// NewJobRunner membuat runner dengan job bawaan (purge retensi file setiap jam, prune job
+// selesai setiap hari, notifikasi email, import, dan export).
+// workers <= 0 berarti instance ini tidak memproses job (enqueue tetap bisa).
+func NewJobRunner(db *mongoDB.Database, workers int) *JobRunner {
+	hostname, _ := os.Hostname()
+	b := make([]byte, 4)
+	rand.Read(b)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &JobRunner{
+		db:       db,
+		workers:  workers,
+		workerID: fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), hex.EncodeToString(b)),
+		handlers: map[string]jobHandler{},
+		stop:     make(chan struct{}),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+
+	RegisterJobHandler(r, JobTypeFileRetentionPurge, func(ctx context.Context, _ struct{}) error {
+		n, err := PurgeExpiredFiles(db)
+		if n > 0 {
+			log.Printf("File retention purge removed %d file(s)", n)
+		}
+		return err
+	})
+	if err := r.Schedule("file-retention-purge", "@hourly", JobTypeFileRetentionPurge, struct{}{}); err != nil {
+		log.Fatalf("Invalid job schedule: %v", err)
+	}
+	RegisterJobHandler(r, JobTypeJobsPrune, func(ctx context.Context, _ struct{}) error {
+		n, err := repository.DeleteFinishedJobs(db, time.Now().Add(-jobRetention))
+		if n > 0 {
+			log.Printf("Job prune removed %d finished job(s)", n)
+		}
+		return err
+	})
+	if err := r.Schedule("jobs-prune", "30 3 * * *", JobTypeJobsPrune, struct{}{}); err != nil {
+		log.Fatalf("Invalid job schedule: %v", err)
+	}
+
+	registerNotificationJobs(r)
+	registerReferenceJobs(r)
+	registerSalaryJobs(r)
+	registerLocationJobs(r)
+	registerImportJobs(r)
+	registerExportJobs(r)
+	return r
+}
+
+// JobWorkersFromEnv -> jumlah worker dari JOB_WORKERS (default 2)
+func JobWorkersFromEnv() int {
+	if n, err := strconv.Atoi(os.Getenv("JOB_WORKERS")); err == nil {
+		return n
+	}
+	return 2
+}
+
+// RegisterJobHandler mendaftarkan handler bertipe: payload JSON di-decode ke T sebelum fn
+// dipanggil. Payload yang tidak bisa di-decode langsung di-dead-letter.
+func RegisterJobHandler[T any](r *JobRunner, jobType string, fn func(ctx context.Context, payload T) error) {
+	r.handlers[jobType] = func(ctx context.Context, raw json.RawMessage) error {
+		var payload T
+		if len(raw) > 0 {
+			if err := json.Unmarshal(raw, &payload); err != nil {
+				return PermanentJobError(fmt.Errorf("payload tidak valid: %v", err))
+			}
+		}
+		return fn(ctx, payload)
+	}
+}
+
+// Schedule -> enqueue jobType setiap kali ekspresi cron terpenuhi
+func (r *JobRunner) Schedule(name, spec, jobType string, payload interface{}) error {
+	cron, err := helper.ParseCron(spec)
+	if err != nil {
+		return err
+	}
+	r.schedules = append(r.schedules, &jobSchedule{name: name, jobType: jobType, cron: cron, payload: payload})
+	return nil
+}
+
+// Start menjalankan worker dan scheduler di background
+func (r *JobRunner) Start() {
+	if r.workers <= 0 {
+		log.Println("Job runner disabled (JOB_WORKERS=0)")
+		return
+	}
+	r.started = true
+
+	for i := 0; i < r.workers; i++ {
+		r.wg.Add(1)
+		go r.work()
+	}
+	r.wg.Add(1)
+	go r.schedule()
+	log.Printf("Job runner started with %d worker(s) as %s", r.workers, r.workerID)
+}
+
+// Shutdown berhenti mengambil job baru lalu menunggu job yang berjalan sampai ctx habis.
+// Setelah itu context job dibatalkan dan job yang terputus dikembalikan ke antrean.
+func (r *JobRunner) Shutdown(ctx context.Context) error {
+	if !r.started {
+		return nil
+	}
+	close(r.stop)
+
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		r.cancel()
+		return nil
+	case <-ctx.Done():
+		r.cancel()
+	}
+	select {
+	case <-done:
+	case <-time.After(jobShutdownGrace):
+	}
+	return ctx.Err()
+}
+
+func (r *JobRunner) work() {
+	defer r.wg.Done()
+	for {
+		select {
+		case <-r.stop:
+			return
+		default:
+		}
+
+		job, err := repository.ClaimJob(r.db, r.workerID, jobLockTimeout)
+		if err != nil {
+			log.Printf("Job claim failed: %v", err)
+		}
+		if job == nil {
+			select {
+			case <-r.stop:
+				return
+			case <-time.After(jobPollInterval):
+			}
+			continue
+		}
+		r.run(job)
+	}
+}
+
+// run -> jalankan handler dengan heartbeat; lock yang hilang (job dibatalkan admin)
+// membatalkan context handler
+func (r *JobRunner) run(job *model.Job) {
+	handler, ok := r.handlers[job.Type]
+	if !ok {
+		r.finish(job, PermanentJobError(fmt.Errorf("handler untuk type %q tidak terdaftar", job.Type)), false)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.WithValue(r.ctx, runningJobKey{}, job))
+	defer cancel()
+
+	done := make(chan struct{})
+	heartbeat := make(chan bool, 1) // true bila lock hilang
+	go func() {
+		ticker := time.NewTicker(jobHeartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				heartbeat <- false
+				return
+			case <-ticker.C:
+				owned, err := repository.ExtendJobLock(r.db, job.ID, r.workerID, jobLockTimeout)
+				if err != nil {
+					log.Printf("Job %s heartbeat failed: %v", job.ID.Hex(), err)
+					continue
+				}
+				if !owned {
+					cancel()
+					heartbeat <- true
+					return
+				}
+			}
+		}
+	}()
+
+	err := runJobHandler(ctx, handler, job.Payload)
+	close(done)
+	lost := <-heartbeat
+	r.finish(job, err, lost)
+}
+
+// saveJobResult -> simpan progress / hasil handler ke field result job yang sedang berjalan
+func (r *JobRunner) saveJobResult(ctx context.Context, result interface{}) error {
+	job := runningJob(ctx)
+	if job == nil {
+		return fmt.Errorf("saveJobResult dipanggil di luar job")
+	}
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	job.Result = raw
+	return repository.SaveJobResult(r.db, job.ID, r.workerID, raw)
+}
+
+// runJobHandler -> panic di handler diperlakukan sebagai error biasa
+func runJobHandler(ctx context.Context, handler jobHandler, payload json.RawMessage) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+	return handler(ctx, payload)
+}
+
+func (r *JobRunner) finish(job *model.Job, err error, lost bool) {
+	var permanent permanentJobError
+	var updateErr error
+	switch {
+	case lost:
+		log.Printf("Job %s (%s) stopped: no longer owned by this worker", job.ID.Hex(), job.Type)
+		return
+	case err == nil:
+		updateErr = repository.CompleteJob(r.db, job.ID, r.workerID)
+	case r.ctx.Err() != nil:
+		updateErr = repository.ReleaseJob(r.db, job.ID, r.workerID)
+	case errors.As(err, &permanent) || job.Attempts >= job.MaxAttempts:
+		log.Printf("Job %s (%s) dead after %d attempt(s): %v", job.ID.Hex(), job.Type, job.Attempts, err)
+		updateErr = repository.DeadLetterJob(r.db, job.ID, r.workerID, err.Error())
+	default:
+		delay := helper.Backoff(job.Attempts, jobRetryBase, jobRetryMax)
+		log.Printf("Job %s (%s) attempt %d failed, retry in %s: %v", job.ID.Hex(), job.Type, job.Attempts, delay, err)
+		updateErr = repository.RetryJobLater(r.db, job.ID, r.workerID, time.Now().Add(delay), err.Error())
+	}
+	if updateErr != nil {
+		log.Printf("Job %s status update failed: %v", job.ID.Hex(), updateErr)
+	}
+}
+
+// schedule -> enqueue job terjadwal; unique_key "schedule:<nama>:<unix>" mencegah
+// instance lain meng-enqueue slot yang sama
+func (r *JobRunner) schedule() {
+	defer r.wg.Done()
+	now := time.Now()
+	for _, s := range r.schedules {
+		s.next = s.cron.Next(now)
+	}
+
+	ticker := time.NewTicker(jobScheduleInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stop:
+			return
+		case now := <-ticker.C:
+			for _, s := range r.schedules {
+				if s.next.IsZero() || now.Before(s.next) {
+					continue
+				}
+				key := fmt.Sprintf("schedule:%s:%d", s.name, s.next.Unix())
+				_, err := EnqueueJob(r.db, s.jobType, s.payload, JobOptions{UniqueKey: key, Schedule: s.name})
+				if err != nil && err != ErrDuplicateJob {
+					log.Printf("Scheduled job %s enqueue failed: %v", s.name, err)
+					continue
+				}
+				s.next = s.cron.Next(now)
+			}
+		}
+	}
+}
+
+func jobError(c *fiber.Ctx, status int, message string) error {
+	return c.Status(status).JSON(fiber.Map{
+		"success": false,
+		"message": message,
+	})
+}
+
+// findJobFromParam -> job berdasarkan :id; response error sudah ditulis bila nil
+func findJobFromParam(c *fiber.Ctx, db *mongoDB.Database) (*model.Job, error) {
+	id, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return nil, jobError(c, fiber.StatusBadRequest, "ID job tidak valid")
+	}
+	job, err := repository.FindJobByID(db, id)
+	if err != nil {
+		return nil, jobError(c, fiber.StatusInternalServerError, "Gagal mengambil job: "+err.Error())
+	}
+	if job == nil {
+		return nil, jobError(c, fiber.StatusNotFound, "Job tidak ditemukan")
+	}
+	return job, nil
+}
+
+// ListJobsService -> daftar job terbaru, filter ?status= dan ?type=
+func ListJobsService(c *fiber.Ctx, db *mongoDB.Database) error {
+	status := c.Query("status")
+	switch status {
+	case "", model.JobStatusQueued, model.JobStatusRunning, model.JobStatusCompleted, model.JobStatusDead, model.JobStatusCancelled:
+	default:
+		return jobError(c, fiber.StatusBadRequest, "status harus salah satu dari queued, running, completed, dead, cancelled")
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+
+	jobs, total, err := repository.FindJobs(db, status, c.Query("type"), page, limit)
+	if err != nil {
+		return jobError(c, fiber.StatusInternalServerError, "Gagal mengambil daftar job: "+err.Error())
+	}
+	if jobs == nil {
+		jobs = []model.Job{}
+	}
+
+	return c.JSON(model.JobListResponse{
+		Success: true,
+		Message: "Berhasil mengambil daftar job",
+		Data: model.JobListData{
+			Items: jobs,
+			Meta: model.MetaInfo{
+				Page:  page,
+				Limit: limit,
+				Total: &total,
+				Pages: int(math.Ceil(float64(total) / float64(limit))),
+			},
+		},
+	})
+}
+
+// GetJobService -> detail job termasuk error terakhir
+func GetJobService(c *fiber.Ctx, db *mongoDB.Database) error {
+	job, err := findJobFromParam(c, db)
+	if job == nil {
+		return err
+	}
+	return c.JSON(model.JobResponse{
+		Success: true,
+		Message: "Berhasil mengambil job",
+		Data:    *job,
+	})
+}
+
+// RetryJobService -> jalankan ulang job dead atau cancelled dengan jatah percobaan baru
+func RetryJobService(c *fiber.Ctx, db *mongoDB.Database) error {
+	job, err := findJobFromParam(c, db)
+	if job == nil {
+		return err
+	}
+	if job.Status != model.JobStatusDead && job.Status != model.JobStatusCancelled {
+		return jobError(c, fiber.StatusConflict, "Hanya job dead atau cancelled yang bisa di-retry")
+	}
+
+	job, err = repository.RequeueJob(db, job.ID)
+	if err != nil {
+		return jobError(c, fiber.StatusInternalServerError, "Gagal me-retry job: "+err.Error())
+	}
+	if job == nil {
+		return jobError(c, fiber.StatusConflict, "Status job sudah berubah, coba lagi")
+	}
+	return c.JSON(model.JobResponse{
+		Success: true,
+		Message: "Job dimasukkan kembali ke antrean",
+		Data:    *job,
+	})
+}
+
+// CancelJobService -> batalkan job queued atau running
+func CancelJobService(c *fiber.Ctx, db *mongoDB.Database) error {
+	job, err := findJobFromParam(c, db)
+	if job == nil {
+		return err
+	}
+	if job.Status != model.JobStatusQueued && job.Status != model.JobStatusRunning {
+		return jobError(c, fiber.StatusConflict, "Hanya job queued atau running yang bisa dibatalkan")
+	}
+
+	job, err = repository.CancelJob(db, job.ID)
+	if err != nil {
+		return jobError(c, fiber.StatusInternalServerError, "Gagal membatalkan job: "+err.Error())
+	}
+	if job == nil {
+		return jobError(c, fiber.StatusConflict, "Status job sudah berubah, coba lagi")
+	}
+	return c.JSON(model.JobResponse{
+		Success: true,
+		Message: "Job dibatalkan",
+		Data:    *job,
+	})
+}
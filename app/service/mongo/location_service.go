@@ -0,0 +1,112 @@
+package mongo
+
+import (
+	"context"
+	"go-fiber/app/model/mongo"
+	repository "go-fiber/app/repository/mongo"
+	"go-fiber/helper"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+
+	mongoDB "go.mongodb.org/mongo-driver/mongo"
+)
+
+// JobTypeLocationBackfill -> isi lokasi terstruktur pekerjaan lama dari teks lokasi_kerja
+const JobTypeLocationBackfill = "pekerjaan.location_backfill"
+
+// locationText -> nilai field lokasi PATCH; nil sama dengan kosong
+func locationText(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// ListRegionsService -> GET /wilayah: provinsi pada dataset wilayah, tanpa daftar kotanya
+func ListRegionsService(c *fiber.Ctx) error {
+	provinces, err := helper.Provinces()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(mongo.ListRegionResponse{
+			Success: false,
+			Message: "Gagal memuat dataset wilayah: " + err.Error(),
+			Data:    []helper.Province{},
+		})
+	}
+	data := make([]helper.Province, len(provinces))
+	for i, p := range provinces {
+		p.Kota = nil
+		data[i] = p
+	}
+	return c.JSON(mongo.ListRegionResponse{
+		Success: true,
+		Message: "Berhasil mengambil data wilayah",
+		Data:    data,
+	})
+}
+
+// GetRegionService -> GET /wilayah/:kode: provinsi (kode, nama, atau alias) beserta kota / kabupatennya
+func GetRegionService(c *fiber.Ctx) error {
+	province, ok := helper.FindProvince(c.Params("kode"))
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(mongo.RegionResponse{
+			Success: false,
+			Message: "Provinsi tidak ditemukan",
+		})
+	}
+	return c.JSON(mongo.RegionResponse{
+		Success: true,
+		Message: "Berhasil mengambil data wilayah",
+		Data:    province,
+	})
+}
+
+// BackfillLocationService -> POST /pekerjaan/lokasi/backfill: jalankan job pekerjaan.location_backfill di background
+func BackfillLocationService(c *fiber.Ctx, db *mongoDB.Database) error {
+	job, err := EnqueueJob(db, JobTypeLocationBackfill, struct{}{}, JobOptions{MaxAttempts: 1})
+	if err != nil {
+		return jobError(c, fiber.StatusInternalServerError, "Gagal menjadwalkan backfill lokasi: "+err.Error())
+	}
+	return c.Status(fiber.StatusAccepted).JSON(mongo.JobResponse{
+		Success: true,
+		Message: "Backfill lokasi berjalan di background, cek progress di /jobs/" + job.ID.Hex(),
+		Data:    *job,
+	})
+}
+
+func registerLocationJobs(r *JobRunner) {
+	RegisterJobHandler(r, JobTypeLocationBackfill, func(ctx context.Context, _ struct{}) error {
+		located, skipped, err := backfillLocations(ctx, r.db)
+		if located > 0 || skipped > 0 {
+			log.Printf("Location backfill: %d pekerjaan diberi lokasi, %d lokasi_kerja tidak dikenali", located, skipped)
+		}
+		return err
+	})
+}
+
+// backfillLocations -> tebak lokasi terstruktur setiap pekerjaan yang belum punya dari teks
+// lokasi_kerja. Teks yang tidak dikenali dibiarkan agar bisa dilengkapi manual lewat PUT / PATCH.
+func backfillLocations(ctx context.Context, db *mongoDB.Database) (located, skipped int, err error) {
+	unlocated, err := repository.ListUnlocatedPekerjaan(ctx, db)
+	if err != nil {
+		return 0, 0, err
+	}
+	for id, text := range unlocated {
+		if err := ctx.Err(); err != nil {
+			return located, skipped, err
+		}
+		loc, ok := helper.GuessLocation(text)
+		if !ok {
+			skipped++
+			continue
+		}
+		ok, err := repository.BackfillPekerjaanLocation(ctx, db, id, text, loc)
+		if err != nil {
+			return located, skipped, err
+		}
+		if ok {
+			located++
+		}
+	}
+	return located, skipped, nil
+}
@@ -0,0 +1,390 @@
+package mongo
+
+import (
+	"errors"
+	"go-fiber/app/model/mongo"
+	repository "go-fiber/app/repository/mongo"
+	"go-fiber/helper"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongoDB "go.mongodb.org/mongo-driver/mongo"
+)
+
+// accountDeletionReasonMaxLength -> batas panjang alasan / catatan permintaan hapus akun
+const accountDeletionReasonMaxLength = 1000
+
+func meError(c *fiber.Ctx, status int, message string) error {
+	return c.Status(status).JSON(fiber.Map{"success": false, "message": message})
+}
+
+// meID -> ID alumni yang sedang login dari token
+func meID(c *fiber.Ctx) (primitive.ObjectID, *fiber.Error) {
+	userID, _ := c.Locals("user_id").(string)
+	id, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return primitive.NilObjectID, fiber.NewError(fiber.StatusUnauthorized, "Token tidak valid")
+	}
+	return id, nil
+}
+
+// GetMeService -> GET /me: data alumni yang sedang login, dibaca dari database (bukan hanya isi token
+// seperti /profile), beserta permintaan hapus akun yang masih pending
+func GetMeService(c *fiber.Ctx, db *mongoDB.Database) error {
+	userID, ferr := meID(c)
+	if ferr != nil {
+		return meError(c, ferr.Code, ferr.Message)
+	}
+	alumni, err := repository.GetAlumniByID(db, userID.Hex())
+	if err != nil {
+		return meError(c, fiber.StatusInternalServerError, "Gagal mengambil data alumni: "+err.Error())
+	}
+	if alumni == nil {
+		return meError(c, fiber.StatusNotFound, "Alumni tidak ditemukan")
+	}
+	pending, err := repository.GetPendingAccountDeletion(db, userID)
+	if err != nil {
+		return meError(c, fiber.StatusInternalServerError, "Gagal mengambil permintaan hapus akun: "+err.Error())
+	}
+
+	c.Set(fiber.HeaderETag, helper.ETag(alumni.Version))
+	return c.Status(fiber.StatusOK).JSON(mongo.MeProfileResponse{
+		Success: true,
+		Message: "Berhasil mengambil profil",
+		Data:    mongo.MeProfile{Alumni: *alumni, DeletionRequest: pending},
+	})
+}
+
+// PatchMeService -> PATCH /me: alumni mengubah profilnya sendiri dengan JSON Merge Patch atau JSON
+// Patch. Hanya field di MePatchDocument (no_telepon, alamat) yang boleh diubah; field lain ditolak 400.
+func PatchMeService(c *fiber.Ctx, db *mongoDB.Database) error {
+	format, ferr := patchFormat(c)
+	if ferr != nil {
+		return meError(c, ferr.Code, ferr.Message)
+	}
+	expectedVersion, ferr := ifMatchVersion(c)
+	if ferr != nil {
+		return meError(c, ferr.Code, ferr.Message)
+	}
+	userID, ferr := meID(c)
+	if ferr != nil {
+		return meError(c, ferr.Code, ferr.Message)
+	}
+
+	existing, err := repository.GetAlumniByID(db, userID.Hex())
+	if err != nil {
+		return meError(c, fiber.StatusInternalServerError, "Gagal mengambil data alumni: "+err.Error())
+	}
+	if existing == nil {
+		return meError(c, fiber.StatusNotFound, "Alumni tidak ditemukan")
+	}
+	if versionMismatch(c, expectedVersion, existing.Version) {
+		return meError(c, fiber.StatusPreconditionFailed, versionConflictMessage)
+	}
+
+	var doc mongo.MePatchDocument
+	current := mongo.MePatchDocument{NoTelepon: existing.NoTelepon, Alamat: existing.Alamat}
+	if ferr := applyPatch(c, format, current, &doc); ferr != nil {
+		return meError(c, ferr.Code, ferr.Message)
+	}
+
+	// String kosong sama dengan null: field dihapus
+	repoReq := &mongo.UpdateAlumniRepositoryRequest{}
+	if doc.NoTelepon != nil && strings.TrimSpace(*doc.NoTelepon) != "" {
+		noTelepon := strings.TrimSpace(*doc.NoTelepon)
+		repoReq.NoTelepon = &noTelepon
+	} else {
+		repoReq.Unset = append(repoReq.Unset, "no_telepon")
+	}
+	if doc.Alamat != nil && strings.TrimSpace(*doc.Alamat) != "" {
+		alamat := strings.TrimSpace(*doc.Alamat)
+		repoReq.Alamat = &alamat
+	} else {
+		repoReq.Unset = append(repoReq.Unset, "alamat")
+	}
+
+	alumni, err := repository.UpdateAlumni(db, userID.Hex(), repoReq, expectedVersion)
+	if errors.Is(err, helper.ErrVersionConflict) {
+		return meError(c, fiber.StatusPreconditionFailed, versionConflictMessage)
+	}
+	if err != nil {
+		return meError(c, fiber.StatusInternalServerError, "Gagal mengupdate profil: "+err.Error())
+	}
+	recordAudit(c, db, auditEntry(helper.AuditActionUpdate, helper.AuditEntityAlumni, userID.Hex(), existing, alumni))
+
+	c.Set(fiber.HeaderETag, helper.ETag(alumni.Version))
+	return c.Status(fiber.StatusOK).JSON(mongo.UpdateAlumniResponse{
+		Success: true,
+		Message: "Berhasil mengupdate profil",
+		Data:    *alumni,
+	})
+}
+
+// GetMyPekerjaanService -> GET /me/pekerjaan: pekerjaan milik alumni yang sedang login
+func GetMyPekerjaanService(c *fiber.Ctx, db *mongoDB.Database) error {
+	userID, ferr := meID(c)
+	if ferr != nil {
+		return meError(c, ferr.Code, ferr.Message)
+	}
+	pekerjaan, err := repository.GetPekerjaanByAlumniID(db, userID.Hex())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(mongo.GetPekerjaanAlumniByAlumniIDResponse{
+			Success: false,
+			Message: "Gagal mengambil data pekerjaan: " + err.Error(),
+			Data:    []mongo.PekerjaanAlumni{},
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(mongo.GetPekerjaanAlumniByAlumniIDResponse{
+		Success: true,
+		Message: "Berhasil mengambil data pekerjaan",
+		Data:    pekerjaan,
+	})
+}
+
+// CreateMyPekerjaanService -> POST /me/pekerjaan: tambah pekerjaan untuk diri sendiri; alumni_id
+// selalu diambil dari token, nilai di body diabaikan
+func CreateMyPekerjaanService(c *fiber.Ctx, db *mongoDB.Database) error {
+	userID, ferr := meID(c)
+	if ferr != nil {
+		return meError(c, ferr.Code, ferr.Message)
+	}
+	var req mongo.CreatePekerjaanAlumniRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(mongo.CreatePekerjaanAlumniResponse{
+			Success: false,
+			Message: "Format data tidak valid: " + err.Error(),
+			Data:    mongo.PekerjaanAlumni{},
+		})
+	}
+	req.AlumniID = userID.Hex()
+	return createPekerjaan(c, db, &req)
+}
+
+// PatchMyPekerjaanService -> PATCH /me/pekerjaan/:id: ubah pekerjaan milik sendiri; pekerjaan alumni
+// lain dijawab 404
+func PatchMyPekerjaanService(c *fiber.Ctx, db *mongoDB.Database) error {
+	idStr := c.Params("id")
+	if _, err := primitive.ObjectIDFromHex(idStr); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(mongo.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: "Format ID tidak valid",
+			Data:    mongo.PekerjaanAlumni{},
+		})
+	}
+	userID, ferr := meID(c)
+	if ferr != nil {
+		return meError(c, ferr.Code, ferr.Message)
+	}
+	return patchPekerjaan(c, db, idStr, &userID)
+}
+
+// ListMyFilesService -> GET /me/files: file milik alumni yang sedang login, query sama dengan
+// GET /users/:id/files
+func ListMyFilesService(c *fiber.Ctx, db *mongoDB.Database) error {
+	userID, ferr := meID(c)
+	if ferr != nil {
+		return meError(c, ferr.Code, ferr.Message)
+	}
+	return listFiles(c, db, userID)
+}
+
+// accountDeletionText -> alasan / catatan opsional; string kosong menjadi nil
+func accountDeletionText(s *string, field string) (*string, *fiber.Error) {
+	if s == nil || strings.TrimSpace(*s) == "" {
+		return nil, nil
+	}
+	text := strings.TrimSpace(*s)
+	if len([]rune(text)) > accountDeletionReasonMaxLength {
+		return nil, fiber.NewError(fiber.StatusBadRequest, field+" maksimal "+strconv.Itoa(accountDeletionReasonMaxLength)+" karakter")
+	}
+	return &text, nil
+}
+
+// RequestAccountDeletionService -> DELETE /me: ajukan penghapusan akun. Akun belum dihapus sampai
+// admin menyetujui permintaannya; satu alumni hanya punya satu permintaan pending.
+func RequestAccountDeletionService(c *fiber.Ctx, db *mongoDB.Database) error {
+	var req mongo.CreateAccountDeletionRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return meError(c, fiber.StatusBadRequest, "Request body tidak valid")
+		}
+	}
+	alasan, ferr := accountDeletionText(req.Alasan, "Alasan")
+	if ferr != nil {
+		return meError(c, ferr.Code, ferr.Message)
+	}
+	userID, ferr := meID(c)
+	if ferr != nil {
+		return meError(c, ferr.Code, ferr.Message)
+	}
+
+	alumni, err := repository.GetAlumniByID(db, userID.Hex())
+	if err != nil {
+		return meError(c, fiber.StatusInternalServerError, "Gagal mengambil data alumni: "+err.Error())
+	}
+	if alumni == nil {
+		return meError(c, fiber.StatusNotFound, "Alumni tidak ditemukan")
+	}
+
+	deletion := &mongo.AccountDeletionRequest{AlumniID: alumni.ID, NIM: alumni.NIM, Nama: alumni.Nama, Alasan: alasan}
+	created, err := repository.CreateAccountDeletion(db, deletion)
+	if err != nil {
+		return meError(c, fiber.StatusInternalServerError, "Gagal menyimpan permintaan hapus akun: "+err.Error())
+	}
+	if !created {
+		pending, err := repository.GetPendingAccountDeletion(db, userID)
+		if err != nil || pending == nil {
+			return meError(c, fiber.StatusConflict, "Permintaan hapus akun sudah diajukan dan menunggu persetujuan admin")
+		}
+		return c.Status(fiber.StatusConflict).JSON(mongo.AccountDeletionResponse{
+			Success: false,
+			Message: "Permintaan hapus akun sudah diajukan dan menunggu persetujuan admin",
+			Data:    *pending,
+		})
+	}
+	recordAudit(c, db, auditEntry(helper.AuditActionCreate, helper.AuditEntityAccountDeletion, deletion.ID.Hex(), nil, deletion))
+
+	return c.Status(fiber.StatusAccepted).JSON(mongo.AccountDeletionResponse{
+		Success: true,
+		Message: "Permintaan hapus akun diterima dan menunggu persetujuan admin",
+		Data:    *deletion,
+	})
+}
+
+// ListAccountDeletionsService -> GET /account-deletions: daftar permintaan hapus akun (?status=)
+func ListAccountDeletionsService(c *fiber.Ctx, db *mongoDB.Database) error {
+	status := strings.TrimSpace(c.Query("status"))
+	switch status {
+	case "", mongo.AccountDeletionPending, mongo.AccountDeletionApproved, mongo.AccountDeletionRejected:
+	default:
+		return meError(c, fiber.StatusBadRequest, "Status harus pending, approved, atau rejected")
+	}
+	requests, err := repository.ListAccountDeletions(db, status)
+	if err != nil {
+		return meError(c, fiber.StatusInternalServerError, "Gagal mengambil permintaan hapus akun: "+err.Error())
+	}
+	return c.Status(fiber.StatusOK).JSON(mongo.ListAccountDeletionResponse{
+		Success: true,
+		Message: "Berhasil mengambil permintaan hapus akun",
+		Data:    requests,
+	})
+}
+
+// processAccountDeletionInput -> id permintaan, admin yang memproses, dan catatan dari request
+// approve / reject
+func processAccountDeletionInput(c *fiber.Ctx) (primitive.ObjectID, primitive.ObjectID, *string, *fiber.Error) {
+	id, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return primitive.NilObjectID, primitive.NilObjectID, nil, fiber.NewError(fiber.StatusBadRequest, "Format ID tidak valid")
+	}
+	var req mongo.ProcessAccountDeletionRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return primitive.NilObjectID, primitive.NilObjectID, nil, fiber.NewError(fiber.StatusBadRequest, "Request body tidak valid")
+		}
+	}
+	catatan, ferr := accountDeletionText(req.Catatan, "Catatan")
+	if ferr != nil {
+		return primitive.NilObjectID, primitive.NilObjectID, nil, ferr
+	}
+	adminID, ferr := meID(c)
+	if ferr != nil {
+		return primitive.NilObjectID, primitive.NilObjectID, nil, ferr
+	}
+	return id, adminID, catatan, nil
+}
+
+// pendingAccountDeletion -> permintaan yang masih pending; bila tidak, response 404 (tidak ada)
+// atau 409 (sudah diproses) sudah dikirim dan done bernilai true
+func pendingAccountDeletion(c *fiber.Ctx, db *mongoDB.Database, id primitive.ObjectID) (deletion *mongo.AccountDeletionRequest, done bool, err error) {
+	deletion, err = repository.GetAccountDeletionByID(db, id)
+	if err != nil {
+		return nil, true, meError(c, fiber.StatusInternalServerError, "Gagal mengambil permintaan hapus akun: "+err.Error())
+	}
+	if deletion == nil {
+		return nil, true, meError(c, fiber.StatusNotFound, "Permintaan hapus akun tidak ditemukan")
+	}
+	if deletion.Status != mongo.AccountDeletionPending {
+		return nil, true, c.Status(fiber.StatusConflict).JSON(mongo.AccountDeletionResponse{
+			Success: false,
+			Message: "Permintaan hapus akun sudah diproses",
+			Data:    *deletion,
+		})
+	}
+	return deletion, false, nil
+}
+
+// ApproveAccountDeletionService -> POST /account-deletions/:id/approve: setujui permintaan dan hapus
+// akun alumni (sama seperti DELETE /alumni/:id)
+func ApproveAccountDeletionService(c *fiber.Ctx, db *mongoDB.Database) error {
+	id, adminID, catatan, ferr := processAccountDeletionInput(c)
+	if ferr != nil {
+		return meError(c, ferr.Code, ferr.Message)
+	}
+	pending, done, err := pendingAccountDeletion(c, db, id)
+	if done {
+		return err
+	}
+
+	// Akun dihapus lebih dulu; approve yang bersamaan menghapus akun yang sama dan hanya satu yang
+	// berhasil menandai permintaan
+	alumni, err := repository.GetAlumniByID(db, pending.AlumniID.Hex())
+	if err != nil {
+		return meError(c, fiber.StatusInternalServerError, "Gagal mengambil data alumni: "+err.Error())
+	}
+	if alumni != nil {
+		if err := repository.DeleteAlumni(db, alumni.ID.Hex()); err != nil {
+			return meError(c, fiber.StatusInternalServerError, "Gagal menghapus alumni: "+err.Error())
+		}
+		entry := auditEntry(helper.AuditActionDelete, helper.AuditEntityAlumni, alumni.ID.Hex(), alumni, nil)
+		entry.Metadata = map[string]any{"account_deletion_id": id.Hex()}
+		recordAudit(c, db, entry)
+	}
+
+	deletion, err := repository.FinishAccountDeletion(db, id, mongo.AccountDeletionApproved, adminID, catatan)
+	if err != nil {
+		return meError(c, fiber.StatusInternalServerError, "Gagal memproses permintaan hapus akun: "+err.Error())
+	}
+	if deletion == nil {
+		_, _, err := pendingAccountDeletion(c, db, id)
+		return err
+	}
+	recordAudit(c, db, auditEntry(helper.AuditActionUpdate, helper.AuditEntityAccountDeletion, id.Hex(), nil, deletion))
+
+	return c.Status(fiber.StatusOK).JSON(mongo.AccountDeletionResponse{
+		Success: true,
+		Message: "Permintaan hapus akun disetujui, akun sudah dihapus",
+		Data:    *deletion,
+	})
+}
+
+// RejectAccountDeletionService -> POST /account-deletions/:id/reject: tolak permintaan; akun tetap ada
+func RejectAccountDeletionService(c *fiber.Ctx, db *mongoDB.Database) error {
+	id, adminID, catatan, ferr := processAccountDeletionInput(c)
+	if ferr != nil {
+		return meError(c, ferr.Code, ferr.Message)
+	}
+	_, done, err := pendingAccountDeletion(c, db, id)
+	if done {
+		return err
+	}
+
+	deletion, err := repository.FinishAccountDeletion(db, id, mongo.AccountDeletionRejected, adminID, catatan)
+	if err != nil {
+		return meError(c, fiber.StatusInternalServerError, "Gagal memproses permintaan hapus akun: "+err.Error())
+	}
+	if deletion == nil {
+		_, _, err := pendingAccountDeletion(c, db, id)
+		return err
+	}
+	recordAudit(c, db, auditEntry(helper.AuditActionUpdate, helper.AuditEntityAccountDeletion, id.Hex(), nil, deletion))
+
+	return c.Status(fiber.StatusOK).JSON(mongo.AccountDeletionResponse{
+		Success: true,
+		Message: "Permintaan hapus akun ditolak",
+		Data:    *deletion,
+	})
+}
@@ -0,0 +1,249 @@
+package mongo
+
+import (
+	"log"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	model "go-fiber/app/model/mongo"
+	repository "go-fiber/app/repository/mongo"
+	utils "go-fiber/utils/mongo"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongoDB "go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	passwordResetDefaultTTL = time.Hour
+	passwordResetDefaultURL = "http://localhost:3000/reset-password"
+	// Respons forgot password selalu sama agar email terdaftar tidak bisa ditebak
+	forgotPasswordMessage = "Jika email terdaftar, tautan reset password telah dikirim"
+)
+
+// passwordResetTTL -> masa berlaku token dari PASSWORD_RESET_TTL_MINUTES (default 60)
+func passwordResetTTL() time.Duration {
+	if n, err := strconv.Atoi(os.Getenv("PASSWORD_RESET_TTL_MINUTES")); err == nil && n > 0 {
+		return time.Duration(n) * time.Minute
+	}
+	return passwordResetDefaultTTL
+}
+
+// passwordResetURL -> tautan halaman reset di frontend (PASSWORD_RESET_URL) dengan ?token=
+func passwordResetURL(token string) string {
+	base := os.Getenv("PASSWORD_RESET_URL")
+	if base == "" {
+		base = passwordResetDefaultURL
+	}
+	sep := "?"
+	if strings.Contains(base, "?") {
+		sep = "&"
+	}
+	return base + sep + "token=" + url.QueryEscape(token)
+}
+
+func passwordError(c *fiber.Ctx, status int, message string) error {
+	return c.Status(status).JSON(model.PasswordResponse{Success: false, Message: message})
+}
+
+// SessionChecker -> middleware.SessionChecker: token hanya berlaku selama session_version
+// di klaim sama dengan milik alumni (naik setiap password berubah)
+func SessionChecker(db *mongoDB.Database) func(claims *model.JWTClaims) (bool, error) {
+	return func(claims *model.JWTClaims) (bool, error) {
+		id, err := primitive.ObjectIDFromHex(claims.UserID)
+		if err != nil {
+			return false, nil
+		}
+		version, found, err := repository.GetAlumniSessionVersion(db, id)
+		if err != nil || !found {
+			return false, err
+		}
+		return version == claims.SessionVersion, nil
+	}
+}
+
+// ForgotPasswordService -> POST /password/forgot: kirim tautan reset sekali pakai ke email alumni
+func ForgotPasswordService(c *fiber.Ctx, db *mongoDB.Database) error {
+	var req model.ForgotPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return passwordError(c, fiber.StatusBadRequest, "Request body tidak valid")
+	}
+	email := strings.TrimSpace(req.Email)
+	if email == "" {
+		return passwordError(c, fiber.StatusBadRequest, "Email harus diisi")
+	}
+
+	// Rate limit per IP dan per email (termasuk email yang tidak terdaftar)
+	cfg, limiter := loginProtection(db)
+	count, retryAfter, err := limiter.Hit(forgotPasswordIPKey(c.IP()), cfg.Window)
+	if err != nil {
+		return passwordError(c, fiber.StatusInternalServerError, "Gagal memeriksa rate limit")
+	}
+	if count > cfg.IPLimit {
+		return tooManyAttempts(c, retryAfter, "Terlalu banyak permintaan reset password, coba lagi nanti")
+	}
+	count, retryAfter, err = limiter.Hit(forgotPasswordAccountKey(email), cfg.Window)
+	if err != nil {
+		return passwordError(c, fiber.StatusInternalServerError, "Gagal memeriksa rate limit")
+	}
+	if count > cfg.AccountLimit {
+		return tooManyAttempts(c, retryAfter, "Terlalu banyak permintaan reset password, coba lagi nanti")
+	}
+
+	alumni, err := repository.GetAlumniByEmail(db, email)
+	if err != nil {
+		return passwordError(c, fiber.StatusInternalServerError, "Error database")
+	}
+	// Kegagalan hanya dicatat di log agar respons tidak membedakan email terdaftar
+	if alumni != nil {
+		if err := sendPasswordReset(db, alumni); err != nil {
+			log.Printf("Password reset for %s failed: %v", alumni.ID.Hex(), err)
+		}
+	}
+
+	return c.JSON(model.PasswordResponse{Success: true, Message: forgotPasswordMessage})
+}
+
+func forgotPasswordIPKey(ip string) string {
+	return "forgot:ip:" + ip
+}
+
+func forgotPasswordAccountKey(email string) string {
+	return "forgot:account:" + strings.ToLower(email)
+}
+
+// sendPasswordReset -> buat token reset lalu antrekan email berisi link reset
+func sendPasswordReset(db *mongoDB.Database, alumni *model.Alumni) error {
+	token, hash, err := utils.GenerateResetToken()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	reset := &model.PasswordReset{
+		AlumniID:  alumni.ID,
+		TokenHash: hash,
+		ExpiresAt: now.Add(passwordResetTTL()),
+		CreatedAt: now,
+	}
+	if err := repository.CreatePasswordReset(db, reset); err != nil {
+		return err
+	}
+	return NotifyPasswordReset(db, alumni, passwordResetURL(token), reset.ExpiresAt)
+}
+
+// ResetPasswordService -> POST /password/reset: ganti password dengan token dari email.
+// Token hanya bisa dipakai sekali dan semua sesi alumni dicabut.
+func ResetPasswordService(c *fiber.Ctx, db *mongoDB.Database) error {
+	var req model.ResetPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return passwordError(c, fiber.StatusBadRequest, "Request body tidak valid")
+	}
+	if req.Token == "" || req.Password == "" {
+		return passwordError(c, fiber.StatusBadRequest, "Token dan password harus diisi")
+	}
+
+	tokenHash := utils.HashResetToken(req.Token)
+	reset, err := repository.FindPasswordReset(db, tokenHash)
+	if err != nil {
+		return passwordError(c, fiber.StatusInternalServerError, "Error database")
+	}
+	if reset == nil {
+		return passwordError(c, fiber.StatusBadRequest, "Token reset tidak valid atau sudah kedaluwarsa")
+	}
+	alumni, err := repository.GetAlumniByID(db, reset.AlumniID.Hex())
+	if err != nil {
+		return passwordError(c, fiber.StatusInternalServerError, "Error database")
+	}
+	if alumni == nil {
+		return passwordError(c, fiber.StatusBadRequest, "Token reset tidak valid atau sudah kedaluwarsa")
+	}
+
+	// Kebijakan dicek sebelum token dipakai agar password lemah tidak menghabiskan token
+	if err := utils.ValidatePassword(req.Password, alumni.Email, alumni.NIM); err != nil {
+		return passwordError(c, fiber.StatusBadRequest, "Password tidak memenuhi kebijakan: "+err.Error())
+	}
+	hashed, err := utils.HashPassword(req.Password)
+	if err != nil {
+		return passwordError(c, fiber.StatusInternalServerError, "Gagal memproses password")
+	}
+
+	consumed, err := repository.ConsumePasswordReset(db, tokenHash)
+	if err != nil {
+		return passwordError(c, fiber.StatusInternalServerError, "Error database")
+	}
+	if consumed == nil {
+		return passwordError(c, fiber.StatusBadRequest, "Token reset tidak valid atau sudah kedaluwarsa")
+	}
+	if _, err := repository.UpdateAlumniPassword(db, alumni.ID, hashed); err != nil {
+		return passwordError(c, fiber.StatusInternalServerError, "Gagal menyimpan password")
+	}
+	recordAccountAudit(c, db, alumni.ID, map[string]any{"password_changed": true, "method": "reset"})
+
+	return c.JSON(model.PasswordResponse{Success: true, Message: "Password berhasil direset, silakan login"})
+}
+
+// ChangePasswordService -> POST /me/password: ganti password sendiri dengan password saat ini.
+// Semua sesi lain dicabut; response berisi token baru untuk sesi ini.
+func ChangePasswordService(c *fiber.Ctx, db *mongoDB.Database) error {
+	var req model.ChangePasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return passwordError(c, fiber.StatusBadRequest, "Request body tidak valid")
+	}
+	if req.CurrentPassword == "" || req.NewPassword == "" {
+		return passwordError(c, fiber.StatusBadRequest, "Password saat ini dan password baru harus diisi")
+	}
+
+	userID, _ := c.Locals("user_id").(string)
+	if _, err := primitive.ObjectIDFromHex(userID); err != nil {
+		return passwordError(c, fiber.StatusUnauthorized, "Token tidak valid")
+	}
+	alumni, err := repository.GetAlumniByID(db, userID)
+	if err != nil {
+		return passwordError(c, fiber.StatusInternalServerError, "Error database")
+	}
+	if alumni == nil {
+		return passwordError(c, fiber.StatusNotFound, "Alumni tidak ditemukan")
+	}
+
+	if !utils.CheckPassword(req.CurrentPassword, alumni.Password) {
+		return passwordError(c, fiber.StatusBadRequest, "Password saat ini salah")
+	}
+	if req.NewPassword == req.CurrentPassword {
+		return passwordError(c, fiber.StatusBadRequest, "Password baru harus berbeda dari password saat ini")
+	}
+	if err := utils.ValidatePassword(req.NewPassword, alumni.Email, alumni.NIM); err != nil {
+		return passwordError(c, fiber.StatusBadRequest, "Password tidak memenuhi kebijakan: "+err.Error())
+	}
+	hashed, err := utils.HashPassword(req.NewPassword)
+	if err != nil {
+		return passwordError(c, fiber.StatusInternalServerError, "Gagal memproses password")
+	}
+
+	updated, err := repository.UpdateAlumniPassword(db, alumni.ID, hashed)
+	if err != nil || updated == nil {
+		return passwordError(c, fiber.StatusInternalServerError, "Gagal menyimpan password")
+	}
+	recordAccountAudit(c, db, alumni.ID, map[string]any{"password_changed": true, "method": "change"})
+	role, _ := c.Locals("role").(string)
+	token, err := utils.GenerateToken(model.User{
+		ID:             updated.ID,
+		Username:       updated.Email,
+		Email:          updated.Email,
+		Role:           role,
+		SessionVersion: updated.SessionVersion,
+	})
+	if err != nil {
+		return passwordError(c, fiber.StatusInternalServerError, "Gagal generate token")
+	}
+
+	// Token baru tidak boleh di-cache atau disimpan untuk replay Idempotency-Key
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.JSON(model.ChangePasswordResponse{
+		Success: true,
+		Message: "Password berhasil diganti, sesi lain telah dicabut",
+		Data:    model.ChangePasswordData{Token: token},
+	})
+}
@@ -0,0 +1,60 @@
+package mongo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"go-fiber/helper"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// patchFormat -> format body PATCH dari Content-Type (merge patch atau JSON Patch).
+// Error 415 untuk Content-Type lain, 400 bila body bukan JSON; dicek sebelum data diambil.
+func patchFormat(c *fiber.Ctx) (string, *fiber.Error) {
+	mediaType := strings.ToLower(strings.TrimSpace(strings.SplitN(c.Get(fiber.HeaderContentType), ";", 2)[0]))
+	if mediaType != helper.MergePatchContentType && mediaType != helper.JSONPatchContentType {
+		return "", fiber.NewError(fiber.StatusUnsupportedMediaType,
+			"Content-Type PATCH harus "+helper.MergePatchContentType+" atau "+helper.JSONPatchContentType)
+	}
+	if !json.Valid(c.Body()) {
+		return "", fiber.NewError(fiber.StatusBadRequest, "Body patch bukan JSON yang valid")
+	}
+	return mediaType, nil
+}
+
+// applyPatch -> terapkan body PATCH ke dokumen current lalu decode hasilnya ke out
+func applyPatch(c *fiber.Ctx, format string, current, out any) *fiber.Error {
+	return patchDocument(format, c.Body(), current, out)
+}
+
+// patchDocument -> terapkan patch ke dokumen current lalu decode hasilnya ke out. Field yang
+// tidak dikenal ditolak; operasi test JSON Patch yang gagal dijawab 409.
+func patchDocument(format string, patch []byte, current, out any) *fiber.Error {
+	doc, err := json.Marshal(current)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "Gagal memproses data: "+err.Error())
+	}
+
+	var patched []byte
+	if format == helper.MergePatchContentType {
+		patched, err = helper.MergePatch(doc, patch)
+	} else {
+		patched, err = helper.ApplyJSONPatch(doc, patch)
+	}
+	if errors.Is(err, helper.ErrPatchTestFailed) {
+		return fiber.NewError(fiber.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Patch tidak valid: "+err.Error())
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(out); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Hasil patch tidak valid: "+err.Error())
+	}
+	return nil
+}
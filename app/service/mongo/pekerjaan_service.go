@@ -1,9 +1,11 @@
 package mongo
 
 import (
-	"fmt"
+	"context"
+	"errors"
 	"go-fiber/app/model/mongo"
 	repository "go-fiber/app/repository/mongo"
+	"go-fiber/helper"
 	"strconv"
 	"strings"
 	"time"
@@ -14,50 +16,39 @@ import (
 	mongoDB "go.mongodb.org/mongo-driver/mongo"
 )
 
-var acceptedDateLayouts = []string{
-	"2006-01-02",
-	"02-01-2006",
-	"2006/01/02",
-	"02/01/2006",
-}
-
-func parseDateFlexible(dateStr string) (time.Time, error) {
-	for _, layout := range acceptedDateLayouts {
-		if t, err := time.Parse(layout, dateStr); err == nil {
-			return t, nil
-		}
-	}
-	return time.Time{}, fmt.Errorf("format tanggal tidak valid. Gunakan salah satu format: YYYY-MM-DD, DD-MM-YYYY, YYYY/MM/DD, DD/MM/YYYY")
-}
-
 // Pekerjaan Alumni Services
 
 func GetAllPekerjaanService(c *fiber.Ctx, db *mongoDB.Database) error {
 	// Parse query parameters
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	limit, _ := strconv.Atoi(c.Query("limit", "10"))
 	sortBy := c.Query("sortBy", "id")
 	order := c.Query("order", "asc")
 	search := c.Query("search", "")
 
-	// Hitung offset untuk pagination
-	offset := (page - 1) * limit
-
 	// Validasi input
-	sortByWhitelist := map[string]bool{"id": true, "nama_perusahaan": true, "posisi_jabatan": true, "bidang_industri": true, "lokasi_kerja": true, "tanggal_mulai_kerja": true, "status_pekerjaan": true, "created_at": true}
-	if !sortByWhitelist[sortBy] {
+	if _, ok := repository.PekerjaanSortTypes[sortBy]; !ok {
 		sortBy = "id"
 	}
 	if strings.ToLower(order) != "desc" {
 		order = "asc"
 	}
 
-	// Ambil data dari repository
-	pekerjaan, err := repository.GetPekerjaanRepo(db, search, sortBy, order, limit, offset)
+	filters, err := helper.ParseFilters(c.Queries(), repository.PekerjaanFilterFields)
 	if err != nil {
-		return c.Status(500).JSON(mongo.GetAllPekerjaanResponse{
+		return c.Status(400).JSON(mongo.GetAllPekerjaanResponse{
 			Success: false,
-			Message: "Failed to fetch pekerjaan",
+			Message: err.Error(),
+			Data: mongo.PekerjaanData{
+				Items: []mongo.PekerjaanAlumni{},
+				Meta:  mongo.MetaInfo{},
+			},
+		})
+	}
+
+	page, err := parseListPage(c, sortBy, order, repository.PekerjaanSortTypes[sortBy], helper.FilterTypeObjectID)
+	if err != nil {
+		return c.Status(400).JSON(mongo.GetAllPekerjaanResponse{
+			Success: false,
+			Message: err.Error(),
 			Data: mongo.PekerjaanData{
 				Items: []mongo.PekerjaanAlumni{},
 				Meta:  mongo.MetaInfo{},
@@ -65,11 +56,18 @@ func GetAllPekerjaanService(c *fiber.Ctx, db *mongoDB.Database) error {
 		})
 	}
 
-	total, err := repository.CountPekerjaanRepo(db, search)
+	// Ambil data dari repository: mode cursor tanpa skip/offset, mode page tetap pakai offset
+	var pekerjaan []mongo.PekerjaanAlumni
+	var hasMore bool
+	if page.Keyset != nil {
+		pekerjaan, hasMore, err = repository.GetPekerjaanKeysetRepo(db, search, filters, sortBy, order, page.Keyset, page.Limit)
+	} else {
+		pekerjaan, err = repository.GetPekerjaanRepo(db, search, filters, sortBy, order, page.Limit, page.Offset)
+	}
 	if err != nil {
 		return c.Status(500).JSON(mongo.GetAllPekerjaanResponse{
 			Success: false,
-			Message: "Failed to count pekerjaan",
+			Message: "Failed to fetch pekerjaan",
 			Data: mongo.PekerjaanData{
 				Items: []mongo.PekerjaanAlumni{},
 				Meta:  mongo.MetaInfo{},
@@ -77,25 +75,153 @@ func GetAllPekerjaanService(c *fiber.Ctx, db *mongoDB.Database) error {
 		})
 	}
 
+	// Total selalu dihitung pada mode page, pada mode cursor hanya bila with_total=true
+	var total *int
+	if page.Keyset == nil || page.WithTotal {
+		count, err := repository.CountPekerjaanRepo(db, search, filters)
+		if err != nil {
+			return c.Status(500).JSON(mongo.GetAllPekerjaanResponse{
+				Success: false,
+				Message: "Failed to count pekerjaan",
+				Data: mongo.PekerjaanData{
+					Items: []mongo.PekerjaanAlumni{},
+					Meta:  mongo.MetaInfo{},
+				},
+			})
+		}
+		total = &count
+		if page.Keyset == nil {
+			hasMore = page.Offset+len(pekerjaan) < count
+		}
+	}
+
+	var first, last *helper.CursorPosition
+	if len(pekerjaan) > 0 {
+		first = pekerjaanPosition(pekerjaan[0], sortBy)
+		last = pekerjaanPosition(pekerjaan[len(pekerjaan)-1], sortBy)
+	}
+
+	for i := range pekerjaan {
+		maskSalary(c, &pekerjaan[i])
+	}
+
+	meta := page.meta(total, hasMore, first, last)
+	meta.SortBy = sortBy
+	meta.Order = order
+	meta.Search = search
+
 	// Buat response pakai model
 	response := mongo.GetAllPekerjaanResponse{
 		Success: true,
 		Message: "Berhasil mengambil data pekerjaan",
 		Data: mongo.PekerjaanData{
 			Items: pekerjaan,
+			Meta:  meta,
+		},
+	}
+
+	return c.JSON(response)
+}
+
+// pekerjaanPosition -> nilai kolom sort + id pekerjaan untuk membentuk cursor
+func pekerjaanPosition(p mongo.PekerjaanAlumni, sortBy string) *helper.CursorPosition {
+	var value interface{}
+	switch sortBy {
+	case "nama_perusahaan":
+		value = p.NamaPerusahaan
+	case "posisi_jabatan":
+		value = p.PosisiJabatan
+	case "bidang_industri":
+		value = p.BidangIndustri
+	case "lokasi_kerja":
+		value = p.LokasiKerja
+	case "tanggal_mulai_kerja":
+		value = p.TanggalMulaiKerja
+	case "status_pekerjaan":
+		value = p.StatusPekerjaan
+	case "created_at":
+		value = p.CreatedAt
+	default:
+		value = p.ID
+	}
+	return &helper.CursorPosition{Value: value, ID: p.ID}
+}
+
+// SearchPekerjaanService -> full-text search pekerjaan dengan ranking relevansi dan highlight
+func SearchPekerjaanService(c *fiber.Ctx, db *mongoDB.Database) error {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(mongo.SearchPekerjaanResponse{
+			Success: false,
+			Message: "Parameter q wajib diisi",
+			Data:    mongo.SearchPekerjaanData{Items: []mongo.PekerjaanSearchResult{}},
+		})
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	offset := (page - 1) * limit
+
+	results, err := repository.SearchPekerjaanRepo(db, query, limit, offset)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(mongo.SearchPekerjaanResponse{
+			Success: false,
+			Message: "Gagal mencari pekerjaan: " + err.Error(),
+			Data:    mongo.SearchPekerjaanData{Items: []mongo.PekerjaanSearchResult{}},
+		})
+	}
+
+	total, err := repository.CountSearchPekerjaanRepo(db, query)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(mongo.SearchPekerjaanResponse{
+			Success: false,
+			Message: "Gagal menghitung hasil pencarian: " + err.Error(),
+			Data:    mongo.SearchPekerjaanData{Items: []mongo.PekerjaanSearchResult{}},
+		})
+	}
+
+	terms := helper.SearchTerms(query)
+	for i := range results {
+		fields := map[string]string{
+			"nama_perusahaan": results[i].NamaPerusahaan,
+			"posisi_jabatan":  results[i].PosisiJabatan,
+			"bidang_industri": results[i].BidangIndustri,
+			"lokasi_kerja":    results[i].LokasiKerja,
+		}
+		if results[i].DeskripsiPekerjaan != nil {
+			fields["deskripsi_pekerjaan"] = *results[i].DeskripsiPekerjaan
+		}
+		results[i].Highlights = highlightFields(terms, fields)
+	}
+	for i := range results {
+		maskSalary(c, &results[i].PekerjaanAlumni)
+	}
+	if results == nil {
+		results = []mongo.PekerjaanSearchResult{}
+	}
+
+	return c.JSON(mongo.SearchPekerjaanResponse{
+		Success: true,
+		Message: "Berhasil mencari pekerjaan",
+		Data: mongo.SearchPekerjaanData{
+			Items: results,
 			Meta: mongo.MetaInfo{
 				Page:   page,
 				Limit:  limit,
-				Total:  total,
+				Total:  &total,
 				Pages:  (total + limit - 1) / limit,
-				SortBy: sortBy,
-				Order:  order,
-				Search: search,
+				SortBy: "relevance",
+				Order:  "desc",
+				Search: query,
 			},
 		},
-	}
-
-	return c.JSON(response)
+	})
 }
 
 func GetPekerjaanByIDService(c *fiber.Ctx, db *mongoDB.Database) error {
@@ -125,6 +251,8 @@ func GetPekerjaanByIDService(c *fiber.Ctx, db *mongoDB.Database) error {
 		})
 	}
 
+	maskSalary(c, pekerjaan)
+	c.Set(fiber.HeaderETag, helper.ETag(pekerjaan.Version))
 	return c.Status(fiber.StatusOK).JSON(mongo.GetPekerjaanAlumniByIDResponse{
 		Success: true,
 		Message: "Berhasil mengambil data pekerjaan",
@@ -161,6 +289,10 @@ func GetPekerjaanByAlumniIDService(c *fiber.Ctx, db *mongoDB.Database) error {
 		})
 	}
 
+	for i := range pekerjaan {
+		maskSalary(c, &pekerjaan[i])
+	}
+
 	return c.Status(fiber.StatusOK).JSON(mongo.GetPekerjaanAlumniByAlumniIDResponse{
 		Success: true,
 		Message: "Berhasil mengambil data pekerjaan alumni",
@@ -168,6 +300,97 @@ func GetPekerjaanByAlumniIDService(c *fiber.Ctx, db *mongoDB.Database) error {
 	})
 }
 
+// pekerjaanCreateRequest -> validasi request create pekerjaan dan parse tanggalnya; dipakai create
+// biasa dan batch
+func pekerjaanCreateRequest(req *mongo.CreatePekerjaanAlumniRequest) (*mongo.CreatePekerjaanAlumniRepositoryRequest, *fiber.Error) {
+	// Basic validation dengan pesan yang lebih detail
+	switch {
+	case req.AlumniID == "":
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Alumni ID wajib diisi")
+	case req.NamaPerusahaan == "" && req.CompanyID == nil:
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Nama perusahaan wajib diisi")
+	case req.PosisiJabatan == "":
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Posisi jabatan wajib diisi")
+	case req.BidangIndustri == "" && req.IndustryID == nil:
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Bidang industri wajib diisi")
+	case req.LokasiKerja == "":
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Lokasi kerja wajib diisi")
+	case req.TanggalMulaiKerja == "":
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Tanggal mulai kerja wajib diisi")
+	case req.StatusPekerjaan == "":
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Status pekerjaan wajib diisi")
+	case req.StatusPekerjaan != "aktif" && req.StatusPekerjaan != "selesai" && req.StatusPekerjaan != "resigned":
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Status pekerjaan harus aktif, selesai, atau resigned")
+	}
+
+	// Parse tanggal mulai kerja
+	tanggalMulai, err := helper.ParseDateFlexible(req.TanggalMulaiKerja)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Format tanggal mulai kerja tidak valid. Gunakan format YYYY-MM-DD")
+	}
+
+	// Parse tanggal selesai kerja jika ada
+	var tanggalSelesai *time.Time
+	if req.TanggalSelesaiKerja != nil && *req.TanggalSelesaiKerja != "" {
+		parsed, err := helper.ParseDateFlexible(*req.TanggalSelesaiKerja)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Format tanggal selesai kerja tidak valid. Gunakan format YYYY-MM-DD")
+		}
+		if parsed.Before(tanggalMulai) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Tanggal selesai kerja tidak boleh sebelum tanggal mulai kerja")
+		}
+		tanggalSelesai = &parsed
+	}
+
+	// Convert AlumniID string to ObjectID
+	alumniID, err := primitive.ObjectIDFromHex(req.AlumniID)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Alumni ID tidak valid")
+	}
+	companyID, ferr := referenceIDParam(req.CompanyID, "Company ID")
+	if ferr != nil {
+		return nil, ferr
+	}
+	industryID, ferr := referenceIDParam(req.IndustryID, "Industry ID")
+	if ferr != nil {
+		return nil, ferr
+	}
+	// gaji_range format lama masih diterima selama masa transisi
+	salary, err := helper.ResolveSalary(req.GajiRange, req.GajiMin, req.GajiMax, req.GajiMataUang, req.GajiPeriode)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	location, err := helper.ResolveLocation(req.LokasiKerja, req.Negara, req.Provinsi, req.Kota, req.Latitude, req.Longitude)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	return &mongo.CreatePekerjaanAlumniRepositoryRequest{
+		AlumniID:            alumniID,
+		NamaPerusahaan:      req.NamaPerusahaan,
+		CompanyID:           companyID,
+		PosisiJabatan:       req.PosisiJabatan,
+		BidangIndustri:      req.BidangIndustri,
+		IndustryID:          industryID,
+		LokasiKerja:         req.LokasiKerja,
+		Negara:              location.Negara,
+		Provinsi:            location.Provinsi,
+		Kota:                location.Kota,
+		Latitude:            location.Latitude,
+		Longitude:           location.Longitude,
+		GajiMin:             salary.Min,
+		GajiMax:             salary.Max,
+		GajiMataUang:        salary.Currency,
+		GajiPeriode:         salary.Period,
+		GajiRahasia:         req.GajiRahasia,
+		TanggalMulaiKerja:   tanggalMulai,
+		TanggalSelesaiKerja: tanggalSelesai,
+		StatusPekerjaan:     req.StatusPekerjaan,
+		DeskripsiPekerjaan:  req.DeskripsiPekerjaan,
+		ParuhWaktu:          req.ParuhWaktu,
+	}, nil
+}
+
 func CreatePekerjaanService(c *fiber.Ctx, db *mongoDB.Database) error {
 	var req mongo.CreatePekerjaanAlumniRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -178,81 +401,129 @@ func CreatePekerjaanService(c *fiber.Ctx, db *mongoDB.Database) error {
 		})
 	}
 
-	// Debug logging untuk melihat data yang diterima
-	fmt.Printf("Received request data: %+v\n", req)
+	return createPekerjaan(c, db, &req)
+}
 
-	// Basic validation dengan pesan yang lebih detail
-	if req.AlumniID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(mongo.CreatePekerjaanAlumniResponse{
+// createPekerjaan -> validasi lalu simpan pekerjaan baru; dipakai POST /pekerjaan (admin) dan
+// POST /me/pekerjaan (alumni_id dari token)
+func createPekerjaan(c *fiber.Ctx, db *mongoDB.Database, req *mongo.CreatePekerjaanAlumniRequest) error {
+	repoReq, ferr := pekerjaanCreateRequest(req)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(mongo.CreatePekerjaanAlumniResponse{
 			Success: false,
-			Message: "Alumni ID wajib diisi",
+			Message: ferr.Message,
 			Data:    mongo.PekerjaanAlumni{},
 		})
 	}
-	if req.NamaPerusahaan == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(mongo.CreatePekerjaanAlumniResponse{
+
+	// Check if alumni exists
+	if _, err := repository.GetAlumniByID(db, req.AlumniID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(mongo.CreatePekerjaanAlumniResponse{
 			Success: false,
-			Message: "Nama perusahaan wajib diisi",
+			Message: "Gagal mengambil data alumni: " + err.Error(),
 			Data:    mongo.PekerjaanAlumni{},
 		})
 	}
-	if req.PosisiJabatan == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(mongo.CreatePekerjaanAlumniResponse{
+
+	if ferr := linkPekerjaanReferences(c.Context(), db, &repoReq.CompanyID, &repoReq.NamaPerusahaan, &repoReq.IndustryID, &repoReq.BidangIndustri); ferr != nil {
+		return c.Status(ferr.Code).JSON(mongo.CreatePekerjaanAlumniResponse{
 			Success: false,
-			Message: "Posisi jabatan wajib diisi",
+			Message: ferr.Message,
 			Data:    mongo.PekerjaanAlumni{},
 		})
 	}
-	if req.BidangIndustri == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(mongo.CreatePekerjaanAlumniResponse{
+
+	pekerjaan, err := saveWithCareerRules(db, repoReq.AlumniID, createCareerPeriod(repoReq), func(ctx context.Context) (*mongo.PekerjaanAlumni, error) {
+		return repository.CreatePekerjaanCtx(ctx, db, repoReq)
+	})
+	if ferr, ok := err.(*fiber.Error); ok {
+		return c.Status(ferr.Code).JSON(mongo.CreatePekerjaanAlumniResponse{
 			Success: false,
-			Message: "Bidang industri wajib diisi",
+			Message: ferr.Message,
 			Data:    mongo.PekerjaanAlumni{},
 		})
 	}
-	if req.LokasiKerja == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(mongo.CreatePekerjaanAlumniResponse{
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(mongo.CreatePekerjaanAlumniResponse{
 			Success: false,
-			Message: "Lokasi kerja wajib diisi",
+			Message: "Gagal membuat pekerjaan: " + err.Error(),
 			Data:    mongo.PekerjaanAlumni{},
 		})
 	}
-	if req.TanggalMulaiKerja == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(mongo.CreatePekerjaanAlumniResponse{
+	recordAudit(c, db, auditEntry(helper.AuditActionCreate, helper.AuditEntityPekerjaan, pekerjaan.ID.Hex(), nil, pekerjaan))
+
+	return c.Status(fiber.StatusCreated).JSON(mongo.CreatePekerjaanAlumniResponse{
+		Success: true,
+		Message: "Berhasil membuat pekerjaan",
+		Data:    *pekerjaan,
+	})
+}
+
+func UpdatePekerjaanService(c *fiber.Ctx, db *mongoDB.Database) error {
+	idStr := c.Params("id")
+	if idStr == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(mongo.UpdatePekerjaanAlumniResponse{
 			Success: false,
-			Message: "Tanggal mulai kerja wajib diisi",
+			Message: "ID tidak valid",
 			Data:    mongo.PekerjaanAlumni{},
 		})
 	}
-	if req.StatusPekerjaan == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(mongo.CreatePekerjaanAlumniResponse{
+
+	expectedVersion, ferr := ifMatchVersion(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(mongo.UpdatePekerjaanAlumniResponse{
 			Success: false,
-			Message: "Status pekerjaan wajib diisi",
+			Message: ferr.Message,
 			Data:    mongo.PekerjaanAlumni{},
 		})
 	}
-	if req.StatusPekerjaan != "aktif" && req.StatusPekerjaan != "selesai" && req.StatusPekerjaan != "resigned" {
-		return c.Status(fiber.StatusBadRequest).JSON(mongo.CreatePekerjaanAlumniResponse{
+
+	var req mongo.UpdatePekerjaanAlumniRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(mongo.UpdatePekerjaanAlumniResponse{
 			Success: false,
-			Message: "Status pekerjaan harus aktif, selesai, atau resigned",
+			Message: "Format data tidak valid: " + err.Error(),
 			Data:    mongo.PekerjaanAlumni{},
 		})
 	}
 
-	// Check if alumni exists
-	_, err := repository.GetAlumniByID(db, req.AlumniID)
+	// Check if pekerjaan exists
+	existing, err := repository.GetPekerjaanByID(db, idStr)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(mongo.CreatePekerjaanAlumniResponse{
+		return c.Status(fiber.StatusInternalServerError).JSON(mongo.UpdatePekerjaanAlumniResponse{
 			Success: false,
-			Message: "Gagal mengambil data alumni: " + err.Error(),
+			Message: "Gagal mengambil data pekerjaan: " + err.Error(),
+			Data:    mongo.PekerjaanAlumni{},
+		})
+	}
+	if existing == nil {
+		return c.Status(fiber.StatusNotFound).JSON(mongo.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: "Pekerjaan tidak ditemukan",
+			Data:    mongo.PekerjaanAlumni{},
+		})
+	}
+	if versionMismatch(c, expectedVersion, existing.Version) {
+		return c.Status(fiber.StatusPreconditionFailed).JSON(mongo.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: versionConflictMessage,
+			Data:    mongo.PekerjaanAlumni{},
+		})
+	}
+
+	// Validate status
+	if req.StatusPekerjaan != "aktif" && req.StatusPekerjaan != "selesai" && req.StatusPekerjaan != "resigned" {
+		return c.Status(fiber.StatusBadRequest).JSON(mongo.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: "Status pekerjaan harus aktif, selesai, atau resigned",
 			Data:    mongo.PekerjaanAlumni{},
 		})
 	}
 
 	// Parse tanggal mulai kerja
-	tanggalMulai, err := parseDateFlexible(req.TanggalMulaiKerja)
+	tanggalMulai, err := helper.ParseDateFlexible(req.TanggalMulaiKerja)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(mongo.CreatePekerjaanAlumniResponse{
+		return c.Status(fiber.StatusBadRequest).JSON(mongo.UpdatePekerjaanAlumniResponse{
 			Success: false,
 			Message: "Format tanggal mulai kerja tidak valid. Gunakan format YYYY-MM-DD",
 			Data:    mongo.PekerjaanAlumni{},
@@ -262,78 +533,161 @@ func CreatePekerjaanService(c *fiber.Ctx, db *mongoDB.Database) error {
 	// Parse tanggal selesai kerja jika ada
 	var tanggalSelesai *time.Time
 	if req.TanggalSelesaiKerja != nil && *req.TanggalSelesaiKerja != "" {
-		parsed, err := parseDateFlexible(*req.TanggalSelesaiKerja)
+		parsed, err := helper.ParseDateFlexible(*req.TanggalSelesaiKerja)
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(mongo.CreatePekerjaanAlumniResponse{
+			return c.Status(fiber.StatusBadRequest).JSON(mongo.UpdatePekerjaanAlumniResponse{
 				Success: false,
 				Message: "Format tanggal selesai kerja tidak valid. Gunakan format YYYY-MM-DD",
 				Data:    mongo.PekerjaanAlumni{},
 			})
 		}
+		if parsed.Before(tanggalMulai) {
+			return c.Status(fiber.StatusBadRequest).JSON(mongo.UpdatePekerjaanAlumniResponse{
+				Success: false,
+				Message: "Tanggal selesai kerja tidak boleh sebelum tanggal mulai kerja",
+				Data:    mongo.PekerjaanAlumni{},
+			})
+		}
 		tanggalSelesai = &parsed
 	}
 
-	// Convert AlumniID string to ObjectID
-	alumniID, err := primitive.ObjectIDFromHex(req.AlumniID)
+	companyID, ferr := referenceIDParam(req.CompanyID, "Company ID")
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(mongo.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    mongo.PekerjaanAlumni{},
+		})
+	}
+	industryID, ferr := referenceIDParam(req.IndustryID, "Industry ID")
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(mongo.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    mongo.PekerjaanAlumni{},
+		})
+	}
+	salary, err := helper.ResolveSalary(req.GajiRange, req.GajiMin, req.GajiMax, req.GajiMataUang, req.GajiPeriode)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(mongo.CreatePekerjaanAlumniResponse{
+		return c.Status(fiber.StatusBadRequest).JSON(mongo.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: err.Error(),
+			Data:    mongo.PekerjaanAlumni{},
+		})
+	}
+	location, err := helper.ResolveLocation(req.LokasiKerja, req.Negara, req.Provinsi, req.Kota, req.Latitude, req.Longitude)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(mongo.UpdatePekerjaanAlumniResponse{
 			Success: false,
-			Message: "Alumni ID tidak valid",
+			Message: err.Error(),
 			Data:    mongo.PekerjaanAlumni{},
 		})
 	}
 
 	// Konversi ke repository request
-	repoReq := &mongo.CreatePekerjaanAlumniRepositoryRequest{
-		AlumniID:            alumniID,
+	repoReq := &mongo.UpdatePekerjaanAlumniRepositoryRequest{
 		NamaPerusahaan:      req.NamaPerusahaan,
+		CompanyID:           companyID,
 		PosisiJabatan:       req.PosisiJabatan,
 		BidangIndustri:      req.BidangIndustri,
+		IndustryID:          industryID,
 		LokasiKerja:         req.LokasiKerja,
-		GajiRange:           req.GajiRange,
+		Negara:              location.Negara,
+		Provinsi:            location.Provinsi,
+		Kota:                location.Kota,
+		Latitude:            location.Latitude,
+		Longitude:           location.Longitude,
+		GajiMin:             salary.Min,
+		GajiMax:             salary.Max,
+		GajiMataUang:        salary.Currency,
+		GajiPeriode:         salary.Period,
+		GajiRahasia:         req.GajiRahasia,
 		TanggalMulaiKerja:   tanggalMulai,
 		TanggalSelesaiKerja: tanggalSelesai,
 		StatusPekerjaan:     req.StatusPekerjaan,
 		DeskripsiPekerjaan:  req.DeskripsiPekerjaan,
+		ParuhWaktu:          req.ParuhWaktu,
 	}
 
-	pekerjaan, err := repository.CreatePekerjaan(db, repoReq)
+	if ferr := linkPekerjaanReferences(c.Context(), db, &repoReq.CompanyID, &repoReq.NamaPerusahaan, &repoReq.IndustryID, &repoReq.BidangIndustri); ferr != nil {
+		return c.Status(ferr.Code).JSON(mongo.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    mongo.PekerjaanAlumni{},
+		})
+	}
+
+	pekerjaan, err := saveWithCareerRules(db, existing.AlumniID, updateCareerPeriod(existing.ID, repoReq), func(ctx context.Context) (*mongo.PekerjaanAlumni, error) {
+		return repository.UpdatePekerjaanCtx(ctx, db, idStr, repoReq, expectedVersion)
+	})
+	if ferr, ok := err.(*fiber.Error); ok {
+		return c.Status(ferr.Code).JSON(mongo.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    mongo.PekerjaanAlumni{},
+		})
+	}
+	if errors.Is(err, helper.ErrVersionConflict) {
+		return c.Status(fiber.StatusPreconditionFailed).JSON(mongo.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: versionConflictMessage,
+			Data:    mongo.PekerjaanAlumni{},
+		})
+	}
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(mongo.CreatePekerjaanAlumniResponse{
+		return c.Status(fiber.StatusInternalServerError).JSON(mongo.UpdatePekerjaanAlumniResponse{
 			Success: false,
-			Message: "Gagal membuat pekerjaan: " + err.Error(),
+			Message: "Gagal mengupdate pekerjaan: " + err.Error(),
 			Data:    mongo.PekerjaanAlumni{},
 		})
 	}
+	recordAudit(c, db, auditEntry(helper.AuditActionUpdate, helper.AuditEntityPekerjaan, idStr, existing, pekerjaan))
 
-	return c.Status(fiber.StatusCreated).JSON(mongo.CreatePekerjaanAlumniResponse{
+	c.Set(fiber.HeaderETag, helper.ETag(pekerjaan.Version))
+	return c.Status(fiber.StatusOK).JSON(mongo.UpdatePekerjaanAlumniResponse{
 		Success: true,
-		Message: "Berhasil membuat pekerjaan",
+		Message: "Berhasil mengupdate pekerjaan",
 		Data:    *pekerjaan,
 	})
 }
 
-func UpdatePekerjaanService(c *fiber.Ctx, db *mongoDB.Database) error {
+// PatchPekerjaanService -> ubah sebagian pekerjaan dengan JSON Merge Patch (RFC 7396) atau JSON
+// Patch (RFC 6902) tanpa harus mengirim semua field seperti PUT. Hasil patch divalidasi seperti
+// update biasa; null / remove pada field opsional mengosongkannya.
+func PatchPekerjaanService(c *fiber.Ctx, db *mongoDB.Database) error {
 	idStr := c.Params("id")
-	if idStr == "" {
+	if _, err := primitive.ObjectIDFromHex(idStr); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(mongo.UpdatePekerjaanAlumniResponse{
 			Success: false,
-			Message: "ID tidak valid",
+			Message: "Format ID tidak valid",
 			Data:    mongo.PekerjaanAlumni{},
 		})
 	}
+	return patchPekerjaan(c, db, idStr, nil)
+}
 
-	var req mongo.UpdatePekerjaanAlumniRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(mongo.UpdatePekerjaanAlumniResponse{
+// patchPekerjaan -> terapkan PATCH ke pekerjaan idStr. Bila ownerID diisi, pekerjaan milik alumni lain
+// dijawab 404 seperti pekerjaan yang tidak ada (PATCH /me/pekerjaan/:id)
+func patchPekerjaan(c *fiber.Ctx, db *mongoDB.Database, idStr string, ownerID *primitive.ObjectID) error {
+	format, ferr := patchFormat(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(mongo.UpdatePekerjaanAlumniResponse{
 			Success: false,
-			Message: "Format data tidak valid: " + err.Error(),
+			Message: ferr.Message,
 			Data:    mongo.PekerjaanAlumni{},
 		})
 	}
 
-	// Check if pekerjaan exists
-	_, err := repository.GetPekerjaanByID(db, idStr)
+	expectedVersion, ferr := ifMatchVersion(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(mongo.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    mongo.PekerjaanAlumni{},
+		})
+	}
+
+	existing, err := repository.GetPekerjaanByID(db, idStr)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(mongo.UpdatePekerjaanAlumniResponse{
 			Success: false,
@@ -341,54 +695,62 @@ func UpdatePekerjaanService(c *fiber.Ctx, db *mongoDB.Database) error {
 			Data:    mongo.PekerjaanAlumni{},
 		})
 	}
-
-	// Validate status
-	if req.StatusPekerjaan != "aktif" && req.StatusPekerjaan != "selesai" && req.StatusPekerjaan != "resigned" {
-		return c.Status(fiber.StatusBadRequest).JSON(mongo.UpdatePekerjaanAlumniResponse{
+	if existing == nil || (ownerID != nil && existing.AlumniID != *ownerID) {
+		return c.Status(fiber.StatusNotFound).JSON(mongo.UpdatePekerjaanAlumniResponse{
 			Success: false,
-			Message: "Status pekerjaan harus aktif, selesai, atau resigned",
+			Message: "Pekerjaan tidak ditemukan",
 			Data:    mongo.PekerjaanAlumni{},
 		})
 	}
-
-	// Parse tanggal mulai kerja
-	tanggalMulai, err := parseDateFlexible(req.TanggalMulaiKerja)
-	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(mongo.UpdatePekerjaanAlumniResponse{
+	if versionMismatch(c, expectedVersion, existing.Version) {
+		return c.Status(fiber.StatusPreconditionFailed).JSON(mongo.UpdatePekerjaanAlumniResponse{
 			Success: false,
-			Message: "Format tanggal mulai kerja tidak valid. Gunakan format YYYY-MM-DD",
+			Message: versionConflictMessage,
 			Data:    mongo.PekerjaanAlumni{},
 		})
 	}
 
-	// Parse tanggal selesai kerja jika ada
-	var tanggalSelesai *time.Time
-	if req.TanggalSelesaiKerja != nil && *req.TanggalSelesaiKerja != "" {
-		parsed, err := parseDateFlexible(*req.TanggalSelesaiKerja)
-		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(mongo.UpdatePekerjaanAlumniResponse{
-				Success: false,
-				Message: "Format tanggal selesai kerja tidak valid. Gunakan format YYYY-MM-DD",
-				Data:    mongo.PekerjaanAlumni{},
-			})
-		}
-		tanggalSelesai = &parsed
+	var doc mongo.PekerjaanPatchDocument
+	if ferr := applyPatch(c, format, pekerjaanPatchDocument(existing), &doc); ferr != nil {
+		return c.Status(ferr.Code).JSON(mongo.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    mongo.PekerjaanAlumni{},
+		})
 	}
-
-	// Konversi ke repository request
-	repoReq := &mongo.UpdatePekerjaanAlumniRepositoryRequest{
-		NamaPerusahaan:      req.NamaPerusahaan,
-		PosisiJabatan:       req.PosisiJabatan,
-		BidangIndustri:      req.BidangIndustri,
-		LokasiKerja:         req.LokasiKerja,
-		GajiRange:           req.GajiRange,
-		TanggalMulaiKerja:   tanggalMulai,
-		TanggalSelesaiKerja: tanggalSelesai,
-		StatusPekerjaan:     req.StatusPekerjaan,
-		DeskripsiPekerjaan:  req.DeskripsiPekerjaan,
+	repoReq, ferr := pekerjaanPatchRequest(&doc)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(mongo.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    mongo.PekerjaanAlumni{},
+		})
+	}
+	if ferr := linkPekerjaanReferences(c.Context(), db, &repoReq.CompanyID, &repoReq.NamaPerusahaan, &repoReq.IndustryID, &repoReq.BidangIndustri); ferr != nil {
+		return c.Status(ferr.Code).JSON(mongo.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    mongo.PekerjaanAlumni{},
+		})
 	}
 
-	pekerjaan, err := repository.UpdatePekerjaan(db, idStr, repoReq)
+	pekerjaan, err := saveWithCareerRules(db, existing.AlumniID, updateCareerPeriod(existing.ID, repoReq), func(ctx context.Context) (*mongo.PekerjaanAlumni, error) {
+		return repository.UpdatePekerjaanCtx(ctx, db, idStr, repoReq, expectedVersion)
+	})
+	if ferr, ok := err.(*fiber.Error); ok {
+		return c.Status(ferr.Code).JSON(mongo.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    mongo.PekerjaanAlumni{},
+		})
+	}
+	if errors.Is(err, helper.ErrVersionConflict) {
+		return c.Status(fiber.StatusPreconditionFailed).JSON(mongo.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: versionConflictMessage,
+			Data:    mongo.PekerjaanAlumni{},
+		})
+	}
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(mongo.UpdatePekerjaanAlumniResponse{
 			Success: false,
@@ -396,7 +758,9 @@ func UpdatePekerjaanService(c *fiber.Ctx, db *mongoDB.Database) error {
 			Data:    mongo.PekerjaanAlumni{},
 		})
 	}
+	recordAudit(c, db, auditEntry(helper.AuditActionUpdate, helper.AuditEntityPekerjaan, idStr, existing, pekerjaan))
 
+	c.Set(fiber.HeaderETag, helper.ETag(pekerjaan.Version))
 	return c.Status(fiber.StatusOK).JSON(mongo.UpdatePekerjaanAlumniResponse{
 		Success: true,
 		Message: "Berhasil mengupdate pekerjaan",
@@ -404,6 +768,92 @@ func UpdatePekerjaanService(c *fiber.Ctx, db *mongoDB.Database) error {
 	})
 }
 
+// pekerjaanPatchDocument -> data pekerjaan saat ini dalam bentuk yang bisa di-patch
+func pekerjaanPatchDocument(existing *mongo.PekerjaanAlumni) mongo.PekerjaanPatchDocument {
+	doc := mongo.PekerjaanPatchDocument{
+		NamaPerusahaan:     existing.NamaPerusahaan,
+		PosisiJabatan:      existing.PosisiJabatan,
+		BidangIndustri:     existing.BidangIndustri,
+		LokasiKerja:        existing.LokasiKerja,
+		Negara:             existing.Negara,
+		Provinsi:           existing.Provinsi,
+		Kota:               existing.Kota,
+		Latitude:           existing.Latitude,
+		Longitude:          existing.Longitude,
+		GajiRange:          existing.GajiRange,
+		GajiMin:            existing.GajiMin,
+		GajiMax:            existing.GajiMax,
+		GajiMataUang:       existing.GajiMataUang,
+		GajiPeriode:        existing.GajiPeriode,
+		GajiRahasia:        existing.GajiRahasia,
+		TanggalMulaiKerja:  existing.TanggalMulaiKerja.Format("2006-01-02"),
+		StatusPekerjaan:    existing.StatusPekerjaan,
+		DeskripsiPekerjaan: existing.DeskripsiPekerjaan,
+		ParuhWaktu:         existing.ParuhWaktu,
+	}
+	if existing.TanggalSelesaiKerja != nil {
+		selesai := existing.TanggalSelesaiKerja.Format("2006-01-02")
+		doc.TanggalSelesaiKerja = &selesai
+	}
+	return doc
+}
+
+// pekerjaanPatchRequest -> validasi hasil patch (aturan sama seperti PUT) lalu ubah menjadi update
+// lengkap; repository menyimpan semua field sehingga nil tersimpan sebagai null
+func pekerjaanPatchRequest(doc *mongo.PekerjaanPatchDocument) (*mongo.UpdatePekerjaanAlumniRepositoryRequest, *fiber.Error) {
+	if doc.NamaPerusahaan == "" || doc.PosisiJabatan == "" || doc.BidangIndustri == "" || doc.LokasiKerja == "" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Nama perusahaan, posisi jabatan, bidang industri, dan lokasi kerja wajib diisi")
+	}
+	if doc.StatusPekerjaan != "aktif" && doc.StatusPekerjaan != "selesai" && doc.StatusPekerjaan != "resigned" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Status pekerjaan harus aktif, selesai, atau resigned")
+	}
+	tanggalMulai, err := helper.ParseDateFlexible(doc.TanggalMulaiKerja)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Format tanggal mulai kerja tidak valid. Gunakan format YYYY-MM-DD")
+	}
+	var tanggalSelesai *time.Time
+	if doc.TanggalSelesaiKerja != nil && *doc.TanggalSelesaiKerja != "" {
+		parsed, err := helper.ParseDateFlexible(*doc.TanggalSelesaiKerja)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Format tanggal selesai kerja tidak valid. Gunakan format YYYY-MM-DD")
+		}
+		if parsed.Before(tanggalMulai) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Tanggal selesai kerja tidak boleh sebelum tanggal mulai kerja")
+		}
+		tanggalSelesai = &parsed
+	}
+	salary, err := helper.ResolveSalary(doc.GajiRange, doc.GajiMin, doc.GajiMax, doc.GajiMataUang, doc.GajiPeriode)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	location, err := helper.ResolveLocation(doc.LokasiKerja, locationText(doc.Negara), locationText(doc.Provinsi), locationText(doc.Kota), doc.Latitude, doc.Longitude)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	return &mongo.UpdatePekerjaanAlumniRepositoryRequest{
+		NamaPerusahaan:      doc.NamaPerusahaan,
+		PosisiJabatan:       doc.PosisiJabatan,
+		BidangIndustri:      doc.BidangIndustri,
+		LokasiKerja:         doc.LokasiKerja,
+		Negara:              location.Negara,
+		Provinsi:            location.Provinsi,
+		Kota:                location.Kota,
+		Latitude:            location.Latitude,
+		Longitude:           location.Longitude,
+		GajiMin:             salary.Min,
+		GajiMax:             salary.Max,
+		GajiMataUang:        salary.Currency,
+		GajiPeriode:         salary.Period,
+		GajiRahasia:         doc.GajiRahasia,
+		TanggalMulaiKerja:   tanggalMulai,
+		TanggalSelesaiKerja: tanggalSelesai,
+		StatusPekerjaan:     doc.StatusPekerjaan,
+		DeskripsiPekerjaan:  doc.DeskripsiPekerjaan,
+		ParuhWaktu:          doc.ParuhWaktu,
+	}, nil
+}
+
 func DeletePekerjaanService(c *fiber.Ctx, db *mongoDB.Database) error {
 	idStr := c.Params("id")
 	if idStr == "" {
@@ -414,7 +864,7 @@ func DeletePekerjaanService(c *fiber.Ctx, db *mongoDB.Database) error {
 	}
 
 	// Check if pekerjaan exists
-	_, err := repository.GetPekerjaanByID(db, idStr)
+	existing, err := repository.GetPekerjaanByID(db, idStr)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"success": false,
@@ -429,6 +879,7 @@ func DeletePekerjaanService(c *fiber.Ctx, db *mongoDB.Database) error {
 			"message": "Gagal menghapus pekerjaan: " + err.Error(),
 		})
 	}
+	recordAudit(c, db, auditEntry(helper.AuditActionDelete, helper.AuditEntityPekerjaan, idStr, existing, nil))
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success": true,
@@ -0,0 +1,449 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"go-fiber/app/model/mongo"
+	repository "go-fiber/app/repository/mongo"
+	"go-fiber/helper"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongoDB "go.mongodb.org/mongo-driver/mongo"
+)
+
+// Data referensi perusahaan (companies) dan bidang industri (industries). Kedua jenis memakai
+// service yang sama; kind menentukan collection dan field pekerjaan yang terhubung.
+
+// JobTypeReferenceBackfill -> hubungkan pekerjaan lama ke data referensi
+const JobTypeReferenceBackfill = "reference.backfill"
+
+// referenceAutocompleteMaxLimit -> batas ?limit autocomplete
+const referenceAutocompleteMaxLimit = 50
+
+type referenceBackfillPayload struct {
+	Kind string `json:"kind"`
+}
+
+func ListReferencesService(c *fiber.Ctx, db *mongoDB.Database, kind helper.ReferenceKind) error {
+	refs, err := repository.ListReferences(db, kind, c.Query("search"))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(mongo.ListReferenceDataResponse{
+			Success: false,
+			Message: "Gagal mengambil data " + strings.ToLower(kind.Label) + ": " + err.Error(),
+			Data:    []mongo.ReferenceData{},
+		})
+	}
+	return c.JSON(mongo.ListReferenceDataResponse{
+		Success: true,
+		Message: "Berhasil mengambil data " + strings.ToLower(kind.Label),
+		Data:    refs,
+	})
+}
+
+// ReferenceAutocompleteService -> GET /:kind/autocomplete?q=: nama atau alias yang mirip q, termasuk
+// yang salah ketik, skor tertinggi lebih dulu
+func ReferenceAutocompleteService(c *fiber.Ctx, db *mongoDB.Database, kind helper.ReferenceKind) error {
+	limit := c.QueryInt("limit", 10)
+	if limit <= 0 || limit > referenceAutocompleteMaxLimit {
+		limit = 10
+	}
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(mongo.ReferenceAutocompleteResponse{
+			Success: false,
+			Message: "Parameter q wajib diisi",
+			Data:    []mongo.ReferenceMatch{},
+		})
+	}
+
+	refs, err := repository.ListReferences(db, kind, "")
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(mongo.ReferenceAutocompleteResponse{
+			Success: false,
+			Message: "Gagal mengambil data " + strings.ToLower(kind.Label) + ": " + err.Error(),
+			Data:    []mongo.ReferenceMatch{},
+		})
+	}
+	candidates := make([][]string, len(refs))
+	for i, ref := range refs {
+		candidates[i] = append([]string{ref.Nama}, ref.Aliases...)
+	}
+
+	matches := []mongo.ReferenceMatch{}
+	for _, m := range helper.RankReferences(query, candidates, limit) {
+		matches = append(matches, mongo.ReferenceMatch{
+			ReferenceData: refs[m.Index],
+			Score:         math.Round(m.Score*100) / 100,
+			Matched:       m.Matched,
+		})
+	}
+	return c.JSON(mongo.ReferenceAutocompleteResponse{
+		Success: true,
+		Message: "Berhasil mengambil saran " + strings.ToLower(kind.Label),
+		Data:    matches,
+	})
+}
+
+func GetReferenceService(c *fiber.Ctx, db *mongoDB.Database, kind helper.ReferenceKind) error {
+	ref, status, message := referenceFromParam(c, db, kind)
+	if ref == nil {
+		return c.Status(status).JSON(mongo.ReferenceDataResponse{Success: false, Message: message})
+	}
+	return c.JSON(mongo.ReferenceDataResponse{
+		Success: true,
+		Message: "Berhasil mengambil data " + strings.ToLower(kind.Label),
+		Data:    *ref,
+	})
+}
+
+func CreateReferenceService(c *fiber.Ctx, db *mongoDB.Database, kind helper.ReferenceKind) error {
+	var req mongo.ReferenceDataRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(mongo.ReferenceDataResponse{
+			Success: false,
+			Message: "Format data tidak valid: " + err.Error(),
+		})
+	}
+	ref, ferr := referenceFromRequest(&req)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(mongo.ReferenceDataResponse{Success: false, Message: ferr.Message})
+	}
+	if ferr := checkReferenceConflict(db, kind, ref); ferr != nil {
+		return c.Status(ferr.Code).JSON(mongo.ReferenceDataResponse{Success: false, Message: ferr.Message})
+	}
+
+	if err := repository.CreateReference(db, kind, ref); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(mongo.ReferenceDataResponse{
+			Success: false,
+			Message: "Gagal membuat data " + strings.ToLower(kind.Label) + ": " + err.Error(),
+		})
+	}
+	recordAudit(c, db, auditEntry(helper.AuditActionCreate, kind.AuditEntity, ref.ID.Hex(), nil, ref))
+
+	return c.Status(fiber.StatusCreated).JSON(mongo.ReferenceDataResponse{
+		Success: true,
+		Message: "Berhasil membuat data " + strings.ToLower(kind.Label),
+		Data:    *ref,
+	})
+}
+
+// UpdateReferenceService -> ganti nama dan alias. Bila nama berubah, teks bebas pekerjaan yang
+// terhubung ikut diganti.
+func UpdateReferenceService(c *fiber.Ctx, db *mongoDB.Database, kind helper.ReferenceKind) error {
+	existing, status, message := referenceFromParam(c, db, kind)
+	if existing == nil {
+		return c.Status(status).JSON(mongo.ReferenceDataResponse{Success: false, Message: message})
+	}
+
+	var req mongo.ReferenceDataRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(mongo.ReferenceDataResponse{
+			Success: false,
+			Message: "Format data tidak valid: " + err.Error(),
+		})
+	}
+	ref, ferr := referenceFromRequest(&req)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(mongo.ReferenceDataResponse{Success: false, Message: ferr.Message})
+	}
+	ref.ID, ref.CreatedAt = existing.ID, existing.CreatedAt
+	if ferr := checkReferenceConflict(db, kind, ref); ferr != nil {
+		return c.Status(ferr.Code).JSON(mongo.ReferenceDataResponse{Success: false, Message: ferr.Message})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	err := repository.UpdateReferenceCtx(ctx, db, kind, ref)
+	if err == nil && ref.Nama != existing.Nama {
+		_, err = repository.RelinkPekerjaanReferenceCtx(ctx, db, kind, []primitive.ObjectID{ref.ID}, ref)
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(mongo.ReferenceDataResponse{
+			Success: false,
+			Message: "Gagal mengupdate data " + strings.ToLower(kind.Label) + ": " + err.Error(),
+		})
+	}
+	recordAudit(c, db, auditEntry(helper.AuditActionUpdate, kind.AuditEntity, ref.ID.Hex(), existing, ref))
+
+	return c.JSON(mongo.ReferenceDataResponse{
+		Success: true,
+		Message: "Berhasil mengupdate data " + strings.ToLower(kind.Label),
+		Data:    *ref,
+	})
+}
+
+// DeleteReferenceService -> hapus data referensi; pekerjaan yang terhubung kembali memakai teks bebas
+func DeleteReferenceService(c *fiber.Ctx, db *mongoDB.Database, kind helper.ReferenceKind) error {
+	existing, status, message := referenceFromParam(c, db, kind)
+	if existing == nil {
+		return c.Status(status).JSON(mongo.ReferenceDataResponse{Success: false, Message: message})
+	}
+
+	if err := repository.UnlinkPekerjaanReference(db, kind, existing.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(mongo.ReferenceDataResponse{
+			Success: false,
+			Message: "Gagal melepas pekerjaan dari " + strings.ToLower(kind.Label) + ": " + err.Error(),
+		})
+	}
+	if err := repository.DeleteReference(db, kind, existing.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(mongo.ReferenceDataResponse{
+			Success: false,
+			Message: "Gagal menghapus data " + strings.ToLower(kind.Label) + ": " + err.Error(),
+		})
+	}
+	recordAudit(c, db, auditEntry(helper.AuditActionDelete, kind.AuditEntity, existing.ID.Hex(), existing, nil))
+
+	return c.JSON(mongo.ReferenceDataResponse{
+		Success: true,
+		Message: "Berhasil menghapus data " + strings.ToLower(kind.Label),
+		Data:    *existing,
+	})
+}
+
+// MergeReferenceService -> POST /:kind/:id/merge: gabungkan duplikat (source_ids) ke data :id.
+// Nama dan alias duplikat menjadi alias data tujuan, pekerjaannya dipindahkan, lalu duplikat dihapus.
+func MergeReferenceService(c *fiber.Ctx, db *mongoDB.Database, kind helper.ReferenceKind) error {
+	target, status, message := referenceFromParam(c, db, kind)
+	if target == nil {
+		return c.Status(status).JSON(mongo.ReferenceMergeResponse{Success: false, Message: message})
+	}
+
+	var req mongo.ReferenceMergeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(mongo.ReferenceMergeResponse{
+			Success: false,
+			Message: "Format data tidak valid: " + err.Error(),
+		})
+	}
+	if len(req.SourceIDs) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(mongo.ReferenceMergeResponse{
+			Success: false,
+			Message: "source_ids wajib diisi",
+		})
+	}
+
+	var sources []mongo.ReferenceData
+	var sourceIDs []primitive.ObjectID
+	for _, raw := range req.SourceIDs {
+		id, err := primitive.ObjectIDFromHex(raw)
+		if err != nil || id == target.ID {
+			return c.Status(fiber.StatusBadRequest).JSON(mongo.ReferenceMergeResponse{
+				Success: false,
+				Message: fmt.Sprintf("source_ids tidak valid: %s", raw),
+			})
+		}
+		source, err := repository.GetReferenceByID(db, kind, raw)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(mongo.ReferenceMergeResponse{
+				Success: false,
+				Message: "Gagal mengambil data " + strings.ToLower(kind.Label) + ": " + err.Error(),
+			})
+		}
+		if source == nil {
+			return c.Status(fiber.StatusNotFound).JSON(mongo.ReferenceMergeResponse{
+				Success: false,
+				Message: fmt.Sprintf("%s %s tidak ditemukan", kind.Label, raw),
+			})
+		}
+		sources = append(sources, *source)
+		sourceIDs = append(sourceIDs, id)
+	}
+
+	merged := *target
+	aliases := append([]string{}, target.Aliases...)
+	for _, source := range sources {
+		aliases = append(append(aliases, source.Nama), source.Aliases...)
+	}
+	merged.Aliases = helper.CleanReferenceAliases(merged.Nama, aliases)
+	merged.Keys = helper.ReferenceKeys(merged.Nama, merged.Aliases)
+
+	var relinked int64
+	err := repository.RunInTransaction(db, func(ctx context.Context) error {
+		// Duplikat dihapus lebih dulu agar unique index keys tidak bentrok dengan alias baru
+		if err := repository.DeleteReferencesCtx(ctx, db, kind, sourceIDs); err != nil {
+			return err
+		}
+		if err := repository.UpdateReferenceCtx(ctx, db, kind, &merged); err != nil {
+			return err
+		}
+		n, err := repository.RelinkPekerjaanReferenceCtx(ctx, db, kind, sourceIDs, &merged)
+		relinked = n
+		return err
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(mongo.ReferenceMergeResponse{
+			Success: false,
+			Message: "Gagal menggabungkan data " + strings.ToLower(kind.Label) + ": " + err.Error(),
+		})
+	}
+	recordAudit(c, db, auditEntry(helper.AuditActionMerge, kind.AuditEntity, merged.ID.Hex(), target, &merged))
+	for i := range sources {
+		recordAudit(c, db, auditEntry(helper.AuditActionMerge, kind.AuditEntity, sources[i].ID.Hex(), &sources[i], nil))
+	}
+
+	return c.JSON(mongo.ReferenceMergeResponse{
+		Success: true,
+		Message: fmt.Sprintf("Berhasil menggabungkan %d data %s", len(sources), strings.ToLower(kind.Label)),
+		Data: mongo.ReferenceMergeResult{
+			Reference: merged,
+			Merged:    len(sources),
+			Relinked:  relinked,
+		},
+	})
+}
+
+// BackfillReferenceService -> POST /:kind/backfill: jalankan job reference.backfill di background
+func BackfillReferenceService(c *fiber.Ctx, db *mongoDB.Database, kind helper.ReferenceKind) error {
+	job, err := EnqueueJob(db, JobTypeReferenceBackfill, referenceBackfillPayload{Kind: kind.Name}, JobOptions{MaxAttempts: 1})
+	if err != nil {
+		return jobError(c, fiber.StatusInternalServerError, "Gagal menjadwalkan backfill: "+err.Error())
+	}
+	return c.Status(fiber.StatusAccepted).JSON(mongo.JobResponse{
+		Success: true,
+		Message: "Backfill berjalan di background, cek progress di /jobs/" + job.ID.Hex(),
+		Data:    *job,
+	})
+}
+
+func registerReferenceJobs(r *JobRunner) {
+	RegisterJobHandler(r, JobTypeReferenceBackfill, func(ctx context.Context, p referenceBackfillPayload) error {
+		kind, ok := helper.ReferenceKindByName(p.Kind)
+		if !ok {
+			return PermanentJobError(fmt.Errorf("jenis data referensi tidak dikenal: %q", p.Kind))
+		}
+		created, linked, err := backfillReferences(ctx, r.db, kind)
+		if created > 0 || linked > 0 {
+			log.Printf("Reference backfill %s: %d data baru, %d pekerjaan dihubungkan", kind.Name, created, linked)
+		}
+		return err
+	})
+}
+
+// backfillReferences -> hubungkan pekerjaan yang belum punya referensi. Teks bebas yang kuncinya
+// belum dikenal dibuatkan data referensi baru; ejaan yang paling sering dipakai menjadi namanya.
+func backfillReferences(ctx context.Context, db *mongoDB.Database, kind helper.ReferenceKind) (created int, linked int64, err error) {
+	texts, err := repository.UnlinkedPekerjaanTextsCtx(ctx, db, kind)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, text := range texts {
+		key := helper.NormalizeReferenceName(text)
+		if key == "" {
+			continue
+		}
+		ref, err := repository.GetReferenceByKeyCtx(ctx, db, kind, key)
+		if err != nil {
+			return created, linked, err
+		}
+		if ref == nil {
+			nama := strings.Join(strings.Fields(text), " ")
+			ref = &mongo.ReferenceData{Nama: nama, Aliases: []string{}, Keys: []string{key}}
+			if err := repository.CreateReferenceCtx(ctx, db, kind, ref); err != nil {
+				return created, linked, err
+			}
+			created++
+		}
+		n, err := repository.LinkPekerjaanByTextCtx(ctx, db, kind, text, ref)
+		if err != nil {
+			return created, linked, err
+		}
+		linked += n
+	}
+	return created, linked, nil
+}
+
+// linkPekerjaanReferences -> hubungkan pekerjaan ke perusahaan dan bidang industri. ID dari client
+// diutamakan; tanpa ID, teks bebas dicocokkan dengan nama / alias. Teks yang cocok diganti nama
+// resminya, teks yang belum dikenal disimpan apa adanya tanpa ID.
+func linkPekerjaanReferences(ctx context.Context, db *mongoDB.Database, companyID **primitive.ObjectID, namaPerusahaan *string, industryID **primitive.ObjectID, bidangIndustri *string) *fiber.Error {
+	links := []struct {
+		kind helper.ReferenceKind
+		id   **primitive.ObjectID
+		text *string
+	}{
+		{helper.ReferenceCompanies, companyID, namaPerusahaan},
+		{helper.ReferenceIndustries, industryID, bidangIndustri},
+	}
+	for _, link := range links {
+		var ref *mongo.ReferenceData
+		var err error
+		if *link.id != nil {
+			ref, err = repository.GetReferenceByIDCtx(ctx, db, link.kind, **link.id)
+			if err == nil && ref == nil {
+				return fiber.NewError(fiber.StatusBadRequest, link.kind.Label+" tidak ditemukan")
+			}
+		} else if key := helper.NormalizeReferenceName(*link.text); key != "" {
+			ref, err = repository.GetReferenceByKeyCtx(ctx, db, link.kind, key)
+		}
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, "Gagal mengambil data "+strings.ToLower(link.kind.Label)+": "+err.Error())
+		}
+		if ref != nil {
+			*link.id, *link.text = &ref.ID, ref.Nama
+		}
+	}
+	return nil
+}
+
+// referenceIDParam -> ObjectID opsional dari request pekerjaan (company_id / industry_id)
+func referenceIDParam(raw *string, field string) (*primitive.ObjectID, *fiber.Error) {
+	if raw == nil || *raw == "" {
+		return nil, nil
+	}
+	id, err := primitive.ObjectIDFromHex(*raw)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, field+" tidak valid")
+	}
+	return &id, nil
+}
+
+// referenceFromRequest -> validasi nama dan rapikan alias
+func referenceFromRequest(req *mongo.ReferenceDataRequest) (*mongo.ReferenceData, *fiber.Error) {
+	nama := strings.Join(strings.Fields(req.Nama), " ")
+	if nama == "" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Nama wajib diisi")
+	}
+	if helper.NormalizeReferenceName(nama) == "" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Nama harus mengandung huruf atau angka")
+	}
+	aliases := helper.CleanReferenceAliases(nama, req.Aliases)
+	return &mongo.ReferenceData{
+		Nama:    nama,
+		Aliases: aliases,
+		Keys:    helper.ReferenceKeys(nama, aliases),
+	}, nil
+}
+
+// checkReferenceConflict -> 409 bila nama / alias ref sudah dipakai data referensi lain
+func checkReferenceConflict(db *mongoDB.Database, kind helper.ReferenceKind, ref *mongo.ReferenceData) *fiber.Error {
+	other, err := repository.GetReferenceConflict(db, kind, ref.Keys, ref.ID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "Gagal memeriksa data "+strings.ToLower(kind.Label)+": "+err.Error())
+	}
+	if other != nil {
+		return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Nama atau alias sudah dipakai %s %q (%s); gunakan merge untuk menggabungkan", strings.ToLower(kind.Label), other.Nama, other.ID.Hex()))
+	}
+	return nil
+}
+
+// referenceFromParam -> data referensi dari :id; nil beserta status dan pesan error bila gagal
+func referenceFromParam(c *fiber.Ctx, db *mongoDB.Database, kind helper.ReferenceKind) (*mongo.ReferenceData, int, string) {
+	id := c.Params("id")
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return nil, fiber.StatusBadRequest, "Format ID tidak valid"
+	}
+	ref, err := repository.GetReferenceByID(db, kind, id)
+	if err != nil {
+		return nil, fiber.StatusInternalServerError, "Gagal mengambil data " + strings.ToLower(kind.Label) + ": " + err.Error()
+	}
+	if ref == nil {
+		return nil, fiber.StatusNotFound, kind.Label + " tidak ditemukan"
+	}
+	return ref, 0, ""
+}
@@ -1,10 +1,13 @@
 package mongo
 
 import (
+	"errors"
 	"go-fiber/app/model/mongo"
 	repository "go-fiber/app/repository/mongo"
+	"go-fiber/helper"
 
 	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	mongoDB "go.mongodb.org/mongo-driver/mongo"
 )
 
@@ -32,6 +35,7 @@ func CreateRoleService(c *fiber.Ctx, db *mongoDB.Database) error {
 			Data:    mongo.Role{},
 		})
 	}
+	recordAudit(c, db, auditEntry(helper.AuditActionCreate, helper.AuditEntityRole, role.ID.Hex(), nil, role))
 	return c.Status(fiber.StatusCreated).JSON(mongo.CreateRoleResponse{
 		Success: true,
 		Message: "Berhasil membuat role",
@@ -63,6 +67,7 @@ func GetRoleByIDService(c *fiber.Ctx, db *mongoDB.Database) error {
 			Data:    mongo.Role{},
 		})
 	}
+	c.Set(fiber.HeaderETag, helper.ETag(role.Version))
 	return c.JSON(mongo.GetRoleByIDResponse{
 		Success: true,
 		Message: "Berhasil mengambil role",
@@ -95,6 +100,14 @@ func UpdateRoleService(c *fiber.Ctx, db *mongoDB.Database) error {
 			Data:    mongo.Role{},
 		})
 	}
+	expectedVersion, ferr := ifMatchVersion(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(mongo.UpdateRoleResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    mongo.Role{},
+		})
+	}
 	var req mongo.UpdateRoleRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(mongo.UpdateRoleResponse{
@@ -103,7 +116,36 @@ func UpdateRoleService(c *fiber.Ctx, db *mongoDB.Database) error {
 			Data:    mongo.Role{},
 		})
 	}
-	role, err := repository.UpdateRole(db, id, &req)
+	existing, err := repository.GetRoleByID(db, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(mongo.UpdateRoleResponse{
+			Success: false,
+			Message: "Gagal mengambil role",
+			Data:    mongo.Role{},
+		})
+	}
+	if existing == nil {
+		return c.Status(fiber.StatusNotFound).JSON(mongo.UpdateRoleResponse{
+			Success: false,
+			Message: "Role tidak ditemukan",
+			Data:    mongo.Role{},
+		})
+	}
+	if versionMismatch(c, expectedVersion, existing.Version) {
+		return c.Status(fiber.StatusPreconditionFailed).JSON(mongo.UpdateRoleResponse{
+			Success: false,
+			Message: versionConflictMessage,
+			Data:    mongo.Role{},
+		})
+	}
+	role, err := repository.UpdateRole(db, id, &req, expectedVersion)
+	if errors.Is(err, helper.ErrVersionConflict) {
+		return c.Status(fiber.StatusPreconditionFailed).JSON(mongo.UpdateRoleResponse{
+			Success: false,
+			Message: versionConflictMessage,
+			Data:    mongo.Role{},
+		})
+	}
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(mongo.UpdateRoleResponse{
 			Success: false,
@@ -118,6 +160,95 @@ func UpdateRoleService(c *fiber.Ctx, db *mongoDB.Database) error {
 			Data:    mongo.Role{},
 		})
 	}
+	recordAudit(c, db, auditEntry(helper.AuditActionUpdate, helper.AuditEntityRole, id, existing, role))
+	c.Set(fiber.HeaderETag, helper.ETag(role.Version))
+	return c.JSON(mongo.UpdateRoleResponse{
+		Success: true,
+		Message: "Berhasil mengupdate role",
+		Data:    *role,
+	})
+}
+
+// PatchRoleService -> ubah role dengan JSON Merge Patch (RFC 7396) atau JSON Patch (RFC 6902)
+func PatchRoleService(c *fiber.Ctx, db *mongoDB.Database) error {
+	id := c.Params("id")
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(mongo.UpdateRoleResponse{
+			Success: false,
+			Message: "ID tidak valid",
+			Data:    mongo.Role{},
+		})
+	}
+	format, ferr := patchFormat(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(mongo.UpdateRoleResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    mongo.Role{},
+		})
+	}
+	expectedVersion, ferr := ifMatchVersion(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(mongo.UpdateRoleResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    mongo.Role{},
+		})
+	}
+	existing, err := repository.GetRoleByID(db, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(mongo.UpdateRoleResponse{
+			Success: false,
+			Message: "Gagal mengambil role",
+			Data:    mongo.Role{},
+		})
+	}
+	if existing == nil {
+		return c.Status(fiber.StatusNotFound).JSON(mongo.UpdateRoleResponse{
+			Success: false,
+			Message: "Role tidak ditemukan",
+			Data:    mongo.Role{},
+		})
+	}
+	if versionMismatch(c, expectedVersion, existing.Version) {
+		return c.Status(fiber.StatusPreconditionFailed).JSON(mongo.UpdateRoleResponse{
+			Success: false,
+			Message: versionConflictMessage,
+			Data:    mongo.Role{},
+		})
+	}
+	var doc mongo.RolePatchDocument
+	if ferr := applyPatch(c, format, mongo.RolePatchDocument{Name: existing.Name}, &doc); ferr != nil {
+		return c.Status(ferr.Code).JSON(mongo.UpdateRoleResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    mongo.Role{},
+		})
+	}
+	if doc.Name == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(mongo.UpdateRoleResponse{
+			Success: false,
+			Message: "Nama role wajib diisi",
+			Data:    mongo.Role{},
+		})
+	}
+	role, err := repository.UpdateRole(db, id, &mongo.UpdateRoleRequest{Name: &doc.Name}, expectedVersion)
+	if errors.Is(err, helper.ErrVersionConflict) {
+		return c.Status(fiber.StatusPreconditionFailed).JSON(mongo.UpdateRoleResponse{
+			Success: false,
+			Message: versionConflictMessage,
+			Data:    mongo.Role{},
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(mongo.UpdateRoleResponse{
+			Success: false,
+			Message: "Gagal mengupdate role",
+			Data:    mongo.Role{},
+		})
+	}
+	recordAudit(c, db, auditEntry(helper.AuditActionUpdate, helper.AuditEntityRole, id, existing, role))
+	c.Set(fiber.HeaderETag, helper.ETag(role.Version))
 	return c.JSON(mongo.UpdateRoleResponse{
 		Success: true,
 		Message: "Berhasil mengupdate role",
@@ -133,12 +264,20 @@ func DeleteRoleService(c *fiber.Ctx, db *mongoDB.Database) error {
 			Message: "ID tidak valid",
 		})
 	}
+	existing, err := repository.GetRoleByID(db, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(mongo.DeleteRoleResponse{
+			Success: false,
+			Message: "Gagal mengambil role",
+		})
+	}
 	if err := repository.DeleteRole(db, id); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(mongo.DeleteRoleResponse{
 			Success: false,
 			Message: "Gagal menghapus role",
 		})
 	}
+	recordAudit(c, db, auditEntry(helper.AuditActionDelete, helper.AuditEntityRole, id, existing, nil))
 	return c.JSON(mongo.DeleteRoleResponse{
 		Success: true,
 		Message: "Berhasil menghapus role",
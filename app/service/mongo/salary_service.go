@@ -0,0 +1,85 @@
+package mongo
+
+import (
+	"context"
+	"go-fiber/app/model/mongo"
+	repository "go-fiber/app/repository/mongo"
+	"go-fiber/helper"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongoDB "go.mongodb.org/mongo-driver/mongo"
+)
+
+// JobTypeSalaryMigrate -> ubah teks gaji_range lama menjadi gaji terstruktur
+const JobTypeSalaryMigrate = "pekerjaan.salary_migrate"
+
+// canSeeSalary -> nominal gaji yang dirahasiakan hanya untuk admin dan alumni pemiliknya
+func canSeeSalary(c *fiber.Ctx, alumniID primitive.ObjectID) bool {
+	if role, _ := c.Locals("role").(string); role == "admin" {
+		return true
+	}
+	userID, ok := c.Locals("user_id").(string)
+	return ok && userID == alumniID.Hex()
+}
+
+// maskSalary -> kosongkan nominal gaji pekerjaan yang dirahasiakan bila c tidak berhak melihatnya;
+// mata uang, periode, dan flag gaji_rahasia tetap dikirim
+func maskSalary(c *fiber.Ctx, p *mongo.PekerjaanAlumni) {
+	if p.GajiRahasia && !canSeeSalary(c, p.AlumniID) {
+		p.GajiMin, p.GajiMax, p.GajiRange = nil, nil, nil
+	}
+}
+
+// MigrateSalaryService -> POST /pekerjaan/gaji/migrate: jalankan job pekerjaan.salary_migrate di background
+func MigrateSalaryService(c *fiber.Ctx, db *mongoDB.Database) error {
+	job, err := EnqueueJob(db, JobTypeSalaryMigrate, struct{}{}, JobOptions{MaxAttempts: 1})
+	if err != nil {
+		return jobError(c, fiber.StatusInternalServerError, "Gagal menjadwalkan migrasi gaji: "+err.Error())
+	}
+	return c.Status(fiber.StatusAccepted).JSON(mongo.JobResponse{
+		Success: true,
+		Message: "Migrasi gaji berjalan di background, cek progress di /jobs/" + job.ID.Hex(),
+		Data:    *job,
+	})
+}
+
+func registerSalaryJobs(r *JobRunner) {
+	RegisterJobHandler(r, JobTypeSalaryMigrate, func(ctx context.Context, _ struct{}) error {
+		migrated, skipped, err := migrateSalaries(ctx, r.db)
+		if migrated > 0 || skipped > 0 {
+			log.Printf("Salary migrate: %d pekerjaan dimigrasi, %d teks gaji tidak dikenali", migrated, skipped)
+		}
+		return err
+	})
+}
+
+// migrateSalaries -> parse gaji_range setiap pekerjaan yang belum punya gaji terstruktur. Teks yang
+// tidak dikenali dibiarkan agar bisa diperbaiki manual lewat PUT / PATCH.
+func migrateSalaries(ctx context.Context, db *mongoDB.Database) (migrated, skipped int, err error) {
+	legacy, err := repository.ListLegacySalaryPekerjaan(ctx, db)
+	if err != nil {
+		return 0, 0, err
+	}
+	for id, text := range legacy {
+		if err := ctx.Err(); err != nil {
+			return migrated, skipped, err
+		}
+		salary, err := helper.ResolveSalary(&text, nil, nil, "", "")
+		if err != nil {
+			log.Printf("Salary migrate: pekerjaan %s dilewati: %v", id.Hex(), err)
+			skipped++
+			continue
+		}
+		ok, err := repository.MigratePekerjaanSalary(ctx, db, id, text, salary)
+		if err != nil {
+			return migrated, skipped, err
+		}
+		if ok {
+			migrated++
+		}
+	}
+	return migrated, skipped, nil
+}
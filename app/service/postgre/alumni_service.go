@@ -2,11 +2,12 @@ package postgre
 
 import (
 	"database/sql"
+	"errors"
 	model "go-fiber/app/model/postgre"
 	repository "go-fiber/app/repository/postgre"
+	"go-fiber/helper"
 	utils "go-fiber/utils/postgre"
 	"log"
-	"os"
 	"strconv"
 	"strings"
 
@@ -23,65 +24,107 @@ func GetAllAlumniService(c *fiber.Ctx, db *sql.DB) error {
 	}
 
 	// Parse query parameters
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	limit, _ := strconv.Atoi(c.Query("limit", "10"))
 	sortBy := c.Query("sortBy", "id")
 	order := c.Query("order", "asc")
 	search := c.Query("search", "")
 
-	// Debug logging
-	log.Printf("=== Service Debug ===")
-	log.Printf("Service received parameters - page: %d, limit: %d, sortBy: '%s', order: '%s', search: '%s' (len: %d)", page, limit, sortBy, order, search, len(search))
-	log.Printf("Search is empty: %t", search == "")
-
-	// Hitung offset untuk pagination
-	offset := (page - 1) * limit
-
 	// Validasi input
-	sortByWhitelist := map[string]bool{"id": true, "nama": true, "email": true, "jurusan": true, "angkatan": true, "tahun_lulus": true, "created_at": true}
-	if !sortByWhitelist[sortBy] {
+	if _, ok := repository.AlumniSortTypes[sortBy]; !ok {
 		sortBy = "id"
 	}
 	if strings.ToLower(order) != "desc" {
 		order = "asc"
 	}
 
-	// Ambil data dari repository
-	alumni, err := repository.GetAlumniRepo(db, search, sortBy, order, limit, offset)
+	filters, err := helper.ParseFilters(c.Queries(), repository.AlumniFilterFields)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"error": "Failed to fetch alumni",
+		return c.Status(400).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	page, err := parseListPage(c, sortBy, order, repository.AlumniSortTypes[sortBy], helper.FilterTypeInt)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{
+			"error": err.Error(),
 		})
 	}
 
-	total, err := repository.CountAlumniRepo(db, search)
+	// Ambil data dari repository: mode cursor tanpa OFFSET, mode page tetap pakai offset
+	var alumni []model.Alumni
+	var hasMore bool
+	if page.Keyset != nil {
+		alumni, hasMore, err = repository.GetAlumniKeysetRepo(db, search, filters, sortBy, order, page.Keyset, page.Limit)
+	} else {
+		alumni, err = repository.GetAlumniRepo(db, search, filters, sortBy, order, page.Limit, page.Offset)
+	}
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{
-			"error": "Failed to count alumni",
+			"error": "Failed to fetch alumni",
 		})
 	}
 
+	// Total selalu dihitung pada mode page, pada mode cursor hanya bila with_total=true
+	var total *int
+	if page.Keyset == nil || page.WithTotal {
+		count, err := repository.CountAlumniRepo(db, search, filters)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{
+				"error": "Failed to count alumni",
+			})
+		}
+		total = &count
+		if page.Keyset == nil {
+			hasMore = page.Offset+len(alumni) < count
+		}
+	}
+
+	var first, last *helper.CursorPosition
+	if len(alumni) > 0 {
+		first = alumniPosition(alumni[0], sortBy)
+		last = alumniPosition(alumni[len(alumni)-1], sortBy)
+	}
+
+	meta := page.meta(total, hasMore, first, last)
+	meta.SortBy = sortBy
+	meta.Order = order
+	meta.Search = search
+
 	// Buat response pakai model
 	response := model.GetAllAlumniResponse{
 		Success: true,
 		Message: "Berhasil mengambil data alumni",
 		Data: model.AlumniData{
 			Items: alumni,
-			Meta: model.MetaInfo{
-				Page:   page,
-				Limit:  limit,
-				Total:  total,
-				Pages:  (total + limit - 1) / limit,
-				SortBy: sortBy,
-				Order:  order,
-				Search: search,
-			},
+			Meta:  meta,
 		},
 	}
 
 	return c.JSON(response)
 }
 
+// alumniPosition -> nilai kolom sort + id alumni untuk membentuk cursor
+func alumniPosition(a model.Alumni, sortBy string) *helper.CursorPosition {
+	var value interface{}
+	switch sortBy {
+	case "nama":
+		value = a.Nama
+	case "email":
+		value = a.Email
+	case "jurusan":
+		value = a.Jurusan
+	case "angkatan":
+		value = a.Angkatan
+	case "tahun_lulus":
+		value = a.TahunLulus
+	case "created_at":
+		value = a.CreatedAt
+	default:
+		value = a.ID
+	}
+	return &helper.CursorPosition{Value: value, ID: a.ID}
+}
+
 func GetAlumniByIDService(c *fiber.Ctx, db *sql.DB) error {
 	idStr := c.Params("id")
 	id, err := strconv.Atoi(idStr)
@@ -109,6 +152,7 @@ func GetAlumniByIDService(c *fiber.Ctx, db *sql.DB) error {
 		})
 	}
 
+	c.Set(fiber.HeaderETag, helper.ETag(alumni.Version))
 	return c.Status(fiber.StatusOK).JSON(model.GetAlumniByIDResponse{
 		Success: true,
 		Message: "Berhasil mengambil data alumni",
@@ -116,6 +160,42 @@ func GetAlumniByIDService(c *fiber.Ctx, db *sql.DB) error {
 	})
 }
 
+// alumniCreateRequest -> validasi request create alumni dan hash password-nya; dipakai create
+// biasa dan batch
+func alumniCreateRequest(req *model.CreateAlumniRequest) (*model.CreateAlumniRepositoryRequest, *fiber.Error) {
+	if req.NIM == "" || req.Nama == "" || req.Jurusan == "" || req.Email == "" || req.Password == "" || req.RoleID == 0 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "NIM, nama, jurusan, email, password, dan role_id wajib diisi")
+	}
+	if req.Angkatan <= 0 || req.TahunLulus <= 0 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Angkatan dan tahun lulus harus lebih dari 0")
+	}
+	if err := utils.ValidatePassword(req.Password, req.Email, req.NIM); err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Password tidak memenuhi kebijakan: "+err.Error())
+	}
+	tanggalLahir, ferr := parseTanggalLahir(req.TanggalLahir)
+	if ferr != nil {
+		return nil, ferr
+	}
+	hashed, err := utils.HashPassword(req.Password)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Gagal memproses password")
+	}
+
+	return &model.CreateAlumniRepositoryRequest{
+		NIM:          req.NIM,
+		Nama:         req.Nama,
+		Jurusan:      req.Jurusan,
+		Angkatan:     req.Angkatan,
+		TahunLulus:   req.TahunLulus,
+		Email:        req.Email,
+		Password:     hashed,
+		RoleID:       req.RoleID,
+		NoTelepon:    req.NoTelepon,
+		Alamat:       req.Alamat,
+		TanggalLahir: tanggalLahir,
+	}, nil
+}
+
 func CreateAlumniService(c *fiber.Ctx, db *sql.DB) error {
 	var req model.CreateAlumniRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -126,53 +206,49 @@ func CreateAlumniService(c *fiber.Ctx, db *sql.DB) error {
 		})
 	}
 
-	if req.NIM == "" || req.Nama == "" || req.Jurusan == "" || req.Email == "" || req.Password == "" || req.RoleID == 0 {
-		return c.Status(fiber.StatusBadRequest).JSON(model.CreateAlumniResponse{
+	repoReq, ferr := alumniCreateRequest(&req)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(model.CreateAlumniResponse{
 			Success: false,
-			Message: "NIM, nama, jurusan, email, password, dan role_id wajib diisi",
+			Message: ferr.Message,
 			Data:    model.Alumni{},
 		})
 	}
 
-	if req.Angkatan <= 0 || req.TahunLulus <= 0 {
-		return c.Status(fiber.StatusBadRequest).JSON(model.CreateAlumniResponse{
+	// Alumni dan email pemberitahuan akun disimpan dalam satu transaksi
+	tx, err := db.Begin()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.CreateAlumniResponse{
 			Success: false,
-			Message: "Angkatan dan tahun lulus harus lebih dari 0",
+			Message: "Gagal membuat alumni: " + err.Error(),
 			Data:    model.Alumni{},
 		})
 	}
+	defer tx.Rollback()
 
-	hashed, err := utils.HashPassword(req.Password)
+	alumni, err := repository.CreateAlumni(tx, repoReq)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(model.CreateAlumniResponse{
 			Success: false,
-			Message: "Gagal memproses password",
+			Message: "Gagal membuat alumni: " + err.Error(),
 			Data:    model.Alumni{},
 		})
 	}
-
-	// Konversi ke repository request
-	repoReq := &model.CreateAlumniRepositoryRequest{
-		NIM:        req.NIM,
-		Nama:       req.Nama,
-		Jurusan:    req.Jurusan,
-		Angkatan:   req.Angkatan,
-		TahunLulus: req.TahunLulus,
-		Email:      req.Email,
-		Password:   hashed,
-		RoleID:     req.RoleID,
-		NoTelepon:  req.NoTelepon,
-		Alamat:     req.Alamat,
+	if err := enqueueAccountCreated(tx, alumni); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.CreateAlumniResponse{
+			Success: false,
+			Message: "Gagal menyimpan email notifikasi: " + err.Error(),
+			Data:    model.Alumni{},
+		})
 	}
-
-	alumni, err := repository.CreateAlumni(db, repoReq)
-	if err != nil {
+	if err := tx.Commit(); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(model.CreateAlumniResponse{
 			Success: false,
 			Message: "Gagal membuat alumni: " + err.Error(),
 			Data:    model.Alumni{},
 		})
 	}
+	recordAudit(c, db, auditEntry(helper.AuditActionCreate, helper.AuditEntityAlumni, strconv.Itoa(alumni.ID), nil, alumni))
 
 	return c.Status(fiber.StatusCreated).JSON(model.CreateAlumniResponse{
 		Success: true,
@@ -192,6 +268,15 @@ func UpdateAlumniService(c *fiber.Ctx, db *sql.DB) error {
 		})
 	}
 
+	expectedVersion, ferr := ifMatchVersion(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(model.UpdateAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    model.Alumni{},
+		})
+	}
+
 	var req model.UpdateAlumniRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(model.UpdateAlumniResponse{
@@ -200,9 +285,17 @@ func UpdateAlumniService(c *fiber.Ctx, db *sql.DB) error {
 			Data:    model.Alumni{},
 		})
 	}
+	tanggalLahir, ferr := parseTanggalLahir(req.TanggalLahir)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(model.UpdateAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    model.Alumni{},
+		})
+	}
 
 	// Check if alumni exists
-	_, err = repository.GetAlumniByID(db, id)
+	existing, err := repository.GetAlumniByID(db, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return c.Status(fiber.StatusNotFound).JSON(model.UpdateAlumniResponse{
@@ -217,21 +310,48 @@ func UpdateAlumniService(c *fiber.Ctx, db *sql.DB) error {
 			Data:    model.Alumni{},
 		})
 	}
+	if versionMismatch(c, expectedVersion, existing.Version) {
+		return c.Status(fiber.StatusPreconditionFailed).JSON(model.UpdateAlumniResponse{
+			Success: false,
+			Message: versionConflictMessage,
+			Data:    model.Alumni{},
+		})
+	}
 
 	// Hash password if provided
 	repoReq := &model.UpdateAlumniRepositoryRequest{
-		NIM:        req.NIM,
-		Nama:       req.Nama,
-		Jurusan:    req.Jurusan,
-		Angkatan:   req.Angkatan,
-		TahunLulus: req.TahunLulus,
-		Email:      req.Email,
-		RoleID:     req.RoleID,
-		NoTelepon:  req.NoTelepon,
-		Alamat:     req.Alamat,
+		NIM:          req.NIM,
+		Nama:         req.Nama,
+		Jurusan:      req.Jurusan,
+		Angkatan:     req.Angkatan,
+		TahunLulus:   req.TahunLulus,
+		Email:        req.Email,
+		RoleID:       req.RoleID,
+		NoTelepon:    req.NoTelepon,
+		Alamat:       req.Alamat,
+		TanggalLahir: tanggalLahir,
 	}
 
 	if req.Password != nil && *req.Password != "" {
+		// Password tidak boleh sama dengan email/NIM lama maupun yang baru dikirim
+		identifiers := []string{}
+		if existing != nil {
+			identifiers = append(identifiers, existing.Email, existing.NIM)
+		}
+		if req.Email != nil {
+			identifiers = append(identifiers, *req.Email)
+		}
+		if req.NIM != nil {
+			identifiers = append(identifiers, *req.NIM)
+		}
+		if err := utils.ValidatePassword(*req.Password, identifiers...); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(model.UpdateAlumniResponse{
+				Success: false,
+				Message: "Password tidak memenuhi kebijakan: " + err.Error(),
+				Data:    model.Alumni{},
+			})
+		}
+
 		hashed, err := utils.HashPassword(*req.Password)
 		if err != nil {
 			return c.Status(fiber.StatusInternalServerError).JSON(model.UpdateAlumniResponse{
@@ -243,7 +363,14 @@ func UpdateAlumniService(c *fiber.Ctx, db *sql.DB) error {
 		repoReq.Password = &hashed
 	}
 
-	alumni, err := repository.UpdateAlumni(db, id, repoReq)
+	alumni, err := repository.UpdateAlumni(db, id, repoReq, expectedVersion)
+	if errors.Is(err, helper.ErrVersionConflict) {
+		return c.Status(fiber.StatusPreconditionFailed).JSON(model.UpdateAlumniResponse{
+			Success: false,
+			Message: versionConflictMessage,
+			Data:    model.Alumni{},
+		})
+	}
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(model.UpdateAlumniResponse{
 			Success: false,
@@ -251,7 +378,14 @@ func UpdateAlumniService(c *fiber.Ctx, db *sql.DB) error {
 			Data:    model.Alumni{},
 		})
 	}
+	entry := auditEntry(helper.AuditActionUpdate, helper.AuditEntityAlumni, idStr, existing, alumni)
+	if repoReq.Password != nil {
+		// Password tidak pernah masuk snapshot; cukup catat bahwa ia diganti
+		entry.Metadata = map[string]any{"password_changed": true}
+	}
+	recordAudit(c, db, entry)
 
+	c.Set(fiber.HeaderETag, helper.ETag(alumni.Version))
 	return c.Status(fiber.StatusOK).JSON(model.UpdateAlumniResponse{
 		Success: true,
 		Message: "Berhasil mengupdate alumni",
@@ -259,6 +393,163 @@ func UpdateAlumniService(c *fiber.Ctx, db *sql.DB) error {
 	})
 }
 
+// PatchAlumniService -> ubah sebagian alumni dengan JSON Merge Patch (RFC 7396) atau JSON Patch
+// (RFC 6902). Patch diterapkan ke data saat ini, hasilnya divalidasi seperti create lalu disimpan;
+// null / remove pada no_telepon dan alamat mengosongkan kolom tersebut.
+func PatchAlumniService(c *fiber.Ctx, db *sql.DB) error {
+	idStr := c.Params("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.UpdateAlumniResponse{
+			Success: false,
+			Message: "ID tidak valid",
+			Data:    model.Alumni{},
+		})
+	}
+
+	format, ferr := patchFormat(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(model.UpdateAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    model.Alumni{},
+		})
+	}
+
+	expectedVersion, ferr := ifMatchVersion(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(model.UpdateAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    model.Alumni{},
+		})
+	}
+
+	existing, err := repository.GetAlumniByID(db, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return c.Status(fiber.StatusNotFound).JSON(model.UpdateAlumniResponse{
+				Success: false,
+				Message: "Alumni tidak ditemukan",
+				Data:    model.Alumni{},
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.UpdateAlumniResponse{
+			Success: false,
+			Message: "Gagal mengambil data alumni: " + err.Error(),
+			Data:    model.Alumni{},
+		})
+	}
+	if versionMismatch(c, expectedVersion, existing.Version) {
+		return c.Status(fiber.StatusPreconditionFailed).JSON(model.UpdateAlumniResponse{
+			Success: false,
+			Message: versionConflictMessage,
+			Data:    model.Alumni{},
+		})
+	}
+
+	var doc model.AlumniPatchDocument
+	if ferr := applyPatch(c, format, alumniPatchDocument(existing), &doc); ferr != nil {
+		return c.Status(ferr.Code).JSON(model.UpdateAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    model.Alumni{},
+		})
+	}
+	repoReq, ferr := alumniPatchRequest(existing, &doc)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(model.UpdateAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    model.Alumni{},
+		})
+	}
+
+	alumni, err := repository.UpdateAlumni(db, id, repoReq, expectedVersion)
+	if errors.Is(err, helper.ErrVersionConflict) {
+		return c.Status(fiber.StatusPreconditionFailed).JSON(model.UpdateAlumniResponse{
+			Success: false,
+			Message: versionConflictMessage,
+			Data:    model.Alumni{},
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.UpdateAlumniResponse{
+			Success: false,
+			Message: "Gagal mengupdate alumni: " + err.Error(),
+			Data:    model.Alumni{},
+		})
+	}
+	entry := auditEntry(helper.AuditActionUpdate, helper.AuditEntityAlumni, idStr, existing, alumni)
+	if repoReq.Password != nil {
+		entry.Metadata = map[string]any{"password_changed": true}
+	}
+	recordAudit(c, db, entry)
+
+	c.Set(fiber.HeaderETag, helper.ETag(alumni.Version))
+	return c.Status(fiber.StatusOK).JSON(model.UpdateAlumniResponse{
+		Success: true,
+		Message: "Berhasil mengupdate alumni",
+		Data:    *alumni,
+	})
+}
+
+// alumniPatchDocument -> data alumni saat ini dalam bentuk yang bisa di-patch
+func alumniPatchDocument(existing *model.Alumni) model.AlumniPatchDocument {
+	return model.AlumniPatchDocument{
+		NIM:        existing.NIM,
+		Nama:       existing.Nama,
+		Jurusan:    existing.Jurusan,
+		Angkatan:   existing.Angkatan,
+		TahunLulus: existing.TahunLulus,
+		Email:      existing.Email,
+		RoleID:     existing.RoleID,
+		NoTelepon:  existing.NoTelepon,
+		Alamat:     existing.Alamat,
+	}
+}
+
+// alumniPatchRequest -> validasi hasil patch (aturan sama seperti create) lalu ubah menjadi update
+// lengkap; kolom opsional yang null masuk Unset
+func alumniPatchRequest(existing *model.Alumni, doc *model.AlumniPatchDocument) (*model.UpdateAlumniRepositoryRequest, *fiber.Error) {
+	if doc.NIM == "" || doc.Nama == "" || doc.Jurusan == "" || doc.Email == "" || doc.RoleID <= 0 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "NIM, nama, jurusan, email, dan role_id wajib diisi")
+	}
+	if doc.Angkatan <= 0 || doc.TahunLulus <= 0 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Angkatan dan tahun lulus harus lebih dari 0")
+	}
+
+	repoReq := &model.UpdateAlumniRepositoryRequest{
+		NIM:        &doc.NIM,
+		Nama:       &doc.Nama,
+		Jurusan:    &doc.Jurusan,
+		Angkatan:   &doc.Angkatan,
+		TahunLulus: &doc.TahunLulus,
+		Email:      &doc.Email,
+		RoleID:     &doc.RoleID,
+		NoTelepon:  doc.NoTelepon,
+		Alamat:     doc.Alamat,
+	}
+	if doc.NoTelepon == nil {
+		repoReq.Unset = append(repoReq.Unset, "no_telepon")
+	}
+	if doc.Alamat == nil {
+		repoReq.Unset = append(repoReq.Unset, "alamat")
+	}
+
+	if doc.Password != nil {
+		if err := utils.ValidatePassword(*doc.Password, existing.Email, existing.NIM, doc.Email, doc.NIM); err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Password tidak memenuhi kebijakan: "+err.Error())
+		}
+		hashed, err := utils.HashPassword(*doc.Password)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusInternalServerError, "Gagal memproses password")
+		}
+		repoReq.Password = &hashed
+	}
+	return repoReq, nil
+}
+
 func DeleteAlumniService(c *fiber.Ctx, db *sql.DB) error {
 	idStr := c.Params("id")
 	id, err := strconv.Atoi(idStr)
@@ -270,7 +561,7 @@ func DeleteAlumniService(c *fiber.Ctx, db *sql.DB) error {
 	}
 
 	// Check if alumni exists
-	_, err = repository.GetAlumniByID(db, id)
+	existing, err := repository.GetAlumniByID(db, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return c.Status(fiber.StatusNotFound).JSON(model.DeleteAlumniResponse{
@@ -291,6 +582,7 @@ func DeleteAlumniService(c *fiber.Ctx, db *sql.DB) error {
 			Message: "Gagal menghapus alumni: " + err.Error(),
 		})
 	}
+	recordAudit(c, db, auditEntry(helper.AuditActionDelete, helper.AuditEntityAlumni, idStr, existing, nil))
 
 	return c.Status(fiber.StatusOK).JSON(model.DeleteAlumniResponse{
 		Success: true,
@@ -298,16 +590,26 @@ func DeleteAlumniService(c *fiber.Ctx, db *sql.DB) error {
 	})
 }
 
-// Legacy function for backward compatibility
+// CheckAlumniService -> endpoint cek alumni lama dengan API_KEY tunggal di URL.
+// Deprecated: gunakan GET /partner/alumni/check dengan header X-API-Key
 func CheckAlumniService(c *fiber.Ctx, db *sql.DB) error {
-	key := c.Params("key")
-	if key != os.Getenv("API_KEY") {
+	if !legacyCheckAllowed(c) {
+		return c.Status(fiber.StatusGone).JSON(fiber.Map{
+			"message": "Endpoint ini sudah dinonaktifkan, gunakan " + partnerCheckPath + " dengan header " + helper.APIKeyHeader,
+			"success": false,
+		})
+	}
+	if !legacyAPIKeyValid(c.Params("key")) {
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 			"message": "Key tidak valid",
 			"success": false,
 		})
 	}
-	nim := c.FormValue("nim")
+	return checkAlumniByNIM(c, db, c.FormValue("nim"))
+}
+
+// checkAlumniByNIM -> respons cek status alumni, dipakai endpoint lama dan endpoint partner
+func checkAlumniByNIM(c *fiber.Ctx, db *sql.DB, nim string) error {
 	if nim == "" {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"message": "NIM wajib diisi",
@@ -336,58 +638,83 @@ func CheckAlumniService(c *fiber.Ctx, db *sql.DB) error {
 	})
 }
 
-// Get Alumni Employment Status Service
-func GetAlumniEmploymentStatusService(c *fiber.Ctx, db *sql.DB) error {
-	// Parse query parameters
+// parseEmploymentStatusRequest -> filter lama status pekerjaan (id, nama, jurusan, ...) beserta sort,
+// page, limit, dan hak melihat gaji rahasia
+func parseEmploymentStatusRequest(c *fiber.Ctx) *model.AlumniEmploymentStatusRequest {
+	role, _ := c.Locals("role").(string)
+	viewerID, _ := c.Locals("user_id").(int)
+	return employmentStatusRequest(c, role, viewerID)
+}
+
+// employmentStatusRequest -> isi parseEmploymentStatusRequest dari query apa pun, dipakai juga job export
+func employmentStatusRequest(q queryValues, role string, viewerID int) *model.AlumniEmploymentStatusRequest {
 	req := &model.AlumniEmploymentStatusRequest{
-		Page:  1,
-		Limit: 20,
+		SortBy: q.Query("sortBy"),
+		Order:  q.Query("order"),
+		Page:   1,
+		Limit:  20,
 	}
+	req.IncludeConfidential = role == "admin"
+	req.ViewerID = viewerID
 
 	// Parse query parameters
-	if idStr := c.Query("id"); idStr != "" {
+	if idStr := q.Query("id"); idStr != "" {
 		if id, err := strconv.Atoi(idStr); err == nil {
 			req.ID = &id
 		}
 	}
-	if nama := c.Query("nama"); nama != "" {
+	if nama := q.Query("nama"); nama != "" {
 		req.Nama = &nama
 	}
-	if jurusan := c.Query("jurusan"); jurusan != "" {
+	if jurusan := q.Query("jurusan"); jurusan != "" {
 		req.Jurusan = &jurusan
 	}
-	if angkatanStr := c.Query("angkatan"); angkatanStr != "" {
+	if angkatanStr := q.Query("angkatan"); angkatanStr != "" {
 		if angkatan, err := strconv.Atoi(angkatanStr); err == nil {
 			req.Angkatan = &angkatan
 		}
 	}
-	if bidangIndustri := c.Query("bidang_industri"); bidangIndustri != "" {
+	if bidangIndustri := q.Query("bidang_industri"); bidangIndustri != "" {
 		req.BidangIndustri = &bidangIndustri
 	}
-	if namaPerusahaan := c.Query("nama_perusahaan"); namaPerusahaan != "" {
+	if namaPerusahaan := q.Query("nama_perusahaan"); namaPerusahaan != "" {
 		req.NamaPerusahaan = &namaPerusahaan
 	}
-	if posisiJabatan := c.Query("posisi_jabatan"); posisiJabatan != "" {
+	if posisiJabatan := q.Query("posisi_jabatan"); posisiJabatan != "" {
 		req.PosisiJabatan = &posisiJabatan
 	}
-	if lebihDari1TahunStr := c.Query("lebih_dari_1_tahun"); lebihDari1TahunStr != "" {
+	if lebihDari1TahunStr := q.Query("lebih_dari_1_tahun"); lebihDari1TahunStr != "" {
 		if lebihDari1Tahun, err := strconv.Atoi(lebihDari1TahunStr); err == nil {
 			req.LebihDari1Tahun = &lebihDari1Tahun
 		}
 	}
-	if pageStr := c.Query("page"); pageStr != "" {
+	if pageStr := q.Query("page"); pageStr != "" {
 		if page, err := strconv.Atoi(pageStr); err == nil && page > 0 {
 			req.Page = page
 		}
 	}
-	if limitStr := c.Query("limit"); limitStr != "" {
+	if limitStr := q.Query("limit"); limitStr != "" {
 		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
 			req.Limit = limit
 		}
 	}
+	return req
+}
+
+// Get Alumni Employment Status Service
+func GetAlumniEmploymentStatusService(c *fiber.Ctx, db *sql.DB) error {
+	req := parseEmploymentStatusRequest(c)
+
+	filters, err := helper.ParseFilters(c.Queries(), repository.EmploymentStatusFilterFields)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+			"success": false,
+		})
+	}
 
 	// Get data from repository
-	results, err := repository.GetAlumniEmploymentStatus(db, req)
+	results, err := repository.GetAlumniEmploymentStatus(db, req, filters)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"message": "Gagal mengambil data status pekerjaan alumni: " + err.Error(),
@@ -395,8 +722,14 @@ func GetAlumniEmploymentStatusService(c *fiber.Ctx, db *sql.DB) error {
 		})
 	}
 
-	// Calculate pagination info
-	totalRecords := len(results)
+	// Calculate pagination info dari total seluruh data, bukan hanya halaman ini
+	totalRecords, err := repository.CountAlumniEmploymentStatus(db, req, filters)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Gagal menghitung data status pekerjaan alumni: " + err.Error(),
+			"success": false,
+		})
+	}
 	totalPages := (totalRecords + req.Limit - 1) / req.Limit
 
 	return c.Status(fiber.StatusOK).JSON(model.GetAlumniEmploymentStatusResponse{
@@ -411,3 +744,63 @@ func GetAlumniEmploymentStatusService(c *fiber.Ctx, db *sql.DB) error {
 		},
 	})
 }
+
+// SearchAlumniService -> full-text search alumni dengan ranking relevansi dan highlight
+func SearchAlumniService(c *fiber.Ctx, db *sql.DB) error {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Parameter q wajib diisi",
+		})
+	}
+
+	page, limit, offset := searchPagination(c)
+
+	results, err := repository.SearchAlumniRepo(db, query, limit, offset)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to search alumni",
+		})
+	}
+
+	total, err := repository.CountSearchAlumniRepo(db, query)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to count search results",
+		})
+	}
+
+	if results == nil {
+		results = []model.AlumniSearchResult{}
+	}
+
+	return c.JSON(model.SearchAlumniResponse{
+		Success: true,
+		Message: "Berhasil mencari alumni",
+		Data: model.SearchAlumniData{
+			Items: results,
+			Meta: model.MetaInfo{
+				Page:   page,
+				Limit:  limit,
+				Total:  &total,
+				Pages:  (total + limit - 1) / limit,
+				SortBy: "relevance",
+				Order:  "desc",
+				Search: query,
+			},
+		},
+	})
+}
+
+// searchPagination -> page/limit untuk endpoint search (limit maksimal 100)
+func searchPagination(c *fiber.Ctx) (page, limit, offset int) {
+	page, _ = strconv.Atoi(c.Query("page", "1"))
+	limit, _ = strconv.Atoi(c.Query("limit", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	return page, limit, (page - 1) * limit
+}
@@ -0,0 +1,230 @@
+package postgre
+
+import (
+	"database/sql"
+	"fmt"
+	model "go-fiber/app/model/postgre"
+	repository "go-fiber/app/repository/postgre"
+	"go-fiber/helper"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Analytics Services
+
+// parseAnalyticsQuery membaca group_by (angkatan, jurusan, tahun_lulus, atau all) dan filter kohort
+func parseAnalyticsQuery(q queryValues, defaultGroupBy string) (string, []helper.Filter, error) {
+	groupBy := q.Query("group_by", defaultGroupBy)
+	if _, ok := repository.AnalyticsGroupFields[groupBy]; !ok && groupBy != "all" {
+		return "", nil, fmt.Errorf("group_by harus salah satu dari angkatan, jurusan, tahun_lulus, all")
+	}
+
+	filters, err := helper.ParseFilters(q.Queries(), repository.AnalyticsFilterFields)
+	if err != nil {
+		return "", nil, err
+	}
+	return groupBy, filters, nil
+}
+
+func analyticsError(c *fiber.Ctx, status int, message string) error {
+	return c.Status(status).JSON(fiber.Map{
+		"success": false,
+		"message": message,
+	})
+}
+
+// loadEmploymentRate -> employment rate per kelompok (dipakai endpoint analytics dan export)
+func loadEmploymentRate(db *sql.DB, groupBy string, filters []helper.Filter) ([]model.EmploymentRateItem, error) {
+	items, err := repository.GetEmploymentRate(db, groupBy, filters)
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []model.EmploymentRateItem{}
+	}
+	for i := range items {
+		items[i].EmploymentRate = helper.Percentage(items[i].Employed, items[i].TotalAlumni)
+	}
+	return items, nil
+}
+
+// loadTimeToFirstJob -> median/rata-rata bulan ke pekerjaan pertama, dibulatkan 1 desimal
+func loadTimeToFirstJob(db *sql.DB, groupBy string, filters []helper.Filter) ([]model.TimeToFirstJobItem, error) {
+	items, err := repository.GetTimeToFirstJob(db, groupBy, filters)
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []model.TimeToFirstJobItem{}
+	}
+	for i := range items {
+		items[i].MedianMonths = helper.Round(items[i].MedianMonths, 1)
+		items[i].AverageMonths = helper.Round(items[i].AverageMonths, 1)
+	}
+	return items, nil
+}
+
+// loadJobDistribution -> distribusi pekerjaan beserta persentase dan total pekerjaan
+func loadJobDistribution(db *sql.DB, field string, activeOnly bool, filters []helper.Filter) ([]model.DistributionItem, int, error) {
+	items, err := repository.GetJobDistribution(db, field, activeOnly, filters)
+	if err != nil {
+		return nil, 0, err
+	}
+	if items == nil {
+		items = []model.DistributionItem{}
+	}
+
+	totalJobs := 0
+	for _, item := range items {
+		totalJobs += item.Jobs
+	}
+	for i := range items {
+		items[i].Percentage = helper.Percentage(items[i].Jobs, totalJobs)
+	}
+	return items, totalJobs, nil
+}
+
+// loadSalaryHistogram -> histogram gaji; semua kelompok selalu ditampilkan, termasuk yang kosong
+func loadSalaryHistogram(db *sql.DB, activeOnly bool, filters []helper.Filter) (model.SalaryHistogram, error) {
+	counts, err := repository.GetSalaryHistogram(db, activeOnly, filters)
+	if err != nil {
+		return model.SalaryHistogram{}, err
+	}
+
+	histogram := model.SalaryHistogram{Unit: "juta"}
+	byMin := map[float64]int{}
+	for _, bc := range counts {
+		histogram.Total += bc.Count
+		if bc.Min < 0 {
+			histogram.Unparsed += bc.Count
+			continue
+		}
+		byMin[bc.Min] += bc.Count
+	}
+	parsed := histogram.Total - histogram.Unparsed
+	for i, min := range helper.SalaryBoundaries {
+		max, label := helper.SalaryBucketRange(i)
+		histogram.Buckets = append(histogram.Buckets, model.SalaryBucket{
+			Label:      label,
+			Min:        min,
+			Max:        max,
+			Count:      byMin[min],
+			Percentage: helper.Percentage(byMin[min], parsed),
+		})
+	}
+	return histogram, nil
+}
+
+// loadJobRetention -> retensi pekerjaan per kelompok beserta persentasenya
+func loadJobRetention(db *sql.DB, groupBy string, filters []helper.Filter) ([]model.RetentionItem, error) {
+	items, err := repository.GetJobRetention(db, groupBy, filters)
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []model.RetentionItem{}
+	}
+	for i := range items {
+		items[i].RetentionRate = helper.Percentage(items[i].RetainedJobs, items[i].EligibleJobs)
+	}
+	return items, nil
+}
+
+// GetEmploymentRateService -> tingkat keterserapan kerja per angkatan/jurusan/tahun_lulus
+func GetEmploymentRateService(c *fiber.Ctx, db *sql.DB) error {
+	groupBy, filters, err := parseAnalyticsQuery(c, "angkatan")
+	if err != nil {
+		return analyticsError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	items, err := loadEmploymentRate(db, groupBy, filters)
+	if err != nil {
+		return analyticsError(c, fiber.StatusInternalServerError, "Gagal menghitung employment rate: "+err.Error())
+	}
+
+	return c.JSON(model.EmploymentRateResponse{
+		Success: true,
+		Message: "Berhasil menghitung employment rate",
+		Data:    model.EmploymentRateData{GroupBy: groupBy, Items: items},
+	})
+}
+
+// GetTimeToFirstJobService -> median bulan dari kelulusan ke pekerjaan pertama
+func GetTimeToFirstJobService(c *fiber.Ctx, db *sql.DB) error {
+	groupBy, filters, err := parseAnalyticsQuery(c, "all")
+	if err != nil {
+		return analyticsError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	items, err := loadTimeToFirstJob(db, groupBy, filters)
+	if err != nil {
+		return analyticsError(c, fiber.StatusInternalServerError, "Gagal menghitung time-to-first-job: "+err.Error())
+	}
+
+	return c.JSON(model.TimeToFirstJobResponse{
+		Success: true,
+		Message: "Berhasil menghitung time-to-first-job",
+		Data:    model.TimeToFirstJobData{GroupBy: groupBy, Items: items},
+	})
+}
+
+// GetJobDistributionService -> distribusi pekerjaan per bidang_industri, lokasi_kerja, provinsi, atau kota
+func GetJobDistributionService(c *fiber.Ctx, db *sql.DB) error {
+	field := c.Query("field", "bidang_industri")
+	if _, ok := repository.AnalyticsDistributionFields[field]; !ok {
+		return analyticsError(c, fiber.StatusBadRequest, "field harus bidang_industri, lokasi_kerja, provinsi, atau kota")
+	}
+	_, filters, err := parseAnalyticsQuery(c, "all")
+	if err != nil {
+		return analyticsError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	items, totalJobs, err := loadJobDistribution(db, field, c.QueryBool("active_only"), filters)
+	if err != nil {
+		return analyticsError(c, fiber.StatusInternalServerError, "Gagal menghitung distribusi pekerjaan: "+err.Error())
+	}
+
+	return c.JSON(model.DistributionResponse{
+		Success: true,
+		Message: "Berhasil menghitung distribusi pekerjaan",
+		Data:    model.DistributionData{Field: field, TotalJobs: totalJobs, Items: items},
+	})
+}
+
+// GetSalaryHistogramService -> histogram gaji dari gaji terstruktur (dan gaji_range lama)
+func GetSalaryHistogramService(c *fiber.Ctx, db *sql.DB) error {
+	_, filters, err := parseAnalyticsQuery(c, "all")
+	if err != nil {
+		return analyticsError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	histogram, err := loadSalaryHistogram(db, c.QueryBool("active_only"), filters)
+	if err != nil {
+		return analyticsError(c, fiber.StatusInternalServerError, "Gagal menghitung histogram gaji: "+err.Error())
+	}
+
+	return c.JSON(model.SalaryHistogramResponse{
+		Success: true,
+		Message: "Berhasil menghitung histogram gaji",
+		Data:    histogram,
+	})
+}
+
+// GetJobRetentionService -> porsi pekerjaan yang bertahan lebih dari 1 tahun
+func GetJobRetentionService(c *fiber.Ctx, db *sql.DB) error {
+	groupBy, filters, err := parseAnalyticsQuery(c, "all")
+	if err != nil {
+		return analyticsError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	items, err := loadJobRetention(db, groupBy, filters)
+	if err != nil {
+		return analyticsError(c, fiber.StatusInternalServerError, "Gagal menghitung retensi pekerjaan: "+err.Error())
+	}
+
+	return c.JSON(model.RetentionResponse{
+		Success: true,
+		Message: "Berhasil menghitung retensi pekerjaan",
+		Data:    model.RetentionData{GroupBy: groupBy, Items: items},
+	})
+}
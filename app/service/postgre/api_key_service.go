@@ -0,0 +1,162 @@
+package postgre
+
+import (
+	"crypto/subtle"
+	"database/sql"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	model "go-fiber/app/model/postgre"
+	repository "go-fiber/app/repository/postgre"
+	"go-fiber/helper"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// partnerCheckPath -> pengganti endpoint cek alumni lama, dikirim di header Link
+const partnerCheckPath = "/go-fiber-postgre/partner/alumni/check"
+
+var legacyCheckWarning sync.Once
+
+func apiKeyError(c *fiber.Ctx, status int, message string) error {
+	return c.Status(status).JSON(fiber.Map{"success": false, "message": message})
+}
+
+// legacyCheckAllowed -> tandai respons endpoint cek alumni lama sebagai deprecated dan
+// laporkan apakah endpoint itu masih diaktifkan (LEGACY_API_KEY_ENABLED)
+func legacyCheckAllowed(c *fiber.Ctx) bool {
+	c.Set("Deprecation", "true")
+	c.Set(fiber.HeaderLink, "<"+partnerCheckPath+`>; rel="successor-version"`)
+	legacyCheckWarning.Do(func() {
+		log.Printf("Endpoint cek alumni dengan API_KEY tunggal sudah deprecated, gunakan %s dengan header %s", partnerCheckPath, helper.APIKeyHeader)
+	})
+	return helper.LegacyAPIKeyEnabled()
+}
+
+// legacyAPIKeyValid -> key sama dengan API_KEY; API_KEY kosong berarti tidak ada key yang valid
+func legacyAPIKeyValid(key string) bool {
+	expected := os.Getenv("API_KEY")
+	return expected != "" && subtle.ConstantTimeCompare([]byte(key), []byte(expected)) == 1
+}
+
+// APIKeyVerifier -> middleware.APIKeyVerifier: cari key aktif berdasarkan hash dan catat
+// pemakaian terakhirnya
+func APIKeyVerifier(db *sql.DB) func(key string) (*model.APIKey, error) {
+	return func(key string) (*model.APIKey, error) {
+		apiKey, err := repository.FindActiveAPIKeyByHash(db, helper.HashAPIKey(key))
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := repository.TouchAPIKeyLastUsed(db, apiKey.ID); err != nil {
+			log.Printf("Gagal mencatat pemakaian API key %s: %v", apiKey.Prefix, err)
+		}
+		return apiKey, nil
+	}
+}
+
+// APIKeyRateLimitStore -> middleware.APIKeyRateLimitStore: store yang sama dengan rate limit
+// login (RATE_LIMIT_STORE) agar batas per key berlaku di semua instance
+func APIKeyRateLimitStore(db *sql.DB) helper.RateLimitStore {
+	_, store := loginProtection(db)
+	return store
+}
+
+// CreateAPIKeyService -> POST /api-keys: buat API key baru. Key hanya ditampilkan sekali
+func CreateAPIKeyService(c *fiber.Ctx, db *sql.DB) error {
+	var req model.CreateAPIKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apiKeyError(c, fiber.StatusBadRequest, "Request body tidak valid")
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return apiKeyError(c, fiber.StatusBadRequest, "Nama API key harus diisi")
+	}
+	if len(name) > 100 {
+		return apiKeyError(c, fiber.StatusBadRequest, "Nama API key maksimal 100 karakter")
+	}
+	scopes, err := helper.NormalizeAPIKeyScopes(req.Scopes)
+	if err != nil {
+		return apiKeyError(c, fiber.StatusBadRequest, err.Error())
+	}
+	if req.RateLimit < 0 {
+		return apiKeyError(c, fiber.StatusBadRequest, "rate_limit tidak boleh negatif")
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return apiKeyError(c, fiber.StatusBadRequest, "expires_at harus di masa depan")
+	}
+
+	key, prefix, hash, err := helper.GenerateAPIKey()
+	if err != nil {
+		return apiKeyError(c, fiber.StatusInternalServerError, "Gagal membuat API key")
+	}
+	apiKey := model.APIKey{
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   hash,
+		Scopes:    scopes,
+		RateLimit: req.RateLimit,
+		ExpiresAt: req.ExpiresAt,
+	}
+	if userID, ok := c.Locals("user_id").(int); ok {
+		apiKey.CreatedBy = &userID
+	}
+	if err := repository.CreateAPIKey(db, &apiKey); err != nil {
+		return apiKeyError(c, fiber.StatusInternalServerError, "Gagal menyimpan API key karena "+err.Error())
+	}
+	recordAudit(c, db, auditEntry(helper.AuditActionCreate, helper.AuditEntityAPIKey, strconv.Itoa(apiKey.ID), nil, apiKey))
+
+	// Key mentah hanya ditampilkan sekali, jangan disimpan di cache
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Status(fiber.StatusCreated).JSON(model.CreateAPIKeyResponse{
+		Success: true,
+		Message: "API key berhasil dibuat, simpan key ini karena tidak akan ditampilkan lagi",
+		Data:    model.CreateAPIKeyData{APIKey: apiKey, Key: key},
+	})
+}
+
+// ListAPIKeysService -> GET /api-keys: daftar API key tanpa key maupun hash-nya
+func ListAPIKeysService(c *fiber.Ctx, db *sql.DB) error {
+	keys, err := repository.ListAPIKeys(db)
+	if err != nil {
+		return apiKeyError(c, fiber.StatusInternalServerError, "Gagal mengambil daftar API key karena "+err.Error())
+	}
+	return c.Status(fiber.StatusOK).JSON(model.ListAPIKeysResponse{
+		Success: true,
+		Message: "Berhasil mengambil daftar API key",
+		Data:    keys,
+	})
+}
+
+// RevokeAPIKeyService -> DELETE /api-keys/:id: cabut API key; key langsung tidak bisa dipakai
+func RevokeAPIKeyService(c *fiber.Ctx, db *sql.DB) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return apiKeyError(c, fiber.StatusBadRequest, "ID API key tidak valid")
+	}
+	apiKey, err := repository.RevokeAPIKey(db, id)
+	if err == sql.ErrNoRows {
+		return apiKeyError(c, fiber.StatusNotFound, "API key tidak ditemukan")
+	}
+	if err != nil {
+		return apiKeyError(c, fiber.StatusInternalServerError, "Gagal mencabut API key karena "+err.Error())
+	}
+	recordAudit(c, db, auditEntry(helper.AuditActionRevoke, helper.AuditEntityAPIKey, strconv.Itoa(apiKey.ID), nil, apiKey))
+	return c.Status(fiber.StatusOK).JSON(model.APIKeyResponse{
+		Success: true,
+		Message: "API key berhasil dicabut",
+		Data:    *apiKey,
+	})
+}
+
+// PartnerCheckAlumniService -> GET /partner/alumni/check?nim=: cek status alumni untuk client
+// dengan API key ber-scope alumni:check
+func PartnerCheckAlumniService(c *fiber.Ctx, db *sql.DB) error {
+	return checkAlumniByNIM(c, db, strings.TrimSpace(c.Query("nim")))
+}
@@ -2,12 +2,53 @@ package postgre
 
 import (
 	"database/sql"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
 	model "go-fiber/app/model/postgre"
+	repository "go-fiber/app/repository/postgre"
+	"go-fiber/helper"
 	utils "go-fiber/utils/postgre"
 
 	"github.com/gofiber/fiber/v2"
 )
 
+var loginLimiter struct {
+	once  sync.Once
+	cfg   helper.LoginProtection
+	store helper.RateLimitStore
+}
+
+// loginProtection -> konfigurasi dan store rate limit login, dibuat sekali saat login pertama
+func loginProtection(db *sql.DB) (helper.LoginProtection, helper.RateLimitStore) {
+	loginLimiter.once.Do(func() {
+		loginLimiter.cfg = helper.LoginProtectionFromEnv()
+		if loginLimiter.cfg.Store == helper.RateLimitStoreDatabase {
+			loginLimiter.store = repository.NewRateLimitStore(db)
+		} else {
+			loginLimiter.store = helper.NewMemoryRateLimitStore()
+		}
+	})
+	return loginLimiter.cfg, loginLimiter.store
+}
+
+func loginIPKey(ip string) string {
+	return "login:ip:" + ip
+}
+
+func loginAccountKey(email string) string {
+	return "login:account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+// tooManyAttempts -> 429 dengan header Retry-After
+func tooManyAttempts(c *fiber.Ctx, retryAfter time.Duration, message string) error {
+	c.Set(fiber.HeaderRetryAfter, helper.RetryAfterSeconds(retryAfter))
+	return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{"error": message})
+}
+
 // Handler untuk login
 func LoginService(c *fiber.Ctx, db *sql.DB) error {
 	type loginRequest struct {
@@ -21,24 +62,101 @@ func LoginService(c *fiber.Ctx, db *sql.DB) error {
 	if req.Email == "" || req.Password == "" {
 		return c.Status(400).JSON(fiber.Map{"error": "Email dan password harus diisi"})
 	}
-	var user model.User
-	var passwordHash string
-	err := db.QueryRow(`
-        SELECT a.id, a.email, a.password, r.name
-        FROM alumni a
-        JOIN roles r ON r.id = a.role_id
-        WHERE a.email = $1
-    `, req.Email).Scan(&user.ID, &user.Email, &passwordHash, &user.Role)
+
+	// Rate limit per IP dan per akun (termasuk email yang tidak terdaftar)
+	cfg, limiter := loginProtection(db)
+	count, retryAfter, err := limiter.Hit(loginIPKey(c.IP()), cfg.Window)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal memeriksa rate limit"})
+	}
+	if count > cfg.IPLimit {
+		return tooManyAttempts(c, retryAfter, "Terlalu banyak percobaan login, coba lagi nanti")
+	}
+	count, retryAfter, err = limiter.Hit(loginAccountKey(req.Email), cfg.Window)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal memeriksa rate limit"})
+	}
+	if count > cfg.AccountLimit {
+		return tooManyAttempts(c, retryAfter, "Terlalu banyak percobaan login, coba lagi nanti")
+	}
+
+	state, err := repository.GetLoginState(db, req.Email)
 	if err != nil {
 		if err == sql.ErrNoRows {
+			recordLoginAudit(c, db, helper.AuditActionLoginFailed, nil, "", map[string]any{"method": "password", "email": req.Email, "reason": "unknown_email"})
 			return c.Status(401).JSON(fiber.Map{"error": "Email atau password salah"})
 		}
 		return c.Status(500).JSON(fiber.Map{"error": "Error database"})
 	}
-	if !utils.CheckPassword(req.Password, passwordHash) {
+	if state.LockedFor > 0 {
+		recordLoginAudit(c, db, helper.AuditActionLoginFailed, &state.ID, "", map[string]any{"method": "password", "reason": "locked"})
+		return tooManyAttempts(c, state.LockedFor, "Akun terkunci sementara karena terlalu banyak percobaan login gagal")
+	}
+	// Jeda progresif: percobaan berikutnya baru diterima setelah jeda dari gagal terakhir
+	if delay := cfg.FailureDelay(state.FailedLoginAttempts); state.SinceLastFailure < delay {
+		return tooManyAttempts(c, delay-state.SinceLastFailure, "Terlalu cepat, tunggu sebelum mencoba login lagi")
+	}
+
+	if !utils.CheckPassword(req.Password, state.Password) {
+		_, lockedFor, err := repository.RecordLoginFailure(db, state.ID, cfg.MaxFailures, cfg.Lockout)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Error database"})
+		}
+		recordLoginAudit(c, db, helper.AuditActionLoginFailed, &state.ID, "", map[string]any{"method": "password", "reason": "wrong_password"})
+		if lockedFor > 0 {
+			return tooManyAttempts(c, lockedFor, "Akun terkunci sementara karena terlalu banyak percobaan login gagal")
+		}
 		return c.Status(401).JSON(fiber.Map{"error": "Email atau password salah"})
 	}
-	user.Username = user.Email
+
+	if state.FailedLoginAttempts > 0 {
+		if err := repository.ResetLoginFailures(db, state.ID); err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Error database"})
+		}
+	}
+	if err := limiter.Reset(loginAccountKey(req.Email)); err != nil {
+		log.Printf("Reset login rate limit for %s failed: %v", req.Email, err)
+	}
+
+	user := model.User{
+		ID:             state.ID,
+		Username:       state.Email,
+		Email:          state.Email,
+		Role:           state.Role,
+		SessionVersion: state.SessionVersion,
+	}
+	return issueLoginResponse(c, db, user, state.TOTPEnabled, "password")
+}
+
+// issueLoginResponse -> respons login sukses (password maupun SSO). Akun dengan 2FA (wajib
+// untuk admin) mendapat token mfa_pending dulu, selain itu langsung token penuh. Login baru
+// dicatat di audit log saat token penuh diterbitkan.
+func issueLoginResponse(c *fiber.Ctx, db *sql.DB, user model.User, totpEnabled bool, method string) error {
+	if mfaRequired(user.Role, totpEnabled) {
+		mfaToken, err := utils.GenerateMFAPendingToken(user)
+		if err != nil {
+			return c.Status(500).JSON(model.LoginResponse{
+				Success: false,
+				Message: "Gagal generate token",
+				Data:    model.LoginData{},
+			})
+		}
+		message := "Masukkan kode 2FA"
+		if !totpEnabled {
+			message = "2FA wajib untuk admin, lakukan enrollment"
+		}
+		return c.JSON(model.LoginResponse{
+			Success: true,
+			Message: message,
+			Data: model.LoginData{
+				User:                  user,
+				MFARequired:           true,
+				MFAEnrollmentRequired: !totpEnabled,
+				MFAToken:              mfaToken,
+			},
+		})
+	}
+
 	token, err := utils.GenerateToken(user)
 	if err != nil {
 		return c.Status(500).JSON(model.LoginResponse{
@@ -47,6 +165,7 @@ func LoginService(c *fiber.Ctx, db *sql.DB) error {
 			Data:    model.LoginData{},
 		})
 	}
+	recordLoginAudit(c, db, helper.AuditActionLogin, &user.ID, user.Role, map[string]any{"method": method})
 
 	return c.JSON(model.LoginResponse{
 		Success: true,
@@ -58,7 +177,34 @@ func LoginService(c *fiber.Ctx, db *sql.DB) error {
 	})
 }
 
-// Handler untuk melihat profile user yang sedang login
+// UnlockAlumniService -> POST /alumni/:id/unlock: admin membuka kunci akun dan menghapus
+// counter gagal login serta rate limit akun
+func UnlockAlumniService(c *fiber.Ctx, db *sql.DB) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.UnlockAlumniResponse{Success: false, Message: "ID tidak valid"})
+	}
+	alumni, err := repository.GetAlumniByID(db, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return c.Status(fiber.StatusNotFound).JSON(model.UnlockAlumniResponse{Success: false, Message: "Alumni tidak ditemukan"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.UnlockAlumniResponse{Success: false, Message: "Gagal mengambil data alumni: " + err.Error()})
+	}
+
+	if err := repository.ResetLoginFailures(db, id); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.UnlockAlumniResponse{Success: false, Message: "Gagal membuka kunci akun: " + err.Error()})
+	}
+	_, limiter := loginProtection(db)
+	if err := limiter.Reset(loginAccountKey(alumni.Email)); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.UnlockAlumniResponse{Success: false, Message: "Gagal menghapus rate limit akun: " + err.Error()})
+	}
+	recordAudit(c, db, auditEntry(helper.AuditActionUnlock, helper.AuditEntityAlumni, strconv.Itoa(id), nil, nil))
+
+	return c.JSON(model.UnlockAlumniResponse{Success: true, Message: "Kunci akun berhasil dibuka"})
+}
+
+// Handler untuk melihat profile user yang sedang login (isi token saja; data alumni lengkap ada di GET /me)
 func GetProfileService(c *fiber.Ctx, db *sql.DB) error {
 	userID := c.Locals("user_id").(int)
 	username := c.Locals("username").(string)
@@ -0,0 +1,458 @@
+package postgre
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	model "go-fiber/app/model/postgre"
+	repository "go-fiber/app/repository/postgre"
+	"go-fiber/helper"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// batchOutcome -> hasil operasi batch yang berhasil. effects (audit) baru dijalankan setelah
+// transaksi di-commit.
+type batchOutcome struct {
+	status  int
+	message string
+	id      int
+	data    interface{}
+	effects func()
+}
+
+// batchStep -> operasi batch yang sudah lolos validasi; tx adalah transaksi tempat operasi
+// dijalankan
+type batchStep func(tx repository.DBTX) (*batchOutcome, *fiber.Error)
+
+// errBatchAborted -> salah satu step gagal sehingga transaksi di-rollback
+var errBatchAborted = errors.New("batch dibatalkan")
+
+// runBatch -> parse body batch, siapkan tiap operasi dengan prepare lalu jalankan sesuai mode.
+// Validasi yang tidak butuh database dilakukan di prepare sehingga all_or_nothing bisa gagal
+// sebelum transaksi dibuka.
+func runBatch(c *fiber.Ctx, db *sql.DB, prepare func(op model.BatchOperation) (batchStep, *fiber.Error)) error {
+	var req model.BatchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return batchFailed(c, fiber.StatusBadRequest, "Format data tidak valid: "+err.Error())
+	}
+	if req.Mode == "" {
+		req.Mode = model.BatchModeAllOrNothing
+	}
+	if req.Mode != model.BatchModeAllOrNothing && req.Mode != model.BatchModeBestEffort {
+		return batchFailed(c, fiber.StatusBadRequest, "Mode harus all_or_nothing atau best_effort")
+	}
+	if len(req.Operations) == 0 {
+		return batchFailed(c, fiber.StatusBadRequest, "Operations wajib diisi")
+	}
+	if limit := helper.BatchMaxOperations(); len(req.Operations) > limit {
+		return batchFailed(c, fiber.StatusRequestEntityTooLarge, fmt.Sprintf("Maksimal %d operasi per batch", limit))
+	}
+
+	summary := model.BatchSummary{Mode: req.Mode, Results: make([]model.BatchResult, len(req.Operations))}
+	steps := make([]batchStep, len(req.Operations))
+	invalid := false
+	for i, op := range req.Operations {
+		summary.Results[i] = model.BatchResult{Index: i, Op: op.Op, ID: op.ID}
+		step, ferr := prepare(op)
+		if ferr != nil {
+			setBatchError(&summary.Results[i], ferr)
+			invalid = true
+			continue
+		}
+		steps[i] = step
+	}
+
+	if req.Mode == model.BatchModeBestEffort {
+		// Tiap operasi dijalankan dalam transaksi sendiri
+		for i, step := range steps {
+			if step == nil {
+				continue
+			}
+			outcomes, err := runBatchTx(db, []batchStep{step}, summary.Results[i:i+1])
+			if err != nil {
+				if !errors.Is(err, errBatchAborted) {
+					setBatchError(&summary.Results[i], fiber.NewError(fiber.StatusInternalServerError, "Gagal menjalankan transaksi: "+err.Error()))
+				}
+				continue
+			}
+			setBatchOutcome(&summary.Results[i], outcomes[0])
+		}
+		return batchResponse(c, summary)
+	}
+
+	if !invalid {
+		outcomes, err := runBatchTx(db, steps, summary.Results)
+		if err == nil {
+			for i, outcome := range outcomes {
+				setBatchOutcome(&summary.Results[i], outcome)
+			}
+			return batchResponse(c, summary)
+		}
+		if !errors.Is(err, errBatchAborted) {
+			return batchFailed(c, fiber.StatusInternalServerError, "Gagal menjalankan transaksi batch: "+err.Error())
+		}
+	}
+
+	// all_or_nothing gagal: tidak ada perubahan yang tersimpan
+	for i := range summary.Results {
+		if summary.Results[i].Status < fiber.StatusBadRequest {
+			summary.Results[i].Status = fiber.StatusFailedDependency
+			summary.Results[i].Message = "Dibatalkan karena operasi lain gagal"
+			summary.Results[i].Data = nil
+		}
+	}
+	return batchResponse(c, summary)
+}
+
+// runBatchTx -> jalankan steps dalam satu transaksi. Step yang gagal dicatat di results dan
+// membuat seluruh transaksi di-rollback dengan errBatchAborted.
+func runBatchTx(db *sql.DB, steps []batchStep, results []model.BatchResult) ([]*batchOutcome, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	outcomes := make([]*batchOutcome, len(steps))
+	for i, step := range steps {
+		outcome, ferr := step(tx)
+		if ferr != nil {
+			setBatchError(&results[i], ferr)
+			return nil, errBatchAborted
+		}
+		outcomes[i] = outcome
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return outcomes, nil
+}
+
+func setBatchError(result *model.BatchResult, ferr *fiber.Error) {
+	result.Status = ferr.Code
+	result.Message = ferr.Message
+}
+
+// setBatchOutcome -> isi hasil operasi yang berhasil dan jalankan efek sampingnya
+func setBatchOutcome(result *model.BatchResult, outcome *batchOutcome) {
+	result.Status = outcome.status
+	result.Message = outcome.message
+	result.Data = outcome.data
+	if outcome.id != 0 {
+		result.ID = outcome.id
+	}
+	if outcome.effects != nil {
+		outcome.effects()
+	}
+}
+
+// batchResponse -> 200 bila semua berhasil, 207 bila best_effort gagal sebagian, dan status
+// operasi pertama yang gagal bila all_or_nothing dibatalkan
+func batchResponse(c *fiber.Ctx, summary model.BatchSummary) error {
+	status := 0
+	for _, result := range summary.Results {
+		if result.Status >= fiber.StatusBadRequest {
+			summary.Failed++
+			if status == 0 && result.Status != fiber.StatusFailedDependency {
+				status = result.Status
+			}
+		} else {
+			summary.Succeeded++
+		}
+	}
+
+	message := "Semua operasi batch berhasil"
+	switch {
+	case summary.Failed == 0:
+		status = fiber.StatusOK
+	case summary.Mode == model.BatchModeBestEffort:
+		status = fiber.StatusMultiStatus
+		message = fmt.Sprintf("%d dari %d operasi batch gagal", summary.Failed, len(summary.Results))
+	default:
+		message = "Batch dibatalkan, tidak ada perubahan yang disimpan"
+	}
+	return c.Status(status).JSON(model.BatchResponse{
+		Success: summary.Failed == 0,
+		Message: message,
+		Data:    summary,
+	})
+}
+
+func batchFailed(c *fiber.Ctx, status int, message string) error {
+	return c.Status(status).JSON(model.BatchResponse{
+		Success: false,
+		Message: message,
+		Data:    model.BatchSummary{Results: []model.BatchResult{}},
+	})
+}
+
+// batchIfMatch -> versi dari if_match operasi, aturannya sama dengan header If-Match
+func batchIfMatch(op model.BatchOperation) (*int, *fiber.Error) {
+	version, ok, err := helper.ParseIfMatch(op.IfMatch)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	if !ok {
+		if helper.IfMatchRequired() && op.IfMatch == "" {
+			return nil, fiber.NewError(fiber.StatusPreconditionRequired, "if_match wajib diisi dengan ETag dari response GET")
+		}
+		return nil, nil
+	}
+	return &version, nil
+}
+
+// batchMergePatch -> data operasi update harus berupa object merge patch
+func batchMergePatch(op model.BatchOperation) *fiber.Error {
+	var patch map[string]json.RawMessage
+	if err := json.Unmarshal(op.Data, &patch); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "data update harus berupa object merge patch")
+	}
+	return nil
+}
+
+var errBatchUnknownOp = fiber.NewError(fiber.StatusBadRequest, "op harus create, update, atau delete")
+
+// BatchAlumniService -> banyak create/update/delete alumni dalam satu request. Update memakai
+// merge patch seperti PATCH /alumni/:id.
+func BatchAlumniService(c *fiber.Ctx, db *sql.DB) error {
+	return runBatch(c, db, func(op model.BatchOperation) (batchStep, *fiber.Error) {
+		switch op.Op {
+		case "create":
+			var req model.CreateAlumniRequest
+			if err := json.Unmarshal(op.Data, &req); err != nil {
+				return nil, fiber.NewError(fiber.StatusBadRequest, "Format data tidak valid: "+err.Error())
+			}
+			repoReq, ferr := alumniCreateRequest(&req)
+			if ferr != nil {
+				return nil, ferr
+			}
+			return func(tx repository.DBTX) (*batchOutcome, *fiber.Error) {
+				alumni, err := repository.CreateAlumni(tx, repoReq)
+				if err != nil {
+					return nil, fiber.NewError(fiber.StatusInternalServerError, "Gagal membuat alumni: "+err.Error())
+				}
+				if err := enqueueAccountCreated(tx, alumni); err != nil {
+					return nil, fiber.NewError(fiber.StatusInternalServerError, "Gagal menyimpan email notifikasi: "+err.Error())
+				}
+				return &batchOutcome{
+					status:  fiber.StatusCreated,
+					message: "Berhasil membuat alumni",
+					id:      alumni.ID,
+					data:    alumni,
+					effects: func() {
+						recordAudit(c, db, auditEntry(helper.AuditActionCreate, helper.AuditEntityAlumni, strconv.Itoa(alumni.ID), nil, alumni))
+					},
+				}, nil
+			}, nil
+
+		case "update":
+			if op.ID <= 0 {
+				return nil, fiber.NewError(fiber.StatusBadRequest, "ID tidak valid")
+			}
+			expectedVersion, ferr := batchIfMatch(op)
+			if ferr != nil {
+				return nil, ferr
+			}
+			if ferr := batchMergePatch(op); ferr != nil {
+				return nil, ferr
+			}
+			return func(tx repository.DBTX) (*batchOutcome, *fiber.Error) {
+				existing, err := repository.GetAlumniByID(tx, op.ID)
+				if err == sql.ErrNoRows {
+					return nil, fiber.NewError(fiber.StatusNotFound, "Alumni tidak ditemukan")
+				}
+				if err != nil {
+					return nil, fiber.NewError(fiber.StatusInternalServerError, "Gagal mengambil data alumni: "+err.Error())
+				}
+				if expectedVersion != nil && *expectedVersion != existing.Version {
+					return nil, fiber.NewError(fiber.StatusPreconditionFailed, versionConflictMessage)
+				}
+				var doc model.AlumniPatchDocument
+				if ferr := patchDocument(helper.MergePatchContentType, op.Data, alumniPatchDocument(existing), &doc); ferr != nil {
+					return nil, ferr
+				}
+				repoReq, ferr := alumniPatchRequest(existing, &doc)
+				if ferr != nil {
+					return nil, ferr
+				}
+				alumni, err := repository.UpdateAlumni(tx, op.ID, repoReq, expectedVersion)
+				if errors.Is(err, helper.ErrVersionConflict) {
+					return nil, fiber.NewError(fiber.StatusPreconditionFailed, versionConflictMessage)
+				}
+				if err != nil {
+					return nil, fiber.NewError(fiber.StatusInternalServerError, "Gagal mengupdate alumni: "+err.Error())
+				}
+				return &batchOutcome{
+					status:  fiber.StatusOK,
+					message: "Berhasil mengupdate alumni",
+					data:    alumni,
+					effects: func() {
+						entry := auditEntry(helper.AuditActionUpdate, helper.AuditEntityAlumni, strconv.Itoa(op.ID), existing, alumni)
+						if repoReq.Password != nil {
+							entry.Metadata = map[string]any{"password_changed": true}
+						}
+						recordAudit(c, db, entry)
+					},
+				}, nil
+			}, nil
+
+		case "delete":
+			if op.ID <= 0 {
+				return nil, fiber.NewError(fiber.StatusBadRequest, "ID tidak valid")
+			}
+			return func(tx repository.DBTX) (*batchOutcome, *fiber.Error) {
+				existing, err := repository.GetAlumniByID(tx, op.ID)
+				if err == sql.ErrNoRows {
+					return nil, fiber.NewError(fiber.StatusNotFound, "Alumni tidak ditemukan")
+				}
+				if err != nil {
+					return nil, fiber.NewError(fiber.StatusInternalServerError, "Gagal mengambil data alumni: "+err.Error())
+				}
+				if err := repository.DeleteAlumni(tx, op.ID); err != nil {
+					return nil, fiber.NewError(fiber.StatusInternalServerError, "Gagal menghapus alumni: "+err.Error())
+				}
+				return &batchOutcome{
+					status:  fiber.StatusOK,
+					message: "Berhasil menghapus alumni",
+					effects: func() {
+						recordAudit(c, db, auditEntry(helper.AuditActionDelete, helper.AuditEntityAlumni, strconv.Itoa(op.ID), existing, nil))
+					},
+				}, nil
+			}, nil
+		}
+		return nil, errBatchUnknownOp
+	})
+}
+
+// BatchPekerjaanService -> banyak create/update/delete pekerjaan dalam satu request, mis. menandai
+// banyak kontrak selesai sekaligus. Update memakai merge patch seperti PATCH /pekerjaan/:id.
+func BatchPekerjaanService(c *fiber.Ctx, db *sql.DB) error {
+	return runBatch(c, db, func(op model.BatchOperation) (batchStep, *fiber.Error) {
+		switch op.Op {
+		case "create":
+			var req model.CreatePekerjaanAlumniRequest
+			if err := json.Unmarshal(op.Data, &req); err != nil {
+				return nil, fiber.NewError(fiber.StatusBadRequest, "Format data tidak valid: "+err.Error())
+			}
+			repoReq, ferr := pekerjaanCreateRequest(&req)
+			if ferr != nil {
+				return nil, ferr
+			}
+			return func(tx repository.DBTX) (*batchOutcome, *fiber.Error) {
+				if _, err := repository.GetAlumniByID(tx, req.AlumniID); err != nil {
+					if err == sql.ErrNoRows {
+						return nil, fiber.NewError(fiber.StatusNotFound, "Alumni tidak ditemukan")
+					}
+					return nil, fiber.NewError(fiber.StatusInternalServerError, "Gagal mengambil data alumni: "+err.Error())
+				}
+				if ferr := linkPekerjaanReferences(tx, &repoReq.CompanyID, &repoReq.NamaPerusahaan, &repoReq.IndustryID, &repoReq.BidangIndustri); ferr != nil {
+					return nil, ferr
+				}
+				if ferr := lockAlumni(tx, repoReq.AlumniID); ferr != nil {
+					return nil, ferr
+				}
+				if ferr := checkCareerRules(tx, repoReq.AlumniID, createCareerPeriod(repoReq)); ferr != nil {
+					return nil, ferr
+				}
+				pekerjaan, err := repository.CreatePekerjaan(tx, repoReq)
+				if err != nil {
+					return nil, fiber.NewError(fiber.StatusInternalServerError, "Gagal membuat pekerjaan: "+err.Error())
+				}
+				return &batchOutcome{
+					status:  fiber.StatusCreated,
+					message: "Berhasil membuat pekerjaan",
+					id:      pekerjaan.ID,
+					data:    pekerjaan,
+					effects: func() {
+						recordAudit(c, db, auditEntry(helper.AuditActionCreate, helper.AuditEntityPekerjaan, strconv.Itoa(pekerjaan.ID), nil, pekerjaan))
+					},
+				}, nil
+			}, nil
+
+		case "update":
+			if op.ID <= 0 {
+				return nil, fiber.NewError(fiber.StatusBadRequest, "ID tidak valid")
+			}
+			expectedVersion, ferr := batchIfMatch(op)
+			if ferr != nil {
+				return nil, ferr
+			}
+			if ferr := batchMergePatch(op); ferr != nil {
+				return nil, ferr
+			}
+			return func(tx repository.DBTX) (*batchOutcome, *fiber.Error) {
+				existing, err := repository.GetPekerjaanByID(tx, op.ID)
+				if err == sql.ErrNoRows {
+					return nil, fiber.NewError(fiber.StatusNotFound, "Pekerjaan tidak ditemukan")
+				}
+				if err != nil {
+					return nil, fiber.NewError(fiber.StatusInternalServerError, "Gagal mengambil data pekerjaan: "+err.Error())
+				}
+				if expectedVersion != nil && *expectedVersion != existing.Version {
+					return nil, fiber.NewError(fiber.StatusPreconditionFailed, versionConflictMessage)
+				}
+				var doc model.PekerjaanPatchDocument
+				if ferr := patchDocument(helper.MergePatchContentType, op.Data, pekerjaanPatchDocument(existing), &doc); ferr != nil {
+					return nil, ferr
+				}
+				repoReq, ferr := pekerjaanPatchRequest(&doc)
+				if ferr != nil {
+					return nil, ferr
+				}
+				if ferr := linkPekerjaanReferences(tx, &repoReq.CompanyID, &repoReq.NamaPerusahaan, &repoReq.IndustryID, &repoReq.BidangIndustri); ferr != nil {
+					return nil, ferr
+				}
+				if ferr := lockAlumni(tx, existing.AlumniID); ferr != nil {
+					return nil, ferr
+				}
+				if ferr := checkCareerRules(tx, existing.AlumniID, updateCareerPeriod(op.ID, repoReq)); ferr != nil {
+					return nil, ferr
+				}
+				pekerjaan, err := repository.UpdatePekerjaan(tx, op.ID, repoReq, expectedVersion)
+				if errors.Is(err, helper.ErrVersionConflict) {
+					return nil, fiber.NewError(fiber.StatusPreconditionFailed, versionConflictMessage)
+				}
+				if err != nil {
+					return nil, fiber.NewError(fiber.StatusInternalServerError, "Gagal mengupdate pekerjaan: "+err.Error())
+				}
+				return &batchOutcome{
+					status:  fiber.StatusOK,
+					message: "Berhasil mengupdate pekerjaan",
+					data:    pekerjaan,
+					effects: func() {
+						recordAudit(c, db, auditEntry(helper.AuditActionUpdate, helper.AuditEntityPekerjaan, strconv.Itoa(op.ID), existing, pekerjaan))
+					},
+				}, nil
+			}, nil
+
+		case "delete":
+			if op.ID <= 0 {
+				return nil, fiber.NewError(fiber.StatusBadRequest, "ID tidak valid")
+			}
+			return func(tx repository.DBTX) (*batchOutcome, *fiber.Error) {
+				existing, err := repository.GetPekerjaanByID(tx, op.ID)
+				if err == sql.ErrNoRows {
+					return nil, fiber.NewError(fiber.StatusNotFound, "Pekerjaan tidak ditemukan")
+				}
+				if err != nil {
+					return nil, fiber.NewError(fiber.StatusInternalServerError, "Gagal mengambil data pekerjaan: "+err.Error())
+				}
+				// Sama seperti endpoint soft-delete, pekerjaan bisa dipulihkan dari trash
+				if err := repository.SoftDeletePekerjaan(tx, op.ID); err != nil {
+					return nil, fiber.NewError(fiber.StatusInternalServerError, "Gagal menghapus pekerjaan: "+err.Error())
+				}
+				return &batchOutcome{
+					status:  fiber.StatusOK,
+					message: "Berhasil menghapus pekerjaan",
+					effects: func() {
+						recordPekerjaanStateAudit(c, db, helper.AuditActionSoftDelete, existing)
+					},
+				}, nil
+			}, nil
+		}
+		return nil, errBatchUnknownOp
+	})
+}
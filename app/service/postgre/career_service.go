@@ -0,0 +1,188 @@
+package postgre
+
+import (
+	"database/sql"
+	model "go-fiber/app/model/postgre"
+	repository "go-fiber/app/repository/postgre"
+	"go-fiber/helper"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CareerTimelineService -> GET /alumni/:id/career: riwayat pekerjaan alumni berurutan dengan masa
+// kerja per pekerjaan, celah antar pekerjaan, dan total pengalaman
+func CareerTimelineService(c *fiber.Ctx, db *sql.DB) error {
+	alumniID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.CareerTimelineResponse{
+			Success: false,
+			Message: "ID tidak valid",
+		})
+	}
+
+	if _, err := repository.GetAlumniByID(db, alumniID); err != nil {
+		if err == sql.ErrNoRows {
+			return c.Status(fiber.StatusNotFound).JSON(model.CareerTimelineResponse{
+				Success: false,
+				Message: "Alumni tidak ditemukan",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.CareerTimelineResponse{
+			Success: false,
+			Message: "Gagal mengambil data alumni: " + err.Error(),
+		})
+	}
+
+	pekerjaan, err := repository.GetPekerjaanByAlumniID(db, alumniID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.CareerTimelineResponse{
+			Success: false,
+			Message: "Gagal mengambil data pekerjaan: " + err.Error(),
+		})
+	}
+
+	for i := range pekerjaan {
+		maskSalary(c, &pekerjaan[i])
+	}
+
+	return c.Status(fiber.StatusOK).JSON(model.CareerTimelineResponse{
+		Success: true,
+		Message: "Berhasil mengambil riwayat karier alumni",
+		Data:    careerTimeline(alumniID, pekerjaan, time.Now()),
+	})
+}
+
+// careerTimeline -> susun timeline dari pekerjaan alumni; pekerjaan yang masih berjalan dihitung
+// sampai now
+func careerTimeline(alumniID int, pekerjaan []model.PekerjaanAlumni, now time.Time) model.CareerTimeline {
+	sort.SliceStable(pekerjaan, func(i, j int) bool {
+		return pekerjaan[i].TanggalMulaiKerja.Before(pekerjaan[j].TanggalMulaiKerja)
+	})
+
+	periods := make([]helper.CareerPeriod, len(pekerjaan))
+	entries := make([]model.CareerTimelineEntry, len(pekerjaan))
+	for i, p := range pekerjaan {
+		periods[i] = careerPeriod(p)
+		days, months := helper.CareerTenure(periods[i], now)
+		entries[i] = model.CareerTimelineEntry{
+			PekerjaanAlumni: p,
+			Ongoing:         p.TanggalSelesaiKerja == nil,
+			TenureDays:      days,
+			TenureMonths:    months,
+		}
+	}
+
+	summary := helper.SummarizeCareer(periods, now)
+	timeline := model.CareerTimeline{
+		AlumniID:              alumniID,
+		Entries:               entries,
+		Gaps:                  make([]model.CareerGap, len(summary.Gaps)),
+		Overlaps:              make([]model.CareerOverlap, len(summary.Overlaps)),
+		TotalExperienceDays:   summary.TotalDays,
+		TotalExperienceMonths: summary.TotalMonths,
+	}
+	for i, g := range summary.Gaps {
+		timeline.Gaps[i] = model.CareerGap{From: g.From, To: g.To, Days: g.Days}
+	}
+	for i, o := range summary.Overlaps {
+		timeline.Overlaps[i] = model.CareerOverlap{
+			PekerjaanIDs: []int{pekerjaan[o.First].ID, pekerjaan[o.Second].ID},
+			Days:         o.Days,
+		}
+	}
+	return timeline
+}
+
+// careerPeriod -> pekerjaan dalam bentuk yang diperiksa aturan karier
+func careerPeriod(p model.PekerjaanAlumni) helper.CareerPeriod {
+	return helper.CareerPeriod{
+		ID:         strconv.Itoa(p.ID),
+		Label:      p.PosisiJabatan + " di " + p.NamaPerusahaan,
+		Start:      p.TanggalMulaiKerja,
+		End:        p.TanggalSelesaiKerja,
+		Aktif:      p.StatusPekerjaan == "aktif",
+		ParuhWaktu: p.ParuhWaktu,
+	}
+}
+
+func createCareerPeriod(req *model.CreatePekerjaanAlumniRepositoryRequest) helper.CareerPeriod {
+	return careerPeriod(model.PekerjaanAlumni{
+		NamaPerusahaan:      req.NamaPerusahaan,
+		PosisiJabatan:       req.PosisiJabatan,
+		TanggalMulaiKerja:   req.TanggalMulaiKerja,
+		TanggalSelesaiKerja: req.TanggalSelesaiKerja,
+		StatusPekerjaan:     req.StatusPekerjaan,
+		ParuhWaktu:          req.ParuhWaktu,
+	})
+}
+
+func updateCareerPeriod(id int, req *model.UpdatePekerjaanAlumniRepositoryRequest) helper.CareerPeriod {
+	return careerPeriod(model.PekerjaanAlumni{
+		ID:                  id,
+		NamaPerusahaan:      req.NamaPerusahaan,
+		PosisiJabatan:       req.PosisiJabatan,
+		TanggalMulaiKerja:   req.TanggalMulaiKerja,
+		TanggalSelesaiKerja: req.TanggalSelesaiKerja,
+		StatusPekerjaan:     req.StatusPekerjaan,
+		ParuhWaktu:          req.ParuhWaktu,
+	})
+}
+
+// lockAlumni -> kunci baris alumni sampai transaksi tx selesai sehingga pemeriksaan aturan karier
+// dan penyimpanan pekerjaan untuk alumni yang sama berjalan bergantian, tidak bisa sama-sama lolos
+func lockAlumni(tx repository.DBTX, alumniID int) *fiber.Error {
+	if err := repository.LockAlumni(tx, alumniID); err != nil {
+		if err == sql.ErrNoRows {
+			return fiber.NewError(fiber.StatusNotFound, "Alumni tidak ditemukan")
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, "Gagal mengunci data alumni: "+err.Error())
+	}
+	return nil
+}
+
+// checkCareerRules -> 409 bila candidate bertabrakan dengan pekerjaan lain milik alumni menurut
+// aturan CAREER_*; pekerjaan di tempat sampah tidak ikut diperiksa. pending -> pekerjaan yang belum
+// tersimpan (baris lain di file import) dan menggantikan pekerjaan tersimpan dengan ID yang sama.
+func checkCareerRules(db repository.DBTX, alumniID int, candidate helper.CareerPeriod, pending ...helper.CareerPeriod) *fiber.Error {
+	pekerjaan, err := repository.GetPekerjaanByAlumniID(db, alumniID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "Gagal mengambil riwayat pekerjaan alumni: "+err.Error())
+	}
+	if err := helper.CareerRulesFromEnv().Check(candidate, helper.MergeCareerPeriods(careerPeriods(pekerjaan), pending)); err != nil {
+		return fiber.NewError(fiber.StatusConflict, "Riwayat pekerjaan tidak valid: "+err.Error())
+	}
+	return nil
+}
+
+func careerPeriods(pekerjaan []model.PekerjaanAlumni) []helper.CareerPeriod {
+	periods := make([]helper.CareerPeriod, len(pekerjaan))
+	for i, p := range pekerjaan {
+		periods[i] = careerPeriod(p)
+	}
+	return periods
+}
+
+// saveWithCareerRules -> kunci alumni, checkCareerRules, lalu save dalam satu transaksi. Pelanggaran
+// aturan dikembalikan sebagai *fiber.Error, error lain berasal dari save atau transaksi.
+func saveWithCareerRules(db *sql.DB, alumniID int, candidate helper.CareerPeriod, save func(tx repository.DBTX) (*model.PekerjaanAlumni, error)) (*model.PekerjaanAlumni, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if ferr := lockAlumni(tx, alumniID); ferr != nil {
+		return nil, ferr
+	}
+	if ferr := checkCareerRules(tx, alumniID, candidate); ferr != nil {
+		return nil, ferr
+	}
+	pekerjaan, err := save(tx)
+	if err != nil {
+		return nil, err
+	}
+	return pekerjaan, tx.Commit()
+}
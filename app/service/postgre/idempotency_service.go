@@ -0,0 +1,13 @@
+package postgre
+
+import (
+	"database/sql"
+	repository "go-fiber/app/repository/postgre"
+	"go-fiber/helper"
+)
+
+// IdempotencyStore -> middleware.IdempotencyStore: response disimpan di database agar retry
+// yang masuk ke instance lain tetap di-replay
+func IdempotencyStore(db *sql.DB) helper.IdempotencyStore {
+	return repository.NewIdempotencyStore(db)
+}
@@ -0,0 +1,345 @@
+package postgre
+
+import (
+	"database/sql"
+	"errors"
+	model "go-fiber/app/model/postgre"
+	repository "go-fiber/app/repository/postgre"
+	"go-fiber/helper"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// accountDeletionReasonMaxLength -> batas panjang alasan / catatan permintaan hapus akun
+const accountDeletionReasonMaxLength = 1000
+
+func meError(c *fiber.Ctx, status int, message string) error {
+	return c.Status(status).JSON(fiber.Map{"success": false, "message": message})
+}
+
+// GetMeService -> GET /me: data alumni yang sedang login, dibaca dari database (bukan hanya isi token
+// seperti /profile), beserta permintaan hapus akun yang masih pending
+func GetMeService(c *fiber.Ctx, db *sql.DB) error {
+	userID, _ := c.Locals("user_id").(int)
+	alumni, err := repository.GetAlumniByID(db, userID)
+	if err == sql.ErrNoRows {
+		return meError(c, fiber.StatusNotFound, "Alumni tidak ditemukan")
+	}
+	if err != nil {
+		return meError(c, fiber.StatusInternalServerError, "Gagal mengambil data alumni: "+err.Error())
+	}
+	pending, err := repository.GetPendingAccountDeletion(db, userID)
+	if err != nil && err != sql.ErrNoRows {
+		return meError(c, fiber.StatusInternalServerError, "Gagal mengambil permintaan hapus akun: "+err.Error())
+	}
+
+	c.Set(fiber.HeaderETag, helper.ETag(alumni.Version))
+	return c.Status(fiber.StatusOK).JSON(model.MeProfileResponse{
+		Success: true,
+		Message: "Berhasil mengambil profil",
+		Data:    model.MeProfile{Alumni: *alumni, DeletionRequest: pending},
+	})
+}
+
+// PatchMeService -> PATCH /me: alumni mengubah profilnya sendiri dengan JSON Merge Patch atau JSON
+// Patch. Hanya field di MePatchDocument (no_telepon, alamat) yang boleh diubah; field lain ditolak 400.
+func PatchMeService(c *fiber.Ctx, db *sql.DB) error {
+	format, ferr := patchFormat(c)
+	if ferr != nil {
+		return meError(c, ferr.Code, ferr.Message)
+	}
+	expectedVersion, ferr := ifMatchVersion(c)
+	if ferr != nil {
+		return meError(c, ferr.Code, ferr.Message)
+	}
+
+	userID, _ := c.Locals("user_id").(int)
+	existing, err := repository.GetAlumniByID(db, userID)
+	if err == sql.ErrNoRows {
+		return meError(c, fiber.StatusNotFound, "Alumni tidak ditemukan")
+	}
+	if err != nil {
+		return meError(c, fiber.StatusInternalServerError, "Gagal mengambil data alumni: "+err.Error())
+	}
+	if versionMismatch(c, expectedVersion, existing.Version) {
+		return meError(c, fiber.StatusPreconditionFailed, versionConflictMessage)
+	}
+
+	var doc model.MePatchDocument
+	current := model.MePatchDocument{NoTelepon: existing.NoTelepon, Alamat: existing.Alamat}
+	if ferr := applyPatch(c, format, current, &doc); ferr != nil {
+		return meError(c, ferr.Code, ferr.Message)
+	}
+
+	// String kosong sama dengan null: kolom dikosongkan
+	repoReq := &model.UpdateAlumniRepositoryRequest{}
+	if doc.NoTelepon != nil && strings.TrimSpace(*doc.NoTelepon) != "" {
+		noTelepon := strings.TrimSpace(*doc.NoTelepon)
+		repoReq.NoTelepon = &noTelepon
+	} else {
+		repoReq.Unset = append(repoReq.Unset, "no_telepon")
+	}
+	if doc.Alamat != nil && strings.TrimSpace(*doc.Alamat) != "" {
+		alamat := strings.TrimSpace(*doc.Alamat)
+		repoReq.Alamat = &alamat
+	} else {
+		repoReq.Unset = append(repoReq.Unset, "alamat")
+	}
+
+	alumni, err := repository.UpdateAlumni(db, userID, repoReq, expectedVersion)
+	if errors.Is(err, helper.ErrVersionConflict) {
+		return meError(c, fiber.StatusPreconditionFailed, versionConflictMessage)
+	}
+	if err != nil {
+		return meError(c, fiber.StatusInternalServerError, "Gagal mengupdate profil: "+err.Error())
+	}
+	recordAudit(c, db, auditEntry(helper.AuditActionUpdate, helper.AuditEntityAlumni, strconv.Itoa(userID), existing, alumni))
+
+	c.Set(fiber.HeaderETag, helper.ETag(alumni.Version))
+	return c.Status(fiber.StatusOK).JSON(model.UpdateAlumniResponse{
+		Success: true,
+		Message: "Berhasil mengupdate profil",
+		Data:    *alumni,
+	})
+}
+
+// GetMyPekerjaanService -> GET /me/pekerjaan: pekerjaan milik alumni yang sedang login
+func GetMyPekerjaanService(c *fiber.Ctx, db *sql.DB) error {
+	userID, _ := c.Locals("user_id").(int)
+	pekerjaan, err := repository.GetPekerjaanByAlumniID(db, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.GetPekerjaanAlumniByAlumniIDResponse{
+			Success: false,
+			Message: "Gagal mengambil data pekerjaan: " + err.Error(),
+			Data:    []model.PekerjaanAlumni{},
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(model.GetPekerjaanAlumniByAlumniIDResponse{
+		Success: true,
+		Message: "Berhasil mengambil data pekerjaan",
+		Data:    pekerjaan,
+	})
+}
+
+// CreateMyPekerjaanService -> POST /me/pekerjaan: tambah pekerjaan untuk diri sendiri; alumni_id
+// selalu diambil dari token, nilai di body diabaikan
+func CreateMyPekerjaanService(c *fiber.Ctx, db *sql.DB) error {
+	var req model.CreatePekerjaanAlumniRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.CreatePekerjaanAlumniResponse{
+			Success: false,
+			Message: "Format data tidak valid: " + err.Error(),
+			Data:    model.PekerjaanAlumni{},
+		})
+	}
+	req.AlumniID, _ = c.Locals("user_id").(int)
+	return createPekerjaan(c, db, &req)
+}
+
+// PatchMyPekerjaanService -> PATCH /me/pekerjaan/:id: ubah pekerjaan milik sendiri; pekerjaan alumni
+// lain dijawab 404
+func PatchMyPekerjaanService(c *fiber.Ctx, db *sql.DB) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: "ID tidak valid",
+			Data:    model.PekerjaanAlumni{},
+		})
+	}
+	userID, _ := c.Locals("user_id").(int)
+	return patchPekerjaan(c, db, id, &userID)
+}
+
+// accountDeletionText -> alasan / catatan opsional; string kosong menjadi nil
+func accountDeletionText(s *string, field string) (*string, *fiber.Error) {
+	if s == nil || strings.TrimSpace(*s) == "" {
+		return nil, nil
+	}
+	text := strings.TrimSpace(*s)
+	if len([]rune(text)) > accountDeletionReasonMaxLength {
+		return nil, fiber.NewError(fiber.StatusBadRequest, field+" maksimal "+strconv.Itoa(accountDeletionReasonMaxLength)+" karakter")
+	}
+	return &text, nil
+}
+
+// RequestAccountDeletionService -> DELETE /me: ajukan penghapusan akun. Akun belum dihapus sampai
+// admin menyetujui permintaannya; satu alumni hanya punya satu permintaan pending.
+func RequestAccountDeletionService(c *fiber.Ctx, db *sql.DB) error {
+	var req model.CreateAccountDeletionRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return meError(c, fiber.StatusBadRequest, "Request body tidak valid")
+		}
+	}
+	alasan, ferr := accountDeletionText(req.Alasan, "Alasan")
+	if ferr != nil {
+		return meError(c, ferr.Code, ferr.Message)
+	}
+
+	userID, _ := c.Locals("user_id").(int)
+	alumni, err := repository.GetAlumniByID(db, userID)
+	if err == sql.ErrNoRows {
+		return meError(c, fiber.StatusNotFound, "Alumni tidak ditemukan")
+	}
+	if err != nil {
+		return meError(c, fiber.StatusInternalServerError, "Gagal mengambil data alumni: "+err.Error())
+	}
+
+	deletion := &model.AccountDeletionRequest{AlumniID: &alumni.ID, NIM: alumni.NIM, Nama: alumni.Nama, Alasan: alasan}
+	created, err := repository.CreateAccountDeletion(db, deletion)
+	if err != nil {
+		return meError(c, fiber.StatusInternalServerError, "Gagal menyimpan permintaan hapus akun: "+err.Error())
+	}
+	if !created {
+		pending, err := repository.GetPendingAccountDeletion(db, userID)
+		if err != nil {
+			return meError(c, fiber.StatusInternalServerError, "Gagal mengambil permintaan hapus akun: "+err.Error())
+		}
+		return c.Status(fiber.StatusConflict).JSON(model.AccountDeletionResponse{
+			Success: false,
+			Message: "Permintaan hapus akun sudah diajukan dan menunggu persetujuan admin",
+			Data:    *pending,
+		})
+	}
+	recordAudit(c, db, auditEntry(helper.AuditActionCreate, helper.AuditEntityAccountDeletion, strconv.Itoa(deletion.ID), nil, deletion))
+
+	return c.Status(fiber.StatusAccepted).JSON(model.AccountDeletionResponse{
+		Success: true,
+		Message: "Permintaan hapus akun diterima dan menunggu persetujuan admin",
+		Data:    *deletion,
+	})
+}
+
+// ListAccountDeletionsService -> GET /account-deletions: daftar permintaan hapus akun (?status=)
+func ListAccountDeletionsService(c *fiber.Ctx, db *sql.DB) error {
+	status := strings.TrimSpace(c.Query("status"))
+	switch status {
+	case "", model.AccountDeletionPending, model.AccountDeletionApproved, model.AccountDeletionRejected:
+	default:
+		return meError(c, fiber.StatusBadRequest, "Status harus pending, approved, atau rejected")
+	}
+	requests, err := repository.ListAccountDeletions(db, status)
+	if err != nil {
+		return meError(c, fiber.StatusInternalServerError, "Gagal mengambil permintaan hapus akun: "+err.Error())
+	}
+	return c.Status(fiber.StatusOK).JSON(model.ListAccountDeletionResponse{
+		Success: true,
+		Message: "Berhasil mengambil permintaan hapus akun",
+		Data:    requests,
+	})
+}
+
+// processAccountDeletionInput -> id permintaan dan catatan admin dari request approve / reject
+func processAccountDeletionInput(c *fiber.Ctx) (int, *string, *fiber.Error) {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return 0, nil, fiber.NewError(fiber.StatusBadRequest, "ID tidak valid")
+	}
+	var req model.ProcessAccountDeletionRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return 0, nil, fiber.NewError(fiber.StatusBadRequest, "Request body tidak valid")
+		}
+	}
+	catatan, ferr := accountDeletionText(req.Catatan, "Catatan")
+	if ferr != nil {
+		return 0, nil, ferr
+	}
+	return id, catatan, nil
+}
+
+// accountDeletionNotPending -> 404 bila permintaan tidak ada, 409 bila sudah diproses
+func accountDeletionNotPending(c *fiber.Ctx, db *sql.DB, id int) error {
+	existing, err := repository.GetAccountDeletionByID(db, id)
+	if err == sql.ErrNoRows {
+		return meError(c, fiber.StatusNotFound, "Permintaan hapus akun tidak ditemukan")
+	}
+	if err != nil {
+		return meError(c, fiber.StatusInternalServerError, "Gagal mengambil permintaan hapus akun: "+err.Error())
+	}
+	return c.Status(fiber.StatusConflict).JSON(model.AccountDeletionResponse{
+		Success: false,
+		Message: "Permintaan hapus akun sudah diproses",
+		Data:    *existing,
+	})
+}
+
+// ApproveAccountDeletionService -> POST /account-deletions/:id/approve: setujui permintaan dan hapus
+// akun alumni beserta datanya (sama seperti DELETE /alumni/:id) dalam satu transaksi
+func ApproveAccountDeletionService(c *fiber.Ctx, db *sql.DB) error {
+	id, catatan, ferr := processAccountDeletionInput(c)
+	if ferr != nil {
+		return meError(c, ferr.Code, ferr.Message)
+	}
+	adminID, _ := c.Locals("user_id").(int)
+
+	tx, err := db.Begin()
+	if err != nil {
+		return meError(c, fiber.StatusInternalServerError, "Gagal memulai transaksi: "+err.Error())
+	}
+	defer tx.Rollback()
+
+	deletion, err := repository.FinishAccountDeletion(tx, id, model.AccountDeletionApproved, adminID, catatan)
+	if err == sql.ErrNoRows {
+		tx.Rollback()
+		return accountDeletionNotPending(c, db, id)
+	}
+	if err != nil {
+		return meError(c, fiber.StatusInternalServerError, "Gagal memproses permintaan hapus akun: "+err.Error())
+	}
+	var alumni *model.Alumni
+	if deletion.AlumniID != nil {
+		alumni, err = repository.GetAlumniByID(tx, *deletion.AlumniID)
+		if err != nil && err != sql.ErrNoRows {
+			return meError(c, fiber.StatusInternalServerError, "Gagal mengambil data alumni: "+err.Error())
+		}
+		if err := repository.DeleteAlumni(tx, *deletion.AlumniID); err != nil {
+			return meError(c, fiber.StatusInternalServerError, "Gagal menghapus alumni: "+err.Error())
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return meError(c, fiber.StatusInternalServerError, "Gagal menyimpan transaksi: "+err.Error())
+	}
+
+	if alumni != nil {
+		entry := auditEntry(helper.AuditActionDelete, helper.AuditEntityAlumni, strconv.Itoa(alumni.ID), alumni, nil)
+		entry.Metadata = map[string]any{"account_deletion_id": deletion.ID}
+		recordAudit(c, db, entry)
+	}
+	recordAudit(c, db, auditEntry(helper.AuditActionUpdate, helper.AuditEntityAccountDeletion, strconv.Itoa(deletion.ID), nil, deletion))
+
+	// alumni_id sudah di-set NULL oleh foreign key setelah akun dihapus
+	deletion.AlumniID = nil
+	return c.Status(fiber.StatusOK).JSON(model.AccountDeletionResponse{
+		Success: true,
+		Message: "Permintaan hapus akun disetujui, akun sudah dihapus",
+		Data:    *deletion,
+	})
+}
+
+// RejectAccountDeletionService -> POST /account-deletions/:id/reject: tolak permintaan; akun tetap ada
+func RejectAccountDeletionService(c *fiber.Ctx, db *sql.DB) error {
+	id, catatan, ferr := processAccountDeletionInput(c)
+	if ferr != nil {
+		return meError(c, ferr.Code, ferr.Message)
+	}
+	adminID, _ := c.Locals("user_id").(int)
+
+	deletion, err := repository.FinishAccountDeletion(db, id, model.AccountDeletionRejected, adminID, catatan)
+	if err == sql.ErrNoRows {
+		return accountDeletionNotPending(c, db, id)
+	}
+	if err != nil {
+		return meError(c, fiber.StatusInternalServerError, "Gagal memproses permintaan hapus akun: "+err.Error())
+	}
+	recordAudit(c, db, auditEntry(helper.AuditActionUpdate, helper.AuditEntityAccountDeletion, strconv.Itoa(deletion.ID), nil, deletion))
+
+	return c.Status(fiber.StatusOK).JSON(model.AccountDeletionResponse{
+		Success: true,
+		Message: "Permintaan hapus akun ditolak",
+		Data:    *deletion,
+	})
+}
@@ -0,0 +1,309 @@
+package postgre
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	model "go-fiber/app/model/postgre"
+	repository "go-fiber/app/repository/postgre"
+	"go-fiber/helper"
+	utils "go-fiber/utils/postgre"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// mfaRequired -> 2FA wajib untuk admin, opsional (bila diaktifkan) untuk role lain
+func mfaRequired(role string, enabled bool) bool {
+	return enabled || role == "admin"
+}
+
+func mfaError(c *fiber.Ctx, status int, message string) error {
+	return c.Status(status).JSON(model.MFAResponse{Success: false, Message: message})
+}
+
+// checkMFARateLimit -> batasi percobaan kode 2FA per akun dengan limit akun login
+func checkMFARateLimit(c *fiber.Ctx, db *sql.DB, alumniID int) (bool, error) {
+	cfg, limiter := loginProtection(db)
+	count, retryAfter, err := limiter.Hit("login:mfa:"+strconv.Itoa(alumniID), cfg.Window)
+	if err != nil {
+		return false, mfaError(c, fiber.StatusInternalServerError, "Gagal memeriksa rate limit")
+	}
+	if count > cfg.AccountLimit {
+		return false, tooManyAttempts(c, retryAfter, "Terlalu banyak percobaan kode 2FA, coba lagi nanti")
+	}
+	return true, nil
+}
+
+func resetMFARateLimit(db *sql.DB, alumniID int) {
+	_, limiter := loginProtection(db)
+	limiter.Reset("login:mfa:" + strconv.Itoa(alumniID))
+}
+
+// verifySecondFactor -> kode 6 digit dicocokkan dengan TOTP (sekali pakai per step),
+// selain itu dianggap kode pemulihan
+func verifySecondFactor(db *sql.DB, alumni *repository.MFAState, code string) (bool, error) {
+	code = strings.TrimSpace(code)
+	if len(code) == helper.TOTPDigits {
+		step, ok := helper.VerifyTOTP(alumni.TOTPSecret, code, time.Now())
+		if !ok {
+			return false, nil
+		}
+		return repository.UseTOTPStep(db, alumni.ID, step)
+	}
+	return repository.ConsumeRecoveryCode(db, alumni.ID, helper.HashRecoveryCode(code))
+}
+
+// loadMFAAlumni -> alumni pemilik token pada request
+func loadMFAAlumni(c *fiber.Ctx, db *sql.DB) (*repository.MFAState, error) {
+	userID, _ := c.Locals("user_id").(int)
+	alumni, err := repository.GetMFAState(db, userID)
+	if err == sql.ErrNoRows {
+		return nil, mfaError(c, fiber.StatusNotFound, "Alumni tidak ditemukan")
+	}
+	if err != nil {
+		return nil, mfaError(c, fiber.StatusInternalServerError, "Error database")
+	}
+	return alumni, nil
+}
+
+func fullLoginToken(alumni *repository.MFAState) (model.User, string, error) {
+	user := model.User{
+		ID:             alumni.ID,
+		Username:       alumni.Email,
+		Email:          alumni.Email,
+		Role:           alumni.Role,
+		SessionVersion: alumni.SessionVersion,
+	}
+	token, err := utils.GenerateToken(user)
+	return user, token, err
+}
+
+// MFALoginService -> POST /login/mfa: tukar token mfa_pending + kode 2FA dengan token penuh
+func MFALoginService(c *fiber.Ctx, db *sql.DB) error {
+	var req model.MFALoginRequest
+	if err := c.BodyParser(&req); err != nil {
+		return mfaError(c, fiber.StatusBadRequest, "Request body tidak valid")
+	}
+	if req.MFAToken == "" || strings.TrimSpace(req.Code) == "" {
+		return mfaError(c, fiber.StatusBadRequest, "mfa_token dan code harus diisi")
+	}
+	claims, err := utils.ValidateToken(req.MFAToken)
+	if err != nil || !claims.MFAPending {
+		return mfaError(c, fiber.StatusUnauthorized, "Token 2FA tidak valid atau expired")
+	}
+
+	if ok, err := checkMFARateLimit(c, db, claims.UserID); !ok {
+		return err
+	}
+	alumni, err := repository.GetMFAState(db, claims.UserID)
+	if err != nil && err != sql.ErrNoRows {
+		return mfaError(c, fiber.StatusInternalServerError, "Error database")
+	}
+	if alumni == nil || alumni.SessionVersion != claims.SessionVersion {
+		return mfaError(c, fiber.StatusUnauthorized, "Sesi sudah berakhir, silakan login ulang")
+	}
+	if !alumni.TOTPEnabled {
+		return mfaError(c, fiber.StatusForbidden, "2FA belum diaktifkan, lakukan enrollment terlebih dahulu")
+	}
+
+	ok, err := verifySecondFactor(db, alumni, req.Code)
+	if err != nil {
+		return mfaError(c, fiber.StatusInternalServerError, "Error database")
+	}
+	if !ok {
+		recordLoginAudit(c, db, helper.AuditActionLoginFailed, &alumni.ID, claims.Role, map[string]any{"method": "mfa", "reason": "invalid_code"})
+		return mfaError(c, fiber.StatusUnauthorized, "Kode 2FA tidak valid")
+	}
+	resetMFARateLimit(db, claims.UserID)
+
+	user, token, err := fullLoginToken(alumni)
+	if err != nil {
+		return mfaError(c, fiber.StatusInternalServerError, "Gagal generate token")
+	}
+	recordLoginAudit(c, db, helper.AuditActionLogin, &alumni.ID, claims.Role, map[string]any{"method": "mfa"})
+	return c.JSON(model.LoginResponse{
+		Success: true,
+		Message: "Login berhasil",
+		Data:    model.LoginData{User: user, Token: token},
+	})
+}
+
+// MFAEnrollService -> POST /me/mfa/enroll: buat secret TOTP baru (belum aktif sampai diverifikasi)
+func MFAEnrollService(c *fiber.Ctx, db *sql.DB) error {
+	alumni, err := loadMFAAlumni(c, db)
+	if alumni == nil {
+		return err
+	}
+	if alumni.TOTPEnabled {
+		return mfaError(c, fiber.StatusConflict, "2FA sudah aktif")
+	}
+
+	secret, err := helper.GenerateTOTPSecret()
+	if err != nil {
+		return mfaError(c, fiber.StatusInternalServerError, "Gagal membuat secret 2FA")
+	}
+	ok, err := repository.SetPendingTOTPSecret(db, alumni.ID, secret)
+	if err != nil {
+		return mfaError(c, fiber.StatusInternalServerError, "Gagal menyimpan secret 2FA")
+	}
+	if !ok {
+		return mfaError(c, fiber.StatusConflict, "2FA sudah aktif")
+	}
+
+	return c.JSON(model.MFAEnrollResponse{
+		Success: true,
+		Message: "Pindai otpauth_uri di aplikasi authenticator lalu verifikasi kodenya",
+		Data:    model.MFAEnrollData{Secret: secret, OTPAuthURI: helper.TOTPURI(alumni.Email, secret)},
+	})
+}
+
+// MFAActivateService -> POST /me/mfa/verify: aktifkan 2FA dengan kode dari secret enrollment.
+// Bila dipanggil dengan token mfa_pending, token penuh ikut dikembalikan.
+func MFAActivateService(c *fiber.Ctx, db *sql.DB) error {
+	var req model.MFACodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return mfaError(c, fiber.StatusBadRequest, "Request body tidak valid")
+	}
+	if strings.TrimSpace(req.Code) == "" {
+		return mfaError(c, fiber.StatusBadRequest, "Kode harus diisi")
+	}
+
+	alumni, err := loadMFAAlumni(c, db)
+	if alumni == nil {
+		return err
+	}
+	if alumni.TOTPEnabled {
+		return mfaError(c, fiber.StatusConflict, "2FA sudah aktif")
+	}
+	if alumni.TOTPPendingSecret == "" {
+		return mfaError(c, fiber.StatusBadRequest, "Lakukan enrollment terlebih dahulu")
+	}
+	if ok, err := checkMFARateLimit(c, db, alumni.ID); !ok {
+		return err
+	}
+	step, ok := helper.VerifyTOTP(alumni.TOTPPendingSecret, req.Code, time.Now())
+	if !ok {
+		return mfaError(c, fiber.StatusBadRequest, "Kode 2FA tidak valid")
+	}
+
+	codes, err := helper.GenerateRecoveryCodes(helper.RecoveryCodeCount)
+	if err != nil {
+		return mfaError(c, fiber.StatusInternalServerError, "Gagal membuat kode pemulihan")
+	}
+	enabled, err := repository.EnableTOTP(db, alumni.ID, alumni.TOTPPendingSecret, step, helper.HashRecoveryCodes(codes))
+	if err != nil {
+		return mfaError(c, fiber.StatusInternalServerError, "Gagal mengaktifkan 2FA")
+	}
+	if !enabled {
+		return mfaError(c, fiber.StatusConflict, "Enrollment 2FA sudah berubah, ulangi enrollment")
+	}
+	resetMFARateLimit(db, alumni.ID)
+
+	data := model.MFARecoveryCodesData{RecoveryCodes: codes}
+	if pending, _ := c.Locals("mfa_pending").(bool); pending {
+		if _, data.Token, err = fullLoginToken(alumni); err != nil {
+			return mfaError(c, fiber.StatusInternalServerError, "Gagal generate token")
+		}
+	}
+	recordAccountAudit(c, db, alumni.ID, map[string]any{"mfa_enabled": true})
+	if data.Token != "" {
+		recordLoginAudit(c, db, helper.AuditActionLogin, &alumni.ID, "", map[string]any{"method": "mfa_enrollment"})
+	}
+	// Kode pemulihan hanya ditampilkan sekali, jangan disimpan di cache
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.JSON(model.MFARecoveryCodesResponse{
+		Success: true,
+		Message: "2FA berhasil diaktifkan, simpan kode pemulihan di tempat aman",
+		Data:    data,
+	})
+}
+
+// MFARecoveryCodesService -> POST /me/mfa/recovery-codes: buat ulang kode pemulihan
+func MFARecoveryCodesService(c *fiber.Ctx, db *sql.DB) error {
+	var req model.MFACodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return mfaError(c, fiber.StatusBadRequest, "Request body tidak valid")
+	}
+	if strings.TrimSpace(req.Code) == "" {
+		return mfaError(c, fiber.StatusBadRequest, "Kode harus diisi")
+	}
+
+	alumni, err := loadMFAAlumni(c, db)
+	if alumni == nil {
+		return err
+	}
+	if !alumni.TOTPEnabled {
+		return mfaError(c, fiber.StatusBadRequest, "2FA belum aktif")
+	}
+	if ok, err := checkMFARateLimit(c, db, alumni.ID); !ok {
+		return err
+	}
+	ok, err := verifySecondFactor(db, alumni, req.Code)
+	if err != nil {
+		return mfaError(c, fiber.StatusInternalServerError, "Error database")
+	}
+	if !ok {
+		return mfaError(c, fiber.StatusBadRequest, "Kode 2FA tidak valid")
+	}
+
+	codes, err := helper.GenerateRecoveryCodes(helper.RecoveryCodeCount)
+	if err != nil {
+		return mfaError(c, fiber.StatusInternalServerError, "Gagal membuat kode pemulihan")
+	}
+	if err := repository.ReplaceRecoveryCodes(db, alumni.ID, helper.HashRecoveryCodes(codes)); err != nil {
+		return mfaError(c, fiber.StatusInternalServerError, "Gagal menyimpan kode pemulihan")
+	}
+	resetMFARateLimit(db, alumni.ID)
+
+	// Kode pemulihan hanya ditampilkan sekali, jangan disimpan di cache
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.JSON(model.MFARecoveryCodesResponse{
+		Success: true,
+		Message: "Kode pemulihan baru dibuat, kode lama tidak berlaku lagi",
+		Data:    model.MFARecoveryCodesData{RecoveryCodes: codes},
+	})
+}
+
+// MFADisableService -> POST /me/mfa/disable: matikan 2FA (tidak tersedia untuk admin)
+func MFADisableService(c *fiber.Ctx, db *sql.DB) error {
+	var req model.MFADisableRequest
+	if err := c.BodyParser(&req); err != nil {
+		return mfaError(c, fiber.StatusBadRequest, "Request body tidak valid")
+	}
+	if req.Password == "" || strings.TrimSpace(req.Code) == "" {
+		return mfaError(c, fiber.StatusBadRequest, "Password dan kode harus diisi")
+	}
+	if role, _ := c.Locals("role").(string); mfaRequired(role, false) {
+		return mfaError(c, fiber.StatusForbidden, "2FA wajib untuk admin dan tidak dapat dimatikan")
+	}
+
+	alumni, err := loadMFAAlumni(c, db)
+	if alumni == nil {
+		return err
+	}
+	if !alumni.TOTPEnabled {
+		return mfaError(c, fiber.StatusBadRequest, "2FA belum aktif")
+	}
+	if !utils.CheckPassword(req.Password, alumni.Password) {
+		return mfaError(c, fiber.StatusBadRequest, "Password salah")
+	}
+	if ok, err := checkMFARateLimit(c, db, alumni.ID); !ok {
+		return err
+	}
+	ok, err := verifySecondFactor(db, alumni, req.Code)
+	if err != nil {
+		return mfaError(c, fiber.StatusInternalServerError, "Error database")
+	}
+	if !ok {
+		return mfaError(c, fiber.StatusBadRequest, "Kode 2FA tidak valid")
+	}
+
+	if err := repository.DisableTOTP(db, alumni.ID); err != nil {
+		return mfaError(c, fiber.StatusInternalServerError, "Gagal mematikan 2FA")
+	}
+	resetMFARateLimit(db, alumni.ID)
+	recordAccountAudit(c, db, alumni.ID, map[string]any{"mfa_enabled": false})
+	return c.JSON(model.MFAResponse{Success: true, Message: "2FA berhasil dimatikan"})
+}
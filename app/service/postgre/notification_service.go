@@ -0,0 +1,193 @@
+package postgre
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	model "go-fiber/app/model/postgre"
+	repository "go-fiber/app/repository/postgre"
+	"go-fiber/helper"
+)
+
+// Konfigurasi pengiriman email
+const (
+	emailDispatchBatch = 100 // maksimal email per run dispatcher
+	emailLockTimeout   = 2 * time.Minute
+	emailMaxAttempts   = 8
+	emailRetryBase     = time.Minute
+	emailRetryMax      = 6 * time.Hour
+
+	tracerReminderDefaultCron   = "0 8 1 8 *" // setiap 1 Agustus pukul 08:00
+	tracerReminderDefaultMonths = 12
+)
+
+// Job type notifikasi
+const (
+	JobTypeEmailDispatch  = "email.dispatch_outbox"
+	JobTypeTracerReminder = "email.tracer_reminder"
+)
+
+type tracerReminderPayload struct {
+	Months int `json:"months"`
+}
+
+var smtpNotConfiguredOnce sync.Once
+
+// newEmailOutbox -> render template ke entri outbox dengan bahasa dari EMAIL_LOCALE
+func newEmailOutbox(template, to string, alumniID *int, data map[string]interface{}, uniqueKey string) (*model.EmailOutbox, error) {
+	locale := helper.EmailLocaleFromEnv()
+	content, err := helper.RenderEmail(locale, template, data)
+	if err != nil {
+		return nil, err
+	}
+
+	email := &model.EmailOutbox{
+		AlumniID: alumniID,
+		Template: template,
+		Locale:   locale,
+		To:       to,
+		Subject:  content.Subject,
+		TextBody: content.Text,
+		HTMLBody: content.HTML,
+		Status:   model.EmailStatusPending,
+	}
+	if uniqueKey != "" {
+		email.UniqueKey = &uniqueKey
+	}
+	return email, nil
+}
+
+// enqueueEmail -> render lalu simpan ke outbox. db bisa berupa *sql.Tx agar email hanya
+// tersimpan bila perubahan data utama ikut di-commit.
+func enqueueEmail(db repository.DBTX, template, to string, alumniID *int, data map[string]interface{}, uniqueKey string) error {
+	email, err := newEmailOutbox(template, to, alumniID, data, uniqueKey)
+	if err != nil {
+		return err
+	}
+	_, err = repository.InsertEmailOutbox(db, email)
+	return err
+}
+
+// enqueueAccountCreated -> email ke alumni yang akunnya dibuat admin
+func enqueueAccountCreated(db repository.DBTX, alumni *model.Alumni) error {
+	return enqueueEmail(db, helper.EmailTemplateAccountCreated, alumni.Email, &alumni.ID, map[string]interface{}{
+		"Nama":  alumni.Nama,
+		"Email": alumni.Email,
+		"NIM":   alumni.NIM,
+	}, fmt.Sprintf("account_created:%d", alumni.ID))
+}
+
+// NotifyPasswordReset -> email berisi tautan reset password
+func NotifyPasswordReset(db repository.DBTX, alumni *model.Alumni, resetURL string, expiresAt time.Time) error {
+	return enqueueEmail(db, helper.EmailTemplatePasswordReset, alumni.Email, &alumni.ID, map[string]interface{}{
+		"Nama":      alumni.Nama,
+		"ResetURL":  resetURL,
+		"ExpiresAt": helper.FormatEmailDate(helper.EmailLocaleFromEnv(), expiresAt) + " " + expiresAt.Format("15:04"),
+	}, "")
+}
+
+// registerNotificationJobs -> dispatcher outbox setiap menit dan pengingat tracer study tahunan
+// (jadwal dari TRACER_REMINDER_CRON, ambang dari TRACER_REMINDER_MONTHS)
+func registerNotificationJobs(r *JobRunner) {
+	RegisterJobHandler(r, JobTypeEmailDispatch, func(ctx context.Context, _ struct{}) error {
+		return dispatchEmailOutbox(ctx, r.db)
+	})
+	RegisterJobHandler(r, JobTypeTracerReminder, func(ctx context.Context, p tracerReminderPayload) error {
+		return sendTracerReminders(r.db, p.Months)
+	})
+
+	if err := r.Schedule("email-dispatch", "* * * * *", JobTypeEmailDispatch, struct{}{}); err != nil {
+		log.Fatalf("Invalid job schedule: %v", err)
+	}
+	spec := os.Getenv("TRACER_REMINDER_CRON")
+	if spec == "" {
+		spec = tracerReminderDefaultCron
+	}
+	months, err := strconv.Atoi(os.Getenv("TRACER_REMINDER_MONTHS"))
+	if err != nil || months <= 0 {
+		months = tracerReminderDefaultMonths
+	}
+	if err := r.Schedule("tracer-reminder", spec, JobTypeTracerReminder, tracerReminderPayload{Months: months}); err != nil {
+		log.Fatalf("Invalid TRACER_REMINDER_CRON: %v", err)
+	}
+}
+
+// dispatchEmailOutbox -> kirim email pending lewat SMTP. Bila SMTP belum dikonfigurasi,
+// email tetap di outbox sampai SMTP_HOST diisi.
+func dispatchEmailOutbox(ctx context.Context, db *sql.DB) error {
+	cfg, err := helper.SMTPConfigFromEnv()
+	if err != nil {
+		smtpNotConfiguredOnce.Do(func() {
+			log.Printf("Email dispatch skipped: %v", err)
+		})
+		return nil
+	}
+	mailer := helper.SMTPMailer{Config: cfg}
+
+	for i := 0; i < emailDispatchBatch && ctx.Err() == nil; i++ {
+		email, err := repository.ClaimEmailOutbox(db, emailLockTimeout)
+		if err != nil {
+			return err
+		}
+		if email == nil {
+			return nil
+		}
+
+		sendErr := mailer.Send(helper.MailMessage{
+			To:      email.To,
+			Subject: email.Subject,
+			Text:    email.TextBody,
+			HTML:    email.HTMLBody,
+		})
+		switch {
+		case sendErr == nil:
+			err = repository.MarkEmailSent(db, email.ID)
+		case email.Attempts >= emailMaxAttempts:
+			log.Printf("Email %d to %s failed permanently: %v", email.ID, email.To, sendErr)
+			err = repository.MarkEmailFailed(db, email.ID, sendErr.Error())
+		default:
+			delay := helper.Backoff(email.Attempts, emailRetryBase, emailRetryMax)
+			err = repository.MarkEmailRetry(db, email.ID, delay, sendErr.Error())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendTracerReminders -> enqueue pengingat untuk alumni yang data pekerjaannya lebih lama dari
+// months bulan. unique_key per alumni per tahun mencegah email ganda saat job di-retry.
+func sendTracerReminders(db *sql.DB, months int) error {
+	if months <= 0 {
+		months = tracerReminderDefaultMonths
+	}
+	now := time.Now()
+	targets, err := repository.FindTracerReminderTargets(db, now.AddDate(0, -months, 0))
+	if err != nil {
+		return err
+	}
+
+	locale := helper.EmailLocaleFromEnv()
+	for _, t := range targets {
+		id := t.ID
+		key := fmt.Sprintf("tracer_reminder:%d:%d", id, now.Year())
+		err := enqueueEmail(db, helper.EmailTemplateTracerReminder, t.Email, &id, map[string]interface{}{
+			"Nama":        t.Nama,
+			"LastUpdated": helper.FormatEmailDate(locale, t.LastUpdated),
+		}, key)
+		if err != nil {
+			return err
+		}
+	}
+	if len(targets) > 0 {
+		log.Printf("Tracer study reminder queued for %d alumni", len(targets))
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package postgre
+
+import (
+	"database/sql"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	model "go-fiber/app/model/postgre"
+	repository "go-fiber/app/repository/postgre"
+	"go-fiber/helper"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// oidcStateCookie -> cookie berisi state, nonce, dan PKCE verifier antara login dan callback
+const oidcStateCookie = "oidc_state"
+
+var oidcClient struct {
+	mu       sync.Mutex
+	provider *helper.OIDCProvider
+}
+
+// oidcProvider -> provider hasil discovery, di-cache selama konfigurasi tidak berubah.
+// Discovery yang gagal dicoba lagi pada request berikutnya.
+func oidcProvider(c *fiber.Ctx) (*helper.OIDCProvider, error) {
+	cfg, err := helper.OIDCConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	oidcClient.mu.Lock()
+	defer oidcClient.mu.Unlock()
+
+	if p := oidcClient.provider; p != nil && p.Config.Issuer == cfg.Issuer && p.Config.ClientID == cfg.ClientID &&
+		p.Config.RedirectURL == cfg.RedirectURL {
+		p.Config = cfg
+		return p, nil
+	}
+	p, err := helper.DiscoverOIDCProvider(c.UserContext(), cfg)
+	if err != nil {
+		return nil, err
+	}
+	oidcClient.provider = p
+	return p, nil
+}
+
+func oidcError(c *fiber.Ctx, status int, message string) error {
+	return c.Status(status).JSON(fiber.Map{"error": message})
+}
+
+// OIDCLoginService -> GET /auth/oidc/login: redirect ke provider (authorization code + PKCE).
+// ?login_hint= diteruskan ke provider.
+func OIDCLoginService(c *fiber.Ctx, db *sql.DB) error {
+	provider, err := oidcProvider(c)
+	if err == helper.ErrOIDCNotConfigured {
+		return oidcError(c, fiber.StatusServiceUnavailable, "SSO belum dikonfigurasi")
+	}
+	if err != nil {
+		log.Printf("OIDC discovery failed: %v", err)
+		return oidcError(c, fiber.StatusBadGateway, "Provider SSO tidak dapat dihubungi")
+	}
+
+	state, err := helper.NewOIDCAuthState()
+	if err != nil {
+		return oidcError(c, fiber.StatusInternalServerError, "Gagal membuat state SSO")
+	}
+	signed, err := helper.SignOIDCState(provider.Config.StateSecret, state)
+	if err != nil {
+		return oidcError(c, fiber.StatusInternalServerError, "Gagal membuat state SSO")
+	}
+	c.Cookie(&fiber.Cookie{
+		Name:     oidcStateCookie,
+		Value:    signed,
+		Path:     "/",
+		Expires:  time.Now().Add(helper.OIDCStateTTL),
+		HTTPOnly: true,
+		Secure:   c.Protocol() == "https",
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+	return c.Redirect(provider.AuthCodeURL(state, c.Query("login_hint")), fiber.StatusFound)
+}
+
+// OIDCCallbackService -> GET /auth/oidc/callback: tukar code dengan ID token, tautkan identitas
+// ke alumni (sub yang sudah tertaut, email terverifikasi, atau NIM), lalu terbitkan JWT aplikasi
+func OIDCCallbackService(c *fiber.Ctx, db *sql.DB) error {
+	if e := c.Query("error"); e != "" {
+		return oidcError(c, fiber.StatusUnauthorized, "Login SSO dibatalkan atau ditolak: "+e)
+	}
+	code, stateParam := c.Query("code"), c.Query("state")
+	if code == "" || stateParam == "" {
+		return oidcError(c, fiber.StatusBadRequest, "Parameter code dan state harus diisi")
+	}
+
+	provider, err := oidcProvider(c)
+	if err == helper.ErrOIDCNotConfigured {
+		return oidcError(c, fiber.StatusServiceUnavailable, "SSO belum dikonfigurasi")
+	}
+	if err != nil {
+		log.Printf("OIDC discovery failed: %v", err)
+		return oidcError(c, fiber.StatusBadGateway, "Provider SSO tidak dapat dihubungi")
+	}
+
+	// State hanya dipakai sekali
+	state, err := helper.ParseOIDCState(provider.Config.StateSecret, c.Cookies(oidcStateCookie))
+	c.ClearCookie(oidcStateCookie)
+	if err != nil || state.State != stateParam {
+		return oidcError(c, fiber.StatusBadRequest, "State SSO tidak valid atau kedaluwarsa, silakan ulangi login")
+	}
+
+	rawIDToken, err := provider.Exchange(c.UserContext(), code, state.Verifier)
+	if err != nil {
+		log.Printf("OIDC code exchange failed: %v", err)
+		return oidcError(c, fiber.StatusUnauthorized, "Gagal menukar kode SSO")
+	}
+	identity, err := provider.VerifyIDToken(c.UserContext(), rawIDToken, state.Nonce)
+	if err != nil {
+		log.Printf("OIDC ID token rejected: %v", err)
+		return oidcError(c, fiber.StatusUnauthorized, "ID token SSO tidak valid")
+	}
+
+	alumni, linked, err := linkOIDCAlumni(db, identity)
+	if err != nil {
+		return oidcError(c, fiber.StatusInternalServerError, "Error database")
+	}
+	if alumni == nil {
+		recordLoginAudit(c, db, helper.AuditActionLoginFailed, nil, "", map[string]any{"method": "oidc", "email": identity.Email, "reason": "unlinked_identity"})
+		return oidcError(c, fiber.StatusForbidden, "Akun SSO tidak terhubung dengan data alumni")
+	}
+	if !linked {
+		recordLoginAudit(c, db, helper.AuditActionLoginFailed, &alumni.ID, "", map[string]any{"method": "oidc", "reason": "identity_conflict"})
+		return oidcError(c, fiber.StatusConflict, "Alumni sudah tertaut dengan akun SSO lain")
+	}
+
+	user := model.User{
+		ID:             alumni.ID,
+		Username:       alumni.Email,
+		Email:          alumni.Email,
+		Role:           alumni.Role,
+		SessionVersion: alumni.SessionVersion,
+	}
+	return issueLoginResponse(c, db, user, alumni.TOTPEnabled, "oidc")
+}
+
+// linkOIDCAlumni -> cari alumni untuk identitas SSO: yang sudah tertaut lewat sub, lalu email
+// terverifikasi, lalu claim NIM. Tautan baru disimpan; linked false bila alumni sudah
+// tertaut ke identitas lain.
+func linkOIDCAlumni(db *sql.DB, identity *helper.OIDCIdentity) (*repository.LoginState, bool, error) {
+	alumni, err := repository.GetLoginStateByOIDCSubject(db, identity.Issuer, identity.Subject)
+	if err == nil {
+		return alumni, true, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, false, err
+	}
+
+	if identity.EmailVerified && identity.Email != "" {
+		alumni, err = repository.GetLoginStateByEmailFold(db, strings.TrimSpace(identity.Email))
+		if err != nil && err != sql.ErrNoRows {
+			return nil, false, err
+		}
+	}
+	if alumni == nil && identity.NIM != "" {
+		alumni, err = repository.GetLoginStateByNIM(db, identity.NIM)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, false, err
+		}
+	}
+	if alumni == nil {
+		return nil, false, nil
+	}
+
+	linked, err := repository.LinkOIDCIdentity(db, alumni.ID, identity.Issuer, identity.Subject)
+	if err != nil {
+		return nil, false, err
+	}
+	return alumni, linked, nil
+}
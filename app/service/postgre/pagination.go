@@ -0,0 +1,69 @@
+package postgre
+
+import (
+	"strconv"
+
+	model "go-fiber/app/model/postgre"
+	"go-fiber/helper"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// listPage -> parameter pagination sebuah listing: mode offset (?page) atau mode cursor (?after / ?before)
+type listPage struct {
+	Page      int
+	Limit     int
+	Offset    int
+	Keyset    *helper.Keyset
+	WithTotal bool
+	cursor    helper.Cursor
+}
+
+// parseListPage membaca page, limit, after, before, dan with_total. Cursor diverifikasi
+// tanda tangannya dan harus dibuat dengan sortBy, order, search, dan filter yang sama.
+func parseListPage(c *fiber.Ctx, sortBy, order, valueType, idType string) (*listPage, error) {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+
+	p := &listPage{
+		Page:      page,
+		Limit:     limit,
+		Offset:    (page - 1) * limit,
+		WithTotal: c.QueryBool("with_total"),
+		cursor:    helper.Cursor{SortBy: sortBy, Order: order, Scope: helper.CursorScope(c.Queries())},
+	}
+
+	token, before := c.Query("after"), false
+	if token == "" {
+		token, before = c.Query("before"), true
+	}
+	if token == "" {
+		return p, nil
+	}
+
+	keyset, err := helper.ParseKeyset(token, before, p.cursor, valueType, idType)
+	if err != nil {
+		return nil, err
+	}
+	p.Keyset = keyset
+	return p, nil
+}
+
+// meta -> MetaInfo untuk halaman ini; total nil berarti tidak dihitung
+func (p *listPage) meta(total *int, hasMore bool, first, last *helper.CursorPosition) model.MetaInfo {
+	meta := model.MetaInfo{Limit: p.Limit, Total: total}
+	if p.Keyset == nil {
+		meta.Page = p.Page
+		if total != nil {
+			meta.Pages = (*total + p.Limit - 1) / p.Limit
+		}
+	}
+	meta.NextCursor, meta.PrevCursor = helper.PageCursors(p.cursor, p.Keyset, hasMore, p.Keyset == nil && p.Page > 1, first, last)
+	return meta
+}
@@ -2,9 +2,10 @@ package postgre
 
 import (
 	"database/sql"
-	"fmt"
+	"errors"
 	model "go-fiber/app/model/postgre"
 	repository "go-fiber/app/repository/postgre"
+	"go-fiber/helper"
 	"strconv"
 	"strings"
 	"time"
@@ -16,30 +17,23 @@ import (
 
 func GetAllPekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 	// Parse query parameters
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	limit, _ := strconv.Atoi(c.Query("limit", "10"))
 	sortBy := c.Query("sortBy", "id")
 	order := c.Query("order", "asc")
 	search := c.Query("search", "")
 
-	// Hitung offset untuk pagination
-	offset := (page - 1) * limit
-
 	// Validasi input
-	sortByWhitelist := map[string]bool{"id": true, "nama_perusahaan": true, "posisi_jabatan": true, "bidang_industri": true, "lokasi_kerja": true, "tanggal_mulai_kerja": true, "status_pekerjaan": true, "created_at": true}
-	if !sortByWhitelist[sortBy] {
+	if _, ok := repository.PekerjaanSortTypes[sortBy]; !ok {
 		sortBy = "id"
 	}
 	if strings.ToLower(order) != "desc" {
 		order = "asc"
 	}
 
-	// Ambil data dari repository
-	pekerjaan, err := repository.GetPekerjaanRepo(db, search, sortBy, order, limit, offset)
+	filters, err := helper.ParseFilters(c.Queries(), repository.PekerjaanFilterFields)
 	if err != nil {
-		return c.Status(500).JSON(model.GetAllPekerjaanResponse{
+		return c.Status(400).JSON(model.GetAllPekerjaanResponse{
 			Success: false,
-			Message: "Failed to fetch pekerjaan",
+			Message: err.Error(),
 			Data: model.PekerjaanData{
 				Items: []model.PekerjaanAlumni{},
 				Meta:  model.MetaInfo{},
@@ -47,11 +41,30 @@ func GetAllPekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 		})
 	}
 
-	total, err := repository.CountPekerjaanRepo(db, search)
+	page, err := parseListPage(c, sortBy, order, repository.PekerjaanSortTypes[sortBy], helper.FilterTypeInt)
+	if err != nil {
+		return c.Status(400).JSON(model.GetAllPekerjaanResponse{
+			Success: false,
+			Message: err.Error(),
+			Data: model.PekerjaanData{
+				Items: []model.PekerjaanAlumni{},
+				Meta:  model.MetaInfo{},
+			},
+		})
+	}
+
+	// Ambil data dari repository: mode cursor tanpa skip/offset, mode page tetap pakai offset
+	var pekerjaan []model.PekerjaanAlumni
+	var hasMore bool
+	if page.Keyset != nil {
+		pekerjaan, hasMore, err = repository.GetPekerjaanKeysetRepo(db, search, filters, sortBy, order, page.Keyset, page.Limit)
+	} else {
+		pekerjaan, err = repository.GetPekerjaanRepo(db, search, filters, sortBy, order, page.Limit, page.Offset)
+	}
 	if err != nil {
 		return c.Status(500).JSON(model.GetAllPekerjaanResponse{
 			Success: false,
-			Message: "Failed to count pekerjaan",
+			Message: "Failed to fetch pekerjaan",
 			Data: model.PekerjaanData{
 				Items: []model.PekerjaanAlumni{},
 				Meta:  model.MetaInfo{},
@@ -59,27 +72,78 @@ func GetAllPekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 		})
 	}
 
+	// Total selalu dihitung pada mode page, pada mode cursor hanya bila with_total=true
+	var total *int
+	if page.Keyset == nil || page.WithTotal {
+		count, err := repository.CountPekerjaanRepo(db, search, filters)
+		if err != nil {
+			return c.Status(500).JSON(model.GetAllPekerjaanResponse{
+				Success: false,
+				Message: "Failed to count pekerjaan",
+				Data: model.PekerjaanData{
+					Items: []model.PekerjaanAlumni{},
+					Meta:  model.MetaInfo{},
+				},
+			})
+		}
+		total = &count
+		if page.Keyset == nil {
+			hasMore = page.Offset+len(pekerjaan) < count
+		}
+	}
+
+	var first, last *helper.CursorPosition
+	if len(pekerjaan) > 0 {
+		first = pekerjaanPosition(pekerjaan[0], sortBy)
+		last = pekerjaanPosition(pekerjaan[len(pekerjaan)-1], sortBy)
+	}
+
+	for i := range pekerjaan {
+		maskSalary(c, &pekerjaan[i])
+	}
+
+	meta := page.meta(total, hasMore, first, last)
+	meta.SortBy = sortBy
+	meta.Order = order
+	meta.Search = search
+
 	// Buat response pakai model
 	response := model.GetAllPekerjaanResponse{
 		Success: true,
 		Message: "Berhasil mengambil data pekerjaan",
 		Data: model.PekerjaanData{
 			Items: pekerjaan,
-			Meta: model.MetaInfo{
-				Page:   page,
-				Limit:  limit,
-				Total:  total,
-				Pages:  (total + limit - 1) / limit,
-				SortBy: sortBy,
-				Order:  order,
-				Search: search,
-			},
+			Meta:  meta,
 		},
 	}
 
 	return c.JSON(response)
 }
 
+// pekerjaanPosition -> nilai kolom sort + id pekerjaan untuk membentuk cursor
+func pekerjaanPosition(p model.PekerjaanAlumni, sortBy string) *helper.CursorPosition {
+	var value interface{}
+	switch sortBy {
+	case "nama_perusahaan":
+		value = p.NamaPerusahaan
+	case "posisi_jabatan":
+		value = p.PosisiJabatan
+	case "bidang_industri":
+		value = p.BidangIndustri
+	case "lokasi_kerja":
+		value = p.LokasiKerja
+	case "tanggal_mulai_kerja":
+		value = p.TanggalMulaiKerja
+	case "status_pekerjaan":
+		value = p.StatusPekerjaan
+	case "created_at":
+		value = p.CreatedAt
+	default:
+		value = p.ID
+	}
+	return &helper.CursorPosition{Value: value, ID: p.ID}
+}
+
 func GetPekerjaanByIDService(c *fiber.Ctx, db *sql.DB) error {
 	idStr := c.Params("id")
 	id, err := strconv.Atoi(idStr)
@@ -107,6 +171,8 @@ func GetPekerjaanByIDService(c *fiber.Ctx, db *sql.DB) error {
 		})
 	}
 
+	maskSalary(c, pekerjaan)
+	c.Set(fiber.HeaderETag, helper.ETag(pekerjaan.Version))
 	return c.Status(fiber.StatusOK).JSON(model.GetPekerjaanAlumniByIDResponse{
 		Success: true,
 		Message: "Berhasil mengambil data pekerjaan",
@@ -151,6 +217,10 @@ func GetPekerjaanByAlumniIDService(c *fiber.Ctx, db *sql.DB) error {
 		})
 	}
 
+	for i := range pekerjaan {
+		maskSalary(c, &pekerjaan[i])
+	}
+
 	return c.Status(fiber.StatusOK).JSON(model.GetPekerjaanAlumniByAlumniIDResponse{
 		Success: true,
 		Message: "Berhasil mengambil data pekerjaan alumni",
@@ -158,6 +228,72 @@ func GetPekerjaanByAlumniIDService(c *fiber.Ctx, db *sql.DB) error {
 	})
 }
 
+// pekerjaanCreateRequest -> validasi request create pekerjaan dan parse tanggalnya; dipakai create
+// biasa dan batch
+func pekerjaanCreateRequest(req *model.CreatePekerjaanAlumniRequest) (*model.CreatePekerjaanAlumniRepositoryRequest, *fiber.Error) {
+	// Basic validation
+	if (req.NamaPerusahaan == "" && req.CompanyID == nil) || req.PosisiJabatan == "" || (req.BidangIndustri == "" && req.IndustryID == nil) || req.LokasiKerja == "" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Nama perusahaan, posisi jabatan, bidang industri, dan lokasi kerja wajib diisi")
+	}
+	if req.StatusPekerjaan != "aktif" && req.StatusPekerjaan != "selesai" && req.StatusPekerjaan != "resigned" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Status pekerjaan harus aktif, selesai, atau resigned")
+	}
+
+	// Parse tanggal mulai kerja
+	tanggalMulai, err := time.Parse("2006-01-02", req.TanggalMulaiKerja)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Format tanggal mulai kerja tidak valid. Gunakan format YYYY-MM-DD")
+	}
+
+	// Parse tanggal selesai kerja jika ada
+	var tanggalSelesai *time.Time
+	if req.TanggalSelesaiKerja != nil && *req.TanggalSelesaiKerja != "" {
+		parsed, err := time.Parse("2006-01-02", *req.TanggalSelesaiKerja)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Format tanggal selesai kerja tidak valid. Gunakan format YYYY-MM-DD")
+		}
+		if parsed.Before(tanggalMulai) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Tanggal selesai kerja tidak boleh sebelum tanggal mulai kerja")
+		}
+		tanggalSelesai = &parsed
+	}
+
+	// gaji_range format lama masih diterima selama masa transisi
+	salary, err := helper.ResolveSalary(req.GajiRange, req.GajiMin, req.GajiMax, req.GajiMataUang, req.GajiPeriode)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	location, err := helper.ResolveLocation(req.LokasiKerja, req.Negara, req.Provinsi, req.Kota, req.Latitude, req.Longitude)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	return &model.CreatePekerjaanAlumniRepositoryRequest{
+		AlumniID:            req.AlumniID,
+		NamaPerusahaan:      req.NamaPerusahaan,
+		CompanyID:           req.CompanyID,
+		PosisiJabatan:       req.PosisiJabatan,
+		BidangIndustri:      req.BidangIndustri,
+		IndustryID:          req.IndustryID,
+		LokasiKerja:         req.LokasiKerja,
+		Negara:              location.Negara,
+		Provinsi:            location.Provinsi,
+		Kota:                location.Kota,
+		Latitude:            location.Latitude,
+		Longitude:           location.Longitude,
+		GajiMin:             salary.Min,
+		GajiMax:             salary.Max,
+		GajiMataUang:        salary.Currency,
+		GajiPeriode:         salary.Period,
+		GajiRahasia:         req.GajiRahasia,
+		TanggalMulaiKerja:   tanggalMulai,
+		TanggalSelesaiKerja: tanggalSelesai,
+		StatusPekerjaan:     req.StatusPekerjaan,
+		DeskripsiPekerjaan:  req.DeskripsiPekerjaan,
+		ParuhWaktu:          req.ParuhWaktu,
+	}, nil
+}
+
 func CreatePekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 	var req model.CreatePekerjaanAlumniRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -167,27 +303,23 @@ func CreatePekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 			Data:    model.PekerjaanAlumni{},
 		})
 	}
+	return createPekerjaan(c, db, &req)
+}
 
-	// Basic validation
-	if req.NamaPerusahaan == "" || req.PosisiJabatan == "" || req.BidangIndustri == "" || req.LokasiKerja == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(model.CreatePekerjaanAlumniResponse{
-			Success: false,
-			Message: "Nama perusahaan, posisi jabatan, bidang industri, dan lokasi kerja wajib diisi",
-			Data:    model.PekerjaanAlumni{},
-		})
-	}
-
-	if req.StatusPekerjaan != "aktif" && req.StatusPekerjaan != "selesai" && req.StatusPekerjaan != "resigned" {
-		return c.Status(fiber.StatusBadRequest).JSON(model.CreatePekerjaanAlumniResponse{
+// createPekerjaan -> validasi lalu simpan pekerjaan baru; dipakai POST /pekerjaan (admin) dan
+// POST /me/pekerjaan (alumni_id dari token)
+func createPekerjaan(c *fiber.Ctx, db *sql.DB, req *model.CreatePekerjaanAlumniRequest) error {
+	repoReq, ferr := pekerjaanCreateRequest(req)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(model.CreatePekerjaanAlumniResponse{
 			Success: false,
-			Message: "Status pekerjaan harus aktif, selesai, atau resigned",
+			Message: ferr.Message,
 			Data:    model.PekerjaanAlumni{},
 		})
 	}
 
 	// Check if alumni exists
-	_, err := repository.GetAlumniByID(db, req.AlumniID)
-	if err != nil {
+	if _, err := repository.GetAlumniByID(db, req.AlumniID); err != nil {
 		if err == sql.ErrNoRows {
 			return c.Status(fiber.StatusNotFound).JSON(model.CreatePekerjaanAlumniResponse{
 				Success: false,
@@ -202,45 +334,24 @@ func CreatePekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 		})
 	}
 
-	// Parse tanggal mulai kerja
-	tanggalMulai, err := time.Parse("2006-01-02", req.TanggalMulaiKerja)
-	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(model.CreatePekerjaanAlumniResponse{
+	if ferr := linkPekerjaanReferences(db, &repoReq.CompanyID, &repoReq.NamaPerusahaan, &repoReq.IndustryID, &repoReq.BidangIndustri); ferr != nil {
+		return c.Status(ferr.Code).JSON(model.CreatePekerjaanAlumniResponse{
 			Success: false,
-			Message: "Format tanggal mulai kerja tidak valid. Gunakan format YYYY-MM-DD",
+			Message: ferr.Message,
 			Data:    model.PekerjaanAlumni{},
 		})
 	}
 
-	// Parse tanggal selesai kerja jika ada
-	var tanggalSelesai *time.Time
-	if req.TanggalSelesaiKerja != nil && *req.TanggalSelesaiKerja != "" {
-		parsed, err := time.Parse("2006-01-02", *req.TanggalSelesaiKerja)
-		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(model.CreatePekerjaanAlumniResponse{
-				Success: false,
-				Message: "Format tanggal selesai kerja tidak valid. Gunakan format YYYY-MM-DD",
-				Data:    model.PekerjaanAlumni{},
-			})
-		}
-		tanggalSelesai = &parsed
-	}
-
-	// Konversi ke repository request
-	repoReq := &model.CreatePekerjaanAlumniRepositoryRequest{
-		AlumniID:            req.AlumniID,
-		NamaPerusahaan:      req.NamaPerusahaan,
-		PosisiJabatan:       req.PosisiJabatan,
-		BidangIndustri:      req.BidangIndustri,
-		LokasiKerja:         req.LokasiKerja,
-		GajiRange:           req.GajiRange,
-		TanggalMulaiKerja:   tanggalMulai,
-		TanggalSelesaiKerja: tanggalSelesai,
-		StatusPekerjaan:     req.StatusPekerjaan,
-		DeskripsiPekerjaan:  req.DeskripsiPekerjaan,
+	pekerjaan, err := saveWithCareerRules(db, repoReq.AlumniID, createCareerPeriod(repoReq), func(tx repository.DBTX) (*model.PekerjaanAlumni, error) {
+		return repository.CreatePekerjaan(tx, repoReq)
+	})
+	if ferr, ok := err.(*fiber.Error); ok {
+		return c.Status(ferr.Code).JSON(model.CreatePekerjaanAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    model.PekerjaanAlumni{},
+		})
 	}
-
-	pekerjaan, err := repository.CreatePekerjaan(db, repoReq)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(model.CreatePekerjaanAlumniResponse{
 			Success: false,
@@ -248,6 +359,7 @@ func CreatePekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 			Data:    model.PekerjaanAlumni{},
 		})
 	}
+	recordAudit(c, db, auditEntry(helper.AuditActionCreate, helper.AuditEntityPekerjaan, strconv.Itoa(pekerjaan.ID), nil, pekerjaan))
 
 	return c.Status(fiber.StatusCreated).JSON(model.CreatePekerjaanAlumniResponse{
 		Success: true,
@@ -267,6 +379,15 @@ func UpdatePekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 		})
 	}
 
+	expectedVersion, ferr := ifMatchVersion(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(model.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    model.PekerjaanAlumni{},
+		})
+	}
+
 	var req model.UpdatePekerjaanAlumniRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(model.UpdatePekerjaanAlumniResponse{
@@ -277,7 +398,7 @@ func UpdatePekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 	}
 
 	// Check if pekerjaan exists
-	_, err = repository.GetPekerjaanByID(db, id)
+	existing, err := repository.GetPekerjaanByID(db, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return c.Status(fiber.StatusNotFound).JSON(model.UpdatePekerjaanAlumniResponse{
@@ -292,6 +413,13 @@ func UpdatePekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 			Data:    model.PekerjaanAlumni{},
 		})
 	}
+	if versionMismatch(c, expectedVersion, existing.Version) {
+		return c.Status(fiber.StatusPreconditionFailed).JSON(model.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: versionConflictMessage,
+			Data:    model.PekerjaanAlumni{},
+		})
+	}
 
 	// Validate status
 	if req.StatusPekerjaan != "aktif" && req.StatusPekerjaan != "selesai" && req.StatusPekerjaan != "resigned" {
@@ -323,23 +451,83 @@ func UpdatePekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 				Data:    model.PekerjaanAlumni{},
 			})
 		}
+		if parsed.Before(tanggalMulai) {
+			return c.Status(fiber.StatusBadRequest).JSON(model.UpdatePekerjaanAlumniResponse{
+				Success: false,
+				Message: "Tanggal selesai kerja tidak boleh sebelum tanggal mulai kerja",
+				Data:    model.PekerjaanAlumni{},
+			})
+		}
 		tanggalSelesai = &parsed
 	}
 
+	salary, err := helper.ResolveSalary(req.GajiRange, req.GajiMin, req.GajiMax, req.GajiMataUang, req.GajiPeriode)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: err.Error(),
+			Data:    model.PekerjaanAlumni{},
+		})
+	}
+	location, err := helper.ResolveLocation(req.LokasiKerja, req.Negara, req.Provinsi, req.Kota, req.Latitude, req.Longitude)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: err.Error(),
+			Data:    model.PekerjaanAlumni{},
+		})
+	}
+
 	// Konversi ke repository request
 	repoReq := &model.UpdatePekerjaanAlumniRepositoryRequest{
 		NamaPerusahaan:      req.NamaPerusahaan,
+		CompanyID:           req.CompanyID,
 		PosisiJabatan:       req.PosisiJabatan,
 		BidangIndustri:      req.BidangIndustri,
+		IndustryID:          req.IndustryID,
 		LokasiKerja:         req.LokasiKerja,
-		GajiRange:           req.GajiRange,
+		Negara:              location.Negara,
+		Provinsi:            location.Provinsi,
+		Kota:                location.Kota,
+		Latitude:            location.Latitude,
+		Longitude:           location.Longitude,
+		GajiMin:             salary.Min,
+		GajiMax:             salary.Max,
+		GajiMataUang:        salary.Currency,
+		GajiPeriode:         salary.Period,
+		GajiRahasia:         req.GajiRahasia,
 		TanggalMulaiKerja:   tanggalMulai,
 		TanggalSelesaiKerja: tanggalSelesai,
 		StatusPekerjaan:     req.StatusPekerjaan,
 		DeskripsiPekerjaan:  req.DeskripsiPekerjaan,
+		ParuhWaktu:          req.ParuhWaktu,
 	}
 
-	pekerjaan, err := repository.UpdatePekerjaan(db, id, repoReq)
+	if ferr := linkPekerjaanReferences(db, &repoReq.CompanyID, &repoReq.NamaPerusahaan, &repoReq.IndustryID, &repoReq.BidangIndustri); ferr != nil {
+		return c.Status(ferr.Code).JSON(model.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    model.PekerjaanAlumni{},
+		})
+	}
+
+	pekerjaan, err := saveWithCareerRules(db, existing.AlumniID, updateCareerPeriod(id, repoReq), func(tx repository.DBTX) (*model.PekerjaanAlumni, error) {
+		return repository.UpdatePekerjaan(tx, id, repoReq, expectedVersion)
+	})
+	if ferr, ok := err.(*fiber.Error); ok {
+		return c.Status(ferr.Code).JSON(model.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    model.PekerjaanAlumni{},
+		})
+	}
+	if errors.Is(err, helper.ErrVersionConflict) {
+		return c.Status(fiber.StatusPreconditionFailed).JSON(model.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: versionConflictMessage,
+			Data:    model.PekerjaanAlumni{},
+		})
+	}
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(model.UpdatePekerjaanAlumniResponse{
 			Success: false,
@@ -347,7 +535,9 @@ func UpdatePekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 			Data:    model.PekerjaanAlumni{},
 		})
 	}
+	recordAudit(c, db, auditEntry(helper.AuditActionUpdate, helper.AuditEntityPekerjaan, idStr, existing, pekerjaan))
 
+	c.Set(fiber.HeaderETag, helper.ETag(pekerjaan.Version))
 	return c.Status(fiber.StatusOK).JSON(model.UpdatePekerjaanAlumniResponse{
 		Success: true,
 		Message: "Berhasil mengupdate pekerjaan",
@@ -355,6 +545,212 @@ func UpdatePekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 	})
 }
 
+// PatchPekerjaanService -> ubah sebagian pekerjaan dengan JSON Merge Patch (RFC 7396) atau JSON
+// Patch (RFC 6902) tanpa harus mengirim semua field seperti PUT. Hasil patch divalidasi seperti
+// update biasa; null / remove pada field opsional mengosongkannya.
+func PatchPekerjaanService(c *fiber.Ctx, db *sql.DB) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: "ID tidak valid",
+			Data:    model.PekerjaanAlumni{},
+		})
+	}
+	return patchPekerjaan(c, db, id, nil)
+}
+
+// patchPekerjaan -> terapkan PATCH ke pekerjaan id. Bila ownerID diisi, pekerjaan milik alumni lain
+// dijawab 404 seperti pekerjaan yang tidak ada (PATCH /me/pekerjaan/:id)
+func patchPekerjaan(c *fiber.Ctx, db *sql.DB, id int, ownerID *int) error {
+	format, ferr := patchFormat(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(model.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    model.PekerjaanAlumni{},
+		})
+	}
+
+	expectedVersion, ferr := ifMatchVersion(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(model.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    model.PekerjaanAlumni{},
+		})
+	}
+
+	existing, err := repository.GetPekerjaanByID(db, id)
+	if err == nil && ownerID != nil && existing.AlumniID != *ownerID {
+		err = sql.ErrNoRows
+	}
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return c.Status(fiber.StatusNotFound).JSON(model.UpdatePekerjaanAlumniResponse{
+				Success: false,
+				Message: "Pekerjaan tidak ditemukan",
+				Data:    model.PekerjaanAlumni{},
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: "Gagal mengambil data pekerjaan: " + err.Error(),
+			Data:    model.PekerjaanAlumni{},
+		})
+	}
+	if versionMismatch(c, expectedVersion, existing.Version) {
+		return c.Status(fiber.StatusPreconditionFailed).JSON(model.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: versionConflictMessage,
+			Data:    model.PekerjaanAlumni{},
+		})
+	}
+
+	var doc model.PekerjaanPatchDocument
+	if ferr := applyPatch(c, format, pekerjaanPatchDocument(existing), &doc); ferr != nil {
+		return c.Status(ferr.Code).JSON(model.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    model.PekerjaanAlumni{},
+		})
+	}
+	repoReq, ferr := pekerjaanPatchRequest(&doc)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(model.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    model.PekerjaanAlumni{},
+		})
+	}
+	if ferr := linkPekerjaanReferences(db, &repoReq.CompanyID, &repoReq.NamaPerusahaan, &repoReq.IndustryID, &repoReq.BidangIndustri); ferr != nil {
+		return c.Status(ferr.Code).JSON(model.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    model.PekerjaanAlumni{},
+		})
+	}
+
+	pekerjaan, err := saveWithCareerRules(db, existing.AlumniID, updateCareerPeriod(id, repoReq), func(tx repository.DBTX) (*model.PekerjaanAlumni, error) {
+		return repository.UpdatePekerjaan(tx, id, repoReq, expectedVersion)
+	})
+	if ferr, ok := err.(*fiber.Error); ok {
+		return c.Status(ferr.Code).JSON(model.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    model.PekerjaanAlumni{},
+		})
+	}
+	if errors.Is(err, helper.ErrVersionConflict) {
+		return c.Status(fiber.StatusPreconditionFailed).JSON(model.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: versionConflictMessage,
+			Data:    model.PekerjaanAlumni{},
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.UpdatePekerjaanAlumniResponse{
+			Success: false,
+			Message: "Gagal mengupdate pekerjaan: " + err.Error(),
+			Data:    model.PekerjaanAlumni{},
+		})
+	}
+	recordAudit(c, db, auditEntry(helper.AuditActionUpdate, helper.AuditEntityPekerjaan, strconv.Itoa(id), existing, pekerjaan))
+
+	c.Set(fiber.HeaderETag, helper.ETag(pekerjaan.Version))
+	return c.Status(fiber.StatusOK).JSON(model.UpdatePekerjaanAlumniResponse{
+		Success: true,
+		Message: "Berhasil mengupdate pekerjaan",
+		Data:    *pekerjaan,
+	})
+}
+
+// pekerjaanPatchDocument -> data pekerjaan saat ini dalam bentuk yang bisa di-patch
+func pekerjaanPatchDocument(existing *model.PekerjaanAlumni) model.PekerjaanPatchDocument {
+	doc := model.PekerjaanPatchDocument{
+		NamaPerusahaan:     existing.NamaPerusahaan,
+		PosisiJabatan:      existing.PosisiJabatan,
+		BidangIndustri:     existing.BidangIndustri,
+		LokasiKerja:        existing.LokasiKerja,
+		Negara:             existing.Negara,
+		Provinsi:           existing.Provinsi,
+		Kota:               existing.Kota,
+		Latitude:           existing.Latitude,
+		Longitude:          existing.Longitude,
+		GajiRange:          existing.GajiRange,
+		GajiMin:            existing.GajiMin,
+		GajiMax:            existing.GajiMax,
+		GajiMataUang:       existing.GajiMataUang,
+		GajiPeriode:        existing.GajiPeriode,
+		GajiRahasia:        existing.GajiRahasia,
+		TanggalMulaiKerja:  existing.TanggalMulaiKerja.Format("2006-01-02"),
+		StatusPekerjaan:    existing.StatusPekerjaan,
+		DeskripsiPekerjaan: existing.DeskripsiPekerjaan,
+		ParuhWaktu:         existing.ParuhWaktu,
+	}
+	if existing.TanggalSelesaiKerja != nil {
+		selesai := existing.TanggalSelesaiKerja.Format("2006-01-02")
+		doc.TanggalSelesaiKerja = &selesai
+	}
+	return doc
+}
+
+// pekerjaanPatchRequest -> validasi hasil patch (aturan sama seperti PUT) lalu ubah menjadi update
+// lengkap; repository menyimpan semua kolom sehingga nil tersimpan sebagai NULL
+func pekerjaanPatchRequest(doc *model.PekerjaanPatchDocument) (*model.UpdatePekerjaanAlumniRepositoryRequest, *fiber.Error) {
+	if doc.NamaPerusahaan == "" || doc.PosisiJabatan == "" || doc.BidangIndustri == "" || doc.LokasiKerja == "" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Nama perusahaan, posisi jabatan, bidang industri, dan lokasi kerja wajib diisi")
+	}
+	if doc.StatusPekerjaan != "aktif" && doc.StatusPekerjaan != "selesai" && doc.StatusPekerjaan != "resigned" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Status pekerjaan harus aktif, selesai, atau resigned")
+	}
+	tanggalMulai, err := time.Parse("2006-01-02", doc.TanggalMulaiKerja)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Format tanggal mulai kerja tidak valid. Gunakan format YYYY-MM-DD")
+	}
+	var tanggalSelesai *time.Time
+	if doc.TanggalSelesaiKerja != nil && *doc.TanggalSelesaiKerja != "" {
+		parsed, err := time.Parse("2006-01-02", *doc.TanggalSelesaiKerja)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Format tanggal selesai kerja tidak valid. Gunakan format YYYY-MM-DD")
+		}
+		if parsed.Before(tanggalMulai) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Tanggal selesai kerja tidak boleh sebelum tanggal mulai kerja")
+		}
+		tanggalSelesai = &parsed
+	}
+	salary, err := helper.ResolveSalary(doc.GajiRange, doc.GajiMin, doc.GajiMax, doc.GajiMataUang, doc.GajiPeriode)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	location, err := helper.ResolveLocation(doc.LokasiKerja, locationText(doc.Negara), locationText(doc.Provinsi), locationText(doc.Kota), doc.Latitude, doc.Longitude)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	return &model.UpdatePekerjaanAlumniRepositoryRequest{
+		NamaPerusahaan:      doc.NamaPerusahaan,
+		PosisiJabatan:       doc.PosisiJabatan,
+		BidangIndustri:      doc.BidangIndustri,
+		LokasiKerja:         doc.LokasiKerja,
+		Negara:              location.Negara,
+		Provinsi:            location.Provinsi,
+		Kota:                location.Kota,
+		Latitude:            location.Latitude,
+		Longitude:           location.Longitude,
+		GajiMin:             salary.Min,
+		GajiMax:             salary.Max,
+		GajiMataUang:        salary.Currency,
+		GajiPeriode:         salary.Period,
+		GajiRahasia:         doc.GajiRahasia,
+		TanggalMulaiKerja:   tanggalMulai,
+		TanggalSelesaiKerja: tanggalSelesai,
+		StatusPekerjaan:     doc.StatusPekerjaan,
+		DeskripsiPekerjaan:  doc.DeskripsiPekerjaan,
+		ParuhWaktu:          doc.ParuhWaktu,
+	}, nil
+}
+
 func DeletePekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 	idStr := c.Params("id")
 	id, err := strconv.Atoi(idStr)
@@ -366,7 +762,7 @@ func DeletePekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 	}
 
 	// Check if pekerjaan exists
-	_, err = repository.GetPekerjaanByID(db, id)
+	existing, err := repository.GetPekerjaanByID(db, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return c.Status(fiber.StatusNotFound).JSON(model.HardDeletePekerjaanAlumniResponse{
@@ -387,6 +783,7 @@ func DeletePekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 			Message: "Gagal menghapus pekerjaan: " + err.Error(),
 		})
 	}
+	recordAudit(c, db, auditEntry(helper.AuditActionDelete, helper.AuditEntityPekerjaan, idStr, existing, nil))
 
 	return c.Status(fiber.StatusOK).JSON(model.HardDeletePekerjaanAlumniResponse{
 		Success: true,
@@ -395,15 +792,14 @@ func DeletePekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 }
 
 func ListDeletedPekerjaanService(c *fiber.Ctx, db *sql.DB) error {
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	limit, _ := strconv.Atoi(c.Query("limit", "10"))
-	if page < 1 {
-		page = 1
-	}
-	if limit <= 0 || limit > 100 {
-		limit = 10
+	// Trash selalu diurutkan dari yang terakhir dihapus
+	page, err := parseListPage(c, "is_delete", "desc", helper.FilterTypeDate, helper.FilterTypeInt)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"message": err.Error(),
+			"success": false,
+		})
 	}
-	offset := (page - 1) * limit
 
 	// Get user info for access control
 	userIDInterface := c.Locals("user_id")
@@ -432,46 +828,67 @@ func ListDeletedPekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 		})
 	}
 
-	// Debug logging
-	fmt.Printf("DEBUG: userID=%d, userRole=%s, offset=%d, limit=%d\n", userID, userRole, offset, limit)
+	// Admin melihat semua data, user hanya pekerjaan miliknya (dibatasi di query agar pagination tetap benar)
+	var alumniID *int
+	if userRole != "admin" {
+		alumniID = &userID
+	}
 
-	pekerjaan, total, err := repository.GetDeletedPekerjaanRepo(db, offset, limit)
+	var pekerjaan []model.PekerjaanAlumni
+	var total *int
+	var hasMore bool
+	if page.Keyset != nil {
+		pekerjaan, hasMore, err = repository.GetDeletedPekerjaanKeysetRepo(db, alumniID, page.Keyset, page.Limit)
+		if err == nil && page.WithTotal {
+			var count int
+			count, err = repository.CountDeletedPekerjaanRepo(db, alumniID)
+			total = &count
+		}
+	} else {
+		var count int
+		pekerjaan, count, err = repository.GetDeletedPekerjaanRepo(db, alumniID, page.Offset, page.Limit)
+		total = &count
+		hasMore = page.Offset+len(pekerjaan) < count
+	}
 	if err != nil {
-		fmt.Printf("DEBUG: Error from repository: %v\n", err)
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"message": "Gagal mengambil daftar pekerjaan yang dihapus: " + err.Error(),
 			"success": false,
 		})
 	}
 
-	fmt.Printf("DEBUG: Retrieved %d pekerjaan, total=%d\n", len(pekerjaan), total)
+	var first, last *helper.CursorPosition
+	if len(pekerjaan) > 0 {
+		first = deletedPekerjaanPosition(pekerjaan[0])
+		last = deletedPekerjaanPosition(pekerjaan[len(pekerjaan)-1])
+	}
 
-	// Filter data based on user role
-	var filteredPekerjaan []model.PekerjaanAlumni
-	if userRole == "admin" {
-		// Admin can see all deleted pekerjaan
-		filteredPekerjaan = pekerjaan
-		fmt.Printf("DEBUG: Admin access - showing all %d pekerjaan\n", len(filteredPekerjaan))
-	} else {
-		// User can only see their own deleted pekerjaan
-		for _, p := range pekerjaan {
-			if p.AlumniID == userID {
-				filteredPekerjaan = append(filteredPekerjaan, p)
-			}
-		}
-		// Recalculate total for user
-		total = len(filteredPekerjaan)
-		fmt.Printf("DEBUG: User access - showing %d pekerjaan for user %d\n", len(filteredPekerjaan), userID)
+	meta := page.meta(total, hasMore, first, last)
+	meta.SortBy = "is_delete"
+	meta.Order = "desc"
+
+	if pekerjaan == nil {
+		pekerjaan = []model.PekerjaanAlumni{}
 	}
 
 	response := model.GetSoftDeletedPekerjaanAlumniResponse{
 		Success: true,
 		Message: "Berhasil mengambil daftar pekerjaan yang dihapus",
-		Data:    filteredPekerjaan,
+		Data:    pekerjaan,
+		Meta:    meta,
 	}
 	return c.Status(fiber.StatusOK).JSON(response)
 }
 
+// deletedPekerjaanPosition -> posisi cursor trash (is_delete, id)
+func deletedPekerjaanPosition(p model.PekerjaanAlumni) *helper.CursorPosition {
+	var deletedAt time.Time
+	if p.IsDeleted != nil {
+		deletedAt = *p.IsDeleted
+	}
+	return &helper.CursorPosition{Value: deletedAt, ID: p.ID}
+}
+
 func RestorePekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 	idStr := c.Params("id")
 	id, err := strconv.Atoi(idStr)
@@ -540,6 +957,7 @@ func RestorePekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 			Message: "Gagal merestore pekerjaan: " + err.Error(),
 		})
 	}
+	recordPekerjaanStateAudit(c, db, helper.AuditActionRestore, pekerjaan)
 	return c.Status(fiber.StatusOK).JSON(model.RestorePekerjaanAlumniResponse{
 		Success: true,
 		Message: "Berhasil merestore pekerjaan",
@@ -614,6 +1032,7 @@ func HardDeletePekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 			Message: "Gagal menghapus permanen pekerjaan: " + err.Error(),
 		})
 	}
+	recordAudit(c, db, auditEntry(helper.AuditActionDelete, helper.AuditEntityPekerjaan, idStr, pekerjaan, nil))
 	return c.Status(fiber.StatusOK).JSON(model.HardDeletePekerjaanAlumniResponse{
 		Success: true,
 		Message: "Berhasil menghapus permanen pekerjaan",
@@ -700,9 +1119,70 @@ func SoftDeletePekerjaanService(c *fiber.Ctx, db *sql.DB) error {
 			Message: "Gagal menghapus pekerjaan: " + err.Error(),
 		})
 	}
+	recordPekerjaanStateAudit(c, db, helper.AuditActionSoftDelete, pekerjaan)
 
 	return c.Status(fiber.StatusOK).JSON(model.SoftDeletePekerjaanAlumniResponse{
 		Success: true,
 		Message: "Berhasil menghapus pekerjaan",
 	})
 }
+
+// recordPekerjaanStateAudit -> audit soft delete/restore; kondisi sesudahnya dibaca ulang agar
+// perubahan is_delete tercatat di diff
+func recordPekerjaanStateAudit(c *fiber.Ctx, db *sql.DB, action string, before *model.PekerjaanAlumni) {
+	var after any
+	if updated, err := repository.GetPekerjaanWithDeletedByID(db, before.ID); err == nil {
+		after = updated
+	}
+	recordAudit(c, db, auditEntry(action, helper.AuditEntityPekerjaan, strconv.Itoa(before.ID), before, after))
+}
+
+// SearchPekerjaanService -> full-text search pekerjaan dengan ranking relevansi dan highlight
+func SearchPekerjaanService(c *fiber.Ctx, db *sql.DB) error {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Parameter q wajib diisi",
+		})
+	}
+
+	page, limit, offset := searchPagination(c)
+
+	results, err := repository.SearchPekerjaanRepo(db, query, limit, offset)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to search pekerjaan",
+		})
+	}
+
+	total, err := repository.CountSearchPekerjaanRepo(db, query)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Failed to count search results",
+		})
+	}
+
+	for i := range results {
+		maskSalary(c, &results[i].PekerjaanAlumni)
+	}
+	if results == nil {
+		results = []model.PekerjaanSearchResult{}
+	}
+
+	return c.JSON(model.SearchPekerjaanResponse{
+		Success: true,
+		Message: "Berhasil mencari pekerjaan",
+		Data: model.SearchPekerjaanData{
+			Items: results,
+			Meta: model.MetaInfo{
+				Page:   page,
+				Limit:  limit,
+				Total:  &total,
+				Pages:  (total + limit - 1) / limit,
+				SortBy: "relevance",
+				Order:  "desc",
+				Search: query,
+			},
+		},
+	})
+}
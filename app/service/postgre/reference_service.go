@@ -0,0 +1,456 @@
+package postgre
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	model "go-fiber/app/model/postgre"
+	repository "go-fiber/app/repository/postgre"
+	"go-fiber/helper"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Data referensi perusahaan (companies) dan bidang industri (industries). Kedua jenis memakai
+// service yang sama; kind menentukan tabel dan kolom pekerjaan yang terhubung.
+
+// JobTypeReferenceBackfill -> hubungkan pekerjaan lama ke data referensi
+const JobTypeReferenceBackfill = "reference.backfill"
+
+// referenceAutocompleteMaxLimit -> batas ?limit autocomplete
+const referenceAutocompleteMaxLimit = 50
+
+type referenceBackfillPayload struct {
+	Kind string `json:"kind"`
+}
+
+func ListReferencesService(c *fiber.Ctx, db *sql.DB, kind helper.ReferenceKind) error {
+	refs, err := repository.ListReferences(db, kind, c.Query("search"))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ListReferenceDataResponse{
+			Success: false,
+			Message: "Gagal mengambil data " + strings.ToLower(kind.Label) + ": " + err.Error(),
+			Data:    []model.ReferenceData{},
+		})
+	}
+	return c.JSON(model.ListReferenceDataResponse{
+		Success: true,
+		Message: "Berhasil mengambil data " + strings.ToLower(kind.Label),
+		Data:    refs,
+	})
+}
+
+// ReferenceAutocompleteService -> GET /:kind/autocomplete?q=: nama atau alias yang mirip q, termasuk
+// yang salah ketik, skor tertinggi lebih dulu
+func ReferenceAutocompleteService(c *fiber.Ctx, db *sql.DB, kind helper.ReferenceKind) error {
+	limit := c.QueryInt("limit", 10)
+	if limit <= 0 || limit > referenceAutocompleteMaxLimit {
+		limit = 10
+	}
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ReferenceAutocompleteResponse{
+			Success: false,
+			Message: "Parameter q wajib diisi",
+			Data:    []model.ReferenceMatch{},
+		})
+	}
+
+	refs, err := repository.ListReferences(db, kind, "")
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ReferenceAutocompleteResponse{
+			Success: false,
+			Message: "Gagal mengambil data " + strings.ToLower(kind.Label) + ": " + err.Error(),
+			Data:    []model.ReferenceMatch{},
+		})
+	}
+	candidates := make([][]string, len(refs))
+	for i, ref := range refs {
+		candidates[i] = append([]string{ref.Nama}, ref.Aliases...)
+	}
+
+	matches := []model.ReferenceMatch{}
+	for _, m := range helper.RankReferences(query, candidates, limit) {
+		matches = append(matches, model.ReferenceMatch{
+			ReferenceData: refs[m.Index],
+			Score:         math.Round(m.Score*100) / 100,
+			Matched:       m.Matched,
+		})
+	}
+	return c.JSON(model.ReferenceAutocompleteResponse{
+		Success: true,
+		Message: "Berhasil mengambil saran " + strings.ToLower(kind.Label),
+		Data:    matches,
+	})
+}
+
+func GetReferenceService(c *fiber.Ctx, db *sql.DB, kind helper.ReferenceKind) error {
+	ref, status, message := referenceFromParam(c, db, kind)
+	if ref == nil {
+		return c.Status(status).JSON(model.ReferenceDataResponse{Success: false, Message: message})
+	}
+	return c.JSON(model.ReferenceDataResponse{
+		Success: true,
+		Message: "Berhasil mengambil data " + strings.ToLower(kind.Label),
+		Data:    *ref,
+	})
+}
+
+func CreateReferenceService(c *fiber.Ctx, db *sql.DB, kind helper.ReferenceKind) error {
+	var req model.ReferenceDataRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ReferenceDataResponse{
+			Success: false,
+			Message: "Format data tidak valid: " + err.Error(),
+		})
+	}
+	ref, ferr := referenceFromRequest(&req)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(model.ReferenceDataResponse{Success: false, Message: ferr.Message})
+	}
+	if ferr := checkReferenceConflict(db, kind, ref); ferr != nil {
+		return c.Status(ferr.Code).JSON(model.ReferenceDataResponse{Success: false, Message: ferr.Message})
+	}
+
+	if err := repository.CreateReference(db, kind, ref); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ReferenceDataResponse{
+			Success: false,
+			Message: "Gagal membuat data " + strings.ToLower(kind.Label) + ": " + err.Error(),
+		})
+	}
+	recordAudit(c, db, auditEntry(helper.AuditActionCreate, kind.AuditEntity, strconv.Itoa(ref.ID), nil, ref))
+
+	return c.Status(fiber.StatusCreated).JSON(model.ReferenceDataResponse{
+		Success: true,
+		Message: "Berhasil membuat data " + strings.ToLower(kind.Label),
+		Data:    *ref,
+	})
+}
+
+// UpdateReferenceService -> ganti nama dan alias. Bila nama berubah, teks bebas pekerjaan yang
+// terhubung ikut diganti dalam transaksi yang sama.
+func UpdateReferenceService(c *fiber.Ctx, db *sql.DB, kind helper.ReferenceKind) error {
+	existing, status, message := referenceFromParam(c, db, kind)
+	if existing == nil {
+		return c.Status(status).JSON(model.ReferenceDataResponse{Success: false, Message: message})
+	}
+
+	var req model.ReferenceDataRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ReferenceDataResponse{
+			Success: false,
+			Message: "Format data tidak valid: " + err.Error(),
+		})
+	}
+	ref, ferr := referenceFromRequest(&req)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(model.ReferenceDataResponse{Success: false, Message: ferr.Message})
+	}
+	ref.ID, ref.CreatedAt = existing.ID, existing.CreatedAt
+	if ferr := checkReferenceConflict(db, kind, ref); ferr != nil {
+		return c.Status(ferr.Code).JSON(model.ReferenceDataResponse{Success: false, Message: ferr.Message})
+	}
+
+	err := runReferenceTx(db, func(tx *sql.Tx) error {
+		if err := repository.UpdateReference(tx, kind, ref); err != nil {
+			return err
+		}
+		if ref.Nama == existing.Nama {
+			return nil
+		}
+		_, err := repository.RelinkPekerjaanReference(tx, kind, []int{ref.ID}, ref)
+		return err
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ReferenceDataResponse{
+			Success: false,
+			Message: "Gagal mengupdate data " + strings.ToLower(kind.Label) + ": " + err.Error(),
+		})
+	}
+	recordAudit(c, db, auditEntry(helper.AuditActionUpdate, kind.AuditEntity, strconv.Itoa(ref.ID), existing, ref))
+
+	return c.JSON(model.ReferenceDataResponse{
+		Success: true,
+		Message: "Berhasil mengupdate data " + strings.ToLower(kind.Label),
+		Data:    *ref,
+	})
+}
+
+// DeleteReferenceService -> hapus data referensi; pekerjaan yang terhubung kembali memakai teks bebas
+func DeleteReferenceService(c *fiber.Ctx, db *sql.DB, kind helper.ReferenceKind) error {
+	existing, status, message := referenceFromParam(c, db, kind)
+	if existing == nil {
+		return c.Status(status).JSON(model.ReferenceDataResponse{Success: false, Message: message})
+	}
+
+	err := runReferenceTx(db, func(tx *sql.Tx) error {
+		if err := repository.UnlinkPekerjaanReference(tx, kind, existing.ID); err != nil {
+			return err
+		}
+		return repository.DeleteReferences(tx, kind, []int{existing.ID})
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ReferenceDataResponse{
+			Success: false,
+			Message: "Gagal menghapus data " + strings.ToLower(kind.Label) + ": " + err.Error(),
+		})
+	}
+	recordAudit(c, db, auditEntry(helper.AuditActionDelete, kind.AuditEntity, strconv.Itoa(existing.ID), existing, nil))
+
+	return c.JSON(model.ReferenceDataResponse{
+		Success: true,
+		Message: "Berhasil menghapus data " + strings.ToLower(kind.Label),
+		Data:    *existing,
+	})
+}
+
+// MergeReferenceService -> POST /:kind/:id/merge: gabungkan duplikat (source_ids) ke data :id.
+// Nama dan alias duplikat menjadi alias data tujuan, pekerjaannya dipindahkan, lalu duplikat dihapus.
+func MergeReferenceService(c *fiber.Ctx, db *sql.DB, kind helper.ReferenceKind) error {
+	target, status, message := referenceFromParam(c, db, kind)
+	if target == nil {
+		return c.Status(status).JSON(model.ReferenceMergeResponse{Success: false, Message: message})
+	}
+
+	var req model.ReferenceMergeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ReferenceMergeResponse{
+			Success: false,
+			Message: "Format data tidak valid: " + err.Error(),
+		})
+	}
+	if len(req.SourceIDs) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(model.ReferenceMergeResponse{
+			Success: false,
+			Message: "source_ids wajib diisi",
+		})
+	}
+
+	var sources []model.ReferenceData
+	for _, id := range req.SourceIDs {
+		if id == target.ID {
+			return c.Status(fiber.StatusBadRequest).JSON(model.ReferenceMergeResponse{
+				Success: false,
+				Message: fmt.Sprintf("source_ids tidak valid: %d", id),
+			})
+		}
+		source, err := repository.GetReferenceByID(db, kind, id)
+		if err == sql.ErrNoRows {
+			return c.Status(fiber.StatusNotFound).JSON(model.ReferenceMergeResponse{
+				Success: false,
+				Message: fmt.Sprintf("%s %d tidak ditemukan", kind.Label, id),
+			})
+		}
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(model.ReferenceMergeResponse{
+				Success: false,
+				Message: "Gagal mengambil data " + strings.ToLower(kind.Label) + ": " + err.Error(),
+			})
+		}
+		sources = append(sources, *source)
+	}
+
+	merged := *target
+	aliases := append([]string{}, target.Aliases...)
+	for _, source := range sources {
+		aliases = append(append(aliases, source.Nama), source.Aliases...)
+	}
+	merged.Aliases = helper.CleanReferenceAliases(merged.Nama, aliases)
+	merged.Keys = helper.ReferenceKeys(merged.Nama, merged.Aliases)
+
+	var relinked int64
+	err := runReferenceTx(db, func(tx *sql.Tx) error {
+		// Pekerjaan dipindahkan sebelum duplikat dihapus; ON DELETE SET NULL akan melepasnya
+		n, err := repository.RelinkPekerjaanReference(tx, kind, req.SourceIDs, &merged)
+		if err != nil {
+			return err
+		}
+		relinked = n
+		if err := repository.UpdateReference(tx, kind, &merged); err != nil {
+			return err
+		}
+		return repository.DeleteReferences(tx, kind, req.SourceIDs)
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.ReferenceMergeResponse{
+			Success: false,
+			Message: "Gagal menggabungkan data " + strings.ToLower(kind.Label) + ": " + err.Error(),
+		})
+	}
+	recordAudit(c, db, auditEntry(helper.AuditActionMerge, kind.AuditEntity, strconv.Itoa(merged.ID), target, &merged))
+	for i := range sources {
+		recordAudit(c, db, auditEntry(helper.AuditActionMerge, kind.AuditEntity, strconv.Itoa(sources[i].ID), &sources[i], nil))
+	}
+
+	return c.JSON(model.ReferenceMergeResponse{
+		Success: true,
+		Message: fmt.Sprintf("Berhasil menggabungkan %d data %s", len(sources), strings.ToLower(kind.Label)),
+		Data: model.ReferenceMergeResult{
+			Reference: merged,
+			Merged:    len(sources),
+			Relinked:  relinked,
+		},
+	})
+}
+
+// BackfillReferenceService -> POST /:kind/backfill: jalankan job reference.backfill di background
+func BackfillReferenceService(c *fiber.Ctx, db *sql.DB, kind helper.ReferenceKind) error {
+	job, err := EnqueueJob(db, JobTypeReferenceBackfill, referenceBackfillPayload{Kind: kind.Name}, JobOptions{MaxAttempts: 1})
+	if err != nil {
+		return jobError(c, fiber.StatusInternalServerError, "Gagal menjadwalkan backfill: "+err.Error())
+	}
+	return c.Status(fiber.StatusAccepted).JSON(model.JobResponse{
+		Success: true,
+		Message: "Backfill berjalan di background, cek progress di /jobs/" + strconv.FormatInt(job.ID, 10),
+		Data:    *job,
+	})
+}
+
+func registerReferenceJobs(r *JobRunner) {
+	RegisterJobHandler(r, JobTypeReferenceBackfill, func(ctx context.Context, p referenceBackfillPayload) error {
+		kind, ok := helper.ReferenceKindByName(p.Kind)
+		if !ok {
+			return PermanentJobError(fmt.Errorf("jenis data referensi tidak dikenal: %q", p.Kind))
+		}
+		created, linked, err := backfillReferences(ctx, r.db, kind)
+		if created > 0 || linked > 0 {
+			log.Printf("Reference backfill %s: %d data baru, %d pekerjaan dihubungkan", kind.Name, created, linked)
+		}
+		return err
+	})
+}
+
+// backfillReferences -> hubungkan pekerjaan yang belum punya referensi. Teks bebas yang kuncinya
+// belum dikenal dibuatkan data referensi baru; ejaan yang paling sering dipakai menjadi namanya.
+func backfillReferences(ctx context.Context, db *sql.DB, kind helper.ReferenceKind) (created int, linked int64, err error) {
+	texts, err := repository.UnlinkedPekerjaanTexts(db, kind)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, text := range texts {
+		if err := ctx.Err(); err != nil {
+			return created, linked, err
+		}
+		key := helper.NormalizeReferenceName(text)
+		if key == "" {
+			continue
+		}
+		ref, err := repository.GetReferenceByKey(db, kind, key)
+		if err == sql.ErrNoRows {
+			nama := strings.Join(strings.Fields(text), " ")
+			ref = &model.ReferenceData{Nama: nama, Aliases: []string{}, Keys: []string{key}}
+			err = repository.CreateReference(db, kind, ref)
+			if err == nil {
+				created++
+			}
+		}
+		if err != nil {
+			return created, linked, err
+		}
+		n, err := repository.LinkPekerjaanByText(db, kind, text, ref)
+		if err != nil {
+			return created, linked, err
+		}
+		linked += n
+	}
+	return created, linked, nil
+}
+
+// linkPekerjaanReferences -> hubungkan pekerjaan ke perusahaan dan bidang industri. ID dari client
+// diutamakan; tanpa ID, teks bebas dicocokkan dengan nama / alias. Teks yang cocok diganti nama
+// resminya, teks yang belum dikenal disimpan apa adanya tanpa ID.
+func linkPekerjaanReferences(db repository.DBTX, companyID **int, namaPerusahaan *string, industryID **int, bidangIndustri *string) *fiber.Error {
+	links := []struct {
+		kind helper.ReferenceKind
+		id   **int
+		text *string
+	}{
+		{helper.ReferenceCompanies, companyID, namaPerusahaan},
+		{helper.ReferenceIndustries, industryID, bidangIndustri},
+	}
+	for _, link := range links {
+		var ref *model.ReferenceData
+		var err error
+		if *link.id != nil {
+			ref, err = repository.GetReferenceByID(db, link.kind, **link.id)
+			if err == sql.ErrNoRows {
+				return fiber.NewError(fiber.StatusBadRequest, link.kind.Label+" tidak ditemukan")
+			}
+		} else if key := helper.NormalizeReferenceName(*link.text); key != "" {
+			ref, err = repository.GetReferenceByKey(db, link.kind, key)
+			if err == sql.ErrNoRows {
+				ref, err = nil, nil
+			}
+		}
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, "Gagal mengambil data "+strings.ToLower(link.kind.Label)+": "+err.Error())
+		}
+		if ref != nil {
+			*link.id, *link.text = &ref.ID, ref.Nama
+		}
+	}
+	return nil
+}
+
+// runReferenceTx -> jalankan fn dalam satu transaksi
+func runReferenceTx(db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// referenceFromRequest -> validasi nama dan rapikan alias
+func referenceFromRequest(req *model.ReferenceDataRequest) (*model.ReferenceData, *fiber.Error) {
+	nama := strings.Join(strings.Fields(req.Nama), " ")
+	if nama == "" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Nama wajib diisi")
+	}
+	if helper.NormalizeReferenceName(nama) == "" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Nama harus mengandung huruf atau angka")
+	}
+	aliases := helper.CleanReferenceAliases(nama, req.Aliases)
+	return &model.ReferenceData{
+		Nama:    nama,
+		Aliases: aliases,
+		Keys:    helper.ReferenceKeys(nama, aliases),
+	}, nil
+}
+
+// checkReferenceConflict -> 409 bila nama / alias ref sudah dipakai data referensi lain
+func checkReferenceConflict(db *sql.DB, kind helper.ReferenceKind, ref *model.ReferenceData) *fiber.Error {
+	other, err := repository.GetReferenceConflict(db, kind, ref.Keys, ref.ID)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "Gagal memeriksa data "+strings.ToLower(kind.Label)+": "+err.Error())
+	}
+	return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Nama atau alias sudah dipakai %s %q (%d); gunakan merge untuk menggabungkan", strings.ToLower(kind.Label), other.Nama, other.ID))
+}
+
+// referenceFromParam -> data referensi dari :id; nil beserta status dan pesan error bila gagal
+func referenceFromParam(c *fiber.Ctx, db *sql.DB, kind helper.ReferenceKind) (*model.ReferenceData, int, string) {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return nil, fiber.StatusBadRequest, "ID tidak valid"
+	}
+	ref, err := repository.GetReferenceByID(db, kind, id)
+	if err == sql.ErrNoRows {
+		return nil, fiber.StatusNotFound, kind.Label + " tidak ditemukan"
+	}
+	if err != nil {
+		return nil, fiber.StatusInternalServerError, "Gagal mengambil data " + strings.ToLower(kind.Label) + ": " + err.Error()
+	}
+	return ref, 0, ""
+}
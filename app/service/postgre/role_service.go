@@ -2,8 +2,10 @@ package postgre
 
 import (
 	"database/sql"
+	"errors"
 	model "go-fiber/app/model/postgre"
 	repository "go-fiber/app/repository/postgre"
+	"go-fiber/helper"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
@@ -33,6 +35,7 @@ func CreateRoleService(c *fiber.Ctx, db *sql.DB) error {
 			Data:    model.Role{},
 		})
 	}
+	recordAudit(c, db, auditEntry(helper.AuditActionCreate, helper.AuditEntityRole, strconv.Itoa(role.ID), nil, role))
 	return c.Status(fiber.StatusCreated).JSON(model.CreateRoleResponse{
 		Success: true,
 		Message: "Berhasil membuat role",
@@ -64,6 +67,7 @@ func GetRoleByIDService(c *fiber.Ctx, db *sql.DB) error {
 			Data:    model.Role{},
 		})
 	}
+	c.Set(fiber.HeaderETag, helper.ETag(role.Version))
 	return c.JSON(model.GetRoleByIDResponse{
 		Success: true,
 		Message: "Berhasil mengambil role",
@@ -96,6 +100,14 @@ func UpdateRoleService(c *fiber.Ctx, db *sql.DB) error {
 			Data:    model.Role{},
 		})
 	}
+	expectedVersion, ferr := ifMatchVersion(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(model.UpdateRoleResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    model.Role{},
+		})
+	}
 	var req model.UpdateRoleRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(model.UpdateRoleResponse{
@@ -104,7 +116,36 @@ func UpdateRoleService(c *fiber.Ctx, db *sql.DB) error {
 			Data:    model.Role{},
 		})
 	}
-	role, err := repository.UpdateRole(db, id, &req)
+	existing, err := repository.GetRoleByID(db, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return c.Status(fiber.StatusNotFound).JSON(model.UpdateRoleResponse{
+				Success: false,
+				Message: "Role tidak ditemukan",
+				Data:    model.Role{},
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.UpdateRoleResponse{
+			Success: false,
+			Message: "Gagal mengambil role",
+			Data:    model.Role{},
+		})
+	}
+	if versionMismatch(c, expectedVersion, existing.Version) {
+		return c.Status(fiber.StatusPreconditionFailed).JSON(model.UpdateRoleResponse{
+			Success: false,
+			Message: versionConflictMessage,
+			Data:    model.Role{},
+		})
+	}
+	role, err := repository.UpdateRole(db, id, &req, expectedVersion)
+	if errors.Is(err, helper.ErrVersionConflict) {
+		return c.Status(fiber.StatusPreconditionFailed).JSON(model.UpdateRoleResponse{
+			Success: false,
+			Message: versionConflictMessage,
+			Data:    model.Role{},
+		})
+	}
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return c.Status(fiber.StatusNotFound).JSON(model.UpdateRoleResponse{
@@ -119,6 +160,95 @@ func UpdateRoleService(c *fiber.Ctx, db *sql.DB) error {
 			Data:    model.Role{},
 		})
 	}
+	recordAudit(c, db, auditEntry(helper.AuditActionUpdate, helper.AuditEntityRole, strconv.Itoa(id), existing, role))
+	c.Set(fiber.HeaderETag, helper.ETag(role.Version))
+	return c.JSON(model.UpdateRoleResponse{
+		Success: true,
+		Message: "Berhasil mengupdate role",
+		Data:    *role,
+	})
+}
+
+// PatchRoleService -> ubah role dengan JSON Merge Patch (RFC 7396) atau JSON Patch (RFC 6902)
+func PatchRoleService(c *fiber.Ctx, db *sql.DB) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(model.UpdateRoleResponse{
+			Success: false,
+			Message: "ID tidak valid",
+			Data:    model.Role{},
+		})
+	}
+	format, ferr := patchFormat(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(model.UpdateRoleResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    model.Role{},
+		})
+	}
+	expectedVersion, ferr := ifMatchVersion(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(model.UpdateRoleResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    model.Role{},
+		})
+	}
+	existing, err := repository.GetRoleByID(db, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return c.Status(fiber.StatusNotFound).JSON(model.UpdateRoleResponse{
+				Success: false,
+				Message: "Role tidak ditemukan",
+				Data:    model.Role{},
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(model.UpdateRoleResponse{
+			Success: false,
+			Message: "Gagal mengambil role",
+			Data:    model.Role{},
+		})
+	}
+	if versionMismatch(c, expectedVersion, existing.Version) {
+		return c.Status(fiber.StatusPreconditionFailed).JSON(model.UpdateRoleResponse{
+			Success: false,
+			Message: versionConflictMessage,
+			Data:    model.Role{},
+		})
+	}
+	var doc model.RolePatchDocument
+	if ferr := applyPatch(c, format, model.RolePatchDocument{Name: existing.Name}, &doc); ferr != nil {
+		return c.Status(ferr.Code).JSON(model.UpdateRoleResponse{
+			Success: false,
+			Message: ferr.Message,
+			Data:    model.Role{},
+		})
+	}
+	if doc.Name == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(model.UpdateRoleResponse{
+			Success: false,
+			Message: "Nama role wajib diisi",
+			Data:    model.Role{},
+		})
+	}
+	role, err := repository.UpdateRole(db, id, &model.UpdateRoleRequest{Name: &doc.Name}, expectedVersion)
+	if errors.Is(err, helper.ErrVersionConflict) {
+		return c.Status(fiber.StatusPreconditionFailed).JSON(model.UpdateRoleResponse{
+			Success: false,
+			Message: versionConflictMessage,
+			Data:    model.Role{},
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.UpdateRoleResponse{
+			Success: false,
+			Message: "Gagal mengupdate role",
+			Data:    model.Role{},
+		})
+	}
+	recordAudit(c, db, auditEntry(helper.AuditActionUpdate, helper.AuditEntityRole, strconv.Itoa(id), existing, role))
+	c.Set(fiber.HeaderETag, helper.ETag(role.Version))
 	return c.JSON(model.UpdateRoleResponse{
 		Success: true,
 		Message: "Berhasil mengupdate role",
@@ -134,12 +264,20 @@ func DeleteRoleService(c *fiber.Ctx, db *sql.DB) error {
 			Message: "ID tidak valid",
 		})
 	}
+	existing, err := repository.GetRoleByID(db, id)
+	if err != nil && err != sql.ErrNoRows {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.DeleteRoleResponse{
+			Success: false,
+			Message: "Gagal mengambil role",
+		})
+	}
 	if err := repository.DeleteRole(db, id); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(model.DeleteRoleResponse{
 			Success: false,
 			Message: "Gagal menghapus role",
 		})
 	}
+	recordAudit(c, db, auditEntry(helper.AuditActionDelete, helper.AuditEntityRole, strconv.Itoa(id), existing, nil))
 	return c.JSON(model.DeleteRoleResponse{
 		Success: true,
 		Message: "Berhasil menghapus role",
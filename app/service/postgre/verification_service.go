@@ -0,0 +1,182 @@
+package postgre
+
+import (
+	"database/sql"
+	"errors"
+	model "go-fiber/app/model/postgre"
+	repository "go-fiber/app/repository/postgre"
+	"go-fiber/helper"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var verificationSigner struct {
+	once   sync.Once
+	signer *helper.VerificationSigner
+	err    error
+}
+
+// loadVerificationSigner -> signer pernyataan verifikasi dari env, dibuat sekali saat dipakai pertama
+func loadVerificationSigner() (*helper.VerificationSigner, error) {
+	verificationSigner.once.Do(func() {
+		verificationSigner.signer, verificationSigner.err = helper.VerificationSignerFromEnv()
+	})
+	return verificationSigner.signer, verificationSigner.err
+}
+
+// parseTanggalLahir -> tanggal lahir opsional (YYYY-MM-DD) dari request create / update alumni
+func parseTanggalLahir(s *string) (*time.Time, *fiber.Error) {
+	if s == nil || strings.TrimSpace(*s) == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02", strings.TrimSpace(*s))
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Format tanggal lahir tidak valid. Gunakan format YYYY-MM-DD")
+	}
+	if t.After(time.Now()) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Tanggal lahir tidak boleh di masa depan")
+	}
+	return &t, nil
+}
+
+// verifyURL -> URL publik pernyataan verifikasi. VERIFICATION_BASE_URL (mis.
+// https://alumni.kampus.ac.id/go-fiber-postgre) menggantikan host request, mis. di belakang proxy.
+func verifyURL(c *fiber.Ctx, token string) string {
+	base := strings.TrimRight(strings.TrimSpace(os.Getenv("VERIFICATION_BASE_URL")), "/")
+	if base == "" {
+		base = c.BaseURL() + "/go-fiber-postgre"
+	}
+	return base + "/verify/" + token
+}
+
+func verificationError(c *fiber.Ctx, status int, message string) error {
+	return c.Status(status).JSON(model.VerifyAlumniResponse{
+		Success: false,
+		Message: message,
+	})
+}
+
+// VerifyAlumniService -> POST /partner/alumni/verify: cocokkan NIM, nama, dan tanggal lahir dengan data
+// alumni. Bila cocok, kirim status akademik minimal beserta pernyataan bertanda tangan (JWS) yang bisa
+// diperiksa siapa pun lewat GET /verify/:token atau secara offline dengan kunci di GET /verify/keys.
+func VerifyAlumniService(c *fiber.Ctx, db *sql.DB) error {
+	var req model.VerifyAlumniRequest
+	if err := c.BodyParser(&req); err != nil {
+		return verificationError(c, fiber.StatusBadRequest, "Request body tidak valid")
+	}
+	nim := strings.TrimSpace(req.NIM)
+	if nim == "" || strings.TrimSpace(req.Nama) == "" || strings.TrimSpace(req.TanggalLahir) == "" {
+		return verificationError(c, fiber.StatusBadRequest, "NIM, nama, dan tanggal lahir wajib diisi")
+	}
+	tanggalLahir, err := helper.ParseDateFlexible(strings.TrimSpace(req.TanggalLahir))
+	if err != nil {
+		return verificationError(c, fiber.StatusBadRequest, "Tanggal lahir: "+err.Error())
+	}
+	signer, err := loadVerificationSigner()
+	if err != nil {
+		return verificationError(c, fiber.StatusInternalServerError, "Kunci verifikasi tidak valid: "+err.Error())
+	}
+
+	// Batas per NIM berlaku untuk semua API key agar tanggal lahir tidak bisa ditebak
+	_, store := loginProtection(db)
+	count, retryAfter, err := store.Hit("verify:nim:"+nim, helper.VerificationAttemptWindow)
+	if err != nil {
+		return verificationError(c, fiber.StatusInternalServerError, "Gagal memeriksa rate limit")
+	}
+	if count > helper.VerificationAttemptLimit() {
+		return tooManyAttempts(c, retryAfter, "Terlalu banyak percobaan verifikasi untuk NIM ini, coba lagi nanti")
+	}
+
+	alumni, err := repository.GetAlumniVerificationByNIM(db, nim)
+	if err != nil && err != sql.ErrNoRows {
+		return verificationError(c, fiber.StatusInternalServerError, "Gagal memverifikasi alumni: "+err.Error())
+	}
+	// NIM tidak ada, tanggal lahir belum diisi, dan data yang salah mendapat jawaban yang sama
+	if alumni == nil || alumni.TanggalLahir == nil ||
+		alumni.TanggalLahir.Format("2006-01-02") != tanggalLahir.Format("2006-01-02") ||
+		!helper.VerificationNameMatches(req.Nama, alumni.Nama) {
+		return c.Status(fiber.StatusOK).JSON(model.VerifyAlumniResponse{
+			Success: true,
+			Message: "Data tidak cocok dengan data alumni",
+			Data:    model.AlumniVerification{Verified: false},
+		})
+	}
+
+	now := time.Now()
+	status := helper.VerificationStatus(alumni.TahunLulus, now)
+	token, err := signer.Sign(helper.VerificationClaims{
+		NIM:        alumni.NIM,
+		Nama:       alumni.Nama,
+		Jurusan:    alumni.Jurusan,
+		Angkatan:   alumni.Angkatan,
+		TahunLulus: alumni.TahunLulus,
+		Status:     status,
+	}, now)
+	if err != nil {
+		return verificationError(c, fiber.StatusInternalServerError, "Gagal menandatangani pernyataan verifikasi: "+err.Error())
+	}
+	expiresAt := now.Add(signer.TTL)
+	return c.Status(fiber.StatusOK).JSON(model.VerifyAlumniResponse{
+		Success: true,
+		Message: "Data cocok dengan data alumni",
+		Data: model.AlumniVerification{
+			Verified:   true,
+			Status:     status,
+			Jurusan:    alumni.Jurusan,
+			Angkatan:   alumni.Angkatan,
+			TahunLulus: alumni.TahunLulus,
+			Token:      token,
+			VerifyURL:  verifyURL(c, token),
+			ExpiresAt:  &expiresAt,
+		},
+	})
+}
+
+// VerificationTokenService -> GET /verify/:token (publik): periksa tanda tangan dan masa berlaku
+// pernyataan verifikasi tanpa akses ke data alumni
+func VerificationTokenService(c *fiber.Ctx) error {
+	signer, err := loadVerificationSigner()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(model.VerificationTokenResponse{
+			Success: false,
+			Message: "Kunci verifikasi tidak valid: " + err.Error(),
+		})
+	}
+	claims, err := signer.Verify(c.Params("token"), time.Now())
+	switch {
+	case errors.Is(err, helper.ErrExpiredVerificationToken):
+		return c.Status(fiber.StatusOK).JSON(model.VerificationTokenResponse{
+			Success: true,
+			Message: "Pernyataan verifikasi sudah kedaluwarsa",
+			Data:    claims,
+		})
+	case err != nil:
+		return c.Status(fiber.StatusOK).JSON(model.VerificationTokenResponse{
+			Success: true,
+			Message: "Pernyataan verifikasi tidak valid",
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(model.VerificationTokenResponse{
+		Success: true,
+		Message: "Pernyataan verifikasi valid",
+		Valid:   true,
+		Data:    claims,
+	})
+}
+
+// VerificationKeysService -> GET /verify/keys (publik): JWKS kunci publik pernyataan verifikasi
+func VerificationKeysService(c *fiber.Ctx) error {
+	signer, err := loadVerificationSigner()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Kunci verifikasi tidak valid: " + err.Error(),
+		})
+	}
+	c.Set(fiber.HeaderCacheControl, "public, max-age=3600")
+	return c.JSON(signer.JWKS())
+}
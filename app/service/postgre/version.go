@@ -0,0 +1,36 @@
+package postgre
+
+import (
+	"go-fiber/helper"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// versionConflictMessage -> pesan 412 saat If-Match tidak sama dengan versi record
+const versionConflictMessage = "Data sudah diubah oleh pengguna lain, ambil ulang data lalu ulangi perubahan"
+
+// ifMatchVersion -> versi yang diminta header If-Match; nil berarti update tanpa syarat.
+// Error 400 bila header tidak valid, 428 bila STRICT_IF_MATCH aktif dan header kosong.
+func ifMatchVersion(c *fiber.Ctx) (*int, *fiber.Error) {
+	version, ok, err := helper.ParseIfMatch(c.Get(fiber.HeaderIfMatch))
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	if !ok {
+		if helper.IfMatchRequired() && c.Get(fiber.HeaderIfMatch) == "" {
+			return nil, fiber.NewError(fiber.StatusPreconditionRequired, "Header If-Match wajib diisi dengan ETag dari response GET")
+		}
+		return nil, nil
+	}
+	return &version, nil
+}
+
+// versionMismatch -> If-Match tidak sama dengan versi saat ini. ETag terbaru ikut dikirim agar
+// client tahu versi yang harus diambil ulang.
+func versionMismatch(c *fiber.Ctx, expected *int, current int) bool {
+	if expected == nil || *expected == current {
+		return false
+	}
+	c.Set(fiber.HeaderETag, helper.ETag(current))
+	return true
+}
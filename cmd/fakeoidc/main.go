@@ -0,0 +1,49 @@
+// Command fakeoidc menjalankan provider OIDC lokal untuk development. Authorization request
+// langsung disetujui untuk akun yang dipilih lewat login_hint (default akun pertama). Jalankan
+// aplikasi dengan OIDC_ISSUER=http://127.0.0.1:9400, OIDC_CLIENT_ID=alumni-app, dan
+// OIDC_REDIRECT_URL ke endpoint callback.
+package main
+
+import (
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"go-fiber/helper"
+)
+
+func main() {
+	addr := os.Getenv("FAKE_OIDC_ADDR")
+	if addr == "" {
+		addr = "127.0.0.1:9400"
+	}
+	clientID := os.Getenv("FAKE_OIDC_CLIENT_ID")
+	if clientID == "" {
+		clientID = "alumni-app"
+	}
+
+	// Akun sesuai data seed Postgres (database/schema.sql) dan Mongo (database/migration.go).
+	// Email yang belum terverifikasi hanya bisa ditautkan lewat NIM.
+	users := []helper.FakeOIDCUser{
+		{Subject: "sso-20170002", Email: "rina.pratama@gmail.com", EmailVerified: true, Name: "Rina Pratama", NIM: "20170002"},
+		{Subject: "sso-20160001", Email: "sayu@gmail.com", EmailVerified: true, Name: "Sayu Amelia", NIM: "20160001"},
+		{Subject: "sso-20150003", Email: "budi@sso.example.ac.id", EmailVerified: false, Name: "Budi Santoso", NIM: "20150003"},
+		{Subject: "sso-2021001", Email: "sayunaa@gmail.com", EmailVerified: true, Name: "Sayu Yunan", NIM: "2021001"},
+		{Subject: "sso-2021002", Email: "siti@sso.example.ac.id", EmailVerified: false, Name: "Siti Nurhaliza", NIM: "2021002"},
+	}
+
+	server, err := helper.StartFakeOIDCServer(addr, clientID, users)
+	if err != nil {
+		log.Fatalf("Failed to start fake OIDC server: %v", err)
+	}
+	log.Printf("Fake OIDC server listening on %s (client_id %s)", server.Issuer(), clientID)
+	for _, u := range users {
+		log.Printf("  login_hint=%s (email_verified=%v, nim=%s)", u.Email, u.EmailVerified, u.NIM)
+	}
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+	server.Close()
+}
@@ -0,0 +1,38 @@
+// Command fakesmtp menjalankan server SMTP lokal untuk development: semua email diterima
+// tanpa autentikasi lalu ditampilkan di log. Jalankan dengan SMTP_HOST=127.0.0.1 dan
+// SMTP_PORT=1025 pada aplikasi.
+package main
+
+import (
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"go-fiber/helper"
+)
+
+func main() {
+	addr := os.Getenv("FAKE_SMTP_ADDR")
+	if addr == "" {
+		addr = "127.0.0.1:1025"
+	}
+
+	server, err := helper.StartFakeSMTPServer(addr, func(m helper.ReceivedMail) {
+		subject := ""
+		if m.Message != nil {
+			subject = m.Message.Header.Get("Subject")
+		}
+		log.Printf("Mail from %s to %s: %s\n%s", m.From, strings.Join(m.To, ", "), subject, m.Raw)
+	})
+	if err != nil {
+		log.Fatalf("Failed to start fake SMTP server: %v", err)
+	}
+	log.Printf("Fake SMTP server listening on %s", server.Addr())
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+	server.Close()
+}
@@ -5,11 +5,13 @@ import (
 	"go-fiber/middleware"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
 func NewApp(db *mongo.Database) *fiber.App {
 	app := fiber.New()
+	app.Use(requestid.New()) // X-Request-ID, dicatat di audit log
 	app.Use(middleware.LoggerMiddleware)
 	app.Post("/check/:key", func(c *fiber.Ctx) error {
 		return service.CheckAlumniService(c, db)
@@ -7,12 +7,14 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
 )
 
 func NewApp(db *sql.DB) *fiber.App {
 	app := fiber.New(fiber.Config{
 		BodyLimit: 2 * 1024 * 1024, // 2MB to support PDF; photo is checked in handler
 	})
+	app.Use(requestid.New()) // X-Request-ID, dicatat di audit log
 	app.Use(middleware.LoggerMiddleware)
 	app.Use(cors.New())
 	app.Post("/check/:key", func(c *fiber.Ctx) error {
@@ -5,6 +5,7 @@ import (
 	"log"
 	"time"
 
+	model "go-fiber/app/model/mongo"
 	utilsmongo "go-fiber/utils/mongo"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -43,7 +44,7 @@ func RunMigrations(db *mongo.Database) error {
 func dropCollections(ctx context.Context, db *mongo.Database) error {
 	log.Println("Dropping existing collections...")
 
-	collections := []string{"roles", "alumni", "pekerjaan_alumni", "files"}
+	collections := []string{"roles", "alumni", "pekerjaan_alumni", "files", "file_categories", "password_resets", "account_deletion_requests"}
 
 	for _, collectionName := range collections {
 		collection := db.Collection(collectionName)
@@ -95,9 +96,40 @@ func createIndexes(ctx context.Context, db *mongo.Database) error {
 			Keys:    bson.D{{Key: "email", Value: 1}},
 			Options: options.Index().SetUnique(true),
 		},
+		{
+			// Satu identitas SSO hanya boleh tertaut ke satu alumni
+			Keys: bson.D{{Key: "oidc_issuer", Value: 1}, {Key: "oidc_subject", Value: 1}},
+			Options: options.Index().SetUnique(true).
+				SetPartialFilterExpression(bson.M{"oidc_subject": bson.M{"$exists": true}}),
+		},
 		{
 			Keys: bson.D{{Key: "role_id", Value: 1}},
 		},
+		{
+			// Full-text search; default_language "none" mematikan stemming bahasa Inggris
+			// dan text index v3 sudah diacritic-insensitive
+			Keys: bson.D{
+				{Key: "nama", Value: "text"},
+				{Key: "nim", Value: "text"},
+				{Key: "email", Value: "text"},
+				{Key: "jurusan", Value: "text"},
+			},
+			Options: options.Index().
+				SetName("alumni_text_search").
+				SetDefaultLanguage("none").
+				SetWeights(bson.D{
+					{Key: "nama", Value: 10},
+					{Key: "nim", Value: 8},
+					{Key: "email", Value: 5},
+					{Key: "jurusan", Value: 3},
+				}),
+		},
+	}
+	// Index keyset pagination: (field sort, _id) untuk cursor ?after/?before
+	for _, field := range []string{"nama", "jurusan", "angkatan", "tahun_lulus", "created_at"} {
+		alumniIndexes = append(alumniIndexes, mongo.IndexModel{
+			Keys: bson.D{{Key: field, Value: 1}, {Key: "_id", Value: 1}},
+		})
 	}
 	if _, err := alumniCollection.Indexes().CreateMany(ctx, alumniIndexes); err != nil {
 		return err
@@ -113,6 +145,46 @@ func createIndexes(ctx context.Context, db *mongo.Database) error {
 		{
 			Keys: bson.D{{Key: "status_pekerjaan", Value: 1}},
 		},
+		{
+			Keys: bson.D{{Key: "gaji_min", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "provinsi", Value: 1}, {Key: "kota", Value: 1}},
+		},
+		{
+			// Filter koordinat near memakai $geoWithin / $centerSphere
+			Keys: bson.D{{Key: "koordinat", Value: "2dsphere"}},
+		},
+		{
+			Keys: bson.D{{Key: "company_id", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "industry_id", Value: 1}},
+		},
+		{
+			Keys: bson.D{
+				{Key: "nama_perusahaan", Value: "text"},
+				{Key: "posisi_jabatan", Value: "text"},
+				{Key: "bidang_industri", Value: "text"},
+				{Key: "lokasi_kerja", Value: "text"},
+				{Key: "deskripsi_pekerjaan", Value: "text"},
+			},
+			Options: options.Index().
+				SetName("pekerjaan_text_search").
+				SetDefaultLanguage("none").
+				SetWeights(bson.D{
+					{Key: "nama_perusahaan", Value: 10},
+					{Key: "posisi_jabatan", Value: 8},
+					{Key: "bidang_industri", Value: 4},
+					{Key: "lokasi_kerja", Value: 4},
+					{Key: "deskripsi_pekerjaan", Value: 1},
+				}),
+		},
+	}
+	for _, field := range []string{"nama_perusahaan", "posisi_jabatan", "tanggal_mulai_kerja", "created_at"} {
+		pekerjaanIndexes = append(pekerjaanIndexes, mongo.IndexModel{
+			Keys: bson.D{{Key: field, Value: 1}, {Key: "_id", Value: 1}},
+		})
 	}
 	if _, err := pekerjaanCollection.Indexes().CreateMany(ctx, pekerjaanIndexes); err != nil {
 		return err
@@ -131,12 +203,171 @@ func createIndexes(ctx context.Context, db *mongo.Database) error {
 		{
 			Keys: bson.D{{Key: "file_type", Value: 1}},
 		},
+		{
+			Keys: bson.D{{Key: "expires_at", Value: 1}},
+		},
+		{
+			// Listing file per user dengan cursor (uploaded_at desc, _id desc)
+			Keys: bson.D{{Key: "alumni_id", Value: 1}, {Key: "uploaded_at", Value: -1}, {Key: "_id", Value: -1}},
+		},
 	}
 	if _, err := filesCollection.Indexes().CreateMany(ctx, filesIndexes); err != nil {
 		return err
 	}
 	log.Println("Created indexes for files collection")
 
+	// File categories collection indexes
+	fileCategoriesCollection := db.Collection("file_categories")
+	fileCategoryIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "name", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	}
+	if _, err := fileCategoriesCollection.Indexes().CreateMany(ctx, fileCategoryIndexes); err != nil {
+		return err
+	}
+	log.Println("Created indexes for file_categories collection")
+
+	// Jobs collection indexes (tidak ikut di-drop agar antrean bertahan setelah restart)
+	jobsCollection := db.Collection("jobs")
+	jobIndexes := []mongo.IndexModel{
+		{
+			// Claim job: status + run_at, juga dipakai untuk lock kedaluwarsa
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "run_at", Value: 1}},
+		},
+		{
+			Keys:    bson.D{{Key: "unique_key", Value: 1}},
+			Options: options.Index().SetUnique(true).SetSparse(true),
+		},
+		{
+			Keys: bson.D{{Key: "type", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "created_at", Value: -1}},
+		},
+	}
+	if _, err := jobsCollection.Indexes().CreateMany(ctx, jobIndexes); err != nil {
+		return err
+	}
+	log.Println("Created indexes for jobs collection")
+
+	// Email outbox indexes (tidak ikut di-drop agar email yang belum terkirim tidak hilang)
+	outboxCollection := db.Collection("email_outbox")
+	outboxIndexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}},
+		},
+		{
+			Keys:    bson.D{{Key: "unique_key", Value: 1}},
+			Options: options.Index().SetUnique(true).SetSparse(true),
+		},
+	}
+	if _, err := outboxCollection.Indexes().CreateMany(ctx, outboxIndexes); err != nil {
+		return err
+	}
+	log.Println("Created indexes for email_outbox collection")
+
+	// Password reset indexes; TTL index menghapus token yang sudah kedaluwarsa
+	resetCollection := db.Collection("password_resets")
+	resetIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "token_hash", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "alumni_id", Value: 1}},
+		},
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	}
+	if _, err := resetCollection.Indexes().CreateMany(ctx, resetIndexes); err != nil {
+		return err
+	}
+	log.Println("Created indexes for password_resets collection")
+
+	// Rate limit counter; TTL index menghapus window yang sudah lewat
+	rateLimitIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}
+	if _, err := db.Collection("rate_limits").Indexes().CreateOne(ctx, rateLimitIndex); err != nil {
+		return err
+	}
+	log.Println("Created indexes for rate_limits collection")
+
+	// Idempotency-Key; TTL index menghapus response yang sudah lewat masa simpan
+	idempotencyIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}
+	if _, err := db.Collection("idempotency_keys").Indexes().CreateOne(ctx, idempotencyIndex); err != nil {
+		return err
+	}
+	log.Println("Created indexes for idempotency_keys collection")
+
+	// API key partner; hash key unik. Collection ini tidak di-drop saat migrasi
+	apiKeyIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "key_hash", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+	if _, err := db.Collection("api_keys").Indexes().CreateOne(ctx, apiKeyIndex); err != nil {
+		return err
+	}
+	log.Println("Created indexes for api_keys collection")
+
+	// Permintaan hapus akun; satu permintaan pending per alumni
+	deletionIndexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "alumni_id", Value: 1}},
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"status": "pending"}),
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+	}
+	if _, err := db.Collection("account_deletion_requests").Indexes().CreateMany(ctx, deletionIndexes); err != nil {
+		return err
+	}
+	log.Println("Created indexes for account_deletion_requests collection")
+
+	// Audit log append-only; collection ini juga tidak di-drop saat migrasi
+	auditIndexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "entity_type", Value: 1}, {Key: "entity_id", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "actor_id", Value: 1}, {Key: "created_at", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "created_at", Value: -1}},
+		},
+	}
+	if _, err := db.Collection("audit_logs").Indexes().CreateMany(ctx, auditIndexes); err != nil {
+		return err
+	}
+	log.Println("Created indexes for audit_logs collection")
+
+	// Data referensi perusahaan dan bidang industri; tidak di-drop saat migrasi. Kunci nama / alias
+	// unik agar satu teks hanya mengarah ke satu data.
+	for _, name := range []string{"companies", "industries"} {
+		referenceIndexes := []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "keys", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys: bson.D{{Key: "nama", Value: 1}},
+			},
+		}
+		if _, err := db.Collection(name).Indexes().CreateMany(ctx, referenceIndexes); err != nil {
+			return err
+		}
+		log.Printf("Created indexes for %s collection", name)
+	}
+
 	return nil
 }
 
@@ -161,6 +392,11 @@ func seedData(ctx context.Context, db *mongo.Database) error {
 		return err
 	}
 
+	// 4. Seed kategori dokumen
+	if err := seedFileCategories(ctx, db); err != nil {
+		return err
+	}
+
 	log.Println("Data seeding completed successfully!")
 	return nil
 }
@@ -205,74 +441,79 @@ func seedAlumni(ctx context.Context, db *mongo.Database, roleIDs map[string]prim
 
 	alumni := []interface{}{
 		bson.M{
-			"nim":         "2021001",
-			"nama":        "Sayu Yunan",
-			"jurusan":     "Teknik Informatika",
-			"angkatan":    2021,
-			"tahun_lulus": 2025,
-			"email":       "sayunaa@gmail.com",
-			"password":    passwordHash,
-			"no_telepon":  "081359528944",
-			"alamat":      "JL Ngagel Rejo Utara NO. 22",
-			"role_id":     roleIDs["admin"],
-			"created_at":  time.Now(),
-			"updated_at":  time.Now(),
+			"nim":           "2021001",
+			"nama":          "Sayu Yunan",
+			"jurusan":       "Teknik Informatika",
+			"angkatan":      2021,
+			"tahun_lulus":   2025,
+			"email":         "sayunaa@gmail.com",
+			"password":      passwordHash,
+			"no_telepon":    "081359528944",
+			"alamat":        "JL Ngagel Rejo Utara NO. 22",
+			"tanggal_lahir": time.Date(2003, 3, 14, 0, 0, 0, 0, time.UTC),
+			"role_id":       roleIDs["admin"],
+			"created_at":    time.Now(),
+			"updated_at":    time.Now(),
 		},
 		bson.M{
-			"nim":         "2021002",
-			"nama":        "Siti Nurhaliza",
-			"jurusan":     "Sistem Informasi",
-			"angkatan":    2021,
-			"tahun_lulus": 2025,
-			"email":       "siti.nurhaliza@email.com",
-			"password":    passwordHash,
-			"no_telepon":  "081234567891",
-			"alamat":      "Jl. Diponegoro No. 2, Malang",
-			"role_id":     roleIDs["user"],
-			"created_at":  time.Now(),
-			"updated_at":  time.Now(),
+			"nim":           "2021002",
+			"nama":          "Siti Nurhaliza",
+			"jurusan":       "Sistem Informasi",
+			"angkatan":      2021,
+			"tahun_lulus":   2025,
+			"email":         "siti.nurhaliza@email.com",
+			"password":      passwordHash,
+			"no_telepon":    "081234567891",
+			"alamat":        "Jl. Diponegoro No. 2, Malang",
+			"tanggal_lahir": time.Date(2003, 7, 2, 0, 0, 0, 0, time.UTC),
+			"role_id":       roleIDs["user"],
+			"created_at":    time.Now(),
+			"updated_at":    time.Now(),
 		},
 		bson.M{
-			"nim":         "2020001",
-			"nama":        "Budi Santoso",
-			"jurusan":     "Teknik Informatika",
-			"angkatan":    2020,
-			"tahun_lulus": 2024,
-			"email":       "budi.santoso@email.com",
-			"password":    passwordHash,
-			"no_telepon":  "081234567892",
-			"alamat":      "Jl. Sudirman No. 3, Jakarta",
-			"role_id":     roleIDs["user"],
-			"created_at":  time.Now(),
-			"updated_at":  time.Now(),
+			"nim":           "2020001",
+			"nama":          "Budi Santoso",
+			"jurusan":       "Teknik Informatika",
+			"angkatan":      2020,
+			"tahun_lulus":   2024,
+			"email":         "budi.santoso@email.com",
+			"password":      passwordHash,
+			"no_telepon":    "081234567892",
+			"alamat":        "Jl. Sudirman No. 3, Jakarta",
+			"tanggal_lahir": time.Date(2002, 11, 23, 0, 0, 0, 0, time.UTC),
+			"role_id":       roleIDs["user"],
+			"created_at":    time.Now(),
+			"updated_at":    time.Now(),
 		},
 		bson.M{
-			"nim":         "2022001",
-			"nama":        "Maria Garcia",
-			"jurusan":     "Teknik Informatika",
-			"angkatan":    2022,
-			"tahun_lulus": 2026,
-			"email":       "maria.garcia@email.com",
-			"password":    passwordHash,
-			"no_telepon":  "081234567893",
-			"alamat":      "Jl. Gatot Subroto No. 4, Bandung",
-			"role_id":     roleIDs["user"],
-			"created_at":  time.Now(),
-			"updated_at":  time.Now(),
+			"nim":           "2022001",
+			"nama":          "Maria Garcia",
+			"jurusan":       "Teknik Informatika",
+			"angkatan":      2022,
+			"tahun_lulus":   2026,
+			"email":         "maria.garcia@email.com",
+			"password":      passwordHash,
+			"no_telepon":    "081234567893",
+			"alamat":        "Jl. Gatot Subroto No. 4, Bandung",
+			"tanggal_lahir": time.Date(2004, 1, 30, 0, 0, 0, 0, time.UTC),
+			"role_id":       roleIDs["user"],
+			"created_at":    time.Now(),
+			"updated_at":    time.Now(),
 		},
 		bson.M{
-			"nim":         "2022002",
-			"nama":        "John Smith",
-			"jurusan":     "Sistem Informasi",
-			"angkatan":    2022,
-			"tahun_lulus": 2026,
-			"email":       "john.smith@email.com",
-			"password":    passwordHash,
-			"no_telepon":  "081234567894",
-			"alamat":      "Jl. Thamrin No. 5, Medan",
-			"role_id":     roleIDs["user"],
-			"created_at":  time.Now(),
-			"updated_at":  time.Now(),
+			"nim":           "2022002",
+			"nama":          "John Smith",
+			"jurusan":       "Sistem Informasi",
+			"angkatan":      2022,
+			"tahun_lulus":   2026,
+			"email":         "john.smith@email.com",
+			"password":      passwordHash,
+			"no_telepon":    "081234567894",
+			"alamat":        "Jl. Thamrin No. 5, Medan",
+			"tanggal_lahir": time.Date(2004, 5, 18, 0, 0, 0, 0, time.UTC),
+			"role_id":       roleIDs["user"],
+			"created_at":    time.Now(),
+			"updated_at":    time.Now(),
 		},
 	}
 
@@ -303,7 +544,16 @@ func seedPekerjaanAlumni(ctx context.Context, db *mongo.Database, alumniIDs []pr
 			"posisi_jabatan":      "Software Developer",
 			"bidang_industri":     "Teknologi",
 			"lokasi_kerja":        "Jakarta",
-			"gaji_range":          "5-8 juta",
+			"negara":              "ID",
+			"provinsi":            "DKI Jakarta",
+			"kota":                "Kota Jakarta Selatan",
+			"latitude":            -6.2615,
+			"longitude":           106.8106,
+			"koordinat":           bson.M{"type": "Point", "coordinates": []float64{106.8106, -6.2615}},
+			"gaji_min":            int64(5000000),
+			"gaji_max":            int64(8000000),
+			"gaji_mata_uang":      "IDR",
+			"gaji_periode":        "bulanan",
 			"tanggal_mulai_kerja": time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
 			"status_pekerjaan":    "aktif",
 			"deskripsi_pekerjaan": "Mengembangkan aplikasi web menggunakan Go dan React",
@@ -316,7 +566,16 @@ func seedPekerjaanAlumni(ctx context.Context, db *mongo.Database, alumniIDs []pr
 			"posisi_jabatan":      "System Analyst",
 			"bidang_industri":     "Teknologi",
 			"lokasi_kerja":        "Surabaya",
-			"gaji_range":          "6-9 juta",
+			"negara":              "ID",
+			"provinsi":            "Jawa Timur",
+			"kota":                "Kota Surabaya",
+			"latitude":            -7.2575,
+			"longitude":           112.7521,
+			"koordinat":           bson.M{"type": "Point", "coordinates": []float64{112.7521, -7.2575}},
+			"gaji_min":            int64(6000000),
+			"gaji_max":            int64(9000000),
+			"gaji_mata_uang":      "IDR",
+			"gaji_periode":        "bulanan",
 			"tanggal_mulai_kerja": time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
 			"status_pekerjaan":    "aktif",
 			"deskripsi_pekerjaan": "Menganalisis kebutuhan sistem dan merancang solusi IT",
@@ -329,7 +588,16 @@ func seedPekerjaanAlumni(ctx context.Context, db *mongo.Database, alumniIDs []pr
 			"posisi_jabatan":      "Data Scientist",
 			"bidang_industri":     "Teknologi",
 			"lokasi_kerja":        "Bandung",
-			"gaji_range":          "8-12 juta",
+			"negara":              "ID",
+			"provinsi":            "Jawa Barat",
+			"kota":                "Kota Bandung",
+			"latitude":            -6.9175,
+			"longitude":           107.6191,
+			"koordinat":           bson.M{"type": "Point", "coordinates": []float64{107.6191, -6.9175}},
+			"gaji_min":            int64(8000000),
+			"gaji_max":            int64(12000000),
+			"gaji_mata_uang":      "IDR",
+			"gaji_periode":        "bulanan",
 			"tanggal_mulai_kerja": time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC),
 			"status_pekerjaan":    "aktif",
 			"deskripsi_pekerjaan": "Menganalisis data besar untuk insights bisnis",
@@ -342,7 +610,16 @@ func seedPekerjaanAlumni(ctx context.Context, db *mongo.Database, alumniIDs []pr
 			"posisi_jabatan":      "DevOps Engineer",
 			"bidang_industri":     "Teknologi",
 			"lokasi_kerja":        "Jakarta",
-			"gaji_range":          "7-10 juta",
+			"negara":              "ID",
+			"provinsi":            "DKI Jakarta",
+			"kota":                "Kota Jakarta Selatan",
+			"latitude":            -6.2615,
+			"longitude":           106.8106,
+			"koordinat":           bson.M{"type": "Point", "coordinates": []float64{106.8106, -6.2615}},
+			"gaji_min":            int64(7000000),
+			"gaji_max":            int64(10000000),
+			"gaji_mata_uang":      "IDR",
+			"gaji_periode":        "bulanan",
 			"tanggal_mulai_kerja": time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
 			"status_pekerjaan":    "aktif",
 			"deskripsi_pekerjaan": "Mengelola infrastruktur cloud dan CI/CD pipeline",
@@ -355,7 +632,7 @@ func seedPekerjaanAlumni(ctx context.Context, db *mongo.Database, alumniIDs []pr
 			"posisi_jabatan":      "Mobile Developer",
 			"bidang_industri":     "Teknologi",
 			"lokasi_kerja":        "Surabaya",
-			"gaji_range":          "6-9 juta",
+			"gaji_range":          "6-9 juta", // format lama, dimigrasi oleh job pekerjaan.salary_migrate; lokasi oleh pekerjaan.location_backfill
 			"tanggal_mulai_kerja": time.Date(2025, 2, 15, 0, 0, 0, 0, time.UTC),
 			"status_pekerjaan":    "aktif",
 			"deskripsi_pekerjaan": "Mengembangkan aplikasi mobile menggunakan Flutter",
@@ -373,3 +650,21 @@ func seedPekerjaanAlumni(ctx context.Context, db *mongo.Database, alumniIDs []pr
 	log.Printf("Inserted %d pekerjaan alumni", len(result.InsertedIDs))
 	return nil
 }
+
+// seedFileCategories mengisi kebijakan default untuk setiap kategori dokumen
+func seedFileCategories(ctx context.Context, db *mongo.Database) error {
+	log.Println("Seeding file categories...")
+
+	var categories []interface{}
+	for _, fc := range model.DefaultFileCategories() {
+		categories = append(categories, fc)
+	}
+
+	result, err := db.Collection("file_categories").InsertMany(ctx, categories)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Inserted %d file categories", len(result.InsertedIDs))
+	return nil
+}
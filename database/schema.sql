@@ -1,11 +1,31 @@
 
+DROP TABLE IF EXISTS audit_logs;
+DROP TABLE IF EXISTS account_deletion_requests;
+DROP TABLE IF EXISTS api_keys;
+DROP TABLE IF EXISTS idempotency_keys;
+DROP TABLE IF EXISTS rate_limits;
+DROP TABLE IF EXISTS mfa_recovery_codes;
+DROP TABLE IF EXISTS password_resets;
+DROP TABLE IF EXISTS email_outbox;
+DROP TABLE IF EXISTS jobs;
 DROP TABLE IF EXISTS pekerjaan_alumni;
+DROP TABLE IF EXISTS companies;
+DROP TABLE IF EXISTS industries;
 DROP TABLE IF EXISTS alumni;
 DROP TABLE IF EXISTS roles;
+DROP TEXT SEARCH CONFIGURATION IF EXISTS idn_unaccent;
+
+-- Full-text search: stemmer bahasa Indonesia + unaccent
+CREATE EXTENSION IF NOT EXISTS unaccent;
+CREATE TEXT SEARCH CONFIGURATION idn_unaccent (COPY = indonesian);
+ALTER TEXT SEARCH CONFIGURATION idn_unaccent
+    ALTER MAPPING FOR hword, hword_part, word WITH unaccent, indonesian_stem;
 
 CREATE TABLE roles (
     id SERIAL PRIMARY KEY,
-    name VARCHAR(50) NOT NULL UNIQUE
+    name VARCHAR(50) NOT NULL UNIQUE,
+    -- Naik setiap update; dikirim sebagai ETag dan dicek terhadap If-Match
+    version INT NOT NULL DEFAULT 1
 );
 
 CREATE TABLE alumni (
@@ -13,24 +33,102 @@ CREATE TABLE alumni (
     email VARCHAR(255) NOT NULL UNIQUE,
     password VARCHAR(255) NOT NULL,
     role_id INT NOT NULL REFERENCES roles(id) ON DELETE RESTRICT,
-    nim VARCHAR(50),
-    nama VARCHAR(255),
-    jurusan VARCHAR(255),
-    angkatan INT,
-    tahun_lulus INT,
+    nim VARCHAR(50) NOT NULL,
+    nama VARCHAR(255) NOT NULL,
+    jurusan VARCHAR(255) NOT NULL,
+    angkatan INT NOT NULL,
+    tahun_lulus INT NOT NULL,
     no_telepon VARCHAR(50),
     alamat TEXT,
+    -- Hanya dipakai verifikasi kelulusan (POST /partner/alumni/verify); tidak pernah dikirim di response
+    tanggal_lahir DATE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    -- Naik setiap update; dikirim sebagai ETag dan dicek terhadap If-Match
+    version INT NOT NULL DEFAULT 1,
+    -- Naik setiap password berubah; token JWT dengan klaim sv lama ditolak
+    session_version INT NOT NULL DEFAULT 0,
+    -- Proteksi brute-force login: counter gagal berturut-turut dan kunci sementara
+    failed_login_attempts INT NOT NULL DEFAULT 0,
+    last_failed_login_at TIMESTAMP,
+    locked_until TIMESTAMP,
+    -- Two-factor authentication (TOTP); pending secret menunggu verifikasi enrollment
+    totp_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    totp_secret VARCHAR(64),
+    totp_pending_secret VARCHAR(64),
+    totp_last_step BIGINT NOT NULL DEFAULT 0,
+    -- Identitas SSO (OIDC) yang tertaut; diisi saat login SSO pertama
+    oidc_issuer VARCHAR(255),
+    oidc_subject VARCHAR(255),
+    search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('idn_unaccent', coalesce(nama, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(nim, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(email, '')), 'B') ||
+        setweight(to_tsvector('idn_unaccent', coalesce(jurusan, '')), 'C')
+    ) STORED
+);
+
+CREATE UNIQUE INDEX idx_alumni_oidc_identity ON alumni(oidc_issuer, oidc_subject) WHERE oidc_subject IS NOT NULL;
+
+CREATE INDEX idx_alumni_search_vector ON alumni USING GIN (search_vector);
+
+-- Index keyset pagination: (kolom sort, id) agar cursor ?after/?before tidak perlu scan
+CREATE INDEX idx_alumni_nama_id ON alumni(nama, id);
+CREATE INDEX idx_alumni_jurusan_id ON alumni(jurusan, id);
+CREATE INDEX idx_alumni_angkatan_id ON alumni(angkatan, id);
+CREATE INDEX idx_alumni_tahun_lulus_id ON alumni(tahun_lulus, id);
+CREATE INDEX idx_alumni_created_at_id ON alumni(created_at, id);
+
+-- Data referensi perusahaan dan bidang industri. keys berisi nama dan alias yang sudah
+-- dinormalisasi (huruf kecil, tanpa tanda baca dan bentuk badan usaha) untuk mencocokkan teks bebas
+CREATE TABLE companies (
+    id SERIAL PRIMARY KEY,
+    nama VARCHAR(255) NOT NULL,
+    aliases TEXT[] NOT NULL DEFAULT '{}',
+    keys TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_companies_keys ON companies USING GIN (keys);
+
+CREATE TABLE industries (
+    id SERIAL PRIMARY KEY,
+    nama VARCHAR(255) NOT NULL,
+    aliases TEXT[] NOT NULL DEFAULT '{}',
+    keys TEXT[] NOT NULL DEFAULT '{}',
     created_at TIMESTAMP NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMP NOT NULL DEFAULT NOW()
 );
 
+CREATE INDEX idx_industries_keys ON industries USING GIN (keys);
+
 CREATE TABLE pekerjaan_alumni (
     id SERIAL PRIMARY KEY,
     alumni_id INT NOT NULL REFERENCES alumni(id) ON DELETE CASCADE,
     nama_perusahaan VARCHAR(255) NOT NULL,
+    -- NULL bila nama perusahaan / bidang industri belum dikenal di data referensi (teks bebas)
+    company_id INT REFERENCES companies(id) ON DELETE SET NULL,
     posisi_jabatan VARCHAR(255) NOT NULL,
     bidang_industri VARCHAR(255) NOT NULL,
+    industry_id INT REFERENCES industries(id) ON DELETE SET NULL,
     lokasi_kerja VARCHAR(255) NOT NULL,
+    -- Lokasi terstruktur; provinsi / kota memakai nama baku dataset wilayah untuk negara ID.
+    -- NULL bila belum diketahui (teks lama diisi oleh job pekerjaan.location_backfill)
+    negara VARCHAR(2),
+    provinsi VARCHAR(100),
+    kota VARCHAR(100),
+    latitude DOUBLE PRECISION CHECK (latitude BETWEEN -90 AND 90),
+    longitude DOUBLE PRECISION CHECK (longitude BETWEEN -180 AND 180),
+    CHECK ((latitude IS NULL) = (longitude IS NULL)),
+    -- Gaji terstruktur; min / max NULL bila tidak diketahui. gaji_rahasia menyembunyikan nominal
+    -- dari selain admin dan alumni pemilik
+    gaji_min BIGINT CHECK (gaji_min >= 0),
+    gaji_max BIGINT CHECK (gaji_max >= 0),
+    gaji_mata_uang VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    gaji_periode VARCHAR(10) NOT NULL DEFAULT 'bulanan' CHECK (gaji_periode IN ('bulanan', 'tahunan')),
+    gaji_rahasia BOOLEAN NOT NULL DEFAULT FALSE,
+    -- Teks gaji format lama ("5-10 juta") yang belum dimigrasi ke kolom terstruktur
     gaji_range VARCHAR(100),
     tanggal_mulai_kerja DATE NOT NULL,
     tanggal_selesai_kerja DATE,
@@ -38,36 +136,229 @@ CREATE TABLE pekerjaan_alumni (
     deskripsi_pekerjaan TEXT,
     created_at TIMESTAMP NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
-    is_delete TIMESTAMP NULL
+    is_delete TIMESTAMP NULL,
+    -- Pekerjaan paruh waktu boleh tumpang tindih dengan pekerjaan lain (aturan riwayat karier)
+    paruh_waktu BOOLEAN NOT NULL DEFAULT FALSE,
+    -- Naik setiap update; dikirim sebagai ETag dan dicek terhadap If-Match
+    version INT NOT NULL DEFAULT 1,
+    search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('idn_unaccent', coalesce(nama_perusahaan, '')), 'A') ||
+        setweight(to_tsvector('idn_unaccent', coalesce(posisi_jabatan, '')), 'A') ||
+        setweight(to_tsvector('idn_unaccent', coalesce(bidang_industri, '')), 'B') ||
+        setweight(to_tsvector('idn_unaccent', coalesce(lokasi_kerja, '')), 'B') ||
+        setweight(to_tsvector('idn_unaccent', coalesce(deskripsi_pekerjaan, '')), 'C')
+    ) STORED
 );
 
+CREATE INDEX idx_pekerjaan_alumni_search_vector ON pekerjaan_alumni USING GIN (search_vector);
+
+-- Index keyset pagination pekerjaan
+CREATE INDEX idx_pekerjaan_alumni_nama_perusahaan_id ON pekerjaan_alumni(nama_perusahaan, id);
+CREATE INDEX idx_pekerjaan_alumni_posisi_jabatan_id ON pekerjaan_alumni(posisi_jabatan, id);
+CREATE INDEX idx_pekerjaan_alumni_tanggal_mulai_kerja_id ON pekerjaan_alumni(tanggal_mulai_kerja, id);
+CREATE INDEX idx_pekerjaan_alumni_created_at_id ON pekerjaan_alumni(created_at, id);
+
+CREATE INDEX idx_pekerjaan_alumni_gaji_min ON pekerjaan_alumni(gaji_min);
+CREATE INDEX idx_pekerjaan_alumni_company_id ON pekerjaan_alumni(company_id);
+CREATE INDEX idx_pekerjaan_alumni_industry_id ON pekerjaan_alumni(industry_id);
+CREATE INDEX idx_pekerjaan_alumni_provinsi_kota ON pekerjaan_alumni(provinsi, kota);
+CREATE INDEX idx_pekerjaan_alumni_lat_lng ON pekerjaan_alumni(latitude, longitude);
+
+-- Jarak lingkaran besar (km) antara dua koordinat, dipakai filter[koordinat][near]; rumus sama
+-- dengan helper.HaversineKm
+CREATE OR REPLACE FUNCTION haversine_km(lat1 DOUBLE PRECISION, lng1 DOUBLE PRECISION, lat2 DOUBLE PRECISION, lng2 DOUBLE PRECISION)
+RETURNS DOUBLE PRECISION AS $$
+    SELECT 2 * 6371.0 * asin(least(1, sqrt(
+        power(sin(radians(lat2 - lat1) / 2), 2) +
+        cos(radians(lat1)) * cos(radians(lat2)) * power(sin(radians(lng2 - lng1) / 2), 2)
+    )))
+$$ LANGUAGE sql IMMUTABLE STRICT PARALLEL SAFE;
+
 -- Add comment to explain the is_delete column purpose
 COMMENT ON COLUMN pekerjaan_alumni.is_delete IS 'Timestamp when the record was soft deleted. NULL means not deleted.';
 
 -- Create an index for better performance on queries filtering by is_delete
-CREATE INDEX idx_pekerjaan_alumni_is_delete ON pekerjaan_alumni(is_delete);
+CREATE INDEX idx_pekerjaan_alumni_is_delete ON pekerjaan_alumni(is_delete, id);
+
+-- Antrean job background; worker mengambil job dengan FOR UPDATE SKIP LOCKED
+CREATE TABLE jobs (
+    id BIGSERIAL PRIMARY KEY,
+    type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'queued',
+    attempts INT NOT NULL DEFAULT 0,
+    max_attempts INT NOT NULL DEFAULT 5,
+    run_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    schedule VARCHAR(100),
+    unique_key VARCHAR(255) UNIQUE,
+    locked_by VARCHAR(100),
+    locked_until TIMESTAMP,
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP,
+    -- Progress / hasil handler (mis. import dan export), dibaca GET /imports/:id dan /exports/:id
+    result JSONB
+);
+
+CREATE INDEX idx_jobs_claim ON jobs(run_at, id) WHERE status IN ('queued', 'running');
+CREATE INDEX idx_jobs_status_created_at ON jobs(status, created_at DESC);
+CREATE INDEX idx_jobs_type_created_at ON jobs(type, created_at DESC);
+
+-- Outbox email: ditulis dalam transaksi yang sama dengan datanya, dikirim oleh job email.dispatch_outbox
+CREATE TABLE email_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    alumni_id INT REFERENCES alumni(id) ON DELETE SET NULL,
+    template VARCHAR(100) NOT NULL,
+    locale VARCHAR(10) NOT NULL,
+    "to" VARCHAR(255) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    text_body TEXT NOT NULL,
+    html_body TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    locked_until TIMESTAMP,
+    last_error TEXT,
+    unique_key VARCHAR(255) UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMP
+);
+
+CREATE INDEX idx_email_outbox_pending ON email_outbox(next_attempt_at, id) WHERE status IN ('pending', 'sending');
+
+-- Token reset password sekali pakai; hanya hash SHA-256 token yang disimpan
+CREATE TABLE password_resets (
+    id BIGSERIAL PRIMARY KEY,
+    alumni_id INT NOT NULL REFERENCES alumni(id) ON DELETE CASCADE,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_password_resets_alumni_id ON password_resets(alumni_id);
+
+-- Kode pemulihan 2FA sekali pakai; hanya hash SHA-256 yang disimpan
+CREATE TABLE mfa_recovery_codes (
+    alumni_id INT NOT NULL REFERENCES alumni(id) ON DELETE CASCADE,
+    code_hash CHAR(64) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (alumni_id, code_hash)
+);
+
+-- Counter rate limit bersama (RATE_LIMIT_STORE=database)
+CREATE TABLE rate_limits (
+    key VARCHAR(255) PRIMARY KEY,
+    count INT NOT NULL,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_rate_limits_expires_at ON rate_limits(expires_at);
+
+-- Response pertama per user + Idempotency-Key untuk di-replay saat POST diulang
+CREATE TABLE idempotency_keys (
+    key VARCHAR(300) PRIMARY KEY,
+    request_hash CHAR(64) NOT NULL,
+    completed BOOLEAN NOT NULL DEFAULT FALSE,
+    status_code INT,
+    content_type VARCHAR(255),
+    response_body BYTEA,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
+
+-- API key client mesin (partner); hanya hash SHA-256 key yang disimpan
+CREATE TABLE api_keys (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(16) NOT NULL,
+    key_hash CHAR(64) NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL,
+    rate_limit INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP,
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    created_by INT REFERENCES alumni(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Permintaan hapus akun dari alumni sendiri (DELETE /me), disetujui / ditolak admin. NIM dan nama
+-- disalin agar riwayat tetap terbaca setelah akun dihapus
+CREATE TABLE account_deletion_requests (
+    id SERIAL PRIMARY KEY,
+    alumni_id INT REFERENCES alumni(id) ON DELETE SET NULL,
+    nim VARCHAR(50) NOT NULL,
+    nama VARCHAR(255) NOT NULL,
+    alasan TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    catatan TEXT,
+    processed_by INT REFERENCES alumni(id) ON DELETE SET NULL,
+    processed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_account_deletion_requests_pending ON account_deletion_requests(alumni_id) WHERE status = 'pending';
+CREATE INDEX idx_account_deletion_requests_status ON account_deletion_requests(status, created_at DESC);
+
+-- Audit log append-only; actor_id sengaja tanpa foreign key agar riwayat tetap utuh
+-- walau alumni pelakunya dihapus
+CREATE TABLE audit_logs (
+    id BIGSERIAL PRIMARY KEY,
+    actor_id INT,
+    actor_role VARCHAR(50),
+    action VARCHAR(50) NOT NULL,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id VARCHAR(64),
+    before JSONB,
+    after JSONB,
+    changes JSONB,
+    metadata JSONB,
+    ip VARCHAR(64),
+    user_agent TEXT,
+    request_id VARCHAR(64),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_logs_entity ON audit_logs(entity_type, entity_id, created_at DESC);
+CREATE INDEX idx_audit_logs_actor ON audit_logs(actor_id, created_at DESC);
+CREATE INDEX idx_audit_logs_created_at ON audit_logs(created_at DESC);
+
+CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_logs bersifat append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_audit_logs_append_only
+    BEFORE UPDATE OR DELETE ON audit_logs
+    FOR EACH ROW EXECUTE FUNCTION audit_logs_append_only();
 
 INSERT INTO roles (name) VALUES ('admin'), ('user');
 
-INSERT INTO alumni (email, password, role_id, nim, nama, jurusan, angkatan, tahun_lulus, no_telepon, alamat)
+INSERT INTO alumni (email, password, role_id, nim, nama, jurusan, angkatan, tahun_lulus, no_telepon, alamat, tanggal_lahir)
 VALUES
-('sayu@gmail.com', '$2a$12$OsfNwKXSbGNaLm25cHcWW.aHssa9JKYmrnlQG6e4CvNeFqFcEKJIa', 1, '20160001', 'Sayu Amelia', 'Informatika', 2016, 2020, '081200000001', 'Jl. Melati No. 1'),
-('rina.pratama@gmail.com', '$2a$12$8f7qEV2pqI4rFU9jHGj37.QOOMWx/KMbb0KRR1lzCzjrD/tas4TXe', 2, '20170002', 'Rina Pratama', 'Sistem Informasi', 2017, 2021, '081200000002', 'Jl. Mawar No. 2'),
-('budi.santoso@gmail.com', '$2a$12$8f7qEV2pqI4rFU9jHGj37.QOOMWx/KMbb0KRR1lzCzjrD/tas4TXe', 2, '20150003', 'Budi Santoso', 'Informatika', 2015, 2019, '081200000003', 'Jl. Kenanga No. 3'),
-('siti.aisyah@gmail.com', '$2a$12$8f7qEV2pqI4rFU9jHGj37.QOOMWx/KMbb0KRR1lzCzjrD/tas4TXe', 2, '20140004', 'Siti Aisyah', 'Teknik Industri', 2014, 2018, '081200000004', 'Jl. Anggrek No. 4'),
-('andi.wijaya@gmail.com', '$2a$12$8f7qEV2pqI4rFU9jHGj37.QOOMWx/KMbb0KRR1lzCzjrD/tas4TXe', 2, '20130005', 'Andi Wijaya', 'Informatika', 2013, 2017, '081200000005', 'Jl. Dahlia No. 5'),
-('dewi.lestari@gmail.com', '$2a$12$8f7qEV2pqI4rFU9jHGj37.QOOMWx/KMbb0KRR1lzCzjrD/tas4TXe', 2, '20120006', 'Dewi Lestari', 'Sistem Informasi', 2012, 2016, '081200000006', 'Jl. Flamboyan No. 6'),
-('fajar.nugraha@gmail.com', '$2a$12$8f7qEV2pqI4rFU9jHGj37.QOOMWx/KMbb0KRR1lzCzjrD/tas4TXe', 2, '20110007', 'Fajar Nugraha', 'Teknik Elektro', 2011, 2015, '081200000007', 'Jl. Teratai No. 7'),
-('intan.safitri@gmail.com', '$2a$12$8f7qEV2pqI4rFU9jHGj37.QOOMWx/KMbb0KRR1lzCzjrD/tas4TXe', 2, '20100008', 'Intan Safitri', 'Teknik Mesin', 2010, 2014, '081200000008', 'Jl. Sakura No. 8'),
-('yoga.prabowo@gmail.com', '$2a$12$8f7qEV2pqI4rFU9jHGj37.QOOMWx/KMbb0KRR1lzCzjrD/tas4TXe', 2, '20180009', 'Yoga Prabowo', 'Informatika', 2018, 2022, '081200000009', 'Jl. Bougenville No. 9'),
-('nabila.putri@gmail.com', '$2a$12$8f7qEV2pqI4rFU9jHGj37.QOOMWx/KMbb0KRR1lzCzjrD/tas4TXe', 2, '20190010', 'Nabila Putri', 'Sistem Informasi', 2019, 2023, '081200000010', 'Jl. Cemara No. 10');
-
-INSERT INTO pekerjaan_alumni (alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan)
+('sayu@gmail.com', '$2a$12$OsfNwKXSbGNaLm25cHcWW.aHssa9JKYmrnlQG6e4CvNeFqFcEKJIa', 1, '20160001', 'Sayu Amelia', 'Informatika', 2016, 2020, '081200000001', 'Jl. Melati No. 1', '1998-03-14'),
+('rina.pratama@gmail.com', '$2a$12$8f7qEV2pqI4rFU9jHGj37.QOOMWx/KMbb0KRR1lzCzjrD/tas4TXe', 2, '20170002', 'Rina Pratama', 'Sistem Informasi', 2017, 2021, '081200000002', 'Jl. Mawar No. 2', '1999-07-02'),
+('budi.santoso@gmail.com', '$2a$12$8f7qEV2pqI4rFU9jHGj37.QOOMWx/KMbb0KRR1lzCzjrD/tas4TXe', 2, '20150003', 'Budi Santoso', 'Informatika', 2015, 2019, '081200000003', 'Jl. Kenanga No. 3', '1997-11-23'),
+('siti.aisyah@gmail.com', '$2a$12$8f7qEV2pqI4rFU9jHGj37.QOOMWx/KMbb0KRR1lzCzjrD/tas4TXe', 2, '20140004', 'Siti Aisyah', 'Teknik Industri', 2014, 2018, '081200000004', 'Jl. Anggrek No. 4', '1996-01-30'),
+('andi.wijaya@gmail.com', '$2a$12$8f7qEV2pqI4rFU9jHGj37.QOOMWx/KMbb0KRR1lzCzjrD/tas4TXe', 2, '20130005', 'Andi Wijaya', 'Informatika', 2013, 2017, '081200000005', 'Jl. Dahlia No. 5', '1995-05-18'),
+('dewi.lestari@gmail.com', '$2a$12$8f7qEV2pqI4rFU9jHGj37.QOOMWx/KMbb0KRR1lzCzjrD/tas4TXe', 2, '20120006', 'Dewi Lestari', 'Sistem Informasi', 2012, 2016, '081200000006', 'Jl. Flamboyan No. 6', '1994-09-09'),
+('fajar.nugraha@gmail.com', '$2a$12$8f7qEV2pqI4rFU9jHGj37.QOOMWx/KMbb0KRR1lzCzjrD/tas4TXe', 2, '20110007', 'Fajar Nugraha', 'Teknik Elektro', 2011, 2015, '081200000007', 'Jl. Teratai No. 7', '1993-12-05'),
+('intan.safitri@gmail.com', '$2a$12$8f7qEV2pqI4rFU9jHGj37.QOOMWx/KMbb0KRR1lzCzjrD/tas4TXe', 2, '20100008', 'Intan Safitri', 'Teknik Mesin', 2010, 2014, '081200000008', 'Jl. Sakura No. 8', '1992-04-27'),
+('yoga.prabowo@gmail.com', '$2a$12$8f7qEV2pqI4rFU9jHGj37.QOOMWx/KMbb0KRR1lzCzjrD/tas4TXe', 2, '20180009', 'Yoga Prabowo', 'Informatika', 2018, 2022, '081200000009', 'Jl. Bougenville No. 9', '2000-08-16'),
+('nabila.putri@gmail.com', '$2a$12$8f7qEV2pqI4rFU9jHGj37.QOOMWx/KMbb0KRR1lzCzjrD/tas4TXe', 2, '20190010', 'Nabila Putri', 'Sistem Informasi', 2019, 2023, '081200000010', 'Jl. Cemara No. 10', '2001-02-11');
+
+INSERT INTO pekerjaan_alumni (alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, negara, provinsi, kota, latitude, longitude, gaji_min, gaji_max, gaji_rahasia, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan)
 VALUES
-(2, 'Perusahaan A', 'Software Engineer', 'Teknologi', 'Jakarta', '8-12jt', '2022-01-10', NULL, 'aktif', 'Pengembangan aplikasi web'),
-(3, 'Perusahaan B', 'Data Analyst', 'Konsultan', 'Bandung', '7-10jt', '2021-06-01', '2023-06-01', 'selesai', 'Analisis data bisnis'),
-(4, 'Perusahaan C', 'Network Engineer', 'Telekomunikasi', 'Surabaya', '6-9jt', '2020-03-15', NULL, 'aktif', 'Administrasi jaringan'),
-(5, 'Perusahaan D', 'QA Engineer', 'Teknologi', 'Yogyakarta', '5-8jt', '2023-02-01', NULL, 'aktif', 'Pengujian perangkat lunak'),
-(6, 'Perusahaan E', 'Product Manager', 'Teknologi', 'Jakarta', '15-20jt', '2019-08-20', '2021-12-31', 'selesai', 'Manajemen produk');
+(2, 'Perusahaan A', 'Software Engineer', 'Teknologi', 'Jakarta', 'ID', 'DKI Jakarta', 'Kota Jakarta Selatan', -6.2615, 106.8106, 8000000, 12000000, FALSE, NULL, '2022-01-10', NULL, 'aktif', 'Pengembangan aplikasi web'),
+(3, 'Perusahaan B', 'Data Analyst', 'Konsultan', 'Bandung', 'ID', 'Jawa Barat', 'Kota Bandung', -6.9175, 107.6191, 7000000, 10000000, FALSE, NULL, '2021-06-01', '2023-06-01', 'selesai', 'Analisis data bisnis'),
+(4, 'Perusahaan C', 'Network Engineer', 'Telekomunikasi', 'Surabaya', 'ID', 'Jawa Timur', 'Kota Surabaya', -7.2575, 112.7521, 6000000, 9000000, TRUE, NULL, '2020-03-15', NULL, 'aktif', 'Administrasi jaringan'),
+(5, 'Perusahaan D', 'QA Engineer', 'Teknologi', 'Yogyakarta', 'ID', 'DI Yogyakarta', 'Kota Yogyakarta', -7.7956, 110.3695, 5000000, 8000000, FALSE, NULL, '2023-02-01', NULL, 'aktif', 'Pengujian perangkat lunak'),
+-- Masih teks gaji dan lokasi format lama; dimigrasi oleh job pekerjaan.salary_migrate dan pekerjaan.location_backfill
+(6, 'Perusahaan E', 'Product Manager', 'Teknologi', 'Jakarta', NULL, NULL, NULL, NULL, NULL, NULL, NULL, FALSE, '15-20jt', '2019-08-20', '2021-12-31', 'selesai', 'Manajemen produk');
 
 
@@ -15,6 +15,165 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/account-deletions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Permintaan hapus akun dari alumni, terbaru dulu",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me (Mongo)"
+                ],
+                "summary": "Daftar permintaan hapus akun",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "pending, approved, atau rejected",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ListAccountDeletionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/account-deletions/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus akun alumni (sama seperti DELETE /alumni/{id}) dan menandai permintaan approved",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me (Mongo)"
+                ],
+                "summary": "Setujui permintaan hapus akun",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID permintaan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Catatan admin (opsional)",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ProcessAccountDeletionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.AccountDeletionResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "409": {
+                        "description": "Permintaan sudah diproses",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.AccountDeletionResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/account-deletions/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menandai permintaan rejected; akun alumni tetap ada",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me (Mongo)"
+                ],
+                "summary": "Tolak permintaan hapus akun",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID permintaan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Catatan admin (opsional)",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ProcessAccountDeletionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.AccountDeletionResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "409": {
+                        "description": "Permintaan sudah diproses",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.AccountDeletionResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/alumni": {
             "get": {
                 "security": [
@@ -22,7 +181,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengambil daftar alumni dengan pagination, sorting, dan pencarian",
+                "description": "Mengambil daftar alumni dengan pagination (offset atau cursor), sorting, dan pencarian",
                 "produces": [
                     "application/json"
                 ],
@@ -60,6 +219,30 @@ const docTemplate = `{
                         "description": "Kata kunci pencarian",
                         "name": "search",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter terstruktur, mis. filter[angkatan][gte]=2018, filter[jurusan][in]=TI,SI",
+                        "name": "filter[field][op]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor dari meta.next_cursor (menggantikan page)",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor dari meta.prev_cursor",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Hitung total data pada mode cursor",
+                        "name": "with_total",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -69,6 +252,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/mongo.GetAllAlumniResponse"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -133,6 +322,69 @@ const docTemplate = `{
                 }
             }
         },
+        "/alumni/batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menjalankan banyak operasi sekaligus. all_or_nothing (default) menyimpan semua atau tidak sama sekali, best_effort menyimpan operasi yang berhasil saja. Update memakai merge patch; batas jumlah operasi diatur BATCH_MAX_OPERATIONS",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alumni (Mongo)"
+                ],
+                "summary": "Batch create/update/delete alumni",
+                "parameters": [
+                    {
+                        "description": "Mode dan daftar operasi",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mongo.BatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.BatchResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.BatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.BatchResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.BatchResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.BatchResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/alumni/check": {
             "get": {
                 "security": [
@@ -140,7 +392,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengecek status alumni menggunakan API key legacy",
+                "description": "Mengecek status alumni menggunakan API key legacy (API_KEY tunggal). Deprecated: gunakan /partner/alumni/check dengan header X-API-Key; nonaktif bila LEGACY_API_KEY_ENABLED=false",
                 "produces": [
                     "application/json"
                 ],
@@ -148,6 +400,7 @@ const docTemplate = `{
                     "Alumni (Mongo)"
                 ],
                 "summary": "Cek alumni berdasarkan NIM",
+                "deprecated": true,
                 "parameters": [
                     {
                         "type": "string",
@@ -182,185 +435,135 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/fiber.Map"
                         }
+                    },
+                    "410": {
+                        "description": "Endpoint legacy dinonaktifkan",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
                     }
                 }
             }
         },
-        "/alumni/{id}": {
+        "/alumni/employment-status": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengambil data alumni berdasarkan ID",
+                "description": "Rekap pekerjaan terbaru tiap alumni, mendukung filter terstruktur filter[field][op]=value",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Alumni (Mongo)"
                 ],
-                "summary": "Detail alumni",
+                "summary": "Status pekerjaan alumni",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Halaman",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah per halaman",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "ID Alumni",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "nama (default), angkatan, tanggal_mulai_kerja, gaji_min, gaji_max; nilai kosong selalu di akhir",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc atau desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter terstruktur, mis. filter[employment_count][gte]=2, filter[gaji_min][gte]=8000000, filter[gaji_periode][eq]=bulanan",
+                        "name": "filter[field][op]",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mongo.GetAlumniByIDResponse"
+                            "$ref": "#/definitions/mongo.GetAlumniEmploymentStatusResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/mongo.GetAlumniByIDResponse"
+                            "$ref": "#/definitions/fiber.Map"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/mongo.GetAlumniByIDResponse"
+                            "$ref": "#/definitions/fiber.Map"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/alumni/employment-status/export": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Memperbarui data alumni berdasarkan ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Export rekap status pekerjaan alumni tanpa pagination, filter sama dengan GET /alumni/employment-status",
                 "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/x-ndjson",
+                    "application/pdf",
                     "application/json"
                 ],
                 "tags": [
-                    "Alumni (Mongo)"
+                    "Export (Mongo)"
                 ],
-                "summary": "Perbarui alumni",
+                "summary": "Export status pekerjaan alumni",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID Alumni",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "csv (default), xlsx, ndjson, pdf",
+                        "name": "format",
+                        "in": "query"
                     },
                     {
-                        "description": "Permintaan pembaruan alumni",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/mongo.UpdateAlumniRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/mongo.UpdateAlumniResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/mongo.UpdateAlumniResponse"
-                        }
+                        "type": "boolean",
+                        "description": "Jalankan sebagai job background",
+                        "name": "async",
+                        "in": "query"
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/mongo.UpdateAlumniResponse"
-                        }
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Menghapus data alumni berdasarkan ID",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Alumni (Mongo)"
-                ],
-                "summary": "Hapus alumni",
-                "parameters": [
                     {
                         "type": "string",
-                        "description": "ID Alumni",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter terstruktur, sama dengan GET /alumni/employment-status",
+                        "name": "filter[field][op]",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mongo.DeleteAlumniResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/mongo.DeleteAlumniResponse"
+                            "type": "file"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/mongo.DeleteAlumniResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/login": {
-            "post": {
-                "description": "Mengembalikan token JWT untuk mengakses API Mongo",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Auth (Mongo)"
-                ],
-                "summary": "Login user (Mongo)",
-                "parameters": [
-                    {
-                        "description": "Login request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/mongo.LoginRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/mongo.LoginResponse"
+                            "$ref": "#/definitions/mongo.ExportJobResponse"
                         }
                     },
                     "400": {
@@ -369,8 +572,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/fiber.Map"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/fiber.Map"
                         }
@@ -378,37 +581,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/pekerjaan": {
+        "/alumni/export": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengambil daftar pekerjaan alumni dengan pagination, sorting, dan pencarian",
+                "description": "Export alumni sebagai CSV, XLSX, NDJSON, atau laporan PDF dengan search, sortBy, order, dan filter yang sama seperti GET /alumni. Export di atas 10000 baris atau dengan async=true berjalan di background (202)",
                 "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/x-ndjson",
+                    "application/pdf",
                     "application/json"
                 ],
                 "tags": [
-                    "Pekerjaan (Mongo)"
+                    "Export (Mongo)"
                 ],
-                "summary": "Daftar pekerjaan alumni",
+                "summary": "Export alumni",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Halaman",
-                        "name": "page",
+                        "type": "string",
+                        "description": "csv (default), xlsx, ndjson, pdf",
+                        "name": "format",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Jumlah per halaman",
-                        "name": "limit",
+                        "type": "boolean",
+                        "description": "Jalankan sebagai job background",
+                        "name": "async",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Kolom sortir (id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, tanggal_mulai_kerja, status_pekerjaan, created_at)",
+                        "description": "Kolom sortir",
                         "name": "sortBy",
                         "in": "query"
                     },
@@ -423,13 +630,31 @@ const docTemplate = `{
                         "description": "Kata kunci pencarian",
                         "name": "search",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter terstruktur, sama dengan GET /alumni",
+                        "name": "filter[field][op]",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mongo.GetAllPekerjaanResponse"
+                            "type": "file"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ExportJobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
                         }
                     },
                     "500": {
@@ -439,122 +664,146 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/alumni/import": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Membuat data pekerjaan baru untuk alumni",
+                "description": "Upsert alumni berdasarkan NIM atau email. Kolom wajib: nim, nama, jurusan, angkatan, tahun_lulus, email. dry_run=true hanya mengembalikan laporan validasi; tanpa dry_run import berjalan di background",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Pekerjaan (Mongo)"
+                    "Import (Mongo)"
                 ],
-                "summary": "Tambah pekerjaan alumni",
+                "summary": "Import alumni dari CSV/XLSX",
                 "parameters": [
                     {
-                        "description": "Data pekerjaan alumni",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/mongo.CreatePekerjaanAlumniRequest"
-                        }
+                        "type": "file",
+                        "description": "File .csv atau .xlsx (maks 5000 baris)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validasi saja tanpa menyimpan",
+                        "name": "dry_run",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mongo.CreatePekerjaanAlumniResponse"
+                            "$ref": "#/definitions/mongo.ImportReportResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ImportJobResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/mongo.CreatePekerjaanAlumniResponse"
+                            "$ref": "#/definitions/fiber.Map"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/mongo.CreatePekerjaanAlumniResponse"
+                            "$ref": "#/definitions/fiber.Map"
                         }
                     }
                 }
             }
         },
-        "/pekerjaan/alumni/{alumni_id}": {
+        "/alumni/search": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengambil seluruh pekerjaan milik alumni tertentu",
+                "description": "Full-text search alumni pada nama, nim, email, dan jurusan dengan ranking relevansi dan highlight",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Pekerjaan (Mongo)"
+                    "Alumni (Mongo)"
                 ],
-                "summary": "Daftar pekerjaan per alumni",
+                "summary": "Cari alumni (full-text)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID Alumni",
-                        "name": "alumni_id",
-                        "in": "path",
+                        "description": "Kata kunci pencarian",
+                        "name": "q",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Halaman",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah per halaman (maks 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mongo.GetPekerjaanAlumniByAlumniIDResponse"
+                            "$ref": "#/definitions/mongo.SearchAlumniResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/mongo.GetPekerjaanAlumniByAlumniIDResponse"
+                            "$ref": "#/definitions/mongo.SearchAlumniResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/mongo.GetPekerjaanAlumniByAlumniIDResponse"
+                            "$ref": "#/definitions/mongo.SearchAlumniResponse"
                         }
                     }
                 }
             }
         },
-        "/pekerjaan/{id}": {
+        "/alumni/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengambil detail pekerjaan alumni berdasarkan ID pekerjaan",
+                "description": "Mengambil data alumni berdasarkan ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Pekerjaan (Mongo)"
+                    "Alumni (Mongo)"
                 ],
-                "summary": "Detail pekerjaan alumni",
+                "summary": "Detail alumni",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID Pekerjaan",
+                        "description": "ID Alumni",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -564,19 +813,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mongo.GetPekerjaanAlumniByIDResponse"
+                            "$ref": "#/definitions/mongo.GetAlumniByIDResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/mongo.GetPekerjaanAlumniByIDResponse"
+                            "$ref": "#/definitions/mongo.GetAlumniByIDResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/mongo.GetPekerjaanAlumniByIDResponse"
+                            "$ref": "#/definitions/mongo.GetAlumniByIDResponse"
                         }
                     }
                 }
@@ -587,7 +836,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Memperbarui data pekerjaan alumni berdasarkan ID pekerjaan",
+                "description": "Memperbarui data alumni berdasarkan ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -595,24 +844,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Pekerjaan (Mongo)"
+                    "Alumni (Mongo)"
                 ],
-                "summary": "Perbarui pekerjaan alumni",
+                "summary": "Perbarui alumni",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID Pekerjaan",
+                        "description": "ID Alumni",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Data pekerjaan alumni yang diperbarui",
+                        "description": "Permintaan pembaruan alumni",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/mongo.UpdatePekerjaanAlumniRequest"
+                            "$ref": "#/definitions/mongo.UpdateAlumniRequest"
                         }
                     }
                 ],
@@ -620,19 +869,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mongo.UpdatePekerjaanAlumniResponse"
+                            "$ref": "#/definitions/mongo.UpdateAlumniResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/mongo.UpdatePekerjaanAlumniResponse"
+                            "$ref": "#/definitions/mongo.UpdateAlumniResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/mongo.UpdatePekerjaanAlumniResponse"
+                            "$ref": "#/definitions/mongo.UpdateAlumniResponse"
                         }
                     }
                 }
@@ -643,18 +892,18 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menghapus data pekerjaan alumni berdasarkan ID pekerjaan",
+                "description": "Menghapus data alumni berdasarkan ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Pekerjaan (Mongo)"
+                    "Alumni (Mongo)"
                 ],
-                "summary": "Hapus pekerjaan alumni",
+                "summary": "Hapus alumni",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID Pekerjaan",
+                        "description": "ID Alumni",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -664,148 +913,229 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/fiber.Map"
+                            "$ref": "#/definitions/mongo.DeleteAlumniResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/fiber.Map"
+                            "$ref": "#/definitions/mongo.DeleteAlumniResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/fiber.Map"
+                            "$ref": "#/definitions/mongo.DeleteAlumniResponse"
                         }
                     }
                 }
-            }
-        },
-        "/profile": {
-            "get": {
+            },
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengambil profil user berdasarkan token",
+                "description": "Menerapkan JSON Merge Patch (RFC 7396) atau JSON Patch (RFC 6902) ke alumni; null menghapus field opsional",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Profile (Mongo)"
+                    "Alumni (Mongo)"
                 ],
-                "summary": "Profil pengguna saat ini",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                "summary": "Ubah sebagian alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Alumni",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari response GET",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Merge patch (application/merge-patch+json) atau array operasi JSON Patch (application/json-patch+json)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/mongo.GetProfileResponse"
+                            "$ref": "#/definitions/mongo.AlumniPatchDocument"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdateAlumniResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/fiber.Map"
+                            "$ref": "#/definitions/mongo.UpdateAlumniResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdateAlumniResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdateAlumniResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdateAlumniResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdateAlumniResponse"
                         }
                     }
                 }
             }
         },
-        "/roles": {
+        "/alumni/{id}/career": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengambil seluruh role yang tersedia",
+                "description": "Riwayat pekerjaan alumni berurutan dengan masa kerja per pekerjaan, celah antar pekerjaan, pekerjaan penuh waktu yang tumpang tindih, dan total pengalaman",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Roles (Mongo)"
+                    "Alumni (Mongo)"
+                ],
+                "summary": "Timeline karier alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Alumni",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Daftar role",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mongo.ListRolesResponse"
+                            "$ref": "#/definitions/mongo.CareerTimelineResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/fiber.Map"
+                            "$ref": "#/definitions/mongo.CareerTimelineResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.CareerTimelineResponse"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/alumni/{id}/history": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Membuat role baru",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Audit log satu alumni (perubahan data, login, unlock), terbaru lebih dulu. Tetap tersedia setelah alumni dihapus",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Roles (Mongo)"
+                    "Audit (Mongo)"
                 ],
-                "summary": "Tambah role",
+                "summary": "Riwayat perubahan alumni",
                 "parameters": [
                     {
-                        "description": "Permintaan pembuatan role",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/mongo.CreateRoleRequest"
-                        }
+                        "type": "string",
+                        "description": "ID Alumni",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Halaman",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Jumlah per halaman (maks 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mongo.CreateRoleResponse"
+                            "$ref": "#/definitions/mongo.AuditLogListResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/mongo.CreateRoleResponse"
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
                         }
                     }
                 }
             }
         },
-        "/roles/{id}": {
-            "get": {
+        "/alumni/{id}/unlock": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengambil data role berdasarkan ID",
+                "description": "Menghapus kunci sementara, counter gagal login, dan rate limit akun alumni",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Roles (Mongo)"
+                    "Alumni (Mongo)"
                 ],
-                "summary": "Detail role",
+                "summary": "Buka kunci akun alumni",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID Role",
+                        "description": "ID Alumni",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -815,151 +1145,195 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mongo.GetRoleByIDResponse"
+                            "$ref": "#/definitions/mongo.UnlockAlumniResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UnlockAlumniResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/mongo.GetRoleByIDResponse"
+                            "$ref": "#/definitions/mongo.UnlockAlumniResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/analytics/distribution": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Memperbarui data role berdasarkan ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Jumlah pekerjaan dan alumni per bidang_industri atau lokasi_kerja",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Roles (Mongo)"
+                    "Analytics (Mongo)"
                 ],
-                "summary": "Perbarui role",
+                "summary": "Distribusi pekerjaan",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID Role",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "bidang_industri (default) atau lokasi_kerja",
+                        "name": "field",
+                        "in": "query"
                     },
                     {
-                        "description": "Permintaan pembaruan role",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/mongo.UpdateRoleRequest"
-                        }
+                        "type": "boolean",
+                        "description": "Hanya pekerjaan berstatus aktif",
+                        "name": "active_only",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter kohort: jurusan, angkatan, tahun_lulus",
+                        "name": "filter[field][op]",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mongo.UpdateRoleResponse"
+                            "$ref": "#/definitions/mongo.DistributionResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/mongo.UpdateRoleResponse"
+                            "$ref": "#/definitions/fiber.Map"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/mongo.UpdateRoleResponse"
+                            "$ref": "#/definitions/fiber.Map"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/analytics/employment-rate": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menghapus role berdasarkan ID",
+                "description": "Persentase alumni yang sedang bekerja (pekerjaan aktif) per kelompok",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Roles (Mongo)"
+                    "Analytics (Mongo)"
                 ],
-                "summary": "Hapus role",
+                "summary": "Employment rate alumni",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID Role",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "angkatan (default), jurusan, tahun_lulus, atau all",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter kohort: jurusan, angkatan, tahun_lulus",
+                        "name": "filter[field][op]",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mongo.DeleteRoleResponse"
+                            "$ref": "#/definitions/mongo.EmploymentRateResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/mongo.DeleteRoleResponse"
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
                         }
                     }
                 }
             }
         },
-        "/users/{id}/upload/certificate": {
-            "post": {
+        "/analytics/export": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Upload file pdf maksimal 2MB untuk user tertentu",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Export hasil satu metric analytics sebagai CSV, XLSX, NDJSON, atau laporan PDF",
                 "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/x-ndjson",
+                    "application/pdf",
                     "application/json"
                 ],
                 "tags": [
-                    "Files (Mongo)"
+                    "Export (Mongo)"
                 ],
-                "summary": "Upload sertifikat/ijazah",
+                "summary": "Export analytics",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID User",
-                        "name": "id",
-                        "in": "path",
+                        "description": "employment-rate, time-to-first-job, distribution, salary, retention",
+                        "name": "metric",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "type": "file",
-                        "description": "File sertifikat (pdf, max 2MB)",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
+                        "type": "string",
+                        "description": "csv (default), xlsx, ndjson, pdf",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "angkatan, jurusan, tahun_lulus, atau all",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Untuk distribution: bidang_industri atau lokasi_kerja",
+                        "name": "field",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Untuk distribution dan salary: hanya pekerjaan aktif",
+                        "name": "active_only",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter kohort: jurusan, angkatan, tahun_lulus",
+                        "name": "filter[field][op]",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mongo.FileUploadResponse"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -968,12 +1342,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/fiber.Map"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/fiber.Map"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -983,45 +1351,228 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}/upload/photo": {
-            "post": {
+        "/analytics/retention": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Upload file foto jpeg/jpg/png maksimal 1MB untuk user tertentu",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Persentase pekerjaan yang bertahan lebih dari 1 tahun",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Files (Mongo)"
+                    "Analytics (Mongo)"
                 ],
-                "summary": "Upload foto profil",
+                "summary": "Retensi pekerjaan",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID User",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "all (default), angkatan, jurusan, tahun_lulus",
+                        "name": "group_by",
+                        "in": "query"
                     },
                     {
-                        "type": "file",
-                        "description": "File foto (jpeg/jpg/png, max 1MB)",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
+                        "type": "string",
+                        "description": "Filter kohort: jurusan, angkatan, tahun_lulus",
+                        "name": "filter[field][op]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.RetentionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/salary": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Histogram gaji (juta rupiah per bulan) dari titik tengah gaji_min / gaji_max; gaji tahunan dibagi 12, gaji_range lama yang belum dimigrasi tetap dibaca",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics (Mongo)"
+                ],
+                "summary": "Histogram gaji",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Hanya pekerjaan berstatus aktif",
+                        "name": "active_only",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter kohort: jurusan, angkatan, tahun_lulus",
+                        "name": "filter[field][op]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.SalaryHistogramResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/time-to-first-job": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Median dan rata-rata bulan dari kelulusan (Juli tahun_lulus) ke pekerjaan pertama",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics (Mongo)"
+                ],
+                "summary": "Time-to-first-job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "all (default), angkatan, jurusan, tahun_lulus",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter kohort: jurusan, angkatan, tahun_lulus",
+                        "name": "filter[field][op]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.TimeToFirstJobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil semua API key partner (tanpa key maupun hash-nya)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API Keys (Mongo)"
+                ],
+                "summary": "Daftar API key",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ListAPIKeysResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membuat API key partner dengan scope, rate limit (request per menit, 0 = tanpa batas) dan masa berlaku opsional. Key hanya ditampilkan sekali",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API Keys (Mongo)"
+                ],
+                "summary": "Buat API key",
+                "parameters": [
+                    {
+                        "description": "Data API key",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mongo.CreateAPIKeyRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/mongo.FileUploadResponse"
+                            "$ref": "#/definitions/mongo.CreateAPIKeyResponse"
                         }
                     },
                     "400": {
@@ -1044,14 +1595,6170 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "fiber.Map": {
+        },
+        "/api-keys/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mencabut API key partner; key langsung tidak bisa dipakai lagi",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API Keys (Mongo)"
+                ],
+                "summary": "Cabut API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID API key",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.APIKeyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/audit-logs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Audit log semua perubahan data dan event autentikasi, terbaru lebih dulu",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Audit (Mongo)"
+                ],
+                "summary": "Query audit log",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID alumni pelaku",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role pelaku",
+                        "name": "actor_role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "create, update, delete, soft_delete, restore, upload, import, unlock, revoke, login, atau login_failed",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "alumni, pekerjaan, role, file, file_category, atau api_key",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID entity",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "X-Request-ID request asal",
+                        "name": "request_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sejak (RFC3339 atau YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sampai (RFC3339 atau YYYY-MM-DD, inklusif)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Halaman",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Jumlah per halaman (maks 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.AuditLogListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/callback": {
+            "get": {
+                "description": "Menukar code dengan ID token, menautkan identitas ke alumni (sub tertaut, email terverifikasi, atau NIM), lalu mengembalikan respons login",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth (Mongo)"
+                ],
+                "summary": "Callback SSO (Mongo)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "State dari redirect login",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "403": {
+                        "description": "Identitas tidak cocok dengan alumni mana pun",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "409": {
+                        "description": "Alumni sudah tertaut ke identitas SSO lain",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/login": {
+            "get": {
+                "description": "Redirect ke provider OIDC (authorization code + PKCE). State disimpan di cookie oidc_state selama 10 menit",
+                "tags": [
+                    "Auth (Mongo)"
+                ],
+                "summary": "Login SSO (Mongo)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Diteruskan ke provider sebagai login_hint",
+                        "name": "login_hint",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found"
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "503": {
+                        "description": "OIDC belum dikonfigurasi",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/companies": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Data referensi urut nama; search dicocokkan dengan nama dan alias",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reference Data (Mongo)"
+                ],
+                "summary": "Daftar perusahaan / bidang industri",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Potongan nama atau alias",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ListReferenceDataResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Nama dan alias tidak boleh sudah dipakai data lain (dibandingkan setelah dinormalisasi)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reference Data (Mongo)"
+                ],
+                "summary": "Tambah perusahaan / bidang industri",
+                "parameters": [
+                    {
+                        "description": "Nama dan alias",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ReferenceDataRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ReferenceDataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/companies/autocomplete": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Saran nama berdasarkan kemiripan dengan nama dan alias, termasuk salah ketik; skor tertinggi lebih dulu",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reference Data (Mongo)"
+                ],
+                "summary": "Autocomplete perusahaan / bidang industri",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Teks yang sedang diketik",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Jumlah saran (maks 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ReferenceAutocompleteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/companies/backfill": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menjadwalkan job reference.backfill: pekerjaan tanpa referensi dihubungkan berdasarkan teks bebasnya, teks yang belum dikenal dibuatkan data baru",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reference Data (Mongo)"
+                ],
+                "summary": "Backfill data referensi",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.JobResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/companies/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reference Data (Mongo)"
+                ],
+                "summary": "Detail perusahaan / bidang industri",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID data referensi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ReferenceDataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Bila nama berubah, teks bebas pekerjaan yang terhubung ikut diganti",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reference Data (Mongo)"
+                ],
+                "summary": "Update perusahaan / bidang industri",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID data referensi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nama dan alias",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ReferenceDataRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ReferenceDataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pekerjaan yang terhubung dilepas dan kembali memakai teks bebasnya",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reference Data (Mongo)"
+                ],
+                "summary": "Hapus perusahaan / bidang industri",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID data referensi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ReferenceDataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/companies/{id}/merge": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Nama dan alias source_ids menjadi alias data :id, pekerjaannya dipindahkan, lalu duplikat dihapus",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reference Data (Mongo)"
+                ],
+                "summary": "Gabungkan duplikat",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID data referensi tujuan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ID duplikat",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ReferenceMergeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ReferenceMergeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/exports/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Progress export dari antrean job (status queued, running, completed, failed): status, jumlah baris, dan download_url setelah selesai",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Export (Mongo)"
+                ],
+                "summary": "Status job export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID job export",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ExportJobResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/exports/{id}/download": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengunduh file hasil job export yang sudah completed (file disimpan 24 jam)",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/x-ndjson",
+                    "application/pdf",
+                    "application/json"
+                ],
+                "tags": [
+                    "Export (Mongo)"
+                ],
+                "summary": "Unduh hasil export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID job export",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/file-categories": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil seluruh kategori dokumen beserta kebijakan upload-nya",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Files (Mongo)"
+                ],
+                "summary": "Daftar kategori dokumen",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ListFileCategoriesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ListFileCategoriesResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/file-categories/{name}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membuat atau memperbarui kebijakan kategori dokumen",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Files (Mongo)"
+                ],
+                "summary": "Simpan kategori dokumen",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nama kategori",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Kebijakan kategori",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpsertFileCategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.FileCategoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.FileCategoryResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.FileCategoryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Progress job import dari antrean job (status queued, running, completed, failed): jumlah baris diproses, dibuat, diupdate, gagal, dan error per baris",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Import (Mongo)"
+                ],
+                "summary": "Status job import",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID job import",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ImportJobResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/industries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Data referensi urut nama; search dicocokkan dengan nama dan alias",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reference Data (Mongo)"
+                ],
+                "summary": "Daftar perusahaan / bidang industri",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Potongan nama atau alias",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ListReferenceDataResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Nama dan alias tidak boleh sudah dipakai data lain (dibandingkan setelah dinormalisasi)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reference Data (Mongo)"
+                ],
+                "summary": "Tambah perusahaan / bidang industri",
+                "parameters": [
+                    {
+                        "description": "Nama dan alias",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ReferenceDataRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ReferenceDataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/industries/autocomplete": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Saran nama berdasarkan kemiripan dengan nama dan alias, termasuk salah ketik; skor tertinggi lebih dulu",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reference Data (Mongo)"
+                ],
+                "summary": "Autocomplete perusahaan / bidang industri",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Teks yang sedang diketik",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Jumlah saran (maks 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ReferenceAutocompleteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/industries/backfill": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menjadwalkan job reference.backfill: pekerjaan tanpa referensi dihubungkan berdasarkan teks bebasnya, teks yang belum dikenal dibuatkan data baru",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reference Data (Mongo)"
+                ],
+                "summary": "Backfill data referensi",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.JobResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/industries/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reference Data (Mongo)"
+                ],
+                "summary": "Detail perusahaan / bidang industri",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID data referensi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ReferenceDataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Bila nama berubah, teks bebas pekerjaan yang terhubung ikut diganti",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reference Data (Mongo)"
+                ],
+                "summary": "Update perusahaan / bidang industri",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID data referensi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nama dan alias",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ReferenceDataRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ReferenceDataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pekerjaan yang terhubung dilepas dan kembali memakai teks bebasnya",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reference Data (Mongo)"
+                ],
+                "summary": "Hapus perusahaan / bidang industri",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID data referensi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ReferenceDataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/industries/{id}/merge": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Nama dan alias source_ids menjadi alias data :id, pekerjaannya dipindahkan, lalu duplikat dihapus",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reference Data (Mongo)"
+                ],
+                "summary": "Gabungkan duplikat",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID data referensi tujuan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ID duplikat",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ReferenceMergeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ReferenceMergeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Job terbaru dari antrean, bisa difilter berdasarkan status dan type",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs (Mongo)"
+                ],
+                "summary": "Daftar job background",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "queued, running, completed, dead, atau cancelled",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Type job, mis. file.retention_purge",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Halaman",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Jumlah per halaman (maks 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.JobListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Status, jumlah percobaan, waktu jalan berikutnya (run_at), dan error terakhir sebuah job",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs (Mongo)"
+                ],
+                "summary": "Detail job background",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID job",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.JobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membatalkan job queued atau running; job running dihentikan pada heartbeat berikutnya",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs (Mongo)"
+                ],
+                "summary": "Batalkan job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID job",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.JobResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/retry": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Memasukkan kembali job dead atau cancelled ke antrean dengan jatah percobaan baru",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs (Mongo)"
+                ],
+                "summary": "Retry job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID job",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.JobResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Mengembalikan token JWT untuk mengakses API Mongo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth (Mongo)"
+                ],
+                "summary": "Login user (Mongo)",
+                "parameters": [
+                    {
+                        "description": "Login request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mongo.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit, jeda progresif, atau akun terkunci (lihat header Retry-After)",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/login/mfa": {
+            "post": {
+                "description": "Menukar mfa_token dari /login dengan token JWT penuh. code berupa kode TOTP 6 digit atau kode pemulihan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth (Mongo)"
+                ],
+                "summary": "Verifikasi 2FA saat login (Mongo)",
+                "parameters": [
+                    {
+                        "description": "Token mfa_pending dan kode 2FA",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mongo.MFALoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.MFAResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.MFAResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.MFAResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Data alumni yang sedang login dari database beserta permintaan hapus akun yang masih pending",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me (Mongo)"
+                ],
+                "summary": "Profil saya",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.MeProfileResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membuat permintaan hapus akun yang diproses admin; akun belum dihapus sampai disetujui",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me (Mongo)"
+                ],
+                "summary": "Ajukan hapus akun",
+                "parameters": [
+                    {
+                        "description": "Alasan (opsional)",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.CreateAccountDeletionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.AccountDeletionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "409": {
+                        "description": "Sudah ada permintaan pending",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.AccountDeletionResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "JSON Merge Patch (RFC 7396) atau JSON Patch (RFC 6902) untuk no_telepon dan alamat; field lain ditolak. null atau string kosong menghapus field",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me (Mongo)"
+                ],
+                "summary": "Ubah profil saya",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ETag dari response GET /me",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Merge patch (application/merge-patch+json) atau array operasi JSON Patch (application/json-patch+json)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mongo.MePatchDocument"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdateAlumniResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/files": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Daftar file milik alumni yang sedang login; query sama dengan GET /users/{id}/files",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me (Mongo)"
+                ],
+                "summary": "File saya",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Jumlah per halaman (maks 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor dari meta.next_cursor",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor dari meta.prev_cursor",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Sertakan total data",
+                        "name": "with_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ListFilesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/mfa/disable": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mematikan 2FA dengan password dan kode 2FA. Tidak tersedia untuk admin",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile (Mongo)"
+                ],
+                "summary": "Matikan 2FA",
+                "parameters": [
+                    {
+                        "description": "Password dan kode 2FA",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mongo.MFADisableRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.MFAResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.MFAResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.MFAResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/mfa/enroll": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membuat secret TOTP dan otpauth URI. 2FA aktif setelah kode diverifikasi di /me/mfa/verify. Menerima token mfa_pending",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile (Mongo)"
+                ],
+                "summary": "Mulai enrollment 2FA",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.MFAEnrollResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.MFAResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/mfa/recovery-codes": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengganti semua kode pemulihan; kode lama tidak berlaku lagi",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile (Mongo)"
+                ],
+                "summary": "Buat ulang kode pemulihan 2FA",
+                "parameters": [
+                    {
+                        "description": "Kode TOTP atau kode pemulihan",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mongo.MFACodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.MFARecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.MFAResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/mfa/verify": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Memverifikasi kode dari secret enrollment lalu mengembalikan kode pemulihan (sekali tampil). Dengan token mfa_pending, token penuh ikut dikembalikan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile (Mongo)"
+                ],
+                "summary": "Aktifkan 2FA",
+                "parameters": [
+                    {
+                        "description": "Kode TOTP",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mongo.MFACodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.MFARecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.MFAResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.MFAResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/password": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengganti password dengan memverifikasi password saat ini. Semua sesi lain dicabut dan token baru dikembalikan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile (Mongo)"
+                ],
+                "summary": "Ganti password sendiri",
+                "parameters": [
+                    {
+                        "description": "Password saat ini dan password baru",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ChangePasswordResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.PasswordResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.PasswordResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/pekerjaan": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Daftar pekerjaan milik alumni yang sedang login",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me (Mongo)"
+                ],
+                "summary": "Pekerjaan saya",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.GetPekerjaanAlumniByAlumniIDResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Body sama dengan POST /pekerjaan; alumni_id diambil dari token dan nilai di body diabaikan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me (Mongo)"
+                ],
+                "summary": "Tambah pekerjaan saya",
+                "parameters": [
+                    {
+                        "description": "Data pekerjaan",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mongo.CreatePekerjaanAlumniRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.CreatePekerjaanAlumniResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.CreatePekerjaanAlumniResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.CreatePekerjaanAlumniResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/pekerjaan/{id}": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sama dengan PATCH /pekerjaan/{id} untuk pekerjaan milik sendiri; pekerjaan alumni lain dijawab 404",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me (Mongo)"
+                ],
+                "summary": "Ubah sebagian pekerjaan saya",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Pekerjaan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari response GET",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Merge patch (application/merge-patch+json) atau array operasi JSON Patch (application/json-patch+json)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mongo.PekerjaanPatchDocument"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdatePekerjaanAlumniResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdatePekerjaanAlumniResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdatePekerjaanAlumniResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdatePekerjaanAlumniResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdatePekerjaanAlumniResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/partner/alumni": {
+            "get": {
+                "security": [
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Mengambil daftar alumni untuk client mesin dengan API key ber-scope alumni:read. Query sama dengan GET /alumni",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Partner (Mongo)"
+                ],
+                "summary": "Daftar alumni (partner)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.GetAllAlumniResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "403": {
+                        "description": "Scope API key tidak mencukupi",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit API key terlampaui (lihat header Retry-After)",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/partner/alumni/check": {
+            "get": {
+                "security": [
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Mengecek status alumni untuk client mesin dengan API key ber-scope alumni:check",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Partner (Mongo)"
+                ],
+                "summary": "Cek alumni berdasarkan NIM (partner)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "NIM Mahasiswa",
+                        "name": "nim",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.CheckAlumniResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "403": {
+                        "description": "Scope API key tidak mencukupi",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit API key terlampaui (lihat header Retry-After)",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/partner/alumni/verify": {
+            "post": {
+                "security": [
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Mencocokkan NIM, nama, dan tanggal lahir dengan data alumni untuk API key ber-scope alumni:verify. Data yang tidak cocok dijawab verified=false tanpa membedakan penyebabnya; bila cocok dikirim status akademik dan pernyataan bertanda tangan (JWS EdDSA)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Partner (Mongo)"
+                ],
+                "summary": "Verifikasi kelulusan (partner)",
+                "parameters": [
+                    {
+                        "description": "Data yang diverifikasi",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mongo.VerifyAlumniRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.VerifyAlumniResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "403": {
+                        "description": "Scope API key tidak mencukupi",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "429": {
+                        "description": "Terlalu banyak percobaan untuk NIM ini atau rate limit API key terlampaui (lihat header Retry-After)",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/partner/alumni/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Mengambil data alumni berdasarkan ID untuk client mesin dengan API key ber-scope alumni:read",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Partner (Mongo)"
+                ],
+                "summary": "Detail alumni (partner)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Alumni",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.GetAlumniByIDResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "403": {
+                        "description": "Scope API key tidak mencukupi",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/password/forgot": {
+            "post": {
+                "description": "Mengirim tautan reset password sekali pakai ke email alumni. Response selalu sama walau email tidak terdaftar",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth (Mongo)"
+                ],
+                "summary": "Lupa password",
+                "parameters": [
+                    {
+                        "description": "Email alumni",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ForgotPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.PasswordResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.PasswordResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.PasswordResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/password/reset": {
+            "post": {
+                "description": "Mengganti password dengan token dari email reset. Token hanya berlaku sekali dan semua sesi dicabut",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth (Mongo)"
+                ],
+                "summary": "Reset password",
+                "parameters": [
+                    {
+                        "description": "Token dan password baru",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.PasswordResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.PasswordResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.PasswordResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pekerjaan": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil daftar pekerjaan alumni dengan pagination (offset atau cursor), sorting, dan pencarian",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pekerjaan (Mongo)"
+                ],
+                "summary": "Daftar pekerjaan alumni",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Halaman",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah per halaman",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kolom sortir (id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, tanggal_mulai_kerja, status_pekerjaan, created_at)",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Urutan asc/desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kata kunci pencarian",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter terstruktur, mis. filter[tanggal_mulai_kerja][between]=2020-01-01,2023-12-31 atau filter[koordinat][near]=-6.2,106.8,25",
+                        "name": "filter[field][op]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor dari meta.next_cursor (menggantikan page)",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor dari meta.prev_cursor",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Hitung total data pada mode cursor",
+                        "name": "with_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.GetAllPekerjaanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.GetAllPekerjaanResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membuat data pekerjaan baru untuk alumni",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pekerjaan (Mongo)"
+                ],
+                "summary": "Tambah pekerjaan alumni",
+                "parameters": [
+                    {
+                        "description": "Data pekerjaan alumni",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mongo.CreatePekerjaanAlumniRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.CreatePekerjaanAlumniResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.CreatePekerjaanAlumniResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.CreatePekerjaanAlumniResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.CreatePekerjaanAlumniResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pekerjaan/alumni/{alumni_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil seluruh pekerjaan milik alumni tertentu",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pekerjaan (Mongo)"
+                ],
+                "summary": "Daftar pekerjaan per alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Alumni",
+                        "name": "alumni_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.GetPekerjaanAlumniByAlumniIDResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.GetPekerjaanAlumniByAlumniIDResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.GetPekerjaanAlumniByAlumniIDResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pekerjaan/batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menjalankan banyak operasi sekaligus. all_or_nothing (default) menyimpan semua atau tidak sama sekali, best_effort menyimpan operasi yang berhasil saja. Update memakai merge patch; batas jumlah operasi diatur BATCH_MAX_OPERATIONS",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pekerjaan (Mongo)"
+                ],
+                "summary": "Batch create/update/delete pekerjaan",
+                "parameters": [
+                    {
+                        "description": "Mode dan daftar operasi",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mongo.BatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.BatchResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.BatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.BatchResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.BatchResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.BatchResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pekerjaan/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Export pekerjaan beserta NIM dan nama alumni sebagai CSV, XLSX, NDJSON, atau laporan PDF dengan search, sortBy, order, dan filter yang sama seperti GET /pekerjaan",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/x-ndjson",
+                    "application/pdf",
+                    "application/json"
+                ],
+                "tags": [
+                    "Export (Mongo)"
+                ],
+                "summary": "Export pekerjaan alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv (default), xlsx, ndjson, pdf",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Jalankan sebagai job background",
+                        "name": "async",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kolom sortir",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Urutan asc/desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kata kunci pencarian",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter terstruktur, sama dengan GET /pekerjaan",
+                        "name": "filter[field][op]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ExportJobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/pekerjaan/gaji/migrate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menjadwalkan job pekerjaan.salary_migrate: gaji_range teks (\"5-10 juta\") diubah menjadi gaji_min / gaji_max terstruktur; teks yang tidak dikenali dibiarkan dan dicatat di log",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pekerjaan (Mongo)"
+                ],
+                "summary": "Migrasi gaji lama",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.JobResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/pekerjaan/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upsert pekerjaan berdasarkan alumni, nama_perusahaan, posisi_jabatan, dan tanggal_mulai_kerja. Alumni dicari lewat kolom nim atau alumni_id",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Import (Mongo)"
+                ],
+                "summary": "Import pekerjaan alumni dari CSV/XLSX",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "File .csv atau .xlsx (maks 5000 baris)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validasi saja tanpa menyimpan",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ImportReportResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ImportJobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/pekerjaan/lokasi/backfill": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menjadwalkan job pekerjaan.location_backfill: provinsi / kota / koordinat ditebak dari teks lokasi_kerja pekerjaan yang belum punya lokasi terstruktur; teks yang tidak dikenali dibiarkan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pekerjaan (Mongo)"
+                ],
+                "summary": "Backfill lokasi terstruktur",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.JobResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/pekerjaan/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Full-text search pekerjaan pada perusahaan, posisi, bidang industri, lokasi, dan deskripsi dengan ranking relevansi dan highlight",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pekerjaan (Mongo)"
+                ],
+                "summary": "Cari pekerjaan alumni (full-text)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kata kunci pencarian",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Halaman",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah per halaman (maks 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.SearchPekerjaanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.SearchPekerjaanResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.SearchPekerjaanResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pekerjaan/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil detail pekerjaan alumni berdasarkan ID pekerjaan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pekerjaan (Mongo)"
+                ],
+                "summary": "Detail pekerjaan alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Pekerjaan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.GetPekerjaanAlumniByIDResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.GetPekerjaanAlumniByIDResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.GetPekerjaanAlumniByIDResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Memperbarui data pekerjaan alumni berdasarkan ID pekerjaan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pekerjaan (Mongo)"
+                ],
+                "summary": "Perbarui pekerjaan alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Pekerjaan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data pekerjaan alumni yang diperbarui",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdatePekerjaanAlumniRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdatePekerjaanAlumniResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdatePekerjaanAlumniResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdatePekerjaanAlumniResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdatePekerjaanAlumniResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus data pekerjaan alumni berdasarkan ID pekerjaan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pekerjaan (Mongo)"
+                ],
+                "summary": "Hapus pekerjaan alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Pekerjaan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menerapkan JSON Merge Patch (RFC 7396) atau JSON Patch (RFC 6902) ke pekerjaan; null menghapus field opsional",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pekerjaan (Mongo)"
+                ],
+                "summary": "Ubah sebagian pekerjaan alumni",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Pekerjaan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari response GET",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Merge patch (application/merge-patch+json) atau array operasi JSON Patch (application/json-patch+json)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mongo.PekerjaanPatchDocument"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdatePekerjaanAlumniResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdatePekerjaanAlumniResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdatePekerjaanAlumniResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdatePekerjaanAlumniResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdatePekerjaanAlumniResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdatePekerjaanAlumniResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pekerjaan/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Audit log satu pekerjaan alumni, terbaru lebih dulu. Tetap tersedia setelah pekerjaan dihapus",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Audit (Mongo)"
+                ],
+                "summary": "Riwayat perubahan pekerjaan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Pekerjaan",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Halaman",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Jumlah per halaman (maks 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.AuditLogListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil profil user berdasarkan token",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile (Mongo)"
+                ],
+                "summary": "Profil pengguna saat ini",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.GetProfileResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil seluruh role yang tersedia",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles (Mongo)"
+                ],
+                "summary": "Daftar role",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ListRolesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membuat role baru",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles (Mongo)"
+                ],
+                "summary": "Tambah role",
+                "parameters": [
+                    {
+                        "description": "Permintaan pembuatan role",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mongo.CreateRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.CreateRoleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.CreateRoleResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil data role berdasarkan ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles (Mongo)"
+                ],
+                "summary": "Detail role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Role",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.GetRoleByIDResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.GetRoleByIDResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Memperbarui data role berdasarkan ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles (Mongo)"
+                ],
+                "summary": "Perbarui role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Role",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Permintaan pembaruan role",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdateRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdateRoleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdateRoleResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdateRoleResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus role berdasarkan ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles (Mongo)"
+                ],
+                "summary": "Hapus role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Role",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.DeleteRoleResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.DeleteRoleResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menerapkan JSON Merge Patch (RFC 7396) atau JSON Patch (RFC 6902) ke role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles (Mongo)"
+                ],
+                "summary": "Ubah sebagian role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Role",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag dari response GET",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Merge patch (application/merge-patch+json) atau array operasi JSON Patch (application/json-patch+json)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mongo.RolePatchDocument"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdateRoleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdateRoleResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdateRoleResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdateRoleResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdateRoleResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.UpdateRoleResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Audit log satu role, terbaru lebih dulu",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Audit (Mongo)"
+                ],
+                "summary": "Riwayat perubahan role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID Role",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Halaman",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Jumlah per halaman (maks 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.AuditLogListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/files": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Daftar file milik user (terbaru dulu) dengan cursor pagination",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Files (Mongo)"
+                ],
+                "summary": "Daftar file user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID User",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah per halaman (maks 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor dari meta.next_cursor",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor dari meta.prev_cursor",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Sertakan total data",
+                        "name": "with_total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter kategori, mis. filter[category][in]=cv,transcript",
+                        "name": "filter[category]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ListFilesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/upload/certificate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload sertifikat untuk user tertentu sesuai kebijakan kategori certificate (bawaan pdf maksimal 2MB)",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Files (Mongo)"
+                ],
+                "summary": "Upload sertifikat/ijazah",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID User",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File sertifikat (pdf, max 2MB)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.FileUploadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/upload/photo": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload foto untuk user tertentu sesuai kebijakan kategori photo (bawaan jpeg/jpg/png maksimal 1MB)",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Files (Mongo)"
+                ],
+                "summary": "Upload foto profil",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID User",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File foto (jpeg/jpg/png, max 1MB)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.FileUploadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/upload/{category}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload file sesuai kebijakan kategori (tipe MIME, ukuran, jumlah maksimum, single/multiple, retensi)",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Files (Mongo)"
+                ],
+                "summary": "Upload dokumen per kategori",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID User",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kategori dokumen (photo, certificate, transcript, cv, id_card, award_letter, ...)",
+                        "name": "category",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File dokumen",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.FileUploadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/verify/keys": {
+            "get": {
+                "description": "JWKS (Ed25519) untuk memverifikasi pernyataan secara offline, termasuk kunci lama yang masih dipercaya",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Verifikasi (Mongo)"
+                ],
+                "summary": "Kunci publik verifikasi",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/helper.JWKSet"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/verify/{token}": {
+            "get": {
+                "description": "Endpoint publik untuk memeriksa tanda tangan dan masa berlaku pernyataan verifikasi kelulusan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Verifikasi (Mongo)"
+                ],
+                "summary": "Periksa pernyataan verifikasi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pernyataan verifikasi (JWS)",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.VerificationTokenResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/wilayah": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Provinsi pada dataset wilayah beserta kode, alias, dan titik tengahnya; daftar kota ada di GET /wilayah/{kode}",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wilayah (Mongo)"
+                ],
+                "summary": "Daftar provinsi",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.ListRegionResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        },
+        "/wilayah/{kode}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Provinsi beserta kota / kabupaten yang dikenali untuk field kota pekerjaan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wilayah (Mongo)"
+                ],
+                "summary": "Detail provinsi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kode Kemendagri, nama, atau alias provinsi (mis. 32, Jawa Barat, jabar)",
+                        "name": "kode",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mongo.RegionResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Map"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "fiber.Map": {
+            "type": "object",
+            "additionalProperties": true
+        },
+        "helper.AuditChange": {
+            "type": "object",
+            "properties": {
+                "from": {},
+                "to": {}
+            }
+        },
+        "helper.City": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "lat": {
+                    "type": "number"
+                },
+                "lng": {
+                    "type": "number"
+                },
+                "nama": {
+                    "type": "string"
+                }
+            }
+        },
+        "helper.JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string"
+                },
+                "crv": {
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string"
+                },
+                "kty": {
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string"
+                },
+                "x": {
+                    "type": "string"
+                }
+            }
+        },
+        "helper.JWKSet": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/helper.JWK"
+                    }
+                }
+            }
+        },
+        "helper.Province": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "kode": {
+                    "type": "string"
+                },
+                "kota": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/helper.City"
+                    }
+                },
+                "lat": {
+                    "type": "number"
+                },
+                "lng": {
+                    "type": "number"
+                },
+                "nama": {
+                    "type": "string"
+                }
+            }
+        },
+        "helper.VerificationClaims": {
+            "type": "object",
+            "properties": {
+                "angkatan": {
+                    "type": "integer"
+                },
+                "aud": {
+                    "description": "the ` + "`" + `aud` + "`" + ` (Audience) claim. See https://datatracker.ietf.org/doc/html/rfc7519#section-4.1.3",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "exp": {
+                    "description": "the ` + "`" + `exp` + "`" + ` (Expiration Time) claim. See https://datatracker.ietf.org/doc/html/rfc7519#section-4.1.4",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/jwt.NumericDate"
+                        }
+                    ]
+                },
+                "iat": {
+                    "description": "the ` + "`" + `iat` + "`" + ` (Issued At) claim. See https://datatracker.ietf.org/doc/html/rfc7519#section-4.1.6",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/jwt.NumericDate"
+                        }
+                    ]
+                },
+                "iss": {
+                    "description": "the ` + "`" + `iss` + "`" + ` (Issuer) claim. See https://datatracker.ietf.org/doc/html/rfc7519#section-4.1.1",
+                    "type": "string"
+                },
+                "jti": {
+                    "description": "the ` + "`" + `jti` + "`" + ` (JWT ID) claim. See https://datatracker.ietf.org/doc/html/rfc7519#section-4.1.7",
+                    "type": "string"
+                },
+                "jurusan": {
+                    "type": "string"
+                },
+                "nama": {
+                    "type": "string"
+                },
+                "nbf": {
+                    "description": "the ` + "`" + `nbf` + "`" + ` (Not Before) claim. See https://datatracker.ietf.org/doc/html/rfc7519#section-4.1.5",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/jwt.NumericDate"
+                        }
+                    ]
+                },
+                "nim": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "sub": {
+                    "description": "the ` + "`" + `sub` + "`" + ` (Subject) claim. See https://datatracker.ietf.org/doc/html/rfc7519#section-4.1.2",
+                    "type": "string"
+                },
+                "tahun_lulus": {
+                    "type": "integer"
+                }
+            }
+        },
+        "jwt.NumericDate": {
+            "type": "object",
+            "properties": {
+                "time.Time": {
+                    "type": "string"
+                }
+            }
+        },
+        "mongo.APIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "rate_limit": {
+                    "description": "request per menit; 0 = tanpa batas",
+                    "type": "integer"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "mongo.APIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.APIKey"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.AccountDeletionRequest": {
+            "type": "object",
+            "properties": {
+                "alasan": {
+                    "type": "string"
+                },
+                "alumni_id": {
+                    "type": "string"
+                },
+                "catatan": {
+                    "description": "catatan admin saat menyetujui / menolak",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "nama": {
+                    "type": "string"
+                },
+                "nim": {
+                    "type": "string"
+                },
+                "processed_at": {
+                    "type": "string"
+                },
+                "processed_by": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "mongo.AccountDeletionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.AccountDeletionRequest"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.Alumni": {
+            "type": "object",
+            "properties": {
+                "alamat": {
+                    "type": "string"
+                },
+                "angkatan": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "jurusan": {
+                    "type": "string"
+                },
+                "nama": {
+                    "type": "string"
+                },
+                "nim": {
+                    "type": "string"
+                },
+                "no_telepon": {
+                    "type": "string"
+                },
+                "role_id": {
+                    "type": "string"
+                },
+                "tahun_lulus": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "naik setiap update; dikirim sebagai ETag",
+                    "type": "integer"
+                }
+            }
+        },
+        "mongo.AlumniData": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mongo.Alumni"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/mongo.MetaInfo"
+                }
+            }
+        },
+        "mongo.AlumniEmploymentStatus": {
+            "type": "object",
+            "properties": {
+                "angkatan": {
+                    "type": "integer"
+                },
+                "bidang_industri": {
+                    "type": "string"
+                },
+                "employment_count": {
+                    "type": "integer"
+                },
+                "gaji_mata_uang": {
+                    "type": "string"
+                },
+                "gaji_max": {
+                    "type": "integer"
+                },
+                "gaji_min": {
+                    "type": "integer"
+                },
+                "gaji_periode": {
+                    "type": "string"
+                },
+                "gaji_range": {
+                    "description": "teks gaji lama yang belum dimigrasi",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "jurusan": {
+                    "type": "string"
+                },
+                "lebih_dari_1_tahun": {
+                    "type": "integer"
+                },
+                "nama": {
+                    "type": "string"
+                },
+                "nama_perusahaan": {
+                    "type": "string"
+                },
+                "posisi_jabatan": {
+                    "type": "string"
+                },
+                "tanggal_mulai_kerja": {
+                    "type": "string"
+                }
+            }
+        },
+        "mongo.AlumniPatchDocument": {
+            "type": "object",
+            "properties": {
+                "alamat": {
+                    "type": "string"
+                },
+                "angkatan": {
+                    "type": "integer"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "jurusan": {
+                    "type": "string"
+                },
+                "nama": {
+                    "type": "string"
+                },
+                "nim": {
+                    "type": "string"
+                },
+                "no_telepon": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "role_id": {
+                    "type": "string"
+                },
+                "tahun_lulus": {
+                    "type": "integer"
+                }
+            }
+        },
+        "mongo.AlumniSearchResult": {
+            "type": "object",
+            "properties": {
+                "alamat": {
+                    "type": "string"
+                },
+                "angkatan": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "highlights": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "jurusan": {
+                    "type": "string"
+                },
+                "nama": {
+                    "type": "string"
+                },
+                "nim": {
+                    "type": "string"
+                },
+                "no_telepon": {
+                    "type": "string"
+                },
+                "role_id": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "tahun_lulus": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "naik setiap update; dikirim sebagai ETag",
+                    "type": "integer"
+                }
+            }
+        },
+        "mongo.AlumniVerification": {
+            "type": "object",
+            "properties": {
+                "angkatan": {
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "jurusan": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "lulus atau mahasiswa",
+                    "type": "string"
+                },
+                "tahun_lulus": {
+                    "type": "integer"
+                },
+                "token": {
+                    "description": "pernyataan bertanda tangan (JWS EdDSA)",
+                    "type": "string"
+                },
+                "verified": {
+                    "type": "boolean"
+                },
+                "verify_url": {
+                    "description": "halaman publik GET /verify/:token",
+                    "type": "string"
+                }
+            }
+        },
+        "mongo.AuditLog": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor_id": {
+                    "type": "string"
+                },
+                "actor_role": {
+                    "type": "string"
+                },
+                "after": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "before": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "changes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/helper.AuditChange"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "entity_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                }
+            }
+        },
+        "mongo.AuditLogListData": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mongo.AuditLog"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/mongo.MetaInfo"
+                }
+            }
+        },
+        "mongo.AuditLogListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.AuditLogListData"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.BatchOperation": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "if_match": {
+                    "type": "string"
+                },
+                "op": {
+                    "description": "create, update, delete",
+                    "type": "string"
+                }
+            }
+        },
+        "mongo.BatchRequest": {
+            "type": "object",
+            "properties": {
+                "mode": {
+                    "description": "all_or_nothing (default) atau best_effort",
+                    "type": "string"
+                },
+                "operations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mongo.BatchOperation"
+                    }
+                }
+            }
+        },
+        "mongo.BatchResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.BatchSummary"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.BatchResult": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "id": {
+                    "type": "string"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "op": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "mongo.BatchSummary": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "type": "integer"
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mongo.BatchResult"
+                    }
+                },
+                "succeeded": {
+                    "type": "integer"
+                }
+            }
+        },
+        "mongo.CareerGap": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "mongo.CareerOverlap": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "integer"
+                },
+                "pekerjaan_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "mongo.CareerTimeline": {
+            "type": "object",
+            "properties": {
+                "alumni_id": {
+                    "type": "string"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mongo.CareerTimelineEntry"
+                    }
+                },
+                "gaps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mongo.CareerGap"
+                    }
+                },
+                "overlaps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mongo.CareerOverlap"
+                    }
+                },
+                "total_experience_days": {
+                    "type": "integer"
+                },
+                "total_experience_months": {
+                    "type": "integer"
+                }
+            }
+        },
+        "mongo.CareerTimelineEntry": {
+            "type": "object",
+            "properties": {
+                "alumni_id": {
+                    "type": "string"
+                },
+                "bidang_industri": {
+                    "type": "string"
+                },
+                "company_id": {
+                    "description": "data referensi companies; nil bila teks bebas belum dikenal",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deskripsi_pekerjaan": {
+                    "type": "string"
+                },
+                "gaji_mata_uang": {
+                    "type": "string"
+                },
+                "gaji_max": {
+                    "type": "integer"
+                },
+                "gaji_min": {
+                    "type": "integer"
+                },
+                "gaji_periode": {
+                    "description": "bulanan / tahunan",
+                    "type": "string"
+                },
+                "gaji_rahasia": {
+                    "description": "nominal hanya terlihat oleh admin dan alumni pemiliknya",
+                    "type": "boolean"
+                },
+                "gaji_range": {
+                    "description": "teks gaji lama yang belum dimigrasi",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "industry_id": {
+                    "description": "data referensi industries; nil bila teks bebas belum dikenal",
+                    "type": "string"
+                },
+                "kota": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "lokasi_kerja": {
+                    "type": "string"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "nama_perusahaan": {
+                    "type": "string"
+                },
+                "negara": {
+                    "description": "kode ISO dua huruf, mis. ID",
+                    "type": "string"
+                },
+                "ongoing": {
+                    "type": "boolean"
+                },
+                "paruh_waktu": {
+                    "description": "boleh berjalan bersamaan dengan pekerjaan lain",
+                    "type": "boolean"
+                },
+                "posisi_jabatan": {
+                    "type": "string"
+                },
+                "provinsi": {
+                    "type": "string"
+                },
+                "status_pekerjaan": {
+                    "type": "string"
+                },
+                "tanggal_mulai_kerja": {
+                    "type": "string"
+                },
+                "tanggal_selesai_kerja": {
+                    "type": "string"
+                },
+                "tenure_days": {
+                    "type": "integer"
+                },
+                "tenure_months": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "naik setiap update; dikirim sebagai ETag",
+                    "type": "integer"
+                }
+            }
+        },
+        "mongo.CareerTimelineResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.CareerTimeline"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.ChangePasswordData": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "mongo.ChangePasswordRequest": {
+            "type": "object",
+            "properties": {
+                "current_password": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string"
+                }
+            }
+        },
+        "mongo.ChangePasswordResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.ChangePasswordData"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.CheckAlumniResponse": {
+            "type": "object",
+            "properties": {
+                "alumni": {
+                    "$ref": "#/definitions/mongo.Alumni"
+                },
+                "isAlumni": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.CreateAPIKeyData": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "rate_limit": {
+                    "description": "request per menit; 0 = tanpa batas",
+                    "type": "integer"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "mongo.CreateAPIKeyRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rate_limit": {
+                    "type": "integer"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "mongo.CreateAPIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.CreateAPIKeyData"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.CreateAccountDeletionRequest": {
+            "type": "object",
+            "properties": {
+                "alasan": {
+                    "type": "string"
+                }
+            }
+        },
+        "mongo.CreateAlumniRequest": {
+            "type": "object",
+            "required": [
+                "angkatan",
+                "email",
+                "jurusan",
+                "nama",
+                "nim",
+                "password",
+                "role_id",
+                "tahun_lulus"
+            ],
+            "properties": {
+                "alamat": {
+                    "type": "string"
+                },
+                "angkatan": {
+                    "type": "integer"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "jurusan": {
+                    "type": "string"
+                },
+                "nama": {
+                    "type": "string"
+                },
+                "nim": {
+                    "type": "string"
+                },
+                "no_telepon": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "role_id": {
+                    "type": "string"
+                },
+                "tahun_lulus": {
+                    "type": "integer"
+                },
+                "tanggal_lahir": {
+                    "description": "YYYY-MM-DD, dipakai verifikasi kelulusan",
+                    "type": "string"
+                }
+            }
+        },
+        "mongo.CreateAlumniResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.Alumni"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.CreatePekerjaanAlumniRequest": {
+            "type": "object",
+            "required": [
+                "alumni_id",
+                "lokasi_kerja",
+                "posisi_jabatan",
+                "status_pekerjaan",
+                "tanggal_mulai_kerja"
+            ],
+            "properties": {
+                "alumni_id": {
+                    "type": "string"
+                },
+                "bidang_industri": {
+                    "type": "string"
+                },
+                "company_id": {
+                    "type": "string"
+                },
+                "deskripsi_pekerjaan": {
+                    "type": "string"
+                },
+                "gaji_mata_uang": {
+                    "type": "string"
+                },
+                "gaji_max": {
+                    "type": "integer"
+                },
+                "gaji_min": {
+                    "type": "integer"
+                },
+                "gaji_periode": {
+                    "type": "string"
+                },
+                "gaji_rahasia": {
+                    "type": "boolean"
+                },
+                "gaji_range": {
+                    "description": "format lama, mis. \"5-10 juta\"; dipakai bila gaji_min dan gaji_max kosong",
+                    "type": "string"
+                },
+                "industry_id": {
+                    "type": "string"
+                },
+                "kota": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "description": "kosong = titik tengah kota",
+                    "type": "number"
+                },
+                "lokasi_kerja": {
+                    "type": "string"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "nama_perusahaan": {
+                    "type": "string"
+                },
+                "negara": {
+                    "description": "kosong = ID bila provinsi / kota diisi",
+                    "type": "string"
+                },
+                "paruh_waktu": {
+                    "type": "boolean"
+                },
+                "posisi_jabatan": {
+                    "type": "string"
+                },
+                "provinsi": {
+                    "type": "string"
+                },
+                "status_pekerjaan": {
+                    "type": "string",
+                    "enum": [
+                        "aktif",
+                        "selesai",
+                        "resigned"
+                    ]
+                },
+                "tanggal_mulai_kerja": {
+                    "type": "string"
+                },
+                "tanggal_selesai_kerja": {
+                    "type": "string"
+                }
+            }
+        },
+        "mongo.CreatePekerjaanAlumniResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.PekerjaanAlumni"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.CreateRoleRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "mongo.CreateRoleResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.Role"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.DeleteAlumniResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.DeleteRoleResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.DistributionData": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mongo.DistributionItem"
+                    }
+                },
+                "total_jobs": {
+                    "type": "integer"
+                }
+            }
+        },
+        "mongo.DistributionItem": {
+            "type": "object",
+            "properties": {
+                "alumni": {
+                    "type": "integer"
+                },
+                "jobs": {
+                    "type": "integer"
+                },
+                "percentage": {
+                    "type": "number"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "mongo.DistributionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.DistributionData"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.EmploymentRateData": {
+            "type": "object",
+            "properties": {
+                "group_by": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mongo.EmploymentRateItem"
+                    }
+                }
+            }
+        },
+        "mongo.EmploymentRateItem": {
+            "type": "object",
+            "properties": {
+                "employed": {
+                    "type": "integer"
+                },
+                "employment_rate": {
+                    "type": "number"
+                },
+                "ever_employed": {
+                    "type": "integer"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "total_alumni": {
+                    "type": "integer"
+                }
+            }
+        },
+        "mongo.EmploymentRateResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.EmploymentRateData"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.ExportJob": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "download_url": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "resource": {
+                    "type": "string"
+                },
+                "rows": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "mongo.ExportJobResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.ExportJob"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.FileCategory": {
+            "type": "object",
+            "properties": {
+                "allowed_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "label": {
+                    "type": "string"
+                },
+                "max_count": {
+                    "description": "0 = tidak dibatasi",
+                    "type": "integer"
+                },
+                "max_size": {
+                    "description": "dalam byte",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "policy": {
+                    "description": "single | multiple",
+                    "type": "string"
+                },
+                "retention_days": {
+                    "description": "0 = simpan selamanya",
+                    "type": "integer"
+                }
+            }
+        },
+        "mongo.FileCategoryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.FileCategory"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.FileResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "file_path": {
+                    "type": "string"
+                },
+                "file_size": {
+                    "type": "integer"
+                },
+                "file_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "original_name": {
+                    "type": "string"
+                },
+                "uploaded_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "mongo.FileUploadResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.FileResponse"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.ForgotPasswordRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "mongo.GetAllAlumniResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.AlumniData"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.GetAllPekerjaanResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.PekerjaanData"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.GetAlumniByIDResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.Alumni"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.GetAlumniEmploymentStatusResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mongo.AlumniEmploymentStatus"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/mongo.PaginationInfo"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.GetPekerjaanAlumniByAlumniIDResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mongo.PekerjaanAlumni"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.GetPekerjaanAlumniByIDResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.PekerjaanAlumni"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.GetProfileResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.ProfileData"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.GetRoleByIDResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.Role"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.ImportJob": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mongo.ImportRowError"
+                    }
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "processed": {
+                    "type": "integer"
+                },
+                "resource": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total_rows": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "mongo.ImportJobResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.ImportJob"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.ImportReport": {
+            "type": "object",
+            "properties": {
+                "invalid_rows": {
+                    "type": "integer"
+                },
+                "resource": {
+                    "type": "string"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mongo.ImportRowResult"
+                    }
+                },
+                "to_create": {
+                    "type": "integer"
+                },
+                "to_update": {
+                    "type": "integer"
+                },
+                "total_rows": {
+                    "type": "integer"
+                },
+                "valid_rows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "mongo.ImportReportResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.ImportReport"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.ImportRowError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "mongo.ImportRowResult": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mongo.ImportRowError"
+                    }
+                },
+                "key": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "mongo.Job": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "locked_by": {
+                    "type": "string"
+                },
+                "locked_until": {
+                    "type": "string"
+                },
+                "max_attempts": {
+                    "type": "integer"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "result": {
+                    "description": "Result -\u003e progress / hasil yang disimpan handler, mis. jumlah baris import atau export",
+                    "type": "object"
+                },
+                "run_at": {
+                    "type": "string"
+                },
+                "schedule": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "unique_key": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "mongo.JobListData": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mongo.Job"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/mongo.MetaInfo"
+                }
+            }
+        },
+        "mongo.JobListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.JobListData"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.JobResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.Job"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.ListAPIKeysResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mongo.APIKey"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.ListAccountDeletionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mongo.AccountDeletionRequest"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.ListFileCategoriesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mongo.FileCategory"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.ListFilesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mongo.FileResponse"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/mongo.MetaInfo"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.ListReferenceDataResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mongo.ReferenceData"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.ListRegionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/helper.Province"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.ListRolesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mongo.Role"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.LoginData": {
+            "type": "object",
+            "properties": {
+                "mfa_enrollment_required": {
+                    "type": "boolean"
+                },
+                "mfa_required": {
+                    "description": "Diisi bila akun wajib 2FA: token sementara untuk POST /login/mfa (atau enrollment\nbila MFAEnrollmentRequired); Token kosong sampai kode diverifikasi",
+                    "type": "boolean"
+                },
+                "mfa_token": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/mongo.User"
+                }
+            }
+        },
+        "mongo.LoginRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "mongo.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.LoginData"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.MFACodeRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "mongo.MFADisableRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "password"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "mongo.MFAEnrollData": {
+            "type": "object",
+            "properties": {
+                "otpauth_uri": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                }
+            }
+        },
+        "mongo.MFAEnrollResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.MFAEnrollData"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.MFALoginRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "mfa_token"
+            ],
+            "properties": {
+                "code": {
+                    "description": "kode 6 digit atau kode pemulihan",
+                    "type": "string"
+                },
+                "mfa_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "mongo.MFARecoveryCodesData": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "mongo.MFARecoveryCodesResponse": {
             "type": "object",
-            "additionalProperties": true
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.MFARecoveryCodesData"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
         },
-        "mongo.Alumni": {
+        "mongo.MFAResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.MePatchDocument": {
+            "type": "object",
+            "properties": {
+                "alamat": {
+                    "type": "string"
+                },
+                "no_telepon": {
+                    "type": "string"
+                }
+            }
+        },
+        "mongo.MeProfile": {
             "type": "object",
             "properties": {
                 "alamat": {
@@ -1063,6 +7770,9 @@ const docTemplate = `{
                 "created_at": {
                     "type": "string"
                 },
+                "deletion_request": {
+                    "$ref": "#/definitions/mongo.AccountDeletionRequest"
+                },
                 "email": {
                     "type": "string"
                 },
@@ -1089,16 +7799,188 @@ const docTemplate = `{
                 },
                 "updated_at": {
                     "type": "string"
+                },
+                "version": {
+                    "description": "naik setiap update; dikirim sebagai ETag",
+                    "type": "integer"
+                }
+            }
+        },
+        "mongo.MeProfileResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.MeProfile"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.MetaInfo": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "order": {
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "pages": {
+                    "type": "integer"
+                },
+                "prev_cursor": {
+                    "type": "string"
+                },
+                "search": {
+                    "type": "string"
+                },
+                "sortBy": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "mongo.PaginationInfo": {
+            "type": "object",
+            "properties": {
+                "current_page": {
+                    "type": "integer"
+                },
+                "per_page": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                },
+                "total_records": {
+                    "type": "integer"
+                }
+            }
+        },
+        "mongo.PasswordResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.PekerjaanAlumni": {
+            "type": "object",
+            "properties": {
+                "alumni_id": {
+                    "type": "string"
+                },
+                "bidang_industri": {
+                    "type": "string"
+                },
+                "company_id": {
+                    "description": "data referensi companies; nil bila teks bebas belum dikenal",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deskripsi_pekerjaan": {
+                    "type": "string"
+                },
+                "gaji_mata_uang": {
+                    "type": "string"
+                },
+                "gaji_max": {
+                    "type": "integer"
+                },
+                "gaji_min": {
+                    "type": "integer"
+                },
+                "gaji_periode": {
+                    "description": "bulanan / tahunan",
+                    "type": "string"
+                },
+                "gaji_rahasia": {
+                    "description": "nominal hanya terlihat oleh admin dan alumni pemiliknya",
+                    "type": "boolean"
+                },
+                "gaji_range": {
+                    "description": "teks gaji lama yang belum dimigrasi",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "industry_id": {
+                    "description": "data referensi industries; nil bila teks bebas belum dikenal",
+                    "type": "string"
+                },
+                "kota": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "lokasi_kerja": {
+                    "type": "string"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "nama_perusahaan": {
+                    "type": "string"
+                },
+                "negara": {
+                    "description": "kode ISO dua huruf, mis. ID",
+                    "type": "string"
+                },
+                "paruh_waktu": {
+                    "description": "boleh berjalan bersamaan dengan pekerjaan lain",
+                    "type": "boolean"
+                },
+                "posisi_jabatan": {
+                    "type": "string"
+                },
+                "provinsi": {
+                    "type": "string"
+                },
+                "status_pekerjaan": {
+                    "type": "string"
+                },
+                "tanggal_mulai_kerja": {
+                    "type": "string"
+                },
+                "tanggal_selesai_kerja": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "naik setiap update; dikirim sebagai ETag",
+                    "type": "integer"
                 }
             }
         },
-        "mongo.AlumniData": {
+        "mongo.PekerjaanData": {
             "type": "object",
             "properties": {
                 "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mongo.Alumni"
+                        "$ref": "#/definitions/mongo.PekerjaanAlumni"
                     }
                 },
                 "meta": {
@@ -1106,93 +7988,73 @@ const docTemplate = `{
                 }
             }
         },
-        "mongo.CheckAlumniResponse": {
+        "mongo.PekerjaanPatchDocument": {
             "type": "object",
             "properties": {
-                "alumni": {
-                    "$ref": "#/definitions/mongo.Alumni"
-                },
-                "isAlumni": {
-                    "type": "boolean"
+                "bidang_industri": {
+                    "type": "string"
                 },
-                "message": {
+                "deskripsi_pekerjaan": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "mongo.CreateAlumniRequest": {
-            "type": "object",
-            "required": [
-                "angkatan",
-                "email",
-                "jurusan",
-                "nama",
-                "nim",
-                "password",
-                "role_id",
-                "tahun_lulus"
-            ],
-            "properties": {
-                "alamat": {
+                "gaji_mata_uang": {
                     "type": "string"
                 },
-                "angkatan": {
+                "gaji_max": {
                     "type": "integer"
                 },
-                "email": {
+                "gaji_min": {
+                    "type": "integer"
+                },
+                "gaji_periode": {
                     "type": "string"
                 },
-                "jurusan": {
+                "gaji_rahasia": {
+                    "type": "boolean"
+                },
+                "gaji_range": {
                     "type": "string"
                 },
-                "nama": {
+                "kota": {
                     "type": "string"
                 },
-                "nim": {
+                "latitude": {
+                    "type": "number"
+                },
+                "lokasi_kerja": {
                     "type": "string"
                 },
-                "no_telepon": {
+                "longitude": {
+                    "type": "number"
+                },
+                "nama_perusahaan": {
                     "type": "string"
                 },
-                "password": {
+                "negara": {
                     "type": "string"
                 },
-                "role_id": {
+                "paruh_waktu": {
+                    "type": "boolean"
+                },
+                "posisi_jabatan": {
                     "type": "string"
                 },
-                "tahun_lulus": {
-                    "type": "integer"
-                }
-            }
-        },
-        "mongo.CreateAlumniResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "$ref": "#/definitions/mongo.Alumni"
+                "provinsi": {
+                    "type": "string"
                 },
-                "message": {
+                "status_pekerjaan": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
+                "tanggal_mulai_kerja": {
+                    "type": "string"
+                },
+                "tanggal_selesai_kerja": {
+                    "type": "string"
                 }
             }
         },
-        "mongo.CreatePekerjaanAlumniRequest": {
+        "mongo.PekerjaanSearchResult": {
             "type": "object",
-            "required": [
-                "alumni_id",
-                "bidang_industri",
-                "lokasi_kerja",
-                "nama_perusahaan",
-                "posisi_jabatan",
-                "status_pekerjaan",
-                "tanggal_mulai_kerja"
-            ],
             "properties": {
                 "alumni_id": {
                     "type": "string"
@@ -1200,67 +8062,130 @@ const docTemplate = `{
                 "bidang_industri": {
                     "type": "string"
                 },
+                "company_id": {
+                    "description": "data referensi companies; nil bila teks bebas belum dikenal",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
                 "deskripsi_pekerjaan": {
                     "type": "string"
                 },
+                "gaji_mata_uang": {
+                    "type": "string"
+                },
+                "gaji_max": {
+                    "type": "integer"
+                },
+                "gaji_min": {
+                    "type": "integer"
+                },
+                "gaji_periode": {
+                    "description": "bulanan / tahunan",
+                    "type": "string"
+                },
+                "gaji_rahasia": {
+                    "description": "nominal hanya terlihat oleh admin dan alumni pemiliknya",
+                    "type": "boolean"
+                },
                 "gaji_range": {
+                    "description": "teks gaji lama yang belum dimigrasi",
                     "type": "string"
                 },
+                "highlights": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "industry_id": {
+                    "description": "data referensi industries; nil bila teks bebas belum dikenal",
+                    "type": "string"
+                },
+                "kota": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
                 "lokasi_kerja": {
                     "type": "string"
                 },
+                "longitude": {
+                    "type": "number"
+                },
                 "nama_perusahaan": {
                     "type": "string"
                 },
+                "negara": {
+                    "description": "kode ISO dua huruf, mis. ID",
+                    "type": "string"
+                },
+                "paruh_waktu": {
+                    "description": "boleh berjalan bersamaan dengan pekerjaan lain",
+                    "type": "boolean"
+                },
                 "posisi_jabatan": {
                     "type": "string"
                 },
+                "provinsi": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                },
                 "status_pekerjaan": {
-                    "type": "string",
-                    "enum": [
-                        "aktif",
-                        "selesai",
-                        "resigned"
-                    ]
+                    "type": "string"
                 },
                 "tanggal_mulai_kerja": {
                     "type": "string"
                 },
                 "tanggal_selesai_kerja": {
                     "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "naik setiap update; dikirim sebagai ETag",
+                    "type": "integer"
                 }
             }
         },
-        "mongo.CreatePekerjaanAlumniResponse": {
+        "mongo.ProcessAccountDeletionRequest": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/mongo.PekerjaanAlumni"
-                },
-                "message": {
+                "catatan": {
                     "type": "string"
-                },
-                "success": {
-                    "type": "boolean"
                 }
             }
         },
-        "mongo.CreateRoleRequest": {
+        "mongo.ProfileData": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "name": {
+                "role": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "mongo.CreateRoleResponse": {
+        "mongo.ReferenceAutocompleteResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/mongo.Role"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mongo.ReferenceMatch"
+                    }
                 },
                 "message": {
                     "type": "string"
@@ -1270,20 +8195,52 @@ const docTemplate = `{
                 }
             }
         },
-        "mongo.DeleteAlumniResponse": {
+        "mongo.ReferenceData": {
             "type": "object",
             "properties": {
-                "message": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
+                "id": {
+                    "type": "string"
+                },
+                "nama": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "mongo.DeleteRoleResponse": {
+        "mongo.ReferenceDataRequest": {
+            "type": "object",
+            "required": [
+                "nama"
+            ],
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "nama": {
+                    "type": "string"
+                }
+            }
+        },
+        "mongo.ReferenceDataResponse": {
             "type": "object",
             "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.ReferenceData"
+                },
                 "message": {
                     "type": "string"
                 },
@@ -1292,37 +8249,54 @@ const docTemplate = `{
                 }
             }
         },
-        "mongo.FileResponse": {
+        "mongo.ReferenceMatch": {
             "type": "object",
             "properties": {
-                "category": {
-                    "type": "string"
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "file_name": {
+                "created_at": {
                     "type": "string"
                 },
-                "file_path": {
+                "id": {
                     "type": "string"
                 },
-                "file_size": {
-                    "type": "integer"
-                },
-                "file_type": {
+                "matched": {
                     "type": "string"
                 },
-                "id": {
+                "nama": {
                     "type": "string"
                 },
-                "original_name": {
+                "score": {
+                    "type": "number"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "mongo.FileUploadResponse": {
+        "mongo.ReferenceMergeRequest": {
+            "type": "object",
+            "required": [
+                "source_ids"
+            ],
+            "properties": {
+                "source_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "mongo.ReferenceMergeResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/mongo.FileResponse"
+                    "$ref": "#/definitions/mongo.ReferenceMergeResult"
                 },
                 "message": {
                     "type": "string"
@@ -1332,11 +8306,25 @@ const docTemplate = `{
                 }
             }
         },
-        "mongo.GetAllAlumniResponse": {
+        "mongo.ReferenceMergeResult": {
+            "type": "object",
+            "properties": {
+                "merged": {
+                    "type": "integer"
+                },
+                "reference": {
+                    "$ref": "#/definitions/mongo.ReferenceData"
+                },
+                "relinked": {
+                    "type": "integer"
+                }
+            }
+        },
+        "mongo.RegionResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/mongo.AlumniData"
+                    "$ref": "#/definitions/helper.Province"
                 },
                 "message": {
                     "type": "string"
@@ -1346,42 +8334,53 @@ const docTemplate = `{
                 }
             }
         },
-        "mongo.GetAllPekerjaanResponse": {
+        "mongo.ResetPasswordRequest": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/mongo.PekerjaanData"
+                "password": {
+                    "type": "string"
                 },
-                "message": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "mongo.RetentionData": {
+            "type": "object",
+            "properties": {
+                "group_by": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mongo.RetentionItem"
+                    }
                 }
             }
         },
-        "mongo.GetAlumniByIDResponse": {
+        "mongo.RetentionItem": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/mongo.Alumni"
+                "eligible_jobs": {
+                    "type": "integer"
                 },
-                "message": {
+                "group": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
+                "retained_jobs": {
+                    "type": "integer"
+                },
+                "retention_rate": {
+                    "type": "number"
                 }
             }
         },
-        "mongo.GetPekerjaanAlumniByAlumniIDResponse": {
+        "mongo.RetentionResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/mongo.PekerjaanAlumni"
-                    }
+                    "$ref": "#/definitions/mongo.RetentionData"
                 },
                 "message": {
                     "type": "string"
@@ -1391,96 +8390,102 @@ const docTemplate = `{
                 }
             }
         },
-        "mongo.GetPekerjaanAlumniByIDResponse": {
+        "mongo.Role": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/mongo.PekerjaanAlumni"
+                "id": {
+                    "type": "string"
                 },
-                "message": {
+                "name": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
+                "version": {
+                    "description": "naik setiap update; dikirim sebagai ETag",
+                    "type": "integer"
                 }
             }
         },
-        "mongo.GetProfileResponse": {
+        "mongo.RolePatchDocument": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/mongo.ProfileData"
-                },
-                "message": {
+                "name": {
                     "type": "string"
-                },
-                "success": {
-                    "type": "boolean"
                 }
             }
         },
-        "mongo.GetRoleByIDResponse": {
+        "mongo.SalaryBucket": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/mongo.Role"
+                "count": {
+                    "type": "integer"
                 },
-                "message": {
+                "label": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
+                "max": {
+                    "type": "number"
+                },
+                "min": {
+                    "type": "number"
+                },
+                "percentage": {
+                    "type": "number"
                 }
             }
         },
-        "mongo.ListRolesResponse": {
+        "mongo.SalaryHistogram": {
             "type": "object",
             "properties": {
-                "data": {
+                "buckets": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mongo.Role"
+                        "$ref": "#/definitions/mongo.SalaryBucket"
                     }
                 },
-                "message": {
+                "total": {
+                    "type": "integer"
+                },
+                "unit": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
+                "unparsed": {
+                    "type": "integer"
                 }
             }
         },
-        "mongo.LoginData": {
+        "mongo.SalaryHistogramResponse": {
             "type": "object",
             "properties": {
-                "token": {
+                "data": {
+                    "$ref": "#/definitions/mongo.SalaryHistogram"
+                },
+                "message": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/mongo.User"
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "mongo.LoginRequest": {
+        "mongo.SearchAlumniData": {
             "type": "object",
-            "required": [
-                "password",
-                "username"
-            ],
             "properties": {
-                "password": {
-                    "type": "string"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mongo.AlumniSearchResult"
+                    }
                 },
-                "username": {
-                    "type": "string"
+                "meta": {
+                    "$ref": "#/definitions/mongo.MetaInfo"
                 }
             }
         },
-        "mongo.LoginResponse": {
+        "mongo.SearchAlumniResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/mongo.LoginData"
+                    "$ref": "#/definitions/mongo.SearchAlumniData"
                 },
                 "message": {
                     "type": "string"
@@ -1490,112 +8495,87 @@ const docTemplate = `{
                 }
             }
         },
-        "mongo.MetaInfo": {
+        "mongo.SearchPekerjaanData": {
             "type": "object",
             "properties": {
-                "limit": {
-                    "type": "integer"
-                },
-                "order": {
-                    "type": "string"
-                },
-                "page": {
-                    "type": "integer"
-                },
-                "pages": {
-                    "type": "integer"
-                },
-                "search": {
-                    "type": "string"
-                },
-                "sortBy": {
-                    "type": "string"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mongo.PekerjaanSearchResult"
+                    }
                 },
-                "total": {
-                    "type": "integer"
+                "meta": {
+                    "$ref": "#/definitions/mongo.MetaInfo"
                 }
             }
         },
-        "mongo.PekerjaanAlumni": {
+        "mongo.SearchPekerjaanResponse": {
             "type": "object",
             "properties": {
-                "alumni_id": {
-                    "type": "string"
-                },
-                "bidang_industri": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "deskripsi_pekerjaan": {
-                    "type": "string"
-                },
-                "gaji_range": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "lokasi_kerja": {
-                    "type": "string"
-                },
-                "nama_perusahaan": {
-                    "type": "string"
-                },
-                "posisi_jabatan": {
-                    "type": "string"
-                },
-                "status_pekerjaan": {
-                    "type": "string"
-                },
-                "tanggal_mulai_kerja": {
-                    "type": "string"
+                "data": {
+                    "$ref": "#/definitions/mongo.SearchPekerjaanData"
                 },
-                "tanggal_selesai_kerja": {
+                "message": {
                     "type": "string"
                 },
-                "updated_at": {
-                    "type": "string"
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "mongo.PekerjaanData": {
+        "mongo.TimeToFirstJobData": {
             "type": "object",
             "properties": {
+                "group_by": {
+                    "type": "string"
+                },
                 "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mongo.PekerjaanAlumni"
+                        "$ref": "#/definitions/mongo.TimeToFirstJobItem"
                     }
-                },
-                "meta": {
-                    "$ref": "#/definitions/mongo.MetaInfo"
                 }
             }
         },
-        "mongo.ProfileData": {
+        "mongo.TimeToFirstJobItem": {
             "type": "object",
             "properties": {
-                "role": {
-                    "type": "string"
+                "alumni_with_job": {
+                    "type": "integer"
                 },
-                "user_id": {
-                    "type": "string"
+                "average_months": {
+                    "type": "number"
                 },
-                "username": {
+                "group": {
                     "type": "string"
+                },
+                "median_months": {
+                    "type": "number"
                 }
             }
         },
-        "mongo.Role": {
+        "mongo.TimeToFirstJobResponse": {
             "type": "object",
             "properties": {
-                "id": {
+                "data": {
+                    "$ref": "#/definitions/mongo.TimeToFirstJobData"
+                },
+                "message": {
                     "type": "string"
                 },
-                "name": {
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.UnlockAlumniResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
                     "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
@@ -1631,6 +8611,9 @@ const docTemplate = `{
                 },
                 "tahun_lulus": {
                     "type": "integer"
+                },
+                "tanggal_lahir": {
+                    "type": "string"
                 }
             }
         },
@@ -1651,9 +8634,7 @@ const docTemplate = `{
         "mongo.UpdatePekerjaanAlumniRequest": {
             "type": "object",
             "required": [
-                "bidang_industri",
                 "lokasi_kerja",
-                "nama_perusahaan",
                 "posisi_jabatan",
                 "status_pekerjaan",
                 "tanggal_mulai_kerja"
@@ -1662,21 +8643,63 @@ const docTemplate = `{
                 "bidang_industri": {
                     "type": "string"
                 },
+                "company_id": {
+                    "type": "string"
+                },
                 "deskripsi_pekerjaan": {
                     "type": "string"
                 },
+                "gaji_mata_uang": {
+                    "type": "string"
+                },
+                "gaji_max": {
+                    "type": "integer"
+                },
+                "gaji_min": {
+                    "type": "integer"
+                },
+                "gaji_periode": {
+                    "type": "string"
+                },
+                "gaji_rahasia": {
+                    "type": "boolean"
+                },
                 "gaji_range": {
+                    "description": "format lama, mis. \"5-10 juta\"; dipakai bila gaji_min dan gaji_max kosong",
+                    "type": "string"
+                },
+                "industry_id": {
+                    "type": "string"
+                },
+                "kota": {
                     "type": "string"
                 },
+                "latitude": {
+                    "description": "kosong = titik tengah kota",
+                    "type": "number"
+                },
                 "lokasi_kerja": {
                     "type": "string"
                 },
+                "longitude": {
+                    "type": "number"
+                },
                 "nama_perusahaan": {
                     "type": "string"
                 },
+                "negara": {
+                    "description": "kosong = ID bila provinsi / kota diisi",
+                    "type": "string"
+                },
+                "paruh_waktu": {
+                    "type": "boolean"
+                },
                 "posisi_jabatan": {
                     "type": "string"
                 },
+                "provinsi": {
+                    "type": "string"
+                },
                 "status_pekerjaan": {
                     "type": "string",
                     "enum": [
@@ -1729,6 +8752,41 @@ const docTemplate = `{
                 }
             }
         },
+        "mongo.UpsertFileCategoryRequest": {
+            "type": "object",
+            "required": [
+                "allowed_types",
+                "max_size",
+                "policy"
+            ],
+            "properties": {
+                "allowed_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "label": {
+                    "type": "string"
+                },
+                "max_count": {
+                    "type": "integer"
+                },
+                "max_size": {
+                    "type": "integer"
+                },
+                "policy": {
+                    "type": "string",
+                    "enum": [
+                        "single",
+                        "multiple"
+                    ]
+                },
+                "retention_days": {
+                    "type": "integer"
+                }
+            }
+        },
         "mongo.User": {
             "type": "object",
             "properties": {
@@ -1748,9 +8806,60 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "mongo.VerificationTokenResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/helper.VerificationClaims"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "valid": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "mongo.VerifyAlumniRequest": {
+            "type": "object",
+            "properties": {
+                "nama": {
+                    "type": "string"
+                },
+                "nim": {
+                    "type": "string"
+                },
+                "tanggal_lahir": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                }
+            }
+        },
+        "mongo.VerifyAlumniResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/mongo.AlumniVerification"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
         }
     },
     "securityDefinitions": {
+        "APIKeyAuth": {
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
         "BearerAuth": {
             "type": "apiKey",
             "name": "Authorization",
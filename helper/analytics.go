@@ -0,0 +1,44 @@
+package helper
+
+import (
+	"fmt"
+	"math"
+)
+
+// GraduationMonth -> bulan acuan kelulusan (Juli) karena data alumni hanya menyimpan tahun_lulus.
+// Dipakai untuk menghitung jarak kelulusan ke pekerjaan pertama dalam bulan.
+const GraduationMonth = 7
+
+// RetentionDays -> batas minimal lama bekerja agar sebuah pekerjaan dihitung bertahan (> 1 tahun)
+const RetentionDays = 365
+
+// SalaryBoundaries -> batas bawah tiap kelompok histogram gaji dalam juta rupiah.
+// Kelompok terakhir tidak punya batas atas.
+var SalaryBoundaries = []float64{0, 3, 5, 8, 12, 20}
+
+// Percentage -> part/total dalam persen dengan 2 angka desimal, 0 bila total 0
+func Percentage(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return Round(float64(part)*100/float64(total), 2)
+}
+
+// Round membulatkan v ke sejumlah digit desimal
+func Round(v float64, digits int) float64 {
+	p := math.Pow(10, float64(digits))
+	return math.Round(v*p) / p
+}
+
+// SalaryBucketRange -> batas atas (nil untuk kelompok terakhir) dan label kelompok gaji ke-i
+func SalaryBucketRange(i int) (max *float64, label string) {
+	min := SalaryBoundaries[i]
+	if i == len(SalaryBoundaries)-1 {
+		return nil, fmt.Sprintf(">= %g juta", min)
+	}
+	upper := SalaryBoundaries[i+1]
+	if min == 0 {
+		return &upper, fmt.Sprintf("< %g juta", upper)
+	}
+	return &upper, fmt.Sprintf("%g-%g juta", min, upper)
+}
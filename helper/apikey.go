@@ -0,0 +1,82 @@
+package helper
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Scope API key untuk client mesin (partner)
+const (
+	APIKeyScopeAlumniCheck  = "alumni:check"  // cek status alumni berdasarkan NIM
+	APIKeyScopeAlumniRead   = "alumni:read"   // baca data alumni
+	APIKeyScopeAlumniVerify = "alumni:verify" // verifikasi kelulusan dan terbitkan pernyataan bertanda tangan
+)
+
+// APIKeyScopes -> semua scope yang bisa diberikan ke API key
+var APIKeyScopes = []string{APIKeyScopeAlumniCheck, APIKeyScopeAlumniRead, APIKeyScopeAlumniVerify}
+
+// APIKeyHeader -> header tempat client mengirim API key
+const APIKeyHeader = "X-API-Key"
+
+const apiKeyPrefixLen = 8
+
+// GenerateAPIKey -> key baru berformat ak_<prefix>_<secret>. prefix disimpan apa adanya untuk
+// identifikasi di daftar key; hanya hash SHA-256 key lengkap yang disimpan.
+func GenerateAPIKey() (key, prefix, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", "", err
+	}
+	p := make([]byte, apiKeyPrefixLen/2)
+	if _, err := rand.Read(p); err != nil {
+		return "", "", "", err
+	}
+	prefix = hex.EncodeToString(p)
+	key = "ak_" + prefix + "_" + base64.RawURLEncoding.EncodeToString(b)
+	return key, prefix, HashAPIKey(key), nil
+}
+
+// HashAPIKey -> SHA-256 hex dari API key
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(key)))
+	return hex.EncodeToString(sum[:])
+}
+
+// NormalizeAPIKeyScopes -> scope unik yang valid; error untuk scope yang tidak dikenal atau kosong
+func NormalizeAPIKeyScopes(scopes []string) ([]string, error) {
+	seen := map[string]bool{}
+	var out []string
+	for _, s := range scopes {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if s == "" || seen[s] {
+			continue
+		}
+		if !containsString(APIKeyScopes, s) {
+			return nil, fmt.Errorf("scope %q tidak dikenal (tersedia: %s)", s, strings.Join(APIKeyScopes, ", "))
+		}
+		seen[s] = true
+		out = append(out, s)
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("minimal satu scope diperlukan (tersedia: %s)", strings.Join(APIKeyScopes, ", "))
+	}
+	return out, nil
+}
+
+// HasScope -> true bila scopes memuat scope
+func HasScope(scopes []string, scope string) bool {
+	return containsString(scopes, scope)
+}
+
+// LegacyAPIKeyEnabled -> endpoint cek alumni lama (key di URL, API_KEY tunggal) masih aktif
+// selama LEGACY_API_KEY_ENABLED bukan "false"
+func LegacyAPIKeyEnabled() bool {
+	enabled, err := strconv.ParseBool(os.Getenv("LEGACY_API_KEY_ENABLED"))
+	return err != nil || enabled
+}
@@ -0,0 +1,138 @@
+package helper
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Aksi audit log
+const (
+	AuditActionCreate      = "create"
+	AuditActionUpdate      = "update"
+	AuditActionDelete      = "delete"
+	AuditActionSoftDelete  = "soft_delete"
+	AuditActionRestore     = "restore"
+	AuditActionUpload      = "upload"
+	AuditActionImport      = "import"
+	AuditActionUnlock      = "unlock"
+	AuditActionRevoke      = "revoke"
+	AuditActionLogin       = "login"
+	AuditActionLoginFailed = "login_failed"
+	AuditActionMerge       = "merge"
+)
+
+// Jenis entity audit log
+const (
+	AuditEntityAlumni          = "alumni"
+	AuditEntityPekerjaan       = "pekerjaan"
+	AuditEntityRole            = "role"
+	AuditEntityFile            = "file"
+	AuditEntityFileCategory    = "file_category"
+	AuditEntityAPIKey          = "api_key"
+	AuditEntityCompany         = "company"
+	AuditEntityIndustry        = "industry"
+	AuditEntityAccountDeletion = "account_deletion"
+)
+
+// AuditChange -> nilai satu field sebelum dan sesudah perubahan
+type AuditChange struct {
+	From any `json:"from" bson:"from"`
+	To   any `json:"to" bson:"to"`
+}
+
+// UnmarshalBSON -> dokumen bersarang di From/To di-decode sebagai bson.M (bukan bson.D) agar
+// bentuk JSON-nya sama dengan snapshot aslinya
+func (c *AuditChange) UnmarshalBSON(data []byte) error {
+	var doc bson.M
+	if err := bson.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	c.From, c.To = doc["from"], doc["to"]
+	return nil
+}
+
+// auditRedactedFields -> field yang tidak pernah disimpan di audit log walau ikut ter-serialize
+var auditRedactedFields = []string{"password", "token", "key", "key_hash", "secret", "recovery_codes"}
+
+// auditIgnoredFields -> field yang selalu berubah dan tidak berarti apa-apa di diff
+var auditIgnoredFields = []string{"updated_at"}
+
+// AuditSnapshot -> representasi JSON sebuah entity (field json:"-" otomatis tidak ikut) untuk
+// kolom before/after audit log. nil bila v nil atau tidak bisa di-serialize.
+func AuditSnapshot(v any) map[string]any {
+	if v == nil {
+		return nil
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var snapshot map[string]any
+	if err := json.Unmarshal(raw, &snapshot); err != nil {
+		return nil
+	}
+	for field := range snapshot {
+		if containsString(auditRedactedFields, strings.ToLower(field)) {
+			delete(snapshot, field)
+		}
+	}
+	return snapshot
+}
+
+// AuditDiff -> field yang berbeda antara dua snapshot. Field yang hanya ada di salah satu sisi
+// juga dicatat (nilai sisi lain nil).
+func AuditDiff(before, after map[string]any) map[string]AuditChange {
+	changes := map[string]AuditChange{}
+	for field, from := range before {
+		if containsString(auditIgnoredFields, field) {
+			continue
+		}
+		if to, ok := after[field]; !ok || !reflect.DeepEqual(from, to) {
+			changes[field] = AuditChange{From: from, To: after[field]}
+		}
+	}
+	for field, to := range after {
+		if containsString(auditIgnoredFields, field) {
+			continue
+		}
+		if _, ok := before[field]; !ok {
+			changes[field] = AuditChange{From: nil, To: to}
+		}
+	}
+	return changes
+}
+
+// AuditLogFilter -> filter query audit log admin
+type AuditLogFilter struct {
+	ActorID    string
+	ActorRole  string
+	Action     string
+	EntityType string
+	EntityID   string
+	RequestID  string
+	From       *time.Time
+	To         *time.Time
+}
+
+// ParseAuditTime -> batas waktu filter audit log, RFC3339 atau tanggal YYYY-MM-DD. Tanggal
+// pada batas akhir (endOfDay) mencakup seluruh hari itu.
+func ParseAuditTime(value string, endOfDay bool) (*time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return nil, err
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+	return &t, nil
+}
@@ -0,0 +1,6 @@
+package helper
+
+// BatchMaxOperations -> batas jumlah operasi per request batch (BATCH_MAX_OPERATIONS, default 100)
+func BatchMaxOperations() int {
+	return envPositiveInt("BATCH_MAX_OPERATIONS", 100)
+}
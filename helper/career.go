@@ -0,0 +1,226 @@
+package helper
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// CareerRules -> aturan riwayat pekerjaan satu alumni yang dicek saat create/update.
+// Pekerjaan paruh waktu tidak ikut diperiksa sehingga boleh berjalan bersamaan.
+type CareerRules struct {
+	RejectOverlap        bool // tolak periode kerja yang tumpang tindih
+	SingleActive         bool // maksimal satu pekerjaan aktif (penuh waktu)
+	OverlapToleranceDays int  // tumpang tindih sampai n hari (masa serah terima) masih diterima
+}
+
+// CareerRulesFromEnv -> CAREER_REJECT_OVERLAP dan CAREER_SINGLE_ACTIVE (default true),
+// CAREER_OVERLAP_TOLERANCE_DAYS (default 0)
+func CareerRulesFromEnv() CareerRules {
+	rules := CareerRules{
+		RejectOverlap: envBool("CAREER_REJECT_OVERLAP", true),
+		SingleActive:  envBool("CAREER_SINGLE_ACTIVE", true),
+	}
+	if n, err := strconv.Atoi(os.Getenv("CAREER_OVERLAP_TOLERANCE_DAYS")); err == nil && n > 0 {
+		rules.OverlapToleranceDays = n
+	}
+	return rules
+}
+
+func envBool(name string, def bool) bool {
+	if b, err := strconv.ParseBool(os.Getenv(name)); err == nil {
+		return b
+	}
+	return def
+}
+
+// CareerPeriod -> satu pekerjaan dalam riwayat karier. End nil berarti masih berjalan.
+type CareerPeriod struct {
+	ID         string
+	Label      string // mis. "Backend Engineer di PT Maju", dipakai di pesan error
+	Start      time.Time
+	End        *time.Time
+	Aktif      bool
+	ParuhWaktu bool
+}
+
+// Check -> error berisi pesan untuk client bila candidate melanggar aturan terhadap pekerjaan
+// lain milik alumni yang sama. others boleh memuat candidate versi lama (ID sama dilewati).
+func (r CareerRules) Check(candidate CareerPeriod, others []CareerPeriod) error {
+	if candidate.ParuhWaktu {
+		return nil
+	}
+	for _, other := range others {
+		if other.ID == candidate.ID || other.ParuhWaktu {
+			continue
+		}
+		if r.SingleActive && candidate.Aktif && other.Aktif {
+			return fmt.Errorf("alumni sudah memiliki pekerjaan aktif (%s); selesaikan pekerjaan tersebut atau tandai paruh_waktu untuk pekerjaan paralel", other.Label)
+		}
+		if !r.RejectOverlap {
+			continue
+		}
+		days, unbounded := overlapDays(candidate, other)
+		if unbounded {
+			return fmt.Errorf("periode kerja tumpang tindih dengan %s yang juga masih berjalan; tandai paruh_waktu untuk pekerjaan paralel", other.Label)
+		}
+		if days > r.OverlapToleranceDays {
+			return fmt.Errorf("periode kerja tumpang tindih %d hari dengan %s; tandai paruh_waktu untuk pekerjaan paralel", days, other.Label)
+		}
+	}
+	return nil
+}
+
+// MergeCareerPeriods -> riwayat tersimpan ditambah pending (mis. baris lain dari file import). Periode
+// pending dengan ID yang sama menggantikan versi tersimpannya.
+func MergeCareerPeriods(saved, pending []CareerPeriod) []CareerPeriod {
+	if len(pending) == 0 {
+		return saved
+	}
+	replaced := make(map[string]bool, len(pending))
+	for _, p := range pending {
+		replaced[p.ID] = true
+	}
+	merged := make([]CareerPeriod, 0, len(saved)+len(pending))
+	for _, p := range saved {
+		if !replaced[p.ID] {
+			merged = append(merged, p)
+		}
+	}
+	return append(merged, pending...)
+}
+
+// overlapDays -> jumlah hari dua periode berjalan bersamaan. Tanggal selesai tidak ikut
+// dihitung sehingga pindah kerja di hari yang sama bukan tumpang tindih. unbounded true bila
+// keduanya masih berjalan.
+func overlapDays(a, b CareerPeriod) (int, bool) {
+	start := a.Start
+	if b.Start.After(start) {
+		start = b.Start
+	}
+	var end time.Time
+	switch {
+	case a.End == nil && b.End == nil:
+		return 0, true
+	case a.End == nil:
+		end = *b.End
+	case b.End == nil || a.End.Before(*b.End):
+		end = *a.End
+	default:
+		end = *b.End
+	}
+	return daysBetween(start, end), false
+}
+
+// CareerTenure -> lama satu pekerjaan dalam hari dan bulan penuh; pekerjaan yang masih berjalan
+// dihitung sampai now
+func CareerTenure(p CareerPeriod, now time.Time) (days, months int) {
+	end := periodEnd(p, now)
+	return daysBetween(p.Start, end), monthsBetween(p.Start, end)
+}
+
+// CareerGap -> masa tanpa pekerjaan di antara dua pekerjaan
+type CareerGap struct {
+	From time.Time
+	To   time.Time
+	Days int
+}
+
+// CareerOverlap -> dua pekerjaan penuh waktu yang berjalan bersamaan (indeks pada periods)
+type CareerOverlap struct {
+	First  int
+	Second int
+	Days   int
+}
+
+// CareerSummary -> ringkasan riwayat karier. Total pengalaman dihitung dari gabungan periode
+// sehingga pekerjaan yang tumpang tindih tidak dihitung dua kali.
+type CareerSummary struct {
+	Gaps        []CareerGap
+	Overlaps    []CareerOverlap
+	TotalDays   int
+	TotalMonths int
+}
+
+// SummarizeCareer -> celah, tumpang tindih, dan total pengalaman dari periods (urutan bebas)
+func SummarizeCareer(periods []CareerPeriod, now time.Time) CareerSummary {
+	summary := CareerSummary{Gaps: []CareerGap{}, Overlaps: []CareerOverlap{}}
+
+	order := make([]int, len(periods))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return periods[order[a]].Start.Before(periods[order[b]].Start)
+	})
+
+	for x, i := range order {
+		for _, j := range order[x+1:] {
+			if periods[i].ParuhWaktu || periods[j].ParuhWaktu {
+				continue
+			}
+			days := daysBetween(periods[j].Start, earliest(periodEnd(periods[i], now), periodEnd(periods[j], now)))
+			if days > 0 {
+				summary.Overlaps = append(summary.Overlaps, CareerOverlap{First: i, Second: j, Days: days})
+			}
+		}
+	}
+
+	// Gabungkan periode yang bersinggungan; celah adalah jarak antar gabungan
+	var mergedStart, mergedEnd time.Time
+	for x, i := range order {
+		start, end := periods[i].Start, periodEnd(periods[i], now)
+		if x > 0 && !start.After(mergedEnd) {
+			if end.After(mergedEnd) {
+				mergedEnd = end
+			}
+			continue
+		}
+		if x > 0 {
+			summary.TotalDays += daysBetween(mergedStart, mergedEnd)
+			summary.TotalMonths += monthsBetween(mergedStart, mergedEnd)
+			summary.Gaps = append(summary.Gaps, CareerGap{From: mergedEnd, To: start, Days: daysBetween(mergedEnd, start)})
+		}
+		mergedStart, mergedEnd = start, end
+	}
+	if len(order) > 0 {
+		summary.TotalDays += daysBetween(mergedStart, mergedEnd)
+		summary.TotalMonths += monthsBetween(mergedStart, mergedEnd)
+	}
+	return summary
+}
+
+func periodEnd(p CareerPeriod, now time.Time) time.Time {
+	if p.End != nil {
+		return *p.End
+	}
+	if now.Before(p.Start) {
+		return p.Start
+	}
+	return now
+}
+
+func earliest(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
+
+func daysBetween(from, to time.Time) int {
+	if !to.After(from) {
+		return 0
+	}
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// monthsBetween -> jumlah bulan penuh dari from sampai to
+func monthsBetween(from, to time.Time) int {
+	months := (to.Year()-from.Year())*12 + int(to.Month()-from.Month())
+	if to.Day() < from.Day() {
+		months--
+	}
+	return max(months, 0)
+}
@@ -0,0 +1,204 @@
+package helper
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrInvalidCursor dikembalikan bila cursor rusak, dimodifikasi, atau tidak cocok dengan query
+var ErrInvalidCursor = errors.New("cursor tidak valid")
+
+// Cursor -> posisi terakhir pada listing keyset: nilai kolom sort + id sebagai tie-breaker.
+// Scope mengikat cursor ke kombinasi search/filter yang sama dengan saat cursor dibuat.
+type Cursor struct {
+	SortBy string `json:"s"`
+	Order  string `json:"o"`
+	Value  string `json:"v"`
+	ID     string `json:"id"`
+	Scope  string `json:"f,omitempty"`
+}
+
+// Keyset -> kondisi keyset yang sudah di-parse untuk dipakai repository.
+// Before=true berarti mengambil halaman sebelum posisi (prev_cursor).
+type Keyset struct {
+	Value  interface{}
+	ID     interface{}
+	Before bool
+}
+
+var (
+	cursorSecretOnce sync.Once
+	cursorRandomKey  []byte
+)
+
+// cursorSecret -> kunci HMAC untuk menandatangani cursor. Tanpa CURSOR_SECRET dipakai kunci
+// acak per proses, sehingga cursor tidak bisa dipalsukan namun tidak berlaku lintas instance/restart.
+func cursorSecret() []byte {
+	if s := os.Getenv("CURSOR_SECRET"); s != "" {
+		return []byte(s)
+	}
+	cursorSecretOnce.Do(func() {
+		cursorRandomKey = make([]byte, 32)
+		rand.Read(cursorRandomKey)
+	})
+	return cursorRandomKey
+}
+
+// EncodeCursor menghasilkan token opaque: base64url(payload).base64url(hmac)
+func EncodeCursor(c Cursor) string {
+	payload, _ := json.Marshal(c)
+	mac := hmac.New(sha256.New, cursorSecret())
+	mac.Write(payload)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// DecodeCursor memverifikasi tanda tangan lalu mengembalikan isi cursor
+func DecodeCursor(token string) (*Cursor, error) {
+	parts := strings.SplitN(token, ".", 2)
+	if len(parts) != 2 {
+		return nil, ErrInvalidCursor
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	mac := hmac.New(sha256.New, cursorSecret())
+	mac.Write(payload)
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// CursorScope -> sidik jari parameter search/filter sehingga cursor tidak bisa dipakai
+// ulang dengan kombinasi filter yang berbeda (hasilnya akan melompati/menggandakan baris)
+func CursorScope(queries map[string]string) string {
+	keys := make([]string, 0, len(queries))
+	for key := range queries {
+		if key == "search" || strings.HasPrefix(key, "filter[") {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return ""
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, key := range keys {
+		h.Write([]byte(key + "=" + queries[key] + "&"))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// CursorPosition -> nilai kolom sort dan id dari satu item (item pertama/terakhir halaman)
+type CursorPosition struct {
+	Value interface{}
+	ID    interface{}
+}
+
+// ParseKeyset membaca token ?after / ?before, memastikan sort, order, dan scope sama dengan
+// request saat ini, lalu mengubah nilai di dalamnya ke tipe kolom sort dan tipe id
+func ParseKeyset(token string, before bool, expect Cursor, valueType, idType string) (*Keyset, error) {
+	c, err := DecodeCursor(token)
+	if err != nil {
+		return nil, err
+	}
+	if c.SortBy != expect.SortBy || c.Order != expect.Order || c.Scope != expect.Scope {
+		return nil, fmt.Errorf("%w: sortBy, order, search, dan filter harus sama dengan saat cursor dibuat", ErrInvalidCursor)
+	}
+
+	value, err := parseCursorValue(valueType, c.Value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	id, err := parseCursorValue(idType, c.ID)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &Keyset{Value: value, ID: id, Before: before}, nil
+}
+
+// PageCursors menghitung next_cursor dan prev_cursor untuk satu halaman.
+// hasMore = masih ada data ke arah pengambilan; hasPrev = ada data sebelum halaman
+// (dipakai pada mode offset, mis. page > 1).
+func PageCursors(base Cursor, keyset *Keyset, hasMore, hasPrev bool, first, last *CursorPosition) (next, prev string) {
+	if first == nil || last == nil {
+		return "", ""
+	}
+
+	encode := func(p *CursorPosition) string {
+		c := base
+		c.Value = cursorValue(p.Value)
+		c.ID = cursorValue(p.ID)
+		return EncodeCursor(c)
+	}
+
+	switch {
+	case keyset == nil:
+		if hasMore {
+			next = encode(last)
+		}
+		if hasPrev {
+			prev = encode(first)
+		}
+	case keyset.Before:
+		next = encode(last)
+		if hasMore {
+			prev = encode(first)
+		}
+	default:
+		if hasMore {
+			next = encode(last)
+		}
+		prev = encode(first)
+	}
+	return next, prev
+}
+
+// cursorValue -> representasi string nilai kolom untuk disimpan di cursor
+func cursorValue(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case int:
+		return strconv.Itoa(t)
+	case time.Time:
+		return t.UTC().Format(time.RFC3339Nano)
+	case primitive.ObjectID:
+		return t.Hex()
+	default:
+		return fmt.Sprint(t)
+	}
+}
+
+func parseCursorValue(typ, raw string) (interface{}, error) {
+	if typ == FilterTypeDate {
+		return time.Parse(time.RFC3339Nano, raw)
+	}
+	return parseFilterValue(typ, raw)
+}
@@ -0,0 +1,23 @@
+package helper
+
+import (
+	"fmt"
+	"time"
+)
+
+var acceptedDateLayouts = []string{
+	"2006-01-02",
+	"02-01-2006",
+	"2006/01/02",
+	"02/01/2006",
+}
+
+// ParseDateFlexible menerima tanggal dalam format YYYY-MM-DD, DD-MM-YYYY, YYYY/MM/DD, atau DD/MM/YYYY
+func ParseDateFlexible(dateStr string) (time.Time, error) {
+	for _, layout := range acceptedDateLayouts {
+		if t, err := time.Parse(layout, dateStr); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("format tanggal tidak valid. Gunakan salah satu format: YYYY-MM-DD, DD-MM-YYYY, YYYY/MM/DD, DD/MM/YYYY")
+}
@@ -0,0 +1,43 @@
+package helper
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidIfMatch -> header If-Match bukan satu ETag versi yang dikenal
+var ErrInvalidIfMatch = errors.New("If-Match harus berisi satu ETag dari response GET")
+
+// ErrVersionConflict -> update bersyarat gagal karena versi record sudah berubah
+var ErrVersionConflict = errors.New("versi data sudah berubah")
+
+// ETag -> nilai header ETag untuk versi record (strong ETag, mis. "3")
+func ETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// ParseIfMatch -> versi yang diminta header If-Match. ok false bila header kosong atau "*"
+// (update tanpa syarat). Awalan W/ diterima agar ETag yang dilemahkan proxy tetap bisa dipakai.
+func ParseIfMatch(header string) (version int, ok bool, err error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, false, nil
+	}
+	value := strings.TrimPrefix(header, "W/")
+	if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+		return 0, false, ErrInvalidIfMatch
+	}
+	version, err = strconv.Atoi(value[1 : len(value)-1])
+	if err != nil || version < 0 {
+		return 0, false, ErrInvalidIfMatch
+	}
+	return version, true, nil
+}
+
+// IfMatchRequired -> STRICT_IF_MATCH=true: PUT/PATCH tanpa If-Match ditolak dengan 428
+func IfMatchRequired() bool {
+	strict, _ := strconv.ParseBool(os.Getenv("STRICT_IF_MATCH"))
+	return strict
+}
@@ -0,0 +1,300 @@
+package helper
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format export yang didukung
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatXLSX   = "xlsx"
+	ExportFormatNDJSON = "ndjson"
+	ExportFormatPDF    = "pdf"
+)
+
+// ErrUnsupportedExportFormat dikembalikan bila format bukan csv, xlsx, ndjson, atau pdf
+var ErrUnsupportedExportFormat = errors.New("format export harus csv, xlsx, ndjson, atau pdf")
+
+var exportContentTypes = map[string]string{
+	ExportFormatCSV:    "text/csv; charset=utf-8",
+	ExportFormatXLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	ExportFormatNDJSON: "application/x-ndjson",
+	ExportFormatPDF:    "application/pdf",
+}
+
+// ExportContentType -> content type untuk format export
+func ExportContentType(format string) (string, error) {
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		return "", ErrUnsupportedExportFormat
+	}
+	return contentType, nil
+}
+
+// ExportColumn -> satu kolom export. Key dipakai sebagai header CSV/XLSX dan key NDJSON
+// (sama dengan nama kolom import), Label dipakai sebagai judul kolom PDF.
+// Width adalah lebar relatif kolom di PDF (0 berarti 1).
+type ExportColumn struct {
+	Key   string
+	Label string
+	Width float64
+}
+
+// ExportWriter menulis baris export secara streaming; Close wajib dipanggil untuk menutup dokumen
+type ExportWriter interface {
+	WriteRow(values []interface{}) error
+	Close() error
+}
+
+// NewExportWriter membuat writer untuk format tertentu. Nilai baris boleh berupa string, angka,
+// bool, time.Time, pointer dari tipe tersebut (nil menjadi kosong), atau tipe dengan method Hex().
+func NewExportWriter(format string, w io.Writer, title string, columns []ExportColumn) (ExportWriter, error) {
+	buf := bufio.NewWriterSize(w, 64<<10)
+	switch format {
+	case ExportFormatCSV:
+		return newCSVExportWriter(buf, columns)
+	case ExportFormatNDJSON:
+		return &ndjsonExportWriter{w: buf, columns: columns}, nil
+	case ExportFormatXLSX:
+		return newXLSXExportWriter(buf, columns)
+	case ExportFormatPDF:
+		return newPDFExportWriter(buf, title, columns)
+	default:
+		return nil, ErrUnsupportedExportFormat
+	}
+}
+
+// exportValue -> nilai yang siap ditulis: pointer di-dereference, tanggal tanpa jam menjadi
+// "2006-01-02", timestamp menjadi RFC3339, ObjectID menjadi hex
+func exportValue(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		return exportValue(rv.Elem().Interface())
+	}
+	if h, ok := v.(interface{ Hex() string }); ok {
+		return h.Hex()
+	}
+
+	if t, ok := v.(time.Time); ok {
+		if t.IsZero() {
+			return nil
+		}
+		if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+			return t.Format("2006-01-02")
+		}
+		return t.Format(time.RFC3339)
+	}
+	return v
+}
+
+// FormatExportValue -> representasi teks sebuah nilai export (dipakai CSV dan PDF)
+func FormatExportValue(v interface{}) string {
+	switch value := exportValue(v).(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(value), 'f', -1, 32)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+type csvExportWriter struct {
+	buf *bufio.Writer
+	w   *csv.Writer
+	row []string
+}
+
+func newCSVExportWriter(buf *bufio.Writer, columns []ExportColumn) (*csvExportWriter, error) {
+	ew := &csvExportWriter{buf: buf, w: csv.NewWriter(buf), row: make([]string, len(columns))}
+	for i, col := range columns {
+		ew.row[i] = col.Key
+	}
+	if err := ew.w.Write(ew.row); err != nil {
+		return nil, err
+	}
+	return ew, nil
+}
+
+func (ew *csvExportWriter) WriteRow(values []interface{}) error {
+	for i := range ew.row {
+		ew.row[i] = ""
+		if i < len(values) {
+			ew.row[i] = FormatExportValue(values[i])
+		}
+	}
+	return ew.w.Write(ew.row)
+}
+
+func (ew *csvExportWriter) Close() error {
+	ew.w.Flush()
+	if err := ew.w.Error(); err != nil {
+		return err
+	}
+	return ew.buf.Flush()
+}
+
+// ndjsonExportWriter -> satu objek JSON per baris dengan urutan key sesuai kolom
+type ndjsonExportWriter struct {
+	w       *bufio.Writer
+	columns []ExportColumn
+}
+
+func (ew *ndjsonExportWriter) WriteRow(values []interface{}) error {
+	ew.w.WriteByte('{')
+	for i, col := range ew.columns {
+		if i > 0 {
+			ew.w.WriteByte(',')
+		}
+		key, _ := json.Marshal(col.Key)
+		ew.w.Write(key)
+		ew.w.WriteByte(':')
+
+		var v interface{}
+		if i < len(values) {
+			v = exportValue(values[i])
+		}
+		value, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		ew.w.Write(value)
+	}
+	ew.w.WriteByte('}')
+	return ew.w.WriteByte('\n')
+}
+
+func (ew *ndjsonExportWriter) Close() error {
+	return ew.w.Flush()
+}
+
+// xlsxExportWriter -> workbook satu sheet; bagian statis ditulis lebih dulu sehingga
+// sheet1.xml bisa di-stream baris per baris sebagai entry terakhir zip
+type xlsxExportWriter struct {
+	buf   *bufio.Writer
+	zip   *zip.Writer
+	sheet io.Writer
+	rowNo int
+}
+
+var xlsxStaticParts = []struct{ name, body string }{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/><Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/></Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Data" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/></Relationships>`},
+	{"xl/styles.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts><fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills><borders count="1"><border/></borders><cellStyleXfs count="1"><xf/></cellStyleXfs><cellXfs count="2"><xf/><xf fontId="1" applyFont="1"/></cellXfs></styleSheet>`},
+}
+
+func newXLSXExportWriter(buf *bufio.Writer, columns []ExportColumn) (*xlsxExportWriter, error) {
+	zw := zip.NewWriter(buf)
+	for _, part := range xlsxStaticParts {
+		w, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(w, part.body); err != nil {
+			return nil, err
+		}
+	}
+
+	sheet, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	io.WriteString(sheet, `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`+"\n"+
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	ew := &xlsxExportWriter{buf: buf, zip: zw, sheet: sheet}
+	header := make([]interface{}, len(columns))
+	for i, col := range columns {
+		header[i] = col.Key
+	}
+	if err := ew.writeRow(header, 1); err != nil {
+		return nil, err
+	}
+	return ew, nil
+}
+
+func (ew *xlsxExportWriter) WriteRow(values []interface{}) error {
+	return ew.writeRow(values, 0)
+}
+
+// writeRow -> angka ditulis sebagai sel numerik, selain itu inline string; style 1 = tebal
+func (ew *xlsxExportWriter) writeRow(values []interface{}, style int) error {
+	ew.rowNo++
+	var b strings.Builder
+	fmt.Fprintf(&b, `<row r="%d">`, ew.rowNo)
+	for i, v := range values {
+		ref := xlsxColumnName(i) + strconv.Itoa(ew.rowNo)
+		styleAttr := ""
+		if style > 0 {
+			styleAttr = fmt.Sprintf(` s="%d"`, style)
+		}
+
+		switch value := exportValue(v).(type) {
+		case nil:
+			continue
+		case int, int32, int64, float32, float64:
+			fmt.Fprintf(&b, `<c r="%s"%s><v>%s</v></c>`, ref, styleAttr, FormatExportValue(value))
+		case bool:
+			boolValue := 0
+			if value {
+				boolValue = 1
+			}
+			fmt.Fprintf(&b, `<c r="%s"%s t="b"><v>%d</v></c>`, ref, styleAttr, boolValue)
+		default:
+			fmt.Fprintf(&b, `<c r="%s"%s t="inlineStr"><is><t xml:space="preserve">`, ref, styleAttr)
+			xml.EscapeText(&b, []byte(FormatExportValue(value)))
+			b.WriteString(`</t></is></c>`)
+		}
+	}
+	b.WriteString(`</row>`)
+	_, err := io.WriteString(ew.sheet, b.String())
+	return err
+}
+
+func (ew *xlsxExportWriter) Close() error {
+	if _, err := io.WriteString(ew.sheet, `</sheetData></worksheet>`); err != nil {
+		return err
+	}
+	if err := ew.zip.Close(); err != nil {
+		return err
+	}
+	return ew.buf.Flush()
+}
+
+// xlsxColumnName -> 0 menjadi "A", 26 menjadi "AA"
+func xlsxColumnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
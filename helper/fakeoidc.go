@@ -0,0 +1,261 @@
+package helper
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// FakeOIDCUser -> akun di FakeOIDCServer. Dipilih lewat parameter login_hint (email atau sub)
+// pada authorization request; tanpa login_hint akun pertama yang dipakai.
+type FakeOIDCUser struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	NIM           string
+}
+
+type fakeOIDCCode struct {
+	user        FakeOIDCUser
+	clientID    string
+	redirectURI string
+	nonce       string
+	challenge   string
+	expiresAt   time.Time
+}
+
+// FakeOIDCServer -> provider OIDC minimal untuk development dan test: discovery, authorization
+// endpoint yang langsung menyetujui (tanpa halaman login), token endpoint dengan PKCE S256,
+// dan JWKS. ID token ditandatangani RS256 dengan kunci yang dibuat saat start.
+type FakeOIDCServer struct {
+	clientID string
+	users    []FakeOIDCUser
+	key      *rsa.PrivateKey
+	listener net.Listener
+	server   *http.Server
+
+	mu    sync.Mutex
+	codes map[string]fakeOIDCCode
+}
+
+const fakeOIDCKeyID = "fake-oidc-1"
+
+// StartFakeOIDCServer mendengarkan di addr (mis. "127.0.0.1:9400" atau "127.0.0.1:0") dan hanya
+// menerima clientID. Issuer-nya http://<addr>.
+func StartFakeOIDCServer(addr, clientID string, users []FakeOIDCUser) (*FakeOIDCServer, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	s := &FakeOIDCServer{clientID: clientID, users: users, key: key, listener: l, codes: map[string]fakeOIDCCode{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", s.discovery)
+	mux.HandleFunc("/authorize", s.authorize)
+	mux.HandleFunc("/token", s.token)
+	mux.HandleFunc("/jwks", s.jwks)
+	s.server = &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	go s.server.Serve(l)
+	return s, nil
+}
+
+// Issuer -> nilai untuk OIDC_ISSUER
+func (s *FakeOIDCServer) Issuer() string {
+	return "http://" + s.listener.Addr().String()
+}
+
+// Close menghentikan server
+func (s *FakeOIDCServer) Close() error {
+	return s.server.Close()
+}
+
+// SignIDToken -> tanda tangani claims dengan kunci server (untuk test token yang dimanipulasi)
+func (s *FakeOIDCServer) SignIDToken(claims jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = fakeOIDCKeyID
+	return token.SignedString(s.key)
+}
+
+func writeOIDCJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func oidcTokenError(w http.ResponseWriter, code, description string) {
+	writeOIDCJSON(w, http.StatusBadRequest, map[string]string{"error": code, "error_description": description})
+}
+
+func (s *FakeOIDCServer) discovery(w http.ResponseWriter, r *http.Request) {
+	issuer := s.Issuer()
+	writeOIDCJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                issuer,
+		"authorization_endpoint":                issuer + "/authorize",
+		"token_endpoint":                        issuer + "/token",
+		"jwks_uri":                              issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+		"scopes_supported":                      []string{"openid", "email", "profile"},
+	})
+}
+
+func (s *FakeOIDCServer) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	redirectURI := q.Get("redirect_uri")
+	target, err := url.Parse(redirectURI)
+	if err != nil || redirectURI == "" {
+		http.Error(w, "redirect_uri tidak valid", http.StatusBadRequest)
+		return
+	}
+	if q.Get("client_id") != s.clientID {
+		http.Error(w, "client_id tidak dikenal", http.StatusBadRequest)
+		return
+	}
+
+	redirectErr := func(code string) {
+		v := target.Query()
+		v.Set("error", code)
+		v.Set("state", q.Get("state"))
+		target.RawQuery = v.Encode()
+		http.Redirect(w, r, target.String(), http.StatusFound)
+	}
+	if q.Get("response_type") != "code" || !strings.Contains(" "+q.Get("scope")+" ", " openid ") {
+		redirectErr("invalid_request")
+		return
+	}
+	if q.Get("code_challenge") == "" || q.Get("code_challenge_method") != "S256" {
+		redirectErr("invalid_request")
+		return
+	}
+	user, ok := s.findUser(q.Get("login_hint"))
+	if !ok {
+		redirectErr("access_denied")
+		return
+	}
+
+	code, err := randomURLToken()
+	if err != nil {
+		redirectErr("server_error")
+		return
+	}
+	s.mu.Lock()
+	s.codes[code] = fakeOIDCCode{
+		user:        user,
+		clientID:    s.clientID,
+		redirectURI: redirectURI,
+		nonce:       q.Get("nonce"),
+		challenge:   q.Get("code_challenge"),
+		expiresAt:   time.Now().Add(time.Minute),
+	}
+	s.mu.Unlock()
+
+	v := target.Query()
+	v.Set("code", code)
+	v.Set("state", q.Get("state"))
+	target.RawQuery = v.Encode()
+	http.Redirect(w, r, target.String(), http.StatusFound)
+}
+
+func (s *FakeOIDCServer) findUser(hint string) (FakeOIDCUser, bool) {
+	if hint == "" && len(s.users) > 0 {
+		return s.users[0], true
+	}
+	for _, u := range s.users {
+		if strings.EqualFold(u.Email, hint) || u.Subject == hint {
+			return u, true
+		}
+	}
+	return FakeOIDCUser{}, false
+}
+
+func (s *FakeOIDCServer) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ParseForm() != nil {
+		oidcTokenError(w, "invalid_request", "POST form diperlukan")
+		return
+	}
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		oidcTokenError(w, "unsupported_grant_type", "")
+		return
+	}
+
+	// Code hanya bisa dipakai sekali
+	s.mu.Lock()
+	code, ok := s.codes[r.PostForm.Get("code")]
+	delete(s.codes, r.PostForm.Get("code"))
+	s.mu.Unlock()
+
+	switch {
+	case !ok || time.Now().After(code.expiresAt):
+		oidcTokenError(w, "invalid_grant", "code tidak valid atau kedaluwarsa")
+		return
+	case r.PostForm.Get("client_id") != code.clientID:
+		oidcTokenError(w, "invalid_client", "")
+		return
+	case r.PostForm.Get("redirect_uri") != code.redirectURI:
+		oidcTokenError(w, "invalid_grant", "redirect_uri tidak cocok")
+		return
+	case PKCEChallenge(r.PostForm.Get("code_verifier")) != code.challenge:
+		oidcTokenError(w, "invalid_grant", "code_verifier tidak cocok")
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            s.Issuer(),
+		"sub":            code.user.Subject,
+		"aud":            code.clientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"email":          code.user.Email,
+		"email_verified": code.user.EmailVerified,
+		"name":           code.user.Name,
+	}
+	if code.nonce != "" {
+		claims["nonce"] = code.nonce
+	}
+	if code.user.NIM != "" {
+		claims["nim"] = code.user.NIM
+	}
+	idToken, err := s.SignIDToken(claims)
+	if err != nil {
+		oidcTokenError(w, "server_error", err.Error())
+		return
+	}
+	writeOIDCJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": "fake-access-token",
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     idToken,
+	})
+}
+
+func (s *FakeOIDCServer) jwks(w http.ResponseWriter, r *http.Request) {
+	pub := s.key.PublicKey
+	writeOIDCJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": fakeOIDCKeyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
@@ -0,0 +1,148 @@
+package helper
+
+import (
+	"bufio"
+	"net"
+	"net/mail"
+	"strings"
+	"sync"
+)
+
+// ReceivedMail -> email yang diterima FakeSMTPServer
+type ReceivedMail struct {
+	From    string
+	To      []string
+	Message *mail.Message
+	Raw     string
+}
+
+// FakeSMTPServer -> server SMTP minimal untuk development dan test. Semua email diterima
+// (tanpa AUTH/TLS) dan disimpan di memori.
+type FakeSMTPServer struct {
+	onMail   func(ReceivedMail)
+	listener net.Listener
+	mu       sync.Mutex
+	mails    []ReceivedMail
+	wg       sync.WaitGroup
+}
+
+// StartFakeSMTPServer mendengarkan di addr (mis. "127.0.0.1:1025" atau "127.0.0.1:0").
+// onMail (boleh nil) dipanggil untuk setiap email yang diterima.
+func StartFakeSMTPServer(addr string, onMail func(ReceivedMail)) (*FakeSMTPServer, error) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	s := &FakeSMTPServer{listener: l, onMail: onMail}
+	s.wg.Add(1)
+	go s.serve()
+	return s, nil
+}
+
+// Addr -> alamat host:port yang sedang didengarkan
+func (s *FakeSMTPServer) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Mails -> salinan semua email yang sudah diterima
+func (s *FakeSMTPServer) Mails() []ReceivedMail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]ReceivedMail(nil), s.mails...)
+}
+
+// Close menghentikan server dan menunggu koneksi yang sedang berjalan
+func (s *FakeSMTPServer) Close() error {
+	err := s.listener.Close()
+	s.wg.Wait()
+	return err
+}
+
+func (s *FakeSMTPServer) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(conn)
+		}()
+	}
+}
+
+func (s *FakeSMTPServer) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) {
+		conn.Write([]byte(line + "\r\n"))
+	}
+
+	reply("220 fake-smtp ready")
+	var current ReceivedMail
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		cmd := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"):
+			reply("250-fake-smtp")
+			reply("250 8BITMIME")
+		case strings.HasPrefix(cmd, "HELO"):
+			reply("250 fake-smtp")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			current = ReceivedMail{From: smtpPath(line[len("MAIL FROM:"):])}
+			reply("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			current.To = append(current.To, smtpPath(line[len("RCPT TO:"):]))
+			reply("250 OK")
+		case cmd == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" || l == ".\n" {
+					break
+				}
+				// Dot-stuffing (RFC 5321 4.5.2)
+				data.WriteString(strings.TrimPrefix(l, "."))
+			}
+			current.Raw = data.String()
+			current.Message, _ = mail.ReadMessage(strings.NewReader(current.Raw))
+			s.mu.Lock()
+			s.mails = append(s.mails, current)
+			s.mu.Unlock()
+			if s.onMail != nil {
+				s.onMail(current)
+			}
+			reply("250 OK: queued")
+		case cmd == "RSET":
+			current = ReceivedMail{}
+			reply("250 OK")
+		case cmd == "NOOP":
+			reply("250 OK")
+		case cmd == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+// smtpPath -> "<a@b.c> SIZE=123" menjadi "a@b.c"
+func smtpPath(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.Index(s, ">"); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimPrefix(s, "<")
+}
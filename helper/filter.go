@@ -0,0 +1,212 @@
+package helper
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Operator filter yang dikenali oleh query language filter[field][op]=value
+const (
+	FilterEq      = "eq"
+	FilterNe      = "ne"
+	FilterGt      = "gt"
+	FilterGte     = "gte"
+	FilterLt      = "lt"
+	FilterLte     = "lte"
+	FilterIn      = "in"
+	FilterNin     = "nin"
+	FilterBetween = "between"
+	FilterLike    = "like"
+	FilterNull    = "null"
+	FilterNear    = "near"
+)
+
+// Tipe nilai field filter, menentukan cara parsing value dari query string
+const (
+	FilterTypeString   = "string"
+	FilterTypeInt      = "int"
+	FilterTypeDate     = "date"
+	FilterTypeObjectID = "objectid"
+	FilterTypeGeo      = "geo"
+)
+
+// Kumpulan operator standar per tipe field
+var (
+	StringFilterOps = []string{FilterEq, FilterNe, FilterIn, FilterNin, FilterLike}
+	NumberFilterOps = []string{FilterEq, FilterNe, FilterGt, FilterGte, FilterLt, FilterLte, FilterIn, FilterNin, FilterBetween}
+	IDFilterOps     = []string{FilterEq, FilterNe, FilterIn, FilterNin}
+	GeoFilterOps    = []string{FilterNear}
+)
+
+// FilterField -> whitelist satu field: nama kolom/path di database, tipe, dan operator yang diizinkan
+type FilterField struct {
+	Column string
+	Type   string
+	Ops    []string
+}
+
+// FilterSpec -> whitelist field filter per resource (key = nama field di query string)
+type FilterSpec map[string]FilterField
+
+// Filter -> satu kondisi filter yang sudah divalidasi dan nilainya sudah di-parse sesuai tipe
+type Filter struct {
+	Field  string
+	Column string
+	Op     string
+	Values []interface{}
+}
+
+var filterKeyPattern = regexp.MustCompile(`^filter\[([a-z0-9_]+)\](?:\[([a-z]+)\])?$`)
+
+// ParseFilters membaca parameter filter[field][op]=value dari query string.
+// Field dan operator di luar spec ditolak; filter[field]=value berarti operator eq.
+// Nilai untuk in/nin/between dipisah dengan koma.
+func ParseFilters(queries map[string]string, spec FilterSpec) ([]Filter, error) {
+	keys := make([]string, 0, len(queries))
+	for key := range queries {
+		if strings.HasPrefix(key, "filter[") {
+			keys = append(keys, key)
+		}
+	}
+	// Urutan stabil agar query dan argumen SQL yang dihasilkan deterministik
+	sort.Strings(keys)
+
+	var filters []Filter
+	for _, key := range keys {
+		match := filterKeyPattern.FindStringSubmatch(key)
+		if match == nil {
+			return nil, fmt.Errorf("format filter tidak valid: %s", key)
+		}
+		field, op := match[1], match[2]
+		if op == "" {
+			op = FilterEq
+		}
+
+		def, ok := spec[field]
+		if !ok {
+			return nil, fmt.Errorf("filter untuk field '%s' tidak diizinkan", field)
+		}
+		if !containsOp(def.Ops, op) {
+			return nil, fmt.Errorf("operator '%s' tidak diizinkan untuk field '%s'", op, field)
+		}
+
+		values, err := parseFilterValues(def, op, queries[key])
+		if err != nil {
+			return nil, fmt.Errorf("nilai filter '%s' tidak valid: %v", field, err)
+		}
+
+		filters = append(filters, Filter{Field: field, Column: def.Column, Op: op, Values: values})
+	}
+	return filters, nil
+}
+
+func containsOp(ops []string, op string) bool {
+	for _, o := range ops {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+func parseFilterValues(def FilterField, op, raw string) ([]interface{}, error) {
+	raw = strings.TrimSpace(raw)
+
+	switch op {
+	case FilterNull:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("gunakan true atau false")
+		}
+		return []interface{}{b}, nil
+	case FilterLike:
+		if raw == "" {
+			return nil, fmt.Errorf("nilai kosong")
+		}
+		return []interface{}{raw}, nil
+	case FilterNear:
+		geo, err := parseGeoRadius(raw)
+		if err != nil {
+			return nil, err
+		}
+		return []interface{}{geo}, nil
+	}
+
+	parts := []string{raw}
+	if op == FilterIn || op == FilterNin || op == FilterBetween {
+		parts = strings.Split(raw, ",")
+	}
+	if op == FilterBetween && len(parts) != 2 {
+		return nil, fmt.Errorf("between membutuhkan dua nilai dipisah koma")
+	}
+
+	values := make([]interface{}, 0, len(parts))
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			return nil, fmt.Errorf("nilai kosong")
+		}
+		v, err := parseFilterValue(def.Type, p)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+func parseFilterValue(typ, raw string) (interface{}, error) {
+	switch typ {
+	case FilterTypeInt:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' bukan angka", raw)
+		}
+		return n, nil
+	case FilterTypeDate:
+		t, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' bukan tanggal YYYY-MM-DD", raw)
+		}
+		return t, nil
+	case FilterTypeObjectID:
+		id, err := primitive.ObjectIDFromHex(raw)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' bukan ObjectID", raw)
+		}
+		return id, nil
+	default:
+		return raw, nil
+	}
+}
+
+// parseGeoRadius -> "lat,lng,radius_km", mis. "-6.2,106.8,25"
+func parseGeoRadius(raw string) (GeoRadius, error) {
+	parts := strings.Split(raw, ",")
+	if len(parts) != 3 {
+		return GeoRadius{}, fmt.Errorf("near membutuhkan lat,lng,radius_km")
+	}
+	var values [3]float64
+	for i, p := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+			return GeoRadius{}, fmt.Errorf("'%s' bukan angka", strings.TrimSpace(p))
+		}
+		values[i] = v
+	}
+	geo := GeoRadius{Lat: values[0], Lng: values[1], RadiusKm: values[2]}
+	if geo.Lat < -90 || geo.Lat > 90 || geo.Lng < -180 || geo.Lng > 180 {
+		return GeoRadius{}, fmt.Errorf("latitude harus -90..90 dan longitude -180..180")
+	}
+	if geo.RadiusKm <= 0 || geo.RadiusKm > MaxGeoRadiusKm {
+		return GeoRadius{}, fmt.Errorf("radius harus lebih dari 0 dan maksimal %d km", MaxGeoRadiusKm)
+	}
+	return geo, nil
+}
@@ -0,0 +1,97 @@
+package helper
+
+import (
+	"sync"
+	"time"
+)
+
+// Header Idempotency-Key: client mengirim key unik per operasi agar POST aman diulang
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+	IdempotencyKeyMaxLength   = 255
+)
+
+// IdempotencyRecord -> response pertama untuk satu Idempotency-Key. Completed false berarti
+// request pertama masih diproses.
+type IdempotencyRecord struct {
+	RequestHash string
+	Completed   bool
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// IdempotencyStore -> penyimpanan Idempotency-Key per user; key kedaluwarsa setelah ttl
+type IdempotencyStore interface {
+	// Reserve mencatat key untuk request baru dan mengembalikan nil. Bila key masih berlaku,
+	// record yang sudah ada dikembalikan tanpa mengubah apa pun
+	Reserve(key, requestHash string, ttl time.Duration) (*IdempotencyRecord, error)
+	// Complete menyimpan response request pertama untuk di-replay
+	Complete(key string, record IdempotencyRecord) error
+	// Release menghapus key agar request bisa diulang, mis. setelah error server
+	Release(key string) error
+}
+
+// IdempotencyTTL -> lama response disimpan untuk di-replay (IDEMPOTENCY_TTL_HOURS, default 24)
+func IdempotencyTTL() time.Duration {
+	return time.Duration(envPositiveInt("IDEMPOTENCY_TTL_HOURS", 24)) * time.Hour
+}
+
+type idempotencyEntry struct {
+	record    IdempotencyRecord
+	expiresAt time.Time
+}
+
+// MemoryIdempotencyStore -> IdempotencyStore di memori proses
+type MemoryIdempotencyStore struct {
+	mu        sync.Mutex
+	entries   map[string]*idempotencyEntry
+	lastSweep time.Time
+}
+
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{entries: map[string]*idempotencyEntry{}, lastSweep: time.Now()}
+}
+
+func (s *MemoryIdempotencyStore) Reserve(key, requestHash string, ttl time.Duration) (*IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.lastSweep) > time.Minute {
+		for k, e := range s.entries {
+			if !now.Before(e.expiresAt) {
+				delete(s.entries, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	if e, ok := s.entries[key]; ok && now.Before(e.expiresAt) {
+		record := e.record
+		return &record, nil
+	}
+	s.entries[key] = &idempotencyEntry{
+		record:    IdempotencyRecord{RequestHash: requestHash},
+		expiresAt: now.Add(ttl),
+	}
+	return nil, nil
+}
+
+func (s *MemoryIdempotencyStore) Complete(key string, record IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.entries[key]; ok {
+		record.Completed = true
+		e.record = record
+	}
+	return nil
+}
+
+func (s *MemoryIdempotencyStore) Release(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+	return nil
+}
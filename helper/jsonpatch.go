@@ -0,0 +1,319 @@
+package helper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Content-Type body PATCH yang didukung
+const (
+	MergePatchContentType = "application/merge-patch+json" // RFC 7396
+	JSONPatchContentType  = "application/json-patch+json"  // RFC 6902
+)
+
+// ErrPatchTestFailed -> operasi "test" JSON Patch tidak cocok dengan dokumen saat ini
+var ErrPatchTestFailed = errors.New("operasi test JSON Patch gagal")
+
+// MergePatch -> terapkan JSON Merge Patch (RFC 7396) ke dokumen. Nilai null menghapus field,
+// object digabung rekursif, nilai lain (termasuk array) menggantikan nilai lama.
+func MergePatch(doc, patch []byte) ([]byte, error) {
+	var target any
+	if err := json.Unmarshal(doc, &target); err != nil {
+		return nil, err
+	}
+	var p any
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, fmt.Errorf("merge patch bukan JSON yang valid: %w", err)
+	}
+	return json.Marshal(mergePatch(target, p))
+}
+
+func mergePatch(target, patch any) any {
+	patchObj, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	targetObj, ok := target.(map[string]any)
+	if !ok {
+		targetObj = map[string]any{}
+	}
+	for field, value := range patchObj {
+		if value == nil {
+			delete(targetObj, field)
+			continue
+		}
+		targetObj[field] = mergePatch(targetObj[field], value)
+	}
+	return targetObj
+}
+
+// JSONPatchOperation -> satu operasi JSON Patch (RFC 6902)
+type JSONPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// ApplyJSONPatch -> terapkan JSON Patch (RFC 6902) ke dokumen. Operasi dijalankan berurutan dan
+// semuanya gagal bila satu gagal; ErrPatchTestFailed bila operasi test tidak cocok.
+func ApplyJSONPatch(doc, patch []byte) ([]byte, error) {
+	var ops []JSONPatchOperation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return nil, fmt.Errorf("JSON Patch harus berupa array operasi: %w", err)
+	}
+	var root any
+	if err := json.Unmarshal(doc, &root); err != nil {
+		return nil, err
+	}
+
+	for i, op := range ops {
+		var err error
+		if root, err = applyPatchOperation(root, op); err != nil {
+			if errors.Is(err, ErrPatchTestFailed) {
+				return nil, fmt.Errorf("%w: operasi ke-%d (%s)", ErrPatchTestFailed, i+1, op.Path)
+			}
+			return nil, fmt.Errorf("operasi ke-%d (%s %s): %w", i+1, op.Op, op.Path, err)
+		}
+	}
+	return json.Marshal(root)
+}
+
+func applyPatchOperation(root any, op JSONPatchOperation) (any, error) {
+	path, err := parseJSONPointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return nil, errors.New("value wajib diisi")
+		}
+		var value any
+		if err := json.Unmarshal(op.Value, &value); err != nil {
+			return nil, fmt.Errorf("value bukan JSON yang valid: %w", err)
+		}
+		switch op.Op {
+		case "add":
+			return pointerAdd(root, path, value)
+		case "replace":
+			return pointerReplace(root, path, value)
+		}
+		current, err := pointerGet(root, path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(current, value) {
+			return nil, ErrPatchTestFailed
+		}
+		return root, nil
+	case "remove":
+		root, _, err := pointerRemove(root, path)
+		return root, err
+	case "move", "copy":
+		from, err := parseJSONPointer(op.From)
+		if err != nil {
+			return nil, fmt.Errorf("from: %w", err)
+		}
+		if op.Op == "move" {
+			if isPointerPrefix(from, path) && len(from) < len(path) {
+				return nil, errors.New("from tidak boleh induk dari path")
+			}
+			root, value, err := pointerRemove(root, from)
+			if err != nil {
+				return nil, err
+			}
+			return pointerAdd(root, path, value)
+		}
+		value, err := pointerGet(root, from)
+		if err != nil {
+			return nil, err
+		}
+		return pointerAdd(root, path, deepCopyJSON(value))
+	}
+	return nil, fmt.Errorf("op %q tidak dikenal", op.Op)
+}
+
+// parseJSONPointer -> token JSON Pointer (RFC 6901); "" menunjuk seluruh dokumen
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("path %q harus diawali /", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func isPointerPrefix(prefix, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// arrayIndex -> indeks array dari token; allowEnd mengizinkan indeks len (dan "-") untuk add
+func arrayIndex(token string, length int, allowEnd bool) (int, error) {
+	if token == "-" && allowEnd {
+		return length, nil
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || (token != "0" && strings.HasPrefix(token, "0")) {
+		return 0, fmt.Errorf("indeks array %q tidak valid", token)
+	}
+	if i > length || (i == length && !allowEnd) {
+		return 0, fmt.Errorf("indeks array %d di luar jangkauan", i)
+	}
+	return i, nil
+}
+
+func pointerGet(node any, path []string) (any, error) {
+	for _, token := range path {
+		switch n := node.(type) {
+		case map[string]any:
+			value, ok := n[token]
+			if !ok {
+				return nil, fmt.Errorf("field %q tidak ada", token)
+			}
+			node = value
+		case []any:
+			i, err := arrayIndex(token, len(n), false)
+			if err != nil {
+				return nil, err
+			}
+			node = n[i]
+		default:
+			return nil, fmt.Errorf("%q bukan object atau array", token)
+		}
+	}
+	return node, nil
+}
+
+// updateParent -> jalankan fn pada container induk dari path dan kembalikan node yang sudah
+// diperbarui (array bisa berganti slice saat elemen ditambah/dihapus)
+func updateParent(node any, path []string, fn func(container any, key string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return fn(node, path[0])
+	}
+	switch n := node.(type) {
+	case map[string]any:
+		child, ok := n[path[0]]
+		if !ok {
+			return nil, fmt.Errorf("field %q tidak ada", path[0])
+		}
+		updated, err := updateParent(child, path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		n[path[0]] = updated
+		return n, nil
+	case []any:
+		i, err := arrayIndex(path[0], len(n), false)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := updateParent(n[i], path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		n[i] = updated
+		return n, nil
+	}
+	return nil, fmt.Errorf("%q bukan object atau array", path[0])
+}
+
+func pointerAdd(root any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return updateParent(root, path, func(container any, key string) (any, error) {
+		switch c := container.(type) {
+		case map[string]any:
+			c[key] = value
+			return c, nil
+		case []any:
+			i, err := arrayIndex(key, len(c), true)
+			if err != nil {
+				return nil, err
+			}
+			c = append(c, nil)
+			copy(c[i+1:], c[i:])
+			c[i] = value
+			return c, nil
+		}
+		return nil, fmt.Errorf("induk %q bukan object atau array", key)
+	})
+}
+
+func pointerReplace(root any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return updateParent(root, path, func(container any, key string) (any, error) {
+		switch c := container.(type) {
+		case map[string]any:
+			if _, ok := c[key]; !ok {
+				return nil, fmt.Errorf("field %q tidak ada", key)
+			}
+			c[key] = value
+			return c, nil
+		case []any:
+			i, err := arrayIndex(key, len(c), false)
+			if err != nil {
+				return nil, err
+			}
+			c[i] = value
+			return c, nil
+		}
+		return nil, fmt.Errorf("induk %q bukan object atau array", key)
+	})
+}
+
+func pointerRemove(root any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, nil, errors.New("seluruh dokumen tidak bisa dihapus")
+	}
+	var removed any
+	root, err := updateParent(root, path, func(container any, key string) (any, error) {
+		switch c := container.(type) {
+		case map[string]any:
+			value, ok := c[key]
+			if !ok {
+				return nil, fmt.Errorf("field %q tidak ada", key)
+			}
+			removed = value
+			delete(c, key)
+			return c, nil
+		case []any:
+			i, err := arrayIndex(key, len(c), false)
+			if err != nil {
+				return nil, err
+			}
+			removed = c[i]
+			return append(c[:i], c[i+1:]...), nil
+		}
+		return nil, fmt.Errorf("induk %q bukan object atau array", key)
+	})
+	return root, removed, err
+}
+
+func deepCopyJSON(value any) any {
+	raw, _ := json.Marshal(value)
+	var copied any
+	_ = json.Unmarshal(raw, &copied)
+	return copied
+}
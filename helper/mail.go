@@ -0,0 +1,161 @@
+package helper
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"strings"
+	"time"
+)
+
+// MailMessage -> email dengan versi teks dan HTML (multipart/alternative)
+type MailMessage struct {
+	From    string
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Mailer mengirim satu email
+type Mailer interface {
+	Send(msg MailMessage) error
+}
+
+// SMTPConfig -> koneksi SMTP. Username kosong berarti tanpa AUTH (mis. fake SMTP lokal)
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// ErrMailerNotConfigured dikembalikan bila SMTP_HOST belum diisi
+var ErrMailerNotConfigured = errors.New("SMTP_HOST belum dikonfigurasi")
+
+// SMTPConfigFromEnv membaca SMTP_HOST, SMTP_PORT (default 587), SMTP_USERNAME,
+// SMTP_PASSWORD, dan SMTP_FROM
+func SMTPConfigFromEnv() (SMTPConfig, error) {
+	cfg := SMTPConfig{
+		Host:     os.Getenv("SMTP_HOST"),
+		Port:     os.Getenv("SMTP_PORT"),
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     os.Getenv("SMTP_FROM"),
+	}
+	if cfg.Host == "" {
+		return cfg, ErrMailerNotConfigured
+	}
+	if cfg.Port == "" {
+		cfg.Port = "587"
+	}
+	if cfg.From == "" {
+		cfg.From = "no-reply@" + cfg.Host
+	}
+	return cfg, nil
+}
+
+// SMTPMailer -> Mailer lewat net/smtp; STARTTLS dipakai otomatis bila server mendukung
+type SMTPMailer struct {
+	Config SMTPConfig
+}
+
+func (m SMTPMailer) Send(msg MailMessage) error {
+	if msg.From == "" {
+		msg.From = m.Config.From
+	}
+	from, err := mail.ParseAddress(msg.From)
+	if err != nil {
+		return fmt.Errorf("alamat pengirim tidak valid: %v", err)
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return fmt.Errorf("alamat penerima tidak valid: %v", err)
+	}
+	body, err := BuildMIMEMessage(msg)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.Config.Username != "" {
+		auth = smtp.PlainAuth("", m.Config.Username, m.Config.Password, m.Config.Host)
+	}
+	return smtp.SendMail(net.JoinHostPort(m.Config.Host, m.Config.Port), auth, from.Address, []string{to.Address}, body)
+}
+
+// BuildMIMEMessage menyusun email RFC 5322: header ter-encode (UTF-8) dan body
+// multipart/alternative quoted-printable (teks lalu HTML)
+func BuildMIMEMessage(msg MailMessage) ([]byte, error) {
+	var buf bytes.Buffer
+	id := make([]byte, 12)
+	rand.Read(id)
+	domain := "localhost"
+	if at := strings.LastIndex(msg.From, "@"); at >= 0 {
+		domain = strings.Trim(msg.From[at+1:], "> ")
+	}
+
+	writer := multipart.NewWriter(&buf)
+	headers := []string{
+		"From: " + formatMailAddress(msg.From),
+		"To: " + formatMailAddress(msg.To),
+		"Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		fmt.Sprintf("Message-ID: <%s@%s>", hex.EncodeToString(id), domain),
+		"MIME-Version: 1.0",
+		"Content-Type: multipart/alternative; boundary=" + writer.Boundary(),
+	}
+	var out bytes.Buffer
+	out.WriteString(strings.Join(headers, "\r\n") + "\r\n\r\n")
+
+	parts := []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", msg.Text},
+		{"text/html; charset=utf-8", msg.HTML},
+	}
+	for _, p := range parts {
+		if p.body == "" {
+			continue
+		}
+		w, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {p.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(strings.ReplaceAll(p.body, "\r\n", "\n"))); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	// SMTP mensyaratkan CRLF
+	out.Write(bytes.ReplaceAll(bytes.ReplaceAll(buf.Bytes(), []byte("\r\n"), []byte("\n")), []byte("\n"), []byte("\r\n")))
+	return out.Bytes(), nil
+}
+
+// formatMailAddress -> nama pada alamat di-encode (RFC 2047) agar aman untuk karakter non-ASCII
+func formatMailAddress(s string) string {
+	addr, err := mail.ParseAddress(s)
+	if err != nil {
+		return s
+	}
+	return addr.String()
+}
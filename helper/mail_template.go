@@ -0,0 +1,132 @@
+package helper
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmlTemplate "html/template"
+	"os"
+	"strings"
+	"sync"
+	textTemplate "text/template"
+	"time"
+)
+
+//go:embed templates/email
+var emailTemplateFS embed.FS
+
+// Template email transaksional (nama file di helper/templates/email/<locale>/)
+const (
+	EmailTemplateAccountCreated = "account_created"
+	EmailTemplatePasswordReset  = "password_reset"
+	EmailTemplateFileAccepted   = "file_accepted"
+	EmailTemplateFileRejected   = "file_rejected"
+	EmailTemplateTracerReminder = "tracer_reminder"
+)
+
+// Bahasa email yang tersedia; DefaultEmailLocale dipakai bila locale tidak dikenal
+const (
+	EmailLocaleID      = "id"
+	EmailLocaleEN      = "en"
+	DefaultEmailLocale = EmailLocaleID
+)
+
+// EmailContent -> hasil render template: subject, versi teks, dan versi HTML
+type EmailContent struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+type emailTemplate struct {
+	text *textTemplate.Template
+	html *htmlTemplate.Template
+	name string
+}
+
+var emailTemplateCache sync.Map // "<locale>/<name>" -> *emailTemplate
+
+// EmailLocaleFromEnv -> EMAIL_LOCALE (id atau en), default id
+func EmailLocaleFromEnv() string {
+	return normalizeEmailLocale(os.Getenv("EMAIL_LOCALE"))
+}
+
+func normalizeEmailLocale(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if locale == EmailLocaleEN || locale == EmailLocaleID {
+		return locale
+	}
+	return DefaultEmailLocale
+}
+
+// emailAppName -> nama aplikasi di subject/isi email dari APP_NAME
+func emailAppName() string {
+	if name := os.Getenv("APP_NAME"); name != "" {
+		return name
+	}
+	return "Sistem Informasi Alumni"
+}
+
+func loadEmailTemplate(locale, name string) (*emailTemplate, error) {
+	key := locale + "/" + name
+	if cached, ok := emailTemplateCache.Load(key); ok {
+		return cached.(*emailTemplate), nil
+	}
+
+	dir := "templates/email/" + locale + "/"
+	text, err := textTemplate.New(name).Option("missingkey=error").ParseFS(emailTemplateFS, dir+"common.tmpl", dir+name+".txt")
+	if err != nil {
+		return nil, fmt.Errorf("template email %s tidak ditemukan: %v", key, err)
+	}
+	html, err := htmlTemplate.New(name).Option("missingkey=error").ParseFS(emailTemplateFS,
+		"templates/email/layout.html", dir+"common.tmpl", dir+name+".html", dir+name+".txt")
+	if err != nil {
+		return nil, fmt.Errorf("template email %s tidak valid: %v", key, err)
+	}
+
+	tmpl := &emailTemplate{text: text, html: html, name: name + ".txt"}
+	emailTemplateCache.Store(key, tmpl)
+	return tmpl, nil
+}
+
+// RenderEmail merender template email untuk locale tertentu. data wajib memuat semua field
+// yang dipakai template; AppName dan Locale ditambahkan otomatis.
+func RenderEmail(locale, name string, data map[string]interface{}) (EmailContent, error) {
+	locale = normalizeEmailLocale(locale)
+	tmpl, err := loadEmailTemplate(locale, name)
+	if err != nil {
+		return EmailContent{}, err
+	}
+
+	values := map[string]interface{}{"AppName": emailAppName(), "Locale": locale}
+	for k, v := range data {
+		values[k] = v
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := tmpl.text.ExecuteTemplate(&subject, "subject", values); err != nil {
+		return EmailContent{}, err
+	}
+	if err := tmpl.text.ExecuteTemplate(&text, tmpl.name, values); err != nil {
+		return EmailContent{}, err
+	}
+	if err := tmpl.html.ExecuteTemplate(&html, "layout", values); err != nil {
+		return EmailContent{}, err
+	}
+	return EmailContent{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+		HTML:    html.String(),
+	}, nil
+}
+
+var indonesianMonths = [...]string{"Januari", "Februari", "Maret", "April", "Mei", "Juni", "Juli",
+	"Agustus", "September", "Oktober", "November", "Desember"}
+
+// FormatEmailDate -> tanggal untuk isi email: "2 Januari 2006" (id) atau "January 2, 2006" (en)
+func FormatEmailDate(locale string, t time.Time) string {
+	if normalizeEmailLocale(locale) == EmailLocaleEN {
+		return t.Format("January 2, 2006")
+	}
+	return fmt.Sprintf("%d %s %d", t.Day(), indonesianMonths[t.Month()-1], t.Year())
+}
@@ -0,0 +1,382 @@
+package helper
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrOIDCNotConfigured dikembalikan bila OIDC_ISSUER, OIDC_CLIENT_ID, atau OIDC_REDIRECT_URL kosong
+var ErrOIDCNotConfigured = errors.New("OIDC_ISSUER, OIDC_CLIENT_ID, dan OIDC_REDIRECT_URL belum dikonfigurasi")
+
+const (
+	// OIDCStateTTL -> batas waktu antara redirect ke provider dan callback
+	OIDCStateTTL = 10 * time.Minute
+	// oidcJWKSRefreshInterval -> JWKS diambil ulang paling sering sekali per menit saat kid tidak dikenal
+	oidcJWKSRefreshInterval = time.Minute
+)
+
+// OIDCConfig -> konfigurasi provider OIDC (authorization code + PKCE)
+type OIDCConfig struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string // kosong untuk public client (cukup PKCE)
+	RedirectURL  string
+	Scopes       []string
+	NIMClaim     string // nama claim ID token yang berisi NIM
+	StateSecret  []byte // kunci HMAC cookie state
+}
+
+var (
+	oidcStateSecretOnce sync.Once
+	oidcStateSecret     []byte
+)
+
+// OIDCConfigFromEnv membaca OIDC_ISSUER, OIDC_CLIENT_ID, OIDC_CLIENT_SECRET, OIDC_REDIRECT_URL,
+// OIDC_SCOPES (default "openid email profile"), OIDC_NIM_CLAIM (default "nim"), dan
+// OIDC_STATE_SECRET. Tanpa OIDC_STATE_SECRET kunci acak per proses dipakai, sehingga
+// callback harus kembali ke instance yang sama.
+func OIDCConfigFromEnv() (OIDCConfig, error) {
+	cfg := OIDCConfig{
+		Issuer:       strings.TrimSpace(os.Getenv("OIDC_ISSUER")),
+		ClientID:     strings.TrimSpace(os.Getenv("OIDC_CLIENT_ID")),
+		ClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
+		RedirectURL:  strings.TrimSpace(os.Getenv("OIDC_REDIRECT_URL")),
+		Scopes:       strings.Fields(os.Getenv("OIDC_SCOPES")),
+		NIMClaim:     strings.TrimSpace(os.Getenv("OIDC_NIM_CLAIM")),
+	}
+	if cfg.Issuer == "" || cfg.ClientID == "" || cfg.RedirectURL == "" {
+		return cfg, ErrOIDCNotConfigured
+	}
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "email", "profile"}
+	}
+	if cfg.NIMClaim == "" {
+		cfg.NIMClaim = "nim"
+	}
+	if secret := os.Getenv("OIDC_STATE_SECRET"); secret != "" {
+		cfg.StateSecret = []byte(secret)
+	} else {
+		oidcStateSecretOnce.Do(func() {
+			oidcStateSecret = make([]byte, 32)
+			rand.Read(oidcStateSecret)
+		})
+		cfg.StateSecret = oidcStateSecret
+	}
+	return cfg, nil
+}
+
+// OIDCProviderMetadata -> bagian discovery document yang dipakai
+type OIDCProviderMetadata struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	JWKSURI               string   `json:"jwks_uri"`
+	CodeChallengeMethods  []string `json:"code_challenge_methods_supported"`
+}
+
+// OIDCProvider -> client OIDC hasil discovery. Kunci publik JWKS di-cache per kid.
+type OIDCProvider struct {
+	Config   OIDCConfig
+	Metadata OIDCProviderMetadata
+	client   *http.Client
+
+	mu            sync.Mutex
+	keys          map[string]*rsa.PublicKey
+	keysFetchedAt time.Time
+}
+
+// OIDCIdentity -> claim ID token yang sudah diverifikasi
+type OIDCIdentity struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	NIM           string
+}
+
+// DiscoverOIDCProvider mengambil <issuer>/.well-known/openid-configuration. Issuer di dokumen
+// harus sama dengan issuer yang dikonfigurasi.
+func DiscoverOIDCProvider(ctx context.Context, cfg OIDCConfig) (*OIDCProvider, error) {
+	p := &OIDCProvider{Config: cfg, client: &http.Client{Timeout: 10 * time.Second}}
+	wellKnown := strings.TrimRight(cfg.Issuer, "/") + "/.well-known/openid-configuration"
+	if err := p.getJSON(ctx, wellKnown, &p.Metadata); err != nil {
+		return nil, fmt.Errorf("discovery OIDC gagal: %v", err)
+	}
+	if strings.TrimRight(p.Metadata.Issuer, "/") != strings.TrimRight(cfg.Issuer, "/") {
+		return nil, fmt.Errorf("issuer discovery %q tidak sama dengan OIDC_ISSUER %q", p.Metadata.Issuer, cfg.Issuer)
+	}
+	if p.Metadata.AuthorizationEndpoint == "" || p.Metadata.TokenEndpoint == "" || p.Metadata.JWKSURI == "" {
+		return nil, errors.New("discovery OIDC tidak lengkap (authorization_endpoint, token_endpoint, jwks_uri)")
+	}
+	if len(p.Metadata.CodeChallengeMethods) > 0 && !containsString(p.Metadata.CodeChallengeMethods, "S256") {
+		return nil, errors.New("provider OIDC tidak mendukung PKCE S256")
+	}
+	return p, nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *OIDCProvider) getJSON(ctx context.Context, u string, dst interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: status %d", u, resp.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(dst)
+}
+
+// OIDCAuthState -> data yang harus sama antara redirect login dan callback
+type OIDCAuthState struct {
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+	jwt.RegisteredClaims
+}
+
+func randomURLToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// NewOIDCAuthState -> state, nonce, dan PKCE code verifier acak
+func NewOIDCAuthState() (*OIDCAuthState, error) {
+	s := &OIDCAuthState{}
+	var err error
+	if s.State, err = randomURLToken(); err != nil {
+		return nil, err
+	}
+	if s.Nonce, err = randomURLToken(); err != nil {
+		return nil, err
+	}
+	if s.Verifier, err = randomURLToken(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// PKCEChallenge -> code_challenge S256 dari code verifier (RFC 7636)
+func PKCEChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// SignOIDCState -> state ditandatangani HMAC untuk disimpan di cookie selama OIDCStateTTL
+func SignOIDCState(secret []byte, s *OIDCAuthState) (string, error) {
+	claims := *s
+	claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(OIDCStateTTL))
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+}
+
+// ParseOIDCState -> kebalikan SignOIDCState; gagal bila tanda tangan salah atau kedaluwarsa
+func ParseOIDCState(secret []byte, raw string) (*OIDCAuthState, error) {
+	var s OIDCAuthState
+	_, err := jwt.ParseWithClaims(raw, &s, func(*jwt.Token) (interface{}, error) {
+		return secret, nil
+	}, jwt.WithValidMethods([]string{"HS256"}), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// AuthCodeURL -> URL authorization endpoint untuk redirect browser. loginHint (boleh kosong)
+// diteruskan sebagai login_hint
+func (p *OIDCProvider) AuthCodeURL(s *OIDCAuthState, loginHint string) string {
+	v := url.Values{}
+	v.Set("response_type", "code")
+	v.Set("client_id", p.Config.ClientID)
+	v.Set("redirect_uri", p.Config.RedirectURL)
+	v.Set("scope", strings.Join(p.Config.Scopes, " "))
+	v.Set("state", s.State)
+	v.Set("nonce", s.Nonce)
+	v.Set("code_challenge", PKCEChallenge(s.Verifier))
+	v.Set("code_challenge_method", "S256")
+	if loginHint != "" {
+		v.Set("login_hint", loginHint)
+	}
+
+	sep := "?"
+	if strings.Contains(p.Metadata.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return p.Metadata.AuthorizationEndpoint + sep + v.Encode()
+}
+
+// Exchange menukar authorization code (beserta code verifier) dengan ID token
+func (p *OIDCProvider) Exchange(ctx context.Context, code, verifier string) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.Config.RedirectURL)
+	form.Set("client_id", p.Config.ClientID)
+	form.Set("code_verifier", verifier)
+	if p.Config.ClientSecret != "" {
+		form.Set("client_secret", p.Config.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.Metadata.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body); err != nil {
+		return "", fmt.Errorf("respons token endpoint tidak valid (status %d)", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK || body.Error != "" {
+		return "", fmt.Errorf("token endpoint menolak code: %s %s", body.Error, body.ErrorDescription)
+	}
+	if body.IDToken == "" {
+		return "", errors.New("token endpoint tidak mengembalikan id_token")
+	}
+	return body.IDToken, nil
+}
+
+// VerifyIDToken memvalidasi tanda tangan (RS256, JWKS), iss, aud, azp, exp, dan nonce
+func (p *OIDCProvider) VerifyIDToken(ctx context.Context, raw, nonce string) (*OIDCIdentity, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return p.publicKey(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256"}),
+		jwt.WithIssuer(p.Metadata.Issuer),
+		jwt.WithAudience(p.Config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ID token tidak valid: %v", err)
+	}
+	if got, _ := claims["nonce"].(string); got == "" || got != nonce {
+		return nil, errors.New("ID token tidak valid: nonce tidak cocok")
+	}
+	if azp, ok := claims["azp"].(string); ok && azp != p.Config.ClientID {
+		return nil, errors.New("ID token tidak valid: azp bukan client ini")
+	}
+
+	id := &OIDCIdentity{Issuer: p.Metadata.Issuer}
+	id.Subject, _ = claims["sub"].(string)
+	if id.Subject == "" {
+		return nil, errors.New("ID token tidak valid: sub kosong")
+	}
+	id.Email, _ = claims["email"].(string)
+	id.Name, _ = claims["name"].(string)
+	switch v := claims["email_verified"].(type) {
+	case bool:
+		id.EmailVerified = v
+	case string:
+		id.EmailVerified = v == "true"
+	}
+	switch v := claims[p.Config.NIMClaim].(type) {
+	case string:
+		id.NIM = strings.TrimSpace(v)
+	case float64:
+		id.NIM = fmt.Sprintf("%.0f", v)
+	}
+	return id, nil
+}
+
+// publicKey -> kunci RSA untuk kid. JWKS diambil ulang bila kid belum dikenal (rotasi kunci).
+// Token tanpa kid diterima bila JWKS hanya berisi satu kunci.
+func (p *OIDCProvider) publicKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key := p.lookupKey(kid); key != nil {
+		return key, nil
+	}
+	if time.Since(p.keysFetchedAt) < oidcJWKSRefreshInterval {
+		return nil, fmt.Errorf("kunci %q tidak ada di JWKS", kid)
+	}
+
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := p.getJSON(ctx, p.Metadata.JWKSURI, &set); err != nil {
+		return nil, fmt.Errorf("gagal mengambil JWKS: %v", err)
+	}
+	keys := map[string]*rsa.PublicKey{}
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		n, errN := base64.RawURLEncoding.DecodeString(k.N)
+		e, errE := base64.RawURLEncoding.DecodeString(k.E)
+		if errN != nil || errE != nil || len(e) == 0 {
+			continue
+		}
+		keys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+	p.keys = keys
+	p.keysFetchedAt = time.Now()
+
+	if key := p.lookupKey(kid); key != nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("kunci %q tidak ada di JWKS", kid)
+}
+
+func (p *OIDCProvider) lookupKey(kid string) *rsa.PublicKey {
+	if key, ok := p.keys[kid]; ok {
+		return key
+	}
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key
+		}
+	}
+	return nil
+}
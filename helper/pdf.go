@@ -0,0 +1,235 @@
+package helper
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Layout laporan PDF: A4 landscape, satuan point
+const (
+	pdfPageWidth  = 842.0
+	pdfPageHeight = 595.0
+	pdfMargin     = 36.0
+	pdfFontSize   = 8.0
+	pdfLineHeight = 13.0
+	// Lebar rata-rata karakter Helvetica relatif terhadap ukuran font, untuk memotong teks
+	pdfCharWidth = 0.52
+)
+
+// pdfExportWriter menulis laporan tabel PDF 1.4 dengan font standar Helvetica (tanpa embed font).
+// Setiap halaman langsung ditulis begitu penuh; objek Pages dan xref ditulis saat Close
+// sehingga jumlah baris tidak perlu diketahui di awal.
+type pdfExportWriter struct {
+	w       *bufio.Writer
+	offset  int
+	objects []int // offset byte tiap objek, index = nomor objek - 1
+
+	title     string
+	generated string
+	columns   []ExportColumn
+	widths    []float64
+
+	pages   []int
+	content bytes.Buffer
+	y       float64
+	rows    int
+	werr    error
+}
+
+// Objek tetap: 1 Catalog, 2 Pages (ditulis terakhir), 3 Helvetica, 4 Helvetica-Bold
+const pdfPagesObject = 2
+
+func newPDFExportWriter(buf *bufio.Writer, title string, columns []ExportColumn) (*pdfExportWriter, error) {
+	ew := &pdfExportWriter{
+		w:         buf,
+		objects:   make([]int, 4),
+		title:     title,
+		generated: time.Now().Format("02-01-2006 15:04"),
+		columns:   columns,
+	}
+
+	total := 0.0
+	for _, col := range columns {
+		total += pdfColumnWeight(col)
+	}
+	available := pdfPageWidth - 2*pdfMargin
+	for _, col := range columns {
+		ew.widths = append(ew.widths, available*pdfColumnWeight(col)/total)
+	}
+
+	ew.write("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	ew.writeObject(1, "<< /Type /Catalog /Pages 2 0 R >>")
+	ew.writeObject(3, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	ew.writeObject(4, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	ew.startPage()
+	return ew, ew.err()
+}
+
+func pdfColumnWeight(col ExportColumn) float64 {
+	if col.Width <= 0 {
+		return 1
+	}
+	return col.Width
+}
+
+func (ew *pdfExportWriter) WriteRow(values []interface{}) error {
+	if ew.y < pdfMargin+pdfLineHeight {
+		if err := ew.finishPage(); err != nil {
+			return err
+		}
+		ew.startPage()
+	}
+
+	cells := make([]string, len(ew.columns))
+	for i := range cells {
+		if i < len(values) {
+			cells[i] = FormatExportValue(values[i])
+		}
+	}
+	if ew.rows%2 == 1 {
+		fmt.Fprintf(&ew.content, "0.95 g %.2f %.2f %.2f %.2f re f 0 g\n", pdfMargin, ew.y-3, pdfPageWidth-2*pdfMargin, pdfLineHeight)
+	}
+	ew.tableRow(cells, "F1")
+	ew.rows++
+	return ew.err()
+}
+
+func (ew *pdfExportWriter) Close() error {
+	if ew.rows == 0 {
+		ew.text(pdfMargin+4, ew.y, "F1", pdfFontSize, "Tidak ada data")
+	}
+	if err := ew.finishPage(); err != nil {
+		return err
+	}
+
+	kids := make([]string, len(ew.pages))
+	for i, p := range ew.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", p)
+	}
+	ew.writeObject(pdfPagesObject, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(ew.pages)))
+
+	xref := ew.offset
+	ew.write(fmt.Sprintf("xref\n0 %d\n0000000000 65535 f \n", len(ew.objects)+1))
+	for _, off := range ew.objects {
+		ew.write(fmt.Sprintf("%010d 00000 n \n", off))
+	}
+	ew.write(fmt.Sprintf("trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(ew.objects)+1, xref))
+	if err := ew.err(); err != nil {
+		return err
+	}
+	return ew.w.Flush()
+}
+
+// startPage -> judul, tanggal cetak, dan header tabel di setiap halaman
+func (ew *pdfExportWriter) startPage() {
+	ew.content.Reset()
+	ew.y = pdfPageHeight - pdfMargin - 14
+	ew.text(pdfMargin, ew.y, "F2", 14, ew.title)
+	ew.y -= 16
+	ew.text(pdfMargin, ew.y, "F1", pdfFontSize, "Dibuat "+ew.generated)
+	ew.y -= 22
+
+	headers := make([]string, len(ew.columns))
+	for i, col := range ew.columns {
+		headers[i] = col.Label
+		if headers[i] == "" {
+			headers[i] = col.Key
+		}
+	}
+	fmt.Fprintf(&ew.content, "0.85 g %.2f %.2f %.2f %.2f re f 0 g\n", pdfMargin, ew.y-4, pdfPageWidth-2*pdfMargin, pdfLineHeight+2)
+	ew.tableRow(headers, "F2")
+	ew.y -= 2
+}
+
+// finishPage -> nomor halaman, lalu tulis content stream (zlib) dan objek Page
+func (ew *pdfExportWriter) finishPage() error {
+	pageNo := len(ew.pages) + 1
+	ew.text(pdfPageWidth-pdfMargin-50, pdfMargin/2, "F1", pdfFontSize, fmt.Sprintf("Halaman %d", pageNo))
+
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	zw.Write(ew.content.Bytes())
+	zw.Close()
+
+	contentObj := len(ew.objects) + 1
+	ew.writeObject(contentObj, fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", compressed.Len(), compressed.String()))
+
+	pageObj := len(ew.objects) + 1
+	ew.writeObject(pageObj, fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %g %g] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+		pdfPagesObject, pdfPageWidth, pdfPageHeight, contentObj))
+	ew.pages = append(ew.pages, pageObj)
+	return ew.err()
+}
+
+func (ew *pdfExportWriter) tableRow(cells []string, font string) {
+	x := pdfMargin
+	for i, cell := range cells {
+		ew.text(x+2, ew.y, font, pdfFontSize, pdfFit(cell, ew.widths[i]-4))
+		x += ew.widths[i]
+	}
+	ew.y -= pdfLineHeight
+}
+
+func (ew *pdfExportWriter) text(x, y float64, font string, size float64, s string) {
+	fmt.Fprintf(&ew.content, "BT /%s %g Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, pdfEscape(s))
+}
+
+// writeObject -> catat offset objek untuk xref lalu tulis isinya
+func (ew *pdfExportWriter) writeObject(num int, body string) {
+	for len(ew.objects) < num {
+		ew.objects = append(ew.objects, 0)
+	}
+	ew.objects[num-1] = ew.offset
+	ew.write(fmt.Sprintf("%d 0 obj\n%s\nendobj\n", num, body))
+}
+
+func (ew *pdfExportWriter) write(s string) {
+	if ew.werr != nil {
+		return
+	}
+	n, err := ew.w.WriteString(s)
+	ew.offset += n
+	ew.werr = err
+}
+
+// err -> error tulis pertama; setelah gagal, penulisan berikutnya diabaikan
+func (ew *pdfExportWriter) err() error {
+	return ew.werr
+}
+
+// pdfFit -> potong teks dengan "..." agar muat di lebar kolom
+func pdfFit(s string, width float64) string {
+	s = strings.Join(strings.Fields(s), " ")
+	limit := int(width / (pdfFontSize * pdfCharWidth))
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	if limit <= 3 {
+		return string(runes[:max(limit, 0)])
+	}
+	return string(runes[:limit-3]) + "..."
+}
+
+// pdfEscape -> string literal PDF dengan WinAnsiEncoding; karakter di luar Latin-1 menjadi '?'
+func pdfEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(byte(r))
+		case r >= 32 && r < 127:
+			b.WriteByte(byte(r))
+		case r >= 0xA0 && r <= 0xFF:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,130 @@
+package helper
+
+import (
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimitStore -> penyimpanan counter fixed-window per key. MemoryRateLimitStore cukup untuk
+// satu instance; beberapa instance harus berbagi store di database.
+type RateLimitStore interface {
+	// Hit menambah counter key dan mengembalikan jumlah hit pada window berjalan
+	// beserta sisa waktu sampai window di-reset
+	Hit(key string, window time.Duration) (int, time.Duration, error)
+	// Reset menghapus counter key
+	Reset(key string) error
+}
+
+type rateLimitEntry struct {
+	count     int
+	expiresAt time.Time
+}
+
+// MemoryRateLimitStore -> RateLimitStore di memori proses
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	entries   map[string]*rateLimitEntry
+	lastSweep time.Time
+}
+
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{entries: map[string]*rateLimitEntry{}, lastSweep: time.Now()}
+}
+
+func (s *MemoryRateLimitStore) Hit(key string, window time.Duration) (int, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	// Bersihkan entry kedaluwarsa paling sering sekali per menit
+	if now.Sub(s.lastSweep) > time.Minute {
+		for k, e := range s.entries {
+			if !now.Before(e.expiresAt) {
+				delete(s.entries, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	e, ok := s.entries[key]
+	if !ok || !now.Before(e.expiresAt) {
+		e = &rateLimitEntry{expiresAt: now.Add(window)}
+		s.entries[key] = e
+	}
+	e.count++
+	return e.count, e.expiresAt.Sub(now), nil
+}
+
+func (s *MemoryRateLimitStore) Reset(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+	return nil
+}
+
+// LoginProtection -> batas percobaan login. Counter per IP dan per akun memakai fixed window;
+// gagal berturut-turut pada satu akun memicu jeda progresif lalu kunci sementara.
+type LoginProtection struct {
+	Store        string // "memory" (default) atau "database" untuk deployment multi-instance
+	IPLimit      int
+	AccountLimit int
+	Window       time.Duration
+	MaxFailures  int
+	Lockout      time.Duration
+	DelayBase    time.Duration
+	DelayMax     time.Duration
+}
+
+// Nilai RATE_LIMIT_STORE
+const (
+	RateLimitStoreMemory   = "memory"
+	RateLimitStoreDatabase = "database"
+)
+
+// LoginProtectionFromEnv membaca RATE_LIMIT_STORE, LOGIN_IP_LIMIT (default 20),
+// LOGIN_ACCOUNT_LIMIT (default 10), LOGIN_RATE_WINDOW_MINUTES (default 15),
+// LOGIN_MAX_FAILURES (default 5), dan LOGIN_LOCKOUT_MINUTES (default 15)
+func LoginProtectionFromEnv() LoginProtection {
+	store := strings.ToLower(strings.TrimSpace(os.Getenv("RATE_LIMIT_STORE")))
+	if store != RateLimitStoreDatabase {
+		store = RateLimitStoreMemory
+	}
+	return LoginProtection{
+		Store:        store,
+		IPLimit:      envPositiveInt("LOGIN_IP_LIMIT", 20),
+		AccountLimit: envPositiveInt("LOGIN_ACCOUNT_LIMIT", 10),
+		Window:       time.Duration(envPositiveInt("LOGIN_RATE_WINDOW_MINUTES", 15)) * time.Minute,
+		MaxFailures:  envPositiveInt("LOGIN_MAX_FAILURES", 5),
+		Lockout:      time.Duration(envPositiveInt("LOGIN_LOCKOUT_MINUTES", 15)) * time.Minute,
+		DelayBase:    time.Second,
+		DelayMax:     30 * time.Second,
+	}
+}
+
+func envPositiveInt(name string, def int) int {
+	if n, err := strconv.Atoi(os.Getenv(name)); err == nil && n > 0 {
+		return n
+	}
+	return def
+}
+
+// FailureDelay -> jeda minimal setelah gagal login ke-failures (1s, 2s, 4s, ... maks DelayMax)
+func (p LoginProtection) FailureDelay(failures int) time.Duration {
+	if failures <= 0 {
+		return 0
+	}
+	return Backoff(failures, p.DelayBase, p.DelayMax)
+}
+
+// RetryAfterSeconds -> nilai header Retry-After (dibulatkan ke atas, minimal 1 detik)
+func RetryAfterSeconds(d time.Duration) string {
+	secs := int(math.Ceil(d.Seconds()))
+	if secs < 1 {
+		secs = 1
+	}
+	return strconv.Itoa(secs)
+}
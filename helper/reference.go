@@ -0,0 +1,178 @@
+package helper
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ReferenceKind -> jenis data referensi pekerjaan (perusahaan / bidang industri) beserta kolom
+// pekerjaan_alumni yang terhubung dengannya
+type ReferenceKind struct {
+	Name        string // nama collection / tabel, sekaligus segmen path endpoint
+	IDField     string // kolom ID referensi di pekerjaan_alumni
+	TextField   string // kolom teks bebas di pekerjaan_alumni
+	Label       string // dipakai di pesan error
+	AuditEntity string
+}
+
+var (
+	ReferenceCompanies  = ReferenceKind{Name: "companies", IDField: "company_id", TextField: "nama_perusahaan", Label: "Perusahaan", AuditEntity: AuditEntityCompany}
+	ReferenceIndustries = ReferenceKind{Name: "industries", IDField: "industry_id", TextField: "bidang_industri", Label: "Bidang industri", AuditEntity: AuditEntityIndustry}
+)
+
+// ReferenceKindByName -> ReferenceKind untuk nama collection / tabel; false bila tidak dikenal
+func ReferenceKindByName(name string) (ReferenceKind, bool) {
+	for _, kind := range []ReferenceKind{ReferenceCompanies, ReferenceIndustries} {
+		if kind.Name == name {
+			return kind, true
+		}
+	}
+	return ReferenceKind{}, false
+}
+
+// referenceNoiseWords -> bentuk badan usaha yang diabaikan saat mencocokkan nama perusahaan
+var referenceNoiseWords = map[string]bool{
+	"pt": true, "tbk": true, "persero": true, "cv": true, "ud": true,
+	"inc": true, "ltd": true, "llc": true, "corp": true, "co": true,
+}
+
+// NormalizeReferenceName -> kunci pencocokan nama: huruf kecil, tanpa tanda baca dan bentuk badan
+// usaha, spasi dirapikan. "PT. Telkom Indonesia (Persero) Tbk" -> "telkom indonesia"
+func NormalizeReferenceName(s string) string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	kept := words[:0]
+	for _, w := range words {
+		if !referenceNoiseWords[w] {
+			kept = append(kept, w)
+		}
+	}
+	if len(kept) == 0 {
+		// Nama yang seluruhnya bentuk badan usaha tetap punya kunci
+		return strings.Join(words, " ")
+	}
+	return strings.Join(kept, " ")
+}
+
+// ReferenceKeys -> kunci unik dari nama dan alias; kunci kosong dilewati
+func ReferenceKeys(nama string, aliases []string) []string {
+	keys := []string{}
+	seen := map[string]bool{}
+	for _, s := range append([]string{nama}, aliases...) {
+		if key := NormalizeReferenceName(s); key != "" && !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// CleanReferenceAliases -> alias tanpa spasi berlebih; alias kosong atau yang kuncinya sama dengan
+// nama / alias sebelumnya dibuang
+func CleanReferenceAliases(nama string, aliases []string) []string {
+	cleaned := []string{}
+	seen := map[string]bool{NormalizeReferenceName(nama): true}
+	for _, alias := range aliases {
+		alias = strings.Join(strings.Fields(alias), " ")
+		if key := NormalizeReferenceName(alias); key != "" && !seen[key] {
+			seen[key] = true
+			cleaned = append(cleaned, alias)
+		}
+	}
+	return cleaned
+}
+
+// referenceMinScore -> skor minimal agar kandidat muncul di autocomplete
+const referenceMinScore = 0.2
+
+// ReferenceMatchScore -> kemiripan query dengan satu nama (0..1). Sama persis 1, awalan nama 0.9,
+// awalan kata 0.8, bagian nama 0.7; selain itu kemiripan trigram per kata (maks 0.6) agar salah
+// ketik tetap ditemukan.
+func ReferenceMatchScore(query, name string) float64 {
+	q, n := NormalizeReferenceName(query), NormalizeReferenceName(name)
+	switch {
+	case q == "" || n == "":
+		return 0
+	case q == n:
+		return 1
+	case strings.HasPrefix(n, q):
+		return 0.9
+	case strings.Contains(" "+n, " "+q):
+		return 0.8
+	case strings.Contains(n, q):
+		return 0.7
+	}
+	return 0.6 * wordSimilarity(q, n)
+}
+
+// ReferenceMatch -> kandidat autocomplete: indeks pada candidates, skor, dan nama/alias yang cocok
+type ReferenceMatch struct {
+	Index   int
+	Score   float64
+	Matched string
+}
+
+// RankReferences -> kandidat (nama dan alias per kandidat) yang mirip query, skor tertinggi lebih
+// dulu; paling banyak limit hasil
+func RankReferences(query string, candidates [][]string, limit int) []ReferenceMatch {
+	matches := []ReferenceMatch{}
+	for i, names := range candidates {
+		best := ReferenceMatch{Index: i}
+		for _, name := range names {
+			if score := ReferenceMatchScore(query, name); score > best.Score {
+				best.Score, best.Matched = score, name
+			}
+		}
+		if best.Score >= referenceMinScore {
+			matches = append(matches, best)
+		}
+	}
+	sort.SliceStable(matches, func(a, b int) bool {
+		return matches[a].Score > matches[b].Score
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// wordSimilarity -> kemiripan trigram q dengan potongan kata berurutan di n yang paling mirip
+// (seperti word_similarity pg_trgm), agar "telkon" tetap dekat dengan "telkom indonesia"
+func wordSimilarity(q, n string) float64 {
+	best := trigramSimilarity(q, n)
+	words, size := strings.Fields(n), len(strings.Fields(q))
+	for i := 0; i+size <= len(words); i++ {
+		if sim := trigramSimilarity(q, strings.Join(words[i:i+size], " ")); sim > best {
+			best = sim
+		}
+	}
+	return best
+}
+
+// trigramSimilarity -> jumlah trigram yang sama dibagi gabungan trigram (seperti pg_trgm)
+func trigramSimilarity(a, b string) float64 {
+	ta, tb := trigrams(a), trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	shared := 0
+	for t := range ta {
+		if tb[t] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(ta)+len(tb)-shared)
+}
+
+func trigrams(s string) map[string]bool {
+	set := map[string]bool{}
+	for _, word := range strings.Fields(s) {
+		r := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(r); i++ {
+			set[string(r[i:i+3])] = true
+		}
+	}
+	return set
+}
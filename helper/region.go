@@ -0,0 +1,354 @@
+package helper
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+//go:embed data/wilayah.json
+var regionFS embed.FS
+
+// DefaultCountry -> kode negara (ISO 3166-1 alpha-2) untuk wilayah dari dataset
+const DefaultCountry = "ID"
+
+// Batas filter radius (km) dan jari-jari bumi untuk perhitungan jarak
+const (
+	MaxGeoRadiusKm = 1000
+	EarthRadiusKm  = 6371.0
+)
+
+// Province -> provinsi pada dataset wilayah beserta kota / kabupaten utamanya. Lat / Lng adalah
+// koordinat ibu kota provinsi.
+type Province struct {
+	Kode  string   `json:"kode"`
+	Nama  string   `json:"nama"`
+	Alias []string `json:"alias,omitempty"`
+	Lat   float64  `json:"lat"`
+	Lng   float64  `json:"lng"`
+	Kota  []City   `json:"kota,omitempty"`
+}
+
+// City -> kota / kabupaten; Nama selalu diawali "Kota" atau "Kabupaten"
+type City struct {
+	Nama  string   `json:"nama"`
+	Alias []string `json:"alias,omitempty"`
+	Lat   float64  `json:"lat"`
+	Lng   float64  `json:"lng"`
+}
+
+// Location -> lokasi kerja terstruktur; nil berarti tidak diketahui. Koordinat selalu berpasangan.
+type Location struct {
+	Negara    *string
+	Provinsi  *string
+	Kota      *string
+	Latitude  *float64
+	Longitude *float64
+}
+
+// IsZero -> true bila tidak ada satu pun field lokasi yang terisi
+func (l Location) IsZero() bool {
+	return l.Negara == nil && l.Provinsi == nil && l.Kota == nil && l.Latitude == nil && l.Longitude == nil
+}
+
+// GeoRadius -> nilai filter[field][near]=lat,lng,radius_km
+type GeoRadius struct {
+	Lat      float64
+	Lng      float64
+	RadiusKm float64
+}
+
+// BoundingBox -> kotak lat / lng yang memuat seluruh lingkaran radius, untuk menyaring kandidat
+// lewat index sebelum jarak sebenarnya dihitung
+func (g GeoRadius) BoundingBox() (minLat, maxLat, minLng, maxLng float64) {
+	dLat := g.RadiusKm / EarthRadiusKm * 180 / math.Pi
+	minLat, maxLat = math.Max(g.Lat-dLat, -90), math.Min(g.Lat+dLat, 90)
+	cos := math.Cos(g.Lat * math.Pi / 180)
+	if maxLat >= 90 || minLat <= -90 || cos < 1e-6 {
+		return minLat, maxLat, -180, 180
+	}
+	dLng := dLat / cos
+	if g.Lng-dLng < -180 || g.Lng+dLng > 180 {
+		// Lingkaran melewati garis tanggal internasional; longitude tidak dibatasi
+		return minLat, maxLat, -180, 180
+	}
+	return minLat, maxLat, g.Lng - dLng, g.Lng + dLng
+}
+
+type regionIndex struct {
+	provinces []Province
+	province  map[string]int      // kunci nama / alias / kode -> index provinsi
+	city      map[string][2]int   // kunci nama / alias kota -> index provinsi, index kota
+	cityIn    map[string][][2]int // kunci nama kota tanpa "kota" / "kabupaten" -> semua kandidat
+}
+
+var (
+	regionOnce sync.Once
+	regions    *regionIndex
+	regionErr  error
+)
+
+var countryCode = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// countryAliases -> nama negara yang sering ditulis di lokasi_kerja
+var countryAliases = map[string]string{
+	"indonesia": "ID", "ri": "ID",
+	"singapore": "SG", "singapura": "SG",
+	"malaysia": "MY", "japan": "JP", "jepang": "JP",
+	"australia": "AU", "united states": "US", "usa": "US", "amerika serikat": "US",
+	"netherlands": "NL", "belanda": "NL", "germany": "DE", "jerman": "DE",
+}
+
+func loadRegions() (*regionIndex, error) {
+	regionOnce.Do(func() {
+		data, err := regionFS.ReadFile("data/wilayah.json")
+		if err != nil {
+			regionErr = err
+			return
+		}
+		idx := &regionIndex{province: map[string]int{}, city: map[string][2]int{}, cityIn: map[string][][2]int{}}
+		if err := json.Unmarshal(data, &idx.provinces); err != nil {
+			regionErr = fmt.Errorf("dataset wilayah tidak valid: %v", err)
+			return
+		}
+		for pi, p := range idx.provinces {
+			for _, key := range append([]string{p.Kode, p.Nama}, p.Alias...) {
+				idx.province[regionKey(key)] = pi
+			}
+			for ci, c := range p.Kota {
+				ref := [2]int{pi, ci}
+				idx.city[regionKey(c.Nama)] = ref
+				for _, alias := range c.Alias {
+					idx.city[regionKey(alias)] = ref
+				}
+				bare := cityBareName(c.Nama)
+				idx.cityIn[bare] = append(idx.cityIn[bare], ref)
+			}
+		}
+		regions = idx
+	})
+	return regions, regionErr
+}
+
+// regionKey -> kunci pencocokan: huruf kecil, tanpa tanda baca dan awalan "provinsi" / "prov"
+func regionKey(s string) string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) > 1 && (words[0] == "provinsi" || words[0] == "prov") {
+		words = words[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// cityBareName -> nama kota tanpa awalan "Kota" / "Kabupaten" / "Kab."
+func cityBareName(s string) string {
+	key := regionKey(s)
+	for _, prefix := range []string{"kota ", "kabupaten ", "kab "} {
+		if strings.HasPrefix(key, prefix) {
+			return strings.TrimPrefix(key, prefix)
+		}
+	}
+	return key
+}
+
+// Provinces -> seluruh provinsi pada dataset, urut kode
+func Provinces() ([]Province, error) {
+	idx, err := loadRegions()
+	if err != nil {
+		return nil, err
+	}
+	return idx.provinces, nil
+}
+
+// FindProvince -> provinsi berdasarkan kode, nama, atau alias ("Jabar", "Provinsi Jawa Barat", "32")
+func FindProvince(name string) (Province, bool) {
+	idx, err := loadRegions()
+	if err != nil {
+		return Province{}, false
+	}
+	pi, ok := idx.province[regionKey(name)]
+	if !ok {
+		return Province{}, false
+	}
+	return idx.provinces[pi], true
+}
+
+// FindCity -> kota / kabupaten berdasarkan nama atau alias. province (boleh kosong) membatasi
+// pencarian ke satu provinsi. Nama tanpa awalan ("Bandung") mendahulukan kota daripada kabupaten.
+func FindCity(name, province string) (City, Province, bool) {
+	idx, err := loadRegions()
+	if err != nil {
+		return City{}, Province{}, false
+	}
+	kode := ""
+	if province != "" {
+		p, ok := FindProvince(province)
+		if !ok {
+			return City{}, Province{}, false
+		}
+		kode = p.Kode
+	}
+	accept := func(ref [2]int) bool { return kode == "" || idx.provinces[ref[0]].Kode == kode }
+	result := func(ref [2]int) (City, Province, bool) {
+		return idx.provinces[ref[0]].Kota[ref[1]], idx.provinces[ref[0]], true
+	}
+
+	key := regionKey(name)
+	if strings.HasPrefix(key, "kab ") {
+		key = "kabupaten " + strings.TrimPrefix(key, "kab ")
+	}
+	if ref, ok := idx.city[key]; ok && accept(ref) {
+		return result(ref)
+	}
+	bare := cityBareName(key)
+	if bare != key {
+		// "Kota X" / "Kabupaten X" yang ditulis lengkap tidak ditukar dengan jenis lainnya
+		return City{}, Province{}, false
+	}
+	var candidates [][2]int
+	for _, ref := range idx.cityIn[bare] {
+		if accept(ref) {
+			candidates = append(candidates, ref)
+		}
+	}
+	for _, ref := range candidates {
+		if strings.HasPrefix(idx.provinces[ref[0]].Kota[ref[1]].Nama, "Kota ") {
+			return result(ref)
+		}
+	}
+	if len(candidates) > 0 {
+		return result(candidates[0])
+	}
+	return City{}, Province{}, false
+}
+
+// NormalizeCountry -> kode negara dua huruf dari kode atau nama negara yang dikenal
+func NormalizeCountry(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", nil
+	}
+	if code, ok := countryAliases[regionKey(s)]; ok {
+		return code, nil
+	}
+	code := strings.ToUpper(s)
+	if !countryCode.MatchString(code) {
+		return "", fmt.Errorf("negara harus kode ISO dua huruf, mis. ID atau SG")
+	}
+	return code, nil
+}
+
+// ResolveLocation -> lokasi terstruktur dari negara / provinsi / kota / koordinat. Wilayah di
+// Indonesia dicocokkan ke dataset dan disimpan dengan nama bakunya; koordinat kosong diisi titik
+// tengah kota. Bila tidak ada field terstruktur sama sekali, lokasi ditebak dari teks lokasi_kerja.
+func ResolveLocation(lokasiKerja, negara, provinsi, kota string, lat, lng *float64) (Location, error) {
+	provinsi, kota = strings.TrimSpace(provinsi), strings.TrimSpace(kota)
+	if strings.TrimSpace(negara) == "" && provinsi == "" && kota == "" && lat == nil && lng == nil {
+		loc, _ := GuessLocation(lokasiKerja)
+		return loc, nil
+	}
+
+	if (lat == nil) != (lng == nil) {
+		return Location{}, errors.New("latitude dan longitude harus diisi bersamaan")
+	}
+	if lat != nil && (*lat < -90 || *lat > 90 || *lng < -180 || *lng > 180 || math.IsNaN(*lat) || math.IsNaN(*lng)) {
+		return Location{}, errors.New("latitude harus -90..90 dan longitude -180..180")
+	}
+
+	code, err := NormalizeCountry(negara)
+	if err != nil {
+		return Location{}, err
+	}
+	if code == "" {
+		code = DefaultCountry
+	}
+	loc := Location{Negara: &code, Latitude: lat, Longitude: lng}
+
+	if code != DefaultCountry {
+		// Wilayah luar negeri tidak ada di dataset, disimpan apa adanya
+		if provinsi != "" {
+			loc.Provinsi = &provinsi
+		}
+		if kota != "" {
+			loc.Kota = &kota
+		}
+		return loc, nil
+	}
+
+	if provinsi != "" {
+		p, ok := FindProvince(provinsi)
+		if !ok {
+			return Location{}, fmt.Errorf("provinsi %q tidak dikenal, lihat GET /wilayah", provinsi)
+		}
+		loc.Provinsi = &p.Nama
+		provinsi = p.Kode
+	}
+	if kota != "" {
+		c, p, ok := FindCity(kota, provinsi)
+		if !ok {
+			if provinsi != "" {
+				return Location{}, fmt.Errorf("kota %q tidak ada di provinsi %s, lihat GET /wilayah/%s", kota, *loc.Provinsi, provinsi)
+			}
+			return Location{}, fmt.Errorf("kota %q tidak dikenal; isi provinsi saja bila kota belum ada di dataset", kota)
+		}
+		loc.Provinsi, loc.Kota = &p.Nama, &c.Nama
+		if loc.Latitude == nil {
+			cLat, cLng := c.Lat, c.Lng
+			loc.Latitude, loc.Longitude = &cLat, &cLng
+		}
+	}
+	return loc, nil
+}
+
+// GuessLocation -> tebak lokasi dari teks bebas lokasi_kerja secara best-effort: "Bandung",
+// "Jakarta Selatan, DKI Jakarta", "Surabaya - Jawa Timur", "Singapore". Bagian teks dicocokkan
+// sebagai kota (dibatasi provinsi bila ada di teks), lalu provinsi, lalu negara; false bila tidak
+// ada yang dikenali.
+func GuessLocation(text string) (Location, bool) {
+	parts := strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == '/' || r == '-' || r == '(' || r == ')' })
+	var province *Province
+	for _, part := range parts {
+		// Kode provinsi ("32") di tengah teks bebas biasanya nomor jalan / kode pos
+		if p, ok := FindProvince(part); ok && strings.TrimSpace(part) != p.Kode {
+			province = &p
+			break
+		}
+	}
+
+	id := DefaultCountry
+	scope := ""
+	if province != nil {
+		scope = province.Kode
+	}
+	for _, part := range parts {
+		if c, p, ok := FindCity(part, scope); ok {
+			lat, lng := c.Lat, c.Lng
+			return Location{Negara: &id, Provinsi: &p.Nama, Kota: &c.Nama, Latitude: &lat, Longitude: &lng}, true
+		}
+	}
+	if province != nil {
+		return Location{Negara: &id, Provinsi: &province.Nama}, true
+	}
+	for _, part := range parts {
+		if code, ok := countryAliases[regionKey(part)]; ok {
+			return Location{Negara: &code}, true
+		}
+	}
+	return Location{}, false
+}
+
+// HaversineKm -> jarak lingkaran besar antara dua titik (km)
+func HaversineKm(lat1, lng1, lat2, lng2 float64) float64 {
+	toRad := math.Pi / 180
+	dLat := (lat2 - lat1) * toRad
+	dLng := (lng2 - lng1) * toRad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1*toRad)*math.Cos(lat2*toRad)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
@@ -0,0 +1,230 @@
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	SalaryPeriodMonthly   = "bulanan"
+	SalaryPeriodYearly    = "tahunan"
+	DefaultSalaryCurrency = "IDR"
+)
+
+// Salary -> gaji terstruktur; Min / Max nil bila tidak diketahui (mis. "> 10 juta" hanya punya Min)
+type Salary struct {
+	Min      *int64
+	Max      *int64
+	Currency string
+	Period   string
+}
+
+var (
+	salaryNumberPattern   = regexp.MustCompile(`(\d+(?:[.,]\d+)*)\s*(?:(miliar|milyar|juta|jt|mio|ribu|rb|k|m|b)\b)?`)
+	salaryCurrencyPattern = regexp.MustCompile(`\b(idr|usd|sgd|eur|myr|aud|jpy)\b`)
+	salaryCurrencyCode    = regexp.MustCompile(`^[A-Z]{3}$`)
+)
+
+var salaryUnits = map[string]float64{
+	"miliar": 1e9, "milyar": 1e9, "b": 1e9,
+	"juta": 1e6, "jt": 1e6, "mio": 1e6, "m": 1e6,
+	"ribu": 1e3, "rb": 1e3, "k": 1e3,
+}
+
+// ParseSalaryRange -> baca teks gaji lama secara best-effort: "5-10 juta", "8-12jt",
+// "Rp 5.000.000 - Rp 7.500.000", "> 10 juta", "USD 2k-3k per tahun". Angka rupiah tanpa satuan
+// di bawah 1000 dianggap juta.
+func ParseSalaryRange(text string) (Salary, error) {
+	lower := strings.ToLower(text)
+	matches := salaryNumberPattern.FindAllStringSubmatch(lower, -1)
+	if len(matches) == 0 {
+		return Salary{}, fmt.Errorf("tidak ada nominal pada %q", text)
+	}
+	if len(matches) > 2 {
+		matches = [][]string{matches[0], matches[len(matches)-1]}
+	}
+
+	salary := Salary{Currency: DefaultSalaryCurrency, Period: SalaryPeriodMonthly}
+	if m := salaryCurrencyPattern.FindStringSubmatch(lower); m != nil {
+		salary.Currency = strings.ToUpper(m[1])
+	} else if strings.Contains(lower, "$") {
+		salary.Currency = "USD"
+	}
+	for _, word := range []string{"tahun", "thn", "year", "annual", "p.a"} {
+		if strings.Contains(lower, word) {
+			salary.Period = SalaryPeriodYearly
+		}
+	}
+
+	// Satuan hanya ditulis di angka terakhir pada "5-10 juta"
+	unit := matches[len(matches)-1][2]
+	values := make([]int64, len(matches))
+	for i, m := range matches {
+		n, ok := parseSalaryNumber(m[1])
+		if !ok {
+			return Salary{}, fmt.Errorf("nominal %q tidak dikenali", m[1])
+		}
+		u := m[2]
+		if u == "" {
+			u = unit
+		}
+		switch {
+		case u != "":
+			n *= salaryUnits[u]
+		case salary.Currency == DefaultSalaryCurrency && n < 1000:
+			n *= 1e6
+		}
+		values[i] = int64(math.Round(n))
+	}
+
+	lo, hi := values[0], values[len(values)-1]
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	if len(values) == 1 {
+		prefix := lower[:strings.Index(lower, matches[0][1])]
+		switch {
+		case containsAny(prefix, ">", "atas", "lebih", "min", "mulai"):
+			return withSalary(salary, &lo, nil), nil
+		case containsAny(prefix, "<", "bawah", "kurang", "maks", "max", "hingga", "sampai"):
+			return withSalary(salary, nil, &hi), nil
+		}
+	}
+	return withSalary(salary, &lo, &hi), nil
+}
+
+func withSalary(s Salary, min, max *int64) Salary {
+	s.Min, s.Max = min, max
+	return s
+}
+
+func containsAny(s string, words ...string) bool {
+	for _, w := range words {
+		if strings.Contains(s, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseSalaryNumber -> angka dengan pemisah ribuan / desimal gaya Indonesia maupun Inggris.
+// "5.000.000" dan "5,000,000" ribuan, "5,5" dan "5.5" desimal.
+func parseSalaryNumber(s string) (float64, bool) {
+	dot, comma := strings.LastIndex(s, "."), strings.LastIndex(s, ",")
+	switch {
+	case dot >= 0 && comma >= 0:
+		// Pemisah yang muncul terakhir adalah desimal
+		if dot > comma {
+			s = strings.ReplaceAll(s, ",", "")
+		} else {
+			s = strings.Replace(strings.ReplaceAll(s, ".", ""), ",", ".", 1)
+		}
+	case dot >= 0 || comma >= 0:
+		sep := "."
+		if comma >= 0 {
+			sep = ","
+		}
+		groups := strings.Split(s, sep)
+		thousands := true
+		for _, g := range groups[1:] {
+			if len(g) != 3 {
+				thousands = false
+			}
+		}
+		switch {
+		case thousands:
+			s = strings.ReplaceAll(s, sep, "")
+		case len(groups) == 2:
+			s = groups[0] + "." + groups[1]
+		default:
+			return 0, false
+		}
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	return n, err == nil
+}
+
+// NormalizeSalary -> lengkapi dan validasi gaji: mata uang default IDR (kode 3 huruf), periode
+// default bulanan, nominal tidak negatif, dan Min tidak lebih dari Max
+func NormalizeSalary(s Salary) (Salary, error) {
+	s.Currency = strings.ToUpper(strings.TrimSpace(s.Currency))
+	if s.Currency == "" {
+		s.Currency = DefaultSalaryCurrency
+	}
+	if !salaryCurrencyCode.MatchString(s.Currency) {
+		return Salary{}, errors.New("gaji_mata_uang harus kode mata uang 3 huruf, mis. IDR")
+	}
+	s.Period = strings.ToLower(strings.TrimSpace(s.Period))
+	if s.Period == "" {
+		s.Period = SalaryPeriodMonthly
+	}
+	if s.Period != SalaryPeriodMonthly && s.Period != SalaryPeriodYearly {
+		return Salary{}, errors.New("gaji_periode harus bulanan atau tahunan")
+	}
+	if (s.Min != nil && *s.Min < 0) || (s.Max != nil && *s.Max < 0) {
+		return Salary{}, errors.New("gaji tidak boleh negatif")
+	}
+	if s.Min != nil && s.Max != nil && *s.Min > *s.Max {
+		return Salary{}, errors.New("gaji_min tidak boleh lebih dari gaji_max")
+	}
+	return s, nil
+}
+
+// ResolveSalary -> gaji dari request pekerjaan. gaji_min / gaji_max diutamakan; bila keduanya
+// kosong, gaji_range format lama dibaca dengan ParseSalaryRange. Mata uang dan periode yang
+// diisi eksplisit menimpa hasil parsing.
+func ResolveSalary(legacy *string, min, max *int64, currency, period string) (Salary, error) {
+	salary := Salary{Min: min, Max: max, Currency: currency, Period: period}
+	if min == nil && max == nil && legacy != nil && strings.TrimSpace(*legacy) != "" {
+		parsed, err := ParseSalaryRange(*legacy)
+		if err != nil {
+			return Salary{}, fmt.Errorf("gaji_range tidak dikenali: %w", err)
+		}
+		salary.Min, salary.Max = parsed.Min, parsed.Max
+		if currency == "" {
+			salary.Currency = parsed.Currency
+		}
+		if period == "" {
+			salary.Period = parsed.Period
+		}
+	}
+	return NormalizeSalary(salary)
+}
+
+// FormatSalary -> teks gaji untuk tampilan dan export: "5-10 juta", "> 10 juta / tahun",
+// "USD 2000-3000"; kosong bila nominal tidak ada
+func FormatSalary(min, max *int64, currency, period string) string {
+	amount := func(v int64) string {
+		if currency == DefaultSalaryCurrency || currency == "" {
+			return strings.Replace(strconv.FormatFloat(float64(v)/1e6, 'f', -1, 64), ".", ",", 1)
+		}
+		return strconv.FormatInt(v, 10)
+	}
+
+	var text string
+	switch {
+	case min != nil && max != nil && *min == *max:
+		text = amount(*min)
+	case min != nil && max != nil:
+		text = amount(*min) + "-" + amount(*max)
+	case min != nil:
+		text = "> " + amount(*min)
+	case max != nil:
+		text = "< " + amount(*max)
+	default:
+		return ""
+	}
+	if currency == DefaultSalaryCurrency || currency == "" {
+		text += " juta"
+	} else {
+		text = currency + " " + text
+	}
+	if period == SalaryPeriodYearly {
+		text += " / tahun"
+	}
+	return text
+}
@@ -0,0 +1,262 @@
+package helper
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnsupportedSpreadsheet dikembalikan bila file bukan .csv atau .xlsx
+var ErrUnsupportedSpreadsheet = errors.New("format file tidak didukung, gunakan .csv atau .xlsx")
+
+// Sheet -> isi spreadsheet: header yang sudah dinormalisasi dan baris data
+type Sheet struct {
+	Headers []string
+	Rows    []SheetRow
+}
+
+// SheetRow -> satu baris data; Number adalah nomor baris di file (header = baris 1)
+type SheetRow struct {
+	Number int
+	Values map[string]string
+}
+
+// ReadSpreadsheet membaca file CSV (pemisah koma atau titik koma) atau XLSX (sheet pertama).
+// Baris pertama dianggap header; nama kolom dinormalisasi ke huruf kecil dengan underscore
+// sehingga "Tahun Lulus" menjadi "tahun_lulus". Baris kosong dilewati.
+func ReadSpreadsheet(filename string, data []byte) (*Sheet, error) {
+	var records [][]string
+	var err error
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		records, err = readCSV(data)
+	case ".xlsx":
+		records, err = readXLSX(data)
+	default:
+		return nil, ErrUnsupportedSpreadsheet
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("file kosong")
+	}
+
+	sheet := &Sheet{}
+	for _, h := range records[0] {
+		sheet.Headers = append(sheet.Headers, NormalizeHeader(h))
+	}
+
+	for i, record := range records[1:] {
+		row := SheetRow{Number: i + 2, Values: map[string]string{}}
+		empty := true
+		for j, value := range record {
+			if j >= len(sheet.Headers) || sheet.Headers[j] == "" {
+				continue
+			}
+			value = strings.TrimSpace(value)
+			if value != "" {
+				empty = false
+			}
+			row.Values[sheet.Headers[j]] = value
+		}
+		if !empty {
+			sheet.Rows = append(sheet.Rows, row)
+		}
+	}
+	return sheet, nil
+}
+
+// NormalizeHeader -> "Tahun Lulus " menjadi "tahun_lulus"
+func NormalizeHeader(h string) string {
+	h = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+	return strings.Join(strings.FieldsFunc(h, func(r rune) bool {
+		return r == ' ' || r == '-' || r == '_' || r == '.'
+	}), "_")
+}
+
+// ParseSpreadsheetDate -> ParseDateFlexible, ditambah serial date Excel (mis. "44927") untuk sel tanggal XLSX
+func ParseSpreadsheetDate(value string) (time.Time, error) {
+	t, err := ParseDateFlexible(value)
+	if err == nil {
+		return t, nil
+	}
+	if serial, serr := strconv.ParseFloat(value, 64); serr == nil && serial > 0 {
+		base := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+		return base.AddDate(0, 0, int(math.Floor(serial))), nil
+	}
+	return time.Time{}, err
+}
+
+// ParseSpreadsheetInt -> angka bulat, termasuk "2018.0" yang sering muncul dari XLSX
+func ParseSpreadsheetInt(value string) (int, error) {
+	if n, err := strconv.Atoi(value); err == nil {
+		return n, nil
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || f != math.Trunc(f) {
+		return 0, fmt.Errorf("'%s' bukan angka bulat", value)
+	}
+	return int(f), nil
+}
+
+func readCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+
+	// Excel berlocale Indonesia menyimpan CSV dengan pemisah titik koma
+	firstLine := data
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		firstLine = data[:i]
+	}
+	reader := csv.NewReader(bytes.NewReader(data))
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("CSV tidak valid: %v", err)
+	}
+	return records, nil
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxRichText `xml:"si"`
+}
+
+type xlsxRichText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxRichText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+	var b strings.Builder
+	for _, r := range t.Runs {
+		b.WriteString(r.Text)
+	}
+	return b.String()
+}
+
+type xlsxWorksheet struct {
+	Rows []struct {
+		Ref   int `xml:"r,attr"`
+		Cells []struct {
+			Ref    string       `xml:"r,attr"`
+			Type   string       `xml:"t,attr"`
+			Value  string       `xml:"v"`
+			Inline xlsxRichText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func readXLSX(data []byte) ([][]string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("XLSX tidak valid: %v", err)
+	}
+
+	files := map[string]*zip.File{}
+	var sheets []string
+	for _, f := range zr.File {
+		files[f.Name] = f
+		if strings.HasPrefix(f.Name, "xl/worksheets/") && strings.HasSuffix(f.Name, ".xml") {
+			sheets = append(sheets, f.Name)
+		}
+	}
+	if len(sheets) == 0 {
+		return nil, fmt.Errorf("XLSX tidak memiliki worksheet")
+	}
+	sheetName := "xl/worksheets/sheet1.xml"
+	if files[sheetName] == nil {
+		sort.Strings(sheets)
+		sheetName = sheets[0]
+	}
+
+	var shared xlsxSharedStrings
+	if f := files["xl/sharedStrings.xml"]; f != nil {
+		if err := decodeZipXML(f, &shared); err != nil {
+			return nil, err
+		}
+	}
+
+	var ws xlsxWorksheet
+	if err := decodeZipXML(files[sheetName], &ws); err != nil {
+		return nil, err
+	}
+
+	records := make([][]string, 0, len(ws.Rows))
+	for _, row := range ws.Rows {
+		// Baris kosong tidak ditulis di XLSX; isi celahnya agar nomor baris tetap sesuai file
+		for row.Ref > len(records)+1 {
+			records = append(records, nil)
+		}
+
+		var record []string
+		for i, cell := range row.Cells {
+			col := i
+			if idx := xlsxColumnIndex(cell.Ref); idx >= 0 {
+				col = idx
+			}
+			for len(record) <= col {
+				record = append(record, "")
+			}
+
+			switch cell.Type {
+			case "s":
+				idx, err := strconv.Atoi(cell.Value)
+				if err == nil && idx >= 0 && idx < len(shared.Items) {
+					record[col] = shared.Items[idx].String()
+				}
+			case "inlineStr":
+				record[col] = cell.Inline.String()
+			default:
+				record[col] = cell.Value
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func decodeZipXML(f *zip.File, v interface{}) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	if err := xml.NewDecoder(io.LimitReader(rc, 64<<20)).Decode(v); err != nil {
+		return fmt.Errorf("XLSX tidak valid: %v", err)
+	}
+	return nil
+}
+
+// xlsxColumnIndex -> "C12" menjadi 2 (kolom berbasis 0)
+func xlsxColumnIndex(ref string) int {
+	col := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		col = col*26 + int(r-'A'+1)
+	}
+	return col - 1
+}
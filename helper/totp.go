@@ -0,0 +1,133 @@
+package helper
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Parameter TOTP (RFC 6238) yang didukung semua aplikasi authenticator
+const (
+	TOTPPeriod = 30 * time.Second
+	TOTPDigits = 6
+	// TOTPSkew -> jumlah step sebelum/sesudah waktu sekarang yang masih diterima (selisih jam)
+	TOTPSkew = 1
+
+	RecoveryCodeCount = 10
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Huruf/angka yang tidak mudah tertukar (tanpa 0/o, 1/l/i)
+const recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+// GenerateTOTPSecret -> secret acak 160-bit dalam base32 tanpa padding
+func GenerateTOTPSecret() (string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(b), nil
+}
+
+// TOTPStep -> nomor step (periode 30 detik) untuk waktu t
+func TOTPStep(t time.Time) int64 {
+	return t.Unix() / int64(TOTPPeriod/time.Second)
+}
+
+// TOTPCode -> kode 6 digit untuk step tertentu (HOTP SHA-1, RFC 4226)
+func TOTPCode(secret string, step int64) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return "", fmt.Errorf("secret TOTP tidak valid: %v", err)
+	}
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", TOTPDigits, value%1000000), nil
+}
+
+// VerifyTOTP -> cocokkan kode dengan step t-TOTPSkew sampai t+TOTPSkew. Mengembalikan step
+// yang cocok agar pemanggil bisa menolak kode yang sama dipakai dua kali.
+func VerifyTOTP(secret, code string, t time.Time) (int64, bool) {
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if len(code) != TOTPDigits {
+		return 0, false
+	}
+	now := TOTPStep(t)
+	for step := now - TOTPSkew; step <= now+TOTPSkew; step++ {
+		expected, err := TOTPCode(secret, step)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// TOTPURI -> otpauth:// URI untuk QR code aplikasi authenticator. Issuer dari APP_NAME.
+func TOTPURI(account, secret string) string {
+	issuer := emailAppName()
+	v := url.Values{}
+	v.Set("secret", secret)
+	v.Set("issuer", issuer)
+	v.Set("algorithm", "SHA1")
+	v.Set("digits", fmt.Sprint(TOTPDigits))
+	v.Set("period", fmt.Sprint(int(TOTPPeriod/time.Second)))
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(account)
+	return "otpauth://totp/" + label + "?" + v.Encode()
+}
+
+// GenerateRecoveryCodes -> n kode pemulihan sekali pakai berformat xxxxx-xxxxx
+func GenerateRecoveryCodes(n int) ([]string, error) {
+	codes := make([]string, n)
+	max := big.NewInt(int64(len(recoveryCodeAlphabet)))
+	for i := range codes {
+		var sb strings.Builder
+		for j := 0; j < 10; j++ {
+			if j == 5 {
+				sb.WriteByte('-')
+			}
+			idx, err := rand.Int(rand.Reader, max)
+			if err != nil {
+				return nil, err
+			}
+			sb.WriteByte(recoveryCodeAlphabet[idx.Int64()])
+		}
+		codes[i] = sb.String()
+	}
+	return codes, nil
+}
+
+// HashRecoveryCode -> SHA-256 hex kode pemulihan; huruf besar, spasi, dan tanda hubung diabaikan
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(code)))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// HashRecoveryCodes -> hash untuk semua kode, siap disimpan
+func HashRecoveryCodes(codes []string) []string {
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = HashRecoveryCode(code)
+	}
+	return hashes
+}
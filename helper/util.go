@@ -1 +1,52 @@
 package helper
+
+import (
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// SearchTerms memecah kata kunci pencarian menjadi term unik (lowercase),
+// mengabaikan operator websearch seperti tanda kutip, OR, dan term negasi (-kata)
+func SearchTerms(query string) []string {
+	seen := map[string]bool{}
+	var terms []string
+	for _, t := range strings.Fields(strings.ToLower(query)) {
+		if strings.HasPrefix(t, "-") {
+			continue
+		}
+		t = strings.Trim(t, `"'+()`)
+		if t == "" || t == "or" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		terms = append(terms, t)
+	}
+	return terms
+}
+
+// Highlight meng-escape text lalu membungkus setiap kemunculan term
+// (case-insensitive) dengan <em></em>. Mengembalikan string kosong bila
+// tidak ada term yang cocok sehingga caller bisa melewatkan field tersebut.
+func Highlight(text string, terms []string) string {
+	if text == "" || len(terms) == 0 {
+		return ""
+	}
+
+	// Term terpanjang dulu agar "informatika" tidak terpotong oleh "informa"
+	sorted := append([]string(nil), terms...)
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+
+	quoted := make([]string, len(sorted))
+	for i, t := range sorted {
+		quoted[i] = regexp.QuoteMeta(html.EscapeString(t))
+	}
+	re := regexp.MustCompile("(?i)(" + strings.Join(quoted, "|") + ")")
+
+	escaped := html.EscapeString(text)
+	if !re.MatchString(escaped) {
+		return ""
+	}
+	return re.ReplaceAllString(escaped, "<em>$1</em>")
+}
@@ -0,0 +1,251 @@
+package helper
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Status akademik pada pernyataan verifikasi
+const (
+	VerificationStatusGraduate = "lulus"     // tahun_lulus sudah lewat atau tahun ini
+	VerificationStatusStudent  = "mahasiswa" // tahun_lulus di masa depan (perkiraan lulus)
+)
+
+// VerificationAttemptWindow -> jendela counter percobaan verifikasi per NIM
+const VerificationAttemptWindow = time.Hour
+
+// VerificationAttemptLimit membaca VERIFICATION_NIM_LIMIT: batas percobaan verifikasi satu NIM per
+// jam (default 10) dari semua API key, agar tanggal lahir tidak bisa ditebak
+func VerificationAttemptLimit() int {
+	return envPositiveInt("VERIFICATION_NIM_LIMIT", 10)
+}
+
+// ErrInvalidVerificationToken dikembalikan bila tanda tangan, kid, atau format token tidak valid
+var ErrInvalidVerificationToken = errors.New("token verifikasi tidak valid")
+
+// ErrExpiredVerificationToken dikembalikan bila token verifikasi sudah lewat masa berlakunya
+var ErrExpiredVerificationToken = errors.New("token verifikasi sudah kedaluwarsa")
+
+// VerificationClaims -> isi pernyataan verifikasi (JWS). Hanya data yang sudah diketahui pemeriksa
+// (NIM, nama) ditambah status akademik; email, telepon, alamat, dan tanggal lahir tidak pernah ikut.
+type VerificationClaims struct {
+	NIM        string `json:"nim"`
+	Nama       string `json:"nama"`
+	Jurusan    string `json:"jurusan"`
+	Angkatan   int    `json:"angkatan"`
+	TahunLulus int    `json:"tahun_lulus"`
+	Status     string `json:"status"`
+	jwt.RegisteredClaims
+}
+
+// VerificationStatus -> status akademik berdasarkan tahun lulus
+func VerificationStatus(tahunLulus int, now time.Time) string {
+	if tahunLulus > now.Year() {
+		return VerificationStatusStudent
+	}
+	return VerificationStatusGraduate
+}
+
+// VerificationNameMatches -> nama dari pemeriksa cocok dengan nama di data alumni. Huruf besar/kecil,
+// spasi ganda, tanda baca, dan gelar di belakang koma ("Budi Santoso, S.Kom.") diabaikan.
+func VerificationNameMatches(given, stored string) bool {
+	a, b := verificationName(given), verificationName(stored)
+	return a != "" && a == b
+}
+
+func verificationName(s string) string {
+	if i := strings.Index(s, ","); i >= 0 {
+		s = s[:i]
+	}
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			return unicode.ToLower(r)
+		case unicode.IsSpace(r):
+			return ' '
+		}
+		return -1
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// JWK -> kunci publik Ed25519 dalam format JSON Web Key (RFC 8037)
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+}
+
+// JWKSet -> daftar kunci publik untuk memverifikasi pernyataan secara offline
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// VerificationSigner menandatangani pernyataan verifikasi dengan Ed25519 (JWS alg EdDSA). Kunci
+// publik yang sudah dipensiunkan tetap dipakai untuk memverifikasi token lama.
+type VerificationSigner struct {
+	Issuer string
+	TTL    time.Duration
+
+	key     ed25519.PrivateKey
+	kid     string
+	trusted map[string]ed25519.PublicKey
+}
+
+var (
+	verificationKeyOnce sync.Once
+	verificationKey     ed25519.PrivateKey
+)
+
+// VerificationSignerFromEnv membaca VERIFICATION_SIGNING_KEY (seed Ed25519 32 byte, base64),
+// VERIFICATION_RETIRED_KEYS (kunci publik lama, base64, dipisah koma), VERIFICATION_ISSUER
+// (default APP_NAME atau "go-fiber"), dan VERIFICATION_TTL_DAYS (default 365). Tanpa
+// VERIFICATION_SIGNING_KEY kunci acak per proses dipakai, sehingga token tidak bisa diverifikasi
+// setelah restart atau oleh instance lain.
+func VerificationSignerFromEnv() (*VerificationSigner, error) {
+	var key ed25519.PrivateKey
+	if raw := strings.TrimSpace(os.Getenv("VERIFICATION_SIGNING_KEY")); raw != "" {
+		seed, err := decodeVerificationKey(raw)
+		if err != nil || len(seed) != ed25519.SeedSize {
+			return nil, fmt.Errorf("VERIFICATION_SIGNING_KEY harus seed Ed25519 %d byte dalam base64", ed25519.SeedSize)
+		}
+		key = ed25519.NewKeyFromSeed(seed)
+	} else {
+		verificationKeyOnce.Do(func() {
+			_, verificationKey, _ = ed25519.GenerateKey(rand.Reader)
+			log.Println("VERIFICATION_SIGNING_KEY kosong, pernyataan verifikasi memakai kunci sementara")
+		})
+		key = verificationKey
+	}
+
+	var retired []ed25519.PublicKey
+	for _, raw := range strings.Split(os.Getenv("VERIFICATION_RETIRED_KEYS"), ",") {
+		if raw = strings.TrimSpace(raw); raw == "" {
+			continue
+		}
+		pub, err := decodeVerificationKey(raw)
+		if err != nil || len(pub) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("VERIFICATION_RETIRED_KEYS berisi kunci publik yang tidak valid: %s", raw)
+		}
+		retired = append(retired, ed25519.PublicKey(pub))
+	}
+
+	issuer := strings.TrimSpace(os.Getenv("VERIFICATION_ISSUER"))
+	if issuer == "" {
+		issuer = strings.TrimSpace(os.Getenv("APP_NAME"))
+	}
+	if issuer == "" {
+		issuer = "go-fiber"
+	}
+	return NewVerificationSigner(key, retired, issuer, time.Duration(envPositiveInt("VERIFICATION_TTL_DAYS", 365))*24*time.Hour), nil
+}
+
+// NewVerificationSigner -> signer dengan kunci aktif key dan kunci publik lama retired
+func NewVerificationSigner(key ed25519.PrivateKey, retired []ed25519.PublicKey, issuer string, ttl time.Duration) *VerificationSigner {
+	pub := key.Public().(ed25519.PublicKey)
+	s := &VerificationSigner{
+		Issuer:  issuer,
+		TTL:     ttl,
+		key:     key,
+		kid:     jwkThumbprint(pub),
+		trusted: map[string]ed25519.PublicKey{},
+	}
+	s.trusted[s.kid] = pub
+	for _, k := range retired {
+		s.trusted[jwkThumbprint(k)] = k
+	}
+	return s
+}
+
+func decodeVerificationKey(s string) ([]byte, error) {
+	if b, err := base64.StdEncoding.DecodeString(s); err == nil {
+		return b, nil
+	}
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
+// jwkThumbprint -> kid berupa JWK thumbprint SHA-256 (RFC 7638) kunci publik
+func jwkThumbprint(pub ed25519.PublicKey) string {
+	canonical := `{"crv":"Ed25519","kty":"OKP","x":"` + base64.RawURLEncoding.EncodeToString(pub) + `"}`
+	sum := sha256.Sum256([]byte(canonical))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// Sign -> JWS compact berisi claims; iss, iat, exp, dan jti diisi signer
+func (s *VerificationSigner) Sign(claims VerificationClaims, now time.Time) (string, error) {
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", err
+	}
+	claims.Issuer = s.Issuer
+	claims.Subject = claims.NIM
+	claims.ID = base64.RawURLEncoding.EncodeToString(jti)
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	claims.ExpiresAt = jwt.NewNumericDate(now.Add(s.TTL))
+
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	token.Header["kid"] = s.kid
+	return token.SignedString(s.key)
+}
+
+// Verify -> periksa tanda tangan (kunci aktif atau kunci lama), issuer, dan masa berlaku token
+func (s *VerificationSigner) Verify(token string, now time.Time) (*VerificationClaims, error) {
+	claims := &VerificationClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		pub, ok := s.trusted[kid]
+		if !ok {
+			return nil, ErrInvalidVerificationToken
+		}
+		return pub, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithIssuer(s.Issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(func() time.Time { return now }),
+	)
+	if errors.Is(err, jwt.ErrTokenExpired) {
+		return claims, ErrExpiredVerificationToken
+	}
+	if err != nil {
+		return nil, ErrInvalidVerificationToken
+	}
+	return claims, nil
+}
+
+// JWKS -> kunci publik aktif dan kunci lama untuk verifikasi offline
+func (s *VerificationSigner) JWKS() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+	add := func(kid string, pub ed25519.PublicKey) {
+		set.Keys = append(set.Keys, JWK{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(pub),
+			Kid: kid,
+			Alg: jwt.SigningMethodEdDSA.Alg(),
+			Use: "sig",
+		})
+	}
+	add(s.kid, s.trusted[s.kid])
+	for kid, pub := range s.trusted {
+		if kid != s.kid {
+			add(kid, pub)
+		}
+	}
+	return set
+}
@@ -1,14 +1,23 @@
 package main
 
 import (
+	"context"
+	mongoService "go-fiber/app/service/mongo"
+	service "go-fiber/app/service/postgre"
 	"go-fiber/config"
 	appConfig "go-fiber/config/postgre"
 	"go-fiber/database"
 	_ "go-fiber/docs"
+	mongoMiddleware "go-fiber/middleware/mongo"
+	middleware "go-fiber/middleware/postgre"
 	mongoRoute "go-fiber/route/mongo"
 	route "go-fiber/route/postgre"
 	"log"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	fiberSwagger "github.com/gofiber/swagger"
 )
@@ -22,14 +31,29 @@ import (
 // @securityDefinitions.apikey BearerAuth
 // @in header
 // @name Authorization
+// @securityDefinitions.apikey APIKeyAuth
+// @in header
+// @name X-API-Key
 func main() {
 	config.LoadEnv()
 
 	// PostgreSQL setup
 	db := database.ConnectDB()
 	app := appConfig.NewApp(db)
-	route.AlumniRoutes(app, db)
-	route.PekerjaanRoutes(app, db)
+	// Route publik / API key didaftarkan dulu; sesudahnya satu grup protected per backend
+	// sehingga AuthRequired dan Idempotency hanya berjalan sekali per request
+	route.VerificationRoutes(app, db)
+	route.AuthRoutes(app, db)
+	protected := app.Group("/go-fiber-postgre", middleware.AuthRequired(), middleware.Idempotency())
+	route.AlumniRoutes(protected, db)
+	route.PekerjaanRoutes(protected, db)
+	route.AnalyticsRoutes(protected, db)
+	route.ImportRoutes(protected, db)
+	route.ExportRoutes(protected, db)
+	route.JobRoutes(protected, db)
+	route.ReferenceRoutes(protected, db)
+	route.RegionRoutes(protected, db)
+	route.MeRoutes(protected, db)
 
 	// MongoDB setup
 	mongoDB := database.ConnectMongoDB()
@@ -40,9 +64,26 @@ func main() {
 	}
 
 	// Register MongoDB routes ke app yang sama
-	mongoRoute.AlumniRoutes(app, mongoDB)
-	mongoRoute.PekerjaanRoutes(app, mongoDB)
-	mongoRoute.FileRoutes(app, mongoDB)
+	mongoRoute.VerificationRoutes(app, mongoDB)
+	mongoRoute.AuthRoutes(app, mongoDB)
+	mongoProtected := app.Group("/go-fiber-mongo", mongoMiddleware.AuthRequired(), mongoMiddleware.Idempotency())
+	mongoRoute.AlumniRoutes(mongoProtected, mongoDB)
+	mongoRoute.PekerjaanRoutes(mongoProtected, mongoDB)
+	mongoRoute.FileRoutes(mongoProtected, mongoDB)
+	mongoRoute.AnalyticsRoutes(mongoProtected, mongoDB)
+	mongoRoute.ImportRoutes(mongoProtected, mongoDB)
+	mongoRoute.ExportRoutes(mongoProtected, mongoDB)
+	mongoRoute.JobRoutes(mongoProtected, mongoDB)
+	mongoRoute.ReferenceRoutes(mongoProtected, mongoDB)
+	mongoRoute.RegionRoutes(mongoProtected, mongoDB)
+	mongoRoute.MeRoutes(mongoProtected, mongoDB)
+
+	// Job runner: purge trash pekerjaan (PostgreSQL) dan purge retensi file (MongoDB)
+	jobWorkers := mongoService.JobWorkersFromEnv()
+	jobRunner := service.NewJobRunner(db, jobWorkers)
+	mongoJobRunner := mongoService.NewJobRunner(mongoDB, jobWorkers)
+	jobRunner.Start()
+	mongoJobRunner.Start()
 
 	// Swagger documentation
 	app.Get("/swagger/*", fiberSwagger.HandlerDefault)
@@ -56,5 +97,36 @@ func main() {
 	app.Static("/uploads", "./uploads")
 
 	// Start server di satu port saja
-	app.Listen(":" + port)
+	go func() {
+		if err := app.Listen(":" + port); err != nil {
+			log.Fatalf("Server error: %v", err)
+		}
+	}()
+
+	// Graceful shutdown: stop menerima request, lalu tunggu job yang sedang berjalan
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	<-quit
+	log.Println("Shutting down...")
+
+	if err := app.ShutdownWithTimeout(10 * time.Second); err != nil {
+		log.Printf("Server shutdown error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if err := jobRunner.Shutdown(ctx); err != nil {
+			log.Printf("Job runner shutdown: %v", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if err := mongoJobRunner.Shutdown(ctx); err != nil {
+			log.Printf("Mongo job runner shutdown: %v", err)
+		}
+	}()
+	wg.Wait()
 }
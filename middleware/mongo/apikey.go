@@ -0,0 +1,80 @@
+package mongo
+
+import (
+	model "go-fiber/app/model/mongo"
+	"go-fiber/helper"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// APIKeyVerifier mencari API key aktif (belum dicabut/kedaluwarsa) dari key mentah; nil berarti
+// key tidak valid. Diisi saat routes didaftarkan.
+var APIKeyVerifier func(key string) (*model.APIKey, error)
+
+// APIKeyRateLimitStore menyimpan counter rate limit per API key. Diisi saat routes didaftarkan;
+// nil berarti memakai store di memori proses.
+var APIKeyRateLimitStore helper.RateLimitStore
+
+var apiKeyMemoryStore = helper.NewMemoryRateLimitStore()
+
+// APIKeyRequired -> autentikasi client mesin lewat header X-API-Key dengan scope tertentu
+func APIKeyRequired(scope string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		raw := strings.TrimSpace(c.Get(helper.APIKeyHeader))
+		if raw == "" {
+			return c.Status(401).JSON(fiber.Map{
+				"error": "API key diperlukan di header " + helper.APIKeyHeader,
+			})
+		}
+		if APIKeyVerifier == nil {
+			return c.Status(500).JSON(fiber.Map{
+				"error": "Verifikasi API key belum dikonfigurasi",
+			})
+		}
+
+		key, err := APIKeyVerifier(raw)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{
+				"error": "Gagal memeriksa API key",
+			})
+		}
+		if key == nil {
+			return c.Status(401).JSON(fiber.Map{
+				"error": "API key tidak valid, dicabut, atau kedaluwarsa",
+			})
+		}
+		if !helper.HasScope(key.Scopes, scope) {
+			return c.Status(403).JSON(fiber.Map{
+				"error": "API key tidak memiliki scope " + scope,
+			})
+		}
+
+		if key.RateLimit > 0 {
+			store := APIKeyRateLimitStore
+			if store == nil {
+				store = apiKeyMemoryStore
+			}
+			count, retryAfter, err := store.Hit("apikey:"+key.ID.Hex(), time.Minute)
+			if err != nil {
+				return c.Status(500).JSON(fiber.Map{
+					"error": "Gagal memeriksa rate limit",
+				})
+			}
+			c.Set("X-RateLimit-Limit", strconv.Itoa(key.RateLimit))
+			c.Set("X-RateLimit-Remaining", strconv.Itoa(max(key.RateLimit-count, 0)))
+			if count > key.RateLimit {
+				c.Set(fiber.HeaderRetryAfter, helper.RetryAfterSeconds(retryAfter))
+				return c.Status(429).JSON(fiber.Map{
+					"error": "Batas request API key terlampaui, coba lagi nanti",
+				})
+			}
+		}
+
+		c.Locals("api_key_id", key.ID.Hex())
+		c.Locals("api_key_name", key.Name)
+		return c.Next()
+	}
+}
@@ -1,48 +1,98 @@
 package mongo
 
 import (
+	model "go-fiber/app/model/mongo"
 	utils "go-fiber/utils/mongo"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
 
+// SessionChecker memeriksa apakah sesi token masih berlaku, mis. belum dicabut karena
+// password diganti. Diisi saat routes didaftarkan; nil berarti cukup validasi JWT.
+var SessionChecker func(claims *model.JWTClaims) (bool, error)
+
 func AuthRequired() fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		authHeader := strings.TrimSpace(c.Get("Authorization"))
-		if authHeader == "" {
+		return authenticate(c, false)
+	}
+}
+
+// MFASetupAuth -> seperti AuthRequired, tetapi juga menerima token mfa_pending agar admin
+// yang belum punya 2FA bisa enrollment sebelum mendapat token penuh
+func MFASetupAuth() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return authenticate(c, true)
+	}
+}
+
+func authenticate(c *fiber.Ctx, allowMFAPending bool) error {
+	// Sudah diautentikasi middleware sebelumnya (mis. MFASetupAuth pada /me/mfa):
+	// token tidak divalidasi ulang, hanya batasan mfa_pending yang diperiksa
+	if c.Locals("user_id") != nil {
+		if pending, _ := c.Locals("mfa_pending").(bool); pending && !allowMFAPending {
 			return c.Status(401).JSON(fiber.Map{
-				"error": "Token akses diperlukan",
+				"error": "Verifikasi 2FA diperlukan",
 			})
 		}
+		return c.Next()
+	}
+
+	authHeader := strings.TrimSpace(c.Get("Authorization"))
+	if authHeader == "" {
+		return c.Status(401).JSON(fiber.Map{
+			"error": "Token akses diperlukan",
+		})
+	}
 
-		var token string
-		if strings.Contains(authHeader, " ") {
-			tokenParts := strings.Fields(authHeader)
-			if len(tokenParts) != 2 || !strings.EqualFold(tokenParts[0], "Bearer") {
-				return c.Status(401).JSON(fiber.Map{
-					"error": "Format token tidak valid",
-				})
-			}
-			token = tokenParts[1]
-		} else {
-			token = authHeader
+	var token string
+	if strings.Contains(authHeader, " ") {
+		tokenParts := strings.Fields(authHeader)
+		if len(tokenParts) != 2 || !strings.EqualFold(tokenParts[0], "Bearer") {
+			return c.Status(401).JSON(fiber.Map{
+				"error": "Format token tidak valid",
+			})
 		}
+		token = tokenParts[1]
+	} else {
+		token = authHeader
+	}
+
+	claims, err := utils.ValidateToken(token)
+	if err != nil {
+		return c.Status(401).JSON(fiber.Map{
+			"error": "Token tidak valid atau expired",
+		})
+	}
+
+	// Token mfa_pending hanya berlaku untuk langkah 2FA
+	if claims.MFAPending && !allowMFAPending {
+		return c.Status(401).JSON(fiber.Map{
+			"error": "Verifikasi 2FA diperlukan",
+		})
+	}
 
-		claims, err := utils.ValidateToken(token)
+	if SessionChecker != nil {
+		active, err := SessionChecker(claims)
 		if err != nil {
+			return c.Status(500).JSON(fiber.Map{
+				"error": "Gagal memeriksa sesi",
+			})
+		}
+		if !active {
 			return c.Status(401).JSON(fiber.Map{
-				"error": "Token tidak valid atau expired",
+				"error": "Sesi sudah berakhir, silakan login ulang",
 			})
 		}
+	}
 
-		// Store user info in context
-		c.Locals("user_id", claims.UserID)
-		c.Locals("username", claims.Username)
-		c.Locals("role", claims.Role)
+	// Store user info in context
+	c.Locals("user_id", claims.UserID)
+	c.Locals("username", claims.Username)
+	c.Locals("role", claims.Role)
+	c.Locals("mfa_pending", claims.MFAPending)
 
-		return c.Next()
-	}
+	return c.Next()
 }
 
 func AdminOnly() fiber.Handler {
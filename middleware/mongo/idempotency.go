@@ -0,0 +1,138 @@
+package mongo
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go-fiber/helper"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// IdempotencyStore menyimpan response per Idempotency-Key. Diisi saat routes didaftarkan;
+// nil berarti memakai store di memori proses.
+var IdempotencyStore helper.IdempotencyStore
+
+var idempotencyMemoryStore = helper.NewMemoryIdempotencyStore()
+
+// Idempotency -> POST dengan header Idempotency-Key dijalankan sekali per user. Response pertama
+// disimpan dan di-replay untuk retry dengan payload yang sama; payload berbeda ditolak 422.
+// Dipasang setelah AuthRequired.
+func Idempotency() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := strings.TrimSpace(c.Get(helper.IdempotencyKeyHeader))
+		// Cukup dijalankan sekali per request bila middleware ini terpasang di lebih dari satu grup
+		if c.Method() != fiber.MethodPost || key == "" || c.Locals("idempotency_key") != nil {
+			return c.Next()
+		}
+		if len(key) > helper.IdempotencyKeyMaxLength {
+			return c.Status(400).JSON(fiber.Map{
+				"error": fmt.Sprintf("%s maksimal %d karakter", helper.IdempotencyKeyHeader, helper.IdempotencyKeyMaxLength),
+			})
+		}
+		userID := c.Locals("user_id")
+		if userID == nil {
+			return c.Next()
+		}
+
+		requestHash, err := idempotencyRequestHash(c)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{
+				"error": "Body request tidak valid",
+			})
+		}
+
+		store := IdempotencyStore
+		if store == nil {
+			store = idempotencyMemoryStore
+		}
+		storeKey := fmt.Sprintf("%v:%s", userID, key)
+		existing, err := store.Reserve(storeKey, requestHash, helper.IdempotencyTTL())
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{
+				"error": "Gagal memeriksa Idempotency-Key",
+			})
+		}
+		if existing != nil {
+			if existing.RequestHash != requestHash {
+				return c.Status(422).JSON(fiber.Map{
+					"error": "Idempotency-Key sudah dipakai untuk request dengan payload berbeda",
+				})
+			}
+			if !existing.Completed {
+				return c.Status(409).JSON(fiber.Map{
+					"error": "Request dengan Idempotency-Key yang sama masih diproses",
+				})
+			}
+			c.Set(helper.IdempotencyReplayedHeader, "true")
+			if existing.ContentType != "" {
+				c.Set(fiber.HeaderContentType, existing.ContentType)
+			}
+			return c.Status(existing.StatusCode).Send(existing.Body)
+		}
+
+		c.Locals("idempotency_key", key)
+		if err := c.Next(); err != nil {
+			_ = store.Release(storeKey)
+			return err
+		}
+
+		// Error server tidak disimpan agar retry benar-benar dijalankan ulang; response berisi
+		// rahasia (Cache-Control: no-store) juga tidak disimpan
+		status := c.Response().StatusCode()
+		noStore := strings.Contains(string(c.Response().Header.Peek(fiber.HeaderCacheControl)), "no-store")
+		if status >= fiber.StatusInternalServerError || noStore {
+			_ = store.Release(storeKey)
+			return nil
+		}
+		_ = store.Complete(storeKey, helper.IdempotencyRecord{
+			RequestHash: requestHash,
+			StatusCode:  status,
+			ContentType: string(c.Response().Header.ContentType()),
+			Body:        bytes.Clone(c.Response().Body()),
+		})
+		return nil
+	}
+}
+
+// idempotencyRequestHash -> SHA-256 dari method, path, dan payload. Multipart di-hash per field
+// dan isi file karena boundary bisa berbeda di setiap retry.
+func idempotencyRequestHash(c *fiber.Ctx) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s\n", c.Method(), c.Path())
+
+	if !strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEMultipartForm) {
+		h.Write(c.Body())
+		return hex.EncodeToString(h.Sum(nil)), nil
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		return "", err
+	}
+	for _, name := range slices.Sorted(maps.Keys(form.Value)) {
+		for _, value := range form.Value[name] {
+			fmt.Fprintf(h, "value %s=%s\n", name, value)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(form.File)) {
+		for _, fh := range form.File[name] {
+			fmt.Fprintf(h, "file %s=%s %d\n", name, fh.Filename, fh.Size)
+			f, err := fh.Open()
+			if err != nil {
+				return "", err
+			}
+			_, err = io.Copy(h, f)
+			f.Close()
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -3,6 +3,7 @@ package mongo
 import (
 	model "go-fiber/app/model/mongo"
 	service "go-fiber/app/service/mongo"
+	"go-fiber/helper"
 	middleware "go-fiber/middleware/mongo"
 
 	"github.com/gofiber/fiber/v2"
@@ -14,45 +15,117 @@ var (
 	_ model.LoginRequest
 	_ model.LoginResponse
 	_ model.GetProfileResponse
+	_ model.ForgotPasswordRequest
+	_ model.ResetPasswordRequest
+	_ model.ChangePasswordRequest
+	_ model.PasswordResponse
+	_ model.ChangePasswordResponse
+	_ model.UnlockAlumniResponse
+	_ model.MFALoginRequest
+	_ model.MFACodeRequest
+	_ model.MFADisableRequest
+	_ model.MFAEnrollResponse
+	_ model.MFARecoveryCodesResponse
+	_ model.MFAResponse
 	_ model.GetAllAlumniResponse
+	_ model.SearchAlumniResponse
+	_ model.GetAlumniEmploymentStatusResponse
 	_ model.GetAlumniByIDResponse
+	_ model.CareerTimelineResponse
 	_ model.CreateAlumniRequest
 	_ model.CreateAlumniResponse
 	_ model.UpdateAlumniRequest
+	_ model.AlumniPatchDocument
+	_ model.BatchRequest
+	_ model.BatchResponse
 	_ model.UpdateAlumniResponse
 	_ model.DeleteAlumniResponse
 	_ model.CheckAlumniResponse
+	_ model.CreateAPIKeyRequest
+	_ model.CreateAPIKeyResponse
+	_ model.ListAPIKeysResponse
+	_ model.APIKeyResponse
+	_ model.AuditLogListResponse
 	_ model.ListRolesResponse
 	_ model.GetRoleByIDResponse
 	_ model.CreateRoleRequest
 	_ model.CreateRoleResponse
 	_ model.UpdateRoleRequest
+	_ model.RolePatchDocument
 	_ model.UpdateRoleResponse
 	_ model.DeleteRoleResponse
 )
 
-func AlumniRoutes(app *fiber.App, db *mongo.Database) {
+// AuthRoutes -> route publik (login, reset password, OIDC, enrollment 2FA, partner API key).
+// Harus didaftarkan sebelum grup protected di main.go agar tidak ikut AuthRequired.
+func AuthRoutes(app *fiber.App, db *mongo.Database) {
 	api := app.Group("/go-fiber-mongo")
 
+	// Token dicabut saat password berubah (session_version)
+	middleware.SessionChecker = service.SessionChecker(db)
+	middleware.APIKeyVerifier = service.APIKeyVerifier(db)
+	middleware.APIKeyRateLimitStore = service.APIKeyRateLimitStore(db)
+	middleware.IdempotencyStore = service.IdempotencyStore(db)
+
 	api.Post("/login", loginHandler(db))
+	api.Post("/password/forgot", forgotPasswordHandler(db))
+	api.Post("/password/reset", resetPasswordHandler(db))
+	api.Post("/login/mfa", mfaLoginHandler(db))
+	api.Get("/auth/oidc/login", oidcLoginHandler(db))
+	api.Get("/auth/oidc/callback", oidcCallbackHandler(db))
+
+	// Enrollment 2FA juga menerima token mfa_pending (admin yang belum punya 2FA);
+	// didaftarkan sebelum grup protected agar tidak melewati AuthRequired
+	mfaSetup := api.Group("/me/mfa", middleware.MFASetupAuth())
+	mfaSetup.Post("/enroll", mfaEnrollHandler(db))
+	mfaSetup.Post("/verify", mfaActivateHandler(db))
+
+	// Client mesin (partner) memakai header X-API-Key, bukan JWT
+	partner := api.Group("/partner")
+	partner.Get("/alumni/check", middleware.APIKeyRequired(helper.APIKeyScopeAlumniCheck), partnerCheckAlumniHandler(db))
+	partner.Get("/alumni", middleware.APIKeyRequired(helper.APIKeyScopeAlumniRead), partnerListAlumniHandler(db))
+	partner.Get("/alumni/:id", middleware.APIKeyRequired(helper.APIKeyScopeAlumniRead), partnerGetAlumniHandler(db))
+}
 
-	protected := api.Group("", middleware.AuthRequired())
+// AlumniRoutes -> route yang membutuhkan JWT; protected sudah memasang AuthRequired dan Idempotency
+func AlumniRoutes(protected fiber.Router, db *mongo.Database) {
 	protected.Get("/profile", profileHandler(db))
+	protected.Post("/me/password", changePasswordHandler(db))
+	protected.Post("/me/mfa/recovery-codes", mfaRecoveryCodesHandler(db))
+	protected.Post("/me/mfa/disable", mfaDisableHandler(db))
 
 	alumni := protected.Group("/alumni")
 	alumni.Get("/", middleware.UserAndAdmin(), getAllAlumniHandler(db))
 	alumni.Get("/check", middleware.UserAndAdmin(), checkAlumniHandler(db))
+	alumni.Get("/search", middleware.UserAndAdmin(), searchAlumniHandler(db))
+	alumni.Get("/employment-status", middleware.UserAndAdmin(), employmentStatusHandler(db))
+	alumni.Get("/export", middleware.AdminOnly(), exportAlumniHandler(db))
+	alumni.Get("/employment-status/export", middleware.AdminOnly(), exportEmploymentStatusHandler(db))
 	alumni.Get("/:id", middleware.UserAndAdmin(), getAlumniByIDHandler(db))
+	alumni.Get("/:id/career", middleware.UserAndAdmin(), alumniCareerHandler(db))
 	alumni.Post("/", middleware.AdminOnly(), createAlumniHandler(db))
+	alumni.Post("/batch", middleware.AdminOnly(), batchAlumniHandler(db))
 	alumni.Put("/:id", middleware.AdminOnly(), updateAlumniHandler(db))
+	alumni.Patch("/:id", middleware.AdminOnly(), patchAlumniHandler(db))
 	alumni.Delete("/:id", middleware.AdminOnly(), deleteAlumniHandler(db))
+	alumni.Post("/:id/unlock", middleware.AdminOnly(), unlockAlumniHandler(db))
+	alumni.Get("/:id/history", middleware.AdminOnly(), alumniHistoryHandler(db))
+
+	apiKeys := protected.Group("/api-keys")
+	apiKeys.Get("/", middleware.AdminOnly(), listAPIKeysHandler(db))
+	apiKeys.Post("/", middleware.AdminOnly(), createAPIKeyHandler(db))
+	apiKeys.Delete("/:id", middleware.AdminOnly(), revokeAPIKeyHandler(db))
 
 	roles := protected.Group("/roles")
 	roles.Get("/", middleware.UserAndAdmin(), listRolesHandler(db))
 	roles.Get("/:id", middleware.UserAndAdmin(), getRoleByIDHandler(db))
 	roles.Post("/", middleware.AdminOnly(), createRoleHandler(db))
 	roles.Put("/:id", middleware.AdminOnly(), updateRoleHandler(db))
+	roles.Patch("/:id", middleware.AdminOnly(), patchRoleHandler(db))
 	roles.Delete("/:id", middleware.AdminOnly(), deleteRoleHandler(db))
+	roles.Get("/:id/history", middleware.AdminOnly(), roleHistoryHandler(db))
+
+	protected.Get("/audit-logs", middleware.AdminOnly(), listAuditLogsHandler(db))
 }
 
 // @Summary Login user (Mongo)
@@ -64,6 +137,7 @@ func AlumniRoutes(app *fiber.App, db *mongo.Database) {
 // @Success 200 {object} model.LoginResponse
 // @Failure 400 {object} fiber.Map
 // @Failure 401 {object} fiber.Map
+// @Failure 429 {object} fiber.Map "Rate limit, jeda progresif, atau akun terkunci (lihat header Retry-After)"
 // @Router /login [post]
 func loginHandler(db *mongo.Database) fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -71,6 +145,171 @@ func loginHandler(db *mongo.Database) fiber.Handler {
 	}
 }
 
+// @Summary Lupa password
+// @Description Mengirim tautan reset password sekali pakai ke email alumni. Response selalu sama walau email tidak terdaftar
+// @Tags Auth (Mongo)
+// @Accept json
+// @Produce json
+// @Param request body model.ForgotPasswordRequest true "Email alumni"
+// @Success 200 {object} model.PasswordResponse
+// @Failure 400 {object} model.PasswordResponse
+// @Failure 500 {object} model.PasswordResponse
+// @Router /password/forgot [post]
+func forgotPasswordHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.ForgotPasswordService(c, db)
+	}
+}
+
+// @Summary Reset password
+// @Description Mengganti password dengan token dari email reset. Token hanya berlaku sekali dan semua sesi dicabut
+// @Tags Auth (Mongo)
+// @Accept json
+// @Produce json
+// @Param request body model.ResetPasswordRequest true "Token dan password baru"
+// @Success 200 {object} model.PasswordResponse
+// @Failure 400 {object} model.PasswordResponse
+// @Failure 500 {object} model.PasswordResponse
+// @Router /password/reset [post]
+func resetPasswordHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.ResetPasswordService(c, db)
+	}
+}
+
+// @Summary Ganti password sendiri
+// @Description Mengganti password dengan memverifikasi password saat ini. Semua sesi lain dicabut dan token baru dikembalikan
+// @Tags Profile (Mongo)
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body model.ChangePasswordRequest true "Password saat ini dan password baru"
+// @Success 200 {object} model.ChangePasswordResponse
+// @Failure 400 {object} model.PasswordResponse
+// @Failure 401 {object} fiber.Map
+// @Failure 500 {object} model.PasswordResponse
+// @Router /me/password [post]
+func changePasswordHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.ChangePasswordService(c, db)
+	}
+}
+
+// @Summary Login SSO (Mongo)
+// @Description Redirect ke provider OIDC (authorization code + PKCE). State disimpan di cookie oidc_state selama 10 menit
+// @Tags Auth (Mongo)
+// @Param login_hint query string false "Diteruskan ke provider sebagai login_hint"
+// @Success 302
+// @Failure 502 {object} fiber.Map
+// @Failure 503 {object} fiber.Map "OIDC belum dikonfigurasi"
+// @Router /auth/oidc/login [get]
+func oidcLoginHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.OIDCLoginService(c, db)
+	}
+}
+
+// @Summary Callback SSO (Mongo)
+// @Description Menukar code dengan ID token, menautkan identitas ke alumni (sub tertaut, email terverifikasi, atau NIM), lalu mengembalikan respons login
+// @Tags Auth (Mongo)
+// @Produce json
+// @Param code query string true "Authorization code"
+// @Param state query string true "State dari redirect login"
+// @Success 200 {object} model.LoginResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 401 {object} fiber.Map
+// @Failure 403 {object} fiber.Map "Identitas tidak cocok dengan alumni mana pun"
+// @Failure 409 {object} fiber.Map "Alumni sudah tertaut ke identitas SSO lain"
+// @Router /auth/oidc/callback [get]
+func oidcCallbackHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.OIDCCallbackService(c, db)
+	}
+}
+
+// @Summary Verifikasi 2FA saat login (Mongo)
+// @Description Menukar mfa_token dari /login dengan token JWT penuh. code berupa kode TOTP 6 digit atau kode pemulihan
+// @Tags Auth (Mongo)
+// @Accept json
+// @Produce json
+// @Param request body model.MFALoginRequest true "Token mfa_pending dan kode 2FA"
+// @Success 200 {object} model.LoginResponse
+// @Failure 400 {object} model.MFAResponse
+// @Failure 401 {object} model.MFAResponse
+// @Failure 403 {object} model.MFAResponse
+// @Failure 429 {object} fiber.Map
+// @Router /login/mfa [post]
+func mfaLoginHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.MFALoginService(c, db)
+	}
+}
+
+// @Summary Mulai enrollment 2FA
+// @Description Membuat secret TOTP dan otpauth URI. 2FA aktif setelah kode diverifikasi di /me/mfa/verify. Menerima token mfa_pending
+// @Tags Profile (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} model.MFAEnrollResponse
+// @Failure 401 {object} fiber.Map
+// @Failure 409 {object} model.MFAResponse
+// @Router /me/mfa/enroll [post]
+func mfaEnrollHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.MFAEnrollService(c, db)
+	}
+}
+
+// @Summary Aktifkan 2FA
+// @Description Memverifikasi kode dari secret enrollment lalu mengembalikan kode pemulihan (sekali tampil). Dengan token mfa_pending, token penuh ikut dikembalikan
+// @Tags Profile (Mongo)
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body model.MFACodeRequest true "Kode TOTP"
+// @Success 200 {object} model.MFARecoveryCodesResponse
+// @Failure 400 {object} model.MFAResponse
+// @Failure 409 {object} model.MFAResponse
+// @Router /me/mfa/verify [post]
+func mfaActivateHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.MFAActivateService(c, db)
+	}
+}
+
+// @Summary Buat ulang kode pemulihan 2FA
+// @Description Mengganti semua kode pemulihan; kode lama tidak berlaku lagi
+// @Tags Profile (Mongo)
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body model.MFACodeRequest true "Kode TOTP atau kode pemulihan"
+// @Success 200 {object} model.MFARecoveryCodesResponse
+// @Failure 400 {object} model.MFAResponse
+// @Router /me/mfa/recovery-codes [post]
+func mfaRecoveryCodesHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.MFARecoveryCodesService(c, db)
+	}
+}
+
+// @Summary Matikan 2FA
+// @Description Mematikan 2FA dengan password dan kode 2FA. Tidak tersedia untuk admin
+// @Tags Profile (Mongo)
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body model.MFADisableRequest true "Password dan kode 2FA"
+// @Success 200 {object} model.MFAResponse
+// @Failure 400 {object} model.MFAResponse
+// @Failure 403 {object} model.MFAResponse
+// @Router /me/mfa/disable [post]
+func mfaDisableHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.MFADisableService(c, db)
+	}
+}
+
 // @Summary Profil pengguna saat ini
 // @Description Mengambil profil user berdasarkan token
 // @Tags Profile (Mongo)
@@ -86,7 +325,7 @@ func profileHandler(db *mongo.Database) fiber.Handler {
 }
 
 // @Summary Daftar alumni
-// @Description Mengambil daftar alumni dengan pagination, sorting, dan pencarian
+// @Description Mengambil daftar alumni dengan pagination (offset atau cursor), sorting, dan pencarian
 // @Tags Alumni (Mongo)
 // @Produce json
 // @Security BearerAuth
@@ -95,7 +334,12 @@ func profileHandler(db *mongo.Database) fiber.Handler {
 // @Param sortBy query string false "Kolom sortir"
 // @Param order query string false "Urutan asc/desc"
 // @Param search query string false "Kata kunci pencarian"
+// @Param filter[field][op] query string false "Filter terstruktur, mis. filter[angkatan][gte]=2018, filter[jurusan][in]=TI,SI"
+// @Param after query string false "Cursor dari meta.next_cursor (menggantikan page)"
+// @Param before query string false "Cursor dari meta.prev_cursor"
+// @Param with_total query bool false "Hitung total data pada mode cursor"
 // @Success 200 {object} model.GetAllAlumniResponse
+// @Failure 400 {object} fiber.Map
 // @Failure 401 {object} fiber.Map
 // @Failure 500 {object} fiber.Map
 // @Router /alumni [get]
@@ -105,6 +349,85 @@ func getAllAlumniHandler(db *mongo.Database) fiber.Handler {
 	}
 }
 
+// @Summary Cari alumni (full-text)
+// @Description Full-text search alumni pada nama, nim, email, dan jurusan dengan ranking relevansi dan highlight
+// @Tags Alumni (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "Kata kunci pencarian"
+// @Param page query int false "Halaman"
+// @Param limit query int false "Jumlah per halaman (maks 100)"
+// @Success 200 {object} model.SearchAlumniResponse
+// @Failure 400 {object} model.SearchAlumniResponse
+// @Failure 500 {object} model.SearchAlumniResponse
+// @Router /alumni/search [get]
+func searchAlumniHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.SearchAlumniService(c, db)
+	}
+}
+
+// @Summary Status pekerjaan alumni
+// @Description Rekap pekerjaan terbaru tiap alumni, mendukung filter terstruktur filter[field][op]=value
+// @Tags Alumni (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Halaman"
+// @Param limit query int false "Jumlah per halaman"
+// @Param sortBy query string false "nama (default), angkatan, tanggal_mulai_kerja, gaji_min, gaji_max; nilai kosong selalu di akhir"
+// @Param order query string false "asc atau desc"
+// @Param filter[field][op] query string false "Filter terstruktur, mis. filter[employment_count][gte]=2, filter[gaji_min][gte]=8000000, filter[gaji_periode][eq]=bulanan"
+// @Success 200 {object} model.GetAlumniEmploymentStatusResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 500 {object} fiber.Map
+// @Router /alumni/employment-status [get]
+func employmentStatusHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.GetAlumniEmploymentStatusService(c, db)
+	}
+}
+
+// @Summary Export alumni
+// @Description Export alumni sebagai CSV, XLSX, NDJSON, atau laporan PDF dengan search, sortBy, order, dan filter yang sama seperti GET /alumni. Export di atas 10000 baris atau dengan async=true berjalan di background (202)
+// @Tags Export (Mongo)
+// @Produce text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,application/x-ndjson,application/pdf,application/json
+// @Security BearerAuth
+// @Param format query string false "csv (default), xlsx, ndjson, pdf"
+// @Param async query bool false "Jalankan sebagai job background"
+// @Param sortBy query string false "Kolom sortir"
+// @Param order query string false "Urutan asc/desc"
+// @Param search query string false "Kata kunci pencarian"
+// @Param filter[field][op] query string false "Filter terstruktur, sama dengan GET /alumni"
+// @Success 200 {file} file
+// @Success 202 {object} model.ExportJobResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 500 {object} fiber.Map
+// @Router /alumni/export [get]
+func exportAlumniHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.ExportAlumniService(c, db)
+	}
+}
+
+// @Summary Export status pekerjaan alumni
+// @Description Export rekap status pekerjaan alumni tanpa pagination, filter sama dengan GET /alumni/employment-status
+// @Tags Export (Mongo)
+// @Produce text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,application/x-ndjson,application/pdf,application/json
+// @Security BearerAuth
+// @Param format query string false "csv (default), xlsx, ndjson, pdf"
+// @Param async query bool false "Jalankan sebagai job background"
+// @Param filter[field][op] query string false "Filter terstruktur, sama dengan GET /alumni/employment-status"
+// @Success 200 {file} file
+// @Success 202 {object} model.ExportJobResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 500 {object} fiber.Map
+// @Router /alumni/employment-status/export [get]
+func exportEmploymentStatusHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.ExportAlumniEmploymentStatusService(c, db)
+	}
+}
+
 // @Summary Detail alumni
 // @Description Mengambil data alumni berdasarkan ID
 // @Tags Alumni (Mongo)
@@ -121,6 +444,22 @@ func getAlumniByIDHandler(db *mongo.Database) fiber.Handler {
 	}
 }
 
+// @Summary Timeline karier alumni
+// @Description Riwayat pekerjaan alumni berurutan dengan masa kerja per pekerjaan, celah antar pekerjaan, pekerjaan penuh waktu yang tumpang tindih, dan total pengalaman
+// @Tags Alumni (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID Alumni"
+// @Success 200 {object} model.CareerTimelineResponse
+// @Failure 400 {object} model.CareerTimelineResponse
+// @Failure 404 {object} model.CareerTimelineResponse
+// @Router /alumni/{id}/career [get]
+func alumniCareerHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.CareerTimelineService(c, db)
+	}
+}
+
 // @Summary Tambah alumni
 // @Description Membuat data alumni baru
 // @Tags Alumni (Mongo)
@@ -156,6 +495,47 @@ func updateAlumniHandler(db *mongo.Database) fiber.Handler {
 	}
 }
 
+// @Summary Batch create/update/delete alumni
+// @Description Menjalankan banyak operasi sekaligus. all_or_nothing (default) menyimpan semua atau tidak sama sekali, best_effort menyimpan operasi yang berhasil saja. Update memakai merge patch; batas jumlah operasi diatur BATCH_MAX_OPERATIONS
+// @Tags Alumni (Mongo)
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body model.BatchRequest true "Mode dan daftar operasi"
+// @Success 200 {object} model.BatchResponse
+// @Success 207 {object} model.BatchResponse
+// @Failure 400 {object} model.BatchResponse
+// @Failure 413 {object} model.BatchResponse
+// @Failure 500 {object} model.BatchResponse
+// @Router /alumni/batch [post]
+func batchAlumniHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.BatchAlumniService(c, db)
+	}
+}
+
+// @Summary Ubah sebagian alumni
+// @Description Menerapkan JSON Merge Patch (RFC 7396) atau JSON Patch (RFC 6902) ke alumni; null menghapus field opsional
+// @Tags Alumni (Mongo)
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID Alumni"
+// @Param If-Match header string false "ETag dari response GET"
+// @Param request body model.AlumniPatchDocument true "Merge patch (application/merge-patch+json) atau array operasi JSON Patch (application/json-patch+json)"
+// @Success 200 {object} model.UpdateAlumniResponse
+// @Failure 400 {object} model.UpdateAlumniResponse
+// @Failure 404 {object} model.UpdateAlumniResponse
+// @Failure 409 {object} model.UpdateAlumniResponse
+// @Failure 412 {object} model.UpdateAlumniResponse
+// @Failure 415 {object} model.UpdateAlumniResponse
+// @Router /alumni/{id} [patch]
+func patchAlumniHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.PatchAlumniService(c, db)
+	}
+}
+
 // @Summary Hapus alumni
 // @Description Menghapus data alumni berdasarkan ID
 // @Tags Alumni (Mongo)
@@ -172,8 +552,24 @@ func deleteAlumniHandler(db *mongo.Database) fiber.Handler {
 	}
 }
 
+// @Summary Buka kunci akun alumni
+// @Description Menghapus kunci sementara, counter gagal login, dan rate limit akun alumni
+// @Tags Alumni (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID Alumni"
+// @Success 200 {object} model.UnlockAlumniResponse
+// @Failure 400 {object} model.UnlockAlumniResponse
+// @Failure 404 {object} model.UnlockAlumniResponse
+// @Router /alumni/{id}/unlock [post]
+func unlockAlumniHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.UnlockAlumniService(c, db)
+	}
+}
+
 // @Summary Cek alumni berdasarkan NIM
-// @Description Mengecek status alumni menggunakan API key legacy
+// @Description Mengecek status alumni menggunakan API key legacy (API_KEY tunggal). Deprecated: gunakan /partner/alumni/check dengan header X-API-Key; nonaktif bila LEGACY_API_KEY_ENABLED=false
 // @Tags Alumni (Mongo)
 // @Produce json
 // @Security BearerAuth
@@ -182,13 +578,179 @@ func deleteAlumniHandler(db *mongo.Database) fiber.Handler {
 // @Success 200 {object} model.CheckAlumniResponse
 // @Failure 400 {object} fiber.Map
 // @Failure 401 {object} fiber.Map
+// @Failure 410 {object} fiber.Map "Endpoint legacy dinonaktifkan"
 // @Router /alumni/check [get]
+// @Deprecated
 func checkAlumniHandler(db *mongo.Database) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		return service.CheckAlumniService(c, db)
 	}
 }
 
+// @Summary Cek alumni berdasarkan NIM (partner)
+// @Description Mengecek status alumni untuk client mesin dengan API key ber-scope alumni:check
+// @Tags Partner (Mongo)
+// @Produce json
+// @Security APIKeyAuth
+// @Param nim query string true "NIM Mahasiswa"
+// @Success 200 {object} model.CheckAlumniResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 401 {object} fiber.Map
+// @Failure 403 {object} fiber.Map "Scope API key tidak mencukupi"
+// @Failure 429 {object} fiber.Map "Rate limit API key terlampaui (lihat header Retry-After)"
+// @Router /partner/alumni/check [get]
+func partnerCheckAlumniHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.PartnerCheckAlumniService(c, db)
+	}
+}
+
+// @Summary Daftar alumni (partner)
+// @Description Mengambil daftar alumni untuk client mesin dengan API key ber-scope alumni:read. Query sama dengan GET /alumni
+// @Tags Partner (Mongo)
+// @Produce json
+// @Security APIKeyAuth
+// @Success 200 {object} model.GetAllAlumniResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 401 {object} fiber.Map
+// @Failure 403 {object} fiber.Map "Scope API key tidak mencukupi"
+// @Failure 429 {object} fiber.Map "Rate limit API key terlampaui (lihat header Retry-After)"
+// @Router /partner/alumni [get]
+func partnerListAlumniHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.GetAllAlumniService(c, db)
+	}
+}
+
+// @Summary Detail alumni (partner)
+// @Description Mengambil data alumni berdasarkan ID untuk client mesin dengan API key ber-scope alumni:read
+// @Tags Partner (Mongo)
+// @Produce json
+// @Security APIKeyAuth
+// @Param id path string true "ID Alumni"
+// @Success 200 {object} model.GetAlumniByIDResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 401 {object} fiber.Map
+// @Failure 403 {object} fiber.Map "Scope API key tidak mencukupi"
+// @Failure 404 {object} fiber.Map
+// @Router /partner/alumni/{id} [get]
+func partnerGetAlumniHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.GetAlumniByIDService(c, db)
+	}
+}
+
+// @Summary Daftar API key
+// @Description Mengambil semua API key partner (tanpa key maupun hash-nya)
+// @Tags API Keys (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} model.ListAPIKeysResponse
+// @Failure 403 {object} fiber.Map
+// @Failure 500 {object} fiber.Map
+// @Router /api-keys [get]
+func listAPIKeysHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.ListAPIKeysService(c, db)
+	}
+}
+
+// @Summary Buat API key
+// @Description Membuat API key partner dengan scope, rate limit (request per menit, 0 = tanpa batas) dan masa berlaku opsional. Key hanya ditampilkan sekali
+// @Tags API Keys (Mongo)
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body model.CreateAPIKeyRequest true "Data API key"
+// @Success 201 {object} model.CreateAPIKeyResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 403 {object} fiber.Map
+// @Failure 500 {object} fiber.Map
+// @Router /api-keys [post]
+func createAPIKeyHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.CreateAPIKeyService(c, db)
+	}
+}
+
+// @Summary Cabut API key
+// @Description Mencabut API key partner; key langsung tidak bisa dipakai lagi
+// @Tags API Keys (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID API key"
+// @Success 200 {object} model.APIKeyResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 403 {object} fiber.Map
+// @Failure 404 {object} fiber.Map
+// @Router /api-keys/{id} [delete]
+func revokeAPIKeyHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.RevokeAPIKeyService(c, db)
+	}
+}
+
+// @Summary Riwayat perubahan alumni
+// @Description Audit log satu alumni (perubahan data, login, unlock), terbaru lebih dulu. Tetap tersedia setelah alumni dihapus
+// @Tags Audit (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID Alumni"
+// @Param page query int false "Halaman" default(1)
+// @Param limit query int false "Jumlah per halaman (maks 100)" default(20)
+// @Success 200 {object} model.AuditLogListResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 403 {object} fiber.Map
+// @Router /alumni/{id}/history [get]
+func alumniHistoryHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.AlumniHistoryService(c, db)
+	}
+}
+
+// @Summary Riwayat perubahan role
+// @Description Audit log satu role, terbaru lebih dulu
+// @Tags Audit (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID Role"
+// @Param page query int false "Halaman" default(1)
+// @Param limit query int false "Jumlah per halaman (maks 100)" default(20)
+// @Success 200 {object} model.AuditLogListResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 403 {object} fiber.Map
+// @Router /roles/{id}/history [get]
+func roleHistoryHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.RoleHistoryService(c, db)
+	}
+}
+
+// @Summary Query audit log
+// @Description Audit log semua perubahan data dan event autentikasi, terbaru lebih dulu
+// @Tags Audit (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Param actor_id query string false "ID alumni pelaku"
+// @Param actor_role query string false "Role pelaku"
+// @Param action query string false "create, update, delete, soft_delete, restore, upload, import, unlock, revoke, login, atau login_failed"
+// @Param entity_type query string false "alumni, pekerjaan, role, file, file_category, atau api_key"
+// @Param entity_id query string false "ID entity"
+// @Param request_id query string false "X-Request-ID request asal"
+// @Param from query string false "Sejak (RFC3339 atau YYYY-MM-DD)"
+// @Param to query string false "Sampai (RFC3339 atau YYYY-MM-DD, inklusif)"
+// @Param page query int false "Halaman" default(1)
+// @Param limit query int false "Jumlah per halaman (maks 100)" default(20)
+// @Success 200 {object} model.AuditLogListResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 403 {object} fiber.Map
+// @Router /audit-logs [get]
+func listAuditLogsHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.ListAuditLogsService(c, db)
+	}
+}
+
 // @Summary Daftar role
 // @Description Mengambil seluruh role yang tersedia
 // @Tags Roles (Mongo)
@@ -252,6 +814,28 @@ func updateRoleHandler(db *mongo.Database) fiber.Handler {
 	}
 }
 
+// @Summary Ubah sebagian role
+// @Description Menerapkan JSON Merge Patch (RFC 7396) atau JSON Patch (RFC 6902) ke role
+// @Tags Roles (Mongo)
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID Role"
+// @Param If-Match header string false "ETag dari response GET"
+// @Param request body model.RolePatchDocument true "Merge patch (application/merge-patch+json) atau array operasi JSON Patch (application/json-patch+json)"
+// @Success 200 {object} model.UpdateRoleResponse
+// @Failure 400 {object} model.UpdateRoleResponse
+// @Failure 404 {object} model.UpdateRoleResponse
+// @Failure 409 {object} model.UpdateRoleResponse
+// @Failure 412 {object} model.UpdateRoleResponse
+// @Failure 415 {object} model.UpdateRoleResponse
+// @Router /roles/{id} [patch]
+func patchRoleHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.PatchRoleService(c, db)
+	}
+}
+
 // @Summary Hapus role
 // @Description Menghapus role berdasarkan ID
 // @Tags Roles (Mongo)
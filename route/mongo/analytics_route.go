@@ -0,0 +1,137 @@
+package mongo
+
+import (
+	model "go-fiber/app/model/mongo"
+	service "go-fiber/app/service/mongo"
+	middleware "go-fiber/middleware/mongo"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// swagger:ignore
+var (
+	_ model.EmploymentRateResponse
+	_ model.TimeToFirstJobResponse
+	_ model.DistributionResponse
+	_ model.SalaryHistogramResponse
+	_ model.RetentionResponse
+)
+
+func AnalyticsRoutes(protected fiber.Router, db *mongo.Database) {
+	analytics := protected.Group("/analytics", middleware.AdminOnly())
+
+	analytics.Get("/employment-rate", employmentRateHandler(db))
+	analytics.Get("/time-to-first-job", timeToFirstJobHandler(db))
+	analytics.Get("/distribution", jobDistributionHandler(db))
+	analytics.Get("/salary", salaryHistogramHandler(db))
+	analytics.Get("/retention", jobRetentionHandler(db))
+	analytics.Get("/export", exportAnalyticsHandler(db))
+}
+
+// @Summary Employment rate alumni
+// @Description Persentase alumni yang sedang bekerja (pekerjaan aktif) per kelompok
+// @Tags Analytics (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Param group_by query string false "angkatan (default), jurusan, tahun_lulus, atau all"
+// @Param filter[field][op] query string false "Filter kohort: jurusan, angkatan, tahun_lulus"
+// @Success 200 {object} model.EmploymentRateResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 500 {object} fiber.Map
+// @Router /analytics/employment-rate [get]
+func employmentRateHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.GetEmploymentRateService(c, db)
+	}
+}
+
+// @Summary Time-to-first-job
+// @Description Median dan rata-rata bulan dari kelulusan (Juli tahun_lulus) ke pekerjaan pertama
+// @Tags Analytics (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Param group_by query string false "all (default), angkatan, jurusan, tahun_lulus"
+// @Param filter[field][op] query string false "Filter kohort: jurusan, angkatan, tahun_lulus"
+// @Success 200 {object} model.TimeToFirstJobResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 500 {object} fiber.Map
+// @Router /analytics/time-to-first-job [get]
+func timeToFirstJobHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.GetTimeToFirstJobService(c, db)
+	}
+}
+
+// @Summary Distribusi pekerjaan
+// @Description Jumlah pekerjaan dan alumni per bidang_industri atau lokasi_kerja
+// @Tags Analytics (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Param field query string false "bidang_industri (default) atau lokasi_kerja"
+// @Param active_only query bool false "Hanya pekerjaan berstatus aktif"
+// @Param filter[field][op] query string false "Filter kohort: jurusan, angkatan, tahun_lulus"
+// @Success 200 {object} model.DistributionResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 500 {object} fiber.Map
+// @Router /analytics/distribution [get]
+func jobDistributionHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.GetJobDistributionService(c, db)
+	}
+}
+
+// @Summary Histogram gaji
+// @Description Histogram gaji (juta rupiah per bulan) dari titik tengah gaji_min / gaji_max; gaji tahunan dibagi 12, gaji_range lama yang belum dimigrasi tetap dibaca
+// @Tags Analytics (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Param active_only query bool false "Hanya pekerjaan berstatus aktif"
+// @Param filter[field][op] query string false "Filter kohort: jurusan, angkatan, tahun_lulus"
+// @Success 200 {object} model.SalaryHistogramResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 500 {object} fiber.Map
+// @Router /analytics/salary [get]
+func salaryHistogramHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.GetSalaryHistogramService(c, db)
+	}
+}
+
+// @Summary Retensi pekerjaan
+// @Description Persentase pekerjaan yang bertahan lebih dari 1 tahun
+// @Tags Analytics (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Param group_by query string false "all (default), angkatan, jurusan, tahun_lulus"
+// @Param filter[field][op] query string false "Filter kohort: jurusan, angkatan, tahun_lulus"
+// @Success 200 {object} model.RetentionResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 500 {object} fiber.Map
+// @Router /analytics/retention [get]
+func jobRetentionHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.GetJobRetentionService(c, db)
+	}
+}
+
+// @Summary Export analytics
+// @Description Export hasil satu metric analytics sebagai CSV, XLSX, NDJSON, atau laporan PDF
+// @Tags Export (Mongo)
+// @Produce text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,application/x-ndjson,application/pdf,application/json
+// @Security BearerAuth
+// @Param metric query string true "employment-rate, time-to-first-job, distribution, salary, retention"
+// @Param format query string false "csv (default), xlsx, ndjson, pdf"
+// @Param group_by query string false "angkatan, jurusan, tahun_lulus, atau all"
+// @Param field query string false "Untuk distribution: bidang_industri atau lokasi_kerja"
+// @Param active_only query bool false "Untuk distribution dan salary: hanya pekerjaan aktif"
+// @Param filter[field][op] query string false "Filter kohort: jurusan, angkatan, tahun_lulus"
+// @Success 200 {file} file
+// @Failure 400 {object} fiber.Map
+// @Failure 500 {object} fiber.Map
+// @Router /analytics/export [get]
+func exportAnalyticsHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.ExportAnalyticsService(c, db)
+	}
+}
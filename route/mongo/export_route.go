@@ -0,0 +1,52 @@
+package mongo
+
+import (
+	model "go-fiber/app/model/mongo"
+	service "go-fiber/app/service/mongo"
+	middleware "go-fiber/middleware/mongo"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// swagger:ignore
+var (
+	_ model.ExportJobResponse
+)
+
+func ExportRoutes(protected fiber.Router, db *mongo.Database) {
+	protected.Get("/exports/:id", middleware.AdminOnly(), getExportJobHandler(db))
+	protected.Get("/exports/:id/download", middleware.AdminOnly(), downloadExportHandler(db))
+}
+
+// @Summary Status job export
+// @Description Progress export dari antrean job (status queued, running, completed, failed): status, jumlah baris, dan download_url setelah selesai
+// @Tags Export (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID job export"
+// @Success 200 {object} model.ExportJobResponse
+// @Failure 404 {object} fiber.Map
+// @Router /exports/{id} [get]
+func getExportJobHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.GetExportJobService(c, db)
+	}
+}
+
+// @Summary Unduh hasil export
+// @Description Mengunduh file hasil job export yang sudah completed (file disimpan 24 jam)
+// @Tags Export (Mongo)
+// @Produce text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,application/x-ndjson,application/pdf,application/json
+// @Security BearerAuth
+// @Param id path string true "ID job export"
+// @Success 200 {file} file
+// @Failure 404 {object} fiber.Map
+// @Failure 409 {object} fiber.Map
+// @Failure 410 {object} fiber.Map
+// @Router /exports/{id}/download [get]
+func downloadExportHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.DownloadExportService(c, db)
+	}
+}
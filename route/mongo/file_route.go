@@ -10,18 +10,50 @@ import (
 )
 
 // swagger:ignore
-var _ model.FileUploadResponse
-
-func FileRoutes(app *fiber.App, db *goMongo.Database) {
-	api := app.Group("/go-fiber-mongo")
+var (
+	_ model.FileUploadResponse
+	_ model.ListFilesResponse
+	_ model.ListFileCategoriesResponse
+	_ model.FileCategoryResponse
+	_ model.UpsertFileCategoryRequest
+)
 
-	files := api.Group("/users/:id/upload", middleware.AuthRequired(), middleware.UserSelfOrAdmin())
+func FileRoutes(protected fiber.Router, db *goMongo.Database) {
+	files := protected.Group("/users/:id/upload", middleware.UserSelfOrAdmin())
 	files.Post("/photo", uploadPhotoHandler(db))
 	files.Post("/certificate", uploadCertificateHandler(db))
+	files.Post("/:category", uploadFileHandler(db))
+
+	protected.Get("/users/:id/files", middleware.UserSelfOrAdmin(), listFilesHandler(db))
+
+	categories := protected.Group("/file-categories")
+	categories.Get("/", middleware.UserAndAdmin(), listFileCategoriesHandler(db))
+	categories.Put("/:name", middleware.AdminOnly(), upsertFileCategoryHandler(db))
+}
+
+// @Summary Daftar file user
+// @Description Daftar file milik user (terbaru dulu) dengan cursor pagination
+// @Tags Files (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID User"
+// @Param limit query int false "Jumlah per halaman (maks 100)"
+// @Param after query string false "Cursor dari meta.next_cursor"
+// @Param before query string false "Cursor dari meta.prev_cursor"
+// @Param with_total query bool false "Sertakan total data"
+// @Param filter[category] query string false "Filter kategori, mis. filter[category][in]=cv,transcript"
+// @Success 200 {object} model.ListFilesResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 403 {object} fiber.Map
+// @Router /users/{id}/files [get]
+func listFilesHandler(db *goMongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.ListFilesService(c, db)
+	}
 }
 
 // @Summary Upload foto profil
-// @Description Upload file foto jpeg/jpg/png maksimal 1MB untuk user tertentu
+// @Description Upload foto untuk user tertentu sesuai kebijakan kategori photo (bawaan jpeg/jpg/png maksimal 1MB)
 // @Tags Files (Mongo)
 // @Accept multipart/form-data
 // @Produce json
@@ -40,7 +72,7 @@ func uploadPhotoHandler(db *goMongo.Database) fiber.Handler {
 }
 
 // @Summary Upload sertifikat/ijazah
-// @Description Upload file pdf maksimal 2MB untuk user tertentu
+// @Description Upload sertifikat untuk user tertentu sesuai kebijakan kategori certificate (bawaan pdf maksimal 2MB)
 // @Tags Files (Mongo)
 // @Accept multipart/form-data
 // @Produce json
@@ -57,3 +89,56 @@ func uploadCertificateHandler(db *goMongo.Database) fiber.Handler {
 		return service.UploadCertificateService(c, db)
 	}
 }
+
+// @Summary Upload dokumen per kategori
+// @Description Upload file sesuai kebijakan kategori (tipe MIME, ukuran, jumlah maksimum, single/multiple, retensi)
+// @Tags Files (Mongo)
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID User"
+// @Param category path string true "Kategori dokumen (photo, certificate, transcript, cv, id_card, award_letter, ...)"
+// @Param file formData file true "File dokumen"
+// @Success 201 {object} model.FileUploadResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 403 {object} fiber.Map
+// @Failure 404 {object} fiber.Map
+// @Failure 500 {object} fiber.Map
+// @Router /users/{id}/upload/{category} [post]
+func uploadFileHandler(db *goMongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.UploadFileService(c, db)
+	}
+}
+
+// @Summary Daftar kategori dokumen
+// @Description Mengambil seluruh kategori dokumen beserta kebijakan upload-nya
+// @Tags Files (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} model.ListFileCategoriesResponse
+// @Failure 500 {object} model.ListFileCategoriesResponse
+// @Router /file-categories [get]
+func listFileCategoriesHandler(db *goMongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.ListFileCategoriesService(c, db)
+	}
+}
+
+// @Summary Simpan kategori dokumen
+// @Description Membuat atau memperbarui kebijakan kategori dokumen
+// @Tags Files (Mongo)
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Nama kategori"
+// @Param request body model.UpsertFileCategoryRequest true "Kebijakan kategori"
+// @Success 200 {object} model.FileCategoryResponse
+// @Failure 400 {object} model.FileCategoryResponse
+// @Failure 500 {object} model.FileCategoryResponse
+// @Router /file-categories/{name} [put]
+func upsertFileCategoryHandler(db *goMongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.UpsertFileCategoryService(c, db)
+	}
+}
@@ -0,0 +1,75 @@
+package mongo
+
+import (
+	model "go-fiber/app/model/mongo"
+	service "go-fiber/app/service/mongo"
+	middleware "go-fiber/middleware/mongo"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// swagger:ignore
+var (
+	_ model.ImportReportResponse
+	_ model.ImportJobResponse
+)
+
+func ImportRoutes(protected fiber.Router, db *mongo.Database) {
+	protected.Post("/alumni/import", middleware.AdminOnly(), importAlumniHandler(db))
+	protected.Post("/pekerjaan/import", middleware.AdminOnly(), importPekerjaanHandler(db))
+	protected.Get("/imports/:id", middleware.AdminOnly(), getImportJobHandler(db))
+}
+
+// @Summary Import alumni dari CSV/XLSX
+// @Description Upsert alumni berdasarkan NIM atau email. Kolom wajib: nim, nama, jurusan, angkatan, tahun_lulus, email. dry_run=true hanya mengembalikan laporan validasi; tanpa dry_run import berjalan di background
+// @Tags Import (Mongo)
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "File .csv atau .xlsx (maks 5000 baris)"
+// @Param dry_run query bool false "Validasi saja tanpa menyimpan"
+// @Success 200 {object} model.ImportReportResponse
+// @Success 202 {object} model.ImportJobResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 500 {object} fiber.Map
+// @Router /alumni/import [post]
+func importAlumniHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.ImportAlumniService(c, db)
+	}
+}
+
+// @Summary Import pekerjaan alumni dari CSV/XLSX
+// @Description Upsert pekerjaan berdasarkan alumni, nama_perusahaan, posisi_jabatan, dan tanggal_mulai_kerja. Alumni dicari lewat kolom nim atau alumni_id
+// @Tags Import (Mongo)
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "File .csv atau .xlsx (maks 5000 baris)"
+// @Param dry_run query bool false "Validasi saja tanpa menyimpan"
+// @Success 200 {object} model.ImportReportResponse
+// @Success 202 {object} model.ImportJobResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 500 {object} fiber.Map
+// @Router /pekerjaan/import [post]
+func importPekerjaanHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.ImportPekerjaanService(c, db)
+	}
+}
+
+// @Summary Status job import
+// @Description Progress job import dari antrean job (status queued, running, completed, failed): jumlah baris diproses, dibuat, diupdate, gagal, dan error per baris
+// @Tags Import (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID job import"
+// @Success 200 {object} model.ImportJobResponse
+// @Failure 404 {object} fiber.Map
+// @Router /imports/{id} [get]
+func getImportJobHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.GetImportJobService(c, db)
+	}
+}
@@ -0,0 +1,90 @@
+package mongo
+
+import (
+	model "go-fiber/app/model/mongo"
+	service "go-fiber/app/service/mongo"
+	middleware "go-fiber/middleware/mongo"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// swagger:ignore
+var (
+	_ model.JobListResponse
+	_ model.JobResponse
+)
+
+func JobRoutes(protected fiber.Router, db *mongo.Database) {
+	protected.Get("/jobs", middleware.AdminOnly(), listJobsHandler(db))
+	protected.Get("/jobs/:id", middleware.AdminOnly(), getJobHandler(db))
+	protected.Post("/jobs/:id/retry", middleware.AdminOnly(), retryJobHandler(db))
+	protected.Post("/jobs/:id/cancel", middleware.AdminOnly(), cancelJobHandler(db))
+}
+
+// @Summary Daftar job background
+// @Description Job terbaru dari antrean, bisa difilter berdasarkan status dan type
+// @Tags Jobs (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "queued, running, completed, dead, atau cancelled"
+// @Param type query string false "Type job, mis. file.retention_purge"
+// @Param page query int false "Halaman" default(1)
+// @Param limit query int false "Jumlah per halaman (maks 100)" default(10)
+// @Success 200 {object} model.JobListResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 500 {object} fiber.Map
+// @Router /jobs [get]
+func listJobsHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.ListJobsService(c, db)
+	}
+}
+
+// @Summary Detail job background
+// @Description Status, jumlah percobaan, waktu jalan berikutnya (run_at), dan error terakhir sebuah job
+// @Tags Jobs (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID job"
+// @Success 200 {object} model.JobResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 404 {object} fiber.Map
+// @Router /jobs/{id} [get]
+func getJobHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.GetJobService(c, db)
+	}
+}
+
+// @Summary Retry job
+// @Description Memasukkan kembali job dead atau cancelled ke antrean dengan jatah percobaan baru
+// @Tags Jobs (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID job"
+// @Success 200 {object} model.JobResponse
+// @Failure 404 {object} fiber.Map
+// @Failure 409 {object} fiber.Map
+// @Router /jobs/{id}/retry [post]
+func retryJobHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.RetryJobService(c, db)
+	}
+}
+
+// @Summary Batalkan job
+// @Description Membatalkan job queued atau running; job running dihentikan pada heartbeat berikutnya
+// @Tags Jobs (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID job"
+// @Success 200 {object} model.JobResponse
+// @Failure 404 {object} fiber.Map
+// @Failure 409 {object} fiber.Map
+// @Router /jobs/{id}/cancel [post]
+func cancelJobHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.CancelJobService(c, db)
+	}
+}
@@ -0,0 +1,210 @@
+package mongo
+
+import (
+	model "go-fiber/app/model/mongo"
+	service "go-fiber/app/service/mongo"
+	middleware "go-fiber/middleware/mongo"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// swagger:ignore
+var (
+	_ model.MeProfileResponse
+	_ model.MePatchDocument
+	_ model.CreateAccountDeletionRequest
+	_ model.ProcessAccountDeletionRequest
+	_ model.AccountDeletionResponse
+	_ model.ListAccountDeletionResponse
+)
+
+// MeRoutes -> profil dan data milik alumni yang sedang login; identitas selalu dari token, tanpa :id
+func MeRoutes(protected fiber.Router, db *mongo.Database) {
+	me := protected.Group("/me")
+	me.Get("/", middleware.UserAndAdmin(), getMeHandler(db))
+	me.Patch("/", middleware.UserAndAdmin(), patchMeHandler(db))
+	me.Delete("/", middleware.UserAndAdmin(), requestAccountDeletionHandler(db))
+	me.Get("/pekerjaan", middleware.UserAndAdmin(), getMyPekerjaanHandler(db))
+	me.Post("/pekerjaan", middleware.UserAndAdmin(), createMyPekerjaanHandler(db))
+	me.Patch("/pekerjaan/:id", middleware.UserAndAdmin(), patchMyPekerjaanHandler(db))
+	me.Get("/files", middleware.UserAndAdmin(), listMyFilesHandler(db))
+
+	deletions := protected.Group("/account-deletions")
+	deletions.Get("/", middleware.AdminOnly(), listAccountDeletionsHandler(db))
+	deletions.Post("/:id/approve", middleware.AdminOnly(), approveAccountDeletionHandler(db))
+	deletions.Post("/:id/reject", middleware.AdminOnly(), rejectAccountDeletionHandler(db))
+}
+
+// @Summary Profil saya
+// @Description Data alumni yang sedang login dari database beserta permintaan hapus akun yang masih pending
+// @Tags Me (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} model.MeProfileResponse
+// @Failure 401 {object} fiber.Map
+// @Failure 404 {object} fiber.Map
+// @Router /me [get]
+func getMeHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.GetMeService(c, db)
+	}
+}
+
+// @Summary Ubah profil saya
+// @Description JSON Merge Patch (RFC 7396) atau JSON Patch (RFC 6902) untuk no_telepon dan alamat; field lain ditolak. null atau string kosong menghapus field
+// @Tags Me (Mongo)
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param If-Match header string false "ETag dari response GET /me"
+// @Param request body model.MePatchDocument true "Merge patch (application/merge-patch+json) atau array operasi JSON Patch (application/json-patch+json)"
+// @Success 200 {object} model.UpdateAlumniResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 412 {object} fiber.Map
+// @Failure 415 {object} fiber.Map
+// @Router /me [patch]
+func patchMeHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.PatchMeService(c, db)
+	}
+}
+
+// @Summary Ajukan hapus akun
+// @Description Membuat permintaan hapus akun yang diproses admin; akun belum dihapus sampai disetujui
+// @Tags Me (Mongo)
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body model.CreateAccountDeletionRequest false "Alasan (opsional)"
+// @Success 202 {object} model.AccountDeletionResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 409 {object} model.AccountDeletionResponse "Sudah ada permintaan pending"
+// @Router /me [delete]
+func requestAccountDeletionHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.RequestAccountDeletionService(c, db)
+	}
+}
+
+// @Summary Pekerjaan saya
+// @Description Daftar pekerjaan milik alumni yang sedang login
+// @Tags Me (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} model.GetPekerjaanAlumniByAlumniIDResponse
+// @Failure 401 {object} fiber.Map
+// @Router /me/pekerjaan [get]
+func getMyPekerjaanHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.GetMyPekerjaanService(c, db)
+	}
+}
+
+// @Summary Tambah pekerjaan saya
+// @Description Body sama dengan POST /pekerjaan; alumni_id diambil dari token dan nilai di body diabaikan
+// @Tags Me (Mongo)
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body model.CreatePekerjaanAlumniRequest true "Data pekerjaan"
+// @Success 201 {object} model.CreatePekerjaanAlumniResponse
+// @Failure 400 {object} model.CreatePekerjaanAlumniResponse
+// @Failure 409 {object} model.CreatePekerjaanAlumniResponse
+// @Router /me/pekerjaan [post]
+func createMyPekerjaanHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.CreateMyPekerjaanService(c, db)
+	}
+}
+
+// @Summary Ubah sebagian pekerjaan saya
+// @Description Sama dengan PATCH /pekerjaan/{id} untuk pekerjaan milik sendiri; pekerjaan alumni lain dijawab 404
+// @Tags Me (Mongo)
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID Pekerjaan"
+// @Param If-Match header string false "ETag dari response GET"
+// @Param request body model.PekerjaanPatchDocument true "Merge patch (application/merge-patch+json) atau array operasi JSON Patch (application/json-patch+json)"
+// @Success 200 {object} model.UpdatePekerjaanAlumniResponse
+// @Failure 400 {object} model.UpdatePekerjaanAlumniResponse
+// @Failure 404 {object} model.UpdatePekerjaanAlumniResponse
+// @Failure 412 {object} model.UpdatePekerjaanAlumniResponse
+// @Failure 415 {object} model.UpdatePekerjaanAlumniResponse
+// @Router /me/pekerjaan/{id} [patch]
+func patchMyPekerjaanHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.PatchMyPekerjaanService(c, db)
+	}
+}
+
+// @Summary File saya
+// @Description Daftar file milik alumni yang sedang login; query sama dengan GET /users/{id}/files
+// @Tags Me (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Jumlah per halaman (maks 100)"
+// @Param after query string false "Cursor dari meta.next_cursor"
+// @Param before query string false "Cursor dari meta.prev_cursor"
+// @Param with_total query bool false "Sertakan total data"
+// @Success 200 {object} model.ListFilesResponse
+// @Failure 400 {object} fiber.Map
+// @Router /me/files [get]
+func listMyFilesHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.ListMyFilesService(c, db)
+	}
+}
+
+// @Summary Daftar permintaan hapus akun
+// @Description Permintaan hapus akun dari alumni, terbaru dulu
+// @Tags Me (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "pending, approved, atau rejected"
+// @Success 200 {object} model.ListAccountDeletionResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 403 {object} fiber.Map
+// @Router /account-deletions [get]
+func listAccountDeletionsHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.ListAccountDeletionsService(c, db)
+	}
+}
+
+// @Summary Setujui permintaan hapus akun
+// @Description Menghapus akun alumni (sama seperti DELETE /alumni/{id}) dan menandai permintaan approved
+// @Tags Me (Mongo)
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID permintaan"
+// @Param request body model.ProcessAccountDeletionRequest false "Catatan admin (opsional)"
+// @Success 200 {object} model.AccountDeletionResponse
+// @Failure 404 {object} fiber.Map
+// @Failure 409 {object} model.AccountDeletionResponse "Permintaan sudah diproses"
+// @Router /account-deletions/{id}/approve [post]
+func approveAccountDeletionHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.ApproveAccountDeletionService(c, db)
+	}
+}
+
+// @Summary Tolak permintaan hapus akun
+// @Description Menandai permintaan rejected; akun alumni tetap ada
+// @Tags Me (Mongo)
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID permintaan"
+// @Param request body model.ProcessAccountDeletionRequest false "Catatan admin (opsional)"
+// @Success 200 {object} model.AccountDeletionResponse
+// @Failure 404 {object} fiber.Map
+// @Failure 409 {object} model.AccountDeletionResponse "Permintaan sudah diproses"
+// @Router /account-deletions/{id}/reject [post]
+func rejectAccountDeletionHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.RejectAccountDeletionService(c, db)
+	}
+}
@@ -12,29 +12,54 @@ import (
 // swagger:ignore
 var (
 	_ model.GetAllPekerjaanResponse
+	_ model.SearchPekerjaanResponse
 	_ model.GetPekerjaanAlumniByIDResponse
 	_ model.GetPekerjaanAlumniByAlumniIDResponse
 	_ model.CreatePekerjaanAlumniRequest
 	_ model.CreatePekerjaanAlumniResponse
 	_ model.UpdatePekerjaanAlumniRequest
 	_ model.UpdatePekerjaanAlumniResponse
+	_ model.PekerjaanPatchDocument
+	_ model.JobResponse
 )
 
-func PekerjaanRoutes(app *fiber.App, db *mongo.Database) {
-	api := app.Group("/go-fiber-mongo")
-	protected := api.Group("", middleware.AuthRequired())
-
+func PekerjaanRoutes(protected fiber.Router, db *mongo.Database) {
 	pekerjaan := protected.Group("/pekerjaan")
 	pekerjaan.Get("/", middleware.UserAndAdmin(), getAllPekerjaanHandler(db))
+	pekerjaan.Get("/search", middleware.UserAndAdmin(), searchPekerjaanHandler(db))
+	pekerjaan.Get("/export", middleware.AdminOnly(), exportPekerjaanHandler(db))
 	pekerjaan.Get("/:id", middleware.UserAndAdmin(), getPekerjaanByIDHandler(db))
 	pekerjaan.Get("/alumni/:alumni_id", middleware.AdminOnly(), getPekerjaanByAlumniIDHandler(db))
 	pekerjaan.Post("/", middleware.AdminOnly(), createPekerjaanHandler(db))
+	pekerjaan.Post("/batch", middleware.AdminOnly(), batchPekerjaanHandler(db))
+	pekerjaan.Post("/gaji/migrate", middleware.AdminOnly(), migrateSalaryHandler(db))
+	pekerjaan.Post("/lokasi/backfill", middleware.AdminOnly(), backfillLocationHandler(db))
 	pekerjaan.Put("/:id", middleware.AdminOnly(), updatePekerjaanHandler(db))
+	pekerjaan.Patch("/:id", middleware.AdminOnly(), patchPekerjaanHandler(db))
 	pekerjaan.Delete("/:id", middleware.AdminOnly(), deletePekerjaanHandler(db))
+	pekerjaan.Get("/:id/history", middleware.AdminOnly(), pekerjaanHistoryHandler(db))
+}
+
+// @Summary Riwayat perubahan pekerjaan
+// @Description Audit log satu pekerjaan alumni, terbaru lebih dulu. Tetap tersedia setelah pekerjaan dihapus
+// @Tags Audit (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID Pekerjaan"
+// @Param page query int false "Halaman" default(1)
+// @Param limit query int false "Jumlah per halaman (maks 100)" default(20)
+// @Success 200 {object} model.AuditLogListResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 403 {object} fiber.Map
+// @Router /pekerjaan/{id}/history [get]
+func pekerjaanHistoryHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.PekerjaanHistoryService(c, db)
+	}
 }
 
 // @Summary Daftar pekerjaan alumni
-// @Description Mengambil daftar pekerjaan alumni dengan pagination, sorting, dan pencarian
+// @Description Mengambil daftar pekerjaan alumni dengan pagination (offset atau cursor), sorting, dan pencarian
 // @Tags Pekerjaan (Mongo)
 // @Produce json
 // @Security BearerAuth
@@ -43,7 +68,12 @@ func PekerjaanRoutes(app *fiber.App, db *mongo.Database) {
 // @Param sortBy query string false "Kolom sortir (id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, tanggal_mulai_kerja, status_pekerjaan, created_at)"
 // @Param order query string false "Urutan asc/desc"
 // @Param search query string false "Kata kunci pencarian"
+// @Param filter[field][op] query string false "Filter terstruktur, mis. filter[tanggal_mulai_kerja][between]=2020-01-01,2023-12-31 atau filter[koordinat][near]=-6.2,106.8,25"
+// @Param after query string false "Cursor dari meta.next_cursor (menggantikan page)"
+// @Param before query string false "Cursor dari meta.prev_cursor"
+// @Param with_total query bool false "Hitung total data pada mode cursor"
 // @Success 200 {object} model.GetAllPekerjaanResponse
+// @Failure 400 {object} model.GetAllPekerjaanResponse
 // @Failure 500 {object} fiber.Map
 // @Router /pekerjaan [get]
 func getAllPekerjaanHandler(db *mongo.Database) fiber.Handler {
@@ -52,6 +82,46 @@ func getAllPekerjaanHandler(db *mongo.Database) fiber.Handler {
 	}
 }
 
+// @Summary Cari pekerjaan alumni (full-text)
+// @Description Full-text search pekerjaan pada perusahaan, posisi, bidang industri, lokasi, dan deskripsi dengan ranking relevansi dan highlight
+// @Tags Pekerjaan (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "Kata kunci pencarian"
+// @Param page query int false "Halaman"
+// @Param limit query int false "Jumlah per halaman (maks 100)"
+// @Success 200 {object} model.SearchPekerjaanResponse
+// @Failure 400 {object} model.SearchPekerjaanResponse
+// @Failure 500 {object} model.SearchPekerjaanResponse
+// @Router /pekerjaan/search [get]
+func searchPekerjaanHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.SearchPekerjaanService(c, db)
+	}
+}
+
+// @Summary Export pekerjaan alumni
+// @Description Export pekerjaan beserta NIM dan nama alumni sebagai CSV, XLSX, NDJSON, atau laporan PDF dengan search, sortBy, order, dan filter yang sama seperti GET /pekerjaan
+// @Tags Export (Mongo)
+// @Produce text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,application/x-ndjson,application/pdf,application/json
+// @Security BearerAuth
+// @Param format query string false "csv (default), xlsx, ndjson, pdf"
+// @Param async query bool false "Jalankan sebagai job background"
+// @Param sortBy query string false "Kolom sortir"
+// @Param order query string false "Urutan asc/desc"
+// @Param search query string false "Kata kunci pencarian"
+// @Param filter[field][op] query string false "Filter terstruktur, sama dengan GET /pekerjaan"
+// @Success 200 {file} file
+// @Success 202 {object} model.ExportJobResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 500 {object} fiber.Map
+// @Router /pekerjaan/export [get]
+func exportPekerjaanHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.ExportPekerjaanService(c, db)
+	}
+}
+
 // @Summary Detail pekerjaan alumni
 // @Description Mengambil detail pekerjaan alumni berdasarkan ID pekerjaan
 // @Tags Pekerjaan (Mongo)
@@ -93,6 +163,7 @@ func getPekerjaanByAlumniIDHandler(db *mongo.Database) fiber.Handler {
 // @Param request body model.CreatePekerjaanAlumniRequest true "Data pekerjaan alumni"
 // @Success 201 {object} model.CreatePekerjaanAlumniResponse
 // @Failure 400 {object} model.CreatePekerjaanAlumniResponse
+// @Failure 409 {object} model.CreatePekerjaanAlumniResponse
 // @Failure 500 {object} model.CreatePekerjaanAlumniResponse
 // @Router /pekerjaan [post]
 func createPekerjaanHandler(db *mongo.Database) fiber.Handler {
@@ -111,6 +182,7 @@ func createPekerjaanHandler(db *mongo.Database) fiber.Handler {
 // @Param request body model.UpdatePekerjaanAlumniRequest true "Data pekerjaan alumni yang diperbarui"
 // @Success 200 {object} model.UpdatePekerjaanAlumniResponse
 // @Failure 400 {object} model.UpdatePekerjaanAlumniResponse
+// @Failure 409 {object} model.UpdatePekerjaanAlumniResponse
 // @Failure 500 {object} model.UpdatePekerjaanAlumniResponse
 // @Router /pekerjaan/{id} [put]
 func updatePekerjaanHandler(db *mongo.Database) fiber.Handler {
@@ -119,6 +191,47 @@ func updatePekerjaanHandler(db *mongo.Database) fiber.Handler {
 	}
 }
 
+// @Summary Batch create/update/delete pekerjaan
+// @Description Menjalankan banyak operasi sekaligus. all_or_nothing (default) menyimpan semua atau tidak sama sekali, best_effort menyimpan operasi yang berhasil saja. Update memakai merge patch; batas jumlah operasi diatur BATCH_MAX_OPERATIONS
+// @Tags Pekerjaan (Mongo)
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body model.BatchRequest true "Mode dan daftar operasi"
+// @Success 200 {object} model.BatchResponse
+// @Success 207 {object} model.BatchResponse
+// @Failure 400 {object} model.BatchResponse
+// @Failure 413 {object} model.BatchResponse
+// @Failure 500 {object} model.BatchResponse
+// @Router /pekerjaan/batch [post]
+func batchPekerjaanHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.BatchPekerjaanService(c, db)
+	}
+}
+
+// @Summary Ubah sebagian pekerjaan alumni
+// @Description Menerapkan JSON Merge Patch (RFC 7396) atau JSON Patch (RFC 6902) ke pekerjaan; null menghapus field opsional
+// @Tags Pekerjaan (Mongo)
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID Pekerjaan"
+// @Param If-Match header string false "ETag dari response GET"
+// @Param request body model.PekerjaanPatchDocument true "Merge patch (application/merge-patch+json) atau array operasi JSON Patch (application/json-patch+json)"
+// @Success 200 {object} model.UpdatePekerjaanAlumniResponse
+// @Failure 400 {object} model.UpdatePekerjaanAlumniResponse
+// @Failure 404 {object} model.UpdatePekerjaanAlumniResponse
+// @Failure 409 {object} model.UpdatePekerjaanAlumniResponse
+// @Failure 412 {object} model.UpdatePekerjaanAlumniResponse
+// @Failure 415 {object} model.UpdatePekerjaanAlumniResponse
+// @Router /pekerjaan/{id} [patch]
+func patchPekerjaanHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.PatchPekerjaanService(c, db)
+	}
+}
+
 // @Summary Hapus pekerjaan alumni
 // @Description Menghapus data pekerjaan alumni berdasarkan ID pekerjaan
 // @Tags Pekerjaan (Mongo)
@@ -134,3 +247,31 @@ func deletePekerjaanHandler(db *mongo.Database) fiber.Handler {
 		return service.DeletePekerjaanService(c, db)
 	}
 }
+
+// @Summary Migrasi gaji lama
+// @Description Menjadwalkan job pekerjaan.salary_migrate: gaji_range teks ("5-10 juta") diubah menjadi gaji_min / gaji_max terstruktur; teks yang tidak dikenali dibiarkan dan dicatat di log
+// @Tags Pekerjaan (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Success 202 {object} model.JobResponse
+// @Failure 500 {object} fiber.Map
+// @Router /pekerjaan/gaji/migrate [post]
+func migrateSalaryHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.MigrateSalaryService(c, db)
+	}
+}
+
+// @Summary Backfill lokasi terstruktur
+// @Description Menjadwalkan job pekerjaan.location_backfill: provinsi / kota / koordinat ditebak dari teks lokasi_kerja pekerjaan yang belum punya lokasi terstruktur; teks yang tidak dikenali dibiarkan
+// @Tags Pekerjaan (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Success 202 {object} model.JobResponse
+// @Failure 500 {object} fiber.Map
+// @Router /pekerjaan/lokasi/backfill [post]
+func backfillLocationHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.BackfillLocationService(c, db)
+	}
+}
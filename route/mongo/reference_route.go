@@ -0,0 +1,176 @@
+package mongo
+
+import (
+	model "go-fiber/app/model/mongo"
+	service "go-fiber/app/service/mongo"
+	"go-fiber/helper"
+	middleware "go-fiber/middleware/mongo"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// swagger:ignore
+var (
+	_ model.ReferenceDataRequest
+	_ model.ReferenceDataResponse
+	_ model.ListReferenceDataResponse
+	_ model.ReferenceAutocompleteResponse
+	_ model.ReferenceMergeRequest
+	_ model.ReferenceMergeResponse
+	_ model.JobResponse
+)
+
+// ReferenceRoutes -> /companies dan /industries memakai handler yang sama
+func ReferenceRoutes(protected fiber.Router, db *mongo.Database) {
+	for _, kind := range []helper.ReferenceKind{helper.ReferenceCompanies, helper.ReferenceIndustries} {
+		refs := protected.Group("/" + kind.Name)
+		refs.Get("/", middleware.UserAndAdmin(), listReferencesHandler(db, kind))
+		refs.Get("/autocomplete", middleware.UserAndAdmin(), referenceAutocompleteHandler(db, kind))
+		refs.Post("/backfill", middleware.AdminOnly(), backfillReferenceHandler(db, kind))
+		refs.Get("/:id", middleware.UserAndAdmin(), getReferenceHandler(db, kind))
+		refs.Post("/", middleware.AdminOnly(), createReferenceHandler(db, kind))
+		refs.Put("/:id", middleware.AdminOnly(), updateReferenceHandler(db, kind))
+		refs.Delete("/:id", middleware.AdminOnly(), deleteReferenceHandler(db, kind))
+		refs.Post("/:id/merge", middleware.AdminOnly(), mergeReferenceHandler(db, kind))
+	}
+}
+
+// @Summary Daftar perusahaan / bidang industri
+// @Description Data referensi urut nama; search dicocokkan dengan nama dan alias
+// @Tags Reference Data (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Param search query string false "Potongan nama atau alias"
+// @Success 200 {object} model.ListReferenceDataResponse
+// @Failure 500 {object} fiber.Map
+// @Router /companies [get]
+// @Router /industries [get]
+func listReferencesHandler(db *mongo.Database, kind helper.ReferenceKind) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.ListReferencesService(c, db, kind)
+	}
+}
+
+// @Summary Autocomplete perusahaan / bidang industri
+// @Description Saran nama berdasarkan kemiripan dengan nama dan alias, termasuk salah ketik; skor tertinggi lebih dulu
+// @Tags Reference Data (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "Teks yang sedang diketik"
+// @Param limit query int false "Jumlah saran (maks 50)" default(10)
+// @Success 200 {object} model.ReferenceAutocompleteResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 500 {object} fiber.Map
+// @Router /companies/autocomplete [get]
+// @Router /industries/autocomplete [get]
+func referenceAutocompleteHandler(db *mongo.Database, kind helper.ReferenceKind) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.ReferenceAutocompleteService(c, db, kind)
+	}
+}
+
+// @Summary Detail perusahaan / bidang industri
+// @Tags Reference Data (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID data referensi"
+// @Success 200 {object} model.ReferenceDataResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 404 {object} fiber.Map
+// @Router /companies/{id} [get]
+// @Router /industries/{id} [get]
+func getReferenceHandler(db *mongo.Database, kind helper.ReferenceKind) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.GetReferenceService(c, db, kind)
+	}
+}
+
+// @Summary Tambah perusahaan / bidang industri
+// @Description Nama dan alias tidak boleh sudah dipakai data lain (dibandingkan setelah dinormalisasi)
+// @Tags Reference Data (Mongo)
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body model.ReferenceDataRequest true "Nama dan alias"
+// @Success 201 {object} model.ReferenceDataResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 409 {object} fiber.Map
+// @Router /companies [post]
+// @Router /industries [post]
+func createReferenceHandler(db *mongo.Database, kind helper.ReferenceKind) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.CreateReferenceService(c, db, kind)
+	}
+}
+
+// @Summary Update perusahaan / bidang industri
+// @Description Bila nama berubah, teks bebas pekerjaan yang terhubung ikut diganti
+// @Tags Reference Data (Mongo)
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID data referensi"
+// @Param request body model.ReferenceDataRequest true "Nama dan alias"
+// @Success 200 {object} model.ReferenceDataResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 404 {object} fiber.Map
+// @Failure 409 {object} fiber.Map
+// @Router /companies/{id} [put]
+// @Router /industries/{id} [put]
+func updateReferenceHandler(db *mongo.Database, kind helper.ReferenceKind) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.UpdateReferenceService(c, db, kind)
+	}
+}
+
+// @Summary Hapus perusahaan / bidang industri
+// @Description Pekerjaan yang terhubung dilepas dan kembali memakai teks bebasnya
+// @Tags Reference Data (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID data referensi"
+// @Success 200 {object} model.ReferenceDataResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 404 {object} fiber.Map
+// @Router /companies/{id} [delete]
+// @Router /industries/{id} [delete]
+func deleteReferenceHandler(db *mongo.Database, kind helper.ReferenceKind) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.DeleteReferenceService(c, db, kind)
+	}
+}
+
+// @Summary Gabungkan duplikat
+// @Description Nama dan alias source_ids menjadi alias data :id, pekerjaannya dipindahkan, lalu duplikat dihapus
+// @Tags Reference Data (Mongo)
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID data referensi tujuan"
+// @Param request body model.ReferenceMergeRequest true "ID duplikat"
+// @Success 200 {object} model.ReferenceMergeResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 404 {object} fiber.Map
+// @Router /companies/{id}/merge [post]
+// @Router /industries/{id}/merge [post]
+func mergeReferenceHandler(db *mongo.Database, kind helper.ReferenceKind) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.MergeReferenceService(c, db, kind)
+	}
+}
+
+// @Summary Backfill data referensi
+// @Description Menjadwalkan job reference.backfill: pekerjaan tanpa referensi dihubungkan berdasarkan teks bebasnya, teks yang belum dikenal dibuatkan data baru
+// @Tags Reference Data (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Success 202 {object} model.JobResponse
+// @Failure 500 {object} fiber.Map
+// @Router /companies/backfill [post]
+// @Router /industries/backfill [post]
+func backfillReferenceHandler(db *mongo.Database, kind helper.ReferenceKind) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.BackfillReferenceService(c, db, kind)
+	}
+}
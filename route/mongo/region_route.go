@@ -0,0 +1,52 @@
+package mongo
+
+import (
+	model "go-fiber/app/model/mongo"
+	service "go-fiber/app/service/mongo"
+	middleware "go-fiber/middleware/mongo"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// swagger:ignore
+var (
+	_ model.ListRegionResponse
+	_ model.RegionResponse
+)
+
+// RegionRoutes -> dataset wilayah (provinsi, kota / kabupaten) untuk mengisi lokasi pekerjaan
+func RegionRoutes(protected fiber.Router, _ *mongo.Database) {
+	wilayah := protected.Group("/wilayah")
+	wilayah.Get("/", middleware.UserAndAdmin(), listRegionsHandler())
+	wilayah.Get("/:kode", middleware.UserAndAdmin(), getRegionHandler())
+}
+
+// @Summary Daftar provinsi
+// @Description Provinsi pada dataset wilayah beserta kode, alias, dan titik tengahnya; daftar kota ada di GET /wilayah/{kode}
+// @Tags Wilayah (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} model.ListRegionResponse
+// @Failure 500 {object} fiber.Map
+// @Router /wilayah [get]
+func listRegionsHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.ListRegionsService(c)
+	}
+}
+
+// @Summary Detail provinsi
+// @Description Provinsi beserta kota / kabupaten yang dikenali untuk field kota pekerjaan
+// @Tags Wilayah (Mongo)
+// @Produce json
+// @Security BearerAuth
+// @Param kode path string true "Kode Kemendagri, nama, atau alias provinsi (mis. 32, Jawa Barat, jabar)"
+// @Success 200 {object} model.RegionResponse
+// @Failure 404 {object} fiber.Map
+// @Router /wilayah/{kode} [get]
+func getRegionHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.GetRegionService(c)
+	}
+}
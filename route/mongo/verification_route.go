@@ -0,0 +1,78 @@
+package mongo
+
+import (
+	model "go-fiber/app/model/mongo"
+	service "go-fiber/app/service/mongo"
+	"go-fiber/helper"
+	middleware "go-fiber/middleware/mongo"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// swagger:ignore
+var (
+	_ model.VerifyAlumniRequest
+	_ model.VerifyAlumniResponse
+	_ model.VerificationTokenResponse
+	_ helper.JWKSet
+)
+
+// VerificationRoutes -> verifikasi kelulusan oleh partner dan pemeriksaan publik pernyataan verifikasi.
+// Harus didaftarkan sebelum grup protected di main.go agar tidak ikut AuthRequired.
+func VerificationRoutes(app *fiber.App, db *mongo.Database) {
+	api := app.Group("/go-fiber-mongo")
+
+	// Publik: siapa pun yang memegang pernyataan bisa memeriksanya; /verify/keys harus sebelum /verify/:token
+	api.Get("/verify/keys", verificationKeysHandler())
+	api.Get("/verify/:token", verificationTokenHandler())
+
+	partner := api.Group("/partner")
+	partner.Post("/alumni/verify", middleware.APIKeyRequired(helper.APIKeyScopeAlumniVerify), verifyAlumniHandler(db))
+}
+
+// @Summary Verifikasi kelulusan (partner)
+// @Description Mencocokkan NIM, nama, dan tanggal lahir dengan data alumni untuk API key ber-scope alumni:verify. Data yang tidak cocok dijawab verified=false tanpa membedakan penyebabnya; bila cocok dikirim status akademik dan pernyataan bertanda tangan (JWS EdDSA)
+// @Tags Partner (Mongo)
+// @Accept json
+// @Produce json
+// @Security APIKeyAuth
+// @Param request body model.VerifyAlumniRequest true "Data yang diverifikasi"
+// @Success 200 {object} model.VerifyAlumniResponse
+// @Failure 400 {object} fiber.Map
+// @Failure 401 {object} fiber.Map
+// @Failure 403 {object} fiber.Map "Scope API key tidak mencukupi"
+// @Failure 429 {object} fiber.Map "Terlalu banyak percobaan untuk NIM ini atau rate limit API key terlampaui (lihat header Retry-After)"
+// @Router /partner/alumni/verify [post]
+func verifyAlumniHandler(db *mongo.Database) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.VerifyAlumniService(c, db)
+	}
+}
+
+// @Summary Periksa pernyataan verifikasi
+// @Description Endpoint publik untuk memeriksa tanda tangan dan masa berlaku pernyataan verifikasi kelulusan
+// @Tags Verifikasi (Mongo)
+// @Produce json
+// @Param token path string true "Pernyataan verifikasi (JWS)"
+// @Success 200 {object} model.VerificationTokenResponse
+// @Failure 500 {object} fiber.Map
+// @Router /verify/{token} [get]
+func verificationTokenHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.VerificationTokenService(c)
+	}
+}
+
+// @Summary Kunci publik verifikasi
+// @Description JWKS (Ed25519) untuk memverifikasi pernyataan secara offline, termasuk kunci lama yang masih dipercaya
+// @Tags Verifikasi (Mongo)
+// @Produce json
+// @Success 200 {object} helper.JWKSet
+// @Failure 500 {object} fiber.Map
+// @Router /verify/keys [get]
+func verificationKeysHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return service.VerificationKeysService(c)
+	}
+}
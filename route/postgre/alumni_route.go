@@ -3,44 +3,140 @@ package postgre
 import (
 	"database/sql"
 	service "go-fiber/app/service/postgre"
+	"go-fiber/helper"
 	middleware "go-fiber/middleware/postgre"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func AlumniRoutes(app *fiber.App, db *sql.DB) {
+// AuthRoutes -> route publik (login, reset password, OIDC, enrollment 2FA, partner API key).
+// Harus didaftarkan sebelum grup protected di main.go agar tidak ikut AuthRequired.
+func AuthRoutes(app *fiber.App, db *sql.DB) {
 	api := app.Group("/go-fiber-postgre")
 
+	// Token dicabut saat password berubah (session_version)
+	middleware.SessionChecker = service.SessionChecker(db)
+	middleware.APIKeyVerifier = service.APIKeyVerifier(db)
+	middleware.APIKeyRateLimitStore = service.APIKeyRateLimitStore(db)
+	middleware.IdempotencyStore = service.IdempotencyStore(db)
+
 	api.Post("/login", func(c *fiber.Ctx) error {
 		return service.LoginService(c, db)
 	})
+	api.Post("/password/forgot", func(c *fiber.Ctx) error {
+		return service.ForgotPasswordService(c, db)
+	})
+	api.Post("/password/reset", func(c *fiber.Ctx) error {
+		return service.ResetPasswordService(c, db)
+	})
+
+	api.Post("/login/mfa", func(c *fiber.Ctx) error {
+		return service.MFALoginService(c, db)
+	})
+	api.Get("/auth/oidc/login", func(c *fiber.Ctx) error {
+		return service.OIDCLoginService(c, db)
+	})
+	api.Get("/auth/oidc/callback", func(c *fiber.Ctx) error {
+		return service.OIDCCallbackService(c, db)
+	})
+
+	// Enrollment 2FA juga menerima token mfa_pending (admin yang belum punya 2FA);
+	// didaftarkan sebelum grup protected agar tidak melewati AuthRequired
+	mfaSetup := api.Group("/me/mfa", middleware.MFASetupAuth())
+	mfaSetup.Post("/enroll", func(c *fiber.Ctx) error {
+		return service.MFAEnrollService(c, db)
+	})
+	mfaSetup.Post("/verify", func(c *fiber.Ctx) error {
+		return service.MFAActivateService(c, db)
+	})
 
-	protected := api.Group("", middleware.AuthRequired())
+	// Client mesin (partner) memakai header X-API-Key, bukan JWT
+	partner := api.Group("/partner")
+	partner.Get("/alumni/check", middleware.APIKeyRequired(helper.APIKeyScopeAlumniCheck), func(c *fiber.Ctx) error {
+		return service.PartnerCheckAlumniService(c, db)
+	})
+	partner.Get("/alumni", middleware.APIKeyRequired(helper.APIKeyScopeAlumniRead), func(c *fiber.Ctx) error {
+		return service.GetAllAlumniService(c, db)
+	})
+	partner.Get("/alumni/:id", middleware.APIKeyRequired(helper.APIKeyScopeAlumniRead), func(c *fiber.Ctx) error {
+		return service.GetAlumniByIDService(c, db)
+	})
+}
 
+// AlumniRoutes -> route yang membutuhkan JWT; protected sudah memasang AuthRequired dan Idempotency
+func AlumniRoutes(protected fiber.Router, db *sql.DB) {
 	protected.Get("/profile", func(c *fiber.Ctx) error {
 		return service.GetProfileService(c, db)
 	})
+	protected.Post("/me/password", func(c *fiber.Ctx) error {
+		return service.ChangePasswordService(c, db)
+	})
+	protected.Post("/me/mfa/recovery-codes", func(c *fiber.Ctx) error {
+		return service.MFARecoveryCodesService(c, db)
+	})
+	protected.Post("/me/mfa/disable", func(c *fiber.Ctx) error {
+		return service.MFADisableService(c, db)
+	})
 
 	alumni := protected.Group("/alumni")
 	alumni.Get("/", middleware.UserAndAdmin(), func(c *fiber.Ctx) error {
 		return service.GetAllAlumniService(c, db)
 	})
+	alumni.Get("/search", middleware.UserAndAdmin(), func(c *fiber.Ctx) error {
+		return service.SearchAlumniService(c, db)
+	})
+	alumni.Get("/employment-status", middleware.UserAndAdmin(), func(c *fiber.Ctx) error {
+		return service.GetAlumniEmploymentStatusService(c, db)
+	})
+	alumni.Get("/export", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.ExportAlumniService(c, db)
+	})
+	alumni.Get("/employment-status/export", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.ExportAlumniEmploymentStatusService(c, db)
+	})
 	alumni.Get("/:id", middleware.UserAndAdmin(), func(c *fiber.Ctx) error {
 		return service.GetAlumniByIDService(c, db)
 	})
+	alumni.Get("/:id/career", middleware.UserAndAdmin(), func(c *fiber.Ctx) error {
+		return service.CareerTimelineService(c, db)
+	})
 	alumni.Post("/", middleware.AdminOnly(), func(c *fiber.Ctx) error {
 		return service.CreateAlumniService(c, db)
 	})
+	alumni.Post("/batch", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.BatchAlumniService(c, db)
+	})
 	alumni.Put("/:id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
 		return service.UpdateAlumniService(c, db)
 	})
+	alumni.Patch("/:id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.PatchAlumniService(c, db)
+	})
 	alumni.Delete("/:id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
 		return service.DeleteAlumniService(c, db)
 	})
+	alumni.Post("/:id/unlock", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.UnlockAlumniService(c, db)
+	})
+	alumni.Get("/:id/history", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.AlumniHistoryService(c, db)
+	})
+	// Deprecated: gunakan /partner/alumni/check; nonaktif bila LEGACY_API_KEY_ENABLED=false
 	alumni.Post("/check/:key", func(c *fiber.Ctx) error {
 		return service.CheckAlumniService(c, db)
 	})
 
+	apiKeys := protected.Group("/api-keys")
+	apiKeys.Get("/", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.ListAPIKeysService(c, db)
+	})
+	apiKeys.Post("/", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.CreateAPIKeyService(c, db)
+	})
+	apiKeys.Delete("/:id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.RevokeAPIKeyService(c, db)
+	})
+
 	roles := protected.Group("/roles")
 	roles.Get("/", middleware.UserAndAdmin(), func(c *fiber.Ctx) error {
 		return service.ListRolesService(c, db)
@@ -54,7 +150,17 @@ func AlumniRoutes(app *fiber.App, db *sql.DB) {
 	roles.Put("/:id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
 		return service.UpdateRoleService(c, db)
 	})
+	roles.Patch("/:id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.PatchRoleService(c, db)
+	})
 	roles.Delete("/:id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
 		return service.DeleteRoleService(c, db)
 	})
+	roles.Get("/:id/history", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.RoleHistoryService(c, db)
+	})
+
+	protected.Get("/audit-logs", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.ListAuditLogsService(c, db)
+	})
 }
@@ -0,0 +1,32 @@
+package postgre
+
+import (
+	"database/sql"
+	service "go-fiber/app/service/postgre"
+	middleware "go-fiber/middleware/postgre"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func AnalyticsRoutes(protected fiber.Router, db *sql.DB) {
+	analytics := protected.Group("/analytics", middleware.AdminOnly())
+
+	analytics.Get("/employment-rate", func(c *fiber.Ctx) error {
+		return service.GetEmploymentRateService(c, db)
+	})
+	analytics.Get("/time-to-first-job", func(c *fiber.Ctx) error {
+		return service.GetTimeToFirstJobService(c, db)
+	})
+	analytics.Get("/distribution", func(c *fiber.Ctx) error {
+		return service.GetJobDistributionService(c, db)
+	})
+	analytics.Get("/salary", func(c *fiber.Ctx) error {
+		return service.GetSalaryHistogramService(c, db)
+	})
+	analytics.Get("/retention", func(c *fiber.Ctx) error {
+		return service.GetJobRetentionService(c, db)
+	})
+	analytics.Get("/export", func(c *fiber.Ctx) error {
+		return service.ExportAnalyticsService(c, db)
+	})
+}
@@ -0,0 +1,18 @@
+package postgre
+
+import (
+	"database/sql"
+	service "go-fiber/app/service/postgre"
+	middleware "go-fiber/middleware/postgre"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func ExportRoutes(protected fiber.Router, db *sql.DB) {
+	protected.Get("/exports/:id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.GetExportJobService(c, db)
+	})
+	protected.Get("/exports/:id/download", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.DownloadExportService(c, db)
+	})
+}
@@ -0,0 +1,21 @@
+package postgre
+
+import (
+	"database/sql"
+	service "go-fiber/app/service/postgre"
+	middleware "go-fiber/middleware/postgre"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func ImportRoutes(protected fiber.Router, db *sql.DB) {
+	protected.Post("/alumni/import", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.ImportAlumniService(c, db)
+	})
+	protected.Post("/pekerjaan/import", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.ImportPekerjaanService(c, db)
+	})
+	protected.Get("/imports/:id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.GetImportJobService(c, db)
+	})
+}
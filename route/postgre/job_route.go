@@ -0,0 +1,24 @@
+package postgre
+
+import (
+	"database/sql"
+	service "go-fiber/app/service/postgre"
+	middleware "go-fiber/middleware/postgre"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func JobRoutes(protected fiber.Router, db *sql.DB) {
+	protected.Get("/jobs", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.ListJobsService(c, db)
+	})
+	protected.Get("/jobs/:id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.GetJobService(c, db)
+	})
+	protected.Post("/jobs/:id/retry", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.RetryJobService(c, db)
+	})
+	protected.Post("/jobs/:id/cancel", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.CancelJobService(c, db)
+	})
+}
@@ -0,0 +1,44 @@
+package postgre
+
+import (
+	"database/sql"
+	service "go-fiber/app/service/postgre"
+	middleware "go-fiber/middleware/postgre"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// MeRoutes -> profil dan data milik alumni yang sedang login; identitas selalu dari token, tanpa :id.
+// File upload hanya ada di backend MongoDB, sehingga /me/files tidak tersedia di sini.
+func MeRoutes(protected fiber.Router, db *sql.DB) {
+	me := protected.Group("/me")
+	me.Get("/", middleware.UserAndAdmin(), func(c *fiber.Ctx) error {
+		return service.GetMeService(c, db)
+	})
+	me.Patch("/", middleware.UserAndAdmin(), func(c *fiber.Ctx) error {
+		return service.PatchMeService(c, db)
+	})
+	me.Delete("/", middleware.UserAndAdmin(), func(c *fiber.Ctx) error {
+		return service.RequestAccountDeletionService(c, db)
+	})
+	me.Get("/pekerjaan", middleware.UserAndAdmin(), func(c *fiber.Ctx) error {
+		return service.GetMyPekerjaanService(c, db)
+	})
+	me.Post("/pekerjaan", middleware.UserAndAdmin(), func(c *fiber.Ctx) error {
+		return service.CreateMyPekerjaanService(c, db)
+	})
+	me.Patch("/pekerjaan/:id", middleware.UserAndAdmin(), func(c *fiber.Ctx) error {
+		return service.PatchMyPekerjaanService(c, db)
+	})
+
+	deletions := protected.Group("/account-deletions")
+	deletions.Get("/", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.ListAccountDeletionsService(c, db)
+	})
+	deletions.Post("/:id/approve", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.ApproveAccountDeletionService(c, db)
+	})
+	deletions.Post("/:id/reject", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.RejectAccountDeletionService(c, db)
+	})
+}
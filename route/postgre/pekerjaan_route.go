@@ -8,14 +8,17 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func PekerjaanRoutes(app *fiber.App, db *sql.DB) {
-	api := app.Group("/go-fiber-postgre")
-	protected := api.Group("", middleware.AuthRequired())
-
+func PekerjaanRoutes(protected fiber.Router, db *sql.DB) {
 	pekerjaan := protected.Group("/pekerjaan")
 	pekerjaan.Get("/", middleware.UserAndAdmin(), func(c *fiber.Ctx) error {
 		return service.GetAllPekerjaanService(c, db)
 	})
+	pekerjaan.Get("/search", middleware.UserAndAdmin(), func(c *fiber.Ctx) error {
+		return service.SearchPekerjaanService(c, db)
+	})
+	pekerjaan.Get("/export", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.ExportPekerjaanService(c, db)
+	})
 	pekerjaan.Get("/trash", middleware.UserAndAdmin(), func(c *fiber.Ctx) error {
 		return service.ListDeletedPekerjaanService(c, db)
 	})
@@ -28,9 +31,21 @@ func PekerjaanRoutes(app *fiber.App, db *sql.DB) {
 	pekerjaan.Post("/", middleware.AdminOnly(), func(c *fiber.Ctx) error {
 		return service.CreatePekerjaanService(c, db)
 	})
+	pekerjaan.Post("/batch", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.BatchPekerjaanService(c, db)
+	})
+	pekerjaan.Post("/gaji/migrate", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.MigrateSalaryService(c, db)
+	})
+	pekerjaan.Post("/lokasi/backfill", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.BackfillLocationService(c, db)
+	})
 	pekerjaan.Put("/:id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
 		return service.UpdatePekerjaanService(c, db)
 	})
+	pekerjaan.Patch("/:id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.PatchPekerjaanService(c, db)
+	})
 	pekerjaan.Put("/soft-delete/:id", middleware.UserAndAdmin(), func(c *fiber.Ctx) error {
 		return service.SoftDeletePekerjaanService(c, db)
 	})
@@ -40,4 +55,7 @@ func PekerjaanRoutes(app *fiber.App, db *sql.DB) {
 	pekerjaan.Delete("/hard-delete/:id", middleware.UserAndAdmin(), func(c *fiber.Ctx) error {
 		return service.HardDeletePekerjaanService(c, db)
 	})
+	pekerjaan.Get("/:id/history", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+		return service.PekerjaanHistoryService(c, db)
+	})
 }
@@ -0,0 +1,41 @@
+package postgre
+
+import (
+	"database/sql"
+	service "go-fiber/app/service/postgre"
+	"go-fiber/helper"
+	middleware "go-fiber/middleware/postgre"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ReferenceRoutes -> /companies dan /industries memakai service yang sama
+func ReferenceRoutes(protected fiber.Router, db *sql.DB) {
+	for _, kind := range []helper.ReferenceKind{helper.ReferenceCompanies, helper.ReferenceIndustries} {
+		refs := protected.Group("/" + kind.Name)
+		refs.Get("/", middleware.UserAndAdmin(), func(c *fiber.Ctx) error {
+			return service.ListReferencesService(c, db, kind)
+		})
+		refs.Get("/autocomplete", middleware.UserAndAdmin(), func(c *fiber.Ctx) error {
+			return service.ReferenceAutocompleteService(c, db, kind)
+		})
+		refs.Post("/backfill", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+			return service.BackfillReferenceService(c, db, kind)
+		})
+		refs.Get("/:id", middleware.UserAndAdmin(), func(c *fiber.Ctx) error {
+			return service.GetReferenceService(c, db, kind)
+		})
+		refs.Post("/", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+			return service.CreateReferenceService(c, db, kind)
+		})
+		refs.Put("/:id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+			return service.UpdateReferenceService(c, db, kind)
+		})
+		refs.Delete("/:id", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+			return service.DeleteReferenceService(c, db, kind)
+		})
+		refs.Post("/:id/merge", middleware.AdminOnly(), func(c *fiber.Ctx) error {
+			return service.MergeReferenceService(c, db, kind)
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package postgre
+
+import (
+	"database/sql"
+	service "go-fiber/app/service/postgre"
+	middleware "go-fiber/middleware/postgre"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegionRoutes -> dataset wilayah (provinsi, kota / kabupaten) untuk mengisi lokasi pekerjaan
+func RegionRoutes(protected fiber.Router, _ *sql.DB) {
+	wilayah := protected.Group("/wilayah")
+	wilayah.Get("/", middleware.UserAndAdmin(), func(c *fiber.Ctx) error {
+		return service.ListRegionsService(c)
+	})
+	wilayah.Get("/:kode", middleware.UserAndAdmin(), func(c *fiber.Ctx) error {
+		return service.GetRegionService(c)
+	})
+}
@@ -0,0 +1,29 @@
+package postgre
+
+import (
+	"database/sql"
+	service "go-fiber/app/service/postgre"
+	"go-fiber/helper"
+	middleware "go-fiber/middleware/postgre"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// VerificationRoutes -> verifikasi kelulusan oleh partner dan pemeriksaan publik pernyataan verifikasi.
+// Harus didaftarkan sebelum grup protected di main.go agar tidak ikut AuthRequired.
+func VerificationRoutes(app *fiber.App, db *sql.DB) {
+	api := app.Group("/go-fiber-postgre")
+
+	// Publik: siapa pun yang memegang pernyataan bisa memeriksanya; /verify/keys harus sebelum /verify/:token
+	api.Get("/verify/keys", func(c *fiber.Ctx) error {
+		return service.VerificationKeysService(c)
+	})
+	api.Get("/verify/:token", func(c *fiber.Ctx) error {
+		return service.VerificationTokenService(c)
+	})
+
+	partner := api.Group("/partner")
+	partner.Post("/alumni/verify", middleware.APIKeyRequired(helper.APIKeyScopeAlumniVerify), func(c *fiber.Ctx) error {
+		return service.VerifyAlumniService(c, db)
+	})
+}
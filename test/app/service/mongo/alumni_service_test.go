@@ -2,6 +2,7 @@ package mongo_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -59,6 +60,89 @@ func TestUpdateAlumniService_InvalidID(t *testing.T) {
 	}
 }
 
+func TestUpdateAlumniService_IfMatch(t *testing.T) {
+	app := fiber.New()
+	app.Put("/alumni/:id", func(c *fiber.Ctx) error { return service.UpdateAlumniService(c, nil) })
+
+	put := func(ifMatch string) int {
+		req := httptest.NewRequest(http.MethodPut, "/alumni/507f1f77bcf86cd799439011", bytes.NewBufferString(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		resp, _ := app.Test(req)
+		return resp.StatusCode
+	}
+
+	if status := put("3"); status != http.StatusBadRequest {
+		t.Errorf("unquoted If-Match: expected 400, got %d", status)
+	}
+	t.Setenv("STRICT_IF_MATCH", "true")
+	if status := put(""); status != http.StatusPreconditionRequired {
+		t.Errorf("missing If-Match in strict mode: expected 428, got %d", status)
+	}
+}
+
+func TestPatchAlumniService_Validation(t *testing.T) {
+	app := fiber.New()
+	app.Patch("/alumni/:id", func(c *fiber.Ctx) error { return service.PatchAlumniService(c, nil) })
+
+	patch := func(contentType, body string) int {
+		req := httptest.NewRequest(http.MethodPatch, "/alumni/507f1f77bcf86cd799439011", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", contentType)
+		resp, _ := app.Test(req)
+		return resp.StatusCode
+	}
+
+	if status := patch("application/json", `{"alamat":null}`); status != http.StatusUnsupportedMediaType {
+		t.Errorf("plain JSON: expected 415, got %d", status)
+	}
+	if status := patch("application/merge-patch+json", `{"alamat":`); status != http.StatusBadRequest {
+		t.Errorf("malformed merge patch: expected 400, got %d", status)
+	}
+	if status := patch("application/json-patch+json; charset=utf-8", `[{"op":`); status != http.StatusBadRequest {
+		t.Errorf("malformed JSON Patch: expected 400, got %d", status)
+	}
+}
+
+func TestBatchAlumniService_Validation(t *testing.T) {
+	t.Setenv("BATCH_MAX_OPERATIONS", "2")
+	app := fiber.New()
+	app.Post("/alumni/batch", func(c *fiber.Ctx) error { return service.BatchAlumniService(c, nil) })
+
+	batch := func(body string) (int, map[string]any) {
+		req := httptest.NewRequest(http.MethodPost, "/alumni/batch", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+		var out map[string]any
+		_ = json.NewDecoder(resp.Body).Decode(&out)
+		return resp.StatusCode, out
+	}
+
+	if status, _ := batch(`{"mode":"sometimes","operations":[{"op":"delete","id":"507f1f77bcf86cd799439011"}]}`); status != http.StatusBadRequest {
+		t.Errorf("invalid mode: expected 400, got %d", status)
+	}
+	if status, _ := batch(`{"operations":[]}`); status != http.StatusBadRequest {
+		t.Errorf("empty operations: expected 400, got %d", status)
+	}
+	if status, _ := batch(`{"operations":[{"op":"delete","id":"a"},{"op":"delete","id":"b"},{"op":"delete","id":"c"}]}`); status != http.StatusRequestEntityTooLarge {
+		t.Errorf("too many operations: expected 413, got %d", status)
+	}
+
+	// all_or_nothing: operasi yang tidak valid membatalkan seluruh batch sebelum menyentuh database
+	status, out := batch(`{"operations":[{"op":"delete","id":"507f1f77bcf86cd799439011"},{"op":"upsert"}]}`)
+	if status != http.StatusBadRequest {
+		t.Fatalf("invalid op: expected 400, got %d", status)
+	}
+	results := out["data"].(map[string]any)["results"].([]any)
+	if got := results[0].(map[string]any)["status"]; got != float64(http.StatusFailedDependency) {
+		t.Errorf("valid op in aborted batch: expected 424, got %v", got)
+	}
+	if got := results[1].(map[string]any)["status"]; got != float64(http.StatusBadRequest) {
+		t.Errorf("invalid op: expected 400, got %v", got)
+	}
+}
+
 func TestDeleteAlumniService_InvalidID(t *testing.T) {
 	app := fiber.New()
 	app.Delete("/alumni/:id", func(c *fiber.Ctx) error { return service.DeleteAlumniService(c, nil) })
@@ -90,6 +174,56 @@ func TestCheckAlumniService_InvalidKeyOrNIM(t *testing.T) {
 	if resp2.StatusCode != http.StatusBadRequest {
 		t.Fatalf("expected 400, got %d", resp2.StatusCode)
 	}
+	if resp2.Header.Get("Deprecation") == "" {
+		t.Fatalf("expected Deprecation header on legacy check")
+	}
 }
 
+func TestCheckAlumniService_LegacyDisabled(t *testing.T) {
+	t.Setenv("API_KEY", "test-key")
+	t.Setenv("LEGACY_API_KEY_ENABLED", "false")
 
+	app := fiber.New()
+	app.Get("/alumni/check", func(c *fiber.Ctx) error { return service.CheckAlumniService(c, nil) })
+
+	req := httptest.NewRequest(http.MethodGet, "/alumni/check?key=test-key&nim=123", nil)
+	resp, _ := app.Test(req)
+	if resp.StatusCode != http.StatusGone {
+		t.Fatalf("expected 410, got %d", resp.StatusCode)
+	}
+}
+
+
+
+func TestSearchAlumniService_MissingQuery(t *testing.T) {
+	app := fiber.New()
+	app.Get("/alumni/search", func(c *fiber.Ctx) error { return service.SearchAlumniService(c, nil) })
+
+	req := httptest.NewRequest(http.MethodGet, "/alumni/search?q=%20", nil)
+	resp, _ := app.Test(req)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestGetAllAlumniService_InvalidFilter(t *testing.T) {
+	app := fiber.New()
+	app.Get("/alumni", func(c *fiber.Ctx) error { return service.GetAllAlumniService(c, nil) })
+
+	req := httptest.NewRequest(http.MethodGet, "/alumni?filter%5Bpassword%5D%5Beq%5D=x", nil)
+	resp, _ := app.Test(req)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestGetAllAlumniService_InvalidCursor(t *testing.T) {
+	app := fiber.New()
+	app.Get("/alumni", func(c *fiber.Ctx) error { return service.GetAllAlumniService(c, nil) })
+
+	req := httptest.NewRequest(http.MethodGet, "/alumni?after=bukan-cursor", nil)
+	resp, _ := app.Test(req)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+}
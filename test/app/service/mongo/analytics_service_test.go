@@ -0,0 +1,33 @@
+package mongo_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	service "go-fiber/app/service/mongo"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestGetEmploymentRateService_InvalidGroupBy(t *testing.T) {
+	app := fiber.New()
+	app.Get("/analytics/employment-rate", func(c *fiber.Ctx) error { return service.GetEmploymentRateService(c, nil) })
+
+	req := httptest.NewRequest(http.MethodGet, "/analytics/employment-rate?group_by=email", nil)
+	resp, _ := app.Test(req)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestGetJobDistributionService_InvalidField(t *testing.T) {
+	app := fiber.New()
+	app.Get("/analytics/distribution", func(c *fiber.Ctx) error { return service.GetJobDistributionService(c, nil) })
+
+	req := httptest.NewRequest(http.MethodGet, "/analytics/distribution?field=gaji_range", nil)
+	resp, _ := app.Test(req)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,33 @@
+package mongo_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	service "go-fiber/app/service/mongo"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestListAuditLogsService_InvalidFilters(t *testing.T) {
+	app := fiber.New()
+	app.Get("/audit-logs", func(c *fiber.Ctx) error { return service.ListAuditLogsService(c, nil) })
+
+	for _, query := range []string{"?actor_id=bukan-id", "?from=kemarin", "?to=2024-13-01"} {
+		resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/audit-logs"+query, nil))
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, resp.StatusCode)
+		}
+	}
+}
+
+func TestAlumniHistoryService_InvalidID(t *testing.T) {
+	app := fiber.New()
+	app.Get("/alumni/:id/history", func(c *fiber.Ctx) error { return service.AlumniHistoryService(c, nil) })
+
+	resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/alumni/123/history", nil))
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+}
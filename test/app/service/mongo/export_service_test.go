@@ -0,0 +1,44 @@
+package mongo_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	service "go-fiber/app/service/mongo"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestExportAlumniService_InvalidFormat(t *testing.T) {
+	app := fiber.New()
+	app.Get("/alumni/export", func(c *fiber.Ctx) error { return service.ExportAlumniService(c, nil) })
+
+	req := httptest.NewRequest(http.MethodGet, "/alumni/export?format=docx", nil)
+	resp, _ := app.Test(req)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestExportAnalyticsService_InvalidMetric(t *testing.T) {
+	app := fiber.New()
+	app.Get("/analytics/export", func(c *fiber.Ctx) error { return service.ExportAnalyticsService(c, nil) })
+
+	req := httptest.NewRequest(http.MethodGet, "/analytics/export?metric=gaji", nil)
+	resp, _ := app.Test(req)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestDownloadExportService_NotFound(t *testing.T) {
+	app := fiber.New()
+	app.Get("/exports/:id/download", func(c *fiber.Ctx) error { return service.DownloadExportService(c, nil) })
+
+	req := httptest.NewRequest(http.MethodGet, "/exports/unknown/download", nil)
+	resp, _ := app.Test(req)
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", resp.StatusCode)
+	}
+}
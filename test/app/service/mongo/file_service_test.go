@@ -199,3 +199,34 @@ func TestUploadCertificateService_InvalidFileType(t *testing.T) {
 // Directory creation happens after file validation and before database operations.
 // This scenario is better tested with integration tests that have a real database.
 
+
+func TestUploadFileService_MissingUserID(t *testing.T) {
+	app := fiber.New()
+	app.Post("/upload/:category/:id?", func(c *fiber.Ctx) error {
+		return service.UploadFileService(c, nil)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/upload/transcript/", nil)
+	req.Header.Set("Content-Type", "multipart/form-data")
+	resp, _ := app.Test(req)
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, resp.StatusCode)
+	}
+}
+
+func TestUpsertFileCategoryService_InvalidPolicy(t *testing.T) {
+	app := fiber.New()
+	app.Put("/file-categories/:name", func(c *fiber.Ctx) error {
+		return service.UpsertFileCategoryService(c, nil)
+	})
+
+	body := []byte(`{"allowed_types":["application/pdf"],"max_size":1024,"policy":"sometimes"}`)
+	req := httptest.NewRequest(http.MethodPut, "/file-categories/transcript", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, resp.StatusCode)
+	}
+}
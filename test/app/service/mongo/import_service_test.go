@@ -0,0 +1,53 @@
+package mongo_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	service "go-fiber/app/service/mongo"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestImportAlumniService_MissingFile(t *testing.T) {
+	app := fiber.New()
+	app.Post("/alumni/import", func(c *fiber.Ctx) error { return service.ImportAlumniService(c, nil) })
+
+	req := httptest.NewRequest(http.MethodPost, "/alumni/import?dry_run=true", nil)
+	resp, _ := app.Test(req)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestImportAlumniService_MissingColumn(t *testing.T) {
+	app := fiber.New()
+	app.Post("/alumni/import", func(c *fiber.Ctx) error { return service.ImportAlumniService(c, nil) })
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", "alumni.csv")
+	part.Write([]byte("nim,nama,jurusan,angkatan,tahun_lulus\n123,Budi,TI,2018,2022\n"))
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/alumni/import?dry_run=true", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	resp, _ := app.Test(req)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestGetImportJobService_NotFound(t *testing.T) {
+	app := fiber.New()
+	app.Get("/imports/:id", func(c *fiber.Ctx) error { return service.GetImportJobService(c, nil) })
+
+	req := httptest.NewRequest(http.MethodGet, "/imports/unknown", nil)
+	resp, _ := app.Test(req)
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", resp.StatusCode)
+	}
+}
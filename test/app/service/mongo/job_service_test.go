@@ -0,0 +1,33 @@
+package mongo_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	service "go-fiber/app/service/mongo"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestListJobsService_InvalidStatus(t *testing.T) {
+	app := fiber.New()
+	app.Get("/jobs", func(c *fiber.Ctx) error { return service.ListJobsService(c, nil) })
+
+	req := httptest.NewRequest(http.MethodGet, "/jobs?status=failed", nil)
+	resp, _ := app.Test(req)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestRetryJobService_InvalidID(t *testing.T) {
+	app := fiber.New()
+	app.Post("/jobs/:id/retry", func(c *fiber.Ctx) error { return service.RetryJobService(c, nil) })
+
+	req := httptest.NewRequest(http.MethodPost, "/jobs/bukan-id/retry", nil)
+	resp, _ := app.Test(req)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,87 @@
+package mongo_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	service "go-fiber/app/service/mongo"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func meApp(method, path string, handler func(c *fiber.Ctx) error) *fiber.App {
+	app := fiber.New()
+	app.Add(method, path, func(c *fiber.Ctx) error {
+		c.Locals("user_id", "507f1f77bcf86cd799439011")
+		c.Locals("role", "user")
+		return handler(c)
+	})
+	return app
+}
+
+func TestPatchMeService_UnsupportedContentType(t *testing.T) {
+	app := meApp(http.MethodPatch, "/me", func(c *fiber.Ctx) error { return service.PatchMeService(c, nil) })
+
+	req := httptest.NewRequest(http.MethodPatch, "/me", bytes.NewBufferString(`{"no_telepon":"0812"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+	if resp.StatusCode != http.StatusUnsupportedMediaType {
+		t.Fatalf("expected 415, got %d", resp.StatusCode)
+	}
+}
+
+func TestPatchMeService_InvalidToken(t *testing.T) {
+	app := fiber.New()
+	app.Patch("/me", func(c *fiber.Ctx) error { return service.PatchMeService(c, nil) })
+
+	req := httptest.NewRequest(http.MethodPatch, "/me", bytes.NewBufferString(`{"alamat":"Bandung"}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	resp, _ := app.Test(req)
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", resp.StatusCode)
+	}
+}
+
+func TestPatchMyPekerjaanService_InvalidID(t *testing.T) {
+	app := meApp(http.MethodPatch, "/me/pekerjaan/:id", func(c *fiber.Ctx) error { return service.PatchMyPekerjaanService(c, nil) })
+
+	req := httptest.NewRequest(http.MethodPatch, "/me/pekerjaan/bukan-id", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	resp, _ := app.Test(req)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestRequestAccountDeletionService_ReasonTooLong(t *testing.T) {
+	app := meApp(http.MethodDelete, "/me", func(c *fiber.Ctx) error { return service.RequestAccountDeletionService(c, nil) })
+
+	body := `{"alasan":"` + strings.Repeat("a", 1001) + `"}`
+	req := httptest.NewRequest(http.MethodDelete, "/me", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestListAccountDeletionsService_InvalidStatus(t *testing.T) {
+	app := fiber.New()
+	app.Get("/account-deletions", func(c *fiber.Ctx) error { return service.ListAccountDeletionsService(c, nil) })
+
+	resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/account-deletions?status=done", nil))
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestApproveAccountDeletionService_InvalidID(t *testing.T) {
+	app := meApp(http.MethodPost, "/account-deletions/:id/approve", func(c *fiber.Ctx) error { return service.ApproveAccountDeletionService(c, nil) })
+
+	if resp := postJSON(app, "/account-deletions/123/approve", `{}`); resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+}
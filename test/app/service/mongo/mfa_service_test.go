@@ -0,0 +1,50 @@
+package mongo_test
+
+import (
+	"net/http"
+	"testing"
+
+	model "go-fiber/app/model/mongo"
+	service "go-fiber/app/service/mongo"
+	utils "go-fiber/utils/mongo"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestMFALoginService_MissingFields(t *testing.T) {
+	app := fiber.New()
+	app.Post("/login/mfa", func(c *fiber.Ctx) error { return service.MFALoginService(c, nil) })
+
+	if resp := postJSON(app, "/login/mfa", `{"mfa_token":"","code":"123456"}`); resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestMFALoginService_RejectsFullToken(t *testing.T) {
+	app := fiber.New()
+	app.Post("/login/mfa", func(c *fiber.Ctx) error { return service.MFALoginService(c, nil) })
+
+	// Hanya token mfa_pending yang bisa ditukar
+	token, err := utils.GenerateToken(model.User{Username: "admin", Role: "admin"})
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	if resp := postJSON(app, "/login/mfa", `{"mfa_token":"`+token+`","code":"123456"}`); resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", resp.StatusCode)
+	}
+	if resp := postJSON(app, "/login/mfa", `{"mfa_token":"garbage","code":"123456"}`); resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", resp.StatusCode)
+	}
+}
+
+func TestMFADisableService_AdminForbidden(t *testing.T) {
+	app := fiber.New()
+	app.Post("/me/mfa/disable", func(c *fiber.Ctx) error {
+		c.Locals("role", "admin")
+		return service.MFADisableService(c, nil)
+	})
+
+	if resp := postJSON(app, "/me/mfa/disable", `{"password":"secret123","code":"123456"}`); resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,93 @@
+package mongo_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	service "go-fiber/app/service/mongo"
+	"go-fiber/helper"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func setupOIDCApp(t *testing.T) *fiber.App {
+	t.Helper()
+	server, err := helper.StartFakeOIDCServer("127.0.0.1:0", "alumni-app", []helper.FakeOIDCUser{
+		{Subject: "sub-1", Email: "sayunaa@gmail.com", EmailVerified: true},
+	})
+	if err != nil {
+		t.Fatalf("failed to start fake OIDC server: %v", err)
+	}
+	t.Cleanup(func() { server.Close() })
+	t.Setenv("OIDC_ISSUER", server.Issuer())
+	t.Setenv("OIDC_CLIENT_ID", "alumni-app")
+	t.Setenv("OIDC_REDIRECT_URL", "http://localhost:3000/auth/oidc/callback")
+	t.Setenv("OIDC_STATE_SECRET", "test-state-secret")
+
+	app := fiber.New()
+	app.Get("/auth/oidc/login", func(c *fiber.Ctx) error { return service.OIDCLoginService(c, nil) })
+	app.Get("/auth/oidc/callback", func(c *fiber.Ctx) error { return service.OIDCCallbackService(c, nil) })
+	return app
+}
+
+func TestOIDCLoginService_NotConfigured(t *testing.T) {
+	t.Setenv("OIDC_ISSUER", "")
+	app := fiber.New()
+	app.Get("/auth/oidc/login", func(c *fiber.Ctx) error { return service.OIDCLoginService(c, nil) })
+
+	resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/auth/oidc/login", nil))
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", resp.StatusCode)
+	}
+}
+
+func TestOIDCLoginService_RedirectsWithPKCE(t *testing.T) {
+	app := setupOIDCApp(t)
+
+	resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/auth/oidc/login?login_hint=sayunaa@gmail.com", nil))
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("expected 302, got %d", resp.StatusCode)
+	}
+	loc, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatalf("invalid location: %v", err)
+	}
+	q := loc.Query()
+	if q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" || q.Get("state") == "" ||
+		q.Get("login_hint") != "sayunaa@gmail.com" {
+		t.Errorf("unexpected authorization request: %s", loc)
+	}
+
+	var stateCookie *http.Cookie
+	for _, ck := range resp.Cookies() {
+		if ck.Name == "oidc_state" {
+			stateCookie = ck
+		}
+	}
+	if stateCookie == nil || !stateCookie.HttpOnly {
+		t.Fatalf("expected HttpOnly oidc_state cookie, got %v", resp.Cookies())
+	}
+
+	// Callback dengan state yang tidak sama dengan cookie ditolak sebelum menukar code
+	req := httptest.NewRequest(http.MethodGet, "/auth/oidc/callback?code=abc&state=tampered", nil)
+	req.AddCookie(stateCookie)
+	resp, _ = app.Test(req)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400 for state mismatch, got %d", resp.StatusCode)
+	}
+}
+
+func TestOIDCCallbackService_MissingStateCookie(t *testing.T) {
+	app := setupOIDCApp(t)
+
+	resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/auth/oidc/callback?code=abc&state=xyz", nil))
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+	resp, _ = app.Test(httptest.NewRequest(http.MethodGet, "/auth/oidc/callback?error=access_denied", nil))
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for provider error, got %d", resp.StatusCode)
+	}
+}
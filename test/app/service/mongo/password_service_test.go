@@ -0,0 +1,57 @@
+package mongo_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	service "go-fiber/app/service/mongo"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func postJSON(app *fiber.App, path, body string) *http.Response {
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+	return resp
+}
+
+func TestForgotPasswordService_MissingEmail(t *testing.T) {
+	app := fiber.New()
+	app.Post("/password/forgot", func(c *fiber.Ctx) error { return service.ForgotPasswordService(c, nil) })
+
+	if resp := postJSON(app, "/password/forgot", `{"email":"  "}`); resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestResetPasswordService_MissingFields(t *testing.T) {
+	app := fiber.New()
+	app.Post("/password/reset", func(c *fiber.Ctx) error { return service.ResetPasswordService(c, nil) })
+
+	if resp := postJSON(app, "/password/reset", `{"token":"abc"}`); resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestChangePasswordService_MissingFields(t *testing.T) {
+	app := fiber.New()
+	app.Post("/me/password", func(c *fiber.Ctx) error { return service.ChangePasswordService(c, nil) })
+
+	if resp := postJSON(app, "/me/password", `{"new_password":"rahasia123"}`); resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestCreateAlumniService_WeakPassword(t *testing.T) {
+	app := fiber.New()
+	app.Post("/alumni", func(c *fiber.Ctx) error { return service.CreateAlumniService(c, nil) })
+
+	body := `{"nim":"123","nama":"Budi","jurusan":"TI","angkatan":2018,"tahun_lulus":2022,
+		"email":"budi@example.com","password":"123456","role_id":"507f1f77bcf86cd799439011"}`
+	if resp := postJSON(app, "/alumni", body); resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+}
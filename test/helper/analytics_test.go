@@ -0,0 +1,31 @@
+package helper_test
+
+import (
+	"testing"
+
+	"go-fiber/helper"
+)
+
+func TestPercentage(t *testing.T) {
+	if got := helper.Percentage(2, 3); got != 66.67 {
+		t.Fatalf("expected 66.67, got %v", got)
+	}
+	if got := helper.Percentage(1, 0); got != 0 {
+		t.Fatalf("expected 0 for empty total, got %v", got)
+	}
+}
+
+func TestSalaryBucketRange(t *testing.T) {
+	max, label := helper.SalaryBucketRange(0)
+	if max == nil || *max != 3 || label != "< 3 juta" {
+		t.Fatalf("unexpected first bucket: %v %q", max, label)
+	}
+	max, label = helper.SalaryBucketRange(2)
+	if max == nil || *max != 8 || label != "5-8 juta" {
+		t.Fatalf("unexpected middle bucket: %v %q", max, label)
+	}
+	max, label = helper.SalaryBucketRange(len(helper.SalaryBoundaries) - 1)
+	if max != nil || label != ">= 20 juta" {
+		t.Fatalf("unexpected last bucket: %v %q", max, label)
+	}
+}
@@ -0,0 +1,57 @@
+package helper_test
+
+import (
+	"strings"
+	"testing"
+
+	"go-fiber/helper"
+)
+
+func TestGenerateAPIKey_FormatAndHash(t *testing.T) {
+	key, prefix, hash, err := helper.GenerateAPIKey()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(key, "ak_"+prefix+"_") {
+		t.Errorf("key %q does not start with prefix %q", key, prefix)
+	}
+	if len(prefix) != 8 {
+		t.Errorf("expected 8 char prefix, got %q", prefix)
+	}
+	if hash != helper.HashAPIKey(key) || len(hash) != 64 {
+		t.Errorf("unexpected hash %q", hash)
+	}
+
+	other, _, _, _ := helper.GenerateAPIKey()
+	if other == key {
+		t.Errorf("expected unique keys")
+	}
+}
+
+func TestNormalizeAPIKeyScopes(t *testing.T) {
+	scopes, err := helper.NormalizeAPIKeyScopes([]string{" Alumni:Check ", "alumni:check", "alumni:read", ""})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(scopes) != 2 || scopes[0] != helper.APIKeyScopeAlumniCheck || scopes[1] != helper.APIKeyScopeAlumniRead {
+		t.Errorf("unexpected scopes %v", scopes)
+	}
+
+	if _, err := helper.NormalizeAPIKeyScopes([]string{"alumni:write"}); err == nil {
+		t.Errorf("expected error for unknown scope")
+	}
+	if _, err := helper.NormalizeAPIKeyScopes(nil); err == nil {
+		t.Errorf("expected error for empty scopes")
+	}
+}
+
+func TestLegacyAPIKeyEnabled(t *testing.T) {
+	t.Setenv("LEGACY_API_KEY_ENABLED", "")
+	if !helper.LegacyAPIKeyEnabled() {
+		t.Errorf("legacy key should be enabled by default")
+	}
+	t.Setenv("LEGACY_API_KEY_ENABLED", "false")
+	if helper.LegacyAPIKeyEnabled() {
+		t.Errorf("legacy key should be disabled")
+	}
+}
@@ -0,0 +1,70 @@
+package helper_test
+
+import (
+	"testing"
+	"time"
+
+	"go-fiber/helper"
+)
+
+type auditSample struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Secret   string `json:"-"`
+}
+
+func TestAuditSnapshot_RedactsSecrets(t *testing.T) {
+	snapshot := helper.AuditSnapshot(auditSample{Name: "Budi", Email: "budi@example.com", Password: "hash", Secret: "x"})
+	if snapshot["name"] != "Budi" || snapshot["email"] != "budi@example.com" {
+		t.Errorf("unexpected snapshot %v", snapshot)
+	}
+	if _, ok := snapshot["password"]; ok {
+		t.Errorf("password must be redacted")
+	}
+	if len(snapshot) != 2 {
+		t.Errorf("expected 2 fields, got %v", snapshot)
+	}
+
+	var nilSample *auditSample
+	if helper.AuditSnapshot(nilSample) != nil || helper.AuditSnapshot(nil) != nil {
+		t.Errorf("expected nil snapshot for nil value")
+	}
+}
+
+func TestAuditDiff(t *testing.T) {
+	before := map[string]any{"name": "Budi", "email": "a@example.com", "updated_at": "t1", "phone": "08"}
+	after := map[string]any{"name": "Budi", "email": "b@example.com", "updated_at": "t2", "city": "Bandung"}
+
+	changes := helper.AuditDiff(before, after)
+	if len(changes) != 3 {
+		t.Fatalf("expected 3 changes, got %v", changes)
+	}
+	if c := changes["email"]; c.From != "a@example.com" || c.To != "b@example.com" {
+		t.Errorf("unexpected email change %v", c)
+	}
+	if c := changes["phone"]; c.From != "08" || c.To != nil {
+		t.Errorf("unexpected phone change %v", c)
+	}
+	if c := changes["city"]; c.From != nil || c.To != "Bandung" {
+		t.Errorf("unexpected city change %v", c)
+	}
+}
+
+func TestParseAuditTime(t *testing.T) {
+	from, err := helper.ParseAuditTime("2024-05-01", false)
+	if err != nil || from.Hour() != 0 || from.Day() != 1 {
+		t.Fatalf("unexpected from %v (%v)", from, err)
+	}
+	to, err := helper.ParseAuditTime("2024-05-01", true)
+	if err != nil || to.Day() != 1 || to.Hour() != 23 {
+		t.Fatalf("unexpected to %v (%v)", to, err)
+	}
+	exact, err := helper.ParseAuditTime("2024-05-01T10:00:00Z", true)
+	if err != nil || !exact.Equal(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected exact %v (%v)", exact, err)
+	}
+	if _, err := helper.ParseAuditTime("01/05/2024", false); err == nil {
+		t.Errorf("expected error for invalid format")
+	}
+}
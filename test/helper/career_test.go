@@ -0,0 +1,112 @@
+package helper_test
+
+import (
+	"testing"
+	"time"
+
+	"go-fiber/helper"
+)
+
+func careerDate(s string) time.Time {
+	t, _ := time.Parse("2006-01-02", s)
+	return t
+}
+
+func careerPeriod(id, start, end string, aktif bool) helper.CareerPeriod {
+	p := helper.CareerPeriod{ID: id, Label: id, Start: careerDate(start), Aktif: aktif}
+	if end != "" {
+		e := careerDate(end)
+		p.End = &e
+	}
+	return p
+}
+
+func TestCareerRules_Check(t *testing.T) {
+	rules := helper.CareerRules{RejectOverlap: true, SingleActive: true}
+	history := []helper.CareerPeriod{
+		careerPeriod("a", "2020-01-01", "2021-01-01", false),
+		careerPeriod("b", "2021-03-01", "", true),
+	}
+
+	cases := []struct {
+		name      string
+		rules     helper.CareerRules
+		candidate helper.CareerPeriod
+		wantErr   bool
+	}{
+		{"second active job", rules, careerPeriod("c", "2023-01-01", "", true), true},
+		{"overlapping past job", rules, careerPeriod("c", "2020-06-01", "2020-09-01", false), true},
+		{"handover on the same day", rules, careerPeriod("c", "2019-01-01", "2020-01-01", false), false},
+		{"within tolerance", helper.CareerRules{RejectOverlap: true, OverlapToleranceDays: 14}, careerPeriod("c", "2019-01-01", "2020-01-10", false), false},
+		{"over tolerance", helper.CareerRules{RejectOverlap: true, OverlapToleranceDays: 14}, careerPeriod("c", "2019-01-01", "2020-02-01", false), true},
+		{"overlap allowed by config", helper.CareerRules{}, careerPeriod("c", "2023-01-01", "", true), false},
+		{"updating the active job itself", rules, careerPeriod("b", "2021-03-01", "", true), false},
+	}
+	for _, tc := range cases {
+		err := tc.rules.Check(tc.candidate, history)
+		if (err != nil) != tc.wantErr {
+			t.Fatalf("%s: expected error=%v, got %v", tc.name, tc.wantErr, err)
+		}
+	}
+
+	partTime := careerPeriod("c", "2023-01-01", "", true)
+	partTime.ParuhWaktu = true
+	if err := rules.Check(partTime, history); err != nil {
+		t.Fatalf("part-time job should be exempt, got %v", err)
+	}
+}
+
+func TestMergeCareerPeriods(t *testing.T) {
+	rules := helper.CareerRules{RejectOverlap: true, SingleActive: true}
+	saved := []helper.CareerPeriod{careerPeriod("a", "2020-01-01", "", true)}
+	// Baris file yang menutup pekerjaan "a" menggantikan versi tersimpannya
+	closing := careerPeriod("a", "2020-01-01", "2022-01-01", false)
+	next := careerPeriod("row:3", "2022-01-01", "", true)
+
+	merged := helper.MergeCareerPeriods(saved, []helper.CareerPeriod{closing, next})
+	if len(merged) != 2 {
+		t.Fatalf("expected 2 periods, got %d", len(merged))
+	}
+	if err := rules.Check(next, merged); err != nil {
+		t.Fatalf("new job after the closed one should pass, got %v", err)
+	}
+	if err := rules.Check(next, saved); err == nil {
+		t.Fatal("expected conflict with the still-active saved job")
+	}
+
+	overlap := careerPeriod("row:4", "2021-06-01", "2021-12-01", false)
+	if err := rules.Check(overlap, helper.MergeCareerPeriods(saved, []helper.CareerPeriod{closing, next})); err == nil {
+		t.Fatal("expected overlap with another row of the same file")
+	}
+}
+
+func TestSummarizeCareer(t *testing.T) {
+	now := careerDate("2024-01-01")
+	periods := []helper.CareerPeriod{
+		careerPeriod("b", "2021-01-01", "2021-07-01", false),
+		careerPeriod("a", "2020-01-01", "2020-07-01", false),
+		careerPeriod("c", "2021-06-01", "", true),
+	}
+
+	summary := helper.SummarizeCareer(periods, now)
+	if len(summary.Gaps) != 1 || summary.Gaps[0].Days != 184 {
+		t.Fatalf("expected one gap of 184 days, got %+v", summary.Gaps)
+	}
+	if len(summary.Overlaps) != 1 || summary.Overlaps[0].First != 0 || summary.Overlaps[0].Second != 2 || summary.Overlaps[0].Days != 30 {
+		t.Fatalf("expected overlap between b and c of 30 days, got %+v", summary.Overlaps)
+	}
+	// 2020-01..2020-07 (182 hari, 6 bulan) + 2021-01..2024-01 (1095 hari, 36 bulan)
+	if summary.TotalDays != 182+1095 || summary.TotalMonths != 42 {
+		t.Fatalf("expected 1277 days / 42 months, got %d / %d", summary.TotalDays, summary.TotalMonths)
+	}
+
+	days, months := helper.CareerTenure(periods[2], now)
+	if days != 944 || months != 31 {
+		t.Fatalf("expected tenure 944 days / 31 months, got %d / %d", days, months)
+	}
+
+	periods[2].ParuhWaktu = true
+	if summary := helper.SummarizeCareer(periods, now); len(summary.Overlaps) != 0 {
+		t.Fatalf("part-time job should not be reported as overlap, got %+v", summary.Overlaps)
+	}
+}
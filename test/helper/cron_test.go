@@ -0,0 +1,69 @@
+package helper_test
+
+import (
+	"testing"
+	"time"
+
+	"go-fiber/helper"
+)
+
+func cronNext(t *testing.T, spec string, from time.Time) time.Time {
+	t.Helper()
+	s, err := helper.ParseCron(spec)
+	if err != nil {
+		t.Fatalf("unexpected error for %q: %v", spec, err)
+	}
+	return s.Next(from)
+}
+
+func TestParseCron_Next(t *testing.T) {
+	from := time.Date(2024, 3, 15, 10, 7, 30, 0, time.UTC) // Jumat
+	cases := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2024, 3, 15, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, 3, 15, 10, 15, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2024, 3, 15, 11, 0, 0, 0, time.UTC)},
+		{"0 2 * * *", time.Date(2024, 3, 16, 2, 0, 0, 0, time.UTC)},
+		{"30 9 * * 1-5", time.Date(2024, 3, 18, 9, 30, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2024, 3, 17, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 */3 *", time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// hari-bulan dan hari-minggu sama-sama dibatasi: cukup salah satu yang cocok
+		{"0 12 20 * 6", time.Date(2024, 3, 16, 12, 0, 0, 0, time.UTC)},
+	}
+	for _, tc := range cases {
+		if got := cronNext(t, tc.spec, from); !got.Equal(tc.want) {
+			t.Errorf("%q: expected %s, got %s", tc.spec, tc.want, got)
+		}
+	}
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		if _, err := helper.ParseCron(spec); err == nil {
+			t.Errorf("expected error for %q", spec)
+		}
+	}
+}
+
+func TestParseCron_Impossible(t *testing.T) {
+	if got := cronNext(t, "0 0 31 2 *", time.Now()); !got.IsZero() {
+		t.Fatalf("expected zero time, got %s", got)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	cases := map[int]time.Duration{
+		1: 30 * time.Second,
+		2: time.Minute,
+		4: 4 * time.Minute,
+		9: time.Hour,
+	}
+	for attempt, want := range cases {
+		if got := helper.Backoff(attempt, 30*time.Second, time.Hour); got != want {
+			t.Errorf("attempt %d: expected %s, got %s", attempt, want, got)
+		}
+	}
+}
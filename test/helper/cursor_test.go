@@ -0,0 +1,53 @@
+package helper_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"go-fiber/helper"
+)
+
+func TestCursor_RoundTrip(t *testing.T) {
+	base := helper.Cursor{SortBy: "angkatan", Order: "asc"}
+	next, prev := helper.PageCursors(base, nil, true, false,
+		&helper.CursorPosition{Value: 2018, ID: 7},
+		&helper.CursorPosition{Value: 2019, ID: 12})
+	if next == "" || prev != "" {
+		t.Fatalf("expected only next cursor on first page, got next=%q prev=%q", next, prev)
+	}
+
+	keyset, err := helper.ParseKeyset(next, false, base, helper.FilterTypeInt, helper.FilterTypeInt)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if keyset.Value != 2019 || keyset.ID != 12 || keyset.Before {
+		t.Fatalf("unexpected keyset: %+v", keyset)
+	}
+}
+
+func TestCursor_TamperedRejected(t *testing.T) {
+	token := helper.EncodeCursor(helper.Cursor{SortBy: "id", Order: "asc", Value: "5", ID: "5"})
+	forged := helper.EncodeCursor(helper.Cursor{SortBy: "id", Order: "asc", Value: "500", ID: "500"})
+	tampered := strings.SplitN(forged, ".", 2)[0] + "." + strings.SplitN(token, ".", 2)[1]
+
+	if _, err := helper.DecodeCursor(tampered); !errors.Is(err, helper.ErrInvalidCursor) {
+		t.Fatalf("expected ErrInvalidCursor, got %v", err)
+	}
+	if _, err := helper.DecodeCursor("not-a-cursor"); !errors.Is(err, helper.ErrInvalidCursor) {
+		t.Fatalf("expected ErrInvalidCursor, got %v", err)
+	}
+}
+
+func TestCursor_ScopeMismatch(t *testing.T) {
+	scope := helper.CursorScope(map[string]string{"filter[jurusan]": "TI", "page": "2"})
+	if scope != helper.CursorScope(map[string]string{"filter[jurusan]": "TI"}) {
+		t.Fatal("scope must ignore non-filter parameters")
+	}
+
+	token := helper.EncodeCursor(helper.Cursor{SortBy: "id", Order: "asc", Value: "5", ID: "5", Scope: scope})
+	other := helper.Cursor{SortBy: "id", Order: "asc", Scope: helper.CursorScope(map[string]string{"filter[jurusan]": "SI"})}
+	if _, err := helper.ParseKeyset(token, false, other, helper.FilterTypeInt, helper.FilterTypeInt); !errors.Is(err, helper.ErrInvalidCursor) {
+		t.Fatalf("expected ErrInvalidCursor for different filters, got %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package helper_test
+
+import (
+	"testing"
+
+	"go-fiber/helper"
+)
+
+func TestETagRoundTrip(t *testing.T) {
+	etag := helper.ETag(3)
+	if etag != `"3"` {
+		t.Fatalf("unexpected etag %s", etag)
+	}
+	version, ok, err := helper.ParseIfMatch(etag)
+	if err != nil || !ok || version != 3 {
+		t.Fatalf("unexpected parse result %d %v %v", version, ok, err)
+	}
+	if version, ok, err := helper.ParseIfMatch(`W/"7"`); err != nil || !ok || version != 7 {
+		t.Errorf("weak etag: unexpected parse result %d %v %v", version, ok, err)
+	}
+}
+
+func TestParseIfMatch_Unconditional(t *testing.T) {
+	for _, header := range []string{"", " ", "*"} {
+		if _, ok, err := helper.ParseIfMatch(header); ok || err != nil {
+			t.Errorf("%q: expected unconditional update, got ok=%v err=%v", header, ok, err)
+		}
+	}
+}
+
+func TestParseIfMatch_Invalid(t *testing.T) {
+	for _, header := range []string{"3", `"abc"`, `"-1"`, `"1", "2"`, `"`} {
+		if _, _, err := helper.ParseIfMatch(header); err == nil {
+			t.Errorf("%q: expected error", header)
+		}
+	}
+}
+
+func TestIfMatchRequired(t *testing.T) {
+	t.Setenv("STRICT_IF_MATCH", "")
+	if helper.IfMatchRequired() {
+		t.Errorf("expected If-Match optional by default")
+	}
+	t.Setenv("STRICT_IF_MATCH", "true")
+	if !helper.IfMatchRequired() {
+		t.Errorf("expected If-Match required in strict mode")
+	}
+}
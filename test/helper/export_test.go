@@ -0,0 +1,82 @@
+package helper_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"go-fiber/helper"
+)
+
+var exportColumns = []helper.ExportColumn{
+	{Key: "nim", Label: "NIM"},
+	{Key: "nama", Label: "Nama"},
+	{Key: "angkatan", Label: "Angkatan"},
+	{Key: "tanggal_mulai_kerja", Label: "Mulai"},
+	{Key: "alamat", Label: "Alamat"},
+}
+
+func writeExportRows(t *testing.T, format string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := helper.NewExportWriter(format, &buf, "Data Alumni", exportColumns)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var alamat *string
+	mulai := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	if err := w.WriteRow([]interface{}{"123", "Budi, S.Kom", 2018, &mulai, alamat}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestExportWriter_CSV(t *testing.T) {
+	got := string(writeExportRows(t, helper.ExportFormatCSV))
+	want := "nim,nama,angkatan,tanggal_mulai_kerja,alamat\n123,\"Budi, S.Kom\",2018,2023-01-02,\n"
+	if got != want {
+		t.Fatalf("unexpected CSV:\n%s", got)
+	}
+}
+
+func TestExportWriter_NDJSON(t *testing.T) {
+	got := string(writeExportRows(t, helper.ExportFormatNDJSON))
+	want := `{"nim":"123","nama":"Budi, S.Kom","angkatan":2018,"tanggal_mulai_kerja":"2023-01-02","alamat":null}` + "\n"
+	if got != want {
+		t.Fatalf("unexpected NDJSON:\n%s", got)
+	}
+}
+
+func TestExportWriter_XLSXRoundTrip(t *testing.T) {
+	sheet, err := helper.ReadSpreadsheet("alumni.xlsx", writeExportRows(t, helper.ExportFormatXLSX))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sheet.Rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(sheet.Rows))
+	}
+	row := sheet.Rows[0].Values
+	if row["nama"] != "Budi, S.Kom" || row["angkatan"] != "2018" || row["tanggal_mulai_kerja"] != "2023-01-02" {
+		t.Fatalf("unexpected row: %v", row)
+	}
+}
+
+func TestExportWriter_PDF(t *testing.T) {
+	got := writeExportRows(t, helper.ExportFormatPDF)
+	if !bytes.HasPrefix(got, []byte("%PDF-1.4")) || !bytes.HasSuffix(got, []byte("%%EOF\n")) {
+		t.Fatalf("not a PDF document")
+	}
+	if !strings.Contains(string(got), "/Type /Pages /Kids [6 0 R] /Count 1") {
+		t.Fatalf("expected a single page")
+	}
+}
+
+func TestExportWriter_UnsupportedFormat(t *testing.T) {
+	if _, err := helper.NewExportWriter("docx", &bytes.Buffer{}, "", exportColumns); err != helper.ErrUnsupportedExportFormat {
+		t.Fatalf("expected ErrUnsupportedExportFormat, got %v", err)
+	}
+}
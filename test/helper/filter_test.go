@@ -0,0 +1,54 @@
+package helper_test
+
+import (
+	"testing"
+
+	"go-fiber/helper"
+)
+
+var testSpec = helper.FilterSpec{
+	"angkatan": {Column: "angkatan", Type: helper.FilterTypeInt, Ops: helper.NumberFilterOps},
+	"jurusan":  {Column: "jurusan", Type: helper.FilterTypeString, Ops: helper.StringFilterOps},
+}
+
+func TestParseFilters_Valid(t *testing.T) {
+	filters, err := helper.ParseFilters(map[string]string{
+		"filter[angkatan][between]": "2018,2020",
+		"filter[jurusan][in]":       "TI,SI",
+		"page":                      "1",
+	}, testSpec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(filters) != 2 {
+		t.Fatalf("expected 2 filters, got %d", len(filters))
+	}
+	if filters[0].Op != helper.FilterBetween || filters[0].Values[0] != 2018 || filters[0].Values[1] != 2020 {
+		t.Fatalf("unexpected between filter: %+v", filters[0])
+	}
+	if filters[1].Op != helper.FilterIn || len(filters[1].Values) != 2 {
+		t.Fatalf("unexpected in filter: %+v", filters[1])
+	}
+}
+
+func TestParseFilters_DefaultsToEq(t *testing.T) {
+	filters, err := helper.ParseFilters(map[string]string{"filter[jurusan]": "TI"}, testSpec)
+	if err != nil || len(filters) != 1 || filters[0].Op != helper.FilterEq {
+		t.Fatalf("expected eq filter, got %+v (err %v)", filters, err)
+	}
+}
+
+func TestParseFilters_Rejected(t *testing.T) {
+	cases := map[string]string{
+		"filter[password][eq]":      "x",
+		"filter[jurusan][gte]":      "TI",
+		"filter[angkatan][gte]":     "abc",
+		"filter[angkatan][between]": "2018",
+		"filter[angkatan;drop]":     "1",
+	}
+	for key, value := range cases {
+		if _, err := helper.ParseFilters(map[string]string{key: value}, testSpec); err == nil {
+			t.Errorf("expected error for %s=%s", key, value)
+		}
+	}
+}
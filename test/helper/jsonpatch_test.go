@@ -0,0 +1,77 @@
+package helper_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"go-fiber/helper"
+)
+
+func assertJSONEqual(t *testing.T, got []byte, want string) {
+	t.Helper()
+	var g, w any
+	if err := json.Unmarshal(got, &g); err != nil {
+		t.Fatalf("invalid result %s: %v", got, err)
+	}
+	_ = json.Unmarshal([]byte(want), &w)
+	if !reflect.DeepEqual(g, w) {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	doc := []byte(`{"nama":"Budi","no_telepon":"0812","alamat":{"kota":"Surabaya","kode_pos":"60111"}}`)
+	got, err := helper.MergePatch(doc, []byte(`{"nama":"Budi S","no_telepon":null,"alamat":{"kode_pos":null}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertJSONEqual(t, got, `{"nama":"Budi S","alamat":{"kota":"Surabaya"}}`)
+
+	if _, err := helper.MergePatch(doc, []byte(`{"nama":`)); err == nil {
+		t.Error("expected error for malformed patch")
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	doc := []byte(`{"nama":"Budi","alamat":"Jl. A","tags":["a","b"]}`)
+	patch := []byte(`[
+		{"op":"test","path":"/nama","value":"Budi"},
+		{"op":"replace","path":"/nama","value":"Budi S"},
+		{"op":"remove","path":"/alamat"},
+		{"op":"add","path":"/tags/1","value":"x"},
+		{"op":"add","path":"/tags/-","value":"z"},
+		{"op":"copy","from":"/nama","path":"/alias"},
+		{"op":"move","from":"/alias","path":"/panggilan"}
+	]`)
+	got, err := helper.ApplyJSONPatch(doc, patch)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertJSONEqual(t, got, `{"nama":"Budi S","tags":["a","x","b","z"],"panggilan":"Budi S"}`)
+}
+
+func TestApplyJSONPatch_Errors(t *testing.T) {
+	doc := []byte(`{"nama":"Budi","tags":["a"]}`)
+	cases := map[string]string{
+		"not an array":       `{"op":"remove","path":"/nama"}`,
+		"unknown op":         `[{"op":"merge","path":"/nama"}]`,
+		"missing value":      `[{"op":"add","path":"/email"}]`,
+		"replace missing":    `[{"op":"replace","path":"/email","value":"x"}]`,
+		"remove missing":     `[{"op":"remove","path":"/email"}]`,
+		"bad pointer":        `[{"op":"remove","path":"nama"}]`,
+		"index out of range": `[{"op":"add","path":"/tags/5","value":"x"}]`,
+		"move into child":    `[{"op":"move","from":"/tags","path":"/tags/0"}]`,
+	}
+	for name, patch := range cases {
+		if _, err := helper.ApplyJSONPatch(doc, []byte(patch)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	_, err := helper.ApplyJSONPatch(doc, []byte(`[{"op":"test","path":"/nama","value":"Andi"}]`))
+	if !errors.Is(err, helper.ErrPatchTestFailed) {
+		t.Errorf("expected ErrPatchTestFailed, got %v", err)
+	}
+}
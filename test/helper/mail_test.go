@@ -0,0 +1,136 @@
+package helper_test
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"strings"
+	"testing"
+	"time"
+
+	"go-fiber/helper"
+)
+
+func TestRenderEmail_Locales(t *testing.T) {
+	data := map[string]interface{}{"Nama": "Budi", "Email": "budi@example.com", "NIM": "123"}
+
+	id, err := helper.RenderEmail("id", helper.EmailTemplateAccountCreated, data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	en, err := helper.RenderEmail("en", helper.EmailTemplateAccountCreated, data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id.Subject == "" || en.Subject == "" || id.Subject == en.Subject {
+		t.Fatalf("expected distinct localized subjects, got %q and %q", id.Subject, en.Subject)
+	}
+	for _, c := range []helper.EmailContent{id, en} {
+		if !strings.Contains(c.Text, "Budi") || !strings.Contains(c.HTML, "Budi") {
+			t.Errorf("expected name in text and html body: %+v", c)
+		}
+		if !strings.Contains(c.HTML, "<html") {
+			t.Errorf("expected html layout, got %q", c.HTML)
+		}
+	}
+
+	// Locale tidak dikenal jatuh ke bahasa Indonesia
+	fallback, err := helper.RenderEmail("fr", helper.EmailTemplateAccountCreated, data)
+	if err != nil || fallback.Subject != id.Subject {
+		t.Errorf("expected fallback to id locale, got %q (%v)", fallback.Subject, err)
+	}
+}
+
+func TestRenderEmail_EscapesHTML(t *testing.T) {
+	c, err := helper.RenderEmail("en", helper.EmailTemplateAccountCreated, map[string]interface{}{
+		"Nama": "<script>x</script>", "Email": "a@b.c", "NIM": "1",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(c.HTML, "<script>") {
+		t.Errorf("expected html body to be escaped, got %q", c.HTML)
+	}
+}
+
+func TestRenderEmail_Errors(t *testing.T) {
+	if _, err := helper.RenderEmail("id", helper.EmailTemplateAccountCreated, map[string]interface{}{"Nama": "Budi"}); err == nil {
+		t.Error("expected error for missing template data")
+	}
+	if _, err := helper.RenderEmail("id", "unknown", nil); err == nil {
+		t.Error("expected error for unknown template")
+	}
+}
+
+func TestFormatEmailDate(t *testing.T) {
+	d := time.Date(2024, 8, 17, 0, 0, 0, 0, time.UTC)
+	if got := helper.FormatEmailDate("id", d); got != "17 Agustus 2024" {
+		t.Errorf("id: got %q", got)
+	}
+	if got := helper.FormatEmailDate("en", d); got != "August 17, 2024" {
+		t.Errorf("en: got %q", got)
+	}
+}
+
+func TestSMTPMailer_FakeServer(t *testing.T) {
+	server, err := helper.StartFakeSMTPServer("127.0.0.1:0", nil)
+	if err != nil {
+		t.Fatalf("start fake smtp: %v", err)
+	}
+	defer server.Close()
+
+	host, port, _ := strings.Cut(server.Addr(), ":")
+	mailer := helper.SMTPMailer{Config: helper.SMTPConfig{Host: host, Port: port, From: "Alumni <no-reply@example.com>"}}
+	err = mailer.Send(helper.MailMessage{
+		To:      "budi@example.com",
+		Subject: "Akun Anda siap ✓",
+		Text:    "Halo Budi\n.baris diawali titik\n",
+		HTML:    "<p>Halo Budi</p>",
+	})
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+
+	mails := server.Mails()
+	if len(mails) != 1 {
+		t.Fatalf("expected 1 mail, got %d", len(mails))
+	}
+	m := mails[0]
+	if m.From != "no-reply@example.com" || len(m.To) != 1 || m.To[0] != "budi@example.com" {
+		t.Errorf("unexpected envelope: from %q to %v", m.From, m.To)
+	}
+	if m.Message == nil {
+		t.Fatal("expected parsed message")
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(m.Message.Header.Get("Subject"))
+	if err != nil || subject != "Akun Anda siap ✓" {
+		t.Errorf("unexpected subject %q (%v)", subject, err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(m.Message.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("unexpected content type %q (%v)", mediaType, err)
+	}
+	parts := map[string]string{}
+	r := multipart.NewReader(m.Message.Body, params["boundary"])
+	for {
+		p, err := r.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("read part: %v", err)
+		}
+		ct, _, _ := mime.ParseMediaType(p.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(quotedprintable.NewReader(p))
+		parts[ct] = string(body)
+	}
+	if !strings.Contains(parts["text/plain"], ".baris diawali titik") {
+		t.Errorf("unexpected text part %q", parts["text/plain"])
+	}
+	if parts["text/html"] != "<p>Halo Budi</p>" {
+		t.Errorf("unexpected html part %q", parts["text/html"])
+	}
+}
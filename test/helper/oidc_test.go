@@ -0,0 +1,194 @@
+package helper_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"go-fiber/helper"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func startFakeOIDC(t *testing.T) (*helper.FakeOIDCServer, *helper.OIDCProvider) {
+	t.Helper()
+	server, err := helper.StartFakeOIDCServer("127.0.0.1:0", "alumni-app", []helper.FakeOIDCUser{
+		{Subject: "sub-1", Email: "Rina.Pratama@gmail.com", EmailVerified: true, Name: "Rina", NIM: "20170002"},
+		{Subject: "sub-2", Email: "budi@sso.example.ac.id", NIM: "20150003"},
+	})
+	if err != nil {
+		t.Fatalf("failed to start fake OIDC server: %v", err)
+	}
+	t.Cleanup(func() { server.Close() })
+
+	provider, err := helper.DiscoverOIDCProvider(context.Background(), helper.OIDCConfig{
+		Issuer:      server.Issuer(),
+		ClientID:    "alumni-app",
+		RedirectURL: "http://localhost:3000/callback",
+		Scopes:      []string{"openid", "email"},
+		NIMClaim:    "nim",
+	})
+	if err != nil {
+		t.Fatalf("discovery failed: %v", err)
+	}
+	return server, provider
+}
+
+// authorize -> jalankan redirect authorization lalu kembalikan code dari redirect_uri
+func authorize(t *testing.T, provider *helper.OIDCProvider, state *helper.OIDCAuthState, hint string) string {
+	t.Helper()
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(provider.AuthCodeURL(state, hint))
+	if err != nil {
+		t.Fatalf("authorize request failed: %v", err)
+	}
+	resp.Body.Close()
+	loc, err := url.Parse(resp.Header.Get("Location"))
+	if resp.StatusCode != http.StatusFound || err != nil {
+		t.Fatalf("expected redirect, got %d %q", resp.StatusCode, resp.Header.Get("Location"))
+	}
+	if !strings.HasPrefix(loc.String(), "http://localhost:3000/callback?") || loc.Query().Get("state") != state.State {
+		t.Fatalf("unexpected redirect %s", loc)
+	}
+	return loc.Query().Get("code")
+}
+
+func TestOIDC_AuthorizationCodeFlowWithPKCE(t *testing.T) {
+	server, provider := startFakeOIDC(t)
+	if provider.Metadata.Issuer != server.Issuer() || provider.Metadata.JWKSURI == "" {
+		t.Fatalf("unexpected metadata: %+v", provider.Metadata)
+	}
+
+	state, err := helper.NewOIDCAuthState()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	authURL, _ := url.Parse(provider.AuthCodeURL(state, ""))
+	q := authURL.Query()
+	if q.Get("code_challenge") != helper.PKCEChallenge(state.Verifier) || q.Get("code_challenge_method") != "S256" ||
+		q.Get("nonce") != state.Nonce || q.Get("scope") != "openid email" {
+		t.Errorf("unexpected authorization request: %s", authURL.RawQuery)
+	}
+
+	code := authorize(t, provider, state, "budi@sso.example.ac.id")
+	rawIDToken, err := provider.Exchange(context.Background(), code, state.Verifier)
+	if err != nil {
+		t.Fatalf("exchange failed: %v", err)
+	}
+	identity, err := provider.VerifyIDToken(context.Background(), rawIDToken, state.Nonce)
+	if err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+	if identity.Subject != "sub-2" || identity.EmailVerified || identity.NIM != "20150003" || identity.Issuer != server.Issuer() {
+		t.Errorf("unexpected identity: %+v", identity)
+	}
+
+	// Code hanya bisa ditukar sekali
+	if _, err := provider.Exchange(context.Background(), code, state.Verifier); err == nil {
+		t.Error("expected reused code to be rejected")
+	}
+	// Nonce harus sama dengan yang dikirim saat authorize
+	if _, err := provider.VerifyIDToken(context.Background(), rawIDToken, "other-nonce"); err == nil {
+		t.Error("expected nonce mismatch to be rejected")
+	}
+}
+
+func TestOIDC_ExchangeRejectsWrongVerifier(t *testing.T) {
+	_, provider := startFakeOIDC(t)
+	state, _ := helper.NewOIDCAuthState()
+	code := authorize(t, provider, state, "")
+
+	if _, err := provider.Exchange(context.Background(), code, "wrong-verifier"); err == nil {
+		t.Fatal("expected PKCE verifier mismatch to be rejected")
+	}
+}
+
+func TestOIDC_VerifyIDTokenClaims(t *testing.T) {
+	server, provider := startFakeOIDC(t)
+	now := time.Now()
+	valid := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss": server.Issuer(), "sub": "sub-1", "aud": "alumni-app", "nonce": "n-1",
+			"iat": now.Unix(), "exp": now.Add(time.Minute).Unix(),
+			"email": "rina.pratama@gmail.com", "email_verified": "true", "nim": float64(20170002),
+		}
+	}
+
+	raw, _ := server.SignIDToken(valid())
+	identity, err := provider.VerifyIDToken(context.Background(), raw, "n-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !identity.EmailVerified || identity.NIM != "20170002" {
+		t.Errorf("unexpected identity: %+v", identity)
+	}
+
+	cases := map[string]func(jwt.MapClaims){
+		"wrong audience": func(c jwt.MapClaims) { c["aud"] = "other-app" },
+		"wrong issuer":   func(c jwt.MapClaims) { c["iss"] = "http://evil.example" },
+		"expired":        func(c jwt.MapClaims) { c["exp"] = now.Add(-time.Hour).Unix() },
+		"missing exp":    func(c jwt.MapClaims) { delete(c, "exp") },
+		"wrong azp":      func(c jwt.MapClaims) { c["azp"] = "other-app" },
+		"missing sub":    func(c jwt.MapClaims) { delete(c, "sub") },
+	}
+	for name, mutate := range cases {
+		claims := valid()
+		mutate(claims)
+		raw, _ := server.SignIDToken(claims)
+		if _, err := provider.VerifyIDToken(context.Background(), raw, "n-1"); err == nil {
+			t.Errorf("%s: expected rejection", name)
+		}
+	}
+
+	// Tanda tangan HMAC (alg confusion) ditolak
+	hs, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, valid()).SignedString([]byte("secret"))
+	if _, err := provider.VerifyIDToken(context.Background(), hs, "n-1"); err == nil {
+		t.Error("expected HS256 token to be rejected")
+	}
+}
+
+func TestOIDCState_SignAndParse(t *testing.T) {
+	secret := []byte("state-secret")
+	state, _ := helper.NewOIDCAuthState()
+	signed, err := helper.SignOIDCState(secret, state)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	parsed, err := helper.ParseOIDCState(secret, signed)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if parsed.State != state.State || parsed.Nonce != state.Nonce || parsed.Verifier != state.Verifier {
+		t.Errorf("state mismatch: %+v", parsed)
+	}
+	if _, err := helper.ParseOIDCState([]byte("other-secret"), signed); err == nil {
+		t.Error("expected wrong secret to be rejected")
+	}
+	if _, err := helper.ParseOIDCState(secret, ""); err == nil {
+		t.Error("expected empty cookie to be rejected")
+	}
+}
+
+func TestOIDCConfigFromEnv(t *testing.T) {
+	t.Setenv("OIDC_ISSUER", "")
+	t.Setenv("OIDC_CLIENT_ID", "alumni-app")
+	t.Setenv("OIDC_REDIRECT_URL", "http://localhost:3000/callback")
+	if _, err := helper.OIDCConfigFromEnv(); err != helper.ErrOIDCNotConfigured {
+		t.Fatalf("expected ErrOIDCNotConfigured, got %v", err)
+	}
+
+	t.Setenv("OIDC_ISSUER", "http://127.0.0.1:9400")
+	t.Setenv("OIDC_SCOPES", "")
+	t.Setenv("OIDC_NIM_CLAIM", "")
+	t.Setenv("OIDC_STATE_SECRET", "")
+	cfg, err := helper.OIDCConfigFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(cfg.Scopes, " ") != "openid email profile" || cfg.NIMClaim != "nim" || len(cfg.StateSecret) != 32 {
+		t.Errorf("unexpected defaults: %+v", cfg)
+	}
+}
@@ -0,0 +1,85 @@
+package helper_test
+
+import (
+	"testing"
+	"time"
+
+	"go-fiber/helper"
+)
+
+func TestMemoryRateLimitStore_CountsWithinWindow(t *testing.T) {
+	store := helper.NewMemoryRateLimitStore()
+	for i := 1; i <= 3; i++ {
+		count, remaining, err := store.Hit("login:ip:127.0.0.1", time.Minute)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if count != i {
+			t.Errorf("hit %d: expected count %d, got %d", i, i, count)
+		}
+		if remaining <= 0 || remaining > time.Minute {
+			t.Errorf("hit %d: unexpected remaining %s", i, remaining)
+		}
+	}
+
+	// Key lain punya counter sendiri
+	if count, _, _ := store.Hit("login:ip:10.0.0.1", time.Minute); count != 1 {
+		t.Errorf("expected separate counter, got %d", count)
+	}
+}
+
+func TestMemoryRateLimitStore_WindowExpiresAndReset(t *testing.T) {
+	store := helper.NewMemoryRateLimitStore()
+	store.Hit("k", 20*time.Millisecond)
+	store.Hit("k", 20*time.Millisecond)
+	time.Sleep(30 * time.Millisecond)
+	if count, _, _ := store.Hit("k", 20*time.Millisecond); count != 1 {
+		t.Errorf("expected new window after expiry, got count %d", count)
+	}
+
+	store.Hit("k", time.Minute)
+	if err := store.Reset("k"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count, _, _ := store.Hit("k", time.Minute); count != 1 {
+		t.Errorf("expected count 1 after reset, got %d", count)
+	}
+}
+
+func TestLoginProtectionFromEnv(t *testing.T) {
+	t.Setenv("RATE_LIMIT_STORE", "")
+	t.Setenv("LOGIN_IP_LIMIT", "")
+	t.Setenv("LOGIN_MAX_FAILURES", "abc")
+	cfg := helper.LoginProtectionFromEnv()
+	if cfg.Store != helper.RateLimitStoreMemory || cfg.IPLimit != 20 || cfg.AccountLimit != 10 ||
+		cfg.MaxFailures != 5 || cfg.Window != 15*time.Minute || cfg.Lockout != 15*time.Minute {
+		t.Errorf("unexpected defaults: %+v", cfg)
+	}
+
+	t.Setenv("RATE_LIMIT_STORE", "Database")
+	t.Setenv("LOGIN_IP_LIMIT", "50")
+	t.Setenv("LOGIN_LOCKOUT_MINUTES", "30")
+	cfg = helper.LoginProtectionFromEnv()
+	if cfg.Store != helper.RateLimitStoreDatabase || cfg.IPLimit != 50 || cfg.Lockout != 30*time.Minute {
+		t.Errorf("env not applied: %+v", cfg)
+	}
+}
+
+func TestLoginProtection_FailureDelay(t *testing.T) {
+	cfg := helper.LoginProtection{DelayBase: time.Second, DelayMax: 30 * time.Second}
+	cases := map[int]time.Duration{0: 0, 1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 10: 30 * time.Second}
+	for failures, want := range cases {
+		if got := cfg.FailureDelay(failures); got != want {
+			t.Errorf("failures %d: expected %s, got %s", failures, want, got)
+		}
+	}
+}
+
+func TestRetryAfterSeconds(t *testing.T) {
+	cases := map[time.Duration]string{0: "1", 300 * time.Millisecond: "1", 1500 * time.Millisecond: "2", time.Minute: "60"}
+	for d, want := range cases {
+		if got := helper.RetryAfterSeconds(d); got != want {
+			t.Errorf("%s: expected %s, got %s", d, want, got)
+		}
+	}
+}
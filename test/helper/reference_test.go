@@ -0,0 +1,64 @@
+package helper_test
+
+import (
+	"reflect"
+	"testing"
+
+	"go-fiber/helper"
+)
+
+func TestNormalizeReferenceName(t *testing.T) {
+	cases := map[string]string{
+		"PT. Telkom Indonesia (Persero) Tbk": "telkom indonesia",
+		"  Bank   Central Asia ":             "bank central asia",
+		"CV":                                 "cv",
+		"---":                                "",
+	}
+	for input, want := range cases {
+		if got := helper.NormalizeReferenceName(input); got != want {
+			t.Fatalf("NormalizeReferenceName(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestReferenceAliases(t *testing.T) {
+	aliases := helper.CleanReferenceAliases("PT Telkom Indonesia", []string{"Telkom  Indonesia Tbk", " Telkom ", "", "telkom", "TLKM"})
+	if want := []string{"Telkom", "TLKM"}; !reflect.DeepEqual(aliases, want) {
+		t.Fatalf("expected aliases %v, got %v", want, aliases)
+	}
+	keys := helper.ReferenceKeys("PT Telkom Indonesia", aliases)
+	if want := []string{"telkom indonesia", "telkom", "tlkm"}; !reflect.DeepEqual(keys, want) {
+		t.Fatalf("expected keys %v, got %v", want, keys)
+	}
+}
+
+func TestRankReferences(t *testing.T) {
+	candidates := [][]string{
+		{"Bank Mandiri"},
+		{"Telkom Indonesia", "TLKM"},
+		{"Tokopedia"},
+		{"Bank Central Asia", "BCA"},
+	}
+
+	matches := helper.RankReferences("bank", candidates, 0)
+	if len(matches) != 2 || matches[0].Index != 0 || matches[1].Index != 3 {
+		t.Fatalf("expected both banks by name order, got %+v", matches)
+	}
+
+	matches = helper.RankReferences("telkon", candidates, 0)
+	if len(matches) == 0 || matches[0].Index != 1 || matches[0].Matched != "Telkom Indonesia" {
+		t.Fatalf("expected typo to match Telkom Indonesia first, got %+v", matches)
+	}
+
+	matches = helper.RankReferences("bca", candidates, 0)
+	if len(matches) == 0 || matches[0].Index != 3 || matches[0].Score != 1 || matches[0].Matched != "BCA" {
+		t.Fatalf("expected exact alias match for BCA, got %+v", matches)
+	}
+
+	if matches := helper.RankReferences("bank", candidates, 1); len(matches) != 1 {
+		t.Fatalf("expected limit to cap results at 1, got %d", len(matches))
+	}
+	if matches := helper.RankReferences("zzzz", candidates, 0); len(matches) != 0 {
+		t.Fatalf("expected no match for unrelated query, got %+v", matches)
+	}
+}
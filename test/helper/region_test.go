@@ -0,0 +1,137 @@
+package helper_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"go-fiber/helper"
+)
+
+func locationValue(p *string) string {
+	if p == nil {
+		return ""
+	}
+	return *p
+}
+
+func TestFindProvince(t *testing.T) {
+	for _, name := range []string{"32", "Jawa Barat", "jabar", "Provinsi Jawa Barat"} {
+		p, ok := helper.FindProvince(name)
+		if !ok || p.Nama != "Jawa Barat" {
+			t.Fatalf("%q: expected Jawa Barat, got %+v (%v)", name, p, ok)
+		}
+	}
+	if p, ok := helper.FindProvince("jakarta"); !ok || p.Nama != "DKI Jakarta" {
+		t.Fatalf("expected alias jakarta to match DKI Jakarta, got %+v", p)
+	}
+	if _, ok := helper.FindProvince("Atlantis"); ok {
+		t.Fatal("expected unknown province to fail")
+	}
+}
+
+func TestFindCity(t *testing.T) {
+	city, province, ok := helper.FindCity("Bandung", "")
+	if !ok || city.Nama != "Kota Bandung" || province.Nama != "Jawa Barat" {
+		t.Fatalf("expected bare name to prefer Kota Bandung, got %s / %s", city.Nama, province.Nama)
+	}
+	city, _, ok = helper.FindCity("Kab. Bandung", "jabar")
+	if !ok || city.Nama != "Kabupaten Bandung" {
+		t.Fatalf("expected explicit prefix to match Kabupaten Bandung, got %s", city.Nama)
+	}
+	city, _, ok = helper.FindCity("solo", "")
+	if !ok || city.Nama != "Kota Surakarta" {
+		t.Fatalf("expected alias solo to match Kota Surakarta, got %s", city.Nama)
+	}
+	if _, _, ok := helper.FindCity("Surabaya", "Jawa Barat"); ok {
+		t.Fatal("expected city outside the given province to fail")
+	}
+}
+
+func TestResolveLocation(t *testing.T) {
+	loc, err := helper.ResolveLocation("Bandung", "", "jabar", "bandung", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if locationValue(loc.Negara) != "ID" || locationValue(loc.Provinsi) != "Jawa Barat" || locationValue(loc.Kota) != "Kota Bandung" {
+		t.Fatalf("expected canonical names, got %s / %s / %s", locationValue(loc.Negara), locationValue(loc.Provinsi), locationValue(loc.Kota))
+	}
+	if loc.Latitude == nil || loc.Longitude == nil {
+		t.Fatal("expected city centroid when coordinates are missing")
+	}
+
+	lat, lng := -6.9, 107.6
+	loc, err = helper.ResolveLocation("Bandung", "", "", "Kota Bandung", &lat, &lng)
+	if err != nil || *loc.Latitude != lat || *loc.Longitude != lng {
+		t.Fatalf("expected explicit coordinates to win, got %+v (%v)", loc, err)
+	}
+
+	loc, err = helper.ResolveLocation("Singapore", "singapore", "", "Singapore", nil, nil)
+	if err != nil || locationValue(loc.Negara) != "SG" || locationValue(loc.Kota) != "Singapore" {
+		t.Fatalf("expected foreign location kept as given, got %+v (%v)", loc, err)
+	}
+
+	if _, err := helper.ResolveLocation("x", "", "Atlantis", "", nil, nil); err == nil || !strings.Contains(err.Error(), "/wilayah") {
+		t.Fatalf("expected unknown province error pointing to /wilayah, got %v", err)
+	}
+	if _, err := helper.ResolveLocation("x", "", "", "Kota Bandung", &lat, nil); err == nil {
+		t.Fatal("expected error for latitude without longitude")
+	}
+	bad := 91.0
+	if _, err := helper.ResolveLocation("x", "", "", "", &bad, &lng); err == nil {
+		t.Fatal("expected error for latitude out of range")
+	}
+}
+
+func TestGuessLocation(t *testing.T) {
+	cases := []struct {
+		text, provinsi, kota string
+	}{
+		{"Jakarta Selatan", "DKI Jakarta", "Kota Jakarta Selatan"},
+		{"Bandung, Jawa Barat", "Jawa Barat", "Kota Bandung"},
+		{"Cikarang - Bekasi", "Jawa Barat", "Kabupaten Bekasi"},
+		{"Jawa Tengah", "Jawa Tengah", ""},
+	}
+	for _, tc := range cases {
+		loc, ok := helper.GuessLocation(tc.text)
+		if !ok || locationValue(loc.Provinsi) != tc.provinsi || locationValue(loc.Kota) != tc.kota {
+			t.Fatalf("%q: got %s / %s (%v)", tc.text, locationValue(loc.Provinsi), locationValue(loc.Kota), ok)
+		}
+	}
+	if loc, ok := helper.GuessLocation("Remote"); ok {
+		t.Fatalf("expected unrecognised text to fail, got %+v", loc)
+	}
+}
+
+func TestHaversineKm(t *testing.T) {
+	// Jakarta - Bandung sekitar 120 km garis lurus
+	if d := helper.HaversineKm(-6.2088, 106.8456, -6.9175, 107.6191); math.Abs(d-116) > 10 {
+		t.Fatalf("unexpected distance %.1f km", d)
+	}
+	if d := helper.HaversineKm(1, 1, 1, 1); d != 0 {
+		t.Fatalf("expected zero distance, got %f", d)
+	}
+}
+
+func TestParseFilters_Near(t *testing.T) {
+	spec := helper.FilterSpec{
+		"koordinat": {Column: "latitude,longitude", Type: helper.FilterTypeGeo, Ops: helper.GeoFilterOps},
+	}
+	filters, err := helper.ParseFilters(map[string]string{"filter[koordinat][near]": "-6.2,106.8,25"}, spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	geo, ok := filters[0].Values[0].(helper.GeoRadius)
+	if !ok || geo.Lat != -6.2 || geo.Lng != 106.8 || geo.RadiusKm != 25 {
+		t.Fatalf("unexpected geo filter %+v", filters[0].Values)
+	}
+
+	for _, raw := range []string{"-6.2,106.8", "-6.2,106.8,0", "-6.2,106.8,5000", "95,106.8,10", "a,b,c"} {
+		if _, err := helper.ParseFilters(map[string]string{"filter[koordinat][near]": raw}, spec); err == nil {
+			t.Fatalf("%q: expected error", raw)
+		}
+	}
+	if _, err := helper.ParseFilters(map[string]string{"filter[koordinat][eq]": "1"}, spec); err == nil {
+		t.Fatal("expected eq to be rejected for geo field")
+	}
+}
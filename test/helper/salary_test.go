@@ -0,0 +1,80 @@
+package helper_test
+
+import (
+	"testing"
+
+	"go-fiber/helper"
+)
+
+func salaryValue(p *int64) int64 {
+	if p == nil {
+		return -1
+	}
+	return *p
+}
+
+func TestParseSalaryRange(t *testing.T) {
+	cases := []struct {
+		input    string
+		min, max int64
+		currency string
+		period   string
+	}{
+		{"5-10 juta", 5000000, 10000000, "IDR", "bulanan"},
+		{"8-12jt", 8000000, 12000000, "IDR", "bulanan"},
+		{"Rp 5.000.000 - Rp 7.500.000", 5000000, 7500000, "IDR", "bulanan"},
+		{"5,5 juta", 5500000, 5500000, "IDR", "bulanan"},
+		{"6-9", 6000000, 9000000, "IDR", "bulanan"},
+		{"120 juta per tahun", 120000000, 120000000, "IDR", "tahunan"},
+		{"USD 2k-3k", 2000, 3000, "USD", "bulanan"},
+		{"> 10 juta", 10000000, -1, "IDR", "bulanan"},
+		{"di bawah 4 jt", -1, 4000000, "IDR", "bulanan"},
+	}
+	for _, tc := range cases {
+		got, err := helper.ParseSalaryRange(tc.input)
+		if err != nil {
+			t.Fatalf("%q: unexpected error %v", tc.input, err)
+		}
+		if salaryValue(got.Min) != tc.min || salaryValue(got.Max) != tc.max || got.Currency != tc.currency || got.Period != tc.period {
+			t.Fatalf("%q: got %d-%d %s %s", tc.input, salaryValue(got.Min), salaryValue(got.Max), got.Currency, got.Period)
+		}
+	}
+
+	if _, err := helper.ParseSalaryRange("dirahasiakan"); err == nil {
+		t.Fatal("expected error for text without amount")
+	}
+}
+
+func TestResolveSalary(t *testing.T) {
+	legacy := "5-10 juta"
+	got, err := helper.ResolveSalary(&legacy, nil, nil, "", "tahunan")
+	if err != nil || salaryValue(got.Min) != 5000000 || got.Period != "tahunan" {
+		t.Fatalf("expected parsed legacy range with explicit period, got %+v (%v)", got, err)
+	}
+
+	min, max := int64(7000000), int64(9000000)
+	got, err = helper.ResolveSalary(&legacy, &min, &max, "idr", "")
+	if err != nil || salaryValue(got.Min) != min || got.Currency != "IDR" || got.Period != "bulanan" {
+		t.Fatalf("expected structured amounts to win, got %+v (%v)", got, err)
+	}
+
+	if _, err := helper.ResolveSalary(nil, &max, &min, "", ""); err == nil {
+		t.Fatal("expected error when gaji_min is above gaji_max")
+	}
+	if _, err := helper.ResolveSalary(nil, nil, nil, "rupiah", ""); err == nil {
+		t.Fatal("expected error for invalid currency code")
+	}
+}
+
+func TestFormatSalary(t *testing.T) {
+	min, max := int64(5500000), int64(8000000)
+	if got := helper.FormatSalary(&min, &max, "IDR", "bulanan"); got != "5,5-8 juta" {
+		t.Fatalf("unexpected format %q", got)
+	}
+	if got := helper.FormatSalary(&min, nil, "IDR", "tahunan"); got != "> 5,5 juta / tahun" {
+		t.Fatalf("unexpected format %q", got)
+	}
+	if got := helper.FormatSalary(nil, nil, "IDR", "bulanan"); got != "" {
+		t.Fatalf("expected empty format, got %q", got)
+	}
+}
@@ -0,0 +1,67 @@
+package helper_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"go-fiber/helper"
+)
+
+func TestReadSpreadsheet_CSVSemicolon(t *testing.T) {
+	data := []byte("\ufeffNIM;Nama;Tahun Lulus\n123;Budi;2022\n;;\n456;Sari;2023\n")
+	sheet, err := helper.ReadSpreadsheet("alumni.csv", data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sheet.Headers) != 3 || sheet.Headers[2] != "tahun_lulus" {
+		t.Fatalf("unexpected headers: %v", sheet.Headers)
+	}
+	if len(sheet.Rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(sheet.Rows))
+	}
+	if sheet.Rows[1].Number != 4 || sheet.Rows[1].Values["nama"] != "Sari" {
+		t.Fatalf("unexpected row: %+v", sheet.Rows[1])
+	}
+}
+
+func TestReadSpreadsheet_XLSX(t *testing.T) {
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	w, _ := zw.Create("xl/sharedStrings.xml")
+	w.Write([]byte(`<sst><si><t>nim</t></si><si><t>nama</t></si><si><r><t>Bu</t></r><r><t>di</t></r></si></sst>`))
+	w, _ = zw.Create("xl/worksheets/sheet1.xml")
+	w.Write([]byte(`<worksheet><sheetData>` +
+		`<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>` +
+		`<row r="3"><c r="A3"><v>123</v></c><c r="B3" t="s"><v>2</v></c></row>` +
+		`</sheetData></worksheet>`))
+	zw.Close()
+
+	sheet, err := helper.ReadSpreadsheet("alumni.xlsx", buf.Bytes())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sheet.Rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(sheet.Rows))
+	}
+	row := sheet.Rows[0]
+	if row.Number != 3 || row.Values["nim"] != "123" || row.Values["nama"] != "Budi" {
+		t.Fatalf("unexpected row: %+v", row)
+	}
+}
+
+func TestReadSpreadsheet_Unsupported(t *testing.T) {
+	if _, err := helper.ReadSpreadsheet("alumni.txt", []byte("nim")); err != helper.ErrUnsupportedSpreadsheet {
+		t.Fatalf("expected ErrUnsupportedSpreadsheet, got %v", err)
+	}
+}
+
+func TestParseSpreadsheetDate_ExcelSerial(t *testing.T) {
+	got, err := helper.ParseSpreadsheetDate("44927")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Format("2006-01-02") != "2023-01-01" {
+		t.Fatalf("expected 2023-01-01, got %s", got.Format("2006-01-02"))
+	}
+}
@@ -0,0 +1,107 @@
+package helper_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"go-fiber/helper"
+)
+
+// Secret ASCII "12345678901234567890" dari RFC 6238 Appendix B (SHA-1)
+const rfcTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode_RFC6238Vectors(t *testing.T) {
+	cases := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+	for unix, want := range cases {
+		got, err := helper.TOTPCode(rfcTOTPSecret, helper.TOTPStep(time.Unix(unix, 0)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != want {
+			t.Errorf("t=%d: expected %s, got %s", unix, want, got)
+		}
+	}
+}
+
+func TestVerifyTOTP_Skew(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	step := helper.TOTPStep(now)
+	prev, _ := helper.TOTPCode(rfcTOTPSecret, step-1)
+	old, _ := helper.TOTPCode(rfcTOTPSecret, step-2)
+
+	if got, ok := helper.VerifyTOTP(rfcTOTPSecret, "005924", now); !ok || got != step {
+		t.Errorf("expected current code to match step %d, got %d %v", step, got, ok)
+	}
+	if got, ok := helper.VerifyTOTP(rfcTOTPSecret, prev, now); !ok || got != step-1 {
+		t.Errorf("expected previous step within skew, got %d %v", got, ok)
+	}
+	if _, ok := helper.VerifyTOTP(rfcTOTPSecret, old, now); ok {
+		t.Error("expected code two steps old to be rejected")
+	}
+	if _, ok := helper.VerifyTOTP(rfcTOTPSecret, "12345", now); ok {
+		t.Error("expected short code to be rejected")
+	}
+}
+
+func TestGenerateTOTPSecret_AndURI(t *testing.T) {
+	t.Setenv("APP_NAME", "Alumni App")
+	secret, err := helper.GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(secret) != 32 {
+		t.Errorf("expected 32 base32 chars, got %d", len(secret))
+	}
+	if _, err := helper.TOTPCode(secret, 1); err != nil {
+		t.Errorf("generated secret not usable: %v", err)
+	}
+
+	uri, err := url.Parse(helper.TOTPURI("sayu@gmail.com", secret))
+	if err != nil {
+		t.Fatalf("invalid uri: %v", err)
+	}
+	if uri.Scheme != "otpauth" || uri.Host != "totp" || uri.Path != "/Alumni App:sayu@gmail.com" {
+		t.Errorf("unexpected uri: %s", uri)
+	}
+	q := uri.Query()
+	if q.Get("secret") != secret || q.Get("issuer") != "Alumni App" || q.Get("digits") != "6" || q.Get("period") != "30" {
+		t.Errorf("unexpected query: %s", uri.RawQuery)
+	}
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := helper.GenerateRecoveryCodes(helper.RecoveryCodeCount)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(codes) != helper.RecoveryCodeCount {
+		t.Fatalf("expected %d codes, got %d", helper.RecoveryCodeCount, len(codes))
+	}
+	seen := map[string]bool{}
+	for _, code := range codes {
+		if len(code) != 11 || code[5] != '-' {
+			t.Errorf("unexpected format %q", code)
+		}
+		if seen[code] {
+			t.Errorf("duplicate code %q", code)
+		}
+		seen[code] = true
+	}
+
+	// Hash tidak peka huruf besar, spasi, dan tanda hubung
+	code := codes[0]
+	variant := " " + strings.ToUpper(strings.ReplaceAll(code, "-", "")) + " "
+	if helper.HashRecoveryCode(code) != helper.HashRecoveryCode(variant) {
+		t.Error("expected normalized codes to hash equally")
+	}
+	if helper.HashRecoveryCode(codes[0]) == helper.HashRecoveryCode(codes[1]) {
+		t.Error("expected different codes to hash differently")
+	}
+}
@@ -0,0 +1,144 @@
+package helper_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go-fiber/helper"
+)
+
+func newTestSigner(t *testing.T, retired ...ed25519.PublicKey) (*helper.VerificationSigner, ed25519.PrivateKey) {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	return helper.NewVerificationSigner(key, retired, "kampus-test", 24*time.Hour), key
+}
+
+func testVerificationClaims() helper.VerificationClaims {
+	return helper.VerificationClaims{
+		NIM:        "2021001",
+		Nama:       "Budi Santoso",
+		Jurusan:    "Teknik Informatika",
+		Angkatan:   2021,
+		TahunLulus: 2025,
+		Status:     helper.VerificationStatusGraduate,
+	}
+}
+
+func TestVerificationSignAndVerify(t *testing.T) {
+	signer, _ := newTestSigner(t)
+	now := time.Date(2026, 1, 10, 8, 0, 0, 0, time.UTC)
+
+	token, err := signer.Sign(testVerificationClaims(), now)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	claims, err := signer.Verify(token, now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if claims.NIM != "2021001" || claims.Subject != "2021001" || claims.Issuer != "kampus-test" || claims.Status != "lulus" {
+		t.Fatalf("unexpected claims: %+v", claims)
+	}
+	if claims.ID == "" || !claims.ExpiresAt.Time.Equal(now.Add(24*time.Hour)) {
+		t.Fatalf("expected jti and exp to be set, got %+v", claims.RegisteredClaims)
+	}
+
+	parts := strings.Split(token, ".")
+	tampered := parts[0] + "." + parts[1] + "x." + parts[2]
+	if _, err := signer.Verify(tampered, now); !errors.Is(err, helper.ErrInvalidVerificationToken) {
+		t.Fatalf("expected tampered token to be invalid, got %v", err)
+	}
+	if _, err := signer.Verify("bukan-token", now); !errors.Is(err, helper.ErrInvalidVerificationToken) {
+		t.Fatalf("expected garbage to be invalid, got %v", err)
+	}
+}
+
+func TestVerificationExpired(t *testing.T) {
+	signer, _ := newTestSigner(t)
+	now := time.Date(2026, 1, 10, 8, 0, 0, 0, time.UTC)
+	token, err := signer.Sign(testVerificationClaims(), now)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	claims, err := signer.Verify(token, now.Add(48*time.Hour))
+	if !errors.Is(err, helper.ErrExpiredVerificationToken) {
+		t.Fatalf("expected expired error, got %v", err)
+	}
+	if claims == nil || claims.NIM != "2021001" {
+		t.Fatalf("expected claims of expired token, got %+v", claims)
+	}
+}
+
+func TestVerificationKeyRotation(t *testing.T) {
+	old, oldKey := newTestSigner(t)
+	now := time.Date(2026, 1, 10, 8, 0, 0, 0, time.UTC)
+	token, err := old.Sign(testVerificationClaims(), now)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+
+	rotated, _ := newTestSigner(t, oldKey.Public().(ed25519.PublicKey))
+	if _, err := rotated.Verify(token, now); err != nil {
+		t.Fatalf("expected retired key to verify old token, got %v", err)
+	}
+	if keys := rotated.JWKS().Keys; len(keys) != 2 || keys[0].Kid == old.JWKS().Keys[0].Kid {
+		t.Fatalf("expected active key first followed by retired key, got %+v", keys)
+	}
+
+	other, _ := newTestSigner(t)
+	if _, err := other.Verify(token, now); !errors.Is(err, helper.ErrInvalidVerificationToken) {
+		t.Fatalf("expected unknown kid to be rejected, got %v", err)
+	}
+}
+
+func TestVerificationJWKS(t *testing.T) {
+	signer, key := newTestSigner(t)
+	keys := signer.JWKS().Keys
+	if len(keys) != 1 {
+		t.Fatalf("expected 1 key, got %d", len(keys))
+	}
+	k := keys[0]
+	if k.Kty != "OKP" || k.Crv != "Ed25519" || k.Alg != "EdDSA" || k.Use != "sig" || k.Kid == "" {
+		t.Fatalf("unexpected jwk: %+v", k)
+	}
+	again := helper.NewVerificationSigner(key, nil, "lain", time.Hour)
+	if again.JWKS().Keys[0].Kid != k.Kid {
+		t.Fatal("expected kid to be derived from the public key")
+	}
+}
+
+func TestVerificationNameMatches(t *testing.T) {
+	cases := []struct {
+		given, stored string
+		want          bool
+	}{
+		{"budi santoso", "Budi Santoso", true},
+		{"  BUDI   Santoso ", "Budi Santoso", true},
+		{"Budi Santoso, S.Kom.", "Budi Santoso", true},
+		{"Siti Nur'aini", "Siti Nuraini", true},
+		{"Budi", "Budi Santoso", false},
+		{"", "", false},
+	}
+	for _, tc := range cases {
+		if got := helper.VerificationNameMatches(tc.given, tc.stored); got != tc.want {
+			t.Errorf("VerificationNameMatches(%q, %q) = %v, want %v", tc.given, tc.stored, got, tc.want)
+		}
+	}
+}
+
+func TestVerificationStatus(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	if got := helper.VerificationStatus(2026, now); got != helper.VerificationStatusGraduate {
+		t.Fatalf("expected graduate for this year, got %s", got)
+	}
+	if got := helper.VerificationStatus(2027, now); got != helper.VerificationStatusStudent {
+		t.Fatalf("expected student for future year, got %s", got)
+	}
+}
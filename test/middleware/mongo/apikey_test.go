@@ -0,0 +1,87 @@
+package mongo_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	model "go-fiber/app/model/mongo"
+	"go-fiber/helper"
+	mw "go-fiber/middleware/mongo"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func setupAPIKeyApp(t *testing.T, keys map[string]*model.APIKey) *fiber.App {
+	t.Helper()
+	mw.APIKeyVerifier = func(key string) (*model.APIKey, error) {
+		return keys[key], nil
+	}
+	mw.APIKeyRateLimitStore = helper.NewMemoryRateLimitStore()
+	t.Cleanup(func() {
+		mw.APIKeyVerifier = nil
+		mw.APIKeyRateLimitStore = nil
+	})
+
+	app := fiber.New()
+	app.Get("/", mw.APIKeyRequired(helper.APIKeyScopeAlumniCheck), func(c *fiber.Ctx) error {
+		return c.Status(200).JSON(fiber.Map{"ok": true})
+	})
+	return app
+}
+
+func apiKeyRequest(key string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if key != "" {
+		req.Header.Set(helper.APIKeyHeader, key)
+	}
+	return req
+}
+
+func TestAPIKeyMiddleware_MissingOrUnknownKey(t *testing.T) {
+	app := setupAPIKeyApp(t, map[string]*model.APIKey{})
+
+	for _, key := range []string{"", "ak_unknown"} {
+		resp, _ := app.Test(apiKeyRequest(key))
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("key %q: expected 401, got %d", key, resp.StatusCode)
+		}
+	}
+}
+
+func TestAPIKeyMiddleware_Scope(t *testing.T) {
+	app := setupAPIKeyApp(t, map[string]*model.APIKey{
+		"ak_read":  {ID: primitive.NewObjectID(), Scopes: []string{helper.APIKeyScopeAlumniRead}},
+		"ak_check": {ID: primitive.NewObjectID(), Scopes: []string{helper.APIKeyScopeAlumniCheck}},
+	})
+
+	resp, _ := app.Test(apiKeyRequest("ak_read"))
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", resp.StatusCode)
+	}
+	resp, _ = app.Test(apiKeyRequest("ak_check"))
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestAPIKeyMiddleware_RateLimit(t *testing.T) {
+	app := setupAPIKeyApp(t, map[string]*model.APIKey{
+		"ak_limited": {ID: primitive.NewObjectID(), Scopes: []string{helper.APIKeyScopeAlumniCheck}, RateLimit: 2},
+	})
+
+	for i := 0; i < 2; i++ {
+		resp, _ := app.Test(apiKeyRequest("ak_limited"))
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("request %d: expected 200, got %d", i+1, resp.StatusCode)
+		}
+	}
+	resp, _ := app.Test(apiKeyRequest("ak_limited"))
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d", resp.StatusCode)
+	}
+	if resp.Header.Get("Retry-After") == "" || resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		t.Fatalf("expected rate limit headers, got %v", resp.Header)
+	}
+}
@@ -61,3 +61,50 @@ func TestAuthMiddleware_ValidToken(t *testing.T) {
 }
 
 
+func TestAuthMiddleware_RevokedSession(t *testing.T) {
+	mw.SessionChecker = func(claims *model.JWTClaims) (bool, error) {
+		return claims.SessionVersion == 2, nil
+	}
+	defer func() { mw.SessionChecker = nil }()
+	app := setupAuthApp()
+
+	for version, want := range map[int]int{1: http.StatusUnauthorized, 2: http.StatusOK} {
+		token, err := utils.GenerateToken(model.User{Username: "john", Role: "user", SessionVersion: version})
+		if err != nil {
+			t.Fatalf("failed to generate token: %v", err)
+		}
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, _ := app.Test(req)
+		if resp.StatusCode != want {
+			t.Fatalf("session version %d: expected %d, got %d", version, want, resp.StatusCode)
+		}
+	}
+}
+
+func TestAuthMiddleware_MFAPendingToken(t *testing.T) {
+	token, err := utils.GenerateMFAPendingToken(model.User{Username: "admin", Role: "admin"})
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+
+	// Token mfa_pending ditolak endpoint biasa
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, _ := setupAuthApp().Test(req)
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for pending token, got %d", resp.StatusCode)
+	}
+
+	// ... tetapi diterima endpoint enrollment 2FA
+	app := fiber.New()
+	app.Post("/me/mfa/enroll", mw.MFASetupAuth(), func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{"pending": c.Locals("mfa_pending")})
+	})
+	req = httptest.NewRequest(http.MethodPost, "/me/mfa/enroll", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, _ = app.Test(req)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200 for MFASetupAuth, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,101 @@
+package mongo_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go-fiber/helper"
+	mw "go-fiber/middleware/mongo"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupIdempotencyApp -> handler POST yang menghitung berapa kali dijalankan
+func setupIdempotencyApp(t *testing.T, status int, calls *int) *fiber.App {
+	t.Helper()
+	mw.IdempotencyStore = helper.NewMemoryIdempotencyStore()
+	t.Cleanup(func() { mw.IdempotencyStore = nil })
+
+	app := fiber.New()
+	app.Post("/pekerjaan", func(c *fiber.Ctx) error {
+		c.Locals("user_id", c.Get("X-User"))
+		return c.Next()
+	}, mw.Idempotency(), func(c *fiber.Ctx) error {
+		*calls++
+		return c.Status(status).JSON(fiber.Map{"call": *calls})
+	})
+	return app
+}
+
+func idempotentPost(t *testing.T, app *fiber.App, user, key, body string) (int, string, *http.Response) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/pekerjaan", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User", user)
+	if key != "" {
+		req.Header.Set(helper.IdempotencyKeyHeader, key)
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	raw, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(raw), resp
+}
+
+func TestIdempotency_ReplaysFirstResponse(t *testing.T) {
+	calls := 0
+	app := setupIdempotencyApp(t, http.StatusCreated, &calls)
+
+	status, body, _ := idempotentPost(t, app, "u1", "key-1", `{"posisi":"Backend"}`)
+	if status != http.StatusCreated {
+		t.Fatalf("first request: expected 201, got %d", status)
+	}
+	status2, body2, resp := idempotentPost(t, app, "u1", "key-1", `{"posisi":"Backend"}`)
+	if status2 != http.StatusCreated || body2 != body {
+		t.Fatalf("retry: expected replay of %d %s, got %d %s", status, body, status2, body2)
+	}
+	if resp.Header.Get(helper.IdempotencyReplayedHeader) != "true" {
+		t.Fatalf("retry: expected %s header", helper.IdempotencyReplayedHeader)
+	}
+	if calls != 1 {
+		t.Fatalf("expected handler to run once, ran %d times", calls)
+	}
+
+	// Key yang sama milik user lain berdiri sendiri
+	if idempotentPost(t, app, "u2", "key-1", `{"posisi":"Backend"}`); calls != 2 {
+		t.Fatalf("other user: expected handler to run, ran %d times", calls)
+	}
+	// Tanpa header selalu dijalankan
+	if idempotentPost(t, app, "u1", "", `{"posisi":"Backend"}`); calls != 3 {
+		t.Fatalf("no key: expected handler to run, ran %d times", calls)
+	}
+}
+
+func TestIdempotency_DifferentPayload(t *testing.T) {
+	calls := 0
+	app := setupIdempotencyApp(t, http.StatusCreated, &calls)
+
+	idempotentPost(t, app, "u1", "key-1", `{"posisi":"Backend"}`)
+	status, _, _ := idempotentPost(t, app, "u1", "key-1", `{"posisi":"Frontend"}`)
+	if status != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d", status)
+	}
+	if calls != 1 {
+		t.Fatalf("expected handler to run once, ran %d times", calls)
+	}
+}
+
+func TestIdempotency_ServerErrorNotStored(t *testing.T) {
+	calls := 0
+	app := setupIdempotencyApp(t, http.StatusInternalServerError, &calls)
+
+	idempotentPost(t, app, "u1", "key-1", `{}`)
+	idempotentPost(t, app, "u1", "key-1", `{}`)
+	if calls != 2 {
+		t.Fatalf("expected retry after 500 to run again, ran %d times", calls)
+	}
+}
@@ -1,6 +1,7 @@
 package mongo_test
 
 import (
+	"strings"
 	"testing"
 
 	utils "go-fiber/utils/mongo"
@@ -25,4 +26,45 @@ func TestHashAndCheckPassword(t *testing.T) {
 	}
 }
 
+func TestValidatePassword(t *testing.T) {
+	cases := []struct {
+		password string
+		ok       bool
+	}{
+		{"rahasia123", true},
+		{"Kuat#Sekali9", true},
+		{"pendek1", false},                // kurang dari 8 karakter
+		{"hanyahuruf", false},             // tanpa angka
+		{"1234567890", false},             // tanpa huruf
+		{strings.Repeat("a1", 40), false}, // lebih dari 72 byte
+		{"budi@example.com1", true},
+	}
+	for _, tc := range cases {
+		err := utils.ValidatePassword(tc.password)
+		if (err == nil) != tc.ok {
+			t.Errorf("%q: expected ok=%v, got err=%v", tc.password, tc.ok, err)
+		}
+	}
+
+	if err := utils.ValidatePassword("A1234567B", "budi@example.com", "a1234567b"); err == nil {
+		t.Error("expected error when password equals NIM")
+	}
+}
+
+func TestGenerateResetToken(t *testing.T) {
+	token, hash, err := utils.GenerateResetToken()
+	if err != nil {
+		t.Fatalf("GenerateResetToken error: %v", err)
+	}
+	if len(token) < 40 || len(hash) != 64 {
+		t.Fatalf("unexpected token/hash length: %d/%d", len(token), len(hash))
+	}
+	if utils.HashResetToken(token) != hash {
+		t.Fatal("HashResetToken should match the generated hash")
+	}
 
+	other, _, _ := utils.GenerateResetToken()
+	if other == token {
+		t.Fatal("tokens should be random")
+	}
+}
@@ -11,9 +11,10 @@ var jwtSecret = []byte("your-secret-key-min-32-characters-long")
 
 func GenerateToken(user model.User) (string, error) {
 	claims := model.JWTClaims{
-		UserID:   user.ID,
-		Username: user.Username,
-		Role:     user.Role,
+		UserID:         user.ID,
+		Username:       user.Username,
+		Role:           user.Role,
+		SessionVersion: user.SessionVersion,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -24,6 +25,28 @@ func GenerateToken(user model.User) (string, error) {
 	return token.SignedString(jwtSecret)
 }
 
+// MFAPendingTokenTTL -> masa berlaku token login tahap pertama untuk akun dengan 2FA
+const MFAPendingTokenTTL = 5 * time.Minute
+
+// GenerateMFAPendingToken -> token sementara setelah password benar; ditolak AuthRequired
+// dan hanya bisa ditukar dengan token penuh lewat verifikasi 2FA
+func GenerateMFAPendingToken(user model.User) (string, error) {
+	claims := model.JWTClaims{
+		UserID:         user.ID,
+		Username:       user.Username,
+		Role:           user.Role,
+		SessionVersion: user.SessionVersion,
+		MFAPending:     true,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(MFAPendingTokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(jwtSecret)
+}
+
 func ValidateToken(tokenString string) (*model.JWTClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &model.JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
 		return jwtSecret, nil
@@ -1,6 +1,22 @@
 package postgre
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"unicode"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Kebijakan password: 8-72 byte (batas bcrypt), minimal satu huruf dan satu angka
+const (
+	PasswordMinLength = 8
+	PasswordMaxLength = 72
+)
 
 func HashPassword(password string) (string, error) {
 	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -11,3 +27,51 @@ func CheckPassword(password, hash string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 	return err == nil
 }
+
+// ValidatePassword memeriksa kebijakan kekuatan password sebelum HashPassword dipanggil.
+// identifiers (mis. email dan NIM) tidak boleh dipakai sebagai password.
+func ValidatePassword(password string, identifiers ...string) error {
+	if len(password) < PasswordMinLength {
+		return errors.New("password minimal 8 karakter")
+	}
+	if len(password) > PasswordMaxLength {
+		return errors.New("password maksimal 72 byte")
+	}
+
+	var hasLetter, hasDigit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	if !hasLetter || !hasDigit {
+		return errors.New("password harus mengandung huruf dan angka")
+	}
+
+	for _, id := range identifiers {
+		if id != "" && strings.EqualFold(password, id) {
+			return errors.New("password tidak boleh sama dengan email atau NIM")
+		}
+	}
+	return nil
+}
+
+// GenerateResetToken -> token acak untuk tautan reset password beserta hash SHA-256-nya.
+// Hanya hash yang disimpan di database.
+func GenerateResetToken() (token, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, HashResetToken(token), nil
+}
+
+// HashResetToken -> hash SHA-256 (hex) dari token reset password
+func HashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}